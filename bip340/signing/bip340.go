@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+const (
+	// the length in bytes of a BIP340 x-only public key, message and of each half of a signature
+	bip340Len = 32
+
+	challengeTag = "BIP0340/challenge"
+)
+
+// XOnlyPubKey returns the 32-byte x-only encoding of `pub` used by BIP340 and Taproot outputs.
+// The encoding implies the point with the same x and an even y, which is the key that BIP340 signatures from a LocalParty
+// verify under whatever the parity of the y of the ECDSAPub of the save data.
+func XOnlyPubKey(pub *crypto.ECPoint) []byte {
+	return padTo32(pub.X())
+}
+
+// Verify checks a 64-byte BIP340 signature `sig` over the 32-byte message `msg` under the 32-byte x-only public key
+// `pubKey`, following the verification algorithm of BIP340.
+func Verify(pubKey, msg, sig []byte) bool {
+	if len(pubKey) != bip340Len || len(msg) != bip340Len || len(sig) != 2*bip340Len {
+		return false
+	}
+	ecParams := btcec.S256().Params()
+	P, err := liftX(new(big.Int).SetBytes(pubKey))
+	if err != nil {
+		return false
+	}
+	r, s := new(big.Int).SetBytes(sig[:bip340Len]), new(big.Int).SetBytes(sig[bip340Len:])
+	if r.Cmp(ecParams.P) >= 0 || s.Cmp(ecParams.N) >= 0 {
+		return false
+	}
+	e := challenge(r, P, msg)
+
+	// R = s*G - e*P
+	sG := crypto.ScalarBaseMult(btcec.S256(), s)
+	negEP := P.ScalarMult(new(big.Int).Sub(ecParams.N, e))
+	R, err := sG.Add(negEP)
+	if err != nil { // R is the point at infinity
+		return false
+	}
+	return R.Y().Bit(0) == 0 && R.X().Cmp(r) == 0
+}
+
+// ----- //
+
+// taggedHash computes the BIP340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || msg)
+func taggedHash(tag string, msg ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, m := range msg {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// challenge computes e = int(hash_BIP0340/challenge(bytes(r) || bytes(P) || m)) mod n
+func challenge(r *big.Int, P *crypto.ECPoint, msg []byte) *big.Int {
+	eHash := taggedHash(challengeTag, padTo32(r), XOnlyPubKey(P), msg)
+	return new(big.Int).Mod(new(big.Int).SetBytes(eHash), btcec.S256().Params().N)
+}
+
+// liftX returns the point with the x coordinate `x` and an even y
+func liftX(x *big.Int) (*crypto.ECPoint, error) {
+	p := btcec.S256().Params().P
+	if x.Sign() <= 0 || x.Cmp(p) >= 0 {
+		return nil, errors.New("liftX: x is out of range")
+	}
+	// y^2 = x^3 + 7 on secp256k1
+	modP := common.ModInt(p)
+	c := modP.Add(modP.Exp(x, big.NewInt(3)), big.NewInt(7))
+	y := new(big.Int).ModSqrt(c, p)
+	if y == nil {
+		return nil, errors.New("liftX: x is not on the curve")
+	}
+	if y.Bit(0) != 0 {
+		y.Sub(p, y)
+	}
+	return crypto.NewECPoint(btcec.S256(), x, y)
+}
+
+// negIfOddY returns the negation of `k` mod n if the y of `P` is odd, or `k` otherwise.
+// A point with an odd y is replaced by its negation in BIP340, so the discrete logarithm must be negated with it.
+// `k` is a secret nonce or share, so both are computed and one is selected in constant time.
+func negIfOddY(k *big.Int, P *crypto.ECPoint) *big.Int {
+	N := btcec.S256().Params().N
+	neg := common.ModInt(N).Sub(big.NewInt(0), k)
+	return common.ConstantTimeSelect(int(P.Y().Bit(0)), neg, k, (N.BitLen()+7)/8)
+}
+
+// negPointIfOddY returns the negation of `Q` if the y of `P` is odd, or `Q` otherwise
+func negPointIfOddY(Q, P *crypto.ECPoint) *crypto.ECPoint {
+	if P.Y().Bit(0) == 0 {
+		return Q
+	}
+	return crypto.NewECPointNoCurveCheck(btcec.S256(), Q.X(), new(big.Int).Sub(btcec.S256().Params().P, Q.Y()))
+}
+
+func padTo32(x *big.Int) []byte {
+	bz := make([]byte, bip340Len)
+	xBz := x.Bytes()
+	copy(bz[bip340Len-len(xBz):], xBz)
+	return bz
+}
@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	R, P := round.temp.bigR, round.key.ECDSAPub
+	modN := common.ModInt(round.EC().Params().N)
+
+	// 1. check each sj against its commitments: sj*G = Rj + e*Wj, with Rj and Wj negated as in round 3
+	sumS := round.temp.si
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sj := r3msg.UnmarshalS()
+		sjG := crypto.ScalarBaseMult(round.EC(), sj)
+		eWj := negPointIfOddY(round.temp.bigWs[j], P).ScalarMult(round.temp.e)
+		expected, err := negPointIfOddY(round.temp.bigRjs[j], R).Add(eWj)
+		if err != nil || !sjG.Equals(expected) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the signature share sj"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. save the signature for final output
+	m := padTo32(round.temp.m)
+	round.data.R = padTo32(R.X())
+	round.data.S = padTo32(sumS)
+	round.data.Signature = append(append([]byte{}, round.data.R...), round.data.S...)
+	round.data.M = m
+
+	if ok := Verify(XOnlyPubKey(P), m, round.data.Signature); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	// PHASE: signing
+	// sign a few messages so that both parities of the nonce point are likely to be hit
+	msgs := []*big.Int{big.NewInt(42), common.SHA512_256i(big.NewInt(1)), common.SHA512_256i(big.NewInt(2))}
+	for m, msg := range msgs {
+		p2pCtx := tss.NewPeerContext(signPIDs)
+		parties := make([]*LocalParty, 0, len(signPIDs))
+
+		errCh := make(chan *tss.Error, len(signPIDs))
+		outCh := make(chan tss.Message, len(signPIDs))
+		endCh := make(chan common.SignatureData, len(signPIDs))
+
+		updater := test.SharedPartyUpdater
+
+		// init the parties
+		for i := 0; i < len(signPIDs); i++ {
+			params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+			assert.NoError(t, err)
+			params.SetSessionID(msg.Bytes()) // one session per ceremony
+			// the last ceremony commits in the legacy framing, as with parties that run older versions
+			params.SetLegacyFraming(m == len(msgs)-1)
+
+			P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+			parties = append(parties, P)
+			go func(P *LocalParty) {
+				if err := P.Start(context.Background()); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+
+		var ended int32
+	signing:
+		for {
+			select {
+			case err := <-errCh:
+				common.DefaultLogger().Error("error", "err", err)
+				assert.FailNow(t, err.Error())
+				break signing
+
+			case msg := <-outCh:
+				dest := msg.GetTo()
+				if dest == nil {
+					for _, P := range parties {
+						if P.PartyID().Index == msg.GetFrom().Index {
+							continue
+						}
+						go updater(P, msg, errCh)
+					}
+				} else {
+					go updater(parties[dest[0].Index], msg, errCh)
+				}
+
+			case data := <-endCh:
+				atomic.AddInt32(&ended, 1)
+				if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+					t.Logf("Done. Received signature data from %d participants", ended)
+
+					pubKey := XOnlyPubKey(keys[0].ECDSAPub)
+					assert.Len(t, data.Signature, 64)
+					assert.Len(t, data.M, 32)
+					assert.True(t, Verify(pubKey, data.M, data.Signature), "bip340 verify must pass")
+					assert.False(t, Verify(pubKey, padTo32(new(big.Int).Add(msg, big.NewInt(1))), data.Signature),
+						"bip340 verify must fail for another message")
+					break signing
+				}
+			}
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// test vectors from BIP340
+	vectors := []struct {
+		pubKey, msg, sig string
+		ok               bool
+	}{
+		{
+			"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			true,
+		},
+		{
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			true,
+		},
+		{
+			"DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+			"7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+			"5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+			true,
+		},
+		{
+			"D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+			"4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+			"00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+			true,
+		},
+		{ // public key not on the curve
+			"EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			false,
+		},
+		{ // has_even_y(R) is false
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+			false,
+		},
+		{ // sig[0:32] is not an x on the curve
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			false,
+		},
+	}
+	for i, v := range vectors {
+		pubKey, _ := hex.DecodeString(v.pubKey)
+		msg, _ := hex.DecodeString(v.msg)
+		sig, _ := hex.DecodeString(v.sig)
+		assert.Equal(t, v.ok, Verify(pubKey, msg, sig), "vector %d", i)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	ecdsaSigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the BIP340 Schnorr signing protocol
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// BIP340 is defined over secp256k1 only
+	if round.CurveName() != "secp256k1" {
+		return round.WrapError(fmt.Errorf("BIP340 signs with a secp256k1 key but the party is on %q", round.CurveName())).WithCode(tss.CodeBadInput)
+	}
+	if err := round.CheckCurveName(round.key.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	// 1. select ri
+	ri := common.GetRandomPositiveInt(round.EC().Params().N)
+
+	// 2. make commitment
+	pointRi := crypto.ScalarBaseMult(round.EC(), ri)
+	cmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), pointRi.X(), pointRi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ri = ri
+	round.temp.pointRi = pointRi
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast commitment
+	r1msg := NewSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	if round.temp.m == nil || round.temp.m.Sign() < 0 || round.temp.m.BitLen() > 8*bip340Len {
+		return fmt.Errorf("the message must be a non-negative integer of at most %d bytes", bip340Len)
+	}
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := ecdsaSigning.PrepareForSigning(round.EC(), i, len(ks), xi, ks, bigXs)
+
+	round.temp.wi = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// 1-5. de-commit and verify each Rj, then compute R = sum(Rj)
+	i := round.PartyID().Index
+	round.temp.bigRjs[i] = round.temp.pointRi
+	R := round.temp.pointRi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj).WithCode(tss.CodeDecommitMismatch)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj).WithCode(tss.CodeDecommitMismatch)
+		}
+
+		Rj, err := crypto.NewECPoint(round.EC(), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		proof, err := r2msg.UnmarshalZKProof(round.EC())
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		ok = proof.VerifyInTranscript(round.proofTranscript(2, Pj), Rj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		round.temp.bigRjs[j] = Rj
+
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors.Wrapf(err, "R.Add(Rj)"))
+		}
+	}
+
+	// 6. BIP340 signs with the nonce and the key whose points have an even y, so negate the shares to match
+	ki := negIfOddY(round.temp.ri, R)
+	wi := negIfOddY(round.temp.wi, round.key.ECDSAPub)
+
+	// 7. e = int(hash_BIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
+	e := challenge(R.X(), round.key.ECDSAPub, padTo32(round.temp.m))
+
+	// 8. compute si = ki + e*wi
+	modN := common.ModInt(round.EC().Params().N)
+	si := modN.Add(ki, modN.Mul(e, wi))
+
+	// 9. store r3 message pieces
+	round.temp.si = si
+	round.temp.bigR = R
+	round.temp.e = e
+
+	// 10. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- round.WithSessionID(r3msg)
+
+	return nil
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
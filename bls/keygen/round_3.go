@@ -0,0 +1,197 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1,10. calculate xi
+	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+		share := r2msg1.UnmarshalShare()
+		xi = new(big.Int).Add(xi, share)
+	}
+	round.save.Xi = new(big.Int).Mod(xi, bls12381.Order)
+
+	// 2-3.
+	Vc := make(bls12381.Vs, round.Threshold()+1)
+	for c := range Vc {
+		Vc[c] = round.temp.vs[c] // ours
+	}
+
+	// 4-12.
+	type vssOut struct {
+		unWrappedErr error
+		pjVs         bls12381.Vs
+	}
+	sem := common.NewSemaphore(round.Concurrency())
+	chs := make([]chan vssOut, len(Ps))
+	for i := range chs {
+		if i == PIdx {
+			continue
+		}
+		chs[i] = make(chan vssOut, 1)
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		// 6-9.
+		go func(j int, ch chan<- vssOut) {
+			sem.Acquire()
+			defer sem.Release()
+			// 4-10.
+			KGCj := round.temp.KGCs[j]
+			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+			KGDj := r2msg2.UnmarshalDeCommitment()
+			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
+			ok, flatPolyGs := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+			if !ok || flatPolyGs == nil {
+				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+				return
+			}
+			PjVs, err := bls12381.UnFlattenG1Points(flatPolyGs)
+			if err != nil {
+				ch <- vssOut{err, nil}
+				return
+			}
+			if len(PjVs) != round.Threshold()+1 {
+				ch <- vssOut{errors.New("wrong number of vss commitments"), nil}
+				return
+			}
+			proof, err := r2msg2.UnmarshalZKProof()
+			if err != nil {
+				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
+				return
+			}
+			ok = proof.VerifyInTranscript(round.proofTranscript(2, Ps[j]), PjVs[0])
+			if !ok {
+				ch <- vssOut{errors.New("failed to prove schnorr proof"), nil}
+				return
+			}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+			PjShare := vss.Share{
+				Threshold: round.Threshold(),
+				ID:        round.PartyID().KeyInt(),
+				Share:     r2msg1.UnmarshalShare(),
+			}
+			if ok = bls12381.VerifyShare(&PjShare, round.Threshold(), PjVs); !ok {
+				ch <- vssOut{errors.New("vss verify failed"), nil}
+				return
+			}
+			// (9) handled above
+			ch <- vssOut{nil, PjVs}
+		}(j, chs[j])
+	}
+
+	// consume unbuffered channels (end the goroutines)
+	vssResults := make([]vssOut, len(Ps))
+	{
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			vssResults[j] = <-chs[j]
+			// collect culprits to error out with
+			if err := vssResults[j].unWrappedErr; err != nil {
+				culprits = append(culprits, Pj)
+			}
+		}
+		var multiErr error
+		if len(culprits) > 0 {
+			for _, vssResult := range vssResults {
+				if vssResult.unWrappedErr == nil {
+					continue
+				}
+				multiErr = multierror.Append(multiErr, vssResult.unWrappedErr)
+			}
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		// 11-12.
+		PjVs := vssResults[j].pjVs
+		for c := 0; c <= round.Threshold(); c++ {
+			Vc[c] = Vc[c].Add(PjVs[c])
+		}
+	}
+
+	// 13-17. compute Xj for each Pj
+	{
+		modL := common.ModInt(bls12381.Order)
+		bigXj := round.save.BigXj
+		for j := 0; j < round.PartyCount(); j++ {
+			Pj := round.Parties().IDs()[j]
+			kj := Pj.KeyInt()
+			BigXj := Vc[0]
+			z := new(big.Int).SetInt64(int64(1))
+			for c := 1; c <= round.Threshold(); c++ {
+				z = modL.Mul(z, kj)
+				BigXj = BigXj.Add(Vc[c].ScalarMult(z))
+			}
+			bigXj[j] = BigXj
+		}
+		round.save.BigXj = bigXj
+	}
+
+	// 18. compute and SAVE the BLS public key `y`
+	if Vc[0].IsIdentity() {
+		return round.WrapError(errors.New("the public key is the identity element"))
+	}
+	round.save.PubKey = Vc[0]
+
+	// PRINT public key & private share
+	round.Logger().Debug("public key", "key", fmt.Sprintf("%x", Vc[0].Bytes()))
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
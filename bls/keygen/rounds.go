@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	TaskName = "bls-keygen"
+
+	// the domain of the hash commitments made in round 1, see commitments.NewHashCommitmentInSession
+	commitmentDomain = TaskName + "/round-1"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		save    *LocalPartySaveData
+		temp    *localTempData
+		out     chan<- tss.Message
+		end     chan<- LocalPartySaveData
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+)
+
+// phases names the rounds by their number
+var phases = []string{
+	1: "commitments",
+	2: "shares and de-commitments",
+	3: "finalization",
+}
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+func (round *base) TotalRounds() int {
+	return len(phases) - 1
+}
+
+func (round *base) Phase() string {
+	return phases[round.number]
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// proofTranscript returns the context of the proofs that `prover` makes in round `number`, which binds them to this
+// keygen session, to the round and to the prover, see zkp.NewSessionTranscript
+func (round *base) proofTranscript(number int, prover *tss.PartyID) *zkp.Transcript {
+	tr := zkp.NewSessionTranscript(TaskName, round.Params().SessionID(), number)
+	tr.AppendInts("prover", prover.KeyInt())
+	return tr
+}
@@ -0,0 +1,25 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+)
+
+// Verify reports whether `sig` is a valid compressed BLS signature of `msg` with the domain separation tag `dst` by the
+// compressed public key `pubKey` in G1.
+func Verify(pubKey, msg, sig, dst []byte) bool {
+	P, err := bls12381.NewG1PointFromBytes(pubKey)
+	if err != nil {
+		return false
+	}
+	S, err := bls12381.NewG2PointFromBytes(sig)
+	if err != nil {
+		return false
+	}
+	return bls12381.Verify(P, msg, S, dst)
+}
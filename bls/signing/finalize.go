@@ -0,0 +1,70 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	// 1. check each sigma_j against the share of the public key: e(W_j, H(m)) = e(G, sigma_j)
+	sigma := round.temp.sigmaI
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		sigmaJ, err := r1msg.UnmarshalSignatureShare()
+		if err != nil || !bls12381.VerifyWithHash(round.temp.bigWs[j], round.temp.hm, sigmaJ) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sigma = sigma.Add(sigmaJ)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the signature share sigma_j"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. save the signature for final output
+	round.data.Signature = sigma.Bytes()
+	round.data.M = round.temp.m
+
+	if ok := Verify(round.key.PubKey.Bytes(), round.temp.m, round.data.Signature, round.temp.dst); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
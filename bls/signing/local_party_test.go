@@ -0,0 +1,127 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/bls/keygen"
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = keygen.TestParticipants
+	testThreshold    = keygen.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+	pubKey := keys[0].PubKey.Bytes()
+
+	// PHASE: signing
+	msg := []byte("hello, ethereum")
+	data, ok := runSigning(t, msg, keys, signPIDs)
+	if !ok {
+		return
+	}
+	assert.Len(t, data.Signature, bls12381.G2Len)
+	assert.True(t, Verify(pubKey, msg, data.Signature, bls12381.DST), "bls verify must pass")
+	assert.False(t, Verify(pubKey, []byte("hello, chia"), data.Signature, bls12381.DST),
+		"bls verify must fail for another message")
+	assert.False(t, Verify(pubKey, msg, data.Signature, bls12381.PopDST),
+		"bls verify must fail with another domain separation tag")
+
+	// PHASE: proof of possession, with another set of signers
+	keys, signPIDs, err = keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pop, ok := runSigning(t, pubKey, keys, signPIDs, bls12381.PopDST)
+	if !ok {
+		return
+	}
+	assert.True(t, Verify(pubKey, pubKey, pop.Signature, bls12381.PopDST), "the proof of possession must verify")
+}
+
+func runSigning(
+	t *testing.T,
+	msg []byte,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	optionalDST ...[]byte,
+) (common.SignatureData, bool) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh, optionalDST...).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return common.SignatureData{}, false
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+				return data, true
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into bls-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*SignRound1Message)(nil), tss.BLSProtoNamePrefix+"signing.SignRound1Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	sigShare *bls12381.G2Point,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		SignatureShare: sigShare.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetSignatureShare()) == bls12381.G2Len
+}
+
+func (m *SignRound1Message) UnmarshalSignatureShare() (*bls12381.G2Point, error) {
+	return bls12381.NewG2PointFromBytes(m.GetSignatureShare())
+}
@@ -0,0 +1,61 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+)
+
+// PrepareForSigning(), GG18Spec (11) Fig. 14, in G1 of BLS12-381
+func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int, bigXs []*bls12381.G1Point) (wi *big.Int, bigWs []*bls12381.G1Point) {
+	modOrder := common.ModInt(bls12381.Order)
+	if len(ks) != len(bigXs) {
+		panic(fmt.Errorf("PrepareForSigning: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs)))
+	}
+	if len(ks) != pax {
+		panic(fmt.Errorf("PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
+	}
+	if len(ks) <= i {
+		panic(fmt.Errorf("PrepareForSigning: len(ks) <= i (%d <= %d)", len(ks), i))
+	}
+
+	// 2-4.
+	wi = xi
+	for j := 0; j < pax; j++ {
+		if j == i {
+			continue
+		}
+		// big.Int Div is calculated as: a/b = a * modInv(b,q)
+		coef := modOrder.Mul(ks[j], modOrder.ModInverse(new(big.Int).Sub(ks[j], ks[i])))
+		wi = modOrder.Mul(wi, coef)
+	}
+
+	// 5-10.
+	bigWs = make([]*bls12381.G1Point, len(ks))
+	for j := 0; j < pax; j++ {
+		bigWj := bigXs[j]
+		for c := 0; c < pax; c++ {
+			if j == c {
+				continue
+			}
+			ksc := ks[c]
+			ksj := ks[j]
+			if ksj.Cmp(ksc) == 0 {
+				panic(fmt.Errorf("index of two parties are equal"))
+			}
+			// big.Int Div is calculated as: a/b = a * modInv(b,q)
+			iota := modOrder.Mul(ksc, modOrder.ModInverse(new(big.Int).Sub(ksc, ksj)))
+			bigWj = bigWj.ScalarMult(iota)
+		}
+		bigWs[j] = bigWj
+	}
+	return
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/bls/keygen"
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the BLS signing protocol
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 1. hash the message to H(m) in G2
+	hm, err := bls12381.HashToG2(round.temp.m, round.temp.dst)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "HashToG2(m)"))
+	}
+
+	// 2. the signature share sigma_i = w_i*H(m)
+	sigmaI := hm.ScalarMult(round.temp.wi)
+	round.temp.hm = hm
+	round.temp.sigmaI = sigmaI
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 3. broadcast the signature share
+	r1msg := NewSignRound1Message(round.PartyID(), sigmaI)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := PrepareForSigning(i, len(ks), xi, ks, bigXs)
+
+	round.temp.wi = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	bigGamma := round.temp.bigGamma
+	modN := common.ModInt(round.EC().Params().N)
+
+	// 1. verify that each Delta_j uses the plaintext of K_j
+	bigDeltaJs, bigSJs := make([]*crypto.ECPoint, len(Ps)), make([]*crypto.ECPoint, len(Ps))
+	bigDeltaJs[i], bigSJs[i] = round.temp.bigDelta, round.temp.bigS
+	delta := round.temp.delta
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r1msg2 := round.temp.presignRound1Message2s[j].Content().(*PresignRound1Message2)
+		r3msg1 := round.temp.presignRound3Message1s[j].Content().(*PresignRound3Message1)
+		r3msg2 := round.temp.presignRound3Message2s[j].Content().(*PresignRound3Message2)
+		bigDeltaJ, err := r3msg2.UnmarshalBigDelta(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		bigSJ, err := r3msg2.UnmarshalBigS(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r3msg1.UnmarshalDeltaProof(round.EC())
+		if err != nil || !proof.Verify(
+			round.proofTranscript(3, Pj),
+			round.key.PaillierPKs[j],
+			r1msg2.UnmarshalK(),
+			bigGamma,
+			bigDeltaJ,
+			round.key.NTildej[i],
+			round.key.H1j[i],
+			round.key.H2j[i],
+			round.MtAProofParams()) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		bigDeltaJs[j], bigSJs[j] = bigDeltaJ, bigSJ
+		delta = modN.Add(delta, r3msg2.UnmarshalDelta())
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to prove Delta_j"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. delta*G = sum(Delta_j) = k*gamma*G; a mismatch means that an MtA was cheated on, which presigning can not
+	// attribute to a party
+	sumBigDelta := bigDeltaJs[0]
+	for j := 1; j < len(bigDeltaJs); j++ {
+		var err error
+		if sumBigDelta, err = sumBigDelta.Add(bigDeltaJs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "sumBigDelta.Add(Delta_j)"))
+		}
+	}
+	if delta.Sign() == 0 || !crypto.ScalarBaseMult(round.EC(), delta).Equals(sumBigDelta) {
+		return round.WrapError(errors.New("consistency check of delta failed: delta*G != sum(Delta_j)")).WithCode(tss.CodeInvalidResult)
+	}
+
+	// 3. R = delta^-1*Gamma, R_bar_j = delta^-1*Delta_j = k_j*R, S_j = delta^-1*chi_j*Gamma = chi_j*R
+	deltaInverse := modN.ModInverse(delta)
+	R := bigGamma.ScalarMult(deltaInverse)
+	bigRBarJs, bigSBarJs := make([]*crypto.ECPoint, len(Ps)), make([]*crypto.ECPoint, len(Ps))
+	sumBigS := bigSJs[0].ScalarMult(deltaInverse)
+	for j := range Ps {
+		bigRBarJs[j] = bigDeltaJs[j].ScalarMult(deltaInverse)
+		bigSBarJs[j] = bigSJs[j].ScalarMult(deltaInverse)
+		if j == 0 {
+			continue
+		}
+		var err error
+		if sumBigS, err = sumBigS.Add(bigSBarJs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "sumBigS.Add(S_j)"))
+		}
+	}
+	// 4. sum(S_j) = k*x*R = y
+	if !sumBigS.Equals(round.key.ECDSAPub) {
+		return round.WrapError(errors.New("consistency check of chi failed: sum(S_j) != y")).WithCode(tss.CodeInvalidResult)
+	}
+
+	// 5. SAVE the presignature and clear the nonce shares from memory
+	round.data.Ks = round.key.Ks
+	round.data.ECDSAPub = round.key.ECDSAPub
+	round.data.R = R
+	round.data.KI = round.temp.k
+	round.data.ChiI = round.temp.chi
+	round.data.BigRBarj = bigRBarJs
+	round.data.BigSj = bigSBarJs
+	round.temp.k, round.temp.gamma, round.temp.w, round.temp.chi = nil, nil, nil, nil
+
+	round.end <- *round.data
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
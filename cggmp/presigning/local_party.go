@@ -0,0 +1,188 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty runs the three-round presigning protocol of CGGMP21 with an ECDSA key made by ecdsa/keygen (and
+	// preferably refreshed by cggmp/refresh). Presigning does not depend on the message; its output is signed with a
+	// single message from each signer by cggmp/signing.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+		data PreSignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- PreSignatureData
+	}
+
+	localMessageStore struct {
+		presignRound1Message1s,
+		presignRound1Message2s,
+		presignRound2Message1s,
+		presignRound2Message2s,
+		presignRound3Message1s,
+		presignRound3Message2s []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after presigning) / round 1
+		w,
+		k,
+		rK, // the Paillier randomness of K_i
+		gamma *big.Int
+		bigK  *big.Int // K_i = Enc_i(k_i)
+		bigWs []*crypto.ECPoint
+
+		// round 2
+		pointGamma *crypto.ECPoint
+		betas,
+		vs []*big.Int
+
+		// round 3
+		delta,
+		chi *big.Int
+		bigGamma,
+		bigDelta,
+		bigS *crypto.ECPoint
+	}
+)
+
+// Exported, used in `tss` client
+// `params` must list the signers, at least t+1 of the parties of the key; they must sign with the presignature.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- PreSignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      localTempData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message2s = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.betas = make([]*big.Int, partyCount)
+	p.temp.vs = make([]*big.Int, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.keys, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start(ctx context.Context) *tss.Error {
+	return tss.BaseStart(ctx, p, TaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round")).WithCode(tss.CodeUnexpectedState)
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(ctx context.Context, msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(ctx, p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(ctx context.Context, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := p.params.ParseReceived(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(ctx, msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	if err := p.params.ValidateSessionID(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	if err := p.params.ValidateSender(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store the messages of each round; tss.BaseUpdate holds those of later rounds until then
+	// replays are dropped by tss.BaseUpdate. we expect the caller to apply spoofing protection, e.g. with tss.Parameters.OpenMessage.
+	switch msg.Content().(type) {
+	case *PresignRound1Message1:
+		p.temp.presignRound1Message1s[fromPIdx] = msg
+	case *PresignRound1Message2:
+		p.temp.presignRound1Message2s[fromPIdx] = msg
+	case *PresignRound2Message1:
+		p.temp.presignRound2Message1s[fromPIdx] = msg
+	case *PresignRound2Message2:
+		p.temp.presignRound2Message2s[fromPIdx] = msg
+	case *PresignRound3Message1:
+		p.temp.presignRound3Message1s[fromPIdx] = msg
+	case *PresignRound3Message2:
+		p.temp.presignRound3Message2s[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		p.params.Logger().Warn("unrecognised message ignored", "msg", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/binance-chain/tss-lib/cggmp/presigning"
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan PreSignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		assert.NoError(t, err)
+		P := NewLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	pres := make([]PreSignatureData, 0, len(signPIDs))
+	for len(pres) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case pre := <-endCh:
+			pres = append(pres, pre)
+		}
+	}
+
+	// R = k^-1*G, so sum(R_bar_j) = k*R = G
+	sumBigRBar := pres[0].BigRBarj[0]
+	for j := 1; j < len(signPIDs); j++ {
+		sumBigRBar, err = sumBigRBar.Add(pres[0].BigRBarj[j])
+		assert.NoError(t, err)
+	}
+	G := crypto.ScalarBaseMult(tss.S256(), big.NewInt(1))
+	assert.True(t, sumBigRBar.Equals(G), "sum(R_bar_j) must be G")
+
+	for _, pre := range pres {
+		assert.False(t, pre.Used())
+		assert.NoError(t, pre.CheckSigners(signPIDs))
+		assert.True(t, pre.R.Equals(pres[0].R), "all parties must agree on R")
+		for j := range signPIDs {
+			assert.True(t, pre.BigRBarj[j].Equals(pres[0].BigRBarj[j]), "all parties must agree on R_bar_j")
+			assert.True(t, pre.BigSj[j].Equals(pres[0].BigSj[j]), "all parties must agree on S_j")
+		}
+		assert.NotEqual(t, -1, ownIndex(pre), "R_bar_i and S_i must be k_i*R and chi_i*R")
+	}
+	assert.Error(t, pres[0].CheckSigners(signPIDs[1:]), "the signers must be the parties of the presigning")
+}
+
+// ownIndex returns the index j of the party of \`pre\` such that R_bar_j = k_i*R and S_j = chi_i*R, or -1
+func ownIndex(pre PreSignatureData) int {
+	kR, chiR := pre.R.ScalarMult(pre.KI), pre.R.ScalarMult(pre.ChiI)
+	for j := range pre.BigRBarj {
+		if kR.Equals(pre.BigRBarj[j]) && chiR.Equals(pre.BigSj[j]) {
+			return j
+		}
+	}
+	return -1
+}
@@ -0,0 +1,248 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into cggmp-presigning.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that presigning messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*PresignRound1Message1)(nil),
+		(*PresignRound1Message2)(nil),
+		(*PresignRound2Message1)(nil),
+		(*PresignRound2Message2)(nil),
+		(*PresignRound3Message1)(nil),
+		(*PresignRound3Message2)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*PresignRound1Message1)(nil), tss.CGGMPProtoNamePrefix+"presigning.PresignRound1Message1")
+	proto.RegisterType((*PresignRound1Message2)(nil), tss.CGGMPProtoNamePrefix+"presigning.PresignRound1Message2")
+	proto.RegisterType((*PresignRound2Message1)(nil), tss.CGGMPProtoNamePrefix+"presigning.PresignRound2Message1")
+	proto.RegisterType((*PresignRound2Message2)(nil), tss.CGGMPProtoNamePrefix+"presigning.PresignRound2Message2")
+	proto.RegisterType((*PresignRound3Message1)(nil), tss.CGGMPProtoNamePrefix+"presigning.PresignRound3Message1")
+	proto.RegisterType((*PresignRound3Message2)(nil), tss.CGGMPProtoNamePrefix+"presigning.PresignRound3Message2")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewPresignRound1Message1(
+	to, from *tss.PartyID,
+	proof *mta.RangeProofAlice,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &PresignRound1Message1{
+		RangeProofAlice: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetRangeProofAlice(), mta.RangeProofAliceBytesParts)
+}
+
+func (m *PresignRound1Message1) UnmarshalRangeProofAlice() (*mta.RangeProofAlice, error) {
+	return mta.RangeProofAliceFromBytes(m.GetRangeProofAlice())
+}
+
+// ----- //
+
+func NewPresignRound1Message2(
+	from *tss.PartyID,
+	K *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound1Message2{
+		K: K.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK())
+}
+
+func (m *PresignRound1Message2) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+// ----- //
+
+func NewPresignRound2Message1(
+	to, from *tss.PartyID,
+	c1Ji *big.Int,
+	pi1Ji *mta.ProofBobWC,
+	c2Ji *big.Int,
+	pi2Ji *mta.ProofBobWC,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pf1 := pi1Ji.Bytes()
+	pf2 := pi2Ji.Bytes()
+	content := &PresignRound2Message1{
+		C1:           c1Ji.Bytes(),
+		C2:           c2Ji.Bytes(),
+		ProofBobWc_1: pf1[:],
+		ProofBobWc_2: pf2[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetC1()) &&
+		common.NonEmptyBytes(m.GetC2()) &&
+		common.NonEmptyMultiBytes(m.GetProofBobWc_1(), mta.ProofBobWCBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetProofBobWc_2(), mta.ProofBobWCBytesParts)
+}
+
+func (m *PresignRound2Message1) UnmarshalProofBobWC1(ec elliptic.Curve) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(ec, m.GetProofBobWc_1())
+}
+
+func (m *PresignRound2Message1) UnmarshalProofBobWC2(ec elliptic.Curve) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(ec, m.GetProofBobWc_2())
+}
+
+// ----- //
+
+func NewPresignRound2Message2(
+	from *tss.PartyID,
+	bigGamma *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound2Message2{
+		GammaX: bigGamma.X().Bytes(),
+		GammaY: bigGamma.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetGammaX()) &&
+		common.NonEmptyBytes(m.GetGammaY())
+}
+
+func (m *PresignRound2Message2) UnmarshalGamma(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetGammaX()),
+		new(big.Int).SetBytes(m.GetGammaY()))
+}
+
+// ----- //
+
+func NewPresignRound3Message1(
+	to, from *tss.PartyID,
+	proof *mta.ProofPDL,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &PresignRound3Message1{
+		DeltaProof: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeltaProof(), mta.ProofPDLBytesParts)
+}
+
+func (m *PresignRound3Message1) UnmarshalDeltaProof(ec elliptic.Curve) (*mta.ProofPDL, error) {
+	return mta.ProofPDLFromBytes(ec, m.GetDeltaProof())
+}
+
+// ----- //
+
+func NewPresignRound3Message2(
+	from *tss.PartyID,
+	delta *big.Int,
+	bigDelta, bigS *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound3Message2{
+		Delta:     delta.Bytes(),
+		BigDeltaX: bigDelta.X().Bytes(),
+		BigDeltaY: bigDelta.Y().Bytes(),
+		BigSX:     bigS.X().Bytes(),
+		BigSY:     bigS.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDelta()) &&
+		common.NonEmptyBytes(m.GetBigDeltaX()) &&
+		common.NonEmptyBytes(m.GetBigDeltaY()) &&
+		common.NonEmptyBytes(m.GetBigSX()) &&
+		common.NonEmptyBytes(m.GetBigSY())
+}
+
+func (m *PresignRound3Message2) UnmarshalDelta() *big.Int {
+	return new(big.Int).SetBytes(m.GetDelta())
+}
+
+func (m *PresignRound3Message2) UnmarshalBigDelta(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigDeltaX()),
+		new(big.Int).SetBytes(m.GetBigDeltaY()))
+}
+
+func (m *PresignRound3Message2) UnmarshalBigS(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigSX()),
+		new(big.Int).SetBytes(m.GetBigSY()))
+}
@@ -0,0 +1,128 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the presigning part of CGGMP21 (Canetti, Gennaro, Goldfeder, Makriyannis, Peled; 2021)
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *PreSignatureData, temp *localTempData, out chan<- tss.Message, end chan<- PreSignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+	if err := round.CheckCurveName(round.key.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 1. sample k_i, gamma_i and encrypt k_i once as K_i = Enc_i(k_i; rho_i)
+	k := common.GetRandomPositiveInt(round.EC().Params().N)
+	gamma := common.GetRandomPositiveInt(round.EC().Params().N)
+	bigK, rK, err := round.key.PaillierPKs[i].EncryptAndReturnRandomness(k)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Encrypt(k_i)"))
+	}
+	round.temp.k = k
+	round.temp.gamma = gamma
+	round.temp.bigK = bigK
+	round.temp.rK = rK
+
+	// 2. prove to each P_j that the plaintext of K_i is in range, against P_j's NTilde, h1, h2
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		pf, err := mta.ProveRangeAlice(
+			round.proofTranscript(1, round.PartyID()),
+			round.EC(),
+			round.key.PaillierPKs[i], bigK, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rK, round.MtAProofParams())
+		if err != nil {
+			return round.WrapError(fmt.Errorf("failed to prove the range of k_i: %v", err))
+		}
+		r1msg1 := NewPresignRound1Message1(Pj, round.PartyID(), pf)
+		round.out <- round.WithSessionID(r1msg1)
+	}
+
+	// 3. BROADCAST K_i
+	r1msg2 := NewPresignRound1Message2(round.PartyID(), bigK)
+	round.temp.presignRound1Message2s[i] = r1msg2
+	round.out <- round.WithSessionID(r1msg2)
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg1 := range round.temp.presignRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			return false, nil
+		}
+		msg2 := round.temp.presignRound1Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound1Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into signing.PrepareForSigning()
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := signing.PrepareForSigning(round.EC(), i, len(ks), xi, ks, bigXs)
+
+	round.temp.w = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	pointGamma := crypto.ScalarBaseMult(round.EC(), round.temp.gamma)
+	round.temp.pointGamma = pointGamma
+
+	// 1. Bob's side of the MtA of k_j*gamma_i and k_j*w_i with each P_j, proving the use of the gamma_i of Gamma_i and
+	// the w_i of W_i; both MtAs check the range proof of K_j first
+	type bobMidResult struct {
+		c1, c2   *big.Int
+		pi1, pi2 *mta.ProofBobWC
+	}
+	results := make([]bobMidResult, len(round.Parties().IDs()))
+	culprits := make([]*tss.PartyID, len(round.Parties().IDs()))
+	sem := common.NewSemaphore(round.Concurrency())
+	wg := sync.WaitGroup{}
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r1msg1 := round.temp.presignRound1Message1s[j].Content().(*PresignRound1Message1)
+		r1msg2 := round.temp.presignRound1Message2s[j].Content().(*PresignRound1Message2)
+		rangeProofAliceJ, err := r1msg1.UnmarshalRangeProofAlice()
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		bigKj := r1msg2.UnmarshalK()
+		wg.Add(2)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				return
+			}
+			beta, c1, _, pi1, err := mta.BobMidWC(
+				round.proofTranscript(1, Pj),
+				round.proofTranscript(2, round.PartyID()),
+				round.EC(),
+				round.key.PaillierPKs[j],
+				rangeProofAliceJ,
+				round.temp.gamma,
+				bigKj,
+				round.key.NTildej[j],
+				round.key.H1j[j],
+				round.key.H2j[j],
+				round.key.NTildej[i],
+				round.key.H1j[i],
+				round.key.H2j[i],
+				pointGamma,
+				round.MtAProofParams())
+			if err != nil {
+				culprits[j] = Pj
+				return
+			}
+			round.temp.betas[j], results[j].c1, results[j].pi1 = beta, c1, pi1
+		}(j, Pj)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				return
+			}
+			v, c2, _, pi2, err := mta.BobMidWC(
+				round.proofTranscript(1, Pj),
+				round.proofTranscript(2, round.PartyID()),
+				round.EC(),
+				round.key.PaillierPKs[j],
+				rangeProofAliceJ,
+				round.temp.w,
+				bigKj,
+				round.key.NTildej[j],
+				round.key.H1j[j],
+				round.key.H2j[j],
+				round.key.NTildej[i],
+				round.key.H1j[i],
+				round.key.H2j[i],
+				round.temp.bigWs[i],
+				round.MtAProofParams())
+			if err != nil {
+				culprits[j] = Pj
+				return
+			}
+			round.temp.vs[j], results[j].c2, results[j].pi2 = v, c2, pi2
+		}(j, Pj)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(err)
+	}
+	if culprits := nonNilPartyIDs(culprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to calculate Bob_mid_wc"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. send each P_j its MtA ciphertexts and proofs
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r2msg1 := NewPresignRound2Message1(Pj, round.PartyID(), results[j].c1, results[j].pi1, results[j].c2, results[j].pi2)
+		round.out <- round.WithSessionID(r2msg1)
+	}
+
+	// 3. BROADCAST Gamma_i
+	r2msg2 := NewPresignRound2Message2(round.PartyID(), pointGamma)
+	round.temp.presignRound2Message2s[i] = r2msg2
+	round.out <- round.WithSessionID(r2msg2)
+	return nil
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg1 := range round.temp.presignRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			return false, nil
+		}
+		msg2 := round.temp.presignRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+// ----- //
+
+func nonNilPartyIDs(in []*tss.PartyID) []*tss.PartyID {
+	out := make([]*tss.PartyID, 0, len(in))
+	for _, Pj := range in {
+		if Pj != nil {
+			out = append(out, Pj)
+		}
+	}
+	return out
+}
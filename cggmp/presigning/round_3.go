@@ -0,0 +1,215 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 1. Alice's side of both MtAs with each P_j, checking them against Gamma_j and W_j
+	bigGammaJs := make([]*crypto.ECPoint, len(Ps))
+	bigGammaJs[i] = round.temp.pointGamma
+	alphas, us := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	culprits := make([]*tss.PartyID, len(Ps))
+	sem := common.NewSemaphore(round.Concurrency())
+	wg := sync.WaitGroup{}
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		r2msg1 := round.temp.presignRound2Message1s[j].Content().(*PresignRound2Message1)
+		r2msg2 := round.temp.presignRound2Message2s[j].Content().(*PresignRound2Message2)
+		bigGammaJ, err := r2msg2.UnmarshalGamma(round.EC())
+		if err != nil {
+			culprits[j] = Pj
+			continue
+		}
+		pi1, err1 := r2msg1.UnmarshalProofBobWC1(round.EC())
+		pi2, err2 := r2msg1.UnmarshalProofBobWC2(round.EC())
+		if err1 != nil || err2 != nil {
+			culprits[j] = Pj
+			continue
+		}
+		bigGammaJs[j] = bigGammaJ
+		wg.Add(2)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				return
+			}
+			alphaIj, err := mta.AliceEndWC(
+				round.proofTranscript(2, Pj),
+				round.EC(),
+				round.key.PaillierPKs[i],
+				pi1,
+				bigGammaJ,
+				round.temp.bigK,
+				new(big.Int).SetBytes(r2msg1.GetC1()),
+				round.key.NTildej[i],
+				round.key.H1j[i],
+				round.key.H2j[i],
+				round.key.PaillierSK,
+				round.MtAProofParams())
+			if err != nil {
+				culprits[j] = Pj
+				return
+			}
+			alphas[j] = alphaIj
+		}(j, Pj)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				return
+			}
+			uIj, err := mta.AliceEndWC(
+				round.proofTranscript(2, Pj),
+				round.EC(),
+				round.key.PaillierPKs[i],
+				pi2,
+				round.temp.bigWs[j],
+				round.temp.bigK,
+				new(big.Int).SetBytes(r2msg1.GetC2()),
+				round.key.NTildej[i],
+				round.key.H1j[i],
+				round.key.H2j[i],
+				round.key.PaillierSK,
+				round.MtAProofParams())
+			if err != nil {
+				culprits[j] = Pj
+				return
+			}
+			us[j] = uIj
+		}(j, Pj)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(err)
+	}
+	if culprits := nonNilPartyIDs(culprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to calculate Alice_end_wc"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. delta_i = k_i*gamma_i + sum(alpha_ij + beta_ij), chi_i = k_i*w_i + sum(u_ij + v_ij)
+	modN := common.ModInt(round.EC().Params().N)
+	delta := modN.Mul(round.temp.k, round.temp.gamma)
+	chi := modN.Mul(round.temp.k, round.temp.w)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		delta = modN.Add(delta, modN.Add(alphas[j], round.temp.betas[j]))
+		chi = modN.Add(chi, modN.Add(us[j], round.temp.vs[j]))
+	}
+
+	// 3. Gamma = sum(Gamma_j), Delta_i = k_i*Gamma, S_i = chi_i*Gamma
+	bigGamma := bigGammaJs[i]
+	for j, bigGammaJ := range bigGammaJs {
+		if j == i {
+			continue
+		}
+		var err error
+		if bigGamma, err = bigGamma.Add(bigGammaJ); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "bigGamma.Add(Gamma_j)"), Ps[j])
+		}
+	}
+	bigDelta := bigGamma.ScalarMult(round.temp.k)
+	bigS := bigGamma.ScalarMult(chi)
+
+	round.temp.delta = delta
+	round.temp.chi = chi
+	round.temp.bigGamma = bigGamma
+	round.temp.bigDelta = bigDelta
+	round.temp.bigS = bigS
+
+	// 4. prove to each P_j that Delta_i uses the plaintext of K_i
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		proof, err := mta.ProvePDL(
+			round.proofTranscript(3, round.PartyID()),
+			round.key.PaillierPKs[i],
+			round.temp.bigK,
+			bigGamma,
+			bigDelta,
+			round.key.NTildej[j],
+			round.key.H1j[j],
+			round.key.H2j[j],
+			round.temp.k,
+			round.temp.rK,
+			round.MtAProofParams())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "ProvePDL(Delta_i)"))
+		}
+		r3msg1 := NewPresignRound3Message1(Pj, round.PartyID(), proof)
+		round.out <- round.WithSessionID(r3msg1)
+	}
+
+	// 5. BROADCAST delta_i, Delta_i and S_i
+	r3msg2 := NewPresignRound3Message2(round.PartyID(), delta, bigDelta, bigS)
+	round.temp.presignRound3Message2s[i] = r3msg2
+	round.out <- round.WithSessionID(r3msg2)
+	return nil
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg1 := range round.temp.presignRound3Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			return false, nil
+		}
+		msg2 := round.temp.presignRound3Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound3Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound3Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
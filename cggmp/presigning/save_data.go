@@ -0,0 +1,52 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presigning
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// PreSignatureData is the output of presigning, which a party keeps until the message to sign is known.
+	// It holds the nonce point R, this party's shares k_i and chi_i = k_i*x_i of k and k*x, and the points
+	// R_bar_j = k_j*R and S_j = chi_j*R of all of the signers which let the online round check each signature share.
+	// A presignature must sign no more than one message: two signatures with the same R reveal the private key.
+	PreSignatureData struct {
+		// the keys of the signers, in the order of the party IDs of the presigning
+		Ks []*big.Int
+
+		ECDSAPub *crypto.ECPoint
+		R        *crypto.ECPoint
+
+		// secret fields (not shared, but stored locally); erased when the presignature is used
+		KI, ChiI *big.Int
+
+		BigRBarj, BigSj []*crypto.ECPoint
+	}
+)
+
+// Used reports whether the presignature has been consumed by signing
+func (pre *PreSignatureData) Used() bool {
+	return pre.KI == nil || pre.ChiI == nil
+}
+
+// CheckSigners returns an error unless `sortedIDs` are the parties of the presigning
+func (pre *PreSignatureData) CheckSigners(sortedIDs tss.SortedPartyIDs) error {
+	if len(sortedIDs) != len(pre.Ks) {
+		return errors.New("the signers must be the parties of the presigning")
+	}
+	for j, Pj := range sortedIDs {
+		if Pj.KeyInt().Cmp(pre.Ks[j]) != 0 {
+			return errors.New("the signers must be the parties of the presigning")
+		}
+	}
+	return nil
+}
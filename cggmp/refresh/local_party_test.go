@@ -0,0 +1,123 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/binance-chain/tss-lib/cggmp/refresh"
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// the parties take their "new" pre-params from the fixture of the next party, to avoid generating safe primes
+	preParams := func(i int) keygen.LocalPreParams {
+		return keys[(i+1)%len(keys)].LocalPreParams
+	}
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		P := NewLocalParty(params, keys[i], outCh, endCh, preParams(i)).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			ended++
+		}
+	}
+
+	oldShares, newShares := make(vss.Shares, 0), make(vss.Shares, 0)
+	for i, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(keys[i].ECDSAPub), "the public key must not change")
+		assert.Equal(t, keys[i].Epoch+1, key.Epoch, "the epoch must be bumped")
+		assert.NotEqual(t, 0, key.Xi.Cmp(keys[i].Xi), "the share must change")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), key.Xi).Equals(key.BigXj[i]), "X_i must match x_i")
+		assert.Equal(t, 0, key.PaillierSK.N.Cmp(preParams(i).PaillierSK.N), "the Paillier key must be replaced")
+		assert.Equal(t, 0, key.NTildei.Cmp(preParams(i).NTildei), "NTilde must be replaced")
+		for j := range key.BigXj {
+			assert.True(t, key.BigXj[j].Equals(newKeys[0].BigXj[j]), "all parties must agree on X_j")
+			assert.Equal(t, 0, key.PaillierPKs[j].N.Cmp(preParams(j).PaillierSK.N), "all parties must agree on pk_j")
+			assert.Equal(t, 0, key.NTildej[j].Cmp(preParams(j).NTildei), "all parties must agree on NTilde_j")
+			assert.Equal(t, 0, key.H1j[j].Cmp(preParams(j).H1i))
+			assert.Equal(t, 0, key.H2j[j].Cmp(preParams(j).H2i))
+		}
+		if i <= testThreshold {
+			oldShares = append(oldShares, &vss.Share{Threshold: testThreshold, ID: keys[i].ShareID, Share: keys[i].Xi})
+			newShares = append(newShares, &vss.Share{Threshold: testThreshold, ID: key.ShareID, Share: key.Xi})
+		}
+	}
+
+	// the refreshed shares reconstruct the same private key
+	oldSecret, err := oldShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	newSecret, err := newShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, newSecret.Cmp(oldSecret))
+}
@@ -0,0 +1,185 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into cggmp-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*RefreshRound1Message)(nil),
+		(*RefreshRound2Message1)(nil),
+		(*RefreshRound2Message2)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*RefreshRound1Message)(nil), tss.CGGMPProtoNamePrefix+"refresh.RefreshRound1Message")
+	proto.RegisterType((*RefreshRound2Message1)(nil), tss.CGGMPProtoNamePrefix+"refresh.RefreshRound2Message1")
+	proto.RegisterType((*RefreshRound2Message2)(nil), tss.CGGMPProtoNamePrefix+"refresh.RefreshRound2Message2")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewRefreshRound1Message(
+	from *tss.PartyID,
+	vct cmt.HashCommitment,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dlnProof1Bz, err := dlnProof1.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	content := &RefreshRound1Message{
+		VCommitment: vct.Bytes(),
+		PaillierN:   paillierPK.N.Bytes(),
+		NTilde:      nTildeI.Bytes(),
+		H1:          h1I.Bytes(),
+		H2:          h2I.Bytes(),
+		Dlnproof_1:  dlnProof1Bz,
+		Dlnproof_2:  dlnProof2Bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RefreshRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetVCommitment()) &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+func (m *RefreshRound1Message) UnmarshalVCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetVCommitment())
+}
+
+func (m *RefreshRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RefreshRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RefreshRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RefreshRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+func NewRefreshRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RefreshRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *RefreshRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+// ----- //
+
+func NewRefreshRound2Message2(
+	from *tss.PartyID,
+	vdct cmt.HashDeCommitment,
+	proof paillier.Proof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	pfBzs := make([][]byte, len(proof))
+	for i := range pfBzs {
+		if proof[i] == nil {
+			continue
+		}
+		pfBzs[i] = proof[i].Bytes()
+	}
+	content := &RefreshRound2Message2{
+		VDecommitment: common.BigIntsToBytes(vdct),
+		PaillierProof: pfBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetVDecommitment()) &&
+		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters)
+}
+
+func (m *RefreshRound2Message2) UnmarshalVDeCommitment() cmt.HashDeCommitment {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetVDecommitment())
+}
+
+func (m *RefreshRound2Message2) UnmarshalProofInts() paillier.Proof {
+	var pf paillier.Proof
+	proofBzs := m.GetPaillierProof()
+	for i := range pf {
+		pf[i] = new(big.Int).SetBytes(proofBzs[i])
+	}
+	return pf
+}
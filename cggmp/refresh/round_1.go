@@ -0,0 +1,132 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the key refresh and auxiliary info protocol of CGGMP21 (Canetti et al.; 2021):
+// every party deals a Feldman VSS sharing of zero and publishes its new Paillier and NTilde parameters
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+	if err := round.CheckCurveName(round.input.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	// every party of the key must take part, as all of the shares and Paillier keys are refreshed
+	ids := round.Parties().IDs()
+	if round.input.Xi == nil || len(round.input.Ks) != len(ids) {
+		return round.WrapError(fmt.Errorf("all %d parties of the key must take part in the refresh", len(round.input.Ks))).WithCode(tss.CodeBadInput)
+	}
+	keyIdx := make(map[string]struct{}, len(round.input.Ks))
+	for _, kj := range round.input.Ks {
+		keyIdx[hex.EncodeToString(kj.Bytes())] = struct{}{}
+	}
+	for _, Pj := range ids {
+		if _, ok := keyIdx[hex.EncodeToString(Pj.Key)]; !ok {
+			return round.WrapError(errors.New("a party was not found in the save data of the key"), Pj).WithCode(tss.CodeBadInput)
+		}
+	}
+	*round.input = keygen.BuildLocalSaveDataSubset(*round.input, ids)
+	ks := round.input.Ks
+
+	// 1. create a sharing of zero among all parties
+	vs, shares, err := vss.CreateZeroSharing(round.EC(), round.Threshold(), ks)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 2. commit to v_1..v_t
+	flatVs, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	vCmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), flatVs...)
+
+	// 3. generate the new Paillier key and NTilde, h1, h2, unless they were given to the constructor
+	preParams := round.temp.preParams
+	if preParams == nil {
+		if preParams, err = keygen.GeneratePreParamsWithModulusLen(round.SafePrimeGenTimeout(), round.PaillierModulusLen(), round.Concurrency()); err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.temp.preParams = preParams
+	}
+	if preParams.PaillierSK.N.BitLen() < round.PaillierModulusLen() {
+		return round.WrapError(errors.New("the Paillier modulus of the pre-params is shorter than the configured length"), Pi).WithCode(tss.CodeBadInput)
+	}
+	dlnProof1 := dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei)
+	dlnProof2 := dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei)
+
+	// 4. populate temp data
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.VD = vCmt.D
+	round.temp.VCs[i] = vCmt.C
+	round.temp.paillierPKs[i] = &preParams.PaillierSK.PublicKey
+	round.temp.NTildej[i] = preParams.NTildei
+	round.temp.H1j[i], round.temp.H2j[i] = preParams.H1i, preParams.H2i
+
+	// 5. BROADCAST the commitment and the new parameters
+	r1msg, err := NewRefreshRound1Message(
+		Pi, vCmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.rfRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
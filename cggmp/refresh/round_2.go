@@ -0,0 +1,159 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. verify the dln proofs and ensure the uniqueness of h1j, h2j and that nobody kept their Paillier key
+	h1H2Map := make(map[string]struct{}, len(round.temp.rfRound1Messages)*2)
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	sem := common.NewSemaphore(round.Concurrency())
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.rfRound1Messages {
+		r1msg := msg.Content().(*RefreshRound1Message)
+		H1j, H2j, NTildej :=
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde()
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+		}
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		if err := r1msg.UnmarshalPaillierPK().ValidatePeer(round.PaillierModulusLen()); err != nil {
+			return round.WrapError(err, msg.GetFrom())
+		}
+		if r1msg.UnmarshalPaillierPK().N.Cmp(round.input.PaillierPKs[j].N) == 0 {
+			return round.WrapError(errors.New("the Paillier key was not refreshed"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+		}
+		if j == i {
+			continue
+		}
+		wg.Add(2)
+		go func(j int, msg tss.ParsedMessage, r1msg *RefreshRound1Message, H1j, H2j, NTildej *big.Int) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
+			if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+				dlnProof1FailCulprits[j] = msg.GetFrom()
+			}
+			wg.Done()
+		}(j, msg, r1msg, H1j, H2j, NTildej)
+		go func(j int, msg tss.ParsedMessage, r1msg *RefreshRound1Message, H1j, H2j, NTildej *big.Int) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
+			if dlnProof2, err := r1msg.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+				dlnProof2FailCulprits[j] = msg.GetFrom()
+			}
+			wg.Done()
+		}(j, msg, r1msg, H1j, H2j, NTildej)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(err)
+	}
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit).WithCode(tss.CodeInvalidProof)
+		}
+	}
+
+	// 2. store the new parameters of the other parties
+	for j, msg := range round.temp.rfRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*RefreshRound1Message)
+		round.temp.VCs[j] = r1msg.UnmarshalVCommitment()
+		round.temp.paillierPKs[j] = r1msg.UnmarshalPaillierPK()
+		round.temp.NTildej[j] = r1msg.UnmarshalNTilde()
+		round.temp.H1j[j], round.temp.H2j[j] = r1msg.UnmarshalH1(), r1msg.UnmarshalH2()
+	}
+
+	// 3. send each party its share of zero
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r2msg1 := NewRefreshRound2Message1(Pj, Pi, round.temp.shares[j])
+		round.out <- round.WithSessionID(r2msg1)
+	}
+
+	// 4. BROADCAST the de-commitment of v_1..v_t and the proof of the new Paillier key
+	proof := round.temp.preParams.PaillierSK.Proof(round.input.Ks[i], round.input.ECDSAPub)
+	r2msg2 := NewRefreshRound2Message2(Pi, round.temp.VD, proof)
+	round.temp.rfRound2Message2s[i] = r2msg2
+	round.out <- round.WithSessionID(r2msg2)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RefreshRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		msg2 := round.temp.rfRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
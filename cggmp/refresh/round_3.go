@@ -0,0 +1,130 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	for j := range round.ok {
+		round.ok[j] = true // no messages are received in this round
+	}
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+	threshold := round.Threshold()
+	modQ := common.ModInt(round.EC().Params().N)
+
+	// 1-4. de-commit v_j1..v_jt, verify the share of zero that Pj sent us and the proof of Pj's new Paillier key
+	vjs := make([]vss.Vs, len(Ps))
+	vjs[i] = round.temp.vs
+	newXi := modQ.Add(round.input.Xi, round.temp.shares[i].Share)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		r2msg2 := round.temp.rfRound2Message2s[j].Content().(*RefreshRound2Message2)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.VCs[j], D: r2msg2.UnmarshalVDeCommitment()}
+		ok, flatVs := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+		if !ok || len(flatVs) != threshold*2 { // they're points so * 2
+			culprits = append(culprits, Pj)
+			continue
+		}
+		vj, err := crypto.UnFlattenECPoints(round.EC(), flatVs)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+		sharej := &vss.Share{
+			Threshold: threshold,
+			ID:        Pi.KeyInt(),
+			Share:     r2msg1.UnmarshalShare(),
+		}
+		if !sharej.VerifyZeroSharing(threshold, vj) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if ok, err := r2msg2.UnmarshalProofInts().Verify(round.temp.paillierPKs[j].N, Pj.KeyInt(), round.input.ECDSAPub); err != nil || !ok {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		vjs[j] = vj
+		newXi = modQ.Add(newXi, sharej.Share)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("share of zero or Paillier proof from a peer failed to verify"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 5. V_c = sum_j(v_jc) for c = 1..t
+	Vc, err := vss.SumZeroSharings(vjs)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "vss.SumZeroSharings(vjs)"))
+	}
+
+	// 6. X'_j = X_j + sum_c(V_c * k_j^c)
+	newBigXjs := make([]*crypto.ECPoint, len(Ps))
+	for j, Pj := range Ps {
+		zj, err := Vc.EvaluateZeroSharing(Pj.KeyInt())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "Vc.EvaluateZeroSharing(kj)"))
+		}
+		if newBigXjs[j], err = round.input.BigXj[j].Add(zj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "BigXj[j].Add(zj)"))
+		}
+	}
+	if !crypto.ScalarBaseMult(round.EC(), newXi).Equals(newBigXjs[i]) {
+		return round.WrapError(errors.New("assertion failed: g^x'_i != X'_i"), Pi)
+	}
+
+	// 7. SAVE the refreshed data; the public key and the party IDs are unchanged
+	*round.save = *round.input
+	round.save.Xi = newXi
+	round.save.BigXj = newBigXjs
+	round.save.LocalPreParams = *round.temp.preParams
+	round.save.PaillierPKs = round.temp.paillierPKs
+	round.save.NTildej = round.temp.NTildej
+	round.save.H1j, round.save.H2j = round.temp.H1j, round.temp.H2j
+	round.save.CurveName = round.CurveName()
+	round.save.Epoch = round.input.Epoch + 1
+	round.save.RefreshedAt = time.Now()
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsasigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	m, r, R := round.temp.m, round.temp.r, round.pre.R
+	modN := common.ModInt(round.EC().Params().N)
+
+	// 1. check each sigma_j against the presignature: sigma_j*R = m*R_bar_j + r*S_j
+	sumS := round.temp.sigma
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		sigmaJ := r1msg.UnmarshalSigma()
+		if sigmaJ.Cmp(round.EC().Params().N) >= 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		expected := round.pre.BigSj[j].ScalarMult(r)
+		if m.Sign() != 0 {
+			var err error
+			if expected, err = round.pre.BigRBarj[j].ScalarMult(m).Add(expected); err != nil {
+				culprits = append(culprits, Pj)
+				continue
+			}
+		}
+		if sigmaJ.Sign() == 0 || !R.ScalarMult(sigmaJ).Equals(expected) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, sigmaJ)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the signature share sigma_j"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. normalise s to the lower half of the curve order and save the signature
+	recid := 0
+	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if R.X().Cmp(round.EC().Params().N) > 0 {
+		recid = 2
+	}
+	if R.Y().Bit(0) != 0 {
+		recid |= 1
+	}
+	halfN := new(big.Int).Rsh(round.EC().Params().N, 1)
+	if sumS.Cmp(halfN) > 0 {
+		sumS.Sub(round.EC().Params().N, sumS)
+		recid ^= 1
+	}
+
+	round.data.Signature = append(r.Bytes(), sumS.Bytes()...)
+	round.data.SignatureRecovery = []byte{byte(recid)}
+	round.data.R = r.Bytes()
+	round.data.S = sumS.Bytes()
+	round.data.M = m.Bytes()
+
+	if ok := ecdsasigning.Verify(round.data, round.pre.ECDSAPub, m.Bytes()); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,130 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/cggmp/presigning"
+	. "github.com/binance-chain/tss-lib/cggmp/signing"
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	ecdsasigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	route := func(parties []tss.Party, msg tss.Message) {
+		dest := msg.GetTo()
+		if dest == nil {
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+		} else {
+			go updater(parties[dest[0].Index], msg, errCh)
+		}
+	}
+	start := func(P tss.Party) {
+		if err := P.Start(context.Background()); err != nil {
+			errCh <- err
+		}
+	}
+
+	// PHASE: presigning
+	preEndCh := make(chan presigning.PreSignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		assert.NoError(t, err)
+		P := presigning.NewLocalParty(params, keys[i], outCh, preEndCh)
+		parties = append(parties, P)
+		go start(P)
+	}
+	pres := make([]*presigning.PreSignatureData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+		case msg := <-outCh:
+			route(parties, msg)
+		case pre := <-preEndCh:
+			// match the presignature to its party by k_i*R = R_bar_i
+			for j := range signPIDs {
+				if pre.R.ScalarMult(pre.KI).Equals(pre.BigRBarj[j]) {
+					pres[j] = &pre
+				}
+			}
+			ended++
+		}
+	}
+
+	// PHASE: signing
+	msg := big.NewInt(42)
+	endCh := make(chan common.SignatureData, len(signPIDs))
+	parties = parties[:0]
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		assert.NoError(t, err)
+		P := NewLocalParty(msg, params, pres[i], outCh, endCh)
+		parties = append(parties, P)
+		go start(P)
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+		case msg := <-outCh:
+			route(parties, msg)
+		case data := <-endCh:
+			assert.True(t, ecdsasigning.Verify(&data, keys[0].ECDSAPub, msg.Bytes()), "ecdsa verify must pass")
+			ended++
+		}
+	}
+
+	// a presignature must not sign a second message
+	for _, pre := range pres {
+		assert.True(t, pre.Used(), "the presignature must be erased")
+	}
+	params, err := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	assert.NoError(t, err)
+	P := NewLocalParty(big.NewInt(43), params, pres[0], outCh, endCh)
+	assert.NotNil(t, P.Start(context.Background()), "signing with a used presignature must fail")
+}
@@ -0,0 +1,86 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/cggmp/presigning"
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents the online round of signing of CGGMP21 (Canetti, Gennaro, Goldfeder, Makriyannis, Peled; 2021)
+func newRound1(params *tss.Parameters, pre *presigning.PreSignatureData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, pre, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	if round.temp.m == nil || round.temp.m.Sign() < 0 || round.temp.m.Cmp(round.EC().Params().N) >= 0 {
+		return round.WrapError(errors.New("the message to sign must be in [0, N)")).WithCode(tss.CodeBadInput)
+	}
+	if err := round.pre.CheckSigners(round.Parties().IDs()); err != nil {
+		return round.WrapError(err)
+	}
+	if round.pre.Used() {
+		return round.WrapError(errors.New("the presignature has already been used")).WithCode(tss.CodeBadInput)
+	}
+
+	// 1. sigma_i = m*k_i + r*chi_i
+	modN := common.ModInt(round.EC().Params().N)
+	r := new(big.Int).Mod(round.pre.R.X(), round.EC().Params().N)
+	sigma := modN.Add(modN.Mul(round.temp.m, round.pre.KI), modN.Mul(r, round.pre.ChiI))
+	round.temp.r = r
+	round.temp.sigma = sigma
+
+	// 2. erase the secret shares of the presignature so that it can not sign again
+	round.pre.KI, round.pre.ChiI = nil, nil
+
+	// 3. BROADCAST sigma_i
+	r1msg := NewSignRound1Message(round.PartyID(), sigma)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
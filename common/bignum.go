@@ -0,0 +1,60 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"math/big"
+	"sync"
+)
+
+// The modular exponentiations of Paillier, the MtA and the ZK proofs dominate the cost of the signing rounds. They go
+// through ModInt, which hands them to a BigNumBackend. The default is math/big; building with the `gmp` tag selects
+// GMPBackend, a cgo binding to libgmp.
+
+type (
+	// BigNumBackend does the modular arithmetic of ModInt. Both methods follow the semantics of their big.Int namesakes.
+	BigNumBackend interface {
+		// Exp returns x^y mod m
+		Exp(x, y, m *big.Int) *big.Int
+		// ModInverse returns the inverse of g mod n, or nil if there is none
+		ModInverse(g, n *big.Int) *big.Int
+	}
+
+	// BigIntBackend is the BigNumBackend of math/big
+	BigIntBackend struct{}
+)
+
+var (
+	bigNumBackendMtx sync.RWMutex
+	bigNumBackend    = defaultBigNumBackend
+)
+
+func (BigIntBackend) Exp(x, y, m *big.Int) *big.Int {
+	return new(big.Int).Exp(x, y, m)
+}
+
+func (BigIntBackend) ModInverse(g, n *big.Int) *big.Int {
+	return new(big.Int).ModInverse(g, n)
+}
+
+// SetBigNumBackend replaces the BigNumBackend of ModInt. It should be set before any protocol runs. A nil backend
+// restores the default of the build.
+func SetBigNumBackend(b BigNumBackend) {
+	bigNumBackendMtx.Lock()
+	defer bigNumBackendMtx.Unlock()
+	if b == nil {
+		b = defaultBigNumBackend
+	}
+	bigNumBackend = b
+}
+
+// GetBigNumBackend returns the BigNumBackend in use
+func GetBigNumBackend() BigNumBackend {
+	bigNumBackendMtx.RLock()
+	defer bigNumBackendMtx.RUnlock()
+	return bigNumBackend
+}
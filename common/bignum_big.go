@@ -0,0 +1,14 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+//go:build !gmp
+// +build !gmp
+
+package common
+
+var (
+	defaultBigNumBackend BigNumBackend = BigIntBackend{}
+)
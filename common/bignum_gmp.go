@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+//go:build gmp
+// +build gmp
+
+package common
+
+// #cgo LDFLAGS: -lgmp
+// #include <stdlib.h>
+// #include <gmp.h>
+import "C"
+
+import (
+	"math/big"
+	"unsafe"
+)
+
+// GMPBackend is the BigNumBackend of libgmp. Like math/big, mpz_powm does not run in constant time.
+// The cases that GMP does not share with math/big (a negative exponent, or a modulus that is not positive) fall back
+// to math/big.
+type GMPBackend struct{}
+
+var (
+	defaultBigNumBackend BigNumBackend = GMPBackend{}
+)
+
+func (GMPBackend) Exp(x, y, m *big.Int) *big.Int {
+	if m == nil || m.Sign() <= 0 || y.Sign() < 0 {
+		return new(big.Int).Exp(x, y, m)
+	}
+	var zx, zy, zm, zr C.mpz_t
+	for _, z := range []*C.__mpz_struct{&zx[0], &zy[0], &zm[0], &zr[0]} {
+		C.mpz_init(z)
+		defer C.mpz_clear(z)
+	}
+	toMPZ(&zx[0], x)
+	toMPZ(&zy[0], y)
+	toMPZ(&zm[0], m)
+	C.mpz_powm(&zr[0], &zx[0], &zy[0], &zm[0])
+	return fromMPZ(&zr[0])
+}
+
+func (GMPBackend) ModInverse(g, n *big.Int) *big.Int {
+	if n.Sign() <= 0 {
+		return new(big.Int).ModInverse(g, n)
+	}
+	var zg, zn, zr C.mpz_t
+	for _, z := range []*C.__mpz_struct{&zg[0], &zn[0], &zr[0]} {
+		C.mpz_init(z)
+		defer C.mpz_clear(z)
+	}
+	toMPZ(&zg[0], g)
+	toMPZ(&zn[0], n)
+	if C.mpz_invert(&zr[0], &zg[0], &zn[0]) == 0 {
+		return nil
+	}
+	return fromMPZ(&zr[0])
+}
+
+// toMPZ sets z to x through their big-endian bytes
+func toMPZ(z *C.__mpz_struct, x *big.Int) {
+	bz := x.Bytes()
+	if len(bz) == 0 {
+		C.mpz_set_ui(z, 0)
+		return
+	}
+	C.mpz_import(z, C.size_t(len(bz)), 1, 1, 1, 0, unsafe.Pointer(&bz[0]))
+	if x.Sign() < 0 {
+		C.mpz_neg(z, z)
+	}
+}
+
+// fromMPZ returns the non-negative z as a big.Int
+func fromMPZ(z *C.__mpz_struct) *big.Int {
+	size := (int(C.mpz_sizeinbase(z, 2)) + 7) / 8
+	bz := make([]byte, size)
+	var count C.size_t
+	C.mpz_export(unsafe.Pointer(&bz[0]), &count, 1, 1, 1, 0, z)
+	return new(big.Int).SetBytes(bz[:int(count)])
+}
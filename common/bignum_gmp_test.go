@@ -0,0 +1,81 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+//go:build gmp
+// +build gmp
+
+package common_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// TestGMPBackendDifferential checks GMPBackend against BigIntBackend on random operands of the sizes that the protocols
+// use, and on the edge cases of either
+func TestGMPBackendDifferential(t *testing.T) {
+	assert.Equal(t, common.GMPBackend{}, common.GetBigNumBackend(), "the gmp build must default to GMPBackend")
+	gmp, std := common.GMPBackend{}, common.BigIntBackend{}
+
+	for _, bits := range []int{64, 256, 1024, 2048, 4096} {
+		for i := 0; i < 10; i++ {
+			m := common.MustGetRandomInt(bits)
+			if i%2 == 0 {
+				m.SetBit(m, 0, 0) // an even modulus
+			} else {
+				m.SetBit(m, 0, 1)
+			}
+			x, y := common.MustGetRandomInt(bits+8), common.MustGetRandomInt(bits)
+			if i%3 == 0 {
+				x.Neg(x)
+			}
+			assertIntEqual(t, std.Exp(x, y, m), gmp.Exp(x, y, m), "Exp(%v, %v, %v)", x, y, m)
+			assertIntEqual(t, std.ModInverse(x, m), gmp.ModInverse(x, m), "ModInverse(%v, %v)", x, m)
+		}
+	}
+
+	m := common.MustGetRandomInt(256)
+	m.SetBit(m, 0, 1)
+	x := common.MustGetRandomInt(256)
+	cases := [][3]*big.Int{
+		{x, big.NewInt(0), m},
+		{big.NewInt(0), x, m},
+		{big.NewInt(0), big.NewInt(0), m},
+		{big.NewInt(1), x, m},
+		{new(big.Int).Neg(x), big.NewInt(3), m},
+		{m, x, m},
+		{new(big.Int).Lsh(m, 300), x, m},
+		{x, new(big.Int).Lsh(common.MustGetRandomInt(4096), 4096), m},
+		{x, big.NewInt(5), big.NewInt(1)},
+		{x, big.NewInt(5), big.NewInt(2)},
+		{x, big.NewInt(-5), m},
+		{x, big.NewInt(5), new(big.Int).Neg(m)},
+		{x, big.NewInt(5), big.NewInt(0)},
+		{x, big.NewInt(5), nil},
+	}
+	for _, c := range cases {
+		assertIntEqual(t, std.Exp(c[0], c[1], c[2]), gmp.Exp(c[0], c[1], c[2]), "Exp(%v)", c)
+	}
+
+	inverses := [][2]*big.Int{
+		{big.NewInt(0), m},
+		{big.NewInt(1), m},
+		{m, m},
+		{new(big.Int).Add(m, big.NewInt(2)), m},
+		{new(big.Int).Neg(x), m},
+		{big.NewInt(6), big.NewInt(9)},
+		{big.NewInt(3), big.NewInt(1)},
+		{x, big.NewInt(2)},
+		{x, new(big.Int).Neg(m)},
+	}
+	for _, c := range inverses {
+		assertIntEqual(t, std.ModInverse(c[0], c[1]), gmp.ModInverse(c[0], c[1]), "ModInverse(%v)", c)
+	}
+}
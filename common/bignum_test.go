@@ -0,0 +1,72 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+type countingBackend struct {
+	common.BigIntBackend
+	exps int
+}
+
+func (b *countingBackend) Exp(x, y, m *big.Int) *big.Int {
+	b.exps++
+	return b.BigIntBackend.Exp(x, y, m)
+}
+
+func assertIntEqual(t *testing.T, expected, actual *big.Int, msgAndArgs ...interface{}) {
+	if expected == nil || actual == nil {
+		assert.True(t, expected == nil && actual == nil, msgAndArgs...)
+		return
+	}
+	assert.Equal(t, 0, expected.Cmp(actual), msgAndArgs...)
+}
+
+// TestBigNumBackend checks the backend of the build (run with `-tags gmp` for GMP) against math/big
+func TestBigNumBackend(t *testing.T) {
+	b := common.GetBigNumBackend()
+	for i := 0; i < 20; i++ {
+		m := common.GetRandomPositiveInt(common.MustGetRandomInt(randomIntBitLen))
+		x, y := common.GetRandomPositiveInt(m), common.GetRandomPositiveInt(m)
+		assertIntEqual(t, new(big.Int).Exp(x, y, m), b.Exp(x, y, m))
+		assertIntEqual(t, new(big.Int).ModInverse(x, m), b.ModInverse(x, m))
+	}
+	m := big.NewInt(35)
+	cases := [][3]*big.Int{
+		{big.NewInt(-3), big.NewInt(5), m},
+		{big.NewInt(3), big.NewInt(0), m},
+		{big.NewInt(0), big.NewInt(5), m},
+		{big.NewInt(3), big.NewInt(5), big.NewInt(1)},
+		{big.NewInt(3), big.NewInt(-5), m},
+		{big.NewInt(3), big.NewInt(5), nil},
+	}
+	for _, c := range cases {
+		assertIntEqual(t, new(big.Int).Exp(c[0], c[1], c[2]), b.Exp(c[0], c[1], c[2]), "%v", c)
+	}
+	assert.Nil(t, b.ModInverse(big.NewInt(7), m))
+	assertIntEqual(t, big.NewInt(3), b.ModInverse(big.NewInt(-23), m))
+}
+
+func TestSetBigNumBackend(t *testing.T) {
+	def := common.GetBigNumBackend()
+	b := new(countingBackend)
+	common.SetBigNumBackend(b)
+	defer common.SetBigNumBackend(nil)
+	x := common.ModInt(big.NewInt(35)).Exp(big.NewInt(2), big.NewInt(5))
+	assert.Equal(t, 0, big.NewInt(32).Cmp(x))
+	assert.Equal(t, 1, b.exps)
+
+	common.SetBigNumBackend(nil)
+	assert.Equal(t, def, common.GetBigNumBackend())
+}
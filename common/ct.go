@@ -0,0 +1,65 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+)
+
+// The secret scalars of the protocols (the shares x_i and w_i and the nonces k_i and gamma_i) go through the helpers of
+// this file where a branch or a variable-time algorithm would otherwise depend on their value. math/big itself is not
+// constant time, so these do not make a party immune to timing side channels, but they remove the leaks that depend
+// most directly on a secret: the steps of the extended Euclidean algorithm, and branches and early exits on its bits.
+
+// ModInverseCT returns the inverse of g mod a prime modulus p by Fermat's little theorem, g^-1 = g^(p-2), or nil if
+// g is 0 mod p. Unlike ModInverse, the steps that it takes do not depend on g: the exponent is public, and g is blinded
+// with a random r as (g*r)^(p-2) * r. The modulus must be prime.
+func (mi *modInt) ModInverseCT(g *big.Int) *big.Int {
+	p := mi.i()
+	r := GetRandomPositiveRelativelyPrimeInt(p)
+	blinded := mi.Mul(g, r)
+	if blinded.Sign() == 0 {
+		return nil
+	}
+	return mi.Mul(mi.Exp(blinded, new(big.Int).Sub(p, two)), r)
+}
+
+// ConstantTimeSelect returns a copy of x if v == 1 and of y if v == 0, with the same steps either way. x and y must be
+// non-negative and fit in `size` bytes, e.g. the byte length of the order of the curve.
+func ConstantTimeSelect(v int, x, y *big.Int, size int) *big.Int {
+	out := paddedBytes(y, size)
+	subtle.ConstantTimeCopy(v, out, paddedBytes(x, size))
+	return new(big.Int).SetBytes(out)
+}
+
+// ConstantTimeCmp compares x and y as big.Int Cmp does, returning -1, 0 or +1, without an early exit at the first
+// byte that differs. x and y must be non-negative and fit in `size` bytes.
+func ConstantTimeCmp(x, y *big.Int, size int) int {
+	xBz, yBz := paddedBytes(x, size), paddedBytes(y, size)
+	gt, lt := 0, 0
+	for i := range xBz {
+		a, b := int(xBz[i]), int(yBz[i])
+		// only the first byte that differs decides; a negative difference shifts to -1
+		undecided := 1 ^ (gt | lt)
+		gt |= undecided & ((b - a) >> 8) & 1
+		lt |= undecided & ((a - b) >> 8) & 1
+	}
+	return gt - lt
+}
+
+// paddedBytes returns the big-endian bytes of the non-negative x, left-padded with zeros to `size` bytes
+func paddedBytes(x *big.Int, size int) []byte {
+	xBz := x.Bytes()
+	if x.Sign() < 0 || size < len(xBz) {
+		panic(fmt.Errorf("paddedBytes: the int does not fit in %d bytes", size))
+	}
+	bz := make([]byte, size)
+	copy(bz[size-len(xBz):], xBz)
+	return bz
+}
@@ -0,0 +1,50 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+func TestModInverseCT(t *testing.T) {
+	q := elliptic.P256().Params().N
+	modQ := common.ModInt(q)
+	for i := 0; i < 20; i++ {
+		g := common.GetRandomPositiveInt(q)
+		assertIntEqual(t, new(big.Int).ModInverse(g, q), modQ.ModInverseCT(g))
+	}
+	assert.Nil(t, modQ.ModInverseCT(big.NewInt(0)))
+	assert.Nil(t, modQ.ModInverseCT(q))
+}
+
+func TestConstantTimeSelect(t *testing.T) {
+	x, y := big.NewInt(0x1234), big.NewInt(0xff)
+	assertIntEqual(t, x, common.ConstantTimeSelect(1, x, y, 2))
+	assertIntEqual(t, y, common.ConstantTimeSelect(0, x, y, 2))
+	assert.Panics(t, func() { common.ConstantTimeSelect(1, x, y, 1) })
+}
+
+func TestConstantTimeCmp(t *testing.T) {
+	q := elliptic.P256().Params().N
+	size := (q.BitLen() + 7) / 8
+	for i := 0; i < 100; i++ {
+		x, y := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+		if i%10 == 0 {
+			y = new(big.Int).Set(x)
+		}
+		assert.Equal(t, x.Cmp(y), common.ConstantTimeCmp(x, y, size))
+	}
+	assert.Equal(t, 0, common.ConstantTimeCmp(big.NewInt(0), big.NewInt(0), size))
+	assert.Equal(t, -1, common.ConstantTimeCmp(big.NewInt(0x00ff), big.NewInt(0x0100), 2))
+	assert.Equal(t, 1, common.ConstantTimeCmp(big.NewInt(0x0100), big.NewInt(0x00ff), 2))
+}
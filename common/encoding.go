@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"crypto"
+	_ "crypto/sha512"
+	"encoding/binary"
+	"math/big"
+)
+
+// The canonical encoding is the one byte encoding of the material that this library hashes into commitments and
+// Fiat-Shamir challenges, so that a hash input determines the values that it was made from and two implementations
+// agree on it. Its counts and lengths are fixed-width 8-byte big endian integers:
+//
+//	bytes:   uint64(length) || bytes
+//	integer: sign byte (0, or 1 if negative) || uint64(length of the magnitude) || magnitude, big endian, minimal
+//	list:    uint64(count) || each of the items
+//
+// The golden vectors of encoding_test.go pin it down; a change to it is a change of the version in the domains that
+// are hashed with it, e.g. "tss-lib/commitment/v1".
+
+// AppendUint64 appends `v` as 8 big endian bytes to `dst`
+func AppendUint64(dst []byte, v uint64) []byte {
+	var bz [8]byte
+	binary.BigEndian.PutUint64(bz[:], v)
+	return append(dst, bz[:]...)
+}
+
+// AppendBytes appends the canonical encoding of `bz` to `dst`: its length, then its bytes
+func AppendBytes(dst, bz []byte) []byte {
+	dst = AppendUint64(dst, uint64(len(bz)))
+	return append(dst, bz...)
+}
+
+// AppendInt appends the canonical encoding of `n` to `dst`: its sign, then the length and the bytes of its magnitude.
+// `n` must not be nil.
+func AppendInt(dst []byte, n *big.Int) []byte {
+	sign := byte(0)
+	if n.Sign() < 0 {
+		sign = 1
+	}
+	return AppendBytes(append(dst, sign), n.Bytes())
+}
+
+// EncodeBytes returns the canonical encoding of the list `parts`
+func EncodeBytes(parts ...[]byte) []byte {
+	size := 8
+	for _, bz := range parts {
+		size += 8 + len(bz)
+	}
+	data := AppendUint64(make([]byte, 0, size), uint64(len(parts)))
+	for _, bz := range parts {
+		data = AppendBytes(data, bz)
+	}
+	return data
+}
+
+// EncodeInts returns the canonical encoding of the list `ints`, or nil if one of them is nil
+func EncodeInts(ints ...*big.Int) []byte {
+	size := 8
+	for _, n := range ints {
+		if n == nil {
+			return nil
+		}
+		size += 9 + (n.BitLen()+7)/8
+	}
+	data := AppendUint64(make([]byte, 0, size), uint64(len(ints)))
+	for _, n := range ints {
+		data = AppendInt(data, n)
+	}
+	return data
+}
+
+// SHA512_256Canonical is SHA512_256 of the canonical encoding of `domain` followed by that of the list `in`, so that
+// the digests of two domains or of two lists never share an input
+func SHA512_256Canonical(domain string, in ...[]byte) []byte {
+	return sha512_256(AppendBytes(nil, []byte(domain)), EncodeBytes(in...))
+}
+
+// SHA512_256iCanonical is SHA512_256i of the canonical encoding of `domain` followed by that of the list `in`. It
+// returns nil if one of `in` is nil.
+func SHA512_256iCanonical(domain string, in ...*big.Int) *big.Int {
+	data := EncodeInts(in...)
+	if data == nil {
+		return nil
+	}
+	return new(big.Int).SetBytes(sha512_256(AppendBytes(nil, []byte(domain)), data))
+}
+
+func sha512_256(data ...[]byte) []byte {
+	state := crypto.SHA512_256.New()
+	for _, bz := range data {
+		// n < len(data) or an error will never happen.
+		// see: https://golang.org/pkg/hash/#Hash and https://github.com/golang/go/wiki/Hashing#the-hashhash-interface
+		if _, err := state.Write(bz); err != nil {
+			DefaultLogger().Error("sha512_256 Write() failed", "err", err)
+			return nil
+		}
+	}
+	return state.Sum(nil)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// the golden vectors of the canonical encoding; they must never change, as the hashes of the other versions of this
+// library and of the other implementations are made from the same bytes
+func TestEncodingGolden(t *testing.T) {
+	vectors := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"no bytes", common.EncodeBytes(), "0000000000000000"},
+		{"bytes", common.EncodeBytes([]byte("ab"), nil),
+			"0000000000000002" + "0000000000000002" + "6162" + "0000000000000000"},
+		{"no ints", common.EncodeInts(), "0000000000000000"},
+		{"ints", common.EncodeInts(big.NewInt(0), big.NewInt(-1), big.NewInt(0x0100)),
+			"0000000000000003" + "00" + "0000000000000000" + "01" + "0000000000000001" + "01" + "00" + "0000000000000002" + "0100"},
+		{"uint64", common.AppendUint64([]byte{0xff}, 0x0102030405060708), "ff0102030405060708"},
+		{"SHA512_256Canonical", common.SHA512_256Canonical("tss-lib/test", []byte("a"), nil),
+			"77dec0569b329938e4bad4f22c2d6a087f5631ca344f288c60641791575f7559"},
+		{"SHA512_256iCanonical", common.SHA512_256iCanonical("tss-lib/test", big.NewInt(1), big.NewInt(-2)).Bytes(),
+			"9647278952e36995959aed667dfe9402491ac4e88e090a939e041d22d8346dd6"},
+	}
+	for _, v := range vectors {
+		assert.Equal(t, v.want, hex.EncodeToString(v.got), v.name)
+	}
+}
+
+func TestEncodingInjective(t *testing.T) {
+	// (0x01, 0x022403) and (0x012402, 0x03) hash to the same digest with SHA512_256i, which delimits with '$'
+	a := []*big.Int{big.NewInt(0x01), big.NewInt(0x022403)}
+	b := []*big.Int{big.NewInt(0x012402), big.NewInt(0x03)}
+	assert.Equal(t, 0, common.SHA512_256i(a...).Cmp(common.SHA512_256i(b...)))
+	assert.NotEqual(t, common.EncodeInts(a...), common.EncodeInts(b...))
+	assert.NotEqual(t, 0, common.SHA512_256iCanonical("", a...).Cmp(common.SHA512_256iCanonical("", b...)))
+
+	// (0x01, 0x0203) and (0x0102, 0x03) have the same bytes once concatenated
+	c := []*big.Int{big.NewInt(0x01), big.NewInt(0x0203)}
+	d := []*big.Int{big.NewInt(0x0102), big.NewInt(0x03)}
+	assert.NotEqual(t, common.EncodeInts(c...), common.EncodeInts(d...))
+	assert.NotEqual(t, 0, common.SHA512_256iCanonical("", c...).Cmp(common.SHA512_256iCanonical("", d...)))
+	assert.NotEqual(t,
+		common.SHA512_256Canonical("", []byte{0x01}, []byte{0x02, 0x03}),
+		common.SHA512_256Canonical("", []byte{0x01, 0x02}, []byte{0x03}))
+	// and (0x01, 0x022403) and (0x012402, 0x03) collide with SHA512_256, which also delimits with '$'
+	assert.Equal(t,
+		common.SHA512_256([]byte{0x01}, []byte{0x02, '$', 0x03}),
+		common.SHA512_256([]byte{0x01, '$', 0x02}, []byte{0x03}))
+	assert.NotEqual(t,
+		common.SHA512_256Canonical("", []byte{0x01}, []byte{0x02, '$', 0x03}),
+		common.SHA512_256Canonical("", []byte{0x01, '$', 0x02}, []byte{0x03}))
+
+	// the sign, the split of the bytes and the domain are all part of the input
+	assert.NotEqual(t, common.EncodeInts(big.NewInt(1)), common.EncodeInts(big.NewInt(-1)))
+	assert.NotEqual(t, common.EncodeBytes([]byte("ab"), []byte("c")), common.EncodeBytes([]byte("a"), []byte("bc")))
+	assert.NotEqual(t, common.SHA512_256Canonical("a", []byte("b")), common.SHA512_256Canonical("ab"))
+	assert.NotEqual(t, common.SHA512_256Canonical("a"), common.SHA512_256Canonical("a", nil))
+
+	// a nil int has no encoding
+	assert.Nil(t, common.EncodeInts(big.NewInt(1), nil))
+	assert.Nil(t, common.SHA512_256iCanonical("", nil))
+}
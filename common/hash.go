@@ -10,6 +10,7 @@ import (
 	"crypto"
 	_ "crypto/sha512"
 	"encoding/binary"
+	"hash"
 	"math/big"
 )
 
@@ -44,15 +45,19 @@ func SHA512_256(in ...[]byte) []byte {
 	// n < len(data) or an error will never happen.
 	// see: https://golang.org/pkg/hash/#Hash and https://github.com/golang/go/wiki/Hashing#the-hashhash-interface
 	if _, err := state.Write(data); err != nil {
-		Logger.Errorf("SHA512_256 Write() failed: %v", err)
+		DefaultLogger().Error("SHA512_256 Write() failed", "err", err)
 		return nil
 	}
 	return state.Sum(nil)
 }
 
 func SHA512_256i(in ...*big.Int) *big.Int {
+	return HashInts(crypto.SHA512_256.New(), in...)
+}
+
+// HashInts is SHA512_256i with the hash function of `state`, which must be new or reset
+func HashInts(state hash.Hash, in ...*big.Int) *big.Int {
 	var data []byte
-	state := crypto.SHA512_256.New()
 	inLen := len(in)
 	if inLen == 0 {
 		return nil
@@ -77,7 +82,7 @@ func SHA512_256i(in ...*big.Int) *big.Int {
 	// n < len(data) or an error will never happen.
 	// see: https://golang.org/pkg/hash/#Hash and https://github.com/golang/go/wiki/Hashing#the-hashhash-interface
 	if _, err := state.Write(data); err != nil {
-		Logger.Errorf("SHA512_256i Write() failed: %v", err)
+		DefaultLogger().Error("HashInts Write() failed", "err", err)
 		return nil
 	}
 	return new(big.Int).SetBytes(state.Sum(nil))
@@ -93,7 +98,7 @@ func SHA512_256iOne(in *big.Int) *big.Int {
 	// n < len(data) or an error will never happen.
 	// see: https://golang.org/pkg/hash/#Hash and https://github.com/golang/go/wiki/Hashing#the-hashhash-interface
 	if _, err := state.Write(data); err != nil {
-		Logger.Errorf("SHA512_256iOne Write() failed: %v", err)
+		DefaultLogger().Error("SHA512_256iOne Write() failed", "err", err)
 		return nil
 	}
 	return new(big.Int).SetBytes(state.Sum(nil))
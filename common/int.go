@@ -48,11 +48,11 @@ func (mi *modInt) Mul(x, y *big.Int) *big.Int {
 }
 
 func (mi *modInt) Exp(x, y *big.Int) *big.Int {
-	return new(big.Int).Exp(x, y, mi.i())
+	return GetBigNumBackend().Exp(x, y, mi.i())
 }
 
 func (mi *modInt) ModInverse(g *big.Int) *big.Int {
-	return new(big.Int).ModInverse(g, mi.i())
+	return GetBigNumBackend().ModInverse(g, mi.i())
 }
 
 func (mi *modInt) i() *big.Int {
@@ -7,7 +7,88 @@
 package common
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ipfs/go-log"
 )
 
-var Logger = log.Logger("tss-lib")
+type (
+	// Logger is the logger of tss-lib. An entry has a message and fields given as key-value pairs, e.g.
+	// Info("round started", "round", 2). Implement it to send the logs to zap, zerolog etc.
+	Logger interface {
+		Debug(msg string, keyvals ...interface{})
+		Info(msg string, keyvals ...interface{})
+		Warn(msg string, keyvals ...interface{})
+		Error(msg string, keyvals ...interface{})
+		// With returns a logger that adds the fields to each entry
+		With(keyvals ...interface{}) Logger
+	}
+
+	// goLogger writes the entries to a logger of go-log, with the fields appended to the message as key=value
+	goLogger struct {
+		log    log.StandardLogger
+		fields []interface{}
+	}
+
+	nopLogger struct{}
+)
+
+var defaultLogger Logger = &goLogger{log: log.Logger("tss-lib")}
+
+// DefaultLogger returns the logger that is used when no other is set: the go-log logger named "tss-lib", whose level
+// is set with log.SetLogLevel("tss-lib", level)
+func DefaultLogger() Logger {
+	return defaultLogger
+}
+
+// NopLogger returns a logger that discards every entry
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+func (l *goLogger) Debug(msg string, keyvals ...interface{}) {
+	l.log.Debug(l.format(msg, keyvals))
+}
+
+func (l *goLogger) Info(msg string, keyvals ...interface{}) {
+	l.log.Info(l.format(msg, keyvals))
+}
+
+func (l *goLogger) Warn(msg string, keyvals ...interface{}) {
+	l.log.Warning(l.format(msg, keyvals))
+}
+
+func (l *goLogger) Error(msg string, keyvals ...interface{}) {
+	l.log.Error(l.format(msg, keyvals))
+}
+
+func (l *goLogger) With(keyvals ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keyvals))
+	return &goLogger{log: l.log, fields: append(append(fields, l.fields...), keyvals...)}
+}
+
+func (l *goLogger) format(msg string, keyvals []interface{}) string {
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for _, kvs := range [][]interface{}{l.fields, keyvals} {
+		for i := 0; i < len(kvs); i += 2 {
+			if i+1 < len(kvs) {
+				fmt.Fprintf(&sb, " %v=%v", kvs[i], kvs[i+1])
+			} else {
+				fmt.Fprintf(&sb, " %v", kvs[i])
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+
+func (nopLogger) Info(string, ...interface{}) {}
+
+func (nopLogger) Warn(string, ...interface{}) {}
+
+func (nopLogger) Error(string, ...interface{}) {}
+
+func (l nopLogger) With(...interface{}) Logger { return l }
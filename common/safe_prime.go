@@ -58,43 +58,51 @@ func probablyPrime(prime *big.Int) bool {
 
 // ----- //
 
-// The following code is a modified copy of: https://github.com/didiercrunch/paillier/blob/753322e473bf8ee20267c7824e68ae47360cc69b/safe_prime_generator.go
-// It is an implementation of the algorithm described in "Safe Prime Generation with a Combined Sieve" https://eprint.iacr.org/2003/186.pdf
+// GetRandomSafePrimesConcurrent searches for safe primes with the combined sieve of Wiener: "Safe Prime Generation
+// with a Combined Sieve" https://eprint.iacr.org/2003/186.pdf
+// Each worker draws a random start q0 = 5 mod 6 and sieves the interval q0 + 6k, k < safePrimeSieveInterval, removing
+// every q for which q or p = 2q+1 has a factor below safePrimeSieveLimit. Stepping by 6 keeps q odd and q != 1 mod 3,
+// as p is a multiple of 3 otherwise. Only the survivors are tested with exponentiations: first the Fermat test to
+// base 2 of q and of p, which rejects almost every composite, then Miller-Rabin and Baillie-PSW for q.
+//
+// Knowing q is prime, Pocklington's criterion proves p prime: 2^(p-1) = 1 mod p and gcd(2^2 - 1, p) = 1, which holds
+// as p = 2 mod 3. With SafePrimeOptions.Certify that proof replaces the Miller-Rabin and Baillie-PSW tests of p.
 
-// The code is the original Go implementation of rand.Prime optimized for
-// generating safe (Sophie Germain) primes.
-// A safe prime is a prime number of the form 2p + 1, where p is also a prime.
+const (
+	// the sieve primes are the odd primes from 5 below safePrimeSieveLimit
+	safePrimeSieveLimit = 1 << 14
+	// the number of candidates q0 + 6k that a worker sieves from each random start
+	safePrimeSieveInterval = 1 << 14
+	// DefaultSafePrimeProgressInterval is the interval between progress reports when SafePrimeOptions has none
+	DefaultSafePrimeProgressInterval = time.Second
+)
 
-// Note from Author (https://github.com/pdyraga):
-// I've adapted a Go code for generating random numbers by inserting some
-// optimisations that will allow us to generate safe primes faster than
-// with the previous, naive approach.
-//
-// First of all, having q which can be prime, we first check whether q%3=1.
-// If that's true, there is no chance p=2q+1 is prime. It lets us to reject
-// candidate numbers quicker without running an expensive primality tests.
-//
-// Also, before we run a primality test for q, we may check p=2q+1 against
-// the primes between 3-53 (We are limited by Go's uint64 range).
-//
-// If all those conditions are met and we know p is prime, it's enough to
-// check Pocklington criterion for q instead of running an expensive
-// primality test for it.
-
-// smallPrimes is a list of small, prime numbers that allows us to rapidly
-// exclude some fraction of composite candidates when searching for a random
-// prime. This list is truncated at the point where smallPrimesProduct exceeds
-// a uint64. It does not include two because we ensure that the candidates are
-// odd by construction.
-var smallPrimes = []uint8{
-	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53,
+type (
+	// SafePrimeProgress reports on a search for safe primes
+	SafePrimeProgress struct {
+		Tested  uint64        // the candidates that passed the sieve and were tested with an exponentiation
+		Found   int           // the safe primes found so far
+		Elapsed time.Duration // the time since the search began
+	}
+
+	SafePrimeOptions struct {
+		// Progress, if not nil, is called from the calling goroutine every ProgressInterval and after each safe
+		// prime is found
+		Progress         func(SafePrimeProgress)
+		ProgressInterval time.Duration
+		// Certify proves that p is prime by Pocklington's criterion, given that q is, in place of testing p
+		Certify bool
+	}
+)
+
+type sievePrime struct {
+	r, inv6 uint64 // 6 * inv6 = 1 mod r
 }
 
-// smallPrimesProduct is the product of the values in smallPrimes and allows us
-// to reduce a candidate prime by this number and then determine whether it's
-// coprime to all the elements of smallPrimes without further big.Int
-// operations.
-var smallPrimesProduct = new(big.Int).SetUint64(16294579238595022365)
+var (
+	safePrimeSieveOnce   sync.Once
+	safePrimeSievePrimes []sievePrime
+)
 
 // GetRandomSafePrimesConcurrent tries to find safe primes concurrently.
 // The returned results are safe primes `p` and prime `q` such that `p=2q+1`.
@@ -110,28 +118,31 @@ var smallPrimesProduct = new(big.Int).SetUint64(16294579238595022365)
 // work. This way, with the same finding algorithm, we can get the result
 // faster.
 //
-// Concurrency level should be set depending on what `bitLen` of prime is
-// expected. For example, as of today, on a typical workstation, for 512-bit
-// safe prime, `concurrencyLevel` should be set to `1` as generating the prime
-// of this length is a matter of milliseconds for a single core.
-// For 1024-bit safe prime, `concurrencyLevel` should be usually set to at least
-// `2` and for 2048-bit safe prime, `concurrencyLevel` must be set to at least
-// `4` to get the result in a reasonable time.
-//
 // This function generates safe primes of at least 6 `bitLen`. For every
 // generated safe prime, the two most significant bits are always set to `1`
 // - we don't want the generated number to be too small.
 func GetRandomSafePrimesConcurrent(bitLen, numPrimes int, timeout time.Duration, concurrency int) ([]*GermainSafePrime, error) {
+	return GetRandomSafePrimesWithOptions(bitLen, numPrimes, timeout, concurrency, nil)
+}
+
+// GetRandomSafePrimesWithOptions is GetRandomSafePrimesConcurrent with progress reports and certification as set in
+// `opts`, which may be nil
+func GetRandomSafePrimesWithOptions(bitLen, numPrimes int, timeout time.Duration, concurrency int, opts *SafePrimeOptions) ([]*GermainSafePrime, error) {
 	if bitLen < 6 {
 		return nil, errors.New("safe prime size must be at least 6 bits")
 	}
 	if numPrimes < 1 {
 		return nil, errors.New("numPrimes should be > 0")
 	}
+	if opts == nil {
+		opts = new(SafePrimeOptions)
+	}
+	start := time.Now()
 
 	primeCh := make(chan *GermainSafePrime, concurrency*numPrimes)
 	errCh := make(chan error, concurrency*numPrimes)
 	primes := make([]*GermainSafePrime, 0, numPrimes)
+	var tested uint64
 
 	waitGroup := &sync.WaitGroup{}
 
@@ -144,7 +155,7 @@ func GetRandomSafePrimesConcurrent(bitLen, numPrimes int, timeout time.Duration,
 	for i := 0; i < concurrency; i++ {
 		waitGroup.Add(1)
 		runGenPrimeRoutine(
-			ctx, primeCh, errCh, waitGroup, rand.Reader, bitLen,
+			ctx, primeCh, errCh, waitGroup, rand.Reader, bitLen, &tested, opts.Certify,
 		)
 	}
 
@@ -154,12 +165,33 @@ func GetRandomSafePrimesConcurrent(bitLen, numPrimes int, timeout time.Duration,
 		cancel()
 	}()
 
-	needed := int32(numPrimes)
+	var tickC <-chan time.Time
+	report := func() {}
+	if opts.Progress != nil {
+		interval := opts.ProgressInterval
+		if interval <= 0 {
+			interval = DefaultSafePrimeProgressInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tickC = ticker.C
+		report = func() {
+			opts.Progress(SafePrimeProgress{
+				Tested:  atomic.LoadUint64(&tested),
+				Found:   len(primes),
+				Elapsed: time.Since(start),
+			})
+		}
+	}
+
 	for {
 		select {
+		case <-tickC:
+			report()
 		case result := <-primeCh:
 			primes = append(primes, result)
-			if atomic.AddInt32(&needed, -1) <= 0 {
+			report()
+			if len(primes) >= numPrimes {
 				cancel()
 				return primes[:numPrimes], nil
 			}
@@ -175,38 +207,8 @@ func GetRandomSafePrimesConcurrent(bitLen, numPrimes int, timeout time.Duration,
 // Starts a Goroutine searching for a safe prime of the specified `pBitLen`.
 // If succeeds, writes prime `p` and prime `q` such that `p = 2q+1` to the
 // `primeCh`. Prime `p` has a bit length equal to `pBitLen` and prime `q` has
-// a bit length equal to `pBitLen-1`.
-//
-// The algorithm is as follows:
-// 1. Generate a random odd number `q` of length `pBitLen-1` with two the most
-//    significant bits set to `1`.
-// 2. Execute preliminary primality test on `q` checking whether it is coprime
-//    to all the elements of `smallPrimes`. It allows to eliminate trivial
-//    cases quickly, when `q` is obviously no prime, without running an
-//    expensive final primality tests.
-//    If `q` is coprime to all of the `smallPrimes`, then go to the point 3.
-//    If not, add `2` and try again. Do it at most 10 times.
-// 3. Check the potentially prime `q`, whether `q = 1 (mod 3)`. This will
-//    happen for 50% of cases.
-//    If it is, then `p = 2q+1` will be a multiple of 3, so it will be obviously
-//    not a prime number. In this case, add `2` and try again. Do it at most 10
-//    times. If `q != 1 (mod 3)`, go to the point 4.
-// 4. Now we know `q` is potentially prime and `p = 2q+1` is not a multiple of
-//    3. We execute a preliminary primality test on `p`, checking whether
-//    it is coprime to all the elements of `smallPrimes` just like we did for
-//    `q` in point 2. If `p` is not coprime to at least one element of the
-//    `smallPrimes`, then go back to point 1.
-//    If `p` is coprime to all the elements of `smallPrimes`, go to point 5.
-// 5. At this point, we know `q` is potentially prime, and `p=q+1` is also
-//    potentially prime. We need to execute a final primality test for `q`.
-//    We apply Miller-Rabin and Baillie-PSW tests. If they succeed, it means
-//    that `q` is prime with a very high probability. Knowing `q` is prime,
-//    we use Pocklington's criterion to prove the primality of `p=2q+1`, that
-//    is, we execute Fermat primality test to base 2 checking whether
-//    `2^{p-1} = 1 (mod p)`. It's significantly faster than running full
-//    Miller-Rabin and Baillie-PSW for `p`.
-//    If `q` and `p` are found to be prime, return them as a result. If not, go
-//    back to the point 1.
+// a bit length equal to `pBitLen-1`. Every candidate tested with an
+// exponentiation is counted in `tested`.
 func runGenPrimeRoutine(
 	ctx context.Context,
 	primeCh chan<- *GermainSafePrime,
@@ -214,140 +216,161 @@ func runGenPrimeRoutine(
 	waitGroup *sync.WaitGroup,
 	rand io.Reader,
 	pBitLen int,
+	tested *uint64,
+	certify bool,
 ) {
 	qBitLen := pBitLen - 1
 	b := uint(qBitLen % 8)
 	if b == 0 {
 		b = 8
 	}
+	// a sieve prime must be less than q, which is at least 2^(qBitLen-1)
+	sieveBound := ^uint64(0)
+	if qBitLen-1 < 64 {
+		sieveBound = uint64(1) << uint(qBitLen-1)
+	}
+	sievePrimes := safePrimeSievePrimesBelow(sieveBound)
 
 	bytes := make([]byte, (qBitLen+7)/8)
-	p := new(big.Int)
-	q := new(big.Int)
-
-	bigMod := new(big.Int)
+	six := big.NewInt(6)
 
 	go func() {
 		defer waitGroup.Done()
 
+		composite := make([]bool, safePrimeSieveInterval)
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			default:
-				_, err := io.ReadFull(rand, bytes)
-				if err != nil {
-					errCh <- err
-					return
+			}
+			_, err := io.ReadFull(rand, bytes)
+			if err != nil {
+				select {
+				case errCh <- err:
+				case <-ctx.Done():
 				}
+				return
+			}
 
-				// Clear bits in the first byte to make sure the candidate has
-				// a size <= bits.
-				bytes[0] &= uint8(int(1<<b) - 1)
-				// Don't let the value be too small, i.e, set the most
-				// significant two bits.
-				// Setting the top two bits, rather than just the top bit,
-				// means that when two of these values are multiplied together,
-				// the result isn't ever one bit short.
-				if b >= 2 {
-					bytes[0] |= 3 << (b - 2)
-				} else {
-					// Here b==1, because b cannot be zero.
-					bytes[0] |= 1
-					if len(bytes) > 1 {
-						bytes[1] |= 0x80
-					}
+			// Clear bits in the first byte to make sure the candidate has
+			// a size <= bits.
+			bytes[0] &= uint8(int(1<<b) - 1)
+			// Don't let the value be too small, i.e, set the most
+			// significant two bits.
+			// Setting the top two bits, rather than just the top bit,
+			// means that when two of these values are multiplied together,
+			// the result isn't ever one bit short.
+			if b >= 2 {
+				bytes[0] |= 3 << (b - 2)
+			} else {
+				// Here b==1, because b cannot be zero.
+				bytes[0] |= 1
+				if len(bytes) > 1 {
+					bytes[1] |= 0x80
 				}
-				// Make the value odd since an even number this large certainly
-				// isn't prime.
-				bytes[len(bytes)-1] |= 1
-
-				q.SetBytes(bytes)
-
-				// Calculate the value mod the product of smallPrimes. If it's
-				// a multiple of any of these primes we add two until it isn't.
-				// The probability of overflowing is minimal and can be ignored
-				// because we still perform Miller-Rabin tests on the result.
-				bigMod.Mod(q, smallPrimesProduct)
-				mod := bigMod.Uint64()
-
-			NextDelta:
-				for delta := uint64(0); delta < 1<<20; delta += 2 {
-					m := mod + delta
-					for _, prime := range smallPrimes {
-						if m%uint64(prime) == 0 && (qBitLen > 6 || m != uint64(prime)) {
-							continue NextDelta
-						}
-					}
-
-					if delta > 0 {
-						bigMod.SetUint64(delta)
-						q.Add(q, bigMod)
-					}
-
-					// If `q = 1 (mod 3)`, then `p` is a multiple of `3` so it's
-					// obviously no prime and such `q` should be rejected.
-					// This will happen in 50% of cases and we should detect
-					// and eliminate them early.
-					//
-					// Explanation:
-					// If q = 1 (mod 3) then there exists a q' such that:
-					// q = 3q' + 1
-					//
-					// Since p = 2q + 1:
-					// p = 2q + 1 = 2(3q' + 1) + 1 = 6q' + 2 + 1 = 6q' + 3 =
-					//   = 3(2q' + 1)
-					// So `p` is a multiple of `3`.
-					qMod3 := new(big.Int).Mod(q, big.NewInt(3))
-					if qMod3.Cmp(big.NewInt(1)) == 0 {
-						continue NextDelta
-					}
-
-					// p = 2q+1
-					p.Mul(q, big.NewInt(2))
-					p.Add(p, big.NewInt(1))
-					if !isPrimeCandidate(p) {
-						continue NextDelta
-					}
-
+			}
+			// q0 = 5 mod 6
+			q0 := new(big.Int).SetBytes(bytes)
+			q0.Sub(q0, new(big.Int).Mod(q0, six)).Add(q0, big.NewInt(5))
+
+			sieveSafePrimeInterval(composite, q0, sievePrimes)
+			q := new(big.Int)
+			for k, c := range composite {
+				if c {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+				q.Mul(six, big.NewInt(int64(k))).Add(q, q0)
+				// There is a tiny possibility that, by stepping, we caused
+				// the number to be one bit too long. The rest of the
+				// interval is then too long as well.
+				if q.BitLen() != qBitLen {
 					break
 				}
-
-				// There is a tiny possibility that, by adding delta, we caused
-				// the number to be one bit too long. Thus we check BitLen
-				// here.
-				if q.ProbablyPrime(20) &&
-					isPocklingtonCriterionSatisfied(p) &&
-					q.BitLen() == qBitLen {
-
-					if sgp := (&GermainSafePrime{p: p, q: q}); sgp.Validate() {
-						primeCh <- &GermainSafePrime{p: p, q: q}
+				atomic.AddUint64(tested, 1)
+				if sgp := testSafePrimeCandidate(q, certify); sgp != nil {
+					// the caller stops reading once it has enough
+					select {
+					case primeCh <- sgp:
+					case <-ctx.Done():
+						return
 					}
-					p, q = new(big.Int), new(big.Int)
+					q = new(big.Int)
 				}
 			}
 		}
 	}()
 }
 
-// Pocklington's criterion can be used to prove the primality of `p = 2q + 1`
-// once one has proven the primality of `q`.
-// With `q` prime, `p = 2q + 1`, and `p` passing Fermat's primality test to base
-// `2` that `2^{p-1} = 1 (mod p)` then `p` is prime as well.
-func isPocklingtonCriterionSatisfied(p *big.Int) bool {
-	return new(big.Int).Exp(
-		big.NewInt(2),
-		new(big.Int).Sub(p, big.NewInt(1)),
-		p,
-	).Cmp(big.NewInt(1)) == 0
+// sieveSafePrimeInterval marks k in `composite` when q = q0 + 6k or p = 2q+1 is a multiple of one of the sieve
+// primes, i.e. when q = 0 or q = (r-1)/2 mod r
+func sieveSafePrimeInterval(composite []bool, q0 *big.Int, sievePrimes []sievePrime) {
+	for k := range composite {
+		composite[k] = false
+	}
+	width := uint64(len(composite))
+	rBig, aBig := new(big.Int), new(big.Int)
+	for _, sp := range sievePrimes {
+		r := sp.r
+		a := aBig.Mod(q0, rBig.SetUint64(r)).Uint64()
+		// q0 + 6k = t mod r for k = (t - a) / 6 mod r
+		for _, t := range [2]uint64{0, (r - 1) / 2} {
+			for k := (t + r - a) % r * sp.inv6 % r; k < width; k += r {
+				composite[k] = true
+			}
+		}
+	}
+}
+
+// testSafePrimeCandidate returns the safe prime 2q+1 when q and 2q+1 are prime
+func testSafePrimeCandidate(q *big.Int, certify bool) *GermainSafePrime {
+	if !isFermatProbablePrime(q) {
+		return nil
+	}
+	p := getSafePrime(q)
+	// Pocklington's criterion, once q is known to be prime
+	if !isFermatProbablePrime(p) {
+		return nil
+	}
+	if !q.ProbablyPrime(primeTestN) {
+		return nil
+	}
+	if !certify && !p.ProbablyPrime(primeTestN) {
+		return nil
+	}
+	return &GermainSafePrime{p: p, q: q}
+}
+
+// isFermatProbablePrime checks that 2^(n-1) = 1 mod n
+func isFermatProbablePrime(n *big.Int) bool {
+	return new(big.Int).Exp(two, new(big.Int).Sub(n, one), n).Cmp(one) == 0
 }
 
-func isPrimeCandidate(number *big.Int) bool {
-	m := new(big.Int).Mod(number, smallPrimesProduct).Uint64()
-	for _, prime := range smallPrimes {
-		if m%uint64(prime) == 0 && m != uint64(prime) {
-			return false
+// safePrimeSievePrimesBelow returns the sieve primes less than `bound`
+func safePrimeSievePrimesBelow(bound uint64) []sievePrime {
+	safePrimeSieveOnce.Do(func() {
+		notPrime := make([]bool, safePrimeSieveLimit)
+		for i := uint64(2); i < safePrimeSieveLimit; i++ {
+			if notPrime[i] {
+				continue
+			}
+			if 5 <= i {
+				inv6 := new(big.Int).ModInverse(big.NewInt(6), new(big.Int).SetUint64(i)).Uint64()
+				safePrimeSievePrimes = append(safePrimeSievePrimes, sievePrime{r: i, inv6: inv6})
+			}
+			for j := i * i; j < safePrimeSieveLimit; j += i {
+				notPrime[j] = true
+			}
 		}
+	})
+	n := len(safePrimeSievePrimes)
+	for n > 0 && bound <= safePrimeSievePrimes[n-1].r {
+		n--
 	}
-	return true
+	return safePrimeSievePrimes[:n]
 }
@@ -50,3 +50,59 @@ func TestGetRandomGermainPrimeConcurrent(t *testing.T) {
 		assert.True(t, sgp.Validate())
 	}
 }
+
+func TestGetRandomSafePrimesWithOptions(t *testing.T) {
+	var reports []SafePrimeProgress
+	opts := &SafePrimeOptions{
+		Progress: func(p SafePrimeProgress) {
+			reports = append(reports, p)
+		},
+		ProgressInterval: 10 * time.Millisecond,
+		Certify:          true,
+	}
+	sgps, err := GetRandomSafePrimesWithOptions(512, 2, 5*time.Minute, runtime.NumCPU(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sgps))
+	for _, sgp := range sgps {
+		assert.Equal(t, 512, sgp.SafePrime().BitLen())
+		assert.True(t, sgp.Validate())
+	}
+	if assert.NotEmpty(t, reports) {
+		last := reports[len(reports)-1]
+		assert.Equal(t, 2, last.Found)
+		assert.True(t, 2 <= last.Tested)
+		for i := 1; i < len(reports); i++ {
+			assert.True(t, reports[i-1].Tested <= reports[i].Tested)
+			assert.True(t, reports[i-1].Elapsed <= reports[i].Elapsed)
+		}
+	}
+}
+
+func TestGetRandomSafePrimesSmall(t *testing.T) {
+	for bitLen := 6; bitLen <= 20; bitLen++ {
+		sgps, err := GetRandomSafePrimesConcurrent(bitLen, 1, time.Minute, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, bitLen, sgps[0].SafePrime().BitLen())
+		assert.True(t, sgps[0].Validate(), "bitLen %d", bitLen)
+	}
+}
+
+func Test_sieveSafePrimeInterval(t *testing.T) {
+	q0 := MustGetRandomInt(256)
+	q0.Sub(q0, new(big.Int).Mod(q0, big.NewInt(6))).Add(q0, big.NewInt(5))
+	sievePrimes := safePrimeSievePrimesBelow(1000)
+	composite := make([]bool, 2000)
+	sieveSafePrimeInterval(composite, q0, sievePrimes)
+	for k, c := range composite {
+		q := new(big.Int).Add(q0, big.NewInt(int64(6*k)))
+		p := getSafePrime(q)
+		divisible := false
+		for _, sp := range sievePrimes {
+			r := new(big.Int).SetUint64(sp.r)
+			if new(big.Int).Mod(q, r).Sign() == 0 || new(big.Int).Mod(p, r).Sign() == 0 {
+				divisible = true
+			}
+		}
+		assert.Equal(t, divisible, c, "k = %d", k)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+// Semaphore bounds the number of goroutines that run a piece of CPU-heavy work at the same time
+type Semaphore chan struct{}
+
+// NewSemaphore returns a Semaphore that lets `n` goroutines, or 1 if `n` is smaller, hold it at once
+func NewSemaphore(n int) Semaphore {
+	if n < 1 {
+		n = 1
+	}
+	return make(Semaphore, n)
+}
+
+// Acquire blocks until the semaphore can be held
+func (s Semaphore) Acquire() {
+	s <- struct{}{}
+}
+
+// Release gives up a hold taken with Acquire
+func (s Semaphore) Release() {
+	<-s
+}
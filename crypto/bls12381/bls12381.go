@@ -0,0 +1,206 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package bls12381 provides the groups of the BLS12-381 pairing-friendly curve for the BLS signature scheme.
+// Public keys and the Feldman commitments of keygen are in G1 and signatures are in G2, as in the Ethereum consensus
+// layer and Chia. Scalars are kept as *big.Int mod Order like the rest of the library.
+package bls12381
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	bls "github.com/kilic/bls12-381"
+)
+
+const (
+	// G1Len and G2Len are the lengths in bytes of the compressed encodings of the points of G1 and G2
+	G1Len = 48
+	G2Len = 96
+)
+
+var (
+	// Order is the prime order r of G1 and G2
+	Order, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	one = big.NewInt(1)
+)
+
+// G1Point is a point of G1. Its methods do not modify the receiver.
+type G1Point struct {
+	p *bls.PointG1
+}
+
+// ScalarBaseMultG1 returns k*G for the generator G of G1
+func ScalarBaseMultG1(k *big.Int) *G1Point {
+	g := bls.NewG1()
+	return &G1Point{g.MulScalarBig(g.New(), g.One(), new(big.Int).Mod(k, Order))}
+}
+
+// ScalarMult returns k*P
+func (P *G1Point) ScalarMult(k *big.Int) *G1Point {
+	g := bls.NewG1()
+	return &G1Point{g.MulScalarBig(g.New(), P.p, new(big.Int).Mod(k, Order))}
+}
+
+// Add returns P + Q
+func (P *G1Point) Add(Q *G1Point) *G1Point {
+	g := bls.NewG1()
+	return &G1Point{g.Add(g.New(), P.p, Q.p)}
+}
+
+// Neg returns -P
+func (P *G1Point) Neg() *G1Point {
+	g := bls.NewG1()
+	return &G1Point{g.Neg(g.New(), P.p)}
+}
+
+// Equals returns true if P and Q are the same point
+func (P *G1Point) Equals(Q *G1Point) bool {
+	if P == nil || Q == nil {
+		return false
+	}
+	return bls.NewG1().Equal(P.p, Q.p)
+}
+
+// IsIdentity returns true if P is the point at infinity
+func (P *G1Point) IsIdentity() bool {
+	return bls.NewG1().IsZero(P.p)
+}
+
+// Bytes returns the compressed 48-byte encoding of P
+func (P *G1Point) Bytes() []byte {
+	return bls.NewG1().ToCompressed(P.p)
+}
+
+// NewG1PointFromBytes decodes a compressed encoding of a point of G1, checking that it is in the subgroup
+func NewG1PointFromBytes(bz []byte) (*G1Point, error) {
+	if len(bz) != G1Len {
+		return nil, errors.New("NewG1PointFromBytes: invalid length")
+	}
+	p, err := bls.NewG1().FromCompressed(bz)
+	if err != nil {
+		return nil, err
+	}
+	return &G1Point{p}, nil
+}
+
+// FlattenG1Points returns the encodings of the points in `in` as integers, for use with the hash commitments
+func FlattenG1Points(in []*G1Point) []*big.Int {
+	out := make([]*big.Int, len(in))
+	for i, P := range in {
+		out[i] = new(big.Int).SetBytes(P.Bytes())
+	}
+	return out
+}
+
+// UnFlattenG1Points is the inverse of FlattenG1Points
+func UnFlattenG1Points(in []*big.Int) ([]*G1Point, error) {
+	out := make([]*G1Point, len(in))
+	for i, n := range in {
+		if n == nil || n.Sign() < 0 || n.BitLen() > 8*G1Len {
+			return nil, errors.New("UnFlattenG1Points: invalid point encoding")
+		}
+		bz := make([]byte, G1Len)
+		nBz := n.Bytes()
+		copy(bz[G1Len-len(nBz):], nBz)
+		P, err := NewG1PointFromBytes(bz)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = P
+	}
+	return out, nil
+}
+
+// ----- //
+
+func (P *G1Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(P.Bytes())
+}
+
+func (P *G1Point) UnmarshalJSON(payload []byte) error {
+	var bz []byte
+	if err := json.Unmarshal(payload, &bz); err != nil {
+		return err
+	}
+	Q, err := NewG1PointFromBytes(bz)
+	if err != nil {
+		return err
+	}
+	P.p = Q.p
+	return nil
+}
+
+// ----- //
+
+// G2Point is a point of G2. Its methods do not modify the receiver.
+type G2Point struct {
+	p *bls.PointG2
+}
+
+// HashToG2 hashes `msg` to a point of G2 with the domain separation tag `dst`, following the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ suite of hash-to-curve
+func HashToG2(msg, dst []byte) (*G2Point, error) {
+	p, err := bls.NewG2().HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return &G2Point{p}, nil
+}
+
+// ScalarMult returns k*P
+func (P *G2Point) ScalarMult(k *big.Int) *G2Point {
+	g := bls.NewG2()
+	return &G2Point{g.MulScalarBig(g.New(), P.p, new(big.Int).Mod(k, Order))}
+}
+
+// Add returns P + Q
+func (P *G2Point) Add(Q *G2Point) *G2Point {
+	g := bls.NewG2()
+	return &G2Point{g.Add(g.New(), P.p, Q.p)}
+}
+
+// Equals returns true if P and Q are the same point
+func (P *G2Point) Equals(Q *G2Point) bool {
+	if P == nil || Q == nil {
+		return false
+	}
+	return bls.NewG2().Equal(P.p, Q.p)
+}
+
+// IsIdentity returns true if P is the point at infinity
+func (P *G2Point) IsIdentity() bool {
+	return bls.NewG2().IsZero(P.p)
+}
+
+// Bytes returns the compressed 96-byte encoding of P
+func (P *G2Point) Bytes() []byte {
+	return bls.NewG2().ToCompressed(P.p)
+}
+
+// NewG2PointFromBytes decodes a compressed encoding of a point of G2, checking that it is in the subgroup
+func NewG2PointFromBytes(bz []byte) (*G2Point, error) {
+	if len(bz) != G2Len {
+		return nil, errors.New("NewG2PointFromBytes: invalid length")
+	}
+	p, err := bls.NewG2().FromCompressed(bz)
+	if err != nil {
+		return nil, err
+	}
+	return &G2Point{p}, nil
+}
+
+// ----- //
+
+// pairingCheck returns true if e(a1, a2) == e(b1, b2)
+func pairingCheck(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
+	e := bls.NewEngine()
+	e.AddPair(a1.p, a2.p)
+	e.AddPairInv(b1.p, b2.p)
+	return e.Check()
+}
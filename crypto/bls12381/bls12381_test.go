@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package bls12381_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+func TestSignVerify(t *testing.T) {
+	sk := common.GetRandomPositiveInt(Order)
+	pk := ScalarBaseMultG1(sk)
+	msg := []byte("hello, ethereum")
+
+	sig, err := Sign(sk, msg, DST)
+	assert.NoError(t, err)
+	assert.True(t, Verify(pk, msg, sig, DST))
+	assert.False(t, Verify(pk, []byte("hello, chia"), sig, DST))
+	assert.False(t, Verify(pk, msg, sig, PopDST))
+	assert.False(t, Verify(ScalarBaseMultG1(big.NewInt(0)), msg, sig, DST), "the identity must not be a public key")
+
+	sig2, err := NewG2PointFromBytes(sig.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, sig2.Equals(sig))
+	pk2, err := NewG1PointFromBytes(pk.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, pk2.Equals(pk))
+}
+
+func TestFastAggregateVerify(t *testing.T) {
+	msg := []byte("attestation")
+	pks, sigs := make([]*G1Point, 0, 3), make([]*G2Point, 0, 3)
+	for i := 0; i < 3; i++ {
+		sk := common.GetRandomPositiveInt(Order)
+		sig, err := Sign(sk, msg, DST)
+		assert.NoError(t, err)
+		pks, sigs = append(pks, ScalarBaseMultG1(sk)), append(sigs, sig)
+	}
+	agg := AggregateSignatures(sigs)
+	assert.True(t, FastAggregateVerify(pks, msg, agg, DST))
+	assert.False(t, FastAggregateVerify(pks[1:], msg, agg, DST))
+}
+
+func TestVSS(t *testing.T) {
+	secret := common.GetRandomPositiveInt(Order)
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	vs, shares, err := CreateVSS(2, secret, ids)
+	assert.NoError(t, err)
+	assert.True(t, vs[0].Equals(ScalarBaseMultG1(secret)))
+	for _, share := range shares {
+		assert.True(t, VerifyShare(share, 2, vs))
+	}
+	recovered, err := ReConstruct(shares[:3])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, recovered.Cmp(secret))
+	_, err = ReConstruct(shares[:2])
+	assert.Error(t, err)
+}
+
+func TestG1PointJSON(t *testing.T) {
+	P := ScalarBaseMultG1(common.GetRandomPositiveInt(Order))
+	bz, err := json.Marshal(P)
+	assert.NoError(t, err)
+	var Q G1Point
+	assert.NoError(t, json.Unmarshal(bz, &Q))
+	assert.True(t, Q.Equals(P))
+}
+
+func TestZKProof(t *testing.T) {
+	x := common.GetRandomPositiveInt(Order)
+	X := ScalarBaseMultG1(x)
+	ctx := zkp.NewSessionTranscript("test", []byte("session"), 1)
+	pf, err := NewZKProofInTranscript(ctx, x, X)
+	assert.NoError(t, err)
+	assert.True(t, pf.VerifyInTranscript(ctx, X))
+	assert.False(t, pf.VerifyInTranscript(ctx, ScalarBaseMultG1(big.NewInt(1))))
+	assert.False(t, pf.VerifyInTranscript(zkp.NewSessionTranscript("test", []byte("session"), 2), X))
+}
@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package bls12381
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	"github.com/binance-chain/tss-lib/crypto/zkp/schnorr"
+)
+
+// ZKProof is a Schnorr ZK proof of knowledge of the discrete logarithm of a point, as schnorr.ZKProof in G1
+type ZKProof struct {
+	Alpha *G1Point
+	T     *big.Int
+}
+
+// NewZKProofInTranscript constructs a new Schnorr ZK proof of knowledge of x such that X = x*G, with
+// the challenge drawn in the context `ctx`, see schnorr.NewZKProofInTranscript of crypto/schnorr
+func NewZKProofInTranscript(ctx *zkp.Transcript, x *big.Int, X *G1Point) (*ZKProof, error) {
+	if ctx == nil {
+		return nil, errors.New("the proof requires a context, see zkp.NewSessionTranscript")
+	}
+	if x == nil || X == nil {
+		return nil, errors.New("ZKProof constructor received nil value(s)")
+	}
+	pf, err := schnorr.Prove(SchnorrGroup, schnorr.InTranscript(ctx), x, schnorrElement{X})
+	if err != nil {
+		return nil, err
+	}
+	return &ZKProof{Alpha: pf.Alpha.(schnorrElement).p, T: pf.T}, nil
+}
+
+// VerifyInTranscript verifies the proof, made in the context `ctx`, against the point X
+func (pf *ZKProof) VerifyInTranscript(ctx *zkp.Transcript, X *G1Point) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() || X == nil {
+		return false
+	}
+	zkPf := &schnorr.Proof{Alpha: schnorrElement{pf.Alpha}, T: pf.T}
+	return zkPf.Verify(SchnorrGroup, schnorr.InTranscript(ctx), schnorrElement{X})
+}
+
+func (pf *ZKProof) ValidateBasic() bool {
+	return pf.T != nil && pf.Alpha != nil
+}
+
+// ----- //
+
+// SchnorrGroup is G1 for the proofs of crypto/zkp/schnorr. Its elements are encoded as in G1Point.Bytes.
+var SchnorrGroup schnorr.Group = schnorrGroup{}
+
+type (
+	schnorrGroup struct{}
+
+	schnorrElement struct {
+		p *G1Point
+	}
+)
+
+// SchnorrElement returns `P` as an element of SchnorrGroup
+func SchnorrElement(P *G1Point) schnorr.Element {
+	return schnorrElement{P}
+}
+
+func (schnorrGroup) Order() *big.Int {
+	return Order
+}
+
+func (schnorrGroup) Generator() schnorr.Element {
+	return schnorrElement{ScalarBaseMultG1(one)}
+}
+
+func (schnorrGroup) ScalarBaseMult(k *big.Int) schnorr.Element {
+	return schnorrElement{ScalarBaseMultG1(k)}
+}
+
+func (P schnorrElement) Add(other schnorr.Element) (schnorr.Element, error) {
+	return schnorrElement{P.p.Add(other.(schnorrElement).p)}, nil
+}
+
+func (P schnorrElement) ScalarMult(k *big.Int) schnorr.Element {
+	return schnorrElement{P.p.ScalarMult(k)}
+}
+
+func (P schnorrElement) Equal(other schnorr.Element) bool {
+	Q, ok := other.(schnorrElement)
+	return ok && P.p.Equals(Q.p)
+}
+
+func (P schnorrElement) Encode() [][]byte {
+	return [][]byte{P.p.Bytes()}
+}
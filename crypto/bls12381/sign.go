@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package bls12381
+
+import (
+	"math/big"
+)
+
+var (
+	// DST is the domain separation tag of the proof-of-possession ciphersuite of the IETF BLS signature draft, which is
+	// used by the Ethereum consensus layer
+	DST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	// PopDST is the domain separation tag with which a key signs its own public key as a proof of possession
+	PopDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+// Sign returns the signature sk*H(msg) of `msg` with the domain separation tag `dst`
+func Sign(sk *big.Int, msg, dst []byte) (*G2Point, error) {
+	hm, err := HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return hm.ScalarMult(sk), nil
+}
+
+// Verify returns true if `sig` is a signature of `msg` with the domain separation tag `dst` under the public key `pk`,
+// which must not be the identity
+func Verify(pk *G1Point, msg []byte, sig *G2Point, dst []byte) bool {
+	if pk == nil || sig == nil || pk.IsIdentity() {
+		return false
+	}
+	hm, err := HashToG2(msg, dst)
+	if err != nil {
+		return false
+	}
+	return VerifyWithHash(pk, hm, sig)
+}
+
+// VerifyWithHash returns true if e(pk, hm) == e(G, sig) for a message already hashed to \`hm\` in G2. It checks the
+// signature shares of threshold signing against the shares of the public key.
+func VerifyWithHash(pk *G1Point, hm, sig *G2Point) bool {
+	if pk == nil || hm == nil || sig == nil {
+		return false
+	}
+	return pairingCheck(pk, hm, ScalarBaseMultG1(one), sig)
+}
+
+// AggregateSignatures returns the sum of the signatures in `sigs`
+func AggregateSignatures(sigs []*G2Point) *G2Point {
+	if len(sigs) == 0 {
+		return nil
+	}
+	agg := sigs[0]
+	for _, sig := range sigs[1:] {
+		agg = agg.Add(sig)
+	}
+	return agg
+}
+
+// AggregatePubKeys returns the sum of the public keys in `pks`
+func AggregatePubKeys(pks []*G1Point) *G1Point {
+	if len(pks) == 0 {
+		return nil
+	}
+	agg := pks[0]
+	for _, pk := range pks[1:] {
+		agg = agg.Add(pk)
+	}
+	return agg
+}
+
+// FastAggregateVerify returns true if `sig` is the aggregate of signatures of the same `msg` under each of `pks`.
+// Each of the public keys must have had its proof of possession checked, otherwise the check is open to rogue keys.
+func FastAggregateVerify(pks []*G1Point, msg []byte, sig *G2Point, dst []byte) bool {
+	if len(pks) == 0 {
+		return false
+	}
+	return Verify(AggregatePubKeys(pks), msg, sig, dst)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+)
+
+// Vs are the Feldman commitments v0..vt to the coefficients of a sharing polynomial in G1 of BLS12-381
+type Vs []*G1Point
+
+// CreateVSS returns the Feldman VSS shares of `secret` for each of the `indexes` in G1 of BLS12-381, with the
+// same semantics as vss.Create. The indexes are reduced mod Order.
+func CreateVSS(threshold int, secret *big.Int, indexes []*big.Int) (Vs, vss.Shares, error) {
+	if secret == nil || indexes == nil {
+		return nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, vss.ErrNumSharesBelowThreshold
+	}
+
+	poly := make([]*big.Int, threshold+1)
+	poly[0] = new(big.Int).Mod(secret, Order)
+	for i := 1; i <= threshold; i++ {
+		poly[i] = common.GetRandomPositiveInt(Order)
+	}
+	v := make(Vs, len(poly))
+	for i, ai := range poly {
+		v[i] = ScalarBaseMultG1(ai)
+	}
+
+	modOrder := common.ModInt(Order)
+	shares := make(vss.Shares, num)
+	for i := 0; i < num; i++ {
+		id := new(big.Int).Mod(indexes[i], Order)
+		if id.Sign() == 0 {
+			return nil, nil, fmt.Errorf("party index should not be 0 mod Order")
+		}
+		// Horner's method
+		share := new(big.Int).Set(poly[threshold])
+		for j := threshold - 1; j >= 0; j-- {
+			share = modOrder.Add(modOrder.Mul(share, id), poly[j])
+		}
+		shares[i] = &vss.Share{Threshold: threshold, ID: indexes[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// VerifyShare checks a share created by CreateVSS against the commitments `vs`
+func VerifyShare(share *vss.Share, threshold int, vs Vs) bool {
+	if share == nil || share.Threshold != threshold || len(vs) != threshold+1 {
+		return false
+	}
+	modOrder := common.ModInt(Order)
+	id := new(big.Int).Mod(share.ID, Order)
+	v, t := vs[0], big.NewInt(1)
+	for j := 1; j <= threshold; j++ {
+		// t = k_i^j
+		t = modOrder.Mul(t, id)
+		v = v.Add(vs[j].ScalarMult(t))
+	}
+	return ScalarBaseMultG1(share.Share).Equals(v)
+}
+
+// ReConstruct recovers the secret mod Order from at least t+1 shares created by CreateVSS
+func ReConstruct(shares vss.Shares) (*big.Int, error) {
+	if shares != nil && shares[0].Threshold > len(shares)-1 {
+		return nil, vss.ErrNumSharesBelowThreshold
+	}
+	modOrder := common.ModInt(Order)
+	xs := make([]*big.Int, 0, len(shares))
+	for _, share := range shares {
+		xs = append(xs, new(big.Int).Mod(share.ID, Order))
+	}
+	secret := big.NewInt(0)
+	for i, share := range shares {
+		times := big.NewInt(1)
+		for j := 0; j < len(xs); j++ {
+			if j == i {
+				continue
+			}
+			sub := modOrder.Sub(xs[j], xs[i])
+			if sub.Sign() == 0 {
+				return nil, errors.New("two shares have the same index mod Order")
+			}
+			times = modOrder.Mul(times, modOrder.Mul(xs[j], modOrder.ModInverse(sub)))
+		}
+		secret = modOrder.Add(secret, modOrder.Mul(share.Share, times))
+	}
+	return secret, nil
+}
@@ -10,6 +10,7 @@
 package commitments
 
 import (
+	"errors"
 	"math/big"
 
 	"github.com/binance-chain/tss-lib/common"
@@ -29,13 +30,34 @@ type (
 	}
 )
 
+var (
+	sessionDomain = []byte("tss-lib hash commitment session")
+)
+
 func NewHashCommitmentWithRandomness(r *big.Int, secrets ...*big.Int) *HashCommitDecommit {
+	return NewHashCommitmentWithRandomnessAndHash(SHA512_256, r, secrets...)
+}
+
+// NewHashCommitmentWithRandomnessAndHash is NewHashCommitmentWithRandomness with the hash function `h`, which must be
+// valid
+func NewHashCommitmentWithRandomnessAndHash(h HashFunction, r *big.Int, secrets ...*big.Int) *HashCommitDecommit {
+	return NewHashCommitmentWithRandomnessInSession(h, FramingV1, "", nil, r, secrets...)
+}
+
+// NewHashCommitmentWithRandomnessInSession is NewHashCommitmentInSession with the randomness `r`
+func NewHashCommitmentWithRandomnessInSession(h HashFunction, f Framing, domain string, sessionID []byte, r *big.Int, secrets ...*big.Int) *HashCommitDecommit {
+	if err := h.Validate(); err != nil {
+		panic(err)
+	}
+	if err := f.validate(); err != nil {
+		panic(err)
+	}
 	parts := make([]*big.Int, len(secrets)+1)
 	parts[0] = r
 	for i := 1; i < len(parts); i++ {
 		parts[i] = secrets[i-1]
 	}
-	hash := common.SHA512_256i(parts...)
+	hash := h.hashTagged(f, sessionParts(f, domain, sessionID, parts)...)
 
 	cmt := &HashCommitDecommit{}
 	cmt.C = hash
@@ -44,8 +66,22 @@ func NewHashCommitmentWithRandomness(r *big.Int, secrets ...*big.Int) *HashCommi
 }
 
 func NewHashCommitment(secrets ...*big.Int) *HashCommitDecommit {
+	return NewHashCommitmentWithHash(SHA512_256, secrets...)
+}
+
+// NewHashCommitmentWithHash is NewHashCommitment with the hash function `h`, which must be valid
+func NewHashCommitmentWithHash(h HashFunction, secrets ...*big.Int) *HashCommitDecommit {
 	r := common.MustGetRandomInt(HashLength) // r
-	return NewHashCommitmentWithRandomness(r, secrets...)
+	return NewHashCommitmentWithRandomnessAndHash(h, r, secrets...)
+}
+
+// NewHashCommitmentInSession is NewHashCommitmentWithHash for a commitment of the framing `f` that is bound to the
+// protocol step `domain` and to the ceremony `sessionID`, which are hashed along with the secrets. It opens only with
+// VerifyInSession and DeCommitInSession given the same domain and session ID, so that it cannot be replayed into another
+// ceremony or into another step of a protocol. The de-commitment is the same as that of NewHashCommitmentWithHash.
+func NewHashCommitmentInSession(h HashFunction, f Framing, domain string, sessionID []byte, secrets ...*big.Int) *HashCommitDecommit {
+	r := common.MustGetRandomInt(HashLength) // r
+	return NewHashCommitmentWithRandomnessInSession(h, f, domain, sessionID, r, secrets...)
 }
 
 func NewHashDeCommitmentFromBytes(marshalled [][]byte) HashDeCommitment {
@@ -53,23 +89,74 @@ func NewHashDeCommitmentFromBytes(marshalled [][]byte) HashDeCommitment {
 }
 
 func (cmt *HashCommitDecommit) Verify() bool {
+	return cmt.VerifyInSession(FramingV1, "", nil)
+}
+
+// VerifyInSession verifies a commitment made by NewHashCommitmentInSession with the same `domain` and `sessionID`.
+// `f` is the framing of the commitments of the verifier: those of the legacy framing open only if it is legacy too.
+func (cmt *HashCommitDecommit) VerifyInSession(f Framing, domain string, sessionID []byte) bool {
 	C, D := cmt.C, cmt.D
 	if C == nil || D == nil {
 		return false
 	}
-	hash := common.SHA512_256i(D...)
-	if hash.Cmp(C) == 0 {
+	h, tagged, err := hashFunctionOf(C)
+	if err != nil || !f.accepts(tagged) {
+		return false
+	}
+	hash := h.hashTagged(tagged, sessionParts(tagged, domain, sessionID, D)...)
+	if hash != nil && hash.Cmp(C) == 0 {
 		return true
 	} else {
 		return false
 	}
 }
 
+// HashFunction returns the hash function that the commitment is tagged with, e.g. for a party to enforce its own
+func (cmt *HashCommitDecommit) HashFunction() (HashFunction, error) {
+	if cmt.C == nil {
+		return 0, errors.New("commitments: the commitment is nil")
+	}
+	h, _, err := hashFunctionOf(cmt.C)
+	return h, err
+}
+
+// Framing returns the framing that the commitment is tagged with, see tss.Parameters.SetLegacyFraming
+func (cmt *HashCommitDecommit) Framing() (Framing, error) {
+	if cmt.C == nil {
+		return 0, errors.New("commitments: the commitment is nil")
+	}
+	_, f, err := hashFunctionOf(cmt.C)
+	return f, err
+}
+
 func (cmt *HashCommitDecommit) DeCommit() (bool, HashDeCommitment) {
-	if cmt.Verify() {
+	return cmt.DeCommitInSession(FramingV1, "", nil)
+}
+
+// DeCommitInSession is DeCommit for a commitment made by NewHashCommitmentInSession with the same `domain` and
+// `sessionID`, opened by a verifier whose commitments are of the framing `f`, see VerifyInSession
+func (cmt *HashCommitDecommit) DeCommitInSession(f Framing, domain string, sessionID []byte) (bool, HashDeCommitment) {
+	if cmt.VerifyInSession(f, domain, sessionID) {
 		// [1:] skips random element r in D
 		return true, cmt.D[1:]
 	} else {
 		return false, nil
 	}
 }
+
+// sessionParts prepends the digest of the domain and the session ID to the hashed parts; the commitments without either
+// hash the parts alone, as they always have. The framing v1 hashes the domain and the session ID in the canonical
+// encoding, and the legacy framing as the older versions do.
+func sessionParts(f Framing, domain string, sessionID []byte, parts []*big.Int) []*big.Int {
+	if domain == "" && len(sessionID) == 0 {
+		return parts
+	}
+	var session *big.Int
+	if f == FramingLegacy {
+		domainLen := big.NewInt(int64(len(domain))).Bytes()
+		session = new(big.Int).SetBytes(common.SHA512_256(sessionDomain, domainLen, []byte(domain), sessionID))
+	} else {
+		session = new(big.Int).SetBytes(common.SHA512_256Canonical(string(sessionDomain), []byte(domain), sessionID))
+	}
+	return append([]*big.Int{session}, parts...)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/binance-chain/tss-lib/common"
 	. "github.com/binance-chain/tss-lib/crypto/commitments"
 )
 
@@ -36,3 +37,107 @@ func TestDeCommit(t *testing.T) {
 
 	assert.NotZero(t, len(secrets), "len(secrets) must be non-zero")
 }
+
+func TestHashFunctions(t *testing.T) {
+	one := big.NewInt(1)
+	zero := big.NewInt(0)
+
+	for _, h := range []HashFunction{SHA512_256, SHA256, SHA3_256, BLAKE2b_256} {
+		assert.NoError(t, h.Validate())
+		commitment := NewHashCommitmentWithHash(h, zero, one)
+		assert.True(t, commitment.Verify(), h.String())
+		h2, err := commitment.HashFunction()
+		assert.NoError(t, err)
+		assert.Equal(t, h, h2)
+
+		// the same randomness and secrets give different commitments under the other functions
+		for _, other := range []HashFunction{SHA512_256, SHA256, SHA3_256, BLAKE2b_256} {
+			if other != h {
+				c2 := NewHashCommitmentWithRandomnessAndHash(other, commitment.D[0], zero, one)
+				assert.NotEqual(t, 0, c2.C.Cmp(commitment.C))
+			}
+		}
+
+		// a tampered secret or re-tagged commitment does not verify
+		tampered := &HashCommitDecommit{C: commitment.C, D: []*big.Int{commitment.D[0], zero, zero}}
+		assert.False(t, tampered.Verify())
+		retagged := new(big.Int).SetBit(commitment.C, HashLength+3, 1)
+		assert.False(t, (&HashCommitDecommit{C: retagged, D: commitment.D}).Verify())
+	}
+
+	// the default SHA-512/256 with the legacy framing gives an untagged commitment
+	r := big.NewInt(42)
+	legacy := NewHashCommitmentWithRandomnessInSession(SHA512_256, FramingLegacy, "", nil, r, one)
+	assert.Equal(t, 0, legacy.C.Cmp(common.SHA512_256i(r, one)))
+
+	assert.Error(t, HashFunction(200).Validate())
+	assert.Panics(t, func() { NewHashCommitmentWithHash(HashFunction(200), one) })
+}
+
+func TestSessions(t *testing.T) {
+	one := big.NewInt(1)
+	zero := big.NewInt(0)
+	sessionID := []byte("ceremony 1")
+
+	commitment := NewHashCommitmentInSession(SHA3_256, FramingV1, "keygen/round-1", sessionID, zero, one)
+	assert.True(t, commitment.VerifyInSession(FramingV1, "keygen/round-1", sessionID))
+	pass, secrets := commitment.DeCommitInSession(FramingV1, "keygen/round-1", sessionID)
+	assert.True(t, pass, "must pass")
+	assert.Equal(t, []*big.Int{zero, one}, []*big.Int(secrets))
+
+	// it does not open in another ceremony, in another step or without a session
+	assert.False(t, commitment.VerifyInSession(FramingV1, "keygen/round-1", []byte("ceremony 2")))
+	assert.False(t, commitment.VerifyInSession(FramingV1, "signing/round-1", sessionID))
+	assert.False(t, commitment.Verify())
+	pass, _ = commitment.DeCommitInSession(FramingV1, "keygen/round-1", nil)
+	assert.False(t, pass)
+
+	// without a domain and session ID it is a plain commitment
+	r := big.NewInt(42)
+	assert.Equal(t, 0, NewHashCommitmentWithRandomnessInSession(SHA512_256, FramingV1, "", nil, r, one).C.Cmp(
+		NewHashCommitmentWithRandomness(r, one).C))
+}
+
+func TestFraming(t *testing.T) {
+	r := big.NewInt(42)
+	// (0x01, 0x022403) and (0x012402, 0x03) frame to the same bytes in the legacy framing
+	a := []*big.Int{big.NewInt(0x01), big.NewInt(0x022403)}
+	b := []*big.Int{big.NewInt(0x012402), big.NewInt(0x03)}
+
+	commitment := NewHashCommitmentWithRandomness(r, a...)
+	f, err := commitment.Framing()
+	assert.NoError(t, err)
+	assert.Equal(t, FramingV1, f)
+	assert.True(t, commitment.Verify())
+	assert.False(t, (&HashCommitDecommit{C: commitment.C, D: append([]*big.Int{r}, b...)}).Verify())
+
+	sessionID := []byte("ceremony 1")
+	legacy := NewHashCommitmentWithRandomnessInSession(SHA512_256, FramingLegacy, "keygen/round-1", sessionID, r, a...)
+	f, err = legacy.Framing()
+	assert.NoError(t, err)
+	assert.Equal(t, FramingLegacy, f)
+	forged := &HashCommitDecommit{C: legacy.C, D: append([]*big.Int{r}, b...)}
+	assert.True(t, forged.VerifyInSession(FramingLegacy, "keygen/round-1", sessionID))
+	assert.True(t, commitment.VerifyInSession(FramingLegacy, "", nil), "the framing v1 is accepted with the legacy framing enabled")
+
+	// the legacy commitments are rejected unless the verifier enables the legacy framing
+	assert.False(t, legacy.VerifyInSession(FramingV1, "keygen/round-1", sessionID))
+	pass, _ := legacy.DeCommitInSession(FramingV1, "keygen/round-1", sessionID)
+	assert.False(t, pass)
+	pass, _ = legacy.DeCommitInSession(FramingLegacy, "keygen/round-1", sessionID)
+	assert.True(t, pass)
+	assert.Panics(t, func() { NewHashCommitmentInSession(SHA512_256, Framing(200), "keygen/round-1", sessionID, r) })
+
+	// a nil secret never verifies
+	assert.False(t, (&HashCommitDecommit{C: commitment.C, D: []*big.Int{r, nil, a[1]}}).Verify())
+}
+
+// the golden vectors of the framing v1, with and without a session; they must never change, as the parties of other
+// versions open the same commitments
+func TestFramingGolden(t *testing.T) {
+	r, one := big.NewInt(42), big.NewInt(1)
+	assert.Equal(t, "1007da84dbd34cace8a4fe5fc6f24aaea5c25c7e08a0c6aae2bd6d77837c1cded5a",
+		NewHashCommitmentWithRandomness(r, one).C.Text(16))
+	assert.Equal(t, "100f6080c66821fcbe17bb006878c9bfead925550ee1060c916021ec2e37ff173fd",
+		NewHashCommitmentWithRandomnessInSession(SHA512_256, FramingV1, "keygen/round-1", []byte("ceremony 1"), r, one).C.Text(16))
+}
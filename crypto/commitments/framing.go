@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package commitments
+
+import (
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// The legacy framing of the values of a commitment is that of common.SHA512_256i: their count, then the bytes of each
+// followed by a '$' delimiter. As the values may contain the delimiter themselves, two different lists of values can
+// frame to the same bytes, e.g. (0x01, 0x02 0x24 0x03) and (0x01 0x24 0x02, 0x03), and a party could open its commitment
+// to other values than those it committed to. The framing v1 prefixes a version string and the count of the values,
+// and each value with its sign and byte length, so that the framed bytes determine the values.
+//
+// Commitments carry their framing in their tag (see hash_function.go). The commitments made in a session use the framing
+// they are given, and those of the legacy framing open only if the framing given to open them is legacy too, which
+// tss.Parameters.SetLegacyFraming selects for the ceremonies with parties that run older versions. The commitments
+// outside of a session always use v1.
+
+type (
+	// Framing is the encoding of the values of a commitment into the bytes that are hashed
+	Framing byte
+)
+
+const (
+	// FramingLegacy is the ambiguous framing of the older versions, with a delimiter
+	FramingLegacy Framing = iota
+	// FramingV1 is the length-prefixed framing
+	FramingV1
+
+	framingCount
+)
+
+var (
+	framingV1Prefix = []byte("tss-lib/commitment/v1")
+)
+
+func (f Framing) String() string {
+	switch f {
+	case FramingLegacy:
+		return "legacy"
+	case FramingV1:
+		return "v1"
+	default:
+		return fmt.Sprintf("Framing(%d)", f)
+	}
+}
+
+func (f Framing) validate() error {
+	if framingCount <= f {
+		return fmt.Errorf("commitments: unknown framing %d", f)
+	}
+	return nil
+}
+
+// accepts reports whether a party whose commitments are of the framing `f` opens the commitments of the framing `other`
+func (f Framing) accepts(other Framing) bool {
+	return other == FramingV1 || (other == FramingLegacy && f == FramingLegacy)
+}
+
+// hashFramedV1 returns the digest of the values in the framing v1: the prefix, then the canonical encoding of the list
+// of values (see common.EncodeInts), i.e.
+// prefix || uint64(count) || for each value: sign byte || uint64(byte length) || bytes, with big endian integers.
+// It returns nil if there are no values or one of them is nil.
+func hashFramedV1(state hash.Hash, parts []*big.Int) *big.Int {
+	if len(parts) == 0 {
+		return nil
+	}
+	data := common.EncodeInts(parts...)
+	if data == nil {
+		return nil
+	}
+	state.Write(framingV1Prefix)
+	state.Write(data)
+	return new(big.Int).SetBytes(state.Sum(nil))
+}
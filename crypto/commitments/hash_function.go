@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package commitments
+
+import (
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// The hash function of a commitment is tagged in the commitment itself, above its 256-bit digest: C = tag*2^256 + H,
+// with the framing of the hashed values in the bits of the tag above the function, see Framing. The tag of the default
+// SHA-512/256 with the legacy framing is 0, so those commitments are the plain digests that older versions produce, and
+// a party verifies the commitments of its peers whichever of the functions they use.
+
+type (
+	// HashFunction selects the hash of a HashCommitment, see tss.Parameters.SetCommitmentHash
+	HashFunction byte
+)
+
+const (
+	// SHA512_256 is the default
+	SHA512_256 HashFunction = iota
+	SHA256
+	SHA3_256
+	BLAKE2b_256
+
+	hashFunctionCount
+
+	// the bits of the tag below the framing
+	hashFunctionTagBits = 8
+)
+
+// Validate returns an error if `h` is not one of the supported hash functions
+func (h HashFunction) Validate() error {
+	if hashFunctionCount <= h {
+		return fmt.Errorf("commitments: unknown hash function %d", h)
+	}
+	return nil
+}
+
+func (h HashFunction) String() string {
+	switch h {
+	case SHA512_256:
+		return "SHA-512/256"
+	case SHA256:
+		return "SHA-256"
+	case SHA3_256:
+		return "SHA3-256"
+	case BLAKE2b_256:
+		return "BLAKE2b-256"
+	default:
+		return fmt.Sprintf("HashFunction(%d)", h)
+	}
+}
+
+func (h HashFunction) new() hash.Hash {
+	switch h {
+	case SHA256:
+		return crypto.SHA256.New()
+	case SHA3_256:
+		return sha3.New256()
+	case BLAKE2b_256:
+		h, _ := blake2b.New256(nil) // only a key longer than 64 bytes is an error
+		return h
+	default:
+		return crypto.SHA512_256.New()
+	}
+}
+
+// hashTagged returns the tagged commitment to `parts` with `h` and the framing `f`, or nil if `f` cannot hash them
+func (h HashFunction) hashTagged(f Framing, parts ...*big.Int) *big.Int {
+	var digest *big.Int
+	switch f {
+	case FramingLegacy:
+		digest = common.HashInts(h.new(), parts...)
+	case FramingV1:
+		digest = hashFramedV1(h.new(), parts)
+	}
+	if digest == nil {
+		return nil
+	}
+	tag := int64(f)<<hashFunctionTagBits | int64(h)
+	return digest.Or(digest, new(big.Int).Lsh(big.NewInt(tag), HashLength))
+}
+
+// hashFunctionOf returns the hash function and the framing tagged in the commitment `C`
+func hashFunctionOf(C *big.Int) (HashFunction, Framing, error) {
+	tag := new(big.Int).Rsh(C, HashLength)
+	if !tag.IsInt64() {
+		return 0, 0, fmt.Errorf("commitments: unknown hash function tag %v", tag)
+	}
+	h := HashFunction(tag.Int64() & (1<<hashFunctionTagBits - 1))
+	f := Framing(tag.Int64() >> hashFunctionTagBits)
+	if h.Validate() != nil || f.validate() != nil {
+		return 0, 0, fmt.Errorf("commitments: unknown hash function tag %v", tag)
+	}
+	return h, f, nil
+}
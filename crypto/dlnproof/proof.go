@@ -12,57 +12,96 @@
 package dlnproof
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/binance-chain/tss-lib/common"
 	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 )
 
-const Iterations = 128
+const (
+	// Iterations is the number of iterations of the proofs made by NewDLNProof, which is also the minimum that Verify and
+	// BatchVerify accept. Each iteration has a binary challenge, so a cheating prover succeeds with probability
+	// 2^-Iterations.
+	Iterations = 128
+
+	// the bits of the random exponents that combine the iterations in BatchVerify
+	batchExponentBits = 128
+)
 
 type (
 	Proof struct {
 		Alpha,
-		T [Iterations]*big.Int
+		T []*big.Int
 	}
 )
 
 func NewDLNProof(h1, h2, x, p, q, N *big.Int) *Proof {
+	return NewDLNProofInTranscript(nil, h1, h2, x, p, q, N)
+}
+
+// NewDLNProofInTranscript is NewDLNProof with the challenge drawn from a zkp.Transcript in the context `ctx`, e.g. one
+// made with zkp.NewSessionTranscript, which is left as it is. The proof verifies with VerifyInTranscript in that
+// context only.
+func NewDLNProofInTranscript(ctx *zkp.Transcript, h1, h2, x, p, q, N *big.Int) *Proof {
+	pf, _ := newDLNProof(ctx, h1, h2, x, p, q, N, Iterations)
+	return pf
+}
+
+// NewDLNProofWithIterations constructs a proof that h2 = h1^x mod N with the given number of iterations. The verifier
+// must ask for at least as many with VerifyWithIterations, since Verify only requires Iterations.
+func NewDLNProofWithIterations(h1, h2, x, p, q, N *big.Int, iterations int) (*Proof, error) {
+	return newDLNProof(nil, h1, h2, x, p, q, N, iterations)
+}
+
+func newDLNProof(ctx *zkp.Transcript, h1, h2, x, p, q, N *big.Int, iterations int) (*Proof, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("NewDLNProofWithIterations: iterations must be positive, got %d", iterations)
+	}
 	pMulQ := new(big.Int).Mul(p, q)
 	modN, modPQ := common.ModInt(N), common.ModInt(pMulQ)
-	a := make([]*big.Int, Iterations)
-	alpha := [Iterations]*big.Int{}
+	a := make([]*big.Int, iterations)
+	alpha := make([]*big.Int, iterations)
 	for i := range alpha {
 		a[i] = common.GetRandomPositiveInt(pMulQ)
 		alpha[i] = modN.Exp(h1, a[i])
 	}
-	msg := append([]*big.Int{h1, h2, N}, alpha[:]...)
-	c := common.SHA512_256i(msg...)
-	t := [Iterations]*big.Int{}
+	c := challenge(ctx, h1, h2, N, alpha)
+	t := make([]*big.Int, iterations)
 	cIBI := new(big.Int)
 	for i := range t {
-		cI := c.Bit(i)
-		cIBI = cIBI.SetInt64(int64(cI))
+		cIBI = cIBI.SetInt64(int64(c.bit(i)))
 		t[i] = modPQ.Add(a[i], modPQ.Mul(cIBI, x))
 	}
-	return &Proof{alpha, t}
+	return &Proof{alpha, t}, nil
 }
 
+// Verify checks the proof that h2 = h1^x mod N, which must have at least Iterations iterations
 func (p *Proof) Verify(h1, h2, N *big.Int) bool {
-	if p == nil {
+	return p.verify(nil, h1, h2, N, Iterations)
+}
+
+// VerifyInTranscript is Verify for a proof made with NewDLNProofInTranscript in the context `ctx`
+func (p *Proof) VerifyInTranscript(ctx *zkp.Transcript, h1, h2, N *big.Int) bool {
+	return p.verify(ctx, h1, h2, N, Iterations)
+}
+
+// VerifyWithIterations checks the proof that h2 = h1^x mod N, which must have at least `minIterations` iterations
+func (p *Proof) VerifyWithIterations(h1, h2, N *big.Int, minIterations int) bool {
+	return p.verify(nil, h1, h2, N, minIterations)
+}
+
+func (p *Proof) verify(ctx *zkp.Transcript, h1, h2, N *big.Int, minIterations int) bool {
+	if !p.validate(h1, h2, N, minIterations) {
 		return false
 	}
 	modN := common.ModInt(N)
-	msg := append([]*big.Int{h1, h2, N}, p.Alpha[:]...)
-	c := common.SHA512_256i(msg...)
+	c := challenge(ctx, h1, h2, N, p.Alpha)
 	cIBI := new(big.Int)
-	for i := 0; i < Iterations; i++ {
-		if p.Alpha[i] == nil || p.T[i] == nil {
-			return false
-		}
-		cI := c.Bit(i)
-		cIBI = cIBI.SetInt64(int64(cI))
+	for i := range p.Alpha {
+		cIBI = cIBI.SetInt64(int64(c.bit(i)))
 		h1ExpTi := modN.Exp(h1, p.T[i])
 		h2ExpCi := modN.Exp(h2, cIBI)
 		alphaIMulH2ExpCi := modN.Mul(p.Alpha[i], h2ExpCi)
@@ -73,10 +112,65 @@ func (p *Proof) Verify(h1, h2, N *big.Int) bool {
 	return true
 }
 
+// BatchVerify checks the proof like Verify, but combines the iterations with random exponents r_i into the single
+// equation h1^(sum r_i*t_i) = prod alpha_i^r_i * h2^(sum r_i*c_i), which costs a fraction of Verify.
+//
+// It is sound only up to elements of small order: if the equations of the iterations hold up to factors such as -1
+// mod N, the combined equation holds as well whenever the r_i cancel those factors out. A proof that BatchVerify accepts
+// thus shows that h2 = ±h1^x, which suffices for the ring-Pedersen parameters but not where the exact relation matters;
+// use Verify there.
+func (p *Proof) BatchVerify(h1, h2, N *big.Int) bool {
+	return p.BatchVerifyWithIterations(h1, h2, N, Iterations)
+}
+
+// BatchVerifyWithIterations is BatchVerify for a proof that must have at least `minIterations` iterations
+func (p *Proof) BatchVerifyWithIterations(h1, h2, N *big.Int, minIterations int) bool {
+	if !p.validate(h1, h2, N, minIterations) {
+		return false
+	}
+	modN := common.ModInt(N)
+	c := challenge(nil, h1, h2, N, p.Alpha)
+	rBound := new(big.Int).Lsh(big.NewInt(1), batchExponentBits)
+	sumRT, sumRC := new(big.Int), new(big.Int)
+	alphaExpR := big.NewInt(1)
+	for i := range p.Alpha {
+		r := common.GetRandomPositiveInt(rBound)
+		sumRT.Add(sumRT, new(big.Int).Mul(r, p.T[i]))
+		if c.bit(i) == 1 {
+			sumRC.Add(sumRC, r)
+		}
+		alphaExpR = modN.Mul(alphaExpR, modN.Exp(p.Alpha[i], r))
+	}
+	left := modN.Exp(h1, sumRT)
+	right := modN.Mul(alphaExpR, modN.Exp(h2, sumRC))
+	return left.Cmp(right) == 0
+}
+
+func (p *Proof) validate(h1, h2, N *big.Int, minIterations int) bool {
+	if p == nil || h1 == nil || h2 == nil || N == nil || N.Sign() != 1 {
+		return false
+	}
+	if len(p.Alpha) < minIterations || len(p.Alpha) < 1 || len(p.Alpha) != len(p.T) {
+		return false
+	}
+	for i := range p.Alpha {
+		if p.Alpha[i] == nil || p.T[i] == nil {
+			return false
+		}
+		if p.Alpha[i].Sign() != 1 || p.Alpha[i].Cmp(N) != -1 || p.T[i].Sign() == -1 {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *Proof) Serialize() ([][]byte, error) {
+	if p == nil {
+		return nil, errors.New("Serialize() called on a nil proof")
+	}
 	cb := cmts.NewBuilder()
-	cb = cb.AddPart(p.Alpha[:])
-	cb = cb.AddPart(p.T[:])
+	cb = cb.AddPart(p.Alpha)
+	cb = cb.AddPart(p.T)
 	ints, err := cb.Secrets()
 	if err != nil {
 		return nil, err
@@ -92,6 +186,7 @@ func (p *Proof) Serialize() ([][]byte, error) {
 	return bzs, nil
 }
 
+// UnmarshalDLNProof parses a proof of any number of iterations; the verifier enforces the minimum
 func UnmarshalDLNProof(bzs [][]byte) (*Proof, error) {
 	bis := make([]*big.Int, len(bzs))
 	for i := range bis {
@@ -104,12 +199,25 @@ func UnmarshalDLNProof(bzs [][]byte) (*Proof, error) {
 	if len(parsed) != 2 {
 		return nil, fmt.Errorf("UnmarshalDLNProof expected %d parts but got %d", 2, len(parsed))
 	}
-	pf := new(Proof)
-	if len1 := copy(pf.Alpha[:], parsed[0]); len1 != Iterations {
-		return nil, fmt.Errorf("UnmarshalDLNProof expected %d but copied %d", Iterations, len1)
-	}
-	if len2 := copy(pf.T[:], parsed[1]); len2 != Iterations {
-		return nil, fmt.Errorf("UnmarshalDLNProof expected %d but copied %d", Iterations, len2)
+	if len(parsed[0]) != len(parsed[1]) {
+		return nil, fmt.Errorf("UnmarshalDLNProof expected as many Alpha as T but got %d and %d", len(parsed[0]), len(parsed[1]))
 	}
-	return pf, nil
+	return &Proof{Alpha: parsed[0], T: parsed[1]}, nil
+}
+
+// ----- //
+
+// challengeBits are the binary challenges of the iterations, one bit each, drawn from the transcript of the proof after
+// the statement and the commitments alpha
+type challengeBits []byte
+
+func challenge(ctx *zkp.Transcript, h1, h2, N *big.Int, alpha []*big.Int) challengeBits {
+	tr := zkp.ForProof(ctx, "dln")
+	tr.AppendInts("statement", h1, h2, N)
+	tr.AppendInts("commitment", alpha...)
+	return tr.ChallengeBytes("challenge", (len(alpha)+7)/8)
+}
+
+func (c challengeBits) bit(i int) uint {
+	return uint(c[i/8]>>uint(i%8)) & 1
 }
@@ -0,0 +1,118 @@
+// Copyright © 2019-2020 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package dlnproof_test
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+const (
+	testSafePrimeBits = 512
+)
+
+type setup struct {
+	h1, h2, alpha, p, q, N *big.Int
+}
+
+func newSetup(t *testing.T) *setup {
+	sgps, err := common.GetRandomSafePrimesConcurrent(testSafePrimeBits, 2, 5*time.Minute, runtime.NumCPU())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	P, Q := sgps[0].SafePrime(), sgps[1].SafePrime()
+	N := new(big.Int).Mul(P, Q)
+	modN := common.ModInt(N)
+	f1 := common.GetRandomPositiveRelativelyPrimeInt(N)
+	alpha := common.GetRandomPositiveRelativelyPrimeInt(N)
+	h1 := modN.Mul(f1, f1)
+	h2 := modN.Exp(h1, alpha)
+	return &setup{h1: h1, h2: h2, alpha: alpha, p: sgps[0].Prime(), q: sgps[1].Prime(), N: N}
+}
+
+func TestVerify(t *testing.T) {
+	s := newSetup(t)
+	pf := NewDLNProof(s.h1, s.h2, s.alpha, s.p, s.q, s.N)
+	assert.Len(t, pf.Alpha, Iterations)
+	assert.True(t, pf.Verify(s.h1, s.h2, s.N))
+	assert.True(t, pf.BatchVerify(s.h1, s.h2, s.N))
+
+	// the other way around, or with a wrong witness, does not verify
+	assert.False(t, pf.Verify(s.h2, s.h1, s.N))
+	assert.False(t, pf.BatchVerify(s.h2, s.h1, s.N))
+	bad := NewDLNProof(s.h1, s.h2, new(big.Int).Add(s.alpha, big.NewInt(1)), s.p, s.q, s.N)
+	assert.False(t, bad.Verify(s.h1, s.h2, s.N))
+	assert.False(t, bad.BatchVerify(s.h1, s.h2, s.N))
+
+	// through its bytes
+	bzs, err := pf.Serialize()
+	assert.NoError(t, err)
+	assert.Len(t, bzs, 2+(Iterations*2))
+	pf2, err := UnmarshalDLNProof(bzs)
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(s.h1, s.h2, s.N))
+}
+
+func TestVerifyInTranscript(t *testing.T) {
+	s := newSetup(t)
+	ctx := zkp.NewSessionTranscript("keygen", []byte("session 1"), 1)
+	pf := NewDLNProofInTranscript(ctx, s.h1, s.h2, s.alpha, s.p, s.q, s.N)
+	assert.True(t, pf.VerifyInTranscript(ctx, s.h1, s.h2, s.N))
+	assert.False(t, pf.VerifyInTranscript(zkp.NewSessionTranscript("keygen", []byte("session 2"), 1), s.h1, s.h2, s.N))
+	assert.False(t, pf.Verify(s.h1, s.h2, s.N))
+}
+
+func TestIterations(t *testing.T) {
+	s := newSetup(t)
+	_, err := NewDLNProofWithIterations(s.h1, s.h2, s.alpha, s.p, s.q, s.N, 0)
+	assert.Error(t, err)
+
+	// more than the 256 challenge bits of a single SHA-512/256 block
+	pf, err := NewDLNProofWithIterations(s.h1, s.h2, s.alpha, s.p, s.q, s.N, 300)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(s.h1, s.h2, s.N))
+	assert.True(t, pf.VerifyWithIterations(s.h1, s.h2, s.N, 300))
+	assert.True(t, pf.BatchVerifyWithIterations(s.h1, s.h2, s.N, 300))
+	assert.False(t, pf.VerifyWithIterations(s.h1, s.h2, s.N, 301))
+
+	// too few iterations for the default minimum
+	few, err := NewDLNProofWithIterations(s.h1, s.h2, s.alpha, s.p, s.q, s.N, Iterations/2)
+	assert.NoError(t, err)
+	assert.False(t, few.Verify(s.h1, s.h2, s.N))
+	assert.False(t, few.BatchVerify(s.h1, s.h2, s.N))
+	assert.True(t, few.VerifyWithIterations(s.h1, s.h2, s.N, Iterations/2))
+	bzs, err := few.Serialize()
+	assert.NoError(t, err)
+	parsed, err := UnmarshalDLNProof(bzs)
+	assert.NoError(t, err)
+	assert.False(t, parsed.Verify(s.h1, s.h2, s.N))
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	s := newSetup(t)
+	pf := NewDLNProof(s.h1, s.h2, s.alpha, s.p, s.q, s.N)
+
+	var nilPf *Proof
+	assert.False(t, nilPf.Verify(s.h1, s.h2, s.N))
+	assert.False(t, nilPf.BatchVerify(s.h1, s.h2, s.N))
+	assert.False(t, new(Proof).Verify(s.h1, s.h2, s.N))
+
+	mismatched := &Proof{Alpha: pf.Alpha, T: pf.T[1:]}
+	assert.False(t, mismatched.VerifyWithIterations(s.h1, s.h2, s.N, 1))
+	zeroAlpha := &Proof{Alpha: append([]*big.Int{big.NewInt(0)}, pf.Alpha[1:]...), T: pf.T}
+	assert.False(t, zeroAlpha.BatchVerify(s.h1, s.h2, s.N))
+	negT := &Proof{Alpha: pf.Alpha, T: append([]*big.Int{big.NewInt(-1)}, pf.T[1:]...)}
+	assert.False(t, negT.BatchVerify(s.h1, s.h2, s.N))
+}
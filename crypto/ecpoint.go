@@ -8,6 +8,7 @@ package crypto
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"crypto/elliptic"
 	"encoding/binary"
 	"encoding/json"
@@ -52,6 +53,10 @@ func (p *ECPoint) Add(p1 *ECPoint) (*ECPoint, error) {
 }
 
 func (p *ECPoint) ScalarMult(k *big.Int) *ECPoint {
+	if wc := genericCurveOf(p.curve); wc != nil {
+		// the curves multiply by the bytes of k, i.e. by |k|
+		return wc.scalarMultWNAF(p, new(big.Int).SetBytes(k.Bytes()))
+	}
 	x, y := p.curve.ScalarMult(p.X(), p.Y(), k.Bytes())
 	newP, _ := NewECPoint(p.curve, x, y) // it must be on the curve, no need to check.
 	return newP
@@ -68,6 +73,11 @@ func (p *ECPoint) Equals(p2 *ECPoint) bool {
 	return p.X().Cmp(p2.X()) == 0 && p.Y().Cmp(p2.Y()) == 0
 }
 
+// Curve returns the curve of the point
+func (p *ECPoint) Curve() elliptic.Curve {
+	return p.curve
+}
+
 func (p *ECPoint) SetCurve(curve elliptic.Curve) *ECPoint {
 	p.curve = curve
 	return p
@@ -77,7 +87,19 @@ func (p *ECPoint) ValidateBasic() bool {
 	return p != nil && p.coords[0] != nil && p.coords[1] != nil && p.IsOnCurve()
 }
 
+// ToECDSAPubKey returns the point as a standard library ECDSA public key on the point's curve
+func (p *ECPoint) ToECDSAPubKey() *ecdsa.PublicKey {
+	return &ecdsa.PublicKey{
+		Curve: p.curve,
+		X:     p.X(),
+		Y:     p.Y(),
+	}
+}
+
 func ScalarBaseMult(curve elliptic.Curve, k *big.Int) *ECPoint {
+	if genericCurveOf(curve) != nil {
+		return GeneratorPrecomputation(curve).ScalarMult(new(big.Int).SetBytes(k.Bytes()))
+	}
 	x, y := curve.ScalarBaseMult(k.Bytes())
 	p, _ := NewECPoint(curve, x, y) // it must be on the curve, no need to check.
 	return p
@@ -131,10 +153,115 @@ func UnFlattenECPoints(curve elliptic.Curve, in []*big.Int, noCurveCheck ...bool
 	return unFlat, nil
 }
 
+// CompressECPoints returns the compressed SEC 1 encodings of the points, one part per point, which is about half the
+// size of FlattenECPoints on the wire
+func CompressECPoints(in []*ECPoint) ([][]byte, error) {
+	if in == nil {
+		return nil, errors.New("CompressECPoints encountered a nil in slice")
+	}
+	bzs := make([][]byte, len(in))
+	for i, point := range in {
+		if point == nil || point.coords[0] == nil || point.coords[1] == nil {
+			return nil, errors.New("CompressECPoints found nil point/coordinate")
+		}
+		bzs[i] = point.CompressedBytes()
+	}
+	return bzs, nil
+}
+
+// DecompressECPoints decodes the compressed SEC 1 encodings of CompressECPoints, each of which must be a point of the
+// curve
+func DecompressECPoints(curve elliptic.Curve, bzs [][]byte) ([]*ECPoint, error) {
+	if bzs == nil {
+		return nil, errors.New("DecompressECPoints() received a nil slice")
+	}
+	byteLen := (curve.Params().BitSize + 7) / 8
+	points := make([]*ECPoint, len(bzs))
+	for i, bz := range bzs {
+		if len(bz) != 1+byteLen {
+			return nil, fmt.Errorf("DecompressECPoints(): part %d is not a compressed point", i)
+		}
+		point, err := NewECPointFromBytes(curve, bz)
+		if err != nil {
+			return nil, fmt.Errorf("DecompressECPoints(): part %d: %v", i, err)
+		}
+		points[i] = point
+	}
+	return points, nil
+}
+
+// ----- //
+// SEC 1 encodings, as used by X.509, TLS and JOSE for the NIST curves and by Bitcoin for secp256k1.
+
+// Bytes returns the uncompressed SEC 1 encoding 0x04 || X || Y of the point, with the coordinates padded to the
+// byte length of the field
+func (p *ECPoint) Bytes() []byte {
+	byteLen := (p.curve.Params().BitSize + 7) / 8
+	bz := make([]byte, 1+2*byteLen)
+	bz[0] = 4
+	xBz, yBz := p.coords[0].Bytes(), p.coords[1].Bytes()
+	copy(bz[1+byteLen-len(xBz):], xBz)
+	copy(bz[1+2*byteLen-len(yBz):], yBz)
+	return bz
+}
+
+// CompressedBytes returns the compressed SEC 1 encoding 0x02 || X or 0x03 || X of the point, by the parity of Y
+func (p *ECPoint) CompressedBytes() []byte {
+	byteLen := (p.curve.Params().BitSize + 7) / 8
+	bz := make([]byte, 1+byteLen)
+	bz[0] = byte(2 + p.coords[1].Bit(0))
+	xBz := p.coords[0].Bytes()
+	copy(bz[1+byteLen-len(xBz):], xBz)
+	return bz
+}
+
+// NewECPointFromBytes decodes a point in either SEC 1 encoding and checks that it is on the elliptic curve
+func NewECPointFromBytes(curve elliptic.Curve, bz []byte) (*ECPoint, error) {
+	byteLen := (curve.Params().BitSize + 7) / 8
+	switch {
+	case len(bz) == 1+2*byteLen && bz[0] == 4:
+		return NewECPoint(curve, new(big.Int).SetBytes(bz[1:1+byteLen]), new(big.Int).SetBytes(bz[1+byteLen:]))
+	case len(bz) == 1+byteLen && (bz[0] == 2 || bz[0] == 3):
+		return LiftX(curve, new(big.Int).SetBytes(bz[1:]), bz[0] == 3)
+	}
+	return nil, errors.New("NewECPointFromBytes: the encoding is not a SEC 1 point of the curve")
+}
+
+// LiftX returns the point with the x coordinate `x` and the y with the parity `odd`, if there is one
+func LiftX(curve elliptic.Curve, x *big.Int, odd bool) (*ECPoint, error) {
+	params := curve.Params()
+	P := params.P
+	if x.Sign() < 0 || x.Cmp(P) >= 0 {
+		return nil, errors.New("LiftX: x is out of range")
+	}
+	// elliptic.CurveParams does not carry the coefficient a (it is 0 for secp256k1, -3 for the NIST curves and 1 for
+	// the STARK curve), so it is recovered from the generator: a = (Gy^2 - Gx^3 - b) / Gx
+	a := shortWeierstrassA(curve)
+	if a == nil {
+		return nil, errors.New("LiftX: the curve is not a short Weierstrass one")
+	}
+
+	// y^2 = x^3 + a*x + b
+	y2 := new(big.Int).Mul(x, x)
+	y2.Add(y2, a).Mul(y2, x).Add(y2, params.B).Mod(y2, P)
+	y := new(big.Int).ModSqrt(y2, P)
+	if y == nil {
+		return nil, errors.New("LiftX: x is not on the curve")
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(P, y)
+	}
+	return NewECPoint(curve, x, y)
+}
+
 // ----- //
 // Gob helpers for if you choose to encode messages with Gob.
 
 func (p *ECPoint) GobEncode() ([]byte, error) {
+	name, ok := tss.GetCurveName(p.curve)
+	if !ok {
+		return nil, errors.New("ECPoint.GobEncode: the curve of the point is not registered")
+	}
 	buf := &bytes.Buffer{}
 	x, err := p.coords[0].GobEncode()
 	if err != nil {
@@ -155,6 +282,12 @@ func (p *ECPoint) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 	buf.Write(y)
+	// the name of the curve trails the coordinates
+	err = binary.Write(buf, binary.LittleEndian, uint32(len(name)))
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString(name)
 
 	return buf.Bytes(), nil
 }
@@ -187,36 +320,124 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 	if err := Y.GobDecode(y); err != nil {
 		return err
 	}
-	p.curve = tss.EC()
+	// points of older versions have no curve; there is no default curve to take them to be on
+	if reader.Len() == 0 {
+		return errors.New("ECPoint.GobDecode: the point has no curve")
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return err
+	}
+	name := make([]byte, length)
+	n, err = reader.Read(name)
+	if n != int(length) || err != nil {
+		return fmt.Errorf("gob decode failed: %v", err)
+	}
+	curve, ok := tss.GetCurveByName(string(name))
+	if !ok {
+		return fmt.Errorf("ECPoint.GobDecode: the curve %q is not registered", name)
+	}
+	p.curve = curve
 	p.coords = [2]*big.Int{X, Y}
 	if !p.IsOnCurve() {
-		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
+		return errors.New("ECPoint.GobDecode: the point is not on the elliptic curve")
 	}
 	return nil
 }
 
 // ----- //
 
-// crypto.ECPoint is not inherently json marshal-able
+// crypto.ECPoint is not inherently json marshal-able. A point of a registered curve is saved with the name of the
+// curve (tss.GetCurveName), and in its compressed SEC 1 encoding when the curve is a short Weierstrass one, which
+// roughly halves the size of the save data. The other points are saved by their coordinates. Points of unregistered
+// curves are not marshalled, and UnmarshalJSON rejects a point without the name of its curve; tag those of the save
+// data of older versions with TagECPointsJSON.
 func (p *ECPoint) MarshalJSON() ([]byte, error) {
+	name, registered := tss.GetCurveName(p.curve)
+	if !registered {
+		return nil, errors.New("ECPoint.MarshalJSON: the curve of the point is not registered")
+	}
+	if shortWeierstrassA(p.curve) != nil {
+		return json.Marshal(&struct {
+			Curve      string
+			Compressed []byte
+		}{
+			Curve:      name,
+			Compressed: p.CompressedBytes(),
+		})
+	}
 	return json.Marshal(&struct {
+		Curve  string
 		Coords [2]*big.Int
 	}{
+		Curve:  name,
 		Coords: p.coords,
 	})
 }
 
 func (p *ECPoint) UnmarshalJSON(payload []byte) error {
 	aux := &struct {
-		Coords [2]*big.Int
+		Curve      string
+		Coords     [2]*big.Int
+		Compressed []byte
 	}{}
 	if err := json.Unmarshal(payload, &aux); err != nil {
 		return err
 	}
-	p.curve = tss.EC()
+	if aux.Curve == "" {
+		return errors.New("ECPoint.UnmarshalJSON: the point has no curve, tag it with TagECPointsJSON")
+	}
+	curve, ok := tss.GetCurveByName(aux.Curve)
+	if !ok {
+		return fmt.Errorf("ECPoint.UnmarshalJSON: the curve %q is not registered", aux.Curve)
+	}
+	if aux.Compressed != nil {
+		point, err := DecompressECPoints(curve, [][]byte{aux.Compressed})
+		if err != nil {
+			return fmt.Errorf("ECPoint.UnmarshalJSON: %v", err)
+		}
+		*p = *point[0]
+		return nil
+	}
+	p.curve = curve
 	p.coords = [2]*big.Int{aux.Coords[0], aux.Coords[1]}
 	if !p.IsOnCurve() {
 		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
 	}
 	return nil
 }
+
+// TagECPointsJSON returns the JSON `payload`, e.g. the save data of an older version, with the name of `curve` added
+// to each point saved without the name of its curve, so that UnmarshalJSON accepts them
+func TagECPointsJSON(payload []byte, curve elliptic.Curve) ([]byte, error) {
+	name, ok := tss.GetCurveName(curve)
+	if !ok {
+		return nil, errors.New("TagECPointsJSON: the curve is not registered")
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber() // keep the big integers exact
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	tagECPoints(data, name)
+	return json.Marshal(data)
+}
+
+func tagECPoints(data interface{}, name string) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if _, isPoint := v["Coords"]; isPoint {
+			if curve, _ := v["Curve"].(string); curve == "" {
+				v["Curve"] = name
+			}
+			return
+		}
+		for _, field := range v {
+			tagECPoints(field, name)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			tagECPoints(elem, name)
+		}
+	}
+}
@@ -7,11 +7,17 @@
 package crypto_test
 
 import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/json"
 	"math/big"
 	"reflect"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	. "github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
@@ -27,24 +33,24 @@ func TestFlattenECPoints(t *testing.T) {
 	}{{
 		name: "flatten with 2 points (happy)",
 		args: args{[]*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(3), big.NewInt(4)),
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(1), big.NewInt(2)),
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(3), big.NewInt(4)),
 		}},
 		want: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)},
 	}, {
 		name: "flatten with nil point (expects err)",
 		args: args{[]*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(1), big.NewInt(2)),
 			nil,
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(3), big.NewInt(4))},
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(3), big.NewInt(4))},
 		},
 		want:    nil,
 		wantErr: true,
 	}, {
 		name: "flatten with nil coordinate (expects err)",
 		args: args{[]*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			NewECPointNoCurveCheck(tss.EC(), nil, big.NewInt(4))},
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(1), big.NewInt(2)),
+			NewECPointNoCurveCheck(tss.S256(), nil, big.NewInt(4))},
 		},
 		want:    nil,
 		wantErr: true,
@@ -81,8 +87,8 @@ func TestUnFlattenECPoints(t *testing.T) {
 		name: "un-flatten 2 points (happy)",
 		args: args{[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}},
 		want: []*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(3), big.NewInt(4)),
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(1), big.NewInt(2)),
+			NewECPointNoCurveCheck(tss.S256(), big.NewInt(3), big.NewInt(4)),
 		},
 	}, {
 		name:    "un-flatten uneven len(points) (expects err)",
@@ -102,7 +108,7 @@ func TestUnFlattenECPoints(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := UnFlattenECPoints(tss.EC(), tt.args.in, true)
+			got, err := UnFlattenECPoints(tss.S256(), tt.args.in, true)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UnFlattenECPoints() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -113,3 +119,203 @@ func TestUnFlattenECPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestECPointSEC1Encodings(t *testing.T) {
+	for _, curve := range []elliptic.Curve{btcec.S256(), elliptic.P256(), elliptic.P384()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			for k := int64(1); k <= 8; k++ {
+				x, y := curve.ScalarBaseMult(big.NewInt(k).Bytes())
+				p, err := NewECPoint(curve, x, y)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// the uncompressed encoding is the one of the standard library
+				if !bytes.Equal(p.Bytes(), elliptic.Marshal(curve, x, y)) {
+					t.Errorf("Bytes() = %x, want %x", p.Bytes(), elliptic.Marshal(curve, x, y))
+				}
+				for _, bz := range [][]byte{p.Bytes(), p.CompressedBytes()} {
+					got, err := NewECPointFromBytes(curve, bz)
+					if err != nil {
+						t.Fatalf("NewECPointFromBytes(%x) error = %v", bz, err)
+					}
+					if !got.Equals(p) {
+						t.Errorf("NewECPointFromBytes(%x) = %v, want %v", bz, got, p)
+					}
+				}
+			}
+			bad := NewECPointNoCurveCheck(curve, big.NewInt(1), big.NewInt(2)).Bytes()
+			if _, err := NewECPointFromBytes(curve, bad); err == nil {
+				t.Errorf("NewECPointFromBytes() accepted a point that is not on the curve")
+			}
+			if _, err := NewECPointFromBytes(curve, bad[:5]); err == nil {
+				t.Errorf("NewECPointFromBytes() accepted a truncated encoding")
+			}
+		})
+	}
+}
+
+func TestCompressECPoints(t *testing.T) {
+	points := make([]*ECPoint, 5)
+	for i := range points {
+		points[i] = ScalarBaseMult(tss.S256(), big.NewInt(int64(i+1)))
+	}
+	bzs, err := CompressECPoints(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecompressECPoints(tss.S256(), bzs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range points {
+		if len(bzs[i]) != 33 || !got[i].Equals(points[i]) {
+			t.Errorf("DecompressECPoints()[%d] = %v, want %v", i, got[i], points[i])
+		}
+	}
+	if _, err = CompressECPoints(append(points, nil)); err == nil {
+		t.Errorf("CompressECPoints() accepted a nil point")
+	}
+	// uncompressed, truncated and with an x out of the field
+	bad := NewECPointNoCurveCheck(tss.S256(), tss.S256().Params().P, big.NewInt(2)).CompressedBytes()
+	for _, bz := range [][]byte{points[0].Bytes(), bzs[0][:20], bad} {
+		if _, err = DecompressECPoints(tss.S256(), [][]byte{bzs[1], bz}); err == nil {
+			t.Errorf("DecompressECPoints(%x) accepted a bad encoding", bz)
+		}
+	}
+}
+
+func TestECPointJSON(t *testing.T) {
+	p := ScalarBaseMult(tss.S256(), big.NewInt(7))
+	bz, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(bz, []byte("Compressed")) {
+		t.Errorf("MarshalJSON() = %s, want the compressed encoding", bz)
+	}
+	legacy, err := json.Marshal(&struct{ Coords [2]*big.Int }{[2]*big.Int{p.X(), p.Y()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(legacy) <= len(bz) {
+		t.Errorf("MarshalJSON() = %d bytes, want fewer than the %d of the coordinates", len(bz), len(legacy))
+	}
+	got := new(ECPoint)
+	if err = json.Unmarshal(bz, got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equals(p) {
+		t.Errorf("UnmarshalJSON(%s) = %v, want %v", bz, got, p)
+	}
+	// there is no default curve for a point without the name of its curve
+	if err = json.Unmarshal(legacy, new(ECPoint)); err == nil {
+		t.Errorf("UnmarshalJSON(%s) accepted a point without a curve", legacy)
+	}
+	// the points of the other curves keep their coordinates
+	edP := ScalarBaseMult(ed448.Curve(), big.NewInt(7))
+	if bz, err = json.Marshal(edP); err != nil || !bytes.Contains(bz, []byte("Coords")) {
+		t.Errorf("MarshalJSON() = %s, %v, want the coordinates", bz, err)
+	}
+	if err = json.Unmarshal([]byte(`{"Curve":"secp256k1","Compressed":"AgE="}`), new(ECPoint)); err == nil {
+		t.Errorf("UnmarshalJSON() accepted a bad compressed point")
+	}
+	if _, err = json.Marshal(ScalarBaseMult(unregisteredCurve(), big.NewInt(7))); err == nil {
+		t.Errorf("MarshalJSON() accepted a point of an unregistered curve")
+	}
+}
+
+func TestTagECPointsJSON(t *testing.T) {
+	type saveData struct {
+		Xi    *big.Int
+		BigXj []*ECPoint
+		Pub   *ECPoint
+		Other *ECPoint
+	}
+	p, q := ScalarBaseMult(tss.S256(), big.NewInt(7)), ScalarBaseMult(tss.S256(), big.NewInt(8))
+	edP := ScalarBaseMult(ed448.Curve(), big.NewInt(7))
+	untagged := func(p *ECPoint) interface{} { return &struct{ Coords [2]*big.Int }{[2]*big.Int{p.X(), p.Y()}} }
+	xi, _ := new(big.Int).SetString("123456789012345678901234567890123456789012345678901234567890", 10)
+	legacy, err := json.Marshal(map[string]interface{}{
+		"Xi":    xi,
+		"BigXj": []interface{}{untagged(p), untagged(q)},
+		"Pub":   untagged(p),
+		"Other": edP,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(legacy, new(saveData)); err == nil {
+		t.Fatal("json.Unmarshal() accepted points without a curve")
+	}
+
+	tagged, err := TagECPointsJSON(legacy, tss.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(saveData)
+	if err = json.Unmarshal(tagged, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Xi.Cmp(xi) != 0 {
+		t.Errorf("Xi = %v, want %v", got.Xi, xi)
+	}
+	if len(got.BigXj) != 2 || !got.BigXj[0].Equals(p) || !got.BigXj[1].Equals(q) || !got.Pub.Equals(p) {
+		t.Errorf("TagECPointsJSON() = %s, want the points of secp256k1", tagged)
+	}
+	// a point that has a curve keeps it
+	if !got.Other.Equals(edP) {
+		t.Errorf("Other = %v, want %v", got.Other, edP)
+	}
+
+	// a point is only checked against the curve it is tagged with
+	wrong, err := TagECPointsJSON(legacy, ed448.Curve())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(wrong, new(saveData)); err == nil {
+		t.Errorf("json.Unmarshal() accepted points of secp256k1 tagged as ed448")
+	}
+	if _, err = TagECPointsJSON(legacy, unregisteredCurve()); err == nil {
+		t.Errorf("TagECPointsJSON() accepted an unregistered curve")
+	}
+}
+
+func TestECPointGob(t *testing.T) {
+	for _, p := range []*ECPoint{ScalarBaseMult(tss.S256(), big.NewInt(7)), ScalarBaseMult(ed448.Curve(), big.NewInt(7))} {
+		bz, err := p.GobEncode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := new(ECPoint)
+		if err = got.GobDecode(bz); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equals(p) {
+			t.Errorf("GobDecode() = %v, want %v", got, p)
+		}
+		gotName, _ := tss.GetCurveName(got.Curve())
+		if wantName, _ := tss.GetCurveName(p.Curve()); gotName != wantName {
+			t.Errorf("GobDecode() curve = %q, want %q", gotName, wantName)
+		}
+	}
+	// the encoding of older versions has no curve name, and there is no default curve to decode it on
+	p := ScalarBaseMult(tss.S256(), big.NewInt(7))
+	bz, err := p.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, _ := tss.GetCurveName(tss.S256())
+	if err = new(ECPoint).GobDecode(bz[:len(bz)-4-len(name)]); err == nil {
+		t.Errorf("GobDecode() accepted a point without a curve")
+	}
+	if _, err = ScalarBaseMult(unregisteredCurve(), big.NewInt(7)).GobEncode(); err == nil {
+		t.Errorf("GobEncode() accepted a point of an unregistered curve")
+	}
+}
+
+// unregisteredCurve returns a copy of P-256 under a name that is not in the curve registry
+func unregisteredCurve() elliptic.Curve {
+	params := *elliptic.P256().Params()
+	params.Name = "unregistered"
+	return &params
+}
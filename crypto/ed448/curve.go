@@ -0,0 +1,163 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package ed448 provides the Edwards curve x^2 + y^2 = 1 - 39081*x^2*y^2 of RFC 8032 (edwards448) as an
+// elliptic.Curve, so that it can be set with Parameters.SetCurve, and the encodings and Ed448 signatures of RFC 8032.
+// Edwards curves are not supported by the generic elliptic.CurveParams, which assumes a short Weierstrass curve.
+package ed448
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+)
+
+const (
+	// Name is the name of the curve in its params and in the tss curve registry
+	Name = "ed448"
+)
+
+type curve struct {
+	*elliptic.CurveParams
+	d *big.Int
+}
+
+var (
+	initOnce sync.Once
+	ed448    *curve
+
+	_ elliptic.Curve = (*curve)(nil)
+)
+
+func initEd448() {
+	// p = 2^448 - 2^224 - 1
+	p := new(big.Int).Lsh(big.NewInt(1), 448)
+	p.Sub(p, new(big.Int).Lsh(big.NewInt(1), 224)).Sub(p, big.NewInt(1))
+	// n = 2^446 - 13818066809895115352007386748515426880336692474882178609894547503885
+	n, _ := new(big.Int).SetString("13818066809895115352007386748515426880336692474882178609894547503885", 10)
+	n.Sub(new(big.Int).Lsh(big.NewInt(1), 446), n)
+	gx, _ := new(big.Int).SetString("224580040295924300187604334099896036246789641632564134246125461686950415467406032909029192869357953282578032075146446173674602635247710", 10)
+	gy, _ := new(big.Int).SetString("298819210078481492676017930443930673437544040154080242095928241372331506189835876003536878655418784733982303233503462500531545062832660", 10)
+	ed448 = &curve{
+		CurveParams: &elliptic.CurveParams{P: p, N: n, Gx: gx, Gy: gy, BitSize: 448, Name: Name},
+		d:           new(big.Int).Sub(p, big.NewInt(39081)),
+	}
+}
+
+// Curve returns edwards448. Like the generic elliptic.CurveParams its arithmetic is not constant time.
+// The group has the cofactor 4, and N is the order of the subgroup of the base point.
+func Curve() elliptic.Curve {
+	initOnce.Do(initEd448)
+	return ed448
+}
+
+func (c *curve) Params() *elliptic.CurveParams {
+	return c.CurveParams
+}
+
+func (c *curve) IsOnCurve(x, y *big.Int) bool {
+	P := c.P
+	if x.Sign() < 0 || x.Cmp(P) >= 0 || y.Sign() < 0 || y.Cmp(P) >= 0 {
+		return false
+	}
+	// x^2 + y^2 = 1 + d*x^2*y^2
+	x2 := new(big.Int).Mul(x, x)
+	y2 := new(big.Int).Mul(y, y)
+	lhs := new(big.Int).Add(x2, y2)
+	lhs.Mod(lhs, P)
+	rhs := new(big.Int).Mul(x2, y2)
+	rhs.Mod(rhs, P).Mul(rhs, c.d).Add(rhs, big.NewInt(1)).Mod(rhs, P)
+	return lhs.Cmp(rhs) == 0
+}
+
+// The identity is (0, 1), which is on the curve. The addition law is complete, as d is not a square mod p.
+
+func (c *curve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	return c.toAffine(c.add(c.fromAffine(x1, y1), c.fromAffine(x2, y2)))
+}
+
+func (c *curve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	return c.toAffine(c.double(c.fromAffine(x1, y1)))
+}
+
+func (c *curve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	q := c.fromAffine(x1, y1)
+	r := projective{new(big.Int), big.NewInt(1), big.NewInt(1)}
+	for _, byte := range k {
+		for bit := 0; bit < 8; bit++ {
+			r = c.double(r)
+			if byte&0x80 == 0x80 {
+				r = c.add(r, q)
+			}
+			byte <<= 1
+		}
+	}
+	return c.toAffine(r)
+}
+
+func (c *curve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.ScalarMult(c.Gx, c.Gy, k)
+}
+
+// ----- //
+
+// projective is the point (X/Z, Y/Z)
+type projective struct {
+	X, Y, Z *big.Int
+}
+
+func (c *curve) fromAffine(x, y *big.Int) projective {
+	return projective{new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)}
+}
+
+func (c *curve) toAffine(p projective) (*big.Int, *big.Int) {
+	P := c.P
+	zInv := new(big.Int).ModInverse(p.Z, P)
+	x := new(big.Int).Mul(p.X, zInv)
+	y := new(big.Int).Mul(p.Y, zInv)
+	return x.Mod(x, P), y.Mod(y, P)
+}
+
+// add follows section 5.2.4 of RFC 8032
+func (c *curve) add(p1, p2 projective) projective {
+	P := c.P
+	mul := func(a, b *big.Int) *big.Int {
+		r := new(big.Int).Mul(a, b)
+		return r.Mod(r, P)
+	}
+	A := mul(p1.Z, p2.Z)
+	B := mul(A, A)
+	C := mul(p1.X, p2.X)
+	D := mul(p1.Y, p2.Y)
+	E := mul(c.d, mul(C, D))
+	F := new(big.Int).Sub(B, E)
+	G := new(big.Int).Add(B, E)
+	H := mul(new(big.Int).Add(p1.X, p1.Y), new(big.Int).Add(p2.X, p2.Y))
+	X3 := mul(mul(A, F), new(big.Int).Sub(new(big.Int).Sub(H, C), D))
+	Y3 := mul(mul(A, G), new(big.Int).Sub(D, C))
+	Z3 := mul(F, G)
+	return projective{X3, Y3, Z3}
+}
+
+// double follows section 5.2.4 of RFC 8032
+func (c *curve) double(p1 projective) projective {
+	P := c.P
+	mul := func(a, b *big.Int) *big.Int {
+		r := new(big.Int).Mul(a, b)
+		return r.Mod(r, P)
+	}
+	sum := new(big.Int).Add(p1.X, p1.Y)
+	B := mul(sum, sum)
+	C := mul(p1.X, p1.X)
+	D := mul(p1.Y, p1.Y)
+	E := new(big.Int).Add(C, D)
+	H := mul(p1.Z, p1.Z)
+	J := new(big.Int).Sub(E, new(big.Int).Lsh(H, 1))
+	X3 := mul(new(big.Int).Sub(B, E), J)
+	Y3 := mul(E, new(big.Int).Sub(C, D))
+	Z3 := mul(E, J)
+	return projective{X3, Y3, Z3}
+}
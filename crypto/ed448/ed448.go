@@ -0,0 +1,177 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ed448
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+)
+
+const (
+	// the lengths of the encodings of points and scalars, of private keys and of signatures
+	PointSize     = 57
+	ScalarSize    = 57
+	SeedSize      = 57
+	SignatureSize = PointSize + ScalarSize
+)
+
+// EncodePoint returns the 57-byte encoding of the point (x, y): y in little-endian with the low bit of x in the top bit
+func EncodePoint(x, y *big.Int) []byte {
+	bz := littleEndian(y, PointSize)
+	if x.Bit(0) == 1 {
+		bz[PointSize-1] |= 0x80
+	}
+	return bz
+}
+
+// DecodePoint returns the point of a 57-byte encoding, which must be on the curve
+func DecodePoint(bz []byte) (*big.Int, *big.Int, error) {
+	c := Curve().(*curve)
+	P := c.P
+	if len(bz) != PointSize || bz[PointSize-1]&0x7f != 0 {
+		return nil, nil, errors.New("DecodePoint: invalid encoding")
+	}
+	sign := uint(bz[PointSize-1] >> 7)
+	y := fromLittleEndian(bz[:PointSize-1])
+	if y.Cmp(P) >= 0 {
+		return nil, nil, errors.New("DecodePoint: y is not reduced")
+	}
+	// x^2 = (y^2 - 1) / (d*y^2 - 1)
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, P)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	v := new(big.Int).Mul(c.d, y2)
+	v.Sub(v, big.NewInt(1)).Mod(v, P)
+	vInv := new(big.Int).ModInverse(v, P)
+	if vInv == nil {
+		return nil, nil, errors.New("DecodePoint: invalid encoding")
+	}
+	x2 := u.Mul(u, vInv).Mod(u, P)
+	// p = 3 mod 4, so a square root of x2 is x2^((p+1)/4)
+	x := new(big.Int).Exp(x2, new(big.Int).Rsh(new(big.Int).Add(P, big.NewInt(1)), 2), P)
+	if check := new(big.Int).Mul(x, x); check.Mod(check, P).Cmp(x2) != 0 {
+		return nil, nil, errors.New("DecodePoint: the point is not on the curve")
+	}
+	if x.Sign() == 0 && sign == 1 {
+		return nil, nil, errors.New("DecodePoint: invalid encoding")
+	}
+	if x.Bit(0) != sign {
+		x.Sub(P, x)
+	}
+	return x, y, nil
+}
+
+// EncodeScalar returns the 57-byte little-endian encoding of a scalar
+func EncodeScalar(s *big.Int) []byte {
+	return littleEndian(s, ScalarSize)
+}
+
+// Challenge returns the challenge k = SHAKE256(dom4(0, "") || R || A || M, 114) mod N of an Ed448 signature by the
+// public key `pub` with the nonce point `encodedR`
+func Challenge(encodedR, pub, msg []byte) *big.Int {
+	return hashToScalar(dom4(), encodedR, pub, msg)
+}
+
+// PublicKey returns the encoded public key of the 57-byte private key `seed`
+func PublicKey(seed []byte) ([]byte, error) {
+	s, _, err := expandSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	return EncodePoint(Curve().ScalarBaseMult(s.Bytes())), nil
+}
+
+// Sign returns the Ed448 signature of `msg` with the 57-byte private key `seed` and an empty context
+func Sign(seed, msg []byte) ([]byte, error) {
+	s, prefix, err := expandSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	ec := Curve()
+	N := ec.Params().N
+	pub := EncodePoint(ec.ScalarBaseMult(s.Bytes()))
+	r := hashToScalar(dom4(), prefix, msg)
+	encodedR := EncodePoint(ec.ScalarBaseMult(r.Bytes()))
+	k := Challenge(encodedR, pub, msg)
+	S := new(big.Int).Mul(k, s)
+	S.Add(S, r).Mod(S, N)
+	return append(encodedR, EncodeScalar(S)...), nil
+}
+
+// Verify reports whether `sig` is a valid Ed448 signature of `msg` with an empty context by the encoded public key
+// `pub`. It checks the cofactored equation [4][S]B = [4]R + [4][k]A of RFC 8032.
+func Verify(pub, msg, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	ec := Curve()
+	N := ec.Params().N
+	Ax, Ay, err := DecodePoint(pub)
+	if err != nil {
+		return false
+	}
+	Rx, Ry, err := DecodePoint(sig[:PointSize])
+	if err != nil {
+		return false
+	}
+	S := fromLittleEndian(sig[PointSize:])
+	if S.Cmp(N) >= 0 {
+		return false
+	}
+	k := Challenge(sig[:PointSize], pub, msg)
+	four := []byte{4}
+	lx, ly := ec.ScalarBaseMult(S.Bytes())
+	lx, ly = ec.ScalarMult(lx, ly, four)
+	kAx, kAy := ec.ScalarMult(Ax, Ay, k.Bytes())
+	rx, ry := ec.Add(Rx, Ry, kAx, kAy)
+	rx, ry = ec.ScalarMult(rx, ry, four)
+	return bytes.Equal(EncodePoint(lx, ly), EncodePoint(rx, ry))
+}
+
+// ----- //
+
+// expandSeed returns the clamped secret scalar and the nonce prefix of a private key
+func expandSeed(seed []byte) (*big.Int, []byte, error) {
+	if len(seed) != SeedSize {
+		return nil, nil, errors.New("the private key must be 57 bytes long")
+	}
+	h := Shake256(2*SeedSize, seed)
+	scalar := make([]byte, SeedSize)
+	copy(scalar, h[:SeedSize])
+	scalar[0] &= 0xfc
+	scalar[SeedSize-1] = 0
+	scalar[SeedSize-2] |= 0x80
+	return fromLittleEndian(scalar), h[SeedSize:], nil
+}
+
+// dom4(0, "") of RFC 8032, the prefix of the hashes of Ed448 with an empty context
+func dom4() []byte {
+	return append([]byte("SigEd448"), 0, 0)
+}
+
+func hashToScalar(in ...[]byte) *big.Int {
+	k := fromLittleEndian(Shake256(2*ScalarSize, in...))
+	return k.Mod(k, Curve().Params().N)
+}
+
+func littleEndian(a *big.Int, size int) []byte {
+	bz := make([]byte, size)
+	be := a.Bytes()
+	for i, b := range be {
+		bz[len(be)-1-i] = b
+	}
+	return bz
+}
+
+func fromLittleEndian(bz []byte) *big.Int {
+	be := make([]byte, len(bz))
+	for i, b := range bz {
+		be[len(bz)-1-i] = b
+	}
+	return new(big.Int).SetBytes(be)
+}
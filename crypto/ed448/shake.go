@@ -0,0 +1,22 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ed448
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// Shake256 returns `outLen` bytes of SHAKE256 of the concatenation of `in`, the hash of Ed448
+func Shake256(outLen int, in ...[]byte) []byte {
+	h := sha3.NewShake256()
+	for _, bz := range in {
+		_, _ = h.Write(bz)
+	}
+	out := make([]byte, outLen)
+	_, _ = h.Read(out)
+	return out
+}
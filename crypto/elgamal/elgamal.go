@@ -0,0 +1,94 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package elgamal provides EC-ElGamal encryption of curve points to a public key such as the ECDSAPub of a keygen,
+// so that the ciphertexts can be decrypted jointly by t+1 holders of the key shares with the elgamal/decryption party.
+// Arbitrary data is encrypted by encapsulating a symmetric key in a random point with EncapsulateKey.
+package elgamal
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+var (
+	keyDomain = []byte("tss-lib elgamal encapsulated key")
+)
+
+type (
+	// Ciphertext is the encryption (C1, C2) = (r*G, M + r*Y) of the point M to the public key Y
+	Ciphertext struct {
+		C1, C2 *crypto.ECPoint
+	}
+)
+
+// Encrypt encrypts the point `M` to the public key `pub`
+func Encrypt(pub, M *crypto.ECPoint) (*Ciphertext, error) {
+	if !pub.ValidateBasic() || !M.ValidateBasic() {
+		return nil, errors.New("Encrypt() received an invalid point")
+	}
+	r := common.GetRandomPositiveInt(pub.Curve().Params().N)
+	C2, err := M.Add(pub.ScalarMult(r))
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{C1: crypto.ScalarBaseMult(pub.Curve(), r), C2: C2}, nil
+}
+
+// Decrypt decrypts `ct` with the private key `x` of its public key; it is mainly used to test the threshold decryption
+func Decrypt(x *big.Int, ct *Ciphertext) (*crypto.ECPoint, error) {
+	if !ct.ValidateBasic() {
+		return nil, errors.New("Decrypt() received an invalid ciphertext")
+	}
+	return Combine(ct, []*crypto.ECPoint{ct.C1.ScalarMult(x)})
+}
+
+// Combine recovers M = C2 - sum(Dj) from the decryption shares Dj = wj*C1 of t+1 parties, where wj are the shares
+// of the private key multiplied by their Lagrange coefficients
+func Combine(ct *Ciphertext, Ds []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	if !ct.ValidateBasic() || len(Ds) == 0 {
+		return nil, errors.New("Combine() received an invalid ciphertext or no decryption shares")
+	}
+	D := Ds[0]
+	for _, Dj := range Ds[1:] {
+		var err error
+		if D, err = D.Add(Dj); err != nil {
+			return nil, err
+		}
+	}
+	return ct.C2.Add(neg(D))
+}
+
+// EncapsulateKey returns a 32-byte symmetric key and its encapsulation to the public key `pub`;
+// the key is recovered from the decrypted point with KeyFromPoint
+func EncapsulateKey(pub *crypto.ECPoint) (*Ciphertext, []byte, error) {
+	if !pub.ValidateBasic() {
+		return nil, nil, errors.New("EncapsulateKey() received an invalid point")
+	}
+	M := crypto.ScalarBaseMult(pub.Curve(), common.GetRandomPositiveInt(pub.Curve().Params().N))
+	ct, err := Encrypt(pub, M)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ct, KeyFromPoint(M), nil
+}
+
+// KeyFromPoint derives the 32-byte symmetric key encapsulated in the point `M`
+func KeyFromPoint(M *crypto.ECPoint) []byte {
+	return common.SHA512_256Canonical(string(keyDomain), M.X().Bytes(), M.Y().Bytes())
+}
+
+func (ct *Ciphertext) ValidateBasic() bool {
+	return ct != nil && ct.C1.ValidateBasic() && ct.C2.ValidateBasic()
+}
+
+// neg returns -P = (x, p - y)
+func neg(P *crypto.ECPoint) *crypto.ECPoint {
+	return crypto.NewECPointNoCurveCheck(P.Curve(), P.X(), new(big.Int).Sub(P.Curve().Params().P, P.Y()))
+}
@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package elgamal_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	. "github.com/binance-chain/tss-lib/crypto/elgamal"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	q := tss.S256().Params().N
+	x := common.GetRandomPositiveInt(q)
+	pub := crypto.ScalarBaseMult(tss.S256(), x)
+	M := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+
+	ct, err := Encrypt(pub, M)
+	assert.NoError(t, err)
+	M2, err := Decrypt(x, ct)
+	assert.NoError(t, err)
+	assert.True(t, M.Equals(M2), "the decrypted point must match")
+
+	M3, err := Decrypt(new(big.Int).Add(x, big.NewInt(1)), ct)
+	assert.NoError(t, err)
+	assert.False(t, M.Equals(M3), "decrypting with another key must not give the point")
+}
+
+func TestCombineShares(t *testing.T) {
+	q := tss.S256().Params().N
+	modQ := common.ModInt(q)
+	x := common.GetRandomPositiveInt(q)
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	_, shares, err := vss.Create(tss.S256(), 2, x, ids)
+	assert.NoError(t, err)
+
+	ct, key, err := EncapsulateKey(crypto.ScalarBaseMult(tss.S256(), x))
+	assert.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	// any 3 of the 4 shares with their Lagrange coefficients
+	subset := shares[1:]
+	Ds := make([]*crypto.ECPoint, len(subset))
+	for i, si := range subset {
+		wi := si.Share
+		for j, sj := range subset {
+			if j == i {
+				continue
+			}
+			wi = modQ.Mul(wi, modQ.Mul(sj.ID, modQ.ModInverse(new(big.Int).Sub(sj.ID, si.ID))))
+		}
+		Ds[i] = ct.C1.ScalarMult(wi)
+	}
+	M, err := Combine(ct, Ds)
+	assert.NoError(t, err)
+	assert.Equal(t, key, KeyFromPoint(M), "the encapsulated key must match")
+}
@@ -0,0 +1,240 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package facproof implements the no small factor proof of Canetti, Gennaro, Goldfeder, Makriyannis and Peled: UC
+// Non-Interactive, Proactive, Threshold ECDSA with Identifiable Aborts (2021), Fig. 28.
+//
+// The prover knows the factorization N0 = p*q of an RSA-like modulus, such as a Paillier modulus or an NTilde, and
+// shows that both p and q are larger than about 2^ℓ, with ℓ = 256. The proof is made against the verifier's
+// ring-Pedersen parameters (NTilde, s, t), whose factors the prover must not know. Together with a proof that N0 is a
+// Blum integer, e.g. paillier.ModulusProof, it shows that N0 is well formed.
+package facproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+const (
+	// ProofBytesParts is the number of parts of a serialized Proof
+	ProofBytesParts = 11
+
+	l       = 256 // ℓ, the bit length of the challenge
+	epsilon = 512 // ε, the slack of the ranges
+)
+
+type (
+	// Proof is the no small factor proof of CGGMP21 Fig. 28
+	Proof struct {
+		P, Q, A, B, T, Sigma, Z1, Z2, W1, W2, V *big.Int
+	}
+
+	// Exponentiator computes x^y mod m for x in [0, m) and y >= 0. The prover's exponentiations with the secret
+	// factors go through it; paillier.Exponentiator satisfies it.
+	Exponentiator interface {
+		Exp(x, y, m *big.Int) *big.Int
+	}
+
+	bigExponentiator struct{}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+func (bigExponentiator) Exp(x, y, m *big.Int) *big.Int {
+	return common.ModInt(m).Exp(x, y)
+}
+
+// NewProof proves that both factors P and Q of N0 are larger than about 2^ℓ, to the holder of the ring-Pedersen
+// parameters (NTilde, s, t)
+func NewProof(N0, P, Q, NTilde, s, t *big.Int) (*Proof, error) {
+	return NewProofWithExponentiator(N0, P, Q, NTilde, s, t, nil)
+}
+
+// NewProofWithExponentiator is NewProof with the exponentiations with secret exponents done by `exp`, e.g. a
+// constant-time one. A nil `exp` uses math/big.
+func NewProofWithExponentiator(N0, P, Q, NTilde, s, t *big.Int, exp Exponentiator) (*Proof, error) {
+	if N0 == nil || P == nil || Q == nil || NTilde == nil || s == nil || t == nil {
+		return nil, errors.New("facproof.NewProof() received nil value(s)")
+	}
+	if new(big.Int).Mul(P, Q).Cmp(N0) != 0 {
+		return nil, errors.New("facproof.NewProof(): N0 != P*Q")
+	}
+	if exp == nil {
+		exp = bigExponentiator{}
+	}
+	sqrtN0 := new(big.Int).Sqrt(N0)
+	twoL := new(big.Int).Lsh(one, l)
+	twoLE := new(big.Int).Lsh(one, l+epsilon)
+	twoLNTilde := new(big.Int).Mul(twoL, NTilde)
+	twoLENTilde := new(big.Int).Mul(twoLE, NTilde)
+	N0NTilde := new(big.Int).Mul(N0, NTilde)
+
+	// 1. sample and commit
+	alpha := common.GetRandomPositiveInt(new(big.Int).Mul(twoLE, sqrtN0))
+	beta := common.GetRandomPositiveInt(new(big.Int).Mul(twoLE, sqrtN0))
+	mu := common.GetRandomPositiveInt(twoLNTilde)
+	nu := common.GetRandomPositiveInt(twoLNTilde)
+	sigma := common.GetRandomPositiveInt(new(big.Int).Mul(twoL, N0NTilde))
+	r := common.GetRandomPositiveInt(new(big.Int).Mul(twoLE, N0NTilde))
+	x := common.GetRandomPositiveInt(twoLENTilde)
+	y := common.GetRandomPositiveInt(twoLENTilde)
+
+	modNTilde := common.ModInt(NTilde)
+	secretExp := func(b, e *big.Int) *big.Int {
+		return exp.Exp(new(big.Int).Mod(b, NTilde), e, NTilde)
+	}
+	commit := func(b1, e1, b2, e2 *big.Int) *big.Int {
+		return modNTilde.Mul(secretExp(b1, e1), secretExp(b2, e2))
+	}
+	bigP := commit(s, P, t, mu)
+	bigQ := commit(s, Q, t, nu)
+	A := commit(s, alpha, t, x)
+	B := commit(s, beta, t, y)
+	T := commit(bigQ, alpha, t, r)
+
+	// 2. the challenge
+	e := challenge(N0, NTilde, s, t, bigP, bigQ, A, B, T, sigma)
+
+	// 3. respond, with sigma^ = sigma - nu*p
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, P))
+	z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, Q))
+	w1 := new(big.Int).Add(x, new(big.Int).Mul(e, mu))
+	w2 := new(big.Int).Add(y, new(big.Int).Mul(e, nu))
+	v := new(big.Int).Sub(sigma, new(big.Int).Mul(nu, P))
+	v.Mul(v, e).Add(v, r)
+	return &Proof{P: bigP, Q: bigQ, A: A, B: B, T: T, Sigma: sigma, Z1: z1, Z2: z2, W1: w1, W2: w2, V: v}, nil
+}
+
+// Verify checks that both prime factors of N0 are larger than about 2^ℓ, with the verifier's own ring-Pedersen
+// parameters (NTilde, s, t)
+func (pf *Proof) Verify(N0, NTilde, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || N0 == nil || NTilde == nil || s == nil || t == nil {
+		return false
+	}
+	for _, a := range []*big.Int{pf.P, pf.Q, pf.A, pf.B, pf.T} {
+		if !common.IsNumberInMultiplicativeGroup(NTilde, a) {
+			return false
+		}
+	}
+	// range check: z1, z2 < 2^(ℓ+ε) * sqrt(N0)
+	bound := new(big.Int).Lsh(new(big.Int).Sqrt(N0), l+epsilon)
+	if pf.Z1.Sign() < 0 || pf.Z1.Cmp(bound) >= 0 || pf.Z2.Sign() < 0 || pf.Z2.Cmp(bound) >= 0 {
+		return false
+	}
+	e := challenge(N0, NTilde, s, t, pf.P, pf.Q, pf.A, pf.B, pf.T, pf.Sigma)
+
+	modNTilde := common.ModInt(NTilde)
+	// s^z1 * t^w1 = A * P^e
+	left, ok := expSigned(NTilde, s, pf.Z1, t, pf.W1)
+	if !ok || left.Cmp(modNTilde.Mul(pf.A, modNTilde.Exp(pf.P, e))) != 0 {
+		return false
+	}
+	// s^z2 * t^w2 = B * Q^e
+	left, ok = expSigned(NTilde, s, pf.Z2, t, pf.W2)
+	if !ok || left.Cmp(modNTilde.Mul(pf.B, modNTilde.Exp(pf.Q, e))) != 0 {
+		return false
+	}
+	// Q^z1 * t^v = T * R^e, with R = s^N0 * t^sigma
+	R, ok := expSigned(NTilde, s, N0, t, pf.Sigma)
+	if !ok {
+		return false
+	}
+	left, ok = expSigned(NTilde, pf.Q, pf.Z1, t, pf.V)
+	return ok && left.Cmp(modNTilde.Mul(pf.T, modNTilde.Exp(R, e))) == 0
+}
+
+func (pf *Proof) ValidateBasic() bool {
+	return pf.P != nil &&
+		pf.Q != nil &&
+		pf.A != nil &&
+		pf.B != nil &&
+		pf.T != nil &&
+		pf.Sigma != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.W1 != nil &&
+		pf.W2 != nil &&
+		pf.V != nil
+}
+
+// Bytes returns the ProofBytesParts parts of the proof, for a proto message. v may be negative, so its sign is stored
+// in the first byte of its part.
+func (pf *Proof) Bytes() [ProofBytesParts][]byte {
+	vBz := []byte{0}
+	if pf.V.Sign() < 0 {
+		vBz[0] = 1
+	}
+	return [...][]byte{
+		pf.P.Bytes(),
+		pf.Q.Bytes(),
+		pf.A.Bytes(),
+		pf.B.Bytes(),
+		pf.T.Bytes(),
+		pf.Sigma.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.W1.Bytes(),
+		pf.W2.Bytes(),
+		append(vBz, pf.V.Bytes()...),
+	}
+}
+
+func ProofFromBytes(bzs [][]byte) (*Proof, error) {
+	if len(bzs) != ProofBytesParts {
+		return nil, fmt.Errorf("expected %d byte parts to construct a no small factor proof", ProofBytesParts)
+	}
+	vBz := bzs[ProofBytesParts-1]
+	if len(vBz) == 0 || 1 < vBz[0] {
+		return nil, errors.New("ProofFromBytes: invalid v")
+	}
+	ints := common.MultiBytesToBigInts(bzs[:ProofBytesParts-1])
+	pf := &Proof{
+		P: ints[0], Q: ints[1], A: ints[2], B: ints[3], T: ints[4], Sigma: ints[5],
+		Z1: ints[6], Z2: ints[7], W1: ints[8], W2: ints[9],
+		V: new(big.Int).SetBytes(vBz[1:]),
+	}
+	if vBz[0] == 1 {
+		pf.V.Neg(pf.V)
+	}
+	return pf, nil
+}
+
+// ----- //
+
+// challenge draws e in [0, 2^ℓ) from the transcript of the proof after N0, the verifier's parameters and the
+// commitments
+func challenge(N0, NTilde, s, t *big.Int, commitments ...*big.Int) *big.Int {
+	tr := zkp.ForProof(nil, "facproof")
+	tr.AppendInts("statement", N0, NTilde, s, t)
+	tr.AppendInts("commitment", commitments...)
+	return tr.Challenge("challenge", new(big.Int).Lsh(one, l))
+}
+
+// expSigned returns b1^e1 * b2^e2 mod N, where the exponents may be negative
+func expSigned(N, b1, e1, b2, e2 *big.Int) (*big.Int, bool) {
+	modN := common.ModInt(N)
+	exp := func(b, e *big.Int) *big.Int {
+		if e.Sign() >= 0 {
+			return modN.Exp(b, e)
+		}
+		bInv := new(big.Int).ModInverse(b, N)
+		if bInv == nil {
+			return nil
+		}
+		return modN.Exp(bInv, new(big.Int).Neg(e))
+	}
+	r1, r2 := exp(b1, e1), exp(b2, e2)
+	if r1 == nil || r2 == nil {
+		return nil, false
+	}
+	return modN.Mul(r1, r2), true
+}
@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package facproof_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	. "github.com/binance-chain/tss-lib/crypto/facproof"
+)
+
+const (
+	testPrimeBits = 1024
+)
+
+type setup struct {
+	NTilde, s, t *big.Int
+}
+
+func newSetup(t *testing.T) *setup {
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits)}
+	NTilde, s, tt, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+	return &setup{NTilde: NTilde, s: s, t: tt}
+}
+
+func TestProveVerify(t *testing.T) {
+	s := newSetup(t)
+	P, Q := common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits)
+	N0 := new(big.Int).Mul(P, Q)
+
+	pf, err := NewProof(N0, P, Q, s.NTilde, s.s, s.t)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(N0, s.NTilde, s.s, s.t))
+
+	// through its bytes
+	bzs := pf.Bytes()
+	pf2, err := ProofFromBytes(bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(N0, s.NTilde, s.s, s.t))
+	_, err = ProofFromBytes(bzs[:ProofBytesParts-1])
+	assert.Error(t, err)
+
+	// against another modulus or other ring-Pedersen parameters
+	assert.False(t, pf.Verify(new(big.Int).Add(N0, big.NewInt(2)), s.NTilde, s.s, s.t))
+	assert.False(t, pf.Verify(N0, s.NTilde, s.t, s.s))
+
+	// tampered
+	bad := *pf
+	bad.V = new(big.Int).Add(pf.V, big.NewInt(1))
+	assert.False(t, bad.Verify(N0, s.NTilde, s.s, s.t))
+	assert.False(t, (*Proof)(nil).Verify(N0, s.NTilde, s.s, s.t))
+	assert.False(t, new(Proof).Verify(N0, s.NTilde, s.s, s.t))
+
+	_, err = NewProof(N0, P, P, s.NTilde, s.s, s.t)
+	assert.Error(t, err)
+}
+
+func TestSmallFactor(t *testing.T) {
+	s := newSetup(t)
+	// a 2048-bit modulus with a 128-bit factor
+	P, Q := common.GetRandomPrimeInt(128), common.GetRandomPrimeInt(2*testPrimeBits-128)
+	N0 := new(big.Int).Mul(P, Q)
+
+	pf, err := NewProof(N0, P, Q, s.NTilde, s.s, s.t)
+	assert.NoError(t, err)
+	assert.False(t, pf.Verify(N0, s.NTilde, s.s, s.t))
+}
+
+func TestNegativeV(t *testing.T) {
+	pf := &Proof{
+		P: big.NewInt(1), Q: big.NewInt(2), A: big.NewInt(3), B: big.NewInt(4), T: big.NewInt(5), Sigma: big.NewInt(6),
+		Z1: big.NewInt(7), Z2: big.NewInt(8), W1: big.NewInt(9), W2: big.NewInt(10), V: big.NewInt(-11),
+	}
+	bzs := pf.Bytes()
+	pf2, err := ProofFromBytes(bzs[:])
+	assert.NoError(t, err)
+	assert.Equal(t, pf, pf2)
+
+	bzs[ProofBytesParts-1] = []byte{2, 11}
+	_, err = ProofFromBytes(bzs[:])
+	assert.Error(t, err)
+}
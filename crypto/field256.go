@@ -0,0 +1,160 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// field256 is the arithmetic mod an odd prime p < 2^256 in the Montgomery form x*R mod p with R = 2^256, on four
+// 64-bit limbs (little endian) and without allocations, for MultiScalarMult. math/big is several times slower for
+// numbers of this size, as every product is reduced with a long division and allocated.
+
+const (
+	field256Limbs = 4
+	field256Bits  = field256Limbs * 64
+)
+
+type (
+	fieldElement [field256Limbs]uint64
+
+	field256 struct {
+		p    fieldElement
+		pInv uint64       // -p^-1 mod 2^64
+		rr   fieldElement // R^2 mod p
+		pBig *big.Int
+	}
+)
+
+// newField256 returns nil if p is not odd or does not fit in four limbs
+func newField256(p *big.Int) *field256 {
+	if p.Sign() <= 0 || p.Bit(0) == 0 || field256Bits < p.BitLen() {
+		return nil
+	}
+	f := &field256{pBig: new(big.Int).Set(p)}
+	f.p = limbsOf(p)
+	// Newton's iteration for p^-1 mod 2^64 doubles the correct bits each step, starting from 3 bits as p*p = 1 mod 8
+	inv := f.p[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+	f.pInv = -inv
+	rr := new(big.Int).Lsh(big.NewInt(1), 2*field256Bits)
+	f.rr = limbsOf(rr.Mod(rr, p))
+	return f
+}
+
+func limbsOf(x *big.Int) (z fieldElement) {
+	bz := x.Bytes()
+	for i := 0; i < len(bz); i++ {
+		z[i/8] |= uint64(bz[len(bz)-1-i]) << (8 * uint(i%8))
+	}
+	return
+}
+
+// fromBig returns x*R mod p, for 0 <= x < p
+func (f *field256) fromBig(x *big.Int) (z fieldElement) {
+	xl := limbsOf(x)
+	f.mul(&z, &xl, &f.rr)
+	return
+}
+
+func (f *field256) toBig(x *fieldElement) *big.Int {
+	var z fieldElement
+	one := fieldElement{1}
+	f.mul(&z, x, &one)
+	bz := make([]byte, 8*field256Limbs)
+	for i := 0; i < len(bz); i++ {
+		bz[len(bz)-1-i] = byte(z[i/8] >> (8 * uint(i%8)))
+	}
+	return new(big.Int).SetBytes(bz)
+}
+
+// mul sets z = x*y/R mod p, by the CIOS method of Koç, Acar and Kaliski; z may alias x or y
+func (f *field256) mul(z, x, y *fieldElement) {
+	var t [field256Limbs + 2]uint64
+	for i := 0; i < field256Limbs; i++ {
+		// t += x*y[i]
+		var c uint64
+		for j := 0; j < field256Limbs; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+			var cc uint64
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		var cc uint64
+		t[field256Limbs], cc = bits.Add64(t[field256Limbs], c, 0)
+		t[field256Limbs+1] = cc
+
+		// t = (t + m*p) / 2^64, with m such that the low limb is zero
+		m := t[0] * f.pInv
+		hi, lo := bits.Mul64(m, f.p[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < field256Limbs; j++ {
+			hi, lo = bits.Mul64(m, f.p[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[field256Limbs-1], cc = bits.Add64(t[field256Limbs], c, 0)
+		t[field256Limbs] = t[field256Limbs+1] + cc
+	}
+	var r fieldElement
+	copy(r[:], t[:field256Limbs])
+	f.reduce(z, &r, t[field256Limbs])
+}
+
+// reduce sets z = x - p if carry:x >= p, else z = x
+func (f *field256) reduce(z, x *fieldElement, carry uint64) {
+	var d fieldElement
+	var borrow uint64
+	for i := 0; i < field256Limbs; i++ {
+		d[i], borrow = bits.Sub64(x[i], f.p[i], borrow)
+	}
+	if carry != 0 || borrow == 0 {
+		*z = d
+	} else {
+		*z = *x
+	}
+}
+
+// add sets z = x+y mod p
+func (f *field256) add(z, x, y *fieldElement) {
+	var s fieldElement
+	var carry uint64
+	for i := 0; i < field256Limbs; i++ {
+		s[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	f.reduce(z, &s, carry)
+}
+
+// sub sets z = x-y mod p
+func (f *field256) sub(z, x, y *fieldElement) {
+	var d fieldElement
+	var borrow uint64
+	for i := 0; i < field256Limbs; i++ {
+		d[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+	if borrow != 0 {
+		var carry uint64
+		for i := 0; i < field256Limbs; i++ {
+			d[i], carry = bits.Add64(d[i], f.p[i], carry)
+		}
+	}
+	*z = d
+}
+
+func (x *fieldElement) isZero() bool {
+	return x[0]|x[1]|x[2]|x[3] == 0
+}
@@ -0,0 +1,47 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// errNoContext is returned by the provers of this package for a nil context
+var errNoContext = errors.New("the MtA proofs require a context, see zkp.NewSessionTranscript")
+
+// proofParams returns the optional MtA proof parameters passed to a prover or verifier, falling back to the GG18 defaults
+func proofParams(optionalParams []*tss.MtAProofParams) *tss.MtAProofParams {
+	if 0 < len(optionalParams) && optionalParams[0] != nil {
+		return optionalParams[0]
+	}
+	return tss.DefaultMtAProofParams()
+}
+
+// skipVerify returns whether the proofs of the peers are accepted without verification, see
+// tss.MtAProofParams.InsecureSkipVerify
+func skipVerify(optionalParams []*tss.MtAProofParams) bool {
+	return proofParams(optionalParams).InsecureSkipVerify
+}
+
+// qPow returns q^e
+func qPow(q *big.Int, e int) *big.Int {
+	return new(big.Int).Exp(q, big.NewInt(int64(e)), nil)
+}
+
+// checkModuli returns an error if the largest power of `q` that the proofs of `mtaParams` sample from does not stay well
+// below each of `moduli`, see tss.MtAProofParams.ValidateModulus
+func checkModuli(q *big.Int, mtaParams *tss.MtAProofParams, moduli ...*big.Int) error {
+	for _, N := range moduli {
+		if err := mtaParams.ValidateModulus(q, N); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	ProofPDLBytesParts = 8
+)
+
+type (
+	// ProofPDL is the proof of consistency between a Paillier ciphertext c = Enc(x, r) and an elliptic curve point
+	// Q = x*R, with the slack of the range proofs (GG20 Section 4.4, the "PDL w/ slack" proof of Lindell et al.)
+	ProofPDL struct {
+		Z  *big.Int
+		U1 *crypto.ECPoint
+		U2, U3,
+		S1, S2, S3 *big.Int
+	}
+)
+
+// ProvePDL proves that Q = x*R for the plaintext x of c = Enc(x, r) under `pk`, against the verifier's NTilde, h1, h2.
+// `ctx` is the context of the session, round and prover, and must not be nil. `optionalMtAParams` configure the slack
+// of the proof; the GG18 bound of q^3 is used when absent.
+func ProvePDL(ctx *zkp.Transcript, pk *paillier.PublicKey, c *big.Int, R, Q *crypto.ECPoint, NTilde, h1, h2, x, r *big.Int, optionalMtAParams ...*tss.MtAProofParams) (*ProofPDL, error) {
+	if pk == nil || c == nil || R == nil || Q == nil || NTilde == nil || h1 == nil || h2 == nil || x == nil || r == nil {
+		return nil, errors.New("ProvePDL constructor received nil value(s)")
+	}
+	if ctx == nil {
+		return nil, errNoContext
+	}
+
+	q := R.Curve().Params().N
+	q3 := qPow(q, proofParams(optionalMtAParams).SlackExp)
+	qNTilde := new(big.Int).Mul(q, NTilde)
+	q3NTilde := new(big.Int).Mul(q3, NTilde)
+
+	// 1.
+	alpha := common.GetRandomPositiveInt(q3)
+	beta := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	rho := common.GetRandomPositiveInt(qNTilde)
+	gamma := common.GetRandomPositiveInt(q3NTilde)
+
+	// 2. z = h1^x * h2^rho mod NTilde
+	modNTilde := common.ModInt(NTilde)
+	z := modNTilde.Mul(modNTilde.Exp(h1, x), modNTilde.Exp(h2, rho))
+
+	// 3. u1 = alpha*R, u2 = Gamma^alpha * beta^N mod N^2, u3 = h1^alpha * h2^gamma mod NTilde
+	u1 := R.ScalarMult(alpha)
+	modNSquared := common.ModInt(pk.NSquare())
+	u2 := modNSquared.Mul(modNSquared.Exp(pk.Gamma(), alpha), modNSquared.Exp(beta, pk.N))
+	u3 := modNTilde.Mul(modNTilde.Exp(h1, alpha), modNTilde.Exp(h2, gamma))
+
+	// 4. e
+	e := pdlChallenge(ctx, pk, c, R, Q, NTilde, h1, h2, z, u1, u2, u3)
+
+	// 5. s1 = e*x + alpha, s2 = r^e * beta mod N, s3 = e*rho + gamma
+	s1 := new(big.Int).Add(new(big.Int).Mul(e, x), alpha)
+	modN := common.ModInt(pk.N)
+	s2 := modN.Mul(modN.Exp(r, e), beta)
+	s3 := new(big.Int).Add(new(big.Int).Mul(e, rho), gamma)
+
+	return &ProofPDL{Z: z, U1: u1, U2: u2, U3: u3, S1: s1, S2: s2, S3: s3}, nil
+}
+
+// ProofPDLFromBytes decodes a proof about points of the curve `ec`
+func ProofPDLFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofPDL, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofPDLBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofPDL", ProofPDLBytesParts)
+	}
+	point, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[1]),
+		new(big.Int).SetBytes(bzs[2]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofPDL{
+		Z:  new(big.Int).SetBytes(bzs[0]),
+		U1: point,
+		U2: new(big.Int).SetBytes(bzs[3]),
+		U3: new(big.Int).SetBytes(bzs[4]),
+		S1: new(big.Int).SetBytes(bzs[5]),
+		S2: new(big.Int).SetBytes(bzs[6]),
+		S3: new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+// Verify checks the proof that Q = x*R for the plaintext x of c. `ctx` and `optionalMtAParams` must match those used by
+// the prover.
+func (pf *ProofPDL) Verify(ctx *zkp.Transcript, pk *paillier.PublicKey, c *big.Int, R, Q *crypto.ECPoint, NTilde, h1, h2 *big.Int, optionalMtAParams ...*tss.MtAProofParams) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() || pk == nil || c == nil || !R.ValidateBasic() || !Q.ValidateBasic() ||
+		NTilde == nil || h1 == nil || h2 == nil {
+		return false
+	}
+	if pk.ValidateCiphertext(c) != nil {
+		return false
+	}
+
+	q := R.Curve().Params().N
+	q3 := qPow(q, proofParams(optionalMtAParams).SlackExp)
+
+	// 1. s1 <= q^3
+	if pf.S1.Cmp(q3) == 1 {
+		return false
+	}
+
+	e := pdlChallenge(ctx, pk, c, R, Q, NTilde, h1, h2, pf.Z, pf.U1, pf.U2, pf.U3)
+	minusE := new(big.Int).Sub(zero, e)
+
+	{ // 2. s1*R == u1 + e*Q
+		s1R := R.ScalarMult(pf.S1)
+		u1eQ, err := pf.U1.Add(Q.ScalarMult(e))
+		if err != nil || !s1R.Equals(u1eQ) {
+			return false
+		}
+	}
+
+	{ // 3. u2 == Gamma^s1 * s2^N * c^-e mod N^2
+		modN2 := common.ModInt(pk.NSquare())
+		products := modN2.Mul(modN2.Exp(pk.Gamma(), pf.S1), modN2.Exp(pf.S2, pk.N))
+		products = modN2.Mul(products, modN2.Exp(c, minusE))
+		if pf.U2.Cmp(products) != 0 {
+			return false
+		}
+	}
+
+	{ // 4. u3 == h1^s1 * h2^s3 * z^-e mod NTilde
+		modNTilde := common.ModInt(NTilde)
+		products := modNTilde.Mul(modNTilde.Exp(h1, pf.S1), modNTilde.Exp(h2, pf.S3))
+		products = modNTilde.Mul(products, modNTilde.Exp(pf.Z, minusE))
+		if pf.U3.Cmp(products) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofPDL) ValidateBasic() bool {
+	return pf.Z != nil &&
+		pf.U1.ValidateBasic() &&
+		pf.U2 != nil &&
+		pf.U3 != nil &&
+		pf.S1 != nil &&
+		pf.S2 != nil &&
+		pf.S3 != nil
+}
+
+func (pf *ProofPDL) Bytes() [ProofPDLBytesParts][]byte {
+	return [...][]byte{
+		pf.Z.Bytes(),
+		pf.U1.X().Bytes(),
+		pf.U1.Y().Bytes(),
+		pf.U2.Bytes(),
+		pf.U3.Bytes(),
+		pf.S1.Bytes(),
+		pf.S2.Bytes(),
+		pf.S3.Bytes(),
+	}
+}
+
+// pdlChallenge draws the challenge in [0, q) from the transcript of the proof after the statement and the commitments
+func pdlChallenge(ctx *zkp.Transcript, pk *paillier.PublicKey, c *big.Int, R, Q *crypto.ECPoint, NTilde, h1, h2, z *big.Int, u1 *crypto.ECPoint, u2, u3 *big.Int) *big.Int {
+	tr := zkp.ForProof(ctx, "mta/pdl")
+	tr.AppendInts("public-key", pk.AsInts()...)
+	tr.AppendInts("statement", c, NTilde, h1, h2)
+	tr.AppendPoints("statement", R, Q)
+	tr.AppendInts("commitment", z, u2, u3)
+	tr.AppendPoints("commitment", u1)
+	return tr.Challenge("challenge", R.Curve().Params().N)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestProvePDL(t *testing.T) {
+	q := tss.S256().Params().N
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testSafePrimeBits), common.GetRandomPrimeInt(testSafePrimeBits)}
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+
+	x := common.GetRandomPositiveInt(q)
+	c, r, err := sk.EncryptAndReturnRandomness(x)
+	assert.NoError(t, err)
+	R := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+	Q := R.ScalarMult(x)
+
+	proof, err := ProvePDL(aliceCtx(), pk, c, R, Q, NTildei, h1i, h2i, x, r)
+	assert.NoError(t, err)
+	assert.True(t, proof.Verify(aliceCtx(), pk, c, R, Q, NTildei, h1i, h2i), "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := ProofPDLFromBytes(tss.S256(), bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, proof2.Verify(aliceCtx(), pk, c, R, Q, NTildei, h1i, h2i), "proof must verify after a round trip")
+	assert.False(t, proof.Verify(testContext("other session", 1), pk, c, R, Q, NTildei, h1i, h2i),
+		"proof must not verify in another session")
+	assert.False(t, proof.Verify(bobCtx(), pk, c, R, Q, NTildei, h1i, h2i), "proof must not verify in another round")
+
+	// Q for another x
+	Q2 := R.ScalarMult(new(big.Int).Add(x, big.NewInt(1)))
+	assert.False(t, proof.Verify(aliceCtx(), pk, c, R, Q2, NTildei, h1i, h2i), "proof must not verify for another Q")
+	proof, err = ProvePDL(aliceCtx(), pk, c, R, Q2, NTildei, h1i, h2i, x, r)
+	assert.NoError(t, err)
+	assert.False(t, proof.Verify(aliceCtx(), pk, c, R, Q2, NTildei, h1i, h2i), "proof of an inconsistent Q must not verify")
+}
@@ -7,6 +7,7 @@
 package mta
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"fmt"
 	"math/big"
@@ -14,6 +15,7 @@ import (
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
 	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
@@ -35,16 +37,24 @@ type (
 
 // ProveBobWC implements Bob's proof both with or without check "ProveMtawc_Bob" and "ProveMta_Bob" used in the MtA protocol from GG18Spec (9) Figs. 10 & 11.
 // an absent `X` generates the proof without the X consistency check X = g^x
-func ProveBobWC(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int, X *crypto.ECPoint) (*ProofBobWC, error) {
+// `optionalMtAParams` configure the slack of the proof; the GG18 bound of q^3 is used when absent, for the order q of
+// the curve `ec`. `ctx` is the context of the session, round and prover, and must not be nil.
+func ProveBobWC(ctx *zkp.Transcript, ec elliptic.Curve, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int, X *crypto.ECPoint, optionalMtAParams ...*tss.MtAProofParams) (*ProofBobWC, error) {
 	if pk == nil || NTilde == nil || h1 == nil || h2 == nil || c1 == nil || c2 == nil || x == nil || y == nil || r == nil {
 		return nil, errors.New("ProveBob() received a nil argument")
 	}
+	if ctx == nil {
+		return nil, errNoContext
+	}
 
 	NSquared := pk.NSquare()
 
-	q := tss.EC().Params().N
-	q3 := new(big.Int).Mul(q, q)
-	q3 = new(big.Int).Mul(q, q3)
+	mtaParams := proofParams(optionalMtAParams)
+	q := ec.Params().N
+	if err := checkModuli(q, mtaParams, pk.N, NTilde); err != nil {
+		return nil, err
+	}
+	q3 := qPow(q, mtaParams.SlackExp)
 	qNTilde := new(big.Int).Mul(q, NTilde)
 	q3NTilde := new(big.Int).Mul(q3, NTilde)
 
@@ -62,12 +72,18 @@ func ProveBobWC(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int
 
 	// 4.
 	beta := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
-	gamma := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	var gamma *big.Int
+	if mtaParams.Strict {
+		// the mask of y must cover q^(SlackExp+4) (q^7 in GG18) for the verifier's bound on t1
+		gamma = common.GetRandomPositiveInt(qPow(q, mtaParams.SlackExp+4))
+	} else {
+		gamma = common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	}
 
 	// 5.
-	u := crypto.NewECPointNoCurveCheck(tss.EC(), zero, zero) // initialization suppresses an IDE warning
+	u := crypto.NewECPointNoCurveCheck(ec, zero, zero) // initialization suppresses an IDE warning
 	if X != nil {
-		u = crypto.ScalarBaseMult(tss.EC(), alpha)
+		u = crypto.ScalarBaseMult(ec, alpha)
 	}
 
 	// 6.
@@ -94,17 +110,7 @@ func ProveBobWC(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int
 	w = modNTilde.Mul(w, modNTilde.Exp(h2, tau))
 
 	// 11-12. e'
-	var e *big.Int
-	{ // must use RejectionSample
-		var eHash *big.Int
-		// X is nil if called by ProveBob (Bob's proof "without check")
-		if X == nil {
-			eHash = common.SHA512_256i(append(pk.AsInts(), c1, c2, z, zPrm, t, v, w)...)
-		} else {
-			eHash = common.SHA512_256i(append(pk.AsInts(), X.X(), X.Y(), c1, c2, u.X(), u.Y(), z, zPrm, t, v, w)...)
-		}
-		e = common.RejectionSample(q, eHash)
-	}
+	e := bobChallenge(ctx, pk, NTilde, h1, h2, c1, c2, X, u, q, z, zPrm, t, v, w)
 
 	// 13.
 	modN := common.ModInt(pk.N)
@@ -135,22 +141,23 @@ func ProveBobWC(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int
 }
 
 // ProveBob implements Bob's proof "ProveMta_Bob" used in the MtA protocol from GG18Spec (9) Fig. 11.
-func ProveBob(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int) (*ProofBob, error) {
+func ProveBob(ctx *zkp.Transcript, ec elliptic.Curve, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int, optionalMtAParams ...*tss.MtAProofParams) (*ProofBob, error) {
 	// the Bob proof ("with check") contains the ProofBob "without check"; this method extracts and returns it
 	// X is supplied as nil to exclude it from the proof hash
-	pf, err := ProveBobWC(pk, NTilde, h1, h2, c1, c2, x, y, r, nil)
+	pf, err := ProveBobWC(ctx, ec, pk, NTilde, h1, h2, c1, c2, x, y, r, nil, optionalMtAParams...)
 	if err != nil {
 		return nil, err
 	}
 	return pf.ProofBob, nil
 }
 
-func ProofBobWCFromBytes(bzs [][]byte) (*ProofBobWC, error) {
+// ProofBobWCFromBytes decodes a proof with check about points of the curve `ec`
+func ProofBobWCFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofBobWC, error) {
 	proofBob, err := ProofBobFromBytes(bzs)
 	if err != nil {
 		return nil, err
 	}
-	point, err := crypto.NewECPoint(tss.EC(),
+	point, err := crypto.NewECPoint(ec,
 		new(big.Int).SetBytes(bzs[10]),
 		new(big.Int).SetBytes(bzs[11]))
 	if err != nil {
@@ -185,39 +192,43 @@ func ProofBobFromBytes(bzs [][]byte) (*ProofBob, error) {
 
 // ProveBobWC.Verify implements verification of Bob's proof with check "VerifyMtawc_Bob" used in the MtA protocol from GG18Spec (9) Fig. 10.
 // an absent `X` verifies a proof generated without the X consistency check X = g^x
-func (pf *ProofBobWC) Verify(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2 *big.Int, X *crypto.ECPoint) bool {
-	if pk == nil || NTilde == nil || h1 == nil || h2 == nil || c1 == nil || c2 == nil {
+// `ctx`, `ec` and `optionalMtAParams` must match those used by the prover
+func (pf *ProofBobWC) Verify(ctx *zkp.Transcript, ec elliptic.Curve, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2 *big.Int, X *crypto.ECPoint, optionalMtAParams ...*tss.MtAProofParams) bool {
+	if ctx == nil || pk == nil || NTilde == nil || h1 == nil || h2 == nil || c1 == nil || c2 == nil {
+		return false
+	}
+	if pf == nil || pf.ProofBob == nil || !pf.ProofBob.ValidateBasic() || (X != nil && pf.U == nil) {
+		return false
+	}
+	if pk.ValidateCiphertext(c1) != nil || pk.ValidateCiphertext(c2) != nil {
 		return false
 	}
 
-	q := tss.EC().Params().N
-	q3 := new(big.Int).Mul(q, q)
-	q3 = new(big.Int).Mul(q, q3)
+	mtaParams := proofParams(optionalMtAParams)
+	q := ec.Params().N
+	if checkModuli(q, mtaParams, pk.N, NTilde) != nil {
+		return false
+	}
+	q3 := qPow(q, mtaParams.SlackExp)
 
 	// 3.
 	if pf.S1.Cmp(q3) > 0 {
 		return false
 	}
+	// the t1 bound from GG18 is only enforced in strict mode
+	if mtaParams.Strict && pf.T1.Cmp(qPow(q, mtaParams.SlackExp+4)) > 0 {
+		return false
+	}
 
 	// 1-2. e'
-	var e *big.Int
-	{ // must use RejectionSample
-		var eHash *big.Int
-		// X is nil if called on a ProveBob (Bob's proof "without check")
-		if X == nil {
-			eHash = common.SHA512_256i(append(pk.AsInts(), c1, c2, pf.Z, pf.ZPrm, pf.T, pf.V, pf.W)...)
-		} else {
-			eHash = common.SHA512_256i(append(pk.AsInts(), X.X(), X.Y(), c1, c2, pf.U.X(), pf.U.Y(), pf.Z, pf.ZPrm, pf.T, pf.V, pf.W)...)
-		}
-		e = common.RejectionSample(q, eHash)
-	}
+	e := bobChallenge(ctx, pk, NTilde, h1, h2, c1, c2, X, pf.U, q, pf.Z, pf.ZPrm, pf.T, pf.V, pf.W)
 
 	var left, right *big.Int // for the following conditionals
 
 	// 4. runs only in the "with check" mode from Fig. 10
 	if X != nil {
-		s1ModQ := new(big.Int).Mod(pf.S1, tss.EC().Params().N)
-		gS1 := crypto.ScalarBaseMult(tss.EC(), s1ModQ)
+		s1ModQ := new(big.Int).Mod(pf.S1, q)
+		gS1 := crypto.ScalarBaseMult(ec, s1ModQ)
 		xEU, err := X.ScalarMult(e).Add(pf.U)
 		if err != nil || !gS1.Equals(xEU) {
 			return false
@@ -268,12 +279,30 @@ func (pf *ProofBobWC) Verify(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2 *big
 }
 
 // ProveBob.Verify implements verification of Bob's proof without check "VerifyMta_Bob" used in the MtA protocol from GG18Spec (9) Fig. 11.
-func (pf *ProofBob) Verify(pk *paillier.PublicKey, NTilde, h1, h2, c1, c2 *big.Int) bool {
+func (pf *ProofBob) Verify(ctx *zkp.Transcript, ec elliptic.Curve, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2 *big.Int, optionalMtAParams ...*tss.MtAProofParams) bool {
 	if pf == nil {
 		return false
 	}
 	pfWC := &ProofBobWC{ProofBob: pf, U: nil}
-	return pfWC.Verify(pk, NTilde, h1, h2, c1, c2, nil)
+	return pfWC.Verify(ctx, ec, pk, NTilde, h1, h2, c1, c2, nil, optionalMtAParams...)
+}
+
+// bobChallenge draws e' in [0, q) from the transcript of Bob's proof after the statement and the commitments. X and u
+// are only part of the proof with check, for which X is not nil.
+func bobChallenge(ctx *zkp.Transcript, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2 *big.Int, X, u *crypto.ECPoint, q *big.Int, commitments ...*big.Int) *big.Int {
+	proof := "mta/bob"
+	if X != nil {
+		proof = "mta/bob-wc"
+	}
+	tr := zkp.ForProof(ctx, proof)
+	tr.AppendInts("public-key", pk.AsInts()...)
+	tr.AppendInts("statement", NTilde, h1, h2, c1, c2)
+	if X != nil {
+		tr.AppendPoints("statement", X)
+		tr.AppendPoints("commitment", u)
+	}
+	tr.AppendInts("commitment", commitments...)
+	return tr.Challenge("challenge", q)
 }
 
 func (pf *ProofBob) ValidateBasic() bool {
@@ -290,7 +319,7 @@ func (pf *ProofBob) ValidateBasic() bool {
 }
 
 func (pf *ProofBobWC) ValidateBasic() bool {
-	return pf.ProofBob.ValidateBasic() && pf.U != nil
+	return pf.ProofBob != nil && pf.ProofBob.ValidateBasic() && pf.U != nil
 }
 
 func (pf *ProofBob) Bytes() [ProofBobBytesParts][]byte {
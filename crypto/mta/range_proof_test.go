@@ -25,7 +25,7 @@ const (
 )
 
 func TestProveRangeAlice(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 
 	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
 	assert.NoError(t, err)
@@ -37,9 +37,72 @@ func TestProveRangeAlice(t *testing.T) {
 	primes := [2]*big.Int{common.GetRandomPrimeInt(testSafePrimeBits), common.GetRandomPrimeInt(testSafePrimeBits)}
 	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
 	assert.NoError(t, err)
-	proof, err := ProveRangeAlice(pk, c, NTildei, h1i, h2i, m, r)
+	proof, err := ProveRangeAlice(aliceCtx(), tss.S256(), pk, c, NTildei, h1i, h2i, m, r)
 	assert.NoError(t, err)
 
-	ok := proof.Verify(pk, NTildei, h1i, h2i, c)
+	ok := proof.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, c)
 	assert.True(t, ok, "proof must verify")
 }
+
+func TestProveRangeAliceInvalidCiphertext(t *testing.T) {
+	q := tss.S256().Params().N
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	m := common.GetRandomPositiveInt(q)
+	c, r, err := sk.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testSafePrimeBits), common.GetRandomPrimeInt(testSafePrimeBits)}
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+	proof, err := ProveRangeAlice(aliceCtx(), tss.S256(), pk, c, NTildei, h1i, h2i, m, r)
+	assert.NoError(t, err)
+
+	// a ciphertext outside of Z*_{N^2} never verifies
+	for _, bad := range []*big.Int{big.NewInt(0), pk.N, pk.NSquare()} {
+		assert.False(t, proof.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, bad))
+	}
+}
+
+func TestProveRangeAliceSlackExp(t *testing.T) {
+	q := tss.S256().Params().N
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testSafePrimeBits), common.GetRandomPrimeInt(testSafePrimeBits)}
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+
+	m := common.GetRandomPositiveInt(q)
+	c, r, err := sk.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+
+	loose := &tss.MtAProofParams{SlackExp: 5}
+	assert.NoError(t, loose.Validate())
+	proof, err := ProveRangeAlice(aliceCtx(), tss.S256(), pk, c, NTildei, h1i, h2i, m, r, loose)
+	assert.NoError(t, err)
+	assert.True(t, proof.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, c, loose), "proof must verify with the prover's params")
+	assert.False(t, proof.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, c), "proof with q^5 masks must not verify against the q^3 bound")
+
+	// a message beyond the q^3 bound cannot be proven in range
+	mBig := new(big.Int).Add(new(big.Int).Exp(q, big.NewInt(3), nil), m)
+	cBig, rBig, err := sk.EncryptAndReturnRandomness(mBig)
+	assert.NoError(t, err)
+	proof, err = ProveRangeAlice(aliceCtx(), tss.S256(), pk, cBig, NTildei, h1i, h2i, mBig, rBig)
+	assert.NoError(t, err)
+	assert.False(t, proof.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, cBig), "proof of an out-of-range message must not verify")
+
+	assert.Error(t, (&tss.MtAProofParams{SlackExp: 2}).Validate(), "slack below the GG18 bound must be rejected")
+
+	// a slack whose masks would wrap around the moduli is rejected by the prover and the verifier
+	for _, tooLarge := range []*tss.MtAProofParams{{SlackExp: 8}, {SlackExp: 4, Strict: true}} {
+		assert.Error(t, tooLarge.ValidateModulus(q, pk.N))
+		_, err = ProveRangeAlice(aliceCtx(), tss.S256(), pk, c, NTildei, h1i, h2i, m, r, tooLarge)
+		assert.Error(t, err)
+		assert.False(t, proof.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, c, tooLarge))
+	}
+	assert.NoError(t, tss.StrictMtAProofParams().ValidateModulus(q, pk.N))
+}
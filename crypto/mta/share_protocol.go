@@ -7,38 +7,78 @@
 package mta
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"math/big"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
 	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
+// AliceInit encrypts a under Alice's key `pkA` and proves its range against Bob's NTildeB, h1B, h2B in the context
+// `aliceCtx` of the session, round and Alice's index
 func AliceInit(
+	aliceCtx *zkp.Transcript,
+	ec elliptic.Curve,
 	pkA *paillier.PublicKey,
 	a, NTildeB, h1B, h2B *big.Int,
+	optionalMtAParams ...*tss.MtAProofParams,
 ) (cA *big.Int, pf *RangeProofAlice, err error) {
-	cA, rA, err := pkA.EncryptAndReturnRandomness(a)
+	cA, _, pf, err = AliceInitWithRandomness(aliceCtx, ec, pkA, a, NTildeB, h1B, h2B, optionalMtAParams...)
+	return cA, pf, err
+}
+
+// AliceInitWithRandomness is AliceInit that also returns the randomness rA of cA, for later proofs about a
+func AliceInitWithRandomness(
+	aliceCtx *zkp.Transcript,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	a, NTildeB, h1B, h2B *big.Int,
+	optionalMtAParams ...*tss.MtAProofParams,
+) (cA, rA *big.Int, pf *RangeProofAlice, err error) {
+	cA, rA, err = pkA.EncryptAndReturnRandomness(a)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	pf, err = ProveRangeAlice(pkA, cA, NTildeB, h1B, h2B, a, rA)
-	return cA, pf, err
+	pf, err = ProveRangeAlice(aliceCtx, ec, pkA, cA, NTildeB, h1B, h2B, a, rA, optionalMtAParams...)
+	return cA, rA, pf, err
 }
 
+// AliceInitFromPool is AliceInitWithRandomness that encrypts a with precomputed randomness from `pool`
+func AliceInitFromPool(
+	aliceCtx *zkp.Transcript,
+	ec elliptic.Curve,
+	pool *paillier.RandomnessPool,
+	a, NTildeB, h1B, h2B *big.Int,
+	optionalMtAParams ...*tss.MtAProofParams,
+) (cA, rA *big.Int, pf *RangeProofAlice, err error) {
+	cA, rA, err = pool.EncryptAndReturnRandomness(a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pf, err = ProveRangeAlice(aliceCtx, ec, pool.PublicKey(), cA, NTildeB, h1B, h2B, a, rA, optionalMtAParams...)
+	return cA, rA, pf, err
+}
+
+// BobMid verifies Alice's range proof in her context `aliceCtx`, computes Bob's share and proves it in his context
+// `bobCtx`
 func BobMid(
+	aliceCtx, bobCtx *zkp.Transcript,
+	ec elliptic.Curve,
 	pkA *paillier.PublicKey,
 	pf *RangeProofAlice,
 	b, cA, NTildeA, h1A, h2A, NTildeB, h1B, h2B *big.Int,
+	optionalMtAParams ...*tss.MtAProofParams,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
-	if !pf.Verify(pkA, NTildeB, h1B, h2B, cA) {
+	if !skipVerify(optionalMtAParams) && !pf.Verify(aliceCtx, ec, pkA, NTildeB, h1B, h2B, cA, optionalMtAParams...) {
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
-	q := tss.EC().Params().N
-	betaPrm = common.GetRandomPositiveInt(pkA.N)
+	q := ec.Params().N
+	betaPrm = sampleBetaPrm(q, pkA, proofParams(optionalMtAParams))
 	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(betaPrm)
 	if err != nil {
 		return
@@ -52,22 +92,30 @@ func BobMid(
 		return
 	}
 	beta = common.ModInt(q).Sub(zero, betaPrm)
-	piB, err = ProveBob(pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand)
+	piB, err = ProveBob(bobCtx, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, optionalMtAParams...)
 	return
 }
 
+// BobMidWC is BobMid with the check of Bob's public point B = g^b
 func BobMidWC(
+	aliceCtx, bobCtx *zkp.Transcript,
+	ec elliptic.Curve,
 	pkA *paillier.PublicKey,
 	pf *RangeProofAlice,
 	b, cA, NTildeA, h1A, h2A, NTildeB, h1B, h2B *big.Int,
 	B *crypto.ECPoint,
+	optionalMtAParams ...*tss.MtAProofParams,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBobWC, err error) {
-	if !pf.Verify(pkA, NTildeB, h1B, h2B, cA) {
+	if B == nil {
+		err = errors.New("BobMidWC() requires the public point B = g^b")
+		return
+	}
+	if !skipVerify(optionalMtAParams) && !pf.Verify(aliceCtx, ec, pkA, NTildeB, h1B, h2B, cA, optionalMtAParams...) {
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
-	q := tss.EC().Params().N
-	betaPrm = common.GetRandomPositiveInt(pkA.N)
+	q := ec.Params().N
+	betaPrm = sampleBetaPrm(q, pkA, proofParams(optionalMtAParams))
 	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(betaPrm)
 	if err != nil {
 		return
@@ -81,41 +129,61 @@ func BobMidWC(
 		return
 	}
 	beta = common.ModInt(q).Sub(zero, betaPrm)
-	piB, err = ProveBobWC(pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B)
+	piB, err = ProveBobWC(bobCtx, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B, optionalMtAParams...)
 	return
 }
 
+// AliceEnd verifies Bob's proof in his context `bobCtx` and decrypts Alice's share
 func AliceEnd(
+	bobCtx *zkp.Transcript,
+	ec elliptic.Curve,
 	pkA *paillier.PublicKey,
 	pf *ProofBob,
 	h1A, h2A, cA, cB, NTildeA *big.Int,
 	sk *paillier.PrivateKey,
+	optionalMtAParams ...*tss.MtAProofParams,
 ) (*big.Int, error) {
-	if !pf.Verify(pkA, NTildeA, h1A, h2A, cA, cB) {
+	if !skipVerify(optionalMtAParams) && !pf.Verify(bobCtx, ec, pkA, NTildeA, h1A, h2A, cA, cB, optionalMtAParams...) {
 		return nil, errors.New("ProofBob.Verify() returned false")
 	}
 	alphaPrm, err := sk.Decrypt(cB)
 	if err != nil {
 		return nil, err
 	}
-	q := tss.EC().Params().N
+	q := ec.Params().N
 	return new(big.Int).Mod(alphaPrm, q), nil
 }
 
+// AliceEndWC is AliceEnd with the check of Bob's public point B = g^b
 func AliceEndWC(
+	bobCtx *zkp.Transcript,
+	ec elliptic.Curve,
 	pkA *paillier.PublicKey,
 	pf *ProofBobWC,
 	B *crypto.ECPoint,
 	cA, cB, NTildeA, h1A, h2A *big.Int,
 	sk *paillier.PrivateKey,
+	optionalMtAParams ...*tss.MtAProofParams,
 ) (*big.Int, error) {
-	if !pf.Verify(pkA, NTildeA, h1A, h2A, cA, cB, B) {
+	// without B the proof would be verified "without check", and Bob could input any b
+	if B == nil {
+		return nil, errors.New("AliceEndWC() requires Bob's public point B = g^b")
+	}
+	if !skipVerify(optionalMtAParams) && !pf.Verify(bobCtx, ec, pkA, NTildeA, h1A, h2A, cA, cB, B, optionalMtAParams...) {
 		return nil, errors.New("ProofBobWC.Verify() returned false")
 	}
 	alphaPrm, err := sk.Decrypt(cB)
 	if err != nil {
 		return nil, err
 	}
-	q := tss.EC().Params().N
+	q := ec.Params().N
 	return new(big.Int).Mod(alphaPrm, q), nil
 }
+
+// sampleBetaPrm samples Bob's blinding value beta' from Z_N, or from Z_{q^(SlackExp+2)} (q^5 in GG18) in strict mode
+func sampleBetaPrm(q *big.Int, pkA *paillier.PublicKey, mtaParams *tss.MtAProofParams) *big.Int {
+	if mtaParams.Strict {
+		return common.GetRandomPositiveInt(qPow(q, mtaParams.SlackExp+2))
+	}
+	return common.GetRandomPositiveInt(pkA.N)
+}
@@ -7,7 +7,12 @@
 package mta
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math/big"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -16,7 +21,7 @@ import (
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
 	"github.com/binance-chain/tss-lib/crypto/paillier"
-	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
@@ -26,7 +31,7 @@ const (
 )
 
 func TestShareProtocol(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 
 	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
 	assert.NoError(t, err)
@@ -34,18 +39,18 @@ func TestShareProtocol(t *testing.T) {
 	a := common.GetRandomPositiveInt(q)
 	b := common.GetRandomPositiveInt(q)
 
-	NTildei, h1i, h2i, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
 	assert.NoError(t, err)
-	NTildej, h1j, h2j, err := keygen.LoadNTildeH1H2FromTestFixture(1)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
 	assert.NoError(t, err)
 
-	cA, pf, err := AliceInit(pk, a, NTildej, h1j, h2j)
+	cA, pf, err := AliceInit(aliceCtx(), tss.S256(), pk, a, NTildej, h1j, h2j)
 	assert.NoError(t, err)
 
-	_, cB, betaPrm, pfB, err := BobMid(pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j)
+	_, cB, betaPrm, pfB, err := BobMid(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j)
 	assert.NoError(t, err)
 
-	alpha, err := AliceEnd(pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
+	alpha, err := AliceEnd(bobCtx(), tss.S256(), pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
 	assert.NoError(t, err)
 
 	// expect: alpha = ab + betaPrm
@@ -55,30 +60,60 @@ func TestShareProtocol(t *testing.T) {
 	assert.Equal(t, 0, alpha.Cmp(aTimesBPlusBetaModQ))
 }
 
+func TestShareProtocolFromPool(t *testing.T) {
+	q := tss.S256().Params().N
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+	pool := paillier.NewRandomnessPool(pk, 2)
+	defer pool.Stop()
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+
+	cA, _, pf, err := AliceInitFromPool(aliceCtx(), tss.S256(), pool, a, NTildej, h1j, h2j)
+	assert.NoError(t, err)
+
+	_, cB, betaPrm, pfB, err := BobMid(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j)
+	assert.NoError(t, err)
+
+	alpha, err := AliceEnd(bobCtx(), tss.S256(), pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
+	assert.NoError(t, err)
+
+	// expect: alpha = ab + betaPrm
+	aTimesBPlusBetaModQ := new(big.Int).Mod(new(big.Int).Add(new(big.Int).Mul(a, b), betaPrm), q)
+	assert.Equal(t, 0, alpha.Cmp(aTimesBPlusBetaModQ))
+}
+
 func TestShareProtocolWC(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 
 	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
 	assert.NoError(t, err)
 
 	a := common.GetRandomPositiveInt(q)
 	b := common.GetRandomPositiveInt(q)
-	gBX, gBY := tss.EC().ScalarBaseMult(b.Bytes())
+	gBX, gBY := tss.S256().ScalarBaseMult(b.Bytes())
 
-	NTildei, h1i, h2i, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
 	assert.NoError(t, err)
-	NTildej, h1j, h2j, err := keygen.LoadNTildeH1H2FromTestFixture(1)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
 	assert.NoError(t, err)
 
-	cA, pf, err := AliceInit(pk, a, NTildej, h1j, h2j)
+	cA, pf, err := AliceInit(aliceCtx(), tss.S256(), pk, a, NTildej, h1j, h2j)
 	assert.NoError(t, err)
 
-	gBPoint, err := crypto.NewECPoint(tss.EC(), gBX, gBY)
+	gBPoint, err := crypto.NewECPoint(tss.S256(), gBX, gBY)
 	assert.NoError(t, err)
-	_, cB, betaPrm, pfB, err := BobMidWC(pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	_, cB, betaPrm, pfB, err := BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
 	assert.NoError(t, err)
 
-	alpha, err := AliceEndWC(pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk)
+	alpha, err := AliceEndWC(bobCtx(), tss.S256(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk)
 	assert.NoError(t, err)
 
 	// expect: alpha = ab + betaPrm
@@ -87,3 +122,188 @@ func TestShareProtocolWC(t *testing.T) {
 	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
 	assert.Equal(t, 0, alpha.Cmp(aTimesBPlusBetaModQ))
 }
+
+func TestShareProtocolWCStrict(t *testing.T) {
+	q := tss.S256().Params().N
+	strict := tss.StrictMtAProofParams()
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+	gBPoint := crypto.ScalarBaseMult(tss.S256(), b)
+
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+
+	cA, pf, err := AliceInit(aliceCtx(), tss.S256(), pk, a, NTildej, h1j, h2j, strict)
+	assert.NoError(t, err)
+
+	_, cB, betaPrm, pfB, err := BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint, strict)
+	assert.NoError(t, err)
+	assert.True(t, betaPrm.Cmp(new(big.Int).Exp(q, big.NewInt(5), nil)) < 0, "beta' must be sampled below q^5")
+
+	alpha, err := AliceEndWC(bobCtx(), tss.S256(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk, strict)
+	assert.NoError(t, err)
+
+	// expect: alpha = ab + betaPrm
+	aTimesB := new(big.Int).Mul(a, b)
+	aTimesBPlusBeta := new(big.Int).Add(aTimesB, betaPrm)
+	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
+	assert.Equal(t, 0, alpha.Cmp(aTimesBPlusBetaModQ))
+
+	// a Bob proof made without strict bounds does not satisfy the t1 <= q^7 check
+	_, cB, _, pfB, err = BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	assert.NoError(t, err)
+	_, err = AliceEndWC(bobCtx(), tss.S256(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk, strict)
+	assert.Error(t, err, "a non-strict proof must not verify in strict mode")
+}
+
+func TestShareProtocolWCInconsistentB(t *testing.T) {
+	q := tss.S256().Params().N
+
+	_, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+	gBPoint := crypto.ScalarBaseMult(tss.S256(), b)
+
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+
+	cA, pf, err := AliceInit(aliceCtx(), tss.S256(), pk, a, NTildej, h1j, h2j)
+	assert.NoError(t, err)
+
+	// Bob inputs b+1 instead of the b behind his public point
+	bPlusOne := new(big.Int).Add(b, big.NewInt(1))
+	_, cB, _, pfB, err := BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, bPlusOne, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	assert.NoError(t, err)
+	_, err = AliceEndWC(bobCtx(), tss.S256(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, nil)
+	assert.Error(t, err, "an input inconsistent with B must be rejected")
+
+	// the check cannot be skipped by leaving out B, nor passed with a proof missing U
+	_, _, _, _, err = BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, nil)
+	assert.Error(t, err)
+	_, cB, _, pfB, err = BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	assert.NoError(t, err)
+	_, err = AliceEndWC(bobCtx(), tss.S256(), pk, pfB, nil, cA, cB, NTildei, h1i, h2i, nil)
+	assert.Error(t, err)
+	assert.False(t, (&ProofBobWC{ProofBob: pfB.ProofBob}).Verify(bobCtx(), tss.S256(), pk, NTildei, h1i, h2i, cA, cB, gBPoint))
+	assert.True(t, pfB.Verify(bobCtx(), tss.S256(), pk, NTildei, h1i, h2i, cA, cB, gBPoint))
+}
+
+func TestShareProtocolInsecureSkipVerify(t *testing.T) {
+	q := tss.S256().Params().N
+	insecure := &tss.MtAProofParams{SlackExp: 3, InsecureSkipVerify: true}
+	assert.NoError(t, insecure.Validate())
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+
+	cA, pf, err := AliceInit(aliceCtx(), tss.S256(), pk, a, NTildej, h1j, h2j)
+	assert.NoError(t, err)
+
+	// the range proofs are mandatory by default
+	badPf := *pf
+	badPf.S1 = new(big.Int).Add(pf.S1, big.NewInt(1))
+	_, _, _, _, err = BobMid(aliceCtx(), bobCtx(), tss.S256(), pk, &badPf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j)
+	assert.Error(t, err, "a bad Alice proof must be rejected by default")
+
+	_, cB, betaPrm, pfB, err := BobMid(aliceCtx(), bobCtx(), tss.S256(), pk, &badPf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, insecure)
+	assert.NoError(t, err)
+	badPfB := *pfB
+	badPfB.T1 = new(big.Int).Add(pfB.T1, big.NewInt(1))
+	_, err = AliceEnd(bobCtx(), tss.S256(), pk, &badPfB, h1i, h2i, cA, cB, NTildei, sk)
+	assert.Error(t, err, "a bad Bob proof must be rejected by default")
+
+	alpha, err := AliceEnd(bobCtx(), tss.S256(), pk, &badPfB, h1i, h2i, cA, cB, NTildei, sk, insecure)
+	assert.NoError(t, err)
+	aTimesBPlusBeta := new(big.Int).Add(new(big.Int).Mul(a, b), betaPrm)
+	assert.Equal(t, 0, alpha.Cmp(new(big.Int).Mod(aTimesBPlusBeta, q)))
+}
+
+func TestShareProtocolContext(t *testing.T) {
+	q := tss.S256().Params().N
+
+	_, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+	gBPoint := crypto.ScalarBaseMult(tss.S256(), b)
+
+	NTildei, h1i, h2i, err := loadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := loadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+
+	_, _, err = AliceInit(nil, tss.S256(), pk, a, NTildej, h1j, h2j)
+	assert.Error(t, err, "a proof must not be made without a context")
+
+	cA, pf, err := AliceInit(aliceCtx(), tss.S256(), pk, a, NTildej, h1j, h2j)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(aliceCtx(), tss.S256(), pk, NTildej, h1j, h2j, cA))
+	assert.False(t, pf.Verify(testContext("other session", 1), tss.S256(), pk, NTildej, h1j, h2j, cA),
+		"a proof of one session must not verify in another")
+	assert.False(t, pf.Verify(bobCtx(), tss.S256(), pk, NTildej, h1j, h2j, cA),
+		"a proof of one round must not verify in another")
+	assert.False(t, pf.Verify(nil, tss.S256(), pk, NTildej, h1j, h2j, cA))
+	_, _, _, _, err = BobMidWC(testContext("other session", 1), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	assert.Error(t, err)
+
+	_, cB, _, pfB, err := BobMidWC(aliceCtx(), bobCtx(), tss.S256(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	assert.NoError(t, err)
+	assert.True(t, pfB.Verify(bobCtx(), tss.S256(), pk, NTildei, h1i, h2i, cA, cB, gBPoint))
+	assert.False(t, pfB.Verify(testContext("other session", 2), tss.S256(), pk, NTildei, h1i, h2i, cA, cB, gBPoint),
+		"a proof of one session must not verify in another")
+	assert.False(t, pfB.Verify(aliceCtx(), tss.S256(), pk, NTildei, h1i, h2i, cA, cB, gBPoint),
+		"a proof of one round must not verify in another")
+}
+
+// testContext returns the context of the proofs of round `round` in the session `session` of the tests
+func testContext(session string, round int) *zkp.Transcript {
+	return zkp.NewSessionTranscript("test", []byte(session), round)
+}
+
+// aliceCtx returns the context of Alice's proofs in the tests
+func aliceCtx() *zkp.Transcript {
+	return testContext("session", 1)
+}
+
+// bobCtx returns the context of Bob's proofs in the tests
+func bobCtx() *zkp.Transcript {
+	return testContext("session", 2)
+}
+
+// loadNTildeH1H2FromTestFixture reads the NTilde, h1 and h2 of party `idx` from the ECDSA keygen test fixtures. It reads
+// the file itself, as the keygen package imports this one.
+func loadNTildeH1H2FromTestFixture(idx int) (NTildei, h1i, h2i *big.Int, err error) {
+	_, callerFileName, _, _ := runtime.Caller(0)
+	fixtureFilePath := fmt.Sprintf("%s/../../test/_ecdsa_fixtures/keygen_data_%d.json", filepath.Dir(callerFileName), idx)
+	bz, err := ioutil.ReadFile(fixtureFilePath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not open the test fixture for party %d in the expected location: %s. run keygen tests first: %v",
+			idx, fixtureFilePath, err)
+	}
+	var fixture struct {
+		NTildei, H1i, H2i *big.Int
+	}
+	if err = json.Unmarshal(bz, &fixture); err != nil {
+		return nil, nil, nil, err
+	}
+	return fixture.NTildei, fixture.H1i, fixture.H2i, nil
+}
@@ -0,0 +1,279 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// MultiScalarMult computes k_1*P_1 + ... + k_n*P_n with Straus' interleaving method: the doublings are shared by all
+// the points, and each point adds one of its precomputed odd multiples per non-zero digit of the wNAF of its scalar (see
+// scalar_mult.go). This is about twice as fast as n ScalarMults and n-1 Adds on secp256k1 for the n of the VSS checks,
+// and many times faster on the STARK curve, whose ScalarMult is the generic one. The points are in Jacobian coordinates throughout, with a single
+// inversion at the end, on short Weierstrass curves over fields of up to 256 bits. On the other curves, e.g. ed448, and
+// on P-256, whose ScalarMult in the standard library is in assembly, it falls back to ScalarMult and Add.
+
+type (
+	// a point in Jacobian coordinates (X/Z^2, Y/Z^3); Z = 0 is the point at infinity
+	jacobianPoint struct {
+		x, y, z fieldElement
+	}
+
+	// the field and the coefficient a of y^2 = x^3 + a*x + b, in the Montgomery form
+	weierstrassCurve struct {
+		f       *field256
+		a       fieldElement
+		aIsZero bool
+		one     fieldElement
+	}
+)
+
+var (
+	// elliptic.Curve -> *weierstrassCurve, or nil for the curves that are not short Weierstrass ones over a field of up
+	// to 256 bits
+	weierstrassCurves sync.Map
+	// elliptic.Curve -> the *big.Int a of y^2 = x^3 + a*x + b, or nil for the curves of other forms
+	shortWeierstrassAs sync.Map
+)
+
+// MultiScalarMult returns sum_i(scalars[i] * points[i]). It returns an error if the sum is the point at infinity.
+func MultiScalarMult(curve elliptic.Curve, points []*ECPoint, scalars []*big.Int) (*ECPoint, error) {
+	if curve == nil || len(points) == 0 || len(points) != len(scalars) {
+		return nil, errors.New("MultiScalarMult() expects a curve and as many scalars as points")
+	}
+	for i := range points {
+		if points[i] == nil || scalars[i] == nil {
+			return nil, fmt.Errorf("MultiScalarMult() received a nil point or scalar at %d", i)
+		}
+	}
+	wc := weierstrassCurveOf(curve)
+	if wc == nil {
+		return multiScalarMultSlow(curve, points, scalars)
+	}
+
+	// reduce the scalars mod the order of the group, recode them as wNAFs, and precompute the odd multiples of each point
+	N := curve.Params().N
+	digits := make([][]int, len(scalars))
+	maxDigits := 0
+	for i, k := range scalars {
+		digits[i] = wnaf(new(big.Int).Mod(k, N), wnafWidth)
+		if maxDigits < len(digits[i]) {
+			maxDigits = len(digits[i])
+		}
+	}
+	tables := wc.oddMultiplesTables(points, wnafWidth)
+	if tables == nil {
+		return multiScalarMultSlow(curve, points, scalars)
+	}
+
+	var acc jacobianPoint
+	for d := maxDigits - 1; 0 <= d; d-- {
+		wc.double(&acc, &acc)
+		for i := range digits {
+			if d < len(digits[i]) {
+				wc.addDigit(&acc, tables[i], digits[i][d])
+			}
+		}
+	}
+	if acc.z.isZero() {
+		return nil, errors.New("MultiScalarMult(): the sum is the point at infinity")
+	}
+	sum := []jacobianPoint{acc}
+	wc.batchToAffine(sum)
+	return NewECPoint(curve, wc.f.toBig(&sum[0].x), wc.f.toBig(&sum[0].y))
+}
+
+func multiScalarMultSlow(curve elliptic.Curve, points []*ECPoint, scalars []*big.Int) (*ECPoint, error) {
+	var err error
+	N := curve.Params().N
+	sum := points[0].SetCurve(curve).ScalarMult(new(big.Int).Mod(scalars[0], N))
+	for i := 1; i < len(points); i++ {
+		if sum, err = sum.Add(points[i].SetCurve(curve).ScalarMult(new(big.Int).Mod(scalars[i], N))); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+// weierstrassCurveOf returns the Jacobian arithmetic of a short Weierstrass curve over a field of up to 256 bits
+func weierstrassCurveOf(curve elliptic.Curve) *weierstrassCurve {
+	if wc, ok := weierstrassCurves.Load(curve); ok {
+		return wc.(*weierstrassCurve)
+	}
+	var wc *weierstrassCurve
+	defer func() { weierstrassCurves.Store(curve, wc) }()
+
+	f := newField256(curve.Params().P)
+	a := shortWeierstrassA(curve)
+	if f == nil || a == nil || curve == elliptic.P256() {
+		return nil
+	}
+	wc = &weierstrassCurve{f: f, a: f.fromBig(a), aIsZero: a.Sign() == 0, one: f.fromBig(big.NewInt(1))}
+	return wc
+}
+
+// shortWeierstrassA recovers a of y^2 = x^3 + a*x + b from the generator, as in LiftX, and checks that 2G is on that
+// curve too, which it is not for the curves of other forms, such as ed448 without a b in its params. It returns nil for
+// those.
+func shortWeierstrassA(curve elliptic.Curve) *big.Int {
+	if a, ok := shortWeierstrassAs.Load(curve); ok {
+		return a.(*big.Int)
+	}
+	var a *big.Int
+	defer func() { shortWeierstrassAs.Store(curve, a) }()
+
+	params := curve.Params()
+	P := params.P
+	if params.B == nil || params.Gx.Sign() == 0 {
+		return nil
+	}
+	coef := new(big.Int).Mul(params.Gy, params.Gy)
+	coef.Sub(coef, new(big.Int).Exp(params.Gx, big.NewInt(3), P)).Sub(coef, params.B)
+	coef.Mul(coef, new(big.Int).ModInverse(params.Gx, P)).Mod(coef, P)
+
+	x2, y2 := curve.Double(params.Gx, params.Gy)
+	left := new(big.Int).Mul(y2, y2)
+	right := new(big.Int).Mul(x2, x2)
+	right.Add(right, coef).Mul(right, x2).Add(right, params.B)
+	if left.Sub(left, right).Mod(left, P).Sign() != 0 {
+		return nil
+	}
+	a = coef
+	return a
+}
+
+// double sets p3 = 2*p1 by dbl-2007-bl of the Explicit-Formulas Database; p3 may alias p1
+func (wc *weierstrassCurve) double(p3, p1 *jacobianPoint) {
+	f := wc.f
+	if p1.z.isZero() || p1.y.isZero() {
+		*p3 = jacobianPoint{}
+		return
+	}
+	var xx, yy, yyyy, zz, s, m, t fieldElement
+	f.mul(&xx, &p1.x, &p1.x)
+	f.mul(&yy, &p1.y, &p1.y)
+	f.mul(&yyyy, &yy, &yy)
+	f.mul(&zz, &p1.z, &p1.z)
+	// s = 2*((x1+yy)^2-xx-yyyy)
+	f.add(&s, &p1.x, &yy)
+	f.mul(&s, &s, &s)
+	f.sub(&s, &s, &xx)
+	f.sub(&s, &s, &yyyy)
+	f.add(&s, &s, &s)
+	// m = 3*xx+a*zz^2
+	f.add(&m, &xx, &xx)
+	f.add(&m, &m, &xx)
+	if !wc.aIsZero {
+		f.mul(&t, &zz, &zz)
+		f.mul(&t, &t, &wc.a)
+		f.add(&m, &m, &t)
+	}
+	// z3 = (y1+z1)^2-yy-zz, before p1 is overwritten
+	var z3 fieldElement
+	f.add(&z3, &p1.y, &p1.z)
+	f.mul(&z3, &z3, &z3)
+	f.sub(&z3, &z3, &yy)
+	f.sub(&z3, &z3, &zz)
+	// x3 = m^2-2*s
+	var x3 fieldElement
+	f.mul(&x3, &m, &m)
+	f.sub(&x3, &x3, &s)
+	f.sub(&x3, &x3, &s)
+	// y3 = m*(s-x3)-8*yyyy
+	var y3 fieldElement
+	f.sub(&y3, &s, &x3)
+	f.mul(&y3, &m, &y3)
+	f.add(&yyyy, &yyyy, &yyyy)
+	f.add(&yyyy, &yyyy, &yyyy)
+	f.add(&yyyy, &yyyy, &yyyy)
+	f.sub(&y3, &y3, &yyyy)
+	p3.x, p3.y, p3.z = x3, y3, z3
+}
+
+// addAffine sets p3 = p1+p2 by madd-2007-bl of the Explicit-Formulas Database, for a p2 with z = 1; p3 may alias p1
+func (wc *weierstrassCurve) addAffine(p3, p1, p2 *jacobianPoint) {
+	f := wc.f
+	if p1.z.isZero() {
+		*p3 = *p2
+		return
+	}
+	var z1z1, u2, s2, h, r fieldElement
+	f.mul(&z1z1, &p1.z, &p1.z)
+	f.mul(&u2, &p2.x, &z1z1)
+	f.mul(&s2, &p1.z, &z1z1)
+	f.mul(&s2, &p2.y, &s2)
+	f.sub(&h, &u2, &p1.x)
+	f.sub(&r, &s2, &p1.y)
+	f.add(&r, &r, &r)
+	if h.isZero() {
+		if r.isZero() {
+			wc.double(p3, p1)
+			return
+		}
+		*p3 = jacobianPoint{}
+		return
+	}
+	var hh, i, j, v fieldElement
+	f.mul(&hh, &h, &h)
+	f.add(&i, &hh, &hh)
+	f.add(&i, &i, &i)
+	f.mul(&j, &h, &i)
+	f.mul(&v, &p1.x, &i)
+	// x3 = r^2-j-2*v
+	var x3 fieldElement
+	f.mul(&x3, &r, &r)
+	f.sub(&x3, &x3, &j)
+	f.sub(&x3, &x3, &v)
+	f.sub(&x3, &x3, &v)
+	// y3 = r*(v-x3)-2*y1*j
+	var y3, y1j fieldElement
+	f.sub(&y3, &v, &x3)
+	f.mul(&y3, &r, &y3)
+	f.mul(&y1j, &p1.y, &j)
+	f.sub(&y3, &y3, &y1j)
+	f.sub(&y3, &y3, &y1j)
+	// z3 = (z1+h)^2-z1z1-hh
+	var z3 fieldElement
+	f.add(&z3, &p1.z, &h)
+	f.mul(&z3, &z3, &z3)
+	f.sub(&z3, &z3, &z1z1)
+	f.sub(&z3, &z3, &hh)
+	p3.x, p3.y, p3.z = x3, y3, z3
+}
+
+// batchToAffine sets z = 1 for all the points, which must not be at infinity, with Montgomery's trick
+func (wc *weierstrassCurve) batchToAffine(points []jacobianPoint) {
+	if len(points) == 0 {
+		return
+	}
+	f := wc.f
+	// prefix[i] = z_0 * ... * z_i
+	prefix := make([]fieldElement, len(points))
+	prefix[0] = points[0].z
+	for i := 1; i < len(points); i++ {
+		f.mul(&prefix[i], &prefix[i-1], &points[i].z)
+	}
+	// invert the product of all the z once, out of and back into the Montgomery form
+	inv := f.fromBig(new(big.Int).ModInverse(f.toBig(&prefix[len(points)-1]), f.pBig))
+	for i := len(points) - 1; 0 <= i; i-- {
+		zInv := inv
+		if 0 < i {
+			f.mul(&zInv, &inv, &prefix[i-1])
+			f.mul(&inv, &inv, &points[i].z)
+		}
+		var zInv2, zInv3 fieldElement
+		f.mul(&zInv2, &zInv, &zInv)
+		f.mul(&zInv3, &zInv2, &zInv)
+		f.mul(&points[i].x, &points[i].x, &zInv2)
+		f.mul(&points[i].y, &points[i].y, &zInv3)
+		points[i].z = wc.one
+	}
+}
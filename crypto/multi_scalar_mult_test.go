@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestMultiScalarMult(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.S256(), elliptic.P256(), elliptic.P256().Params(), stark.Curve(), ed448.Curve()} {
+		q := curve.Params().N
+		for _, n := range []int{1, 2, 5, 21} {
+			points := make([]*ECPoint, n)
+			scalars := make([]*big.Int, n)
+			for i := range points {
+				points[i] = ScalarBaseMult(curve, common.GetRandomPositiveInt(q))
+				scalars[i] = common.GetRandomPositiveInt(q)
+			}
+			// a small and a negative scalar
+			scalars[0] = big.NewInt(3)
+			if 1 < n {
+				scalars[1] = new(big.Int).Neg(scalars[1])
+			}
+			expected := expectedMultiScalarMult(t, curve, points, scalars)
+
+			sum, err := MultiScalarMult(curve, points, scalars)
+			assert.NoError(t, err, curve.Params().Name)
+			assert.True(t, expected.Equals(sum), "%s, n = %d", curve.Params().Name, n)
+		}
+
+		// P + P and P - P
+		P := ScalarBaseMult(curve, common.GetRandomPositiveInt(q))
+		sum, err := MultiScalarMult(curve, []*ECPoint{P, P}, []*big.Int{big.NewInt(1), big.NewInt(1)})
+		assert.NoError(t, err)
+		assert.True(t, P.ScalarMult(big.NewInt(2)).Equals(sum))
+		if curve != ed448.Curve() { // the identity of ed448 is a point of its ECPoints
+			_, err = MultiScalarMult(curve, []*ECPoint{P, P}, []*big.Int{big.NewInt(1), new(big.Int).Sub(q, big.NewInt(1))})
+			assert.Error(t, err)
+		}
+	}
+
+	_, err := MultiScalarMult(tss.S256(), nil, nil)
+	assert.Error(t, err)
+	_, err = MultiScalarMult(tss.S256(), []*ECPoint{ScalarBaseMult(tss.S256(), big.NewInt(1))}, nil)
+	assert.Error(t, err)
+}
+
+func expectedMultiScalarMult(t *testing.T, curve elliptic.Curve, points []*ECPoint, scalars []*big.Int) *ECPoint {
+	var err error
+	q := curve.Params().N
+	sum := points[0].ScalarMult(new(big.Int).Mod(scalars[0], q))
+	for i := 1; i < len(points); i++ {
+		sum, err = sum.Add(points[i].ScalarMult(new(big.Int).Mod(scalars[i], q)))
+		assert.NoError(t, err)
+	}
+	return sum
+}
+
+func BenchmarkMultiScalarMult(b *testing.B) {
+	points, scalars := benchmarkMultiScalarMultInputs(11)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = MultiScalarMult(tss.S256(), points, scalars)
+	}
+}
+
+func BenchmarkScalarMultAndAdd(b *testing.B) {
+	points, scalars := benchmarkMultiScalarMultInputs(11)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := points[0].ScalarMult(scalars[0])
+		for j := 1; j < len(points); j++ {
+			sum, _ = sum.Add(points[j].ScalarMult(scalars[j]))
+		}
+	}
+}
+
+func benchmarkMultiScalarMultInputs(n int) ([]*ECPoint, []*big.Int) {
+	q := tss.S256().Params().N
+	points := make([]*ECPoint, n)
+	scalars := make([]*big.Int, n)
+	for i := range points {
+		points[i] = ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+		scalars[i] = common.GetRandomPositiveInt(q)
+	}
+	return points, scalars
+}
@@ -0,0 +1,149 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/otiai10/primes"
+
+	"github.com/binance-chain/tss-lib/common"
+	crypto2 "github.com/binance-chain/tss-lib/crypto"
+)
+
+// BatchVerify checks the Paillier proofs of a whole committee at once. Its work is split into one task per proof
+// (the small factor check and the xs) and one task per exponentiation, spread over a pool of workers, so that the
+// proofs of many parties, and the ProofIters exponentiations of each, run in parallel.
+// The small factor check is a single GCD of N with the product of the primes below verifyPrimesUntil.
+//
+// The checks are not folded into a random linear combination (y_1^(e_1) * ... * y_m^(e_m))^N = x_1^(e_1) * ... *
+// x_m^(e_m). The proofs of different parties use different moduli, so no combination spans them. Within one proof the
+// combination is not sound: a dishonest modulus may have a prime factor r > verifyPrimesUntil that divides phi(N),
+// and a combination then accepts x_i that are not N-th residues with probability about 1/r, instead of about
+// r^-ProofIters for the separate checks.
+
+type (
+	// ProofStatement is a Proof with the modulus and the party key that it was made for
+	ProofStatement struct {
+		Proof Proof
+		N, K  *big.Int
+	}
+)
+
+var (
+	ErrInvalidProof = errors.New("paillier proof verify: the proof is invalid")
+
+	// the product of the primes below verifyPrimesUntil
+	smallPrimesProduct *big.Int
+)
+
+func init() {
+	smallPrimesProduct = big.NewInt(1)
+	for _, prm := range primes.Until(verifyPrimesUntil).List() {
+		smallPrimesProduct.Mul(smallPrimesProduct, big.NewInt(prm))
+	}
+}
+
+// BatchVerify checks each statement as Proof.Verify does, for the same ECDSA public key, with as many workers as
+// CPUs or the number given in `optionalConcurrency`. It returns one error per statement, nil for a valid proof and
+// ErrInvalidProof for one that fails.
+func BatchVerify(statements []ProofStatement, ecdsaPub *crypto2.ECPoint, optionalConcurrency ...int) []error {
+	var concurrency int
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("BatchVerify: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	} else {
+		concurrency = runtime.NumCPU()
+	}
+	errs := make([]error, len(statements))
+	if ecdsaPub == nil {
+		for j := range errs {
+			errs[j] = errors.New("paillier proof verify: the ECDSA public key is nil")
+		}
+		return errs
+	}
+
+	// 1. the small factor check and the xs of each proof
+	xss := make([][]*big.Int, len(statements))
+	fanOut(concurrency, len(statements), func(j int) {
+		st := statements[j]
+		if st.N == nil || st.K == nil || st.N.Sign() <= 0 {
+			errs[j] = errors.New("paillier proof verify: the statement is missing N or K")
+			return
+		}
+		for _, yi := range st.Proof {
+			if yi == nil {
+				errs[j] = ErrInvalidProof
+				return
+			}
+		}
+		if new(big.Int).GCD(nil, nil, st.N, smallPrimesProduct).Cmp(one) != 0 {
+			errs[j] = ErrInvalidProof
+			return
+		}
+		xs := GenerateXs(ProofIters, st.K, st.N, ecdsaPub)
+		if len(xs) != ProofIters {
+			errs[j] = fmt.Errorf("paillier proof verify: expected %d xs but got %d", ProofIters, len(xs))
+			return
+		}
+		xss[j] = xs
+	})
+
+	// 2. y_i^N = x_i mod N for every i of every proof that is left
+	failed := make([][ProofIters]bool, len(statements))
+	fanOut(concurrency, len(statements)*ProofIters, func(task int) {
+		j, i := task/ProofIters, task%ProofIters
+		if errs[j] != nil {
+			return
+		}
+		N := statements[j].N
+		xiModN := new(big.Int).Mod(xss[j][i], N)
+		yiExpN := common.ModInt(N).Exp(statements[j].Proof[i], N)
+		failed[j][i] = xiModN.Cmp(yiExpN) != 0
+	})
+	for j := range statements {
+		if errs[j] != nil {
+			continue
+		}
+		for _, f := range failed[j] {
+			if f {
+				errs[j] = ErrInvalidProof
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// fanOut runs f(0), ..., f(count-1) on `concurrency` workers and waits for them
+func fanOut(concurrency, count int, f func(int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	tasks := make(chan int, count)
+	for t := 0; t < count; t++ {
+		tasks <- t
+	}
+	close(tasks)
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				f(t)
+			}
+		}()
+	}
+	wg.Wait()
+}
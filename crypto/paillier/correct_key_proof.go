@@ -0,0 +1,129 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto/facproof"
+	"github.com/binance-chain/tss-lib/crypto/zkp/modproof"
+)
+
+// The proof of a correct key follows Canetti, Gennaro, Goldfeder, Makriyannis and Peled: UC Non-Interactive, Proactive,
+// Threshold ECDSA with Identifiable Aborts (2021). The modulus proof of Fig. 16 shows that N is the product of two
+// primes that are 3 mod 4, which is square-free, and the no small factor proof of Fig. 28 shows that both primes are
+// larger than about 2^ℓ. The key Proof of GG18 only shows knowledge of the factorization, which a dishonest party can
+// have for a modulus with small factors, and that leaks the secret shares to it through the MtA.
+
+const (
+	CorrectKeyProofIters = modproof.Iterations
+	// CorrectKeyProofBytesParts is the number of parts of a serialized CorrectKeyProof
+	CorrectKeyProofBytesParts = modproof.ProofBytesParts + facproof.ProofBytesParts
+)
+
+type (
+	// CorrectKeyProof proves that a Paillier modulus is the product of two large primes that are 3 mod 4, to the
+	// holder of the ring-Pedersen parameters (NTilde, h1, h2) used to make it
+	CorrectKeyProof struct {
+		Mod ModulusProof
+		Fac FactorProof
+	}
+
+	// ModulusProof is the Paillier-Blum modulus proof of CGGMP21 Fig. 16, see package modproof
+	ModulusProof modproof.Proof
+
+	// FactorProof is the no small factor proof of CGGMP21 Fig. 28, see package facproof
+	FactorProof facproof.Proof
+)
+
+// ProofOfCorrectKey proves that the key is a Paillier-Blum modulus without small factors to a verifier with the
+// ring-Pedersen parameters (NTilde, h1, h2)
+func (privateKey *PrivateKey) ProofOfCorrectKey(NTilde, h1, h2 *big.Int) (*CorrectKeyProof, error) {
+	if NTilde == nil || h1 == nil || h2 == nil {
+		return nil, errors.New("ProofOfCorrectKey() received nil value(s)")
+	}
+	P, Q, err := privateKey.primes()
+	if err != nil {
+		return nil, err
+	}
+	mod, err := newModulusProof(privateKey.N, P, Q)
+	if err != nil {
+		return nil, err
+	}
+	fac, err := newFactorProof(privateKey.N, P, Q, NTilde, h1, h2)
+	if err != nil {
+		return nil, err
+	}
+	return &CorrectKeyProof{Mod: *mod, Fac: *fac}, nil
+}
+
+// Verify checks the proof that `pkN` is a Paillier-Blum modulus without small factors, with the verifier's own
+// ring-Pedersen parameters (NTilde, h1, h2)
+func (pf *CorrectKeyProof) Verify(pkN, NTilde, h1, h2 *big.Int) bool {
+	if pf == nil || pkN == nil || NTilde == nil || h1 == nil || h2 == nil {
+		return false
+	}
+	return pf.Mod.Verify(pkN) && pf.Fac.Verify(pkN, NTilde, h1, h2)
+}
+
+// ----- //
+
+func newModulusProof(N, P, Q *big.Int) (*ModulusProof, error) {
+	exponentiatorMtx.RLock()
+	e := exponentiator
+	exponentiatorMtx.RUnlock()
+	pf, err := modproof.NewProofWithExponentiator(N, P, Q, e)
+	return (*ModulusProof)(pf), err
+}
+
+// Verify checks that N is the product of two primes that are 3 mod 4, with gcd(N, phi(N)) = 1
+func (pf *ModulusProof) Verify(N *big.Int) bool {
+	return (*modproof.Proof)(pf).Verify(N)
+}
+
+// ----- //
+
+func newFactorProof(N, P, Q, NTilde, s, t *big.Int) (*FactorProof, error) {
+	exponentiatorMtx.RLock()
+	e := exponentiator
+	exponentiatorMtx.RUnlock()
+	pf, err := facproof.NewProofWithExponentiator(N, P, Q, NTilde, s, t, e)
+	return (*FactorProof)(pf), err
+}
+
+// Verify checks that both prime factors of N are larger than about 2^ℓ, with the verifier's own ring-Pedersen
+// parameters (NTilde, s, t)
+func (pf *FactorProof) Verify(N, NTilde, s, t *big.Int) bool {
+	return (*facproof.Proof)(pf).Verify(N, NTilde, s, t)
+}
+
+// ----- //
+
+// Serialize returns the CorrectKeyProofBytesParts parts of the proof, for a proto message
+func (pf *CorrectKeyProof) Serialize() [][]byte {
+	modBzs := (*modproof.Proof)(&pf.Mod).Bytes()
+	facBzs := (*facproof.Proof)(&pf.Fac).Bytes()
+	return append(modBzs[:], facBzs[:]...)
+}
+
+func UnmarshalCorrectKeyProof(bzs [][]byte) (*CorrectKeyProof, error) {
+	if len(bzs) != CorrectKeyProofBytesParts {
+		return nil, fmt.Errorf("UnmarshalCorrectKeyProof expected %d parts but got %d", CorrectKeyProofBytesParts, len(bzs))
+	}
+	facBzs := bzs[len(bzs)-facproof.ProofBytesParts:]
+	fac, err := facproof.ProofFromBytes(facBzs)
+	if err != nil {
+		return nil, fmt.Errorf("UnmarshalCorrectKeyProof: %v", err)
+	}
+	mod, err := modproof.ProofFromBytes(bzs[:modproof.ProofBytesParts])
+	if err != nil {
+		return nil, fmt.Errorf("UnmarshalCorrectKeyProof: %v", err)
+	}
+	return &CorrectKeyProof{Mod: ModulusProof(*mod), Fac: FactorProof(*fac)}, nil
+}
@@ -0,0 +1,169 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// The Damgård-Jurik generalization (Damgård, I., Jurik, M.: A Generalisation, a Simplification and Some Applications of
+// Paillier's Probabilistic Public-Key System. PKC 2001) encrypts plaintexts mod N^s into ciphertexts mod N^(s+1) with
+// the same key, for any s >= 1. A ciphertext is (1+N)^m * r^(N^s) mod N^(s+1), and s = 1 is Paillier.
+// Its ciphertext expansion is (s+1)/s, so it suits large plaintexts better than many Paillier ciphertexts would.
+
+type (
+	// GeneralizedPublicKey does not embed PublicKey, whose methods and encodings are those of s = 1
+	GeneralizedPublicKey struct {
+		N *big.Int
+		S int
+	}
+
+	GeneralizedPrivateKey struct {
+		GeneralizedPublicKey
+		LambdaN, // lcm(p-1, q-1)
+		PhiN *big.Int // (p-1) * (q-1)
+	}
+)
+
+var (
+	ErrInvalidS = errors.New("the Damgård-Jurik exponent s must be at least 1")
+)
+
+// Generalized returns the Damgård-Jurik public key with the exponent `s` for the modulus of this key
+func (publicKey *PublicKey) Generalized(s int) (*GeneralizedPublicKey, error) {
+	if s < 1 {
+		return nil, ErrInvalidS
+	}
+	return &GeneralizedPublicKey{N: publicKey.N, S: s}, nil
+}
+
+// Generalized returns the Damgård-Jurik private key with the exponent `s` for this key
+func (privateKey *PrivateKey) Generalized(s int) (*GeneralizedPrivateKey, error) {
+	pk, err := privateKey.PublicKey.Generalized(s)
+	if err != nil {
+		return nil, err
+	}
+	return &GeneralizedPrivateKey{GeneralizedPublicKey: *pk, LambdaN: privateKey.LambdaN, PhiN: privateKey.PhiN}, nil
+}
+
+// Gamma returns the generator 1+N
+func (publicKey *GeneralizedPublicKey) Gamma() *big.Int {
+	return new(big.Int).Add(publicKey.N, one)
+}
+
+// PlaintextModulus returns N^s
+func (publicKey *GeneralizedPublicKey) PlaintextModulus() *big.Int {
+	return new(big.Int).Exp(publicKey.N, big.NewInt(int64(publicKey.S)), nil)
+}
+
+// CiphertextModulus returns N^(s+1)
+func (publicKey *GeneralizedPublicKey) CiphertextModulus() *big.Int {
+	return new(big.Int).Exp(publicKey.N, big.NewInt(int64(publicKey.S+1)), nil)
+}
+
+// EncryptAndReturnRandomness returns the encryption (1+N)^m * x^(N^s) mod N^(s+1) of `m` and its randomness x
+func (publicKey *GeneralizedPublicKey) EncryptAndReturnRandomness(m *big.Int) (c *big.Int, x *big.Int, err error) {
+	Ns, Ns1 := publicKey.PlaintextModulus(), publicKey.CiphertextModulus()
+	if m.Cmp(zero) == -1 || m.Cmp(Ns) != -1 { // m < 0 || m >= N^s ?
+		return nil, nil, ErrMessageTooLong
+	}
+	x = common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
+	modNs1 := common.ModInt(Ns1)
+	// 1. gamma^m mod N^(s+1)
+	Gm := modNs1.Exp(publicKey.Gamma(), m)
+	// 2. x^(N^s) mod N^(s+1)
+	xNs := modNs1.Exp(x, Ns)
+	// 3. (1) * (2) mod N^(s+1)
+	c = modNs1.Mul(Gm, xNs)
+	return
+}
+
+func (publicKey *GeneralizedPublicKey) Encrypt(m *big.Int) (c *big.Int, err error) {
+	c, _, err = publicKey.EncryptAndReturnRandomness(m)
+	return
+}
+
+// ValidateCiphertext checks that `c` is in Z*_{N^(s+1)}
+func (publicKey *GeneralizedPublicKey) ValidateCiphertext(c *big.Int) error {
+	if c == nil || c.Sign() <= 0 || c.Cmp(publicKey.CiphertextModulus()) != -1 {
+		return ErrInvalidCiphertext
+	}
+	if new(big.Int).GCD(nil, nil, c, publicKey.N).Cmp(one) != 0 {
+		return ErrInvalidCiphertext
+	}
+	return nil
+}
+
+// HomoMulPlaintext returns c^m mod N^(s+1), an encryption of the plaintext of `c` times `m`
+func (publicKey *GeneralizedPublicKey) HomoMulPlaintext(m, c *big.Int) (*big.Int, error) {
+	if m == nil || m.Cmp(zero) == -1 || m.Cmp(publicKey.PlaintextModulus()) != -1 { // m < 0 || m >= N^s ?
+		return nil, ErrMessageTooLong
+	}
+	if err := publicKey.ValidateCiphertext(c); err != nil {
+		return nil, err
+	}
+	return common.ModInt(publicKey.CiphertextModulus()).Exp(c, m), nil
+}
+
+// HomoAdd returns c1 * c2 mod N^(s+1), an encryption of the sum of the plaintexts of `c1` and `c2`
+func (publicKey *GeneralizedPublicKey) HomoAdd(c1, c2 *big.Int) (*big.Int, error) {
+	if err := publicKey.ValidateCiphertext(c1); err != nil {
+		return nil, err
+	}
+	if err := publicKey.ValidateCiphertext(c2); err != nil {
+		return nil, err
+	}
+	return common.ModInt(publicKey.CiphertextModulus()).Mul(c1, c2), nil
+}
+
+// Decrypt returns the plaintext mod N^s of `c`: c^lambda = (1+N)^(m*lambda) mod N^(s+1), from which m*lambda is
+// recovered one power of N at a time
+func (privateKey *GeneralizedPrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
+	if err = privateKey.ValidateCiphertext(c); err != nil {
+		return nil, err
+	}
+	Ns, Ns1 := privateKey.PlaintextModulus(), privateKey.CiphertextModulus()
+	// the order of Z*_{N^(s+1)} is phi(N) * N^s
+	a := secretExp(c, privateKey.LambdaN, Ns1, new(big.Int).Mul(privateKey.PhiN, Ns))
+	mLambda := discreteLogOnePlusN(a, privateKey.N, privateKey.S)
+	lambdaInv := new(big.Int).ModInverse(privateKey.LambdaN, Ns)
+	if lambdaInv == nil {
+		return nil, errors.New("paillier: LambdaN is not invertible mod N^s")
+	}
+	return common.ModInt(Ns).Mul(mLambda, lambdaInv), nil
+}
+
+// discreteLogOnePlusN returns i mod N^s for a = (1+N)^i mod N^(s+1), following section 3 of the Damgård-Jurik paper.
+// Each step j finds i mod N^j from L(a mod N^(j+1)) = i + C(i,2)*N + ... + C(i,j)*N^(j-1) mod N^j.
+func discreteLogOnePlusN(a, N *big.Int, s int) *big.Int {
+	i := new(big.Int)
+	Nj := new(big.Int).Set(one) // N^j
+	for j := 1; j <= s; j++ {
+		Nj.Mul(Nj, N)
+		Nj1 := new(big.Int).Mul(Nj, N)
+		modNj := common.ModInt(Nj)
+		t1 := L(new(big.Int).Mod(a, Nj1), N)
+		t2 := new(big.Int).Set(i)
+		Nk := new(big.Int).Set(one) // N^(k-1)
+		kFactorial := big.NewInt(1)
+		for k := 2; k <= j; k++ {
+			i.Sub(i, one)
+			t2 = modNj.Mul(t2, i)
+			Nk.Mul(Nk, N)
+			kFactorial.Mul(kFactorial, big.NewInt(int64(k)))
+			// t1 = t1 - t2 * N^(k-1) / k! mod N^j
+			term := modNj.Mul(t2, Nk)
+			term = modNj.Mul(term, new(big.Int).ModInverse(kFactorial, Nj))
+			t1 = modNj.Sub(t1, term)
+		}
+		i = t1
+	}
+	return i
+}
@@ -0,0 +1,69 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// The private key operations of this package (decryption, the key proofs and threshold decryption shares) do every
+// exponentiation that has a secret exponent or modulus through an Exponentiator. math/big is not constant time, so an
+// application that must resist timing side channels can plug in a constant-time bignum library with SetExponentiator.
+// When the order of the group is known, the secret exponent is also blinded with a random multiple of the order, so
+// that repeated operations do not run on the same exponent bits.
+
+const (
+	// the bit length of the random multiple of the group order added to a blinded exponent
+	exponentBlindingBits = 64
+)
+
+type (
+	// Exponentiator computes x^y mod m for x in [0, m) and y >= 0
+	Exponentiator interface {
+		Exp(x, y, m *big.Int) *big.Int
+	}
+
+	// BigExponentiator is the default Exponentiator, the BigNumBackend of package common (math/big, or GMP when built
+	// with the `gmp` tag). Its running time depends on its operands.
+	BigExponentiator struct{}
+)
+
+var (
+	exponentiatorMtx sync.RWMutex
+	exponentiator    Exponentiator = BigExponentiator{}
+)
+
+func (BigExponentiator) Exp(x, y, m *big.Int) *big.Int {
+	return common.ModInt(m).Exp(x, y)
+}
+
+// SetExponentiator replaces the Exponentiator of the private key operations, e.g. with a constant-time one. It should
+// be set before any key is used. A nil Exponentiator restores BigExponentiator.
+func SetExponentiator(e Exponentiator) {
+	exponentiatorMtx.Lock()
+	defer exponentiatorMtx.Unlock()
+	if e == nil {
+		e = BigExponentiator{}
+	}
+	exponentiator = e
+}
+
+// secretExp returns x^y mod m with the Exponentiator. If `order` is not nil, it must be a multiple of the order of x
+// mod m, and y is blinded with a random multiple of it.
+func secretExp(x, y, m, order *big.Int) *big.Int {
+	if order != nil {
+		k := common.MustGetRandomInt(exponentBlindingBits)
+		y = new(big.Int).Add(y, k.Mul(k, order))
+	}
+	exponentiatorMtx.RLock()
+	e := exponentiator
+	exponentiatorMtx.RUnlock()
+	return e.Exp(new(big.Int).Mod(x, m), y, m)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// Encryption costs two exponentiations: gamma^m and x^N mod N^2. With gamma = 1+N the first is 1 + m*N mod N^2 and
+// needs no table. For the second, Precompute fixes a random g in Z*_N and draws the randomness as x = g^a mod N for a
+// random a, following Damgård, Jurik and Nielsen: A Generalization of Paillier's Public-Key System with Applications
+// to Electronic Voting (2010). Then x^N = (g^N)^a mod N^2, and both g and g^N are fixed bases, whose powers come
+// from window tables with one multiplication per window and no squarings.
+// a is |N| + fixedBaseHidingBits bits long, so x is statistically close to uniform in the subgroup generated by g.
+
+const (
+	fixedBaseHidingBits = 128
+	// MaxFixedBaseWindow bounds the window of Precompute; the tables hold (2^window - 1) * (|N| + 128) / window
+	// integers mod N and as many mod N^2
+	MaxFixedBaseWindow = 8
+)
+
+type (
+	fixedBase struct {
+		g, gN   *fixedBaseTable // g mod N, g^N mod N^2
+		expBits int
+	}
+
+	// fixedBaseTable holds base^(d * 2^(window*i)) mod m in rows[i][d-1], for d in [1, 2^window)
+	fixedBaseTable struct {
+		m      *big.Int
+		window uint
+		rows   [][]*big.Int
+	}
+)
+
+// Precompute caches fixed-base window tables on the key to speed up its later encryptions, with `window` bits per
+// table row. It should be called before the key is shared between goroutines.
+func (publicKey *PublicKey) Precompute(window int) error {
+	if window < 1 || MaxFixedBaseWindow < window {
+		return errors.New("paillier: the window of Precompute must be in [1, MaxFixedBaseWindow]")
+	}
+	if publicKey.N == nil || publicKey.N.Sign() <= 0 {
+		return errors.New("paillier: the public key has no modulus")
+	}
+	expBits := publicKey.N.BitLen() + fixedBaseHidingBits
+	g := common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
+	N2 := publicKey.NSquare()
+	gN := common.ModInt(N2).Exp(g, publicKey.N)
+	publicKey.fixedBase = &fixedBase{
+		g:       newFixedBaseTable(g, publicKey.N, uint(window), expBits),
+		gN:      newFixedBaseTable(gN, N2, uint(window), expBits),
+		expBits: expBits,
+	}
+	return nil
+}
+
+// randomness returns a random x in Z*_N and x^N mod N^2, from the tables when the key has them
+func (publicKey *PublicKey) randomness() (x, xN *big.Int) {
+	if fb := publicKey.fixedBase; fb != nil {
+		a := common.MustGetRandomInt(fb.expBits)
+		return fb.g.exp(a), fb.gN.exp(a)
+	}
+	x = common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
+	return x, common.ModInt(publicKey.NSquare()).Exp(x, publicKey.N)
+}
+
+// ----- //
+
+func newFixedBaseTable(base, m *big.Int, window uint, expBits int) *fixedBaseTable {
+	modM := common.ModInt(m)
+	rowCount := (expBits + int(window) - 1) / int(window)
+	t := &fixedBaseTable{m: m, window: window, rows: make([][]*big.Int, rowCount)}
+	cur := new(big.Int).Mod(base, m) // base^(2^(window*i))
+	for i := range t.rows {
+		row := make([]*big.Int, (1<<window)-1)
+		row[0] = cur
+		for d := 1; d < len(row); d++ {
+			row[d] = modM.Mul(row[d-1], cur)
+		}
+		t.rows[i] = row
+		cur = modM.Mul(row[len(row)-1], cur)
+	}
+	return t
+}
+
+// exp returns base^e mod m for 0 <= e < 2^(window*len(rows))
+func (t *fixedBaseTable) exp(e *big.Int) *big.Int {
+	modM := common.ModInt(t.m)
+	r := new(big.Int).Set(one)
+	for i, row := range t.rows {
+		d := uint(0)
+		for k := uint(0); k < t.window; k++ {
+			d |= e.Bit(i*int(t.window)+int(k)) << k
+		}
+		if d != 0 {
+			r = modM.Mul(r, row[d-1])
+		}
+	}
+	return r
+}
@@ -0,0 +1,248 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Keys are checked when they are loaded, so that a malformed key fails there rather than in a later ceremony.
+// The JSON encodings are those of the plain structs, so that existing save data still loads. The binary encodings are
+// the integers of the key, each as a 4-byte big-endian length followed by the minimal big-endian bytes.
+
+const (
+	// MinModulusLen is the shortest Paillier modulus accepted when a PublicKey or PrivateKey is loaded
+	MinModulusLen = 2048
+)
+
+type (
+	// the JSON encodings of the keys, without their methods
+	publicKeyJSON struct {
+		N *big.Int
+	}
+
+	privateKeyJSON struct {
+		N, LambdaN, PhiN *big.Int
+	}
+
+	thresholdPublicKeyJSON struct {
+		N                     *big.Int
+		Threshold, PartyCount int
+		V                     *big.Int
+	}
+)
+
+// Validate checks that N is odd and at least MinModulusLen bits long
+func (publicKey *PublicKey) Validate() error {
+	if publicKey == nil || publicKey.N == nil {
+		return errors.New("paillier: the public key has no modulus")
+	}
+	if publicKey.N.Bit(0) == 0 {
+		return errors.New("paillier: the modulus must be odd")
+	}
+	if publicKey.N.BitLen() < MinModulusLen {
+		return fmt.Errorf("paillier: the modulus must be at least %d bits long, got %d", MinModulusLen, publicKey.N.BitLen())
+	}
+	return nil
+}
+
+// Validate checks the public key, that PhiN gives two primes p and q with p*q = N, and that LambdaN is lcm(p-1, q-1)
+func (privateKey *PrivateKey) Validate() error {
+	if privateKey == nil {
+		return errors.New("paillier: the private key is nil")
+	}
+	if err := privateKey.PublicKey.Validate(); err != nil {
+		return err
+	}
+	if privateKey.LambdaN == nil || privateKey.PhiN == nil {
+		return errors.New("paillier: the private key is missing LambdaN or PhiN")
+	}
+	P, Q, err := privateKey.primes()
+	if err != nil {
+		return err
+	}
+	if !P.ProbablyPrime(20) || !Q.ProbablyPrime(20) {
+		return errors.New("paillier: the factors of the modulus must be prime")
+	}
+	PMinus1, QMinus1 := new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one)
+	gcd := new(big.Int).GCD(nil, nil, PMinus1, QMinus1)
+	if new(big.Int).Div(privateKey.PhiN, gcd).Cmp(privateKey.LambdaN) != 0 {
+		return errors.New("paillier: LambdaN must be lcm(p-1, q-1)")
+	}
+	return nil
+}
+
+// ----- //
+
+func (publicKey *PublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&publicKeyJSON{N: publicKey.N})
+}
+
+func (publicKey *PublicKey) UnmarshalJSON(payload []byte) error {
+	aux := new(publicKeyJSON)
+	if err := json.Unmarshal(payload, aux); err != nil {
+		return err
+	}
+	pk := PublicKey{N: aux.N}
+	if err := pk.Validate(); err != nil {
+		return err
+	}
+	*publicKey = pk
+	return nil
+}
+
+func (publicKey *PublicKey) MarshalBinary() ([]byte, error) {
+	return marshalInts(publicKey.N)
+}
+
+func (publicKey *PublicKey) UnmarshalBinary(data []byte) error {
+	ints, err := unmarshalInts(data, 1)
+	if err != nil {
+		return err
+	}
+	pk := PublicKey{N: ints[0]}
+	if err := pk.Validate(); err != nil {
+		return err
+	}
+	*publicKey = pk
+	return nil
+}
+
+func (privateKey *PrivateKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&privateKeyJSON{N: privateKey.N, LambdaN: privateKey.LambdaN, PhiN: privateKey.PhiN})
+}
+
+func (privateKey *PrivateKey) UnmarshalJSON(payload []byte) error {
+	aux := new(privateKeyJSON)
+	if err := json.Unmarshal(payload, aux); err != nil {
+		return err
+	}
+	sk := PrivateKey{PublicKey: PublicKey{N: aux.N}, LambdaN: aux.LambdaN, PhiN: aux.PhiN}
+	if err := sk.Validate(); err != nil {
+		return err
+	}
+	*privateKey = sk
+	return nil
+}
+
+func (privateKey *PrivateKey) MarshalBinary() ([]byte, error) {
+	return marshalInts(privateKey.N, privateKey.LambdaN, privateKey.PhiN)
+}
+
+func (privateKey *PrivateKey) UnmarshalBinary(data []byte) error {
+	ints, err := unmarshalInts(data, 3)
+	if err != nil {
+		return err
+	}
+	sk := PrivateKey{PublicKey: PublicKey{N: ints[0]}, LambdaN: ints[1], PhiN: ints[2]}
+	if err := sk.Validate(); err != nil {
+		return err
+	}
+	*privateKey = sk
+	return nil
+}
+
+// ThresholdPublicKey has its own encodings in place of those of the embedded PublicKey. Its modulus may be shorter
+// than MinModulusLen, as the dealer chooses its length.
+
+func (pk *ThresholdPublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&thresholdPublicKeyJSON{N: pk.N, Threshold: pk.Threshold, PartyCount: pk.PartyCount, V: pk.V})
+}
+
+func (pk *ThresholdPublicKey) UnmarshalJSON(payload []byte) error {
+	aux := new(thresholdPublicKeyJSON)
+	if err := json.Unmarshal(payload, aux); err != nil {
+		return err
+	}
+	tpk := ThresholdPublicKey{PublicKey: PublicKey{N: aux.N}, Threshold: aux.Threshold, PartyCount: aux.PartyCount, V: aux.V}
+	if err := tpk.validate(); err != nil {
+		return err
+	}
+	*pk = tpk
+	return nil
+}
+
+func (pk *ThresholdPublicKey) MarshalBinary() ([]byte, error) {
+	return marshalInts(pk.N, pk.V, big.NewInt(int64(pk.Threshold)), big.NewInt(int64(pk.PartyCount)))
+}
+
+func (pk *ThresholdPublicKey) UnmarshalBinary(data []byte) error {
+	ints, err := unmarshalInts(data, 4)
+	if err != nil {
+		return err
+	}
+	if !ints[2].IsInt64() || !ints[3].IsInt64() {
+		return errors.New("paillier: invalid threshold or party count")
+	}
+	tpk := ThresholdPublicKey{
+		PublicKey:  PublicKey{N: ints[0]},
+		V:          ints[1],
+		Threshold:  int(ints[2].Int64()),
+		PartyCount: int(ints[3].Int64()),
+	}
+	if err := tpk.validate(); err != nil {
+		return err
+	}
+	*pk = tpk
+	return nil
+}
+
+func (pk *ThresholdPublicKey) validate() error {
+	if pk.N == nil || pk.N.Bit(0) == 0 {
+		return errors.New("paillier: the modulus must be odd")
+	}
+	if pk.Threshold < 0 || pk.PartyCount <= pk.Threshold {
+		return errors.New("paillier: the threshold must be less than the party count")
+	}
+	if pk.V == nil || pk.V.Sign() <= 0 || pk.V.Cmp(pk.NSquare()) >= 0 {
+		return errors.New("paillier: V must be in Z_N^2")
+	}
+	return nil
+}
+
+// ----- //
+
+func marshalInts(ints ...*big.Int) ([]byte, error) {
+	var bz []byte
+	for _, i := range ints {
+		if i == nil || i.Sign() < 0 {
+			return nil, errors.New("paillier: cannot marshal a missing or negative integer")
+		}
+		iBz := i.Bytes()
+		l := make([]byte, 4)
+		binary.BigEndian.PutUint32(l, uint32(len(iBz)))
+		bz = append(append(bz, l...), iBz...)
+	}
+	return bz, nil
+}
+
+func unmarshalInts(bz []byte, count int) ([]*big.Int, error) {
+	ints := make([]*big.Int, count)
+	for i := range ints {
+		if len(bz) < 4 {
+			return nil, errors.New("paillier: the encoding is too short")
+		}
+		l := binary.BigEndian.Uint32(bz)
+		bz = bz[4:]
+		if uint32(len(bz)) < l {
+			return nil, errors.New("paillier: the encoding is too short")
+		}
+		if 0 < l && bz[0] == 0 {
+			return nil, errors.New("paillier: the encoding is not canonical")
+		}
+		ints[i] = new(big.Int).SetBytes(bz[:l])
+		bz = bz[l:]
+	}
+	if len(bz) != 0 {
+		return nil, errors.New("paillier: unexpected bytes after the encoding")
+	}
+	return ints, nil
+}
@@ -18,16 +18,15 @@ package paillier
 import (
 	"errors"
 	"fmt"
-	gmath "math"
 	"math/big"
 	"runtime"
-	"strconv"
 	"time"
 
 	"github.com/otiai10/primes"
 
 	"github.com/binance-chain/tss-lib/common"
 	crypto2 "github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 )
 
 const (
@@ -39,6 +38,8 @@ const (
 type (
 	PublicKey struct {
 		N *big.Int
+
+		fixedBase *fixedBase // see Precompute
 	}
 
 	PrivateKey struct {
@@ -52,7 +53,8 @@ type (
 )
 
 var (
-	ErrMessageTooLong = fmt.Errorf("the message is too large or < 0")
+	ErrMessageTooLong    = fmt.Errorf("the message is too large or < 0")
+	ErrInvalidCiphertext = fmt.Errorf("the ciphertext is not in Z*_{N^2}")
 
 	zero = big.NewInt(0)
 	one  = big.NewInt(1)
@@ -65,6 +67,12 @@ func init() {
 
 // len is the length of the modulus (each prime = len / 2)
 func GenerateKeyPair(modulusBitLen int, timeout time.Duration, optionalConcurrency ...int) (privateKey *PrivateKey, publicKey *PublicKey, err error) {
+	return GenerateKeyPairWithProgress(modulusBitLen, timeout, nil, optionalConcurrency...)
+}
+
+// GenerateKeyPairWithProgress is GenerateKeyPair that reports the search for the safe primes P and Q to `progress`,
+// which may be nil. The counts include the searches whose P and Q are too close and are discarded.
+func GenerateKeyPairWithProgress(modulusBitLen int, timeout time.Duration, progress func(common.SafePrimeProgress), optionalConcurrency ...int) (privateKey *PrivateKey, publicKey *PublicKey, err error) {
 	var concurrency int
 	if 0 < len(optionalConcurrency) {
 		if 1 < len(optionalConcurrency) {
@@ -79,11 +87,26 @@ func GenerateKeyPair(modulusBitLen int, timeout time.Duration, optionalConcurren
 	var P, Q, N *big.Int
 	{
 		tmp := new(big.Int)
+		start := time.Now()
+		var done common.SafePrimeProgress // the totals of the discarded searches
 		for {
-			sgps, err := common.GetRandomSafePrimesConcurrent(modulusBitLen/2, 2, timeout, concurrency)
+			var opts *common.SafePrimeOptions
+			var last common.SafePrimeProgress
+			if progress != nil {
+				opts = &common.SafePrimeOptions{Progress: func(p common.SafePrimeProgress) {
+					last = p
+					progress(common.SafePrimeProgress{
+						Tested:  done.Tested + p.Tested,
+						Found:   done.Found + p.Found,
+						Elapsed: time.Since(start),
+					})
+				}}
+			}
+			sgps, err := common.GetRandomSafePrimesWithOptions(modulusBitLen/2, 2, timeout, concurrency, opts)
 			if err != nil {
 				return nil, nil, err
 			}
+			done.Tested, done.Found = done.Tested+last.Tested, done.Found+last.Found
 			P, Q = sgps[0].SafePrime(), sgps[1].SafePrime()
 			// KS-BTL-F-03: check that p-q is also very large in order to avoid square-root attacks
 			if tmp.Sub(P, Q).BitLen() >= (modulusBitLen/2)-pQBitLenDifference {
@@ -112,14 +135,14 @@ func (publicKey *PublicKey) EncryptAndReturnRandomness(m *big.Int) (c *big.Int,
 	if m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
 		return nil, nil, ErrMessageTooLong
 	}
-	x = common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
-	N2 := publicKey.NSquare()
-	// 1. gamma^m mod N2
-	Gm := new(big.Int).Exp(publicKey.Gamma(), m, N2)
+	modN2 := common.ModInt(publicKey.NSquare())
+	// 1. gamma^m = 1 + m*N mod N2
+	Gm := new(big.Int).Mul(m, publicKey.N)
+	Gm.Add(Gm, one)
 	// 2. x^N mod N2
-	xN := new(big.Int).Exp(x, publicKey.N, N2)
+	x, xN := publicKey.randomness()
 	// 3. (1) * (2) mod N2
-	c = common.ModInt(N2).Mul(Gm, xN)
+	c = modN2.Mul(Gm, xN)
 	return
 }
 
@@ -128,28 +151,60 @@ func (publicKey *PublicKey) Encrypt(m *big.Int) (c *big.Int, err error) {
 	return
 }
 
-func (publicKey *PublicKey) HomoMult(m, c1 *big.Int) (*big.Int, error) {
-	if m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
-		return nil, ErrMessageTooLong
+// ValidateCiphertext checks that `c` is in Z*_{N^2}, i.e. 0 < c < N^2 and gcd(c, N) = 1
+func (publicKey *PublicKey) ValidateCiphertext(c *big.Int) error {
+	if c == nil || c.Sign() <= 0 || c.Cmp(publicKey.NSquare()) != -1 {
+		return ErrInvalidCiphertext
 	}
-	N2 := publicKey.NSquare()
-	if c1.Cmp(zero) == -1 || c1.Cmp(N2) != -1 { // c1 < 0 || c1 >= N2 ?
+	if new(big.Int).GCD(nil, nil, c, publicKey.N).Cmp(one) != 0 {
+		return ErrInvalidCiphertext
+	}
+	return nil
+}
+
+// HomoMulPlaintext returns c^m mod N^2, an encryption of the plaintext of `c` times `m`
+func (publicKey *PublicKey) HomoMulPlaintext(m, c *big.Int) (*big.Int, error) {
+	if m == nil || m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
 		return nil, ErrMessageTooLong
 	}
+	if err := publicKey.ValidateCiphertext(c); err != nil {
+		return nil, err
+	}
 	// cipher^m mod N2
-	return common.ModInt(N2).Exp(c1, m), nil
+	return common.ModInt(publicKey.NSquare()).Exp(c, m), nil
+}
+
+// HomoMult is HomoMulPlaintext
+func (publicKey *PublicKey) HomoMult(m, c1 *big.Int) (*big.Int, error) {
+	return publicKey.HomoMulPlaintext(m, c1)
 }
 
+// HomoAdd returns c1 * c2 mod N^2, an encryption of the sum of the plaintexts of `c1` and `c2`
 func (publicKey *PublicKey) HomoAdd(c1, c2 *big.Int) (*big.Int, error) {
-	N2 := publicKey.NSquare()
-	if c1.Cmp(zero) == -1 || c1.Cmp(N2) != -1 { // c1 < 0 || c1 >= N2 ?
-		return nil, ErrMessageTooLong
+	if err := publicKey.ValidateCiphertext(c1); err != nil {
+		return nil, err
 	}
-	if c2.Cmp(zero) == -1 || c2.Cmp(N2) != -1 { // c2 < 0 || c2 >= N2 ?
-		return nil, ErrMessageTooLong
+	if err := publicKey.ValidateCiphertext(c2); err != nil {
+		return nil, err
 	}
 	// c1 * c2 mod N2
-	return common.ModInt(N2).Mul(c1, c2), nil
+	return common.ModInt(publicKey.NSquare()).Mul(c1, c2), nil
+}
+
+// ReRandomizeAndReturnRandomness returns c * x^N mod N^2 for a fresh x, which encrypts the same plaintext as `c` and
+// cannot be linked to it without the private key, and x. With c = gamma^m * r^N, the new randomness is r*x mod N.
+func (publicKey *PublicKey) ReRandomizeAndReturnRandomness(c *big.Int) (c2 *big.Int, x *big.Int, err error) {
+	if err = publicKey.ValidateCiphertext(c); err != nil {
+		return nil, nil, err
+	}
+	x, xN := publicKey.randomness()
+	c2 = common.ModInt(publicKey.NSquare()).Mul(c, xN)
+	return
+}
+
+func (publicKey *PublicKey) ReRandomize(c *big.Int) (c2 *big.Int, err error) {
+	c2, _, err = publicKey.ReRandomizeAndReturnRandomness(c)
+	return
 }
 
 func (publicKey *PublicKey) NSquare() *big.Int {
@@ -168,21 +223,59 @@ func (publicKey *PublicKey) Gamma() *big.Int {
 
 // ----- //
 
+// Decrypt recovers the plaintext of `c` modulo p and q and combines the results with the Chinese Remainder Theorem,
+// which takes about a quarter of the time of a full-width exponentiation mod N^2
 func (privateKey *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	N2 := privateKey.NSquare()
 	if c.Cmp(zero) == -1 || c.Cmp(N2) != -1 { // c < 0 || c >= N2 ?
 		return nil, ErrMessageTooLong
 	}
-	// 1. L(u) = (c^LambdaN-1 mod N2) / N
-	Lc := L(new(big.Int).Exp(c, privateKey.LambdaN, N2), privateKey.N)
-	// 2. L(u) = (Gamma^LambdaN-1 mod N2) / N
-	Lg := L(new(big.Int).Exp(privateKey.Gamma(), privateKey.LambdaN, N2), privateKey.N)
-	// 3. (1) * modInv(2) mod N
-	inv := new(big.Int).ModInverse(Lg, privateKey.N)
-	m = common.ModInt(privateKey.N).Mul(Lc, inv)
+	P, Q, err := privateKey.primes()
+	if err != nil {
+		return nil, err
+	}
+	// 1. m_p = L_p(c^(p-1) mod p^2) * h_p mod p, and the same mod q
+	mP, mQ := decryptModPrime(c, P, Q), decryptModPrime(c, Q, P)
+	// 2. m = m_q + q * ((m_p - m_q) / q mod p)
+	qInv := new(big.Int).ModInverse(Q, P)
+	m = common.ModInt(P).Mul(new(big.Int).Sub(mP, mQ), qInv)
+	m.Mul(m, Q).Add(m, mQ)
 	return
 }
 
+// primes recovers p and q from N and PhiN, as p + q = N - PhiN + 1 and p * q = N
+func (privateKey *PrivateKey) primes() (*big.Int, *big.Int, error) {
+	N := privateKey.N
+	sum := new(big.Int).Sub(N, privateKey.PhiN)
+	sum.Add(sum, one)
+	// (p - q)^2 = (p + q)^2 - 4N
+	disc := new(big.Int).Mul(sum, sum)
+	disc.Sub(disc, new(big.Int).Lsh(N, 2))
+	if disc.Sign() < 0 {
+		return nil, nil, errors.New("paillier: PhiN does not match N")
+	}
+	diff := new(big.Int).Sqrt(disc)
+	P := new(big.Int).Add(sum, diff)
+	Q := new(big.Int).Sub(sum, diff)
+	P.Rsh(P, 1)
+	Q.Rsh(Q, 1)
+	if Q.Sign() <= 0 || new(big.Int).Mul(P, Q).Cmp(N) != 0 {
+		return nil, nil, errors.New("paillier: PhiN does not match N")
+	}
+	return P, Q, nil
+}
+
+// decryptModPrime returns L_p(c^(p-1) mod p^2) * h_p mod p for the prime p of N = p*q. With Gamma = N+1,
+// L_p(Gamma^(p-1) mod p^2) = -q mod p, so h_p = (-q)^-1 mod p.
+func decryptModPrime(c, P, Q *big.Int) *big.Int {
+	P2 := new(big.Int).Mul(P, P)
+	PMinus1 := new(big.Int).Sub(P, one)
+	// the order of Z*_{p^2} is p*(p-1); c^(p-1) is 0 mod p^2 for either exponent when p divides c
+	u := secretExp(c, PMinus1, P2, new(big.Int).Mul(P, PMinus1))
+	hP := new(big.Int).ModInverse(new(big.Int).Sub(P, new(big.Int).Mod(Q, P)), P)
+	return common.ModInt(P).Mul(L(u, P), hP)
+}
+
 // ----- //
 
 // Proof is an implementation of Gennaro, R., Micciancio, D., Rabin, T.:
@@ -195,7 +288,7 @@ func (privateKey *PrivateKey) Proof(k *big.Int, ecdsaPub *crypto2.ECPoint) Proof
 	xs := GenerateXs(iters, k, privateKey.N, ecdsaPub)
 	for i := 0; i < iters; i++ {
 		M := new(big.Int).ModInverse(privateKey.N, privateKey.PhiN)
-		pi[i] = new(big.Int).Exp(xs[i], M, privateKey.N)
+		pi[i] = secretExp(xs[i], M, privateKey.N, privateKey.PhiN)
 	}
 	return pi
 }
@@ -229,7 +322,7 @@ func (pf Proof) Verify(pkN, k *big.Int, ecdsaPub *crypto2.ECPoint) (bool, error)
 			}
 			for i, xi := range xs {
 				xiModN := new(big.Int).Mod(xi, pkN)
-				yiExpN := new(big.Int).Exp(pf[i], pkN, pkN)
+				yiExpN := common.ModInt(pkN).Exp(pf[i], pkN)
 				if xiModN.Cmp(yiExpN) != 0 {
 					return false, nil
 				}
@@ -246,41 +339,19 @@ func L(u, N *big.Int) *big.Int {
 	return new(big.Int).Div(t, N)
 }
 
-// GenerateXs generates the challenges used in Paillier key Proof
+// GenerateXs generates the challenges used in Paillier key Proof, drawn in Z*_N from a zkp.Transcript of k, N and the
+// ECDSA public key
 func GenerateXs(m int, k, N *big.Int, ecdsaPub *crypto2.ECPoint) []*big.Int {
-	var i, n int
+	tr := zkp.ForProof(nil, "paillier/key")
+	tr.AppendInts("statement", k, N)
+	tr.AppendPoints("statement", ecdsaPub)
 	ret := make([]*big.Int, m)
-	sX, sY := ecdsaPub.X(), ecdsaPub.Y()
-	kb, sXb, sYb, Nb := k.Bytes(), sX.Bytes(), sY.Bytes(), N.Bytes()
-	bits := N.BitLen()
-	blocks := int(gmath.Ceil(float64(bits) / 256))
-	chs := make([]chan []byte, blocks)
-	for k := range chs {
-		chs[k] = make(chan []byte)
-	}
-	for i < m {
-		xi := make([]byte, 0, blocks*32)
-		ib := []byte(strconv.Itoa(i))
-		nb := []byte(strconv.Itoa(n))
-		for j := 0; j < blocks; j++ {
-			go func(j int) {
-				jBz := []byte(strconv.Itoa(j))
-				hash := common.SHA512_256(ib, jBz, nb, kb, sXb, sYb, Nb)
-				chs[j] <- hash
-			}(j)
-		}
-		for _, ch := range chs { // must be in order
-			rx := <-ch
-			if rx == nil { // this should never happen. see: https://golang.org/pkg/hash/#Hash
-				panic(errors.New("GenerateXs hash write error!"))
+	for i := range ret {
+		for {
+			ret[i] = tr.Challenge("challenge", N)
+			if common.IsNumberInMultiplicativeGroup(N, ret[i]) {
+				break
 			}
-			xi = append(xi, rx...) // xi1||···||xib
-		}
-		ret[i] = new(big.Int).SetBytes(xi)
-		if common.IsNumberInMultiplicativeGroup(N, ret[i]) {
-			i++
-		} else {
-			n++
 		}
 	}
 	return ret
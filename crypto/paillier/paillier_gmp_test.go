@@ -0,0 +1,46 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+//go:build gmp
+// +build gmp
+
+package paillier_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+// TestDecryptCRTBackends checks that the CRT decryption gives the same plaintexts with GMPBackend as with
+// BigIntBackend, and the same as the full-width decryption mod N^2 with math/big
+func TestDecryptCRTBackends(t *testing.T) {
+	setUp(t)
+	defer common.SetBigNumBackend(nil)
+	N, N2 := publicKey.N, publicKey.NSquare()
+	Lg := L(new(big.Int).Exp(publicKey.Gamma(), privateKey.LambdaN, N2), N)
+	LgInv := new(big.Int).ModInverse(Lg, N)
+
+	for _, m := range []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(N, big.NewInt(1)), common.GetRandomPositiveInt(N)} {
+		c, err := publicKey.Encrypt(m)
+		assert.NoError(t, err)
+		Lc := L(new(big.Int).Exp(c, privateKey.LambdaN, N2), N)
+		full := new(big.Int).Mod(new(big.Int).Mul(Lc, LgInv), N)
+
+		for _, b := range []common.BigNumBackend{common.GMPBackend{}, common.BigIntBackend{}} {
+			common.SetBigNumBackend(b)
+			ret, err := privateKey.Decrypt(c)
+			if assert.NoError(t, err, "%T", b) {
+				assert.Equal(t, 0, m.Cmp(ret), "%T: wrong decryption %v is not %v", b, ret, m)
+				assert.Equal(t, 0, full.Cmp(ret), "%T: the CRT decryption must match the full-width one", b)
+			}
+		}
+	}
+}
@@ -7,6 +7,8 @@
 package paillier_test
 
 import (
+	"crypto/rand"
+	"encoding/json"
 	"math/big"
 	"testing"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
 	. "github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
@@ -66,6 +69,303 @@ func TestEncryptDecrypt(t *testing.T) {
 		"wrong decryption ", ret, " is not ", exp)
 }
 
+func TestDecryptCRT(t *testing.T) {
+	setUp(t)
+	N, N2 := publicKey.N, publicKey.NSquare()
+	for _, m := range []*big.Int{big.NewInt(0), new(big.Int).Sub(N, big.NewInt(1)), common.GetRandomPositiveInt(N)} {
+		c, err := publicKey.Encrypt(m)
+		assert.NoError(t, err)
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret), "wrong decryption ", ret, " is not ", m)
+
+		// the same plaintext as the full-width decryption mod N^2
+		Lc := L(new(big.Int).Exp(c, privateKey.LambdaN, N2), N)
+		Lg := L(new(big.Int).Exp(publicKey.Gamma(), privateKey.LambdaN, N2), N)
+		full := common.ModInt(N).Mul(Lc, new(big.Int).ModInverse(Lg, N))
+		assert.Equal(t, 0, full.Cmp(ret))
+	}
+
+	// PhiN must belong to N
+	bad := &PrivateKey{PublicKey: *publicKey, LambdaN: privateKey.LambdaN, PhiN: new(big.Int).Add(privateKey.PhiN, big.NewInt(2))}
+	c, _ := publicKey.Encrypt(big.NewInt(1))
+	_, err := bad.Decrypt(c)
+	assert.Error(t, err)
+}
+
+func TestRandomnessPool(t *testing.T) {
+	setUp(t)
+	pool := NewRandomnessPool(publicKey, 4, 2)
+	N2 := publicKey.NSquare()
+	seen := make(map[string]bool)
+	for i := 0; i < 8; i++ {
+		m := common.GetRandomPositiveInt(publicKey.N)
+		c, r, err := pool.EncryptAndReturnRandomness(m)
+		assert.NoError(t, err)
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret), "wrong decryption ", ret, " is not ", m)
+
+		// c = gamma^m * r^N mod N^2
+		exp := new(big.Int).Exp(publicKey.Gamma(), m, N2)
+		exp = common.ModInt(N2).Mul(exp, new(big.Int).Exp(r, publicKey.N, N2))
+		assert.Equal(t, 0, exp.Cmp(c))
+
+		// each randomness is used once
+		assert.False(t, seen[r.String()])
+		seen[r.String()] = true
+	}
+	pool.Stop()
+
+	// a stopped pool still encrypts
+	c, err := pool.Encrypt(big.NewInt(7))
+	assert.NoError(t, err)
+	ret, err := privateKey.Decrypt(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, big.NewInt(7).Cmp(ret))
+
+	_, _, err = pool.EncryptAndReturnRandomness(publicKey.N)
+	assert.Equal(t, ErrMessageTooLong, err)
+}
+
+func TestPrecompute(t *testing.T) {
+	setUp(t)
+	pk := &PublicKey{N: publicKey.N}
+	assert.Error(t, pk.Precompute(0))
+	assert.Error(t, pk.Precompute(MaxFixedBaseWindow+1))
+	assert.NoError(t, pk.Precompute(4))
+
+	N2 := pk.NSquare()
+	for _, m := range []*big.Int{big.NewInt(0), common.GetRandomPositiveInt(pk.N), new(big.Int).Sub(pk.N, big.NewInt(1))} {
+		c, x, err := pk.EncryptAndReturnRandomness(m)
+		assert.NoError(t, err)
+		// c = gamma^m * x^N mod N^2
+		exp := new(big.Int).Exp(pk.Gamma(), m, N2)
+		exp = common.ModInt(N2).Mul(exp, new(big.Int).Exp(x, pk.N, N2))
+		assert.Equal(t, 0, exp.Cmp(c))
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret))
+	}
+
+	pool := NewRandomnessPool(pk, 2, 1)
+	defer pool.Stop()
+	m := common.GetRandomPositiveInt(pk.N)
+	c, err := pool.Encrypt(m)
+	assert.NoError(t, err)
+	ret, err := privateKey.Decrypt(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.Cmp(ret))
+}
+
+func TestProofOfCorrectKey(t *testing.T) {
+	setUp(t)
+	NTilde, h1, h2, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	pf, err := privateKey.ProofOfCorrectKey(NTilde, h1, h2)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(publicKey.N, NTilde, h1, h2))
+
+	// the proof survives serialization
+	bzs := pf.Serialize()
+	assert.Equal(t, CorrectKeyProofBytesParts, len(bzs))
+	pf2, err := UnmarshalCorrectKeyProof(bzs)
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(publicKey.N, NTilde, h1, h2))
+
+	// it is bound to the modulus and to the verifier's parameters
+	_, pk2, err := GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, pf.Verify(pk2.N, NTilde, h1, h2))
+	NTilde2, h12, h22, err := keygen.LoadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+	assert.False(t, pf.Verify(publicKey.N, NTilde2, h12, h22))
+	pf2.Mod.Z[3] = new(big.Int).Add(pf2.Mod.Z[3], big.NewInt(1))
+	assert.False(t, pf2.Verify(publicKey.N, NTilde, h1, h2))
+}
+
+func TestPlaintextProof(t *testing.T) {
+	setUp(t)
+	m := common.GetRandomPositiveInt(publicKey.N)
+	c, r, err := publicKey.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+	pf, err := publicKey.ProvePlaintextKnowledge(c, m, r)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(publicKey, c))
+
+	bzs := pf.Bytes()
+	pf2, err := PlaintextProofFromBytes(bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(publicKey, c))
+
+	// another ciphertext, or another plaintext
+	c2, err := publicKey.Encrypt(m)
+	assert.NoError(t, err)
+	assert.False(t, pf.Verify(publicKey, c2))
+	pf3, err := publicKey.ProvePlaintextKnowledge(c, new(big.Int).Add(m, big.NewInt(1)), r)
+	if err == nil {
+		assert.False(t, pf3.Verify(publicKey, c))
+	}
+	_, err = PlaintextProofFromBytes(bzs[:2])
+	assert.Error(t, err)
+}
+
+func TestRangedPlaintextProof(t *testing.T) {
+	setUp(t)
+	NTilde, h1, h2, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	bound := new(big.Int).Lsh(big.NewInt(1), 256)
+	m := common.GetRandomPositiveInt(bound)
+	c, r, err := publicKey.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+	pf, err := publicKey.ProveRangedPlaintext(c, m, r, bound, NTilde, h1, h2)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(publicKey, c, bound, NTilde, h1, h2))
+
+	bzs := pf.Bytes()
+	pf2, err := RangedPlaintextProofFromBytes(bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(publicKey, c, bound, NTilde, h1, h2))
+
+	// a smaller bound, another ciphertext or other ring-Pedersen parameters
+	assert.False(t, pf.Verify(publicKey, c, big.NewInt(1024), NTilde, h1, h2))
+	c2, err := publicKey.Encrypt(m)
+	assert.NoError(t, err)
+	assert.False(t, pf.Verify(publicKey, c2, bound, NTilde, h1, h2))
+	NTilde2, h12, h22, err := keygen.LoadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+	assert.False(t, pf.Verify(publicKey, c, bound, NTilde2, h12, h22))
+
+	// m out of range
+	big1 := new(big.Int).Add(bound, big.NewInt(1))
+	c3, r3, err := publicKey.EncryptAndReturnRandomness(big1)
+	assert.NoError(t, err)
+	_, err = publicKey.ProveRangedPlaintext(c3, big1, r3, bound, NTilde, h1, h2)
+	assert.Error(t, err)
+}
+
+func TestProofOfCorrectKeySmallFactor(t *testing.T) {
+	NTilde, h1, h2, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	// a 2048-bit modulus of two Blum primes, one of which has only 128 bits
+	blumPrime := func(bits int) *big.Int {
+		for {
+			p, err := rand.Prime(rand.Reader, bits)
+			assert.NoError(t, err)
+			if p.Bit(1) == 1 {
+				return p
+			}
+		}
+	}
+	P, Q := blumPrime(128), blumPrime(testPaillierKeyLength-128)
+	N := new(big.Int).Mul(P, Q)
+	PhiN := new(big.Int).Mul(new(big.Int).Sub(P, big.NewInt(1)), new(big.Int).Sub(Q, big.NewInt(1)))
+	sk := &PrivateKey{PublicKey: PublicKey{N: N}, LambdaN: PhiN, PhiN: PhiN}
+
+	pf, err := sk.ProofOfCorrectKey(NTilde, h1, h2)
+	assert.NoError(t, err)
+	// N is a Paillier-Blum modulus, but one of its factors is too small
+	assert.True(t, pf.Mod.Verify(N))
+	assert.False(t, pf.Verify(N, NTilde, h1, h2))
+}
+
+func TestValidatePeer(t *testing.T) {
+	setUp(t)
+	assert.NoError(t, publicKey.ValidatePeer(2048))
+	assert.Error(t, publicKey.ValidatePeer(3072))
+	assert.Error(t, (&PublicKey{}).ValidatePeer(2048))
+
+	even := &PublicKey{N: new(big.Int).Lsh(publicKey.N, 1)}
+	smallFactor := &PublicKey{N: new(big.Int).Mul(publicKey.N, big.NewInt(991))}
+	prime, err := rand.Prime(rand.Reader, 700)
+	assert.NoError(t, err)
+	cube := &PublicKey{N: new(big.Int).Exp(prime, big.NewInt(3), nil)}
+	square := &PublicKey{N: new(big.Int).Mul(publicKey.N, publicKey.N)}
+	for _, bad := range []*PublicKey{even, smallFactor, cube, square} {
+		assert.Error(t, bad.ValidatePeer(2048))
+	}
+}
+
+func TestMarshalKeys(t *testing.T) {
+	setUp(t)
+	// JSON keeps the field names of the structs
+	bz, err := json.Marshal(privateKey)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), `"LambdaN":`)
+	sk := new(PrivateKey)
+	assert.NoError(t, json.Unmarshal(bz, sk))
+	assert.Equal(t, privateKey, sk)
+	bz, err = json.Marshal(publicKey)
+	assert.NoError(t, err)
+	pk := new(PublicKey)
+	assert.NoError(t, json.Unmarshal(bz, pk))
+	assert.Equal(t, publicKey, pk)
+
+	bz, err = privateKey.MarshalBinary()
+	assert.NoError(t, err)
+	sk = new(PrivateKey)
+	assert.NoError(t, sk.UnmarshalBinary(bz))
+	assert.Equal(t, privateKey, sk)
+	assert.Error(t, sk.UnmarshalBinary(bz[:len(bz)-1]))
+	assert.Error(t, sk.UnmarshalBinary(append(bz, 0)))
+	bz, err = publicKey.MarshalBinary()
+	assert.NoError(t, err)
+	pk = new(PublicKey)
+	assert.NoError(t, pk.UnmarshalBinary(bz))
+	assert.Equal(t, publicKey, pk)
+
+	// malformed keys do not load
+	even := &PublicKey{N: new(big.Int).Add(publicKey.N, big.NewInt(1))}
+	short := &PublicKey{N: new(big.Int).Rsh(publicKey.N, 1)}
+	short.N.SetBit(short.N, 0, 1)
+	for _, bad := range []*PublicKey{even, short} {
+		bz, err := json.Marshal(bad)
+		assert.NoError(t, err)
+		assert.Error(t, json.Unmarshal(bz, new(PublicKey)))
+		bz, err = bad.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Error(t, new(PublicKey).UnmarshalBinary(bz))
+	}
+	badPhi := &PrivateKey{PublicKey: *publicKey, LambdaN: privateKey.LambdaN, PhiN: new(big.Int).Add(privateKey.PhiN, big.NewInt(2))}
+	badLambda := &PrivateKey{PublicKey: *publicKey, LambdaN: privateKey.PhiN, PhiN: privateKey.PhiN}
+	for _, bad := range []*PrivateKey{badPhi, badLambda} {
+		bz, err := json.Marshal(bad)
+		assert.NoError(t, err)
+		assert.Error(t, json.Unmarshal(bz, new(PrivateKey)))
+		bz, err = bad.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Error(t, new(PrivateKey).UnmarshalBinary(bz))
+	}
+}
+
+type countingExponentiator struct {
+	calls int
+}
+
+func (e *countingExponentiator) Exp(x, y, m *big.Int) *big.Int {
+	e.calls++
+	return new(big.Int).Exp(x, y, m)
+}
+
+func TestSetExponentiator(t *testing.T) {
+	setUp(t)
+	exp := new(countingExponentiator)
+	SetExponentiator(exp)
+	defer SetExponentiator(nil)
+
+	m := common.GetRandomPositiveInt(publicKey.N)
+	c, err := publicKey.Encrypt(m)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exp.calls, "encryption has no secret exponent")
+	// the blinded exponents give the same plaintext
+	for i := 0; i < 3; i++ {
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret))
+	}
+	assert.Equal(t, 6, exp.calls, "decryption exponentiates mod p^2 and q^2")
+}
+
 func TestHomoMul(t *testing.T) {
 	setUp(t)
 	three, err := privateKey.Encrypt(big.NewInt(3))
@@ -99,30 +399,170 @@ func TestHomoAdd(t *testing.T) {
 	assert.Equal(t, new(big.Int).Add(num1, num2), plain)
 }
 
+func TestHomoMulPlaintext(t *testing.T) {
+	setUp(t)
+	c, err := publicKey.Encrypt(big.NewInt(7))
+	assert.NoError(t, err)
+	cm, err := publicKey.HomoMulPlaintext(big.NewInt(6), c)
+	assert.NoError(t, err)
+	ret, err := privateKey.Decrypt(cm)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ret.Cmp(big.NewInt(42)))
+
+	_, err = publicKey.HomoMulPlaintext(publicKey.N, c)
+	assert.Equal(t, ErrMessageTooLong, err)
+	_, err = publicKey.HomoMulPlaintext(big.NewInt(6), publicKey.N)
+	assert.Equal(t, ErrInvalidCiphertext, err)
+}
+
+func TestReRandomize(t *testing.T) {
+	setUp(t)
+	m := common.GetRandomPositiveInt(publicKey.N)
+	c, r, err := publicKey.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+	c2, x, err := publicKey.ReRandomizeAndReturnRandomness(c)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, c.Cmp(c2))
+	ret, err := privateKey.Decrypt(c2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.Cmp(ret))
+
+	// c2 = gamma^m * (r*x)^N mod N^2
+	N2 := publicKey.NSquare()
+	rx := common.ModInt(publicKey.N).Mul(r, x)
+	exp := common.ModInt(N2).Mul(new(big.Int).Exp(publicKey.Gamma(), m, N2), new(big.Int).Exp(rx, publicKey.N, N2))
+	assert.Equal(t, 0, exp.Cmp(c2))
+
+	c3, err := publicKey.ReRandomize(c)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, c2.Cmp(c3))
+	_, err = publicKey.ReRandomize(publicKey.N)
+	assert.Equal(t, ErrInvalidCiphertext, err)
+}
+
+func TestValidateCiphertext(t *testing.T) {
+	setUp(t)
+	c, err := publicKey.Encrypt(big.NewInt(1))
+	assert.NoError(t, err)
+	assert.NoError(t, publicKey.ValidateCiphertext(c))
+
+	N2 := publicKey.NSquare()
+	// a multiple of a factor of N is not in Z*_{N^2}
+	gcdN := new(big.Int).Mul(publicKey.N, big.NewInt(3))
+	for _, bad := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1), N2, new(big.Int).Add(N2, big.NewInt(1)), publicKey.N, gcdN} {
+		assert.Equal(t, ErrInvalidCiphertext, publicKey.ValidateCiphertext(bad), "%v must not be valid", bad)
+	}
+	_, err = publicKey.HomoAdd(c, publicKey.N)
+	assert.Equal(t, ErrInvalidCiphertext, err)
+	_, err = publicKey.HomoAdd(gcdN, c)
+	assert.Equal(t, ErrInvalidCiphertext, err)
+}
+
+func TestDamgardJurik(t *testing.T) {
+	setUp(t)
+	_, err := publicKey.Generalized(0)
+	assert.Equal(t, ErrInvalidS, err)
+
+	// s = 1 is Paillier
+	sk1, err := privateKey.Generalized(1)
+	assert.NoError(t, err)
+	m := common.GetRandomPositiveInt(publicKey.N)
+	c, err := publicKey.Encrypt(m)
+	assert.NoError(t, err)
+	ret, err := sk1.Decrypt(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.Cmp(ret))
+
+	for _, s := range []int{2, 3} {
+		sk, err := privateKey.Generalized(s)
+		assert.NoError(t, err)
+		pk := &sk.GeneralizedPublicKey
+		Ns := pk.PlaintextModulus()
+		// plaintexts of s times the length of N
+		assert.True(t, Ns.BitLen() > (s-1)*publicKey.N.BitLen())
+
+		// plaintexts near N^s, as random ints are limited to 5000 bits
+		m1 := new(big.Int).Sub(Ns, common.GetRandomPositiveInt(publicKey.N))
+		m2 := new(big.Int).Sub(Ns, common.GetRandomPositiveInt(publicKey.N))
+		c1, err := pk.Encrypt(m1)
+		assert.NoError(t, err)
+		ret, err := sk.Decrypt(c1)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m1.Cmp(ret), "s = %d", s)
+
+		c2, err := pk.Encrypt(m2)
+		assert.NoError(t, err)
+		sum, err := pk.HomoAdd(c1, c2)
+		assert.NoError(t, err)
+		ret, err = sk.Decrypt(sum)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, common.ModInt(Ns).Add(m1, m2).Cmp(ret))
+
+		k := big.NewInt(12345)
+		prod, err := pk.HomoMulPlaintext(k, c1)
+		assert.NoError(t, err)
+		ret, err = sk.Decrypt(prod)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, common.ModInt(Ns).Mul(m1, k).Cmp(ret))
+
+		_, err = pk.Encrypt(Ns)
+		assert.Equal(t, ErrMessageTooLong, err)
+		_, err = sk.Decrypt(pk.CiphertextModulus())
+		assert.Equal(t, ErrInvalidCiphertext, err)
+	}
+}
+
 func TestProofVerify(t *testing.T) {
 	setUp(t)
-	ki := common.MustGetRandomInt(256)                     // index
-	ui := common.GetRandomPositiveInt(tss.EC().Params().N) // ECDSA private
-	yX, yY := tss.EC().ScalarBaseMult(ui.Bytes())          // ECDSA public
-	proof := privateKey.Proof(ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
-	res, err := proof.Verify(publicKey.N, ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
+	ki := common.MustGetRandomInt(256)                       // index
+	ui := common.GetRandomPositiveInt(tss.S256().Params().N) // ECDSA private
+	yX, yY := tss.S256().ScalarBaseMult(ui.Bytes())          // ECDSA public
+	proof := privateKey.Proof(ki, crypto.NewECPointNoCurveCheck(tss.S256(), yX, yY))
+	res, err := proof.Verify(publicKey.N, ki, crypto.NewECPointNoCurveCheck(tss.S256(), yX, yY))
 	assert.NoError(t, err)
 	assert.True(t, res, "proof verify result must be true")
 }
 
 func TestProofVerifyFail(t *testing.T) {
 	setUp(t)
-	ki := common.MustGetRandomInt(256)                     // index
-	ui := common.GetRandomPositiveInt(tss.EC().Params().N) // ECDSA private
-	yX, yY := tss.EC().ScalarBaseMult(ui.Bytes())          // ECDSA public
-	proof := privateKey.Proof(ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
+	ki := common.MustGetRandomInt(256)                       // index
+	ui := common.GetRandomPositiveInt(tss.S256().Params().N) // ECDSA private
+	yX, yY := tss.S256().ScalarBaseMult(ui.Bytes())          // ECDSA public
+	proof := privateKey.Proof(ki, crypto.NewECPointNoCurveCheck(tss.S256(), yX, yY))
 	last := proof[len(proof)-1]
 	last.Sub(last, big.NewInt(1))
-	res, err := proof.Verify(publicKey.N, ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
+	res, err := proof.Verify(publicKey.N, ki, crypto.NewECPointNoCurveCheck(tss.S256(), yX, yY))
 	assert.NoError(t, err)
 	assert.False(t, res, "proof verify result must be true")
 }
 
+func TestBatchVerify(t *testing.T) {
+	setUp(t)
+	ui := common.GetRandomPositiveInt(tss.S256().Params().N) // ECDSA private
+	yX, yY := tss.S256().ScalarBaseMult(ui.Bytes())          // ECDSA public
+	ecdsaPub := crypto.NewECPointNoCurveCheck(tss.S256(), yX, yY)
+	statements := make([]ProofStatement, 5)
+	for j := range statements {
+		ki := common.MustGetRandomInt(256) // index
+		statements[j] = ProofStatement{Proof: privateKey.Proof(ki, ecdsaPub), N: publicKey.N, K: ki}
+	}
+	// a tampered proof, a proof for another index and a modulus with a small factor
+	last := statements[1].Proof[ProofIters-1]
+	last.Sub(last, big.NewInt(1))
+	statements[2].K = new(big.Int).Add(statements[2].K, big.NewInt(1))
+	statements[3].N = new(big.Int).Mul(publicKey.N, big.NewInt(997))
+
+	for _, concurrency := range []int{1, 4} {
+		errs := BatchVerify(statements, ecdsaPub, concurrency)
+		assert.Equal(t, []error{nil, ErrInvalidProof, ErrInvalidProof, ErrInvalidProof, nil}, errs)
+	}
+	for j, st := range statements {
+		ok, err := st.Proof.Verify(st.N, st.K, ecdsaPub)
+		assert.NoError(t, err)
+		assert.Equal(t, j == 0 || j == 4, ok)
+	}
+}
+
 func TestComputeL(t *testing.T) {
 	u := big.NewInt(21)
 	n := big.NewInt(3)
@@ -139,7 +579,7 @@ func TestGenerateXs(t *testing.T) {
 	sY := common.MustGetRandomInt(256)
 	N := common.GetRandomPrimeInt(2048)
 
-	xs := GenerateXs(13, k, N, crypto.NewECPointNoCurveCheck(tss.EC(), sX, sY))
+	xs := GenerateXs(13, k, N, crypto.NewECPointNoCurveCheck(tss.S256(), sX, sY))
 	assert.Equal(t, 13, len(xs))
 	for _, xi := range xs {
 		assert.True(t, common.IsNumberInMultiplicativeGroup(N, xi))
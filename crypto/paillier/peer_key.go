@@ -0,0 +1,72 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ValidatePeer checks a Paillier public key received from a peer, before any of its ciphertexts are processed: N must
+// be odd, at least `minModulusLen` bits long, without prime factors below verifyPrimesUntil, and not a perfect power.
+// These checks are cheap; the proofs of the key (Proof, CorrectKeyProof) show the rest.
+func (publicKey *PublicKey) ValidatePeer(minModulusLen int) error {
+	if publicKey == nil || publicKey.N == nil {
+		return errors.New("paillier: the public key has no modulus")
+	}
+	N := publicKey.N
+	if N.BitLen() < minModulusLen {
+		return fmt.Errorf("paillier: the modulus must be at least %d bits long, got %d", minModulusLen, N.BitLen())
+	}
+	if N.Bit(0) == 0 {
+		return errors.New("paillier: the modulus must be odd")
+	}
+	if new(big.Int).GCD(nil, nil, N, smallPrimesProduct).Cmp(one) != 0 {
+		return errors.New("paillier: the modulus has a small prime factor")
+	}
+	if isPerfectPower(N) {
+		return errors.New("paillier: the modulus is a perfect power")
+	}
+	return nil
+}
+
+// isPerfectPower returns true if N = r^k for some k >= 2, for an N without prime factors below verifyPrimesUntil.
+// It suffices to try prime k, and then r >= verifyPrimesUntil > 2^9, so k < N.BitLen()/9.
+func isPerfectPower(N *big.Int) bool {
+	if N.Sign() <= 0 {
+		return false
+	}
+	for k := int64(2); k <= int64(N.BitLen()/9)+1; k++ {
+		if !big.NewInt(k).ProbablyPrime(1) {
+			continue
+		}
+		r := nthRoot(N, k)
+		if new(big.Int).Exp(r, big.NewInt(k), nil).Cmp(N) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// nthRoot returns floor(N^(1/k)) by Newton's method, for N > 0 and k >= 2
+func nthRoot(N *big.Int, k int64) *big.Int {
+	bigK, kMinus1 := big.NewInt(k), big.NewInt(k-1)
+	// start above the root: 2^ceil(bitlen/k)
+	x := new(big.Int).Lsh(one, uint((int64(N.BitLen())+k-1)/k))
+	for {
+		// y = ((k-1)*x + N / x^(k-1)) / k
+		y := new(big.Int).Exp(x, kMinus1, nil)
+		y.Quo(N, y)
+		y.Add(y, new(big.Int).Mul(kMinus1, x))
+		y.Quo(y, bigK)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x = y
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+// The proofs of plaintext knowledge bind a ciphertext c = gamma^m * r^N mod N^2 to its plaintext m, for protocols
+// other than the MtA, e.g. those on top of threshold decryption. PlaintextProof only shows that the prover knows m
+// and r. RangedPlaintextProof is the range proof of GG18Spec (9) Fig. 9 with the bound q of the MtA replaced by any
+// `bound`: it also commits to m with the verifier's ring-Pedersen parameters (NTilde, h1, h2), and shows that
+// |m| < bound * 2^(plaintextProofChallengeBits + plaintextProofSlackBits) while an honest prover needs m < bound.
+
+const (
+	PlaintextProofBytesParts       = 3
+	RangedPlaintextProofBytesParts = 6
+
+	plaintextProofChallengeBits = 256 // ℓ, the bit length of the challenge
+	plaintextProofSlackBits     = 512 // ε, the slack that hides e*m in the range proof
+)
+
+type (
+	// PlaintextProof proves knowledge of m and r with c = gamma^m * r^N mod N^2
+	PlaintextProof struct {
+		A, Z1, Z2 *big.Int
+	}
+
+	// RangedPlaintextProof proves knowledge of m and r with c = gamma^m * r^N mod N^2 and m in a range
+	RangedPlaintextProof struct {
+		Z, U, W, S, S1, S2 *big.Int
+	}
+)
+
+// ProvePlaintextKnowledge proves knowledge of the plaintext `m` and randomness `r` of `c`, as returned by
+// EncryptAndReturnRandomness
+func (publicKey *PublicKey) ProvePlaintextKnowledge(c, m, r *big.Int) (*PlaintextProof, error) {
+	if c == nil || m == nil || r == nil {
+		return nil, errors.New("ProvePlaintextKnowledge() received nil value(s)")
+	}
+	if m.Sign() < 0 || m.Cmp(publicKey.N) >= 0 {
+		return nil, ErrMessageTooLong
+	}
+	N := publicKey.N
+	modN, modN2 := common.ModInt(N), common.ModInt(publicKey.NSquare())
+
+	// 1. A = gamma^a * s^N mod N^2 = (1 + a*N) * s^N mod N^2; s is uniform in Z*_N, not from Precompute's tables, as
+	// it hides r^e
+	a := common.GetRandomPositiveInt(N)
+	s := common.GetRandomPositiveRelativelyPrimeInt(N)
+	A := modN2.Mul(new(big.Int).Add(one, new(big.Int).Mul(a, N)), modN2.Exp(s, N))
+
+	// 2. the challenge
+	e := plaintextProofChallenge("paillier/plaintext", publicKey.AsInts(), []*big.Int{c}, A)
+
+	// 3. z1 = a + e*m mod N, as gamma has order N; z2 = s * r^e mod N
+	z1 := modN.Add(a, new(big.Int).Mul(e, m))
+	z2 := modN.Mul(s, modN.Exp(r, e))
+	return &PlaintextProof{A: A, Z1: z1, Z2: z2}, nil
+}
+
+// Verify checks that the prover knows the plaintext of `c`
+func (pf *PlaintextProof) Verify(pk *PublicKey, c *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || pk == nil || pk.N == nil {
+		return false
+	}
+	if pk.ValidateCiphertext(c) != nil || pk.ValidateCiphertext(pf.A) != nil {
+		return false
+	}
+	if pf.Z1.Sign() < 0 || pf.Z1.Cmp(pk.N) >= 0 || !common.IsNumberInMultiplicativeGroup(pk.N, pf.Z2) {
+		return false
+	}
+	e := plaintextProofChallenge("paillier/plaintext", pk.AsInts(), []*big.Int{c}, pf.A)
+
+	// gamma^z1 * z2^N = A * c^e mod N^2
+	modN2 := common.ModInt(pk.NSquare())
+	left := modN2.Mul(new(big.Int).Add(one, new(big.Int).Mul(pf.Z1, pk.N)), modN2.Exp(pf.Z2, pk.N))
+	right := modN2.Mul(pf.A, modN2.Exp(c, e))
+	return left.Cmp(right) == 0
+}
+
+func (pf *PlaintextProof) ValidateBasic() bool {
+	return pf.A != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil
+}
+
+func (pf *PlaintextProof) Bytes() [PlaintextProofBytesParts][]byte {
+	return [...][]byte{
+		pf.A.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+	}
+}
+
+func PlaintextProofFromBytes(bzs [][]byte) (*PlaintextProof, error) {
+	if !common.NonEmptyMultiBytes(bzs, PlaintextProofBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct PlaintextProof", PlaintextProofBytesParts)
+	}
+	return &PlaintextProof{
+		A:  new(big.Int).SetBytes(bzs[0]),
+		Z1: new(big.Int).SetBytes(bzs[1]),
+		Z2: new(big.Int).SetBytes(bzs[2]),
+	}, nil
+}
+
+// ----- //
+
+// ProveRangedPlaintext proves knowledge of the plaintext `m` and randomness `r` of `c`, with 0 <= m < `bound`, to a
+// verifier with the ring-Pedersen parameters (NTilde, h1, h2)
+func (publicKey *PublicKey) ProveRangedPlaintext(c, m, r, bound, NTilde, h1, h2 *big.Int) (*RangedPlaintextProof, error) {
+	if c == nil || m == nil || r == nil || bound == nil || NTilde == nil || h1 == nil || h2 == nil {
+		return nil, errors.New("ProveRangedPlaintext() received nil value(s)")
+	}
+	if m.Sign() < 0 || m.Cmp(bound) >= 0 || m.Cmp(publicKey.N) >= 0 {
+		return nil, errors.New("ProveRangedPlaintext(): m is not in [0, bound)")
+	}
+	slackBound := rangedPlaintextSlackBound(bound)
+	boundNTilde := new(big.Int).Mul(bound, NTilde)
+	slackBoundNTilde := new(big.Int).Mul(slackBound, NTilde)
+
+	// 1-4. sample
+	alpha := common.GetRandomPositiveInt(slackBound)
+	beta := common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
+	gamma := common.GetRandomPositiveInt(slackBoundNTilde)
+	rho := common.GetRandomPositiveInt(boundNTilde)
+
+	// 5. z = h1^m * h2^rho mod NTilde
+	modNTilde := common.ModInt(NTilde)
+	z := modNTilde.Mul(modNTilde.Exp(h1, m), modNTilde.Exp(h2, rho))
+
+	// 6. u = gamma^alpha * beta^N mod N^2
+	modN2 := common.ModInt(publicKey.NSquare())
+	u := modN2.Mul(modN2.Exp(publicKey.Gamma(), alpha), modN2.Exp(beta, publicKey.N))
+
+	// 7. w = h1^alpha * h2^gamma mod NTilde
+	w := modNTilde.Mul(modNTilde.Exp(h1, alpha), modNTilde.Exp(h2, gamma))
+
+	// 8-9. the challenge
+	e := plaintextProofChallenge("paillier/ranged-plaintext", publicKey.AsInts(), []*big.Int{bound, NTilde, h1, h2, c}, z, u, w)
+
+	// 10. s = r^e * beta mod N, s1 = e*m + alpha, s2 = e*rho + gamma
+	modN := common.ModInt(publicKey.N)
+	s := modN.Mul(modN.Exp(r, e), beta)
+	s1 := new(big.Int).Add(new(big.Int).Mul(e, m), alpha)
+	s2 := new(big.Int).Add(new(big.Int).Mul(e, rho), gamma)
+	return &RangedPlaintextProof{Z: z, U: u, W: w, S: s, S1: s1, S2: s2}, nil
+}
+
+// Verify checks that the prover knows the plaintext m of `c` and that |m| < bound * 2^(ℓ+ε), with the verifier's own
+// ring-Pedersen parameters (NTilde, h1, h2)
+func (pf *RangedPlaintextProof) Verify(pk *PublicKey, c, bound, NTilde, h1, h2 *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || pk == nil || pk.N == nil ||
+		bound == nil || bound.Sign() <= 0 || NTilde == nil || h1 == nil || h2 == nil {
+		return false
+	}
+	if pk.ValidateCiphertext(c) != nil || pk.ValidateCiphertext(pf.U) != nil {
+		return false
+	}
+	for _, a := range []*big.Int{pf.Z, pf.W} {
+		if !common.IsNumberInMultiplicativeGroup(NTilde, a) {
+			return false
+		}
+	}
+	if !common.IsNumberInMultiplicativeGroup(pk.N, pf.S) {
+		return false
+	}
+	// 3. the range check
+	if pf.S1.Sign() < 0 || pf.S1.Cmp(rangedPlaintextSlackBound(bound)) >= 0 || pf.S2.Sign() < 0 {
+		return false
+	}
+
+	// 1-2. the challenge
+	e := plaintextProofChallenge("paillier/ranged-plaintext", pk.AsInts(), []*big.Int{bound, NTilde, h1, h2, c}, pf.Z, pf.U, pf.W)
+
+	// 4. gamma^s1 * s^N = u * c^e mod N^2
+	modN2 := common.ModInt(pk.NSquare())
+	left := modN2.Mul(modN2.Exp(pk.Gamma(), pf.S1), modN2.Exp(pf.S, pk.N))
+	if left.Cmp(modN2.Mul(pf.U, modN2.Exp(c, e))) != 0 {
+		return false
+	}
+	// 5. h1^s1 * h2^s2 = w * z^e mod NTilde
+	modNTilde := common.ModInt(NTilde)
+	left = modNTilde.Mul(modNTilde.Exp(h1, pf.S1), modNTilde.Exp(h2, pf.S2))
+	return left.Cmp(modNTilde.Mul(pf.W, modNTilde.Exp(pf.Z, e))) == 0
+}
+
+func (pf *RangedPlaintextProof) ValidateBasic() bool {
+	return pf.Z != nil &&
+		pf.U != nil &&
+		pf.W != nil &&
+		pf.S != nil &&
+		pf.S1 != nil &&
+		pf.S2 != nil
+}
+
+func (pf *RangedPlaintextProof) Bytes() [RangedPlaintextProofBytesParts][]byte {
+	return [...][]byte{
+		pf.Z.Bytes(),
+		pf.U.Bytes(),
+		pf.W.Bytes(),
+		pf.S.Bytes(),
+		pf.S1.Bytes(),
+		pf.S2.Bytes(),
+	}
+}
+
+func RangedPlaintextProofFromBytes(bzs [][]byte) (*RangedPlaintextProof, error) {
+	if !common.NonEmptyMultiBytes(bzs, RangedPlaintextProofBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct RangedPlaintextProof", RangedPlaintextProofBytesParts)
+	}
+	return &RangedPlaintextProof{
+		Z:  new(big.Int).SetBytes(bzs[0]),
+		U:  new(big.Int).SetBytes(bzs[1]),
+		W:  new(big.Int).SetBytes(bzs[2]),
+		S:  new(big.Int).SetBytes(bzs[3]),
+		S1: new(big.Int).SetBytes(bzs[4]),
+		S2: new(big.Int).SetBytes(bzs[5]),
+	}, nil
+}
+
+// ----- //
+
+// plaintextProofChallenge draws the challenge in [0, 2^ℓ) of the proof labelled `proof` from its transcript after the
+// public key, the statement and the commitments
+func plaintextProofChallenge(proof string, pk, statement []*big.Int, commitments ...*big.Int) *big.Int {
+	tr := zkp.ForProof(nil, proof)
+	tr.AppendInts("public-key", pk...)
+	tr.AppendInts("statement", statement...)
+	tr.AppendInts("commitment", commitments...)
+	return tr.Challenge("challenge", new(big.Int).Lsh(one, plaintextProofChallengeBits))
+}
+
+// rangedPlaintextSlackBound returns bound * 2^(ℓ+ε), the bound of alpha and s1
+func rangedPlaintextSlackBound(bound *big.Int) *big.Int {
+	return new(big.Int).Lsh(bound, plaintextProofChallengeBits+plaintextProofSlackBits)
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+type (
+	// RandomnessPool precomputes pairs (r, r^N mod N^2) for a public key in the background, so that encryption only
+	// costs a multiplication. The exponentiation r^N dominates the cost of EncryptAndReturnRandomness.
+	// Each pair is handed out once. When the pool is empty the pair is computed on the spot.
+	RandomnessPool struct {
+		pk    *PublicKey
+		pairs chan randomnessPair
+		quit  chan struct{}
+		wg    sync.WaitGroup
+		once  sync.Once
+	}
+
+	randomnessPair struct {
+		r, rN *big.Int
+	}
+)
+
+// NewRandomnessPool starts filling a pool of up to `size` pairs for `pk`, with as many workers as CPUs or the number
+// given in `optionalConcurrency`. Call Stop to end the workers once the pool is no longer needed.
+func NewRandomnessPool(pk *PublicKey, size int, optionalConcurrency ...int) *RandomnessPool {
+	var concurrency int
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("NewRandomnessPool: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	} else {
+		concurrency = runtime.NumCPU()
+	}
+	pool := &RandomnessPool{
+		pk:    pk,
+		pairs: make(chan randomnessPair, size),
+		quit:  make(chan struct{}),
+	}
+	pool.wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go pool.fill()
+	}
+	return pool
+}
+
+// PublicKey returns the public key that the pool encrypts to
+func (pool *RandomnessPool) PublicKey() *PublicKey {
+	return pool.pk
+}
+
+// Len returns the number of precomputed pairs that are ready
+func (pool *RandomnessPool) Len() int {
+	return len(pool.pairs)
+}
+
+// Stop ends the background workers. The pool can still encrypt, computing each pair on the spot.
+func (pool *RandomnessPool) Stop() {
+	pool.once.Do(func() {
+		close(pool.quit)
+	})
+	pool.wg.Wait()
+}
+
+// EncryptAndReturnRandomness is PublicKey.EncryptAndReturnRandomness with a precomputed pair
+func (pool *RandomnessPool) EncryptAndReturnRandomness(m *big.Int) (c *big.Int, x *big.Int, err error) {
+	pk := pool.pk
+	if m.Cmp(zero) == -1 || m.Cmp(pk.N) != -1 { // m < 0 || m >= N ?
+		return nil, nil, ErrMessageTooLong
+	}
+	var pair randomnessPair
+	select {
+	case pair = <-pool.pairs:
+	default:
+		pair = pool.newPair()
+	}
+	N2 := pk.NSquare()
+	// 1. gamma^m = 1 + m*N mod N2
+	Gm := new(big.Int).Mul(m, pk.N)
+	Gm.Add(Gm, one)
+	// 2. (1) * x^N mod N2
+	c = common.ModInt(N2).Mul(Gm, pair.rN)
+	return c, pair.r, nil
+}
+
+// Encrypt is PublicKey.Encrypt with a precomputed pair
+func (pool *RandomnessPool) Encrypt(m *big.Int) (c *big.Int, err error) {
+	c, _, err = pool.EncryptAndReturnRandomness(m)
+	return
+}
+
+func (pool *RandomnessPool) fill() {
+	defer pool.wg.Done()
+	for {
+		select {
+		case <-pool.quit:
+			return
+		default:
+		}
+		pair := pool.newPair()
+		select {
+		case pool.pairs <- pair:
+		case <-pool.quit:
+			return
+		}
+	}
+}
+
+func (pool *RandomnessPool) newPair() randomnessPair {
+	r, rN := pool.pk.randomness()
+	return randomnessPair{r: r, rN: rN}
+}
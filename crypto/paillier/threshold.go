@@ -0,0 +1,241 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+// Threshold decryption follows the scheme of Damgård and Jurik (PKC 2001) with s = 1, which adapts Shoup's threshold
+// RSA to Paillier: the decryption exponent d (d = 0 mod m and d = 1 mod N) is Shamir shared over the integers mod N*m
+// and each party proves that its decryption share uses its share of d.
+
+const (
+	// the bit length of the challenges of DecryptionShareProof and the statistical hiding of its responses
+	thresholdChallengeBits = 256
+	thresholdHidingBits    = 128
+)
+
+type (
+	// ThresholdPublicKey is a Paillier public key whose decryption exponent is shared among PartyCount parties so
+	// that any Threshold+1 of them can decrypt
+	ThresholdPublicKey struct {
+		PublicKey
+		Threshold, PartyCount int
+
+		// V is a random square mod N^2; party i's verification key is V^(Delta*s_i)
+		V *big.Int
+	}
+
+	// DecryptionShareProof proves that a decryption share c_i = c^(2*Delta*s_i) uses the same s_i as the verification
+	// key v_i = V^(Delta*s_i), by the proof of equality of discrete logs of Shoup's threshold RSA
+	DecryptionShareProof struct {
+		E, Z *big.Int
+	}
+)
+
+// GenerateThresholdKey acts as a trusted dealer: it generates a Paillier key of two safe primes and returns its
+// threshold public key, the shares s_1..s_partyCount of its decryption exponent and their verification keys.
+// The dealer learns the private key and must erase the primes and shares once they are distributed.
+func GenerateThresholdKey(modulusBitLen, threshold, partyCount int, timeout time.Duration, optionalConcurrency ...int) (*ThresholdPublicKey, []*big.Int, []*big.Int, error) {
+	var concurrency int
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("GenerateThresholdKey: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	} else {
+		concurrency = runtime.NumCPU()
+	}
+	for {
+		sgps, err := common.GetRandomSafePrimesConcurrent(modulusBitLen/2, 2, timeout, concurrency)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// KS-BTL-F-03: check that p-q is also very large in order to avoid square-root attacks
+		if new(big.Int).Sub(sgps[0].SafePrime(), sgps[1].SafePrime()).BitLen() >= (modulusBitLen/2)-pQBitLenDifference {
+			return NewThresholdKey(threshold, partyCount, sgps[0].Prime(), sgps[1].Prime())
+		}
+	}
+}
+
+// NewThresholdKey deals the threshold key of N = p*q for the safe primes p = 2*pPrime+1 and q = 2*qPrime+1, as
+// GenerateThresholdKey
+func NewThresholdKey(threshold, partyCount int, pPrime, qPrime *big.Int) (*ThresholdPublicKey, []*big.Int, []*big.Int, error) {
+	if threshold < 1 || partyCount <= threshold {
+		return nil, nil, nil, fmt.Errorf("NewThresholdKey: expected 1 <= threshold < partyCount, got %d and %d", threshold, partyCount)
+	}
+	if pPrime == nil || qPrime == nil || pPrime.Cmp(qPrime) == 0 {
+		return nil, nil, nil, errors.New("NewThresholdKey: expected two distinct primes")
+	}
+	p := new(big.Int).Add(new(big.Int).Lsh(pPrime, 1), one)
+	q := new(big.Int).Add(new(big.Int).Lsh(qPrime, 1), one)
+	N := new(big.Int).Mul(p, q)
+	m := new(big.Int).Mul(pPrime, qPrime)
+	pk := &ThresholdPublicKey{PublicKey: PublicKey{N: N}, Threshold: threshold, PartyCount: partyCount}
+	if pk.Delta().Cmp(pPrime) >= 0 || pk.Delta().Cmp(qPrime) >= 0 {
+		return nil, nil, nil, errors.New("NewThresholdKey: the primes are too small for the party count")
+	}
+
+	// d = 0 mod m, d = 1 mod N
+	mInv := new(big.Int).ModInverse(m, N)
+	if mInv == nil {
+		return nil, nil, nil, errors.New("NewThresholdKey: gcd(N, m) != 1")
+	}
+	d := new(big.Int).Mul(m, mInv)
+
+	// share d with a random polynomial mod N*m
+	Nm := new(big.Int).Mul(N, m)
+	modNm := common.ModInt(Nm)
+	poly := make([]*big.Int, threshold+1)
+	poly[0] = d
+	for i := 1; i <= threshold; i++ {
+		poly[i] = common.GetRandomPositiveInt(Nm)
+	}
+	N2 := pk.NSquare()
+	r := common.GetRandomPositiveRelativelyPrimeInt(N2)
+	pk.V = new(big.Int).Exp(r, big.NewInt(2), N2)
+
+	shares, vks := make([]*big.Int, partyCount), make([]*big.Int, partyCount)
+	for i := 0; i < partyCount; i++ {
+		id := big.NewInt(int64(i + 1))
+		// Horner's method
+		share := new(big.Int).Set(poly[threshold])
+		for j := threshold - 1; j >= 0; j-- {
+			share = modNm.Add(modNm.Mul(share, id), poly[j])
+		}
+		shares[i] = share
+		vks[i] = pk.VerificationKey(share)
+	}
+	return pk, shares, vks, nil
+}
+
+// Delta returns PartyCount!, which makes the Lagrange coefficients of the shares integers
+func (pk *ThresholdPublicKey) Delta() *big.Int {
+	return new(big.Int).MulRange(1, int64(pk.PartyCount))
+}
+
+// VerificationKey returns V^(Delta*share) mod N^2
+func (pk *ThresholdPublicKey) VerificationKey(share *big.Int) *big.Int {
+	return secretExp(pk.V, new(big.Int).Mul(pk.Delta(), share), pk.NSquare(), nil)
+}
+
+// DecryptionShare returns the decryption share c^(2*Delta*share) mod N^2 of the ciphertext `c` and its proof
+func (pk *ThresholdPublicKey) DecryptionShare(c, share *big.Int) (*big.Int, *DecryptionShareProof, error) {
+	if !pk.validCiphertext(c) {
+		return nil, nil, ErrMessageTooLong
+	}
+	N2 := pk.NSquare()
+	x := new(big.Int).Mul(pk.Delta(), share)
+	ci := secretExp(c, new(big.Int).Lsh(x, 1), N2, nil)
+
+	// prove log_{c^4}(ci^2) = log_V(vi) = x
+	u, uTilde := new(big.Int).Exp(c, big.NewInt(4), N2), new(big.Int).Exp(ci, big.NewInt(2), N2)
+	vi := secretExp(pk.V, x, N2, nil)
+	rBound := new(big.Int).Lsh(one, uint(x.BitLen()+thresholdChallengeBits+thresholdHidingBits))
+	r, err := rand.Int(rand.Reader, rBound)
+	if err != nil {
+		return nil, nil, err
+	}
+	uPrime, vPrime := secretExp(u, r, N2, nil), secretExp(pk.V, r, N2, nil)
+	e := decryptionShareChallenge(pk.V, u, vi, uTilde, vPrime, uPrime)
+	z := new(big.Int).Add(r, new(big.Int).Mul(e, x))
+	return ci, &DecryptionShareProof{E: e, Z: z}, nil
+}
+
+// VerifyDecryptionShare checks the decryption share `ci` of `c` against the verification key `vi` of its party
+func (pk *ThresholdPublicKey) VerifyDecryptionShare(c, ci, vi *big.Int, proof *DecryptionShareProof) bool {
+	if proof == nil || proof.E == nil || proof.Z == nil || proof.Z.Sign() < 0 || !pk.validCiphertext(c) {
+		return false
+	}
+	N2 := pk.NSquare()
+	for _, a := range []*big.Int{ci, vi} {
+		if a == nil || a.Sign() <= 0 || a.Cmp(N2) >= 0 {
+			return false
+		}
+	}
+	modN2 := common.ModInt(N2)
+	u, uTilde := new(big.Int).Exp(c, big.NewInt(4), N2), new(big.Int).Exp(ci, big.NewInt(2), N2)
+	uTildeInv, viInv := new(big.Int).ModInverse(uTilde, N2), new(big.Int).ModInverse(vi, N2)
+	if uTildeInv == nil || viInv == nil {
+		return false
+	}
+	// u' = u^z / uTilde^e, v' = V^z / vi^e
+	uPrime := modN2.Mul(modN2.Exp(u, proof.Z), modN2.Exp(uTildeInv, proof.E))
+	vPrime := modN2.Mul(modN2.Exp(pk.V, proof.Z), modN2.Exp(viInv, proof.E))
+	e := decryptionShareChallenge(pk.V, u, vi, uTilde, vPrime, uPrime)
+	return e.Cmp(proof.E) == 0
+}
+
+// CombineDecryptionShares recovers the plaintext from the decryption shares `cis` of at least Threshold+1 parties,
+// whose share indexes (1..PartyCount) are given in `ids`
+func (pk *ThresholdPublicKey) CombineDecryptionShares(ids []int, cis []*big.Int) (*big.Int, error) {
+	if len(ids) != len(cis) {
+		return nil, errors.New("CombineDecryptionShares: expected a decryption share for each id")
+	}
+	if len(ids) <= pk.Threshold {
+		return nil, fmt.Errorf("CombineDecryptionShares: expected at least %d shares, got %d", pk.Threshold+1, len(ids))
+	}
+	N, N2 := pk.N, pk.NSquare()
+	modN2 := common.ModInt(N2)
+	delta := pk.Delta()
+	cPrime := big.NewInt(1)
+	for j, idJ := range ids {
+		if idJ < 1 || pk.PartyCount < idJ {
+			return nil, fmt.Errorf("CombineDecryptionShares: invalid id %d", idJ)
+		}
+		// mu_j = Delta * prod(id_k / (id_k - id_j)) is an integer
+		num, den := new(big.Int).Set(delta), big.NewInt(1)
+		for k, idK := range ids {
+			if k == j {
+				continue
+			}
+			if idK == idJ {
+				return nil, errors.New("CombineDecryptionShares: two shares have the same id")
+			}
+			num.Mul(num, big.NewInt(int64(idK)))
+			den.Mul(den, big.NewInt(int64(idK-idJ)))
+		}
+		mu := new(big.Int).Quo(num, den)
+		base := cis[j]
+		if mu.Sign() < 0 {
+			if base = new(big.Int).ModInverse(cis[j], N2); base == nil {
+				return nil, errors.New("CombineDecryptionShares: a decryption share is not invertible")
+			}
+			mu.Neg(mu)
+		}
+		cPrime = modN2.Mul(cPrime, modN2.Exp(base, new(big.Int).Lsh(mu, 1)))
+	}
+	// cPrime = c^(4*Delta^2*d) = 1 + 4*Delta^2*m*N mod N^2
+	fourDelta2 := new(big.Int).Lsh(new(big.Int).Mul(delta, delta), 2)
+	inv := new(big.Int).ModInverse(fourDelta2, N)
+	if inv == nil {
+		return nil, errors.New("CombineDecryptionShares: gcd(4*Delta^2, N) != 1")
+	}
+	return common.ModInt(N).Mul(L(cPrime, N), inv), nil
+}
+
+func (pk *ThresholdPublicKey) validCiphertext(c *big.Int) bool {
+	return pk.ValidateCiphertext(c) == nil
+}
+
+// decryptionShareChallenge draws the challenge in [0, 2^thresholdChallengeBits) of a DecryptionShareProof from its
+// transcript after the statement and the commitments
+func decryptionShareChallenge(v, u, vi, uTilde, vPrime, uPrime *big.Int) *big.Int {
+	tr := zkp.ForProof(nil, "paillier/decryption-share")
+	tr.AppendInts("statement", v, u, vi, uTilde)
+	tr.AppendInts("commitment", vPrime, uPrime)
+	return tr.Challenge("challenge", new(big.Int).Lsh(big.NewInt(1), thresholdChallengeBits))
+}
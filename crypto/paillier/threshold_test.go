@@ -0,0 +1,85 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+func TestThresholdDecryption(t *testing.T) {
+	// a small modulus keeps the safe prime generation of this test short
+	threshold, partyCount := 2, 5
+	pk, shares, vks, err := GenerateThresholdKey(512, threshold, partyCount, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, shares, partyCount)
+
+	m := common.GetRandomPositiveInt(pk.N)
+	c, err := pk.Encrypt(m)
+	assert.NoError(t, err)
+
+	cis := make([]*big.Int, partyCount)
+	for i, share := range shares {
+		ci, proof, err := pk.DecryptionShare(c, share)
+		assert.NoError(t, err)
+		assert.True(t, pk.VerifyDecryptionShare(c, ci, vks[i], proof), "the decryption share must verify")
+		assert.False(t, pk.VerifyDecryptionShare(c, ci, vks[(i+1)%partyCount], proof),
+			"the decryption share must not verify against another party's key")
+		cis[i] = ci
+	}
+
+	// any t+1 shares decrypt
+	for _, ids := range [][]int{{1, 2, 3}, {5, 3, 1}, {2, 3, 4, 5}} {
+		sub := make([]*big.Int, len(ids))
+		for j, id := range ids {
+			sub[j] = cis[id-1]
+		}
+		m2, err := pk.CombineDecryptionShares(ids, sub)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(m2), "ids %v must decrypt", ids)
+	}
+
+	// t shares do not
+	_, err = pk.CombineDecryptionShares([]int{1, 2}, cis[:2])
+	assert.Error(t, err)
+
+	// a share of another ciphertext does not verify
+	c2, _ := pk.Encrypt(big.NewInt(1))
+	ci, proof, _ := pk.DecryptionShare(c2, shares[0])
+	assert.False(t, pk.VerifyDecryptionShare(c, ci, vks[0], proof))
+}
+
+func TestMarshalThresholdPublicKey(t *testing.T) {
+	pk, _, _, err := GenerateThresholdKey(512, 1, 3, time.Minute)
+	assert.NoError(t, err)
+
+	bz, err := json.Marshal(pk)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), `"Threshold":1`)
+	pk2 := new(ThresholdPublicKey)
+	assert.NoError(t, json.Unmarshal(bz, pk2))
+	assert.Equal(t, pk, pk2)
+
+	bz, err = pk.MarshalBinary()
+	assert.NoError(t, err)
+	pk2 = new(ThresholdPublicKey)
+	assert.NoError(t, pk2.UnmarshalBinary(bz))
+	assert.Equal(t, pk, pk2)
+
+	bad := *pk
+	bad.Threshold = bad.PartyCount
+	bz, err = json.Marshal(&bad)
+	assert.NoError(t, err)
+	assert.Error(t, json.Unmarshal(bz, new(ThresholdPublicKey)))
+}
@@ -0,0 +1,133 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+type (
+	// Precomputation holds the fixed-window table of the multiples of a base point (see scalar_mult.go), so that the
+	// callers that multiply the same point in many rounds or sessions, such as the generator or the BigXj of the other
+	// parties, build it once and keep it. It is immutable and safe for concurrent use.
+	Precomputation struct {
+		point *ECPoint
+		// nil on the curves without the Jacobian arithmetic, which multiply with ScalarMult
+		table *fixedBaseTable
+		// the generator of a curve whose own ScalarBaseMult has a table, such as the secp256k1 of btcec
+		base bool
+	}
+)
+
+var (
+	// elliptic.Curve -> the *Precomputation of its generator
+	generatorPrecomputations sync.Map
+)
+
+// NewPrecomputation builds the Precomputation of P
+func NewPrecomputation(P *ECPoint) (*Precomputation, error) {
+	if !P.ValidateBasic() {
+		return nil, errors.New("NewPrecomputation() received an invalid point")
+	}
+	return newPrecomputation(P), nil
+}
+
+func newPrecomputation(P *ECPoint) *Precomputation {
+	pre := &Precomputation{point: P}
+	if wc := weierstrassCurveOf(P.curve); wc != nil {
+		pre.table = wc.newFixedBaseTable(P)
+	}
+	return pre
+}
+
+// GeneratorPrecomputation returns the Precomputation of the generator of the curve, which is built once per curve
+func GeneratorPrecomputation(curve elliptic.Curve) *Precomputation {
+	if pre, ok := generatorPrecomputations.Load(curve); ok {
+		return pre.(*Precomputation)
+	}
+	params := curve.Params()
+	G := NewECPointNoCurveCheck(curve, params.Gx, params.Gy)
+	var pre *Precomputation
+	if genericCurveOf(curve) != nil {
+		pre = newPrecomputation(G)
+	} else {
+		pre = &Precomputation{point: G, base: true}
+	}
+	actual, _ := generatorPrecomputations.LoadOrStore(curve, pre)
+	return actual.(*Precomputation)
+}
+
+// Point returns the base point of the Precomputation
+func (pre *Precomputation) Point() *ECPoint {
+	return pre.point
+}
+
+// ScalarMult returns k*P for the base point P, for k reduced mod the order of the group, or nil if it is the point at
+// infinity
+func (pre *Precomputation) ScalarMult(k *big.Int) *ECPoint {
+	curve := pre.point.curve
+	k = new(big.Int).Mod(k, curve.Params().N)
+	switch {
+	case pre.table != nil:
+		return pre.table.scalarMult(curve, k)
+	case pre.base:
+		x, y := curve.ScalarBaseMult(k.Bytes())
+		p, err := NewECPoint(curve, x, y)
+		if err != nil {
+			return nil
+		}
+		return p
+	}
+	return pre.point.ScalarMult(k)
+}
+
+// ScalarMultPrecomputed returns k*p with the Precomputation `pre` of p, for k reduced mod the order of the group. A nil
+// `pre`, or one of another point, is ignored.
+func (p *ECPoint) ScalarMultPrecomputed(pre *Precomputation, k *big.Int) *ECPoint {
+	if pre == nil || !pre.point.Equals(p) {
+		pre = &Precomputation{point: p}
+	}
+	return pre.ScalarMult(k)
+}
+
+// BatchAdd returns the sum of the points, which must be points of the same curve, with a single inversion on the short
+// Weierstrass curves over fields of up to 256 bits instead of one per Add. It returns an error if the sum is the point
+// at infinity.
+func BatchAdd(points []*ECPoint) (*ECPoint, error) {
+	if len(points) == 0 {
+		return nil, errors.New("BatchAdd() expects at least one point")
+	}
+	curve := points[0].curve
+	for i, point := range points {
+		if !point.ValidateBasic() || point.curve != curve {
+			return nil, fmt.Errorf("BatchAdd() received an invalid point at %d", i)
+		}
+	}
+	wc := weierstrassCurveOf(curve)
+	if wc == nil {
+		// the curves take (0, 0) for the point at infinity, which an intermediate sum may be
+		x, y := points[0].X(), points[0].Y()
+		for _, point := range points[1:] {
+			x, y = curve.Add(x, y, point.X(), point.Y())
+		}
+		return NewECPoint(curve, x, y)
+	}
+	var acc jacobianPoint
+	for _, point := range points {
+		affine := jacobianPoint{x: wc.f.fromBig(point.X()), y: wc.f.fromBig(point.Y()), z: wc.one}
+		wc.addAffine(&acc, &acc, &affine)
+	}
+	sum := wc.toECPoint(curve, &acc)
+	if sum == nil {
+		return nil, errors.New("BatchAdd(): the sum is the point at infinity")
+	}
+	return sum, nil
+}
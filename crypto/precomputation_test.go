@@ -0,0 +1,93 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestPrecomputation(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.S256(), elliptic.P256(), stark.Curve(), ed448.Curve()} {
+		name := curve.Params().Name
+		q := curve.Params().N
+		P := ScalarBaseMult(curve, common.GetRandomPositiveInt(q))
+		pre, err := NewPrecomputation(P)
+		assert.NoError(t, err, name)
+		assert.True(t, P.Equals(pre.Point()), name)
+		G := GeneratorPrecomputation(curve)
+		assert.True(t, G == GeneratorPrecomputation(curve), name)
+
+		for i := 0; i < 3; i++ {
+			k := common.GetRandomPositiveInt(q)
+			assert.True(t, P.ScalarMult(k).Equals(pre.ScalarMult(k)), name)
+			assert.True(t, P.ScalarMult(k).Equals(P.ScalarMultPrecomputed(pre, k)), name)
+			assert.True(t, ScalarBaseMult(curve, k).Equals(G.ScalarMult(k)), name)
+		}
+		// a Precomputation of another point, or none, is ignored
+		k := common.GetRandomPositiveInt(q)
+		assert.True(t, P.ScalarMult(k).Equals(P.ScalarMultPrecomputed(G, k)), name)
+		assert.True(t, P.ScalarMult(k).Equals(P.ScalarMultPrecomputed(nil, k)), name)
+	}
+	_, err := NewPrecomputation(NewECPointNoCurveCheck(tss.S256(), big.NewInt(1), big.NewInt(2)))
+	assert.Error(t, err)
+}
+
+func TestBatchAdd(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.S256(), elliptic.P256(), stark.Curve(), ed448.Curve()} {
+		name := curve.Params().Name
+		q := curve.Params().N
+		points := make([]*ECPoint, 7)
+		for i := range points {
+			points[i] = ScalarBaseMult(curve, common.GetRandomPositiveInt(q))
+		}
+		expected := points[0]
+		for _, point := range points[1:] {
+			var err error
+			expected, err = expected.Add(point)
+			assert.NoError(t, err, name)
+		}
+		sum, err := BatchAdd(points)
+		assert.NoError(t, err, name)
+		assert.True(t, expected.Equals(sum), name)
+
+		// P + P, and P - P in the middle of the sum
+		P := points[0]
+		sum, err = BatchAdd([]*ECPoint{P, P})
+		assert.NoError(t, err, name)
+		assert.True(t, P.ScalarMult(big.NewInt(2)).Equals(sum), name)
+		minusP := P.ScalarMult(new(big.Int).Sub(q, big.NewInt(1)))
+		sum, err = BatchAdd([]*ECPoint{P, minusP, points[1]})
+		assert.NoError(t, err, name)
+		assert.True(t, points[1].Equals(sum), name)
+		if curve != ed448.Curve() { // the identity of ed448 is a point of its ECPoints
+			_, err = BatchAdd([]*ECPoint{P, minusP})
+			assert.Error(t, err, name)
+		}
+	}
+	_, err := BatchAdd(nil)
+	assert.Error(t, err)
+	_, err = BatchAdd([]*ECPoint{ScalarBaseMult(tss.S256(), big.NewInt(1)), ScalarBaseMult(stark.Curve(), big.NewInt(1))})
+	assert.Error(t, err)
+}
+
+func BenchmarkBatchAdd(b *testing.B) {
+	points, _ := benchmarkMultiScalarMultInputs(11)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BatchAdd(points)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package ristretto provides the ristretto255 prime-order group used by sr25519 (Schnorrkel) alongside crypto.ECPoint.
+// Ristretto elements are encoded rather than given by affine coordinates, so they do not fit elliptic.Curve and
+// the curve registry of tss; scalars are kept as *big.Int mod L like the rest of the library and converted at the group operations.
+package ristretto
+
+import (
+	"errors"
+	"math/big"
+
+	r255 "github.com/gtank/ristretto255"
+)
+
+const (
+	// ElementLen is the length in bytes of an encoded ristretto255 element
+	ElementLen = 32
+)
+
+var (
+	// L is the prime order of the ristretto255 group, 2^252 + 27742317777372353535851937790883648493
+	L, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+)
+
+// NewScalar returns `k` mod L as a ristretto255 scalar
+func NewScalar(k *big.Int) *r255.Scalar {
+	kBz := new(big.Int).Mod(k, L).Bytes()
+	// the scalar encoding is little-endian
+	le := make([]byte, 32)
+	for i, b := range kBz {
+		le[len(kBz)-1-i] = b
+	}
+	s := r255.NewScalar()
+	if err := s.Decode(le); err != nil {
+		panic(err) // unreachable: the value has been reduced mod L
+	}
+	return s
+}
+
+// ScalarToInt returns the value of a ristretto255 scalar
+func ScalarToInt(s *r255.Scalar) *big.Int {
+	le := s.Encode(nil)
+	be := make([]byte, len(le))
+	for i, b := range le {
+		be[len(le)-1-i] = b
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// ScalarBaseMult returns k*B for the ristretto255 generator B
+func ScalarBaseMult(k *big.Int) *r255.Element {
+	return r255.NewElement().ScalarBaseMult(NewScalar(k))
+}
+
+// ScalarMult returns k*P
+func ScalarMult(P *r255.Element, k *big.Int) *r255.Element {
+	return r255.NewElement().ScalarMult(NewScalar(k), P)
+}
+
+// Add returns P + Q
+func Add(P, Q *r255.Element) *r255.Element {
+	return r255.NewElement().Add(P, Q)
+}
+
+// IsIdentity returns true if P is the identity element
+func IsIdentity(P *r255.Element) bool {
+	return P.Equal(r255.NewElement().Zero()) == 1
+}
+
+// EncodeElement returns the canonical 32-byte encoding of P
+func EncodeElement(P *r255.Element) []byte {
+	return P.Encode(nil)
+}
+
+// DecodeElement decodes a canonical 32-byte encoding of an element
+func DecodeElement(bz []byte) (*r255.Element, error) {
+	if len(bz) != ElementLen {
+		return nil, errors.New("DecodeElement: invalid length")
+	}
+	P := r255.NewElement()
+	if err := P.Decode(bz); err != nil {
+		return nil, err
+	}
+	return P, nil
+}
+
+// EncodeElements encodes each of the elements in `in`
+func EncodeElements(in []*r255.Element) [][]byte {
+	out := make([][]byte, len(in))
+	for i, P := range in {
+		out[i] = EncodeElement(P)
+	}
+	return out
+}
+
+// DecodeElements decodes each of the encodings in `in`
+func DecodeElements(in [][]byte) ([]*r255.Element, error) {
+	out := make([]*r255.Element, len(in))
+	for i, bz := range in {
+		P, err := DecodeElement(bz)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = P
+	}
+	return out, nil
+}
+
+// FlattenElements returns the encodings of the elements in `in` as integers, for use with the hash commitments
+func FlattenElements(in []*r255.Element) []*big.Int {
+	out := make([]*big.Int, len(in))
+	for i, P := range in {
+		out[i] = new(big.Int).SetBytes(EncodeElement(P))
+	}
+	return out
+}
+
+// UnFlattenElements is the inverse of FlattenElements
+func UnFlattenElements(in []*big.Int) ([]*r255.Element, error) {
+	out := make([]*r255.Element, len(in))
+	for i, n := range in {
+		if n == nil || n.Sign() < 0 || n.BitLen() > 8*ElementLen {
+			return nil, errors.New("UnFlattenElements: invalid element encoding")
+		}
+		bz := make([]byte, ElementLen)
+		nBz := n.Bytes()
+		copy(bz[ElementLen-len(nBz):], nBz)
+		P, err := DecodeElement(bz)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = P
+	}
+	return out, nil
+}
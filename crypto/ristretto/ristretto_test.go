@@ -0,0 +1,186 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ristretto_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	r255 "github.com/gtank/ristretto255"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto/ristretto"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bz
+}
+
+func TestScalars(t *testing.T) {
+	tests := []struct {
+		name string
+		k    *big.Int
+		want *big.Int
+	}{{
+		name: "zero",
+		k:    big.NewInt(0),
+		want: big.NewInt(0),
+	}, {
+		name: "one",
+		k:    big.NewInt(1),
+		want: big.NewInt(1),
+	}, {
+		name: "L-1",
+		k:    new(big.Int).Sub(L, big.NewInt(1)),
+		want: new(big.Int).Sub(L, big.NewInt(1)),
+	}, {
+		name: "L is reduced to zero",
+		k:    new(big.Int).Set(L),
+		want: big.NewInt(0),
+	}, {
+		name: "L+5 is reduced",
+		k:    new(big.Int).Add(L, big.NewInt(5)),
+		want: big.NewInt(5),
+	}, {
+		name: "-1 is reduced to L-1",
+		k:    big.NewInt(-1),
+		want: new(big.Int).Sub(L, big.NewInt(1)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScalarToInt(NewScalar(tt.k)); got.Cmp(tt.want) != 0 {
+				t.Errorf("ScalarToInt(NewScalar(%v)) = %v, want %v", tt.k, got, tt.want)
+			}
+		})
+	}
+	for i := 0; i < 20; i++ {
+		k := common.GetRandomPositiveInt(L)
+		assert.Equal(t, 0, k.Cmp(ScalarToInt(NewScalar(k))), "the scalar of a random value must round-trip")
+	}
+}
+
+// the encodings of B and 2B of RFC 9496, A.1
+func TestEncodeElementVectors(t *testing.T) {
+	B := ScalarBaseMult(big.NewInt(1))
+	assert.Equal(t, "e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76", hex.EncodeToString(EncodeElement(B)))
+	assert.Equal(t, "6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		hex.EncodeToString(EncodeElement(ScalarBaseMult(big.NewInt(2)))))
+	assert.Equal(t, make([]byte, ElementLen), EncodeElement(ScalarBaseMult(big.NewInt(0))), "the identity is encoded as zeros")
+}
+
+func TestEncodeDecodeElement(t *testing.T) {
+	elements := make([]*r255.Element, 0, 10)
+	for i := 0; i < cap(elements); i++ {
+		elements = append(elements, ScalarBaseMult(common.GetRandomPositiveInt(L)))
+	}
+	elements = append(elements, ScalarBaseMult(big.NewInt(0)))
+
+	for _, P := range elements {
+		bz := EncodeElement(P)
+		assert.Len(t, bz, ElementLen)
+		Q, err := DecodeElement(bz)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, P.Equal(Q), "an element must round-trip")
+			assert.True(t, bytes.Equal(bz, EncodeElement(Q)), "the encoding must be canonical")
+		}
+	}
+
+	decoded, err := DecodeElements(EncodeElements(elements))
+	if assert.NoError(t, err) && assert.Len(t, decoded, len(elements)) {
+		for i := range elements {
+			assert.Equal(t, 1, elements[i].Equal(decoded[i]))
+		}
+	}
+	unflattened, err := UnFlattenElements(FlattenElements(elements))
+	if assert.NoError(t, err) && assert.Len(t, unflattened, len(elements)) {
+		for i := range elements {
+			assert.Equal(t, 1, elements[i].Equal(unflattened[i]), "an element with leading zero bytes must round-trip too")
+		}
+	}
+}
+
+// the invalid encodings of RFC 9496, A.2, and of other lengths
+func TestDecodeElementInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		bz   string
+	}{{
+		name: "empty",
+		bz:   "",
+	}, {
+		name: "too short",
+		bz:   "e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d",
+	}, {
+		name: "too long",
+		bz:   "e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d7600",
+	}, {
+		name: "non-canonical field element",
+		bz:   "00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	}, {
+		name: "non-canonical field element p",
+		bz:   "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	}, {
+		name: "negative field element",
+		bz:   "0100000000000000000000000000000000000000000000000000000000000000",
+	}, {
+		name: "negative field element -1",
+		bz:   "01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	}, {
+		name: "non-square x^2",
+		bz:   "26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bz := mustDecodeHex(t, tt.bz)
+			if _, err := DecodeElement(bz); err == nil {
+				t.Errorf("DecodeElement(%s) must fail", tt.bz)
+			}
+			if _, err := DecodeElements([][]byte{EncodeElement(ScalarBaseMult(big.NewInt(1))), bz}); err == nil {
+				t.Errorf("DecodeElements() with %s must fail", tt.bz)
+			}
+			if len(bz) == ElementLen {
+				if _, err := UnFlattenElements([]*big.Int{new(big.Int).SetBytes(bz)}); err == nil {
+					t.Errorf("UnFlattenElements() with %s must fail", tt.bz)
+				}
+			}
+		})
+	}
+
+	tooLong := new(big.Int).Lsh(big.NewInt(1), 8*ElementLen)
+	for _, n := range []*big.Int{nil, big.NewInt(-1), tooLong} {
+		_, err := UnFlattenElements([]*big.Int{n})
+		assert.Error(t, err, "UnFlattenElements(%v) must fail", n)
+	}
+}
+
+func TestGroupLaw(t *testing.T) {
+	identity := ScalarBaseMult(big.NewInt(0))
+	assert.True(t, IsIdentity(identity))
+	assert.True(t, IsIdentity(ScalarBaseMult(L)), "L*B must be the identity")
+	assert.False(t, IsIdentity(ScalarBaseMult(big.NewInt(1))))
+
+	for i := 0; i < 10; i++ {
+		a, b := common.GetRandomPositiveInt(L), common.GetRandomPositiveInt(L)
+		A, B := ScalarBaseMult(a), ScalarBaseMult(b)
+		modL := common.ModInt(L)
+
+		assert.Equal(t, 1, Add(A, B).Equal(ScalarBaseMult(modL.Add(a, b))), "a*B + b*B must be (a+b)*B")
+		assert.Equal(t, 1, Add(A, B).Equal(Add(B, A)), "addition must commute")
+		assert.Equal(t, 1, Add(A, identity).Equal(A), "the identity must be neutral")
+		assert.Equal(t, 1, ScalarMult(A, b).Equal(ScalarMult(B, a)), "b*(a*B) must be a*(b*B)")
+		assert.Equal(t, 1, ScalarMult(A, b).Equal(ScalarBaseMult(modL.Mul(a, b))), "b*(a*B) must be (ab)*B")
+		assert.True(t, IsIdentity(Add(A, ScalarBaseMult(new(big.Int).Sub(L, a)))), "a*B + (L-a)*B must be the identity")
+		assert.True(t, IsIdentity(ScalarMult(A, L)), "L*A must be the identity")
+	}
+}
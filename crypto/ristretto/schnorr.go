@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ristretto
+
+import (
+	"errors"
+	"math/big"
+
+	r255 "github.com/gtank/ristretto255"
+
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	"github.com/binance-chain/tss-lib/crypto/zkp/schnorr"
+)
+
+// ZKProof is a Schnorr ZK proof of knowledge of the discrete logarithm of an element, as schnorr.ZKProof in the
+// ristretto255 group
+type ZKProof struct {
+	Alpha *r255.Element
+	T     *big.Int
+}
+
+// NewZKProofInTranscript constructs a new Schnorr ZK proof of knowledge of x such that X = x*B, with
+// the challenge drawn in the context `ctx`, see schnorr.NewZKProofInTranscript of crypto/schnorr
+func NewZKProofInTranscript(ctx *zkp.Transcript, x *big.Int, X *r255.Element) (*ZKProof, error) {
+	if ctx == nil {
+		return nil, errors.New("the proof requires a context, see zkp.NewSessionTranscript")
+	}
+	if x == nil || X == nil {
+		return nil, errors.New("ZKProof constructor received nil value(s)")
+	}
+	pf, err := schnorr.Prove(SchnorrGroup, schnorr.InTranscript(ctx), x, schnorrElement{X})
+	if err != nil {
+		return nil, err
+	}
+	return &ZKProof{Alpha: pf.Alpha.(schnorrElement).e, T: pf.T}, nil
+}
+
+// VerifyInTranscript verifies the proof, made in the context `ctx`, against the element X
+func (pf *ZKProof) VerifyInTranscript(ctx *zkp.Transcript, X *r255.Element) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() || X == nil {
+		return false
+	}
+	zkPf := &schnorr.Proof{Alpha: schnorrElement{pf.Alpha}, T: pf.T}
+	return zkPf.Verify(SchnorrGroup, schnorr.InTranscript(ctx), schnorrElement{X})
+}
+
+func (pf *ZKProof) ValidateBasic() bool {
+	return pf.T != nil && pf.Alpha != nil
+}
+
+// ----- //
+
+// SchnorrGroup is the ristretto255 group for the proofs of crypto/zkp/schnorr. Its elements are encoded as in
+// EncodeElement.
+var SchnorrGroup schnorr.Group = schnorrGroup{}
+
+type (
+	schnorrGroup struct{}
+
+	schnorrElement struct {
+		e *r255.Element
+	}
+)
+
+// SchnorrElement returns `P` as an element of SchnorrGroup
+func SchnorrElement(P *r255.Element) schnorr.Element {
+	return schnorrElement{P}
+}
+
+func (schnorrGroup) Order() *big.Int {
+	return L
+}
+
+func (schnorrGroup) Generator() schnorr.Element {
+	return schnorrElement{r255.NewElement().Base()}
+}
+
+func (schnorrGroup) ScalarBaseMult(k *big.Int) schnorr.Element {
+	return schnorrElement{ScalarBaseMult(k)}
+}
+
+func (P schnorrElement) Add(other schnorr.Element) (schnorr.Element, error) {
+	return schnorrElement{Add(P.e, other.(schnorrElement).e)}, nil
+}
+
+func (P schnorrElement) ScalarMult(k *big.Int) schnorr.Element {
+	return schnorrElement{ScalarMult(P.e, k)}
+}
+
+func (P schnorrElement) Equal(other schnorr.Element) bool {
+	Q, ok := other.(schnorrElement)
+	return ok && P.e.Equal(Q.e) == 1
+}
+
+func (P schnorrElement) Encode() [][]byte {
+	return [][]byte{EncodeElement(P.e)}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ristretto
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	r255 "github.com/gtank/ristretto255"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+)
+
+// Vs are the Feldman commitments v0..vt to the coefficients of a sharing polynomial in the ristretto255 group
+type Vs []*r255.Element
+
+// CreateVSS returns the Feldman VSS shares of `secret` for each of the `indexes` in the ristretto255 group, with the
+// same semantics as vss.Create. The indexes are reduced mod L.
+func CreateVSS(threshold int, secret *big.Int, indexes []*big.Int) (Vs, vss.Shares, error) {
+	if secret == nil || indexes == nil {
+		return nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, vss.ErrNumSharesBelowThreshold
+	}
+
+	poly := make([]*big.Int, threshold+1)
+	poly[0] = new(big.Int).Mod(secret, L)
+	for i := 1; i <= threshold; i++ {
+		poly[i] = common.GetRandomPositiveInt(L)
+	}
+	v := make(Vs, len(poly))
+	for i, ai := range poly {
+		v[i] = ScalarBaseMult(ai)
+	}
+
+	modL := common.ModInt(L)
+	shares := make(vss.Shares, num)
+	for i := 0; i < num; i++ {
+		id := new(big.Int).Mod(indexes[i], L)
+		if id.Sign() == 0 {
+			return nil, nil, fmt.Errorf("party index should not be 0 mod L")
+		}
+		// Horner's method
+		share := new(big.Int).Set(poly[threshold])
+		for j := threshold - 1; j >= 0; j-- {
+			share = modL.Add(modL.Mul(share, id), poly[j])
+		}
+		shares[i] = &vss.Share{Threshold: threshold, ID: indexes[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// VerifyShare checks a share created by CreateVSS against the commitments `vs`
+func VerifyShare(share *vss.Share, threshold int, vs Vs) bool {
+	if share == nil || share.Threshold != threshold || len(vs) != threshold+1 {
+		return false
+	}
+	modL := common.ModInt(L)
+	id := new(big.Int).Mod(share.ID, L)
+	v, t := vs[0], big.NewInt(1)
+	for j := 1; j <= threshold; j++ {
+		// t = k_i^j
+		t = modL.Mul(t, id)
+		v = Add(v, ScalarMult(vs[j], t))
+	}
+	return ScalarBaseMult(share.Share).Equal(v) == 1
+}
+
+// ReConstruct recovers the secret mod L from at least t+1 shares created by CreateVSS
+func ReConstruct(shares vss.Shares) (*big.Int, error) {
+	if shares != nil && shares[0].Threshold > len(shares)-1 {
+		return nil, vss.ErrNumSharesBelowThreshold
+	}
+	modL := common.ModInt(L)
+	xs := make([]*big.Int, 0, len(shares))
+	for _, share := range shares {
+		xs = append(xs, new(big.Int).Mod(share.ID, L))
+	}
+	secret := big.NewInt(0)
+	for i, share := range shares {
+		times := big.NewInt(1)
+		for j := 0; j < len(xs); j++ {
+			if j == i {
+				continue
+			}
+			sub := modL.Sub(xs[j], xs[i])
+			if sub.Sign() == 0 {
+				return nil, errors.New("two shares have the same index mod L")
+			}
+			times = modL.Mul(times, modL.Mul(xs[j], modL.ModInverse(sub)))
+		}
+		secret = modL.Add(secret, modL.Mul(share.Share, times))
+	}
+	return secret, nil
+}
@@ -0,0 +1,54 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package rsa
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+)
+
+// the DER encodings of the DigestInfo prefixes of RFC 8017 section 9.2
+var hashPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// PKCS1v15Representative returns the message representative of the digest `hashed` made with `hash` under
+// EMSA-PKCS1-v1_5, so that its signature verifies with rsa.VerifyPKCS1v15 of crypto/rsa
+func (pk *ThresholdPublicKey) PKCS1v15Representative(hash crypto.Hash, hashed []byte) (*big.Int, error) {
+	prefix, ok := hashPrefixes[hash]
+	if !ok {
+		return nil, errors.New("PKCS1v15Representative: unsupported hash function")
+	}
+	if len(hashed) != hash.Size() {
+		return nil, errors.New("PKCS1v15Representative: the digest has the wrong length for the hash function")
+	}
+	// EM = 0x00 || 0x01 || PS || 0x00 || T, with at least 8 bytes of 0xff in PS
+	k, tLen := pk.Size(), len(prefix)+len(hashed)
+	if k < tLen+11 {
+		return nil, errors.New("PKCS1v15Representative: the modulus is too short for the digest")
+	}
+	em := make([]byte, k)
+	em[1] = 1
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:], prefix)
+	copy(em[k-len(hashed):], hashed)
+	return new(big.Int).SetBytes(em), nil
+}
+
+// SignatureBytes returns the signature `y` left-padded to the length of the modulus, as PKCS #1 encodes it
+func (pk *ThresholdPublicKey) SignatureBytes(y *big.Int) []byte {
+	sig := make([]byte, pk.Size())
+	bz := y.Bytes()
+	copy(sig[len(sig)-len(bz):], bz)
+	return sig
+}
@@ -0,0 +1,284 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package rsa provides the threshold RSA signatures of Shoup (Eurocrypt 2000): the private exponent of an RSA key of
+// two safe primes is Shamir shared, and any Threshold+1 of the shares make a standard RSA signature, each of them
+// with a proof that it uses its party's share.
+package rsa
+
+import (
+	"crypto/rand"
+	gorsa "crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+const (
+	// the public exponent F4, a prime above any party count so that it is coprime to 4*Delta^2
+	PublicExponent = 65537
+
+	// the bit length of the challenges of SignatureShareProof and the statistical hiding of its responses
+	thresholdChallengeBits = 256
+	thresholdHidingBits    = 128
+
+	pQBitLenDifference = 3
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+type (
+	// ThresholdPublicKey is an RSA public key whose private exponent is shared among PartyCount parties so that any
+	// Threshold+1 of them can sign
+	ThresholdPublicKey struct {
+		N, E                  *big.Int
+		Threshold, PartyCount int
+
+		// V is a random square mod N; party i's verification key is V^s_i
+		V *big.Int
+	}
+
+	// SignatureShareProof proves that a signature share x_i = x^(2*Delta*s_i) uses the same s_i as the verification
+	// key v_i = V^s_i, by Shoup's proof of equality of discrete logs
+	SignatureShareProof struct {
+		E, Z *big.Int
+	}
+)
+
+// GenerateThresholdKey acts as a trusted dealer: it generates an RSA key of two safe primes and returns its threshold
+// public key, the shares s_1..s_partyCount of its private exponent and their verification keys.
+// The dealer learns the private key and must erase the primes and shares once they are distributed.
+func GenerateThresholdKey(modulusBitLen, threshold, partyCount int, timeout time.Duration, optionalConcurrency ...int) (*ThresholdPublicKey, []*big.Int, []*big.Int, error) {
+	var concurrency int
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("GenerateThresholdKey: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	} else {
+		concurrency = runtime.NumCPU()
+	}
+	for {
+		sgps, err := common.GetRandomSafePrimesConcurrent(modulusBitLen/2, 2, timeout, concurrency)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// check that p-q is also very large in order to avoid square-root attacks
+		if new(big.Int).Sub(sgps[0].SafePrime(), sgps[1].SafePrime()).BitLen() >= (modulusBitLen/2)-pQBitLenDifference {
+			return NewThresholdKey(threshold, partyCount, sgps[0].Prime(), sgps[1].Prime())
+		}
+	}
+}
+
+// NewThresholdKey deals the threshold key of N = p*q for the safe primes p = 2*pPrime+1 and q = 2*qPrime+1, as
+// GenerateThresholdKey
+func NewThresholdKey(threshold, partyCount int, pPrime, qPrime *big.Int) (*ThresholdPublicKey, []*big.Int, []*big.Int, error) {
+	if threshold < 1 || partyCount <= threshold {
+		return nil, nil, nil, fmt.Errorf("NewThresholdKey: expected 1 <= threshold < partyCount, got %d and %d", threshold, partyCount)
+	}
+	if PublicExponent <= partyCount {
+		return nil, nil, nil, fmt.Errorf("NewThresholdKey: expected fewer than %d parties", PublicExponent)
+	}
+	if pPrime == nil || qPrime == nil || pPrime.Cmp(qPrime) == 0 {
+		return nil, nil, nil, errors.New("NewThresholdKey: expected two distinct primes")
+	}
+	p := new(big.Int).Add(new(big.Int).Lsh(pPrime, 1), one)
+	q := new(big.Int).Add(new(big.Int).Lsh(qPrime, 1), one)
+	N := new(big.Int).Mul(p, q)
+	m := new(big.Int).Mul(pPrime, qPrime)
+	pk := &ThresholdPublicKey{N: N, E: big.NewInt(PublicExponent), Threshold: threshold, PartyCount: partyCount}
+	if pk.Delta().Cmp(pPrime) >= 0 || pk.Delta().Cmp(qPrime) >= 0 {
+		return nil, nil, nil, errors.New("NewThresholdKey: the primes are too small for the party count")
+	}
+
+	// d = 1/e mod m
+	d := new(big.Int).ModInverse(pk.E, m)
+	if d == nil {
+		return nil, nil, nil, errors.New("NewThresholdKey: gcd(e, m) != 1")
+	}
+
+	// share d with a random polynomial mod m
+	modM := common.ModInt(m)
+	poly := make([]*big.Int, threshold+1)
+	poly[0] = d
+	for i := 1; i <= threshold; i++ {
+		poly[i] = common.GetRandomPositiveInt(m)
+	}
+	r := common.GetRandomPositiveRelativelyPrimeInt(N)
+	pk.V = new(big.Int).Exp(r, big.NewInt(2), N)
+
+	shares, vks := make([]*big.Int, partyCount), make([]*big.Int, partyCount)
+	for i := 0; i < partyCount; i++ {
+		id := big.NewInt(int64(i + 1))
+		// Horner's method
+		share := new(big.Int).Set(poly[threshold])
+		for j := threshold - 1; j >= 0; j-- {
+			share = modM.Add(modM.Mul(share, id), poly[j])
+		}
+		shares[i] = share
+		vks[i] = pk.VerificationKey(share)
+	}
+	return pk, shares, vks, nil
+}
+
+// PublicKey returns the key as a crypto/rsa public key, e.g. to put it in an X.509 certificate
+func (pk *ThresholdPublicKey) PublicKey() *gorsa.PublicKey {
+	return &gorsa.PublicKey{N: new(big.Int).Set(pk.N), E: int(pk.E.Int64())}
+}
+
+// Size returns the length in bytes of the modulus and of the signatures
+func (pk *ThresholdPublicKey) Size() int {
+	return (pk.N.BitLen() + 7) / 8
+}
+
+// Delta returns PartyCount!, which makes the Lagrange coefficients of the shares integers
+func (pk *ThresholdPublicKey) Delta() *big.Int {
+	return new(big.Int).MulRange(1, int64(pk.PartyCount))
+}
+
+// VerificationKey returns V^share mod N
+func (pk *ThresholdPublicKey) VerificationKey(share *big.Int) *big.Int {
+	return new(big.Int).Exp(pk.V, share, pk.N)
+}
+
+// SignatureShare returns the signature share x^(2*Delta*share) mod N of the message representative `x` and its proof
+func (pk *ThresholdPublicKey) SignatureShare(x, share *big.Int) (*big.Int, *SignatureShareProof, error) {
+	if !pk.validRepresentative(x) {
+		return nil, nil, errors.New("SignatureShare: the message representative is out of range")
+	}
+	N := pk.N
+	delta := pk.Delta()
+	xi := new(big.Int).Exp(x, new(big.Int).Lsh(new(big.Int).Mul(delta, share), 1), N)
+
+	// prove log_{x^(4*Delta)}(xi^2) = log_V(vi) = share
+	xTilde, xi2 := new(big.Int).Exp(x, new(big.Int).Lsh(delta, 2), N), new(big.Int).Exp(xi, big.NewInt(2), N)
+	vi := pk.VerificationKey(share)
+	rBound := new(big.Int).Lsh(one, uint(N.BitLen()+thresholdChallengeBits+thresholdHidingBits))
+	r, err := rand.Int(rand.Reader, rBound)
+	if err != nil {
+		return nil, nil, err
+	}
+	vPrime, xPrime := new(big.Int).Exp(pk.V, r, N), new(big.Int).Exp(xTilde, r, N)
+	e := signatureShareChallenge(pk.V, xTilde, vi, xi2, vPrime, xPrime)
+	z := new(big.Int).Add(r, new(big.Int).Mul(e, share))
+	return xi, &SignatureShareProof{E: e, Z: z}, nil
+}
+
+// VerifySignatureShare checks the signature share `xi` of `x` against the verification key `vi` of its party
+func (pk *ThresholdPublicKey) VerifySignatureShare(x, xi, vi *big.Int, proof *SignatureShareProof) bool {
+	if proof == nil || proof.E == nil || proof.Z == nil || proof.Z.Sign() < 0 || !pk.validRepresentative(x) {
+		return false
+	}
+	N := pk.N
+	for _, a := range []*big.Int{xi, vi} {
+		if a == nil || a.Sign() <= 0 || a.Cmp(N) >= 0 {
+			return false
+		}
+	}
+	modN := common.ModInt(N)
+	xTilde, xi2 := new(big.Int).Exp(x, new(big.Int).Lsh(pk.Delta(), 2), N), new(big.Int).Exp(xi, big.NewInt(2), N)
+	xi2Inv, viInv := new(big.Int).ModInverse(xi2, N), new(big.Int).ModInverse(vi, N)
+	if xi2Inv == nil || viInv == nil {
+		return false
+	}
+	// v' = V^z / vi^e, x' = xTilde^z / xi2^e
+	vPrime := modN.Mul(modN.Exp(pk.V, proof.Z), modN.Exp(viInv, proof.E))
+	xPrime := modN.Mul(modN.Exp(xTilde, proof.Z), modN.Exp(xi2Inv, proof.E))
+	e := signatureShareChallenge(pk.V, xTilde, vi, xi2, vPrime, xPrime)
+	return e.Cmp(proof.E) == 0
+}
+
+// CombineSignatureShares makes the signature y = x^d mod N of the message representative `x` from the signature
+// shares `xis` of at least Threshold+1 parties, whose share indexes (1..PartyCount) are given in `ids`
+func (pk *ThresholdPublicKey) CombineSignatureShares(x *big.Int, ids []int, xis []*big.Int) (*big.Int, error) {
+	if !pk.validRepresentative(x) {
+		return nil, errors.New("CombineSignatureShares: the message representative is out of range")
+	}
+	if len(ids) != len(xis) {
+		return nil, errors.New("CombineSignatureShares: expected a signature share for each id")
+	}
+	if len(ids) <= pk.Threshold {
+		return nil, fmt.Errorf("CombineSignatureShares: expected at least %d shares, got %d", pk.Threshold+1, len(ids))
+	}
+	N := pk.N
+	modN := common.ModInt(N)
+	delta := pk.Delta()
+	w := big.NewInt(1)
+	for j, idJ := range ids {
+		if idJ < 1 || pk.PartyCount < idJ {
+			return nil, fmt.Errorf("CombineSignatureShares: invalid id %d", idJ)
+		}
+		// lambda_j = Delta * prod(id_k / (id_k - id_j)) is an integer
+		num, den := new(big.Int).Set(delta), big.NewInt(1)
+		for k, idK := range ids {
+			if k == j {
+				continue
+			}
+			if idK == idJ {
+				return nil, errors.New("CombineSignatureShares: two shares have the same id")
+			}
+			num.Mul(num, big.NewInt(int64(idK)))
+			den.Mul(den, big.NewInt(int64(idK-idJ)))
+		}
+		lambda := new(big.Int).Quo(num, den)
+		wj, err := expSigned(xis[j], new(big.Int).Lsh(lambda, 1), N)
+		if err != nil {
+			return nil, errors.New("CombineSignatureShares: a signature share is not invertible")
+		}
+		w = modN.Mul(w, wj)
+	}
+	// w = x^(4*Delta^2*d), so with a*4*Delta^2 + b*e = 1, y = w^a * x^b
+	ePrime := new(big.Int).Lsh(new(big.Int).Mul(delta, delta), 2)
+	a, b := new(big.Int), new(big.Int)
+	if new(big.Int).GCD(a, b, ePrime, pk.E).Cmp(one) != 0 {
+		return nil, errors.New("CombineSignatureShares: gcd(4*Delta^2, e) != 1")
+	}
+	wa, err := expSigned(w, a, N)
+	if err != nil {
+		return nil, err
+	}
+	xb, err := expSigned(x, b, N)
+	if err != nil {
+		return nil, err
+	}
+	y := modN.Mul(wa, xb)
+	if new(big.Int).Exp(y, pk.E, N).Cmp(x) != 0 {
+		return nil, errors.New("CombineSignatureShares: the combined signature does not verify")
+	}
+	return y, nil
+}
+
+func (pk *ThresholdPublicKey) validRepresentative(x *big.Int) bool {
+	return x != nil && x.Sign() > 0 && x.Cmp(pk.N) < 0 && new(big.Int).GCD(nil, nil, x, pk.N).Cmp(one) == 0
+}
+
+// expSigned returns base^exp mod N for a negative `exp` as well
+func expSigned(base, exp, N *big.Int) (*big.Int, error) {
+	if exp.Sign() >= 0 {
+		return new(big.Int).Exp(base, exp, N), nil
+	}
+	inv := new(big.Int).ModInverse(base, N)
+	if inv == nil {
+		return nil, errors.New("expSigned: the base is not invertible")
+	}
+	return new(big.Int).Exp(inv, new(big.Int).Neg(exp), N), nil
+}
+
+// signatureShareChallenge draws the challenge in [0, 2^thresholdChallengeBits) of a SignatureShareProof from its
+// transcript after the statement and the commitments
+func signatureShareChallenge(v, xTilde, vi, xi2, vPrime, xPrime *big.Int) *big.Int {
+	tr := zkp.ForProof(nil, "rsa/signature-share")
+	tr.AppendInts("statement", v, xTilde, vi, xi2)
+	tr.AppendInts("commitment", vPrime, xPrime)
+	return tr.Challenge("challenge", new(big.Int).Lsh(big.NewInt(1), thresholdChallengeBits))
+}
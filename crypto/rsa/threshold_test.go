@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package rsa_test
+
+import (
+	"crypto"
+	gorsa "crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/binance-chain/tss-lib/crypto/rsa"
+)
+
+func TestThresholdSigning(t *testing.T) {
+	// a small modulus keeps the safe prime generation of this test short
+	threshold, partyCount := 2, 5
+	pk, shares, vks, err := GenerateThresholdKey(512, threshold, partyCount, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, shares, partyCount)
+
+	hashed := sha256.Sum256([]byte("hello"))
+	x, err := pk.PKCS1v15Representative(crypto.SHA256, hashed[:])
+	assert.NoError(t, err)
+
+	xis := make([]*big.Int, partyCount)
+	for i, share := range shares {
+		xi, proof, err := pk.SignatureShare(x, share)
+		assert.NoError(t, err)
+		assert.True(t, pk.VerifySignatureShare(x, xi, vks[i], proof), "the signature share must verify")
+		assert.False(t, pk.VerifySignatureShare(x, xi, vks[(i+1)%partyCount], proof),
+			"the signature share must not verify against another party's key")
+		xis[i] = xi
+	}
+
+	// any t+1 shares sign
+	for _, ids := range [][]int{{1, 2, 3}, {5, 3, 1}, {2, 3, 4, 5}} {
+		sub := make([]*big.Int, len(ids))
+		for j, id := range ids {
+			sub[j] = xis[id-1]
+		}
+		y, err := pk.CombineSignatureShares(x, ids, sub)
+		assert.NoError(t, err)
+		err = gorsa.VerifyPKCS1v15(pk.PublicKey(), crypto.SHA256, hashed[:], pk.SignatureBytes(y))
+		assert.NoError(t, err, "ids %v must sign", ids)
+	}
+
+	// t shares do not
+	_, err = pk.CombineSignatureShares(x, []int{1, 2}, xis[:2])
+	assert.Error(t, err)
+
+	// a share of another message does not verify
+	other := sha256.Sum256([]byte("world"))
+	x2, _ := pk.PKCS1v15Representative(crypto.SHA256, other[:])
+	xi, proof, _ := pk.SignatureShare(x2, shares[0])
+	assert.False(t, pk.VerifySignatureShare(x, xi, vks[0], proof))
+}
+
+func TestPKCS1v15Representative(t *testing.T) {
+	pk, _, _, err := GenerateThresholdKey(512, 1, 2, time.Minute)
+	assert.NoError(t, err)
+
+	_, err = pk.PKCS1v15Representative(crypto.SHA256, []byte{1, 2, 3})
+	assert.Error(t, err, "a digest of the wrong length must be rejected")
+	_, err = pk.PKCS1v15Representative(crypto.MD5, make([]byte, 16))
+	assert.Error(t, err, "an unsupported hash must be rejected")
+
+	// SHA-512 does not fit in a 512-bit modulus
+	_, err = pk.PKCS1v15Representative(crypto.SHA512, make([]byte, 64))
+	assert.Error(t, err)
+}
@@ -0,0 +1,233 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+
+	s256k1 "github.com/btcsuite/btcd/btcec"
+)
+
+// The scalar multiplications of this file use the Jacobian arithmetic of MultiScalarMult. A variable base is multiplied
+// with its width-w NAF, the signed digits of which are odd and at least w bits apart, so that only the odd multiples
+// 1*P..(2^(w-1)-1)*P are precomputed and about one in w+1 bits costs an addition. A base that is multiplied many times,
+// such as the generator or the BigXj of the other parties, is better served by a fixed-window table of the multiples
+// d * 2^(w*i) * P for every window i, which makes a multiplication one addition per window and no doubling.
+
+const (
+	wnafWidth = 5
+
+	fixedBaseWindow = 4
+	// the fixed-base tables kept for ScalarMultFixedBase; each one has 960 points on a 256-bit curve
+	fixedBaseTableCacheSize = 64
+)
+
+type (
+	// fixedBaseTable holds the affine multiples d * 2^(w*i) * P for d in [1, 2^w) of each window i of the scalars
+	fixedBaseTable struct {
+		wc      *weierstrassCurve
+		windows [][]jacobianPoint
+	}
+
+	fixedBasePrecomputationKey struct {
+		curve elliptic.Curve
+		x, y  string
+	}
+)
+
+var (
+	fixedBasePrecomputationsMtx sync.Mutex
+	// the Precomputations of the bases given to ScalarMultFixedBase
+	fixedBasePrecomputations = make(map[fixedBasePrecomputationKey]*Precomputation, fixedBaseTableCacheSize)
+)
+
+// ScalarMultFixedBase returns k*P, for k reduced mod the order of the group, with a Precomputation of P that is cached
+// for the next multiplications of the same base. Building one costs a few scalar multiplications, so it pays off for
+// the bases that are multiplied repeatedly, e.g. the BigXj of the save data in every signing. A caller that knows its
+// bases can keep their Precomputations instead.
+func ScalarMultFixedBase(P *ECPoint, k *big.Int) *ECPoint {
+	return cachedPrecomputation(P).ScalarMult(k)
+}
+
+func cachedPrecomputation(P *ECPoint) *Precomputation {
+	if weierstrassCurveOf(P.curve) == nil {
+		return &Precomputation{point: P}
+	}
+	key := fixedBasePrecomputationKey{curve: P.curve, x: string(P.coords[0].Bytes()), y: string(P.coords[1].Bytes())}
+	fixedBasePrecomputationsMtx.Lock()
+	pre, ok := fixedBasePrecomputations[key]
+	fixedBasePrecomputationsMtx.Unlock()
+	if ok {
+		return pre
+	}
+	pre = newPrecomputation(P)
+	fixedBasePrecomputationsMtx.Lock()
+	defer fixedBasePrecomputationsMtx.Unlock()
+	if fixedBaseTableCacheSize <= len(fixedBasePrecomputations) {
+		// the bases of a long running process change with its keys; start over rather than track their use
+		fixedBasePrecomputations = make(map[fixedBasePrecomputationKey]*Precomputation, fixedBaseTableCacheSize)
+	}
+	fixedBasePrecomputations[key] = pre
+	return pre
+}
+
+// newFixedBaseTable returns nil if a multiple of P is the point at infinity, which only a point of a small order
+// outside the group has
+func (wc *weierstrassCurve) newFixedBaseTable(P *ECPoint) *fixedBaseTable {
+	windows := (P.curve.Params().N.BitLen() + fixedBaseWindow - 1) / fixedBaseWindow
+	size := 1<<fixedBaseWindow - 1
+	all := make([]jacobianPoint, windows*size)
+	base := jacobianPoint{x: wc.f.fromBig(P.X()), y: wc.f.fromBig(P.Y()), z: wc.one}
+	for i := 0; i < windows; i++ {
+		window := all[i*size : (i+1)*size]
+		window[0] = base
+		for d := 1; d < size; d++ {
+			wc.addAffine(&window[d], &window[d-1], &base)
+			if window[d].z.isZero() {
+				return nil
+			}
+		}
+		// the base of the next window is 2^w times this one, i.e. (2^w-1)*base + base
+		var next jacobianPoint
+		wc.addAffine(&next, &window[size-1], &base)
+		if next.z.isZero() {
+			return nil
+		}
+		nextAffine := []jacobianPoint{next}
+		wc.batchToAffine(nextAffine)
+		base = nextAffine[0]
+	}
+	wc.batchToAffine(all)
+	table := &fixedBaseTable{wc: wc, windows: make([][]jacobianPoint, windows)}
+	for i := range table.windows {
+		table.windows[i] = all[i*size : (i+1)*size]
+	}
+	return table
+}
+
+// scalarMult returns k*P, or nil if it is the point at infinity
+func (t *fixedBaseTable) scalarMult(curve elliptic.Curve, k *big.Int) *ECPoint {
+	k = new(big.Int).Mod(k, curve.Params().N)
+	var acc jacobianPoint
+	for i, window := range t.windows {
+		digit := 0
+		for b := fixedBaseWindow - 1; 0 <= b; b-- {
+			digit = digit<<1 | int(k.Bit(i*fixedBaseWindow+b))
+		}
+		if digit != 0 {
+			t.wc.addAffine(&acc, &acc, &window[digit-1])
+		}
+	}
+	return t.wc.toECPoint(curve, &acc)
+}
+
+// genericCurveOf returns the Jacobian arithmetic for the curves whose own ScalarMult and ScalarBaseMult are the
+// generic ones of elliptic.CurveParams, such as the STARK curve, or nil for the others. The secp256k1 of btcec, with its
+// endomorphism and its table of the multiples of G, is faster than the wNAF here.
+func genericCurveOf(curve elliptic.Curve) *weierstrassCurve {
+	if _, ok := curve.(*s256k1.KoblitzCurve); ok {
+		return nil
+	}
+	return weierstrassCurveOf(curve)
+}
+
+// scalarMultWNAF returns k*P with the width-w NAF of k, or nil if it is the point at infinity
+func (wc *weierstrassCurve) scalarMultWNAF(P *ECPoint, k *big.Int) *ECPoint {
+	k = new(big.Int).Mod(k, P.curve.Params().N)
+	table := wc.oddMultiplesTables([]*ECPoint{P}, wnafWidth)
+	if table == nil {
+		return nil
+	}
+	var acc jacobianPoint
+	digits := wnaf(k, wnafWidth)
+	for d := len(digits) - 1; 0 <= d; d-- {
+		wc.double(&acc, &acc)
+		wc.addDigit(&acc, table[0], digits[d])
+	}
+	return wc.toECPoint(P.curve, &acc)
+}
+
+// oddMultiplesTables returns the affine odd multiples 1*P, 3*P..(2^(w-1)-1)*P of each of the points, normalized with
+// one inversion, or nil if one of them is the point at infinity
+func (wc *weierstrassCurve) oddMultiplesTables(points []*ECPoint, w uint) [][]jacobianPoint {
+	size := 1 << (w - 2)
+	all := make([]jacobianPoint, len(points)*size)
+	doubles := make([]jacobianPoint, len(points))
+	for i, pt := range points {
+		all[i*size] = jacobianPoint{x: wc.f.fromBig(pt.X()), y: wc.f.fromBig(pt.Y()), z: wc.one}
+		wc.double(&doubles[i], &all[i*size])
+		if doubles[i].z.isZero() {
+			return nil
+		}
+	}
+	wc.batchToAffine(doubles)
+	for i := range points {
+		table := all[i*size : (i+1)*size]
+		for j := 1; j < size; j++ {
+			wc.addAffine(&table[j], &table[j-1], &doubles[i])
+			if table[j].z.isZero() {
+				return nil
+			}
+		}
+	}
+	wc.batchToAffine(all)
+	tables := make([][]jacobianPoint, len(points))
+	for i := range tables {
+		tables[i] = all[i*size : (i+1)*size]
+	}
+	return tables
+}
+
+// addDigit adds digit*P to acc, for an odd digit of a wNAF and the odd multiples of P, or does nothing for digit 0
+func (wc *weierstrassCurve) addDigit(acc *jacobianPoint, table []jacobianPoint, digit int) {
+	switch {
+	case 0 < digit:
+		wc.addAffine(acc, acc, &table[digit>>1])
+	case digit < 0:
+		neg := table[(-digit)>>1]
+		wc.f.sub(&neg.y, &fieldElement{}, &neg.y)
+		wc.addAffine(acc, acc, &neg)
+	}
+}
+
+// toECPoint converts a Jacobian point to an ECPoint, or nil for the point at infinity
+func (wc *weierstrassCurve) toECPoint(curve elliptic.Curve, p *jacobianPoint) *ECPoint {
+	if p.z.isZero() {
+		return nil
+	}
+	affine := []jacobianPoint{*p}
+	wc.batchToAffine(affine)
+	pt, err := NewECPoint(curve, wc.f.toBig(&affine[0].x), wc.f.toBig(&affine[0].y))
+	if err != nil {
+		return nil
+	}
+	return pt
+}
+
+// wnaf returns the width-w NAF of k >= 0, least significant digit first: every digit is 0 or odd in
+// (-2^(w-1), 2^(w-1)), and of any w consecutive digits at most one is not 0
+func wnaf(k *big.Int, w uint) []int {
+	k = new(big.Int).Set(k)
+	window := 1 << w
+	digits := make([]int, 0, k.BitLen()+1)
+	digit := new(big.Int)
+	for k.Sign() > 0 {
+		d := 0
+		if k.Bit(0) == 1 {
+			d = int(k.Bits()[0] & big.Word(window-1))
+			if window/2 <= d {
+				d -= window
+			}
+			k.Sub(k, digit.SetInt64(int64(d)))
+		}
+		digits = append(digits, d)
+		k.Rsh(k, 1)
+	}
+	return digits
+}
@@ -0,0 +1,74 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestScalarMult(t *testing.T) {
+	// the STARK curve and P-256 by its params multiply with the wNAF, which must agree with their own ScalarMult
+	for _, curve := range []elliptic.Curve{stark.Curve(), elliptic.P256().Params()} {
+		q := curve.Params().N
+		P := ScalarBaseMult(curve, common.GetRandomPositiveInt(q))
+		scalars := []*big.Int{
+			big.NewInt(1), big.NewInt(2), big.NewInt(31), new(big.Int).Sub(q, big.NewInt(1)),
+			common.GetRandomPositiveInt(q), new(big.Int).Add(q, big.NewInt(7)),
+		}
+		for _, k := range scalars {
+			x, y := curve.ScalarMult(P.X(), P.Y(), k.Bytes())
+			assert.True(t, NewECPointNoCurveCheck(curve, x, y).Equals(P.ScalarMult(k)), "%s, k = %s", curve.Params().Name, k)
+			x, y = curve.ScalarBaseMult(k.Bytes())
+			assert.True(t, NewECPointNoCurveCheck(curve, x, y).Equals(ScalarBaseMult(curve, k)), "%s, k = %s", curve.Params().Name, k)
+		}
+		assert.Nil(t, P.ScalarMult(q))
+		assert.Nil(t, P.ScalarMult(big.NewInt(0)))
+	}
+}
+
+func TestScalarMultFixedBase(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.S256(), elliptic.P256(), stark.Curve(), ed448.Curve()} {
+		q := curve.Params().N
+		P := ScalarBaseMult(curve, common.GetRandomPositiveInt(q))
+		for i := 0; i < 3; i++ { // the first one builds the table
+			k := common.GetRandomPositiveInt(q)
+			assert.True(t, P.ScalarMult(k).Equals(ScalarMultFixedBase(P, k)), curve.Params().Name)
+		}
+		minusOne := new(big.Int).Sub(q, big.NewInt(1))
+		assert.True(t, P.ScalarMult(minusOne).Equals(ScalarMultFixedBase(P, big.NewInt(-1))), curve.Params().Name)
+		if curve != ed448.Curve() { // the identity of ed448 is a point of its ECPoints
+			assert.Nil(t, ScalarMultFixedBase(P, q), curve.Params().Name)
+		}
+	}
+}
+
+func BenchmarkScalarMultFixedBase(b *testing.B) {
+	points, scalars := benchmarkMultiScalarMultInputs(1)
+	ScalarMultFixedBase(points[0], scalars[0])
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ScalarMultFixedBase(points[0], scalars[0])
+	}
+}
+
+func BenchmarkScalarMult(b *testing.B) {
+	points, scalars := benchmarkMultiScalarMultInputs(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		points[0].ScalarMult(scalars[0])
+	}
+}
@@ -12,9 +12,12 @@ import (
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
-	"github.com/binance-chain/tss-lib/tss"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	zkschnorr "github.com/binance-chain/tss-lib/crypto/zkp/schnorr"
 )
 
+var errNoContext = errors.New("the proof requires a context, see zkp.NewSessionTranscript")
+
 type (
 	ZKProof struct {
 		Alpha *crypto.ECPoint
@@ -25,80 +28,72 @@ type (
 		Alpha *crypto.ECPoint
 		T, U  *big.Int
 	}
-)
 
-// NewZKProof constructs a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16)
-func NewZKProof(x *big.Int, X *crypto.ECPoint) (*ZKProof, error) {
-	if x == nil || X == nil || !X.ValidateBasic() {
-		return nil, errors.New("ZKProof constructor received nil or invalid value(s)")
+	ZKSTProof struct {
+		A1, A2 *crypto.ECPoint
+		Z1, Z2 *big.Int
 	}
-	ecParams := tss.EC().Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(tss.EC(), ecParams.Gx, ecParams.Gy) // already on the curve.
 
-	a := common.GetRandomPositiveInt(q)
-	alpha := crypto.ScalarBaseMult(tss.EC(), a)
-
-	var c *big.Int
-	{
-		cHash := common.SHA512_256i(X.X(), X.Y(), g.X(), g.Y(), alpha.X(), alpha.Y())
-		c = common.RejectionSample(q, cHash)
+	ZKDLEQProof struct {
+		A1, A2 *crypto.ECPoint
+		Z      *big.Int
 	}
-	t := new(big.Int).Mul(c, x)
-	t = common.ModInt(q).Add(a, t)
+)
 
-	return &ZKProof{Alpha: alpha, T: t}, nil
-}
+// The challenges of the proofs of this package are drawn from a zkp.Transcript. The constructors and VerifyInTranscript
+// take the context of the proof, e.g. a transcript made with zkp.NewSessionTranscript with the round and the prover,
+// which they leave as it is, so that a proof cannot be replayed in another session, round or protocol. A nil context is
+// rejected. The proofs are on the curve of the points of their statement.
 
-// NewZKProof verifies a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16)
-func (pf *ZKProof) Verify(X *crypto.ECPoint) bool {
-	if pf == nil || !pf.ValidateBasic() {
-		return false
+// NewZKProofInTranscript constructs a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16),
+// with the challenge drawn in the context `ctx`
+func NewZKProofInTranscript(ctx *zkp.Transcript, x *big.Int, X *crypto.ECPoint) (*ZKProof, error) {
+	if ctx == nil {
+		return nil, errNoContext
 	}
-	ecParams := tss.EC().Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(tss.EC(), ecParams.Gx, ecParams.Gy)
-
-	var c *big.Int
-	{
-		cHash := common.SHA512_256i(X.X(), X.Y(), g.X(), g.Y(), pf.Alpha.X(), pf.Alpha.Y())
-		c = common.RejectionSample(q, cHash)
+	if x == nil || X == nil || !X.ValidateBasic() {
+		return nil, errors.New("ZKProof constructor received nil or invalid value(s)")
 	}
-	tG := crypto.ScalarBaseMult(tss.EC(), pf.T)
-	Xc := X.ScalarMult(c)
-	aXc, err := pf.Alpha.Add(Xc)
+	pf, err := zkschnorr.Prove(zkschnorr.CurveGroup(X.Curve()), zkschnorr.InTranscript(ctx), x, (*zkschnorr.CurvePoint)(X))
 	if err != nil {
-		return false
+		return nil, err
 	}
-	if aXc.X().Cmp(tG.X()) != 0 || aXc.Y().Cmp(tG.Y()) != 0 {
+	return &ZKProof{Alpha: pf.Alpha.(*zkschnorr.CurvePoint).ECPoint(), T: pf.T}, nil
+}
+
+// VerifyInTranscript is Verify for a proof made with NewZKProofInTranscript in the context `ctx`
+func (pf *ZKProof) VerifyInTranscript(ctx *zkp.Transcript, X *crypto.ECPoint) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() || X == nil {
 		return false
 	}
-	return true
+	zkPf := &zkschnorr.Proof{Alpha: (*zkschnorr.CurvePoint)(pf.Alpha), T: pf.T}
+	return zkPf.Verify(zkschnorr.CurveGroup(X.Curve()), zkschnorr.InTranscript(ctx), (*zkschnorr.CurvePoint)(X))
 }
 
 func (pf *ZKProof) ValidateBasic() bool {
 	return pf.T != nil && pf.Alpha != nil
 }
 
-// NewZKProof constructs a new Schnorr ZK proof of knowledge s_i, l_i such that V_i = R^s_i, g^l_i (GG18Spec Fig. 17)
-func NewZKVProof(V, R *crypto.ECPoint, s, l *big.Int) (*ZKVProof, error) {
+// NewZKVProofInTranscript constructs a new Schnorr ZK proof of knowledge s_i, l_i such that V_i = R^s_i, g^l_i
+// (GG18Spec Fig. 17), with the challenge drawn in the context `ctx`
+func NewZKVProofInTranscript(ctx *zkp.Transcript, V, R *crypto.ECPoint, s, l *big.Int) (*ZKVProof, error) {
+	if ctx == nil {
+		return nil, errNoContext
+	}
 	if V == nil || R == nil || s == nil || l == nil || !V.ValidateBasic() || !R.ValidateBasic() {
 		return nil, errors.New("ZKVProof constructor received nil value(s)")
 	}
-	ecParams := tss.EC().Params()
+	ec := R.Curve()
+	ecParams := ec.Params()
 	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(tss.EC(), ecParams.Gx, ecParams.Gy)
+	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
 
 	a, b := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
 	aR := R.ScalarMult(a)
-	bG := crypto.ScalarBaseMult(tss.EC(), b)
+	bG := crypto.ScalarBaseMult(ec, b)
 	alpha, _ := aR.Add(bG) // already on the curve.
 
-	var c *big.Int
-	{
-		cHash := common.SHA512_256i(V.X(), V.Y(), R.X(), R.Y(), g.X(), g.Y(), alpha.X(), alpha.Y())
-		c = common.RejectionSample(q, cHash)
-	}
+	c := vChallenge(ctx, V, R, g, alpha)
 	modQ := common.ModInt(q)
 	t := modQ.Add(a, new(big.Int).Mul(c, s))
 	u := modQ.Add(b, new(big.Int).Mul(c, l))
@@ -106,22 +101,23 @@ func NewZKVProof(V, R *crypto.ECPoint, s, l *big.Int) (*ZKVProof, error) {
 	return &ZKVProof{Alpha: alpha, T: t, U: u}, nil
 }
 
-func (pf *ZKVProof) Verify(V, R *crypto.ECPoint) bool {
-	if pf == nil || !pf.ValidateBasic() {
+// VerifyInTranscript is Verify for a proof made with NewZKVProofInTranscript in the context `ctx`
+func (pf *ZKVProof) VerifyInTranscript(ctx *zkp.Transcript, V, R *crypto.ECPoint) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() {
 		return false
 	}
-	ecParams := tss.EC().Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(tss.EC(), ecParams.Gx, ecParams.Gy)
+	if V == nil || R == nil {
+		return false
+	}
+	ec := R.Curve()
+	ecParams := ec.Params()
+	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
 
-	var c *big.Int
-	{
-		cHash := common.SHA512_256i(V.X(), V.Y(), R.X(), R.Y(), g.X(), g.Y(), pf.Alpha.X(), pf.Alpha.Y())
-		c = common.RejectionSample(q, cHash)
+	c := vChallenge(ctx, V, R, g, pf.Alpha)
+	tRuG, err := crypto.MultiScalarMult(ec, []*crypto.ECPoint{R, g}, []*big.Int{pf.T, pf.U})
+	if err != nil {
+		return false
 	}
-	tR := R.ScalarMult(pf.T)
-	uG := crypto.ScalarBaseMult(tss.EC(), pf.U)
-	tRuG, _ := tR.Add(uG) // already on the curve.
 
 	Vc := V.ScalarMult(c)
 	aVc, err := pf.Alpha.Add(Vc)
@@ -137,3 +133,123 @@ func (pf *ZKVProof) Verify(V, R *crypto.ECPoint) bool {
 func (pf *ZKVProof) ValidateBasic() bool {
 	return pf.Alpha != nil && pf.T != nil && pf.U != nil && pf.Alpha.ValidateBasic()
 }
+
+func vChallenge(ctx *zkp.Transcript, V, R, g, alpha *crypto.ECPoint) *big.Int {
+	tr := zkp.ForProof(ctx, "schnorr/v")
+	tr.AppendPoints("statement", V, R, g)
+	tr.AppendPoints("commitment", alpha)
+	return tr.Challenge("challenge", R.Curve().Params().N)
+}
+
+// NewZKSTProofInTranscript constructs a proof of knowledge of s and l such that S = s*R and T = s*G + l*H, which shows
+// that S is consistent with the Pedersen commitment T (GG20 Section 3.3, phase 6), with the challenge drawn in the
+// context `ctx`
+func NewZKSTProofInTranscript(ctx *zkp.Transcript, S, T, R, H *crypto.ECPoint, s, l *big.Int) (*ZKSTProof, error) {
+	if ctx == nil {
+		return nil, errNoContext
+	}
+	if S == nil || T == nil || R == nil || H == nil || s == nil || l == nil ||
+		!S.ValidateBasic() || !T.ValidateBasic() || !R.ValidateBasic() || !H.ValidateBasic() {
+		return nil, errors.New("ZKSTProof constructor received nil value(s)")
+	}
+	q := R.Curve().Params().N
+	a, b := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+	a1 := R.ScalarMult(a)
+	a2, err := crypto.ScalarBaseMult(R.Curve(), a).Add(H.ScalarMult(b))
+	if err != nil {
+		return nil, err
+	}
+	c := stChallenge(ctx, S, T, R, H, a1, a2)
+	modQ := common.ModInt(q)
+	z1 := modQ.Add(a, new(big.Int).Mul(c, s))
+	z2 := modQ.Add(b, new(big.Int).Mul(c, l))
+	return &ZKSTProof{A1: a1, A2: a2, Z1: z1, Z2: z2}, nil
+}
+
+// VerifyInTranscript is Verify for a proof made with NewZKSTProofInTranscript in the context `ctx`
+func (pf *ZKSTProof) VerifyInTranscript(ctx *zkp.Transcript, S, T, R, H *crypto.ECPoint) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() || !S.ValidateBasic() || !T.ValidateBasic() || !R.ValidateBasic() ||
+		!H.ValidateBasic() {
+		return false
+	}
+	c := stChallenge(ctx, S, T, R, H, pf.A1, pf.A2)
+
+	// z1*R == A1 + c*S
+	z1R := R.ScalarMult(pf.Z1)
+	a1cS, err := pf.A1.Add(S.ScalarMult(c))
+	if err != nil || !z1R.Equals(a1cS) {
+		return false
+	}
+
+	// z1*G + z2*H == A2 + c*T
+	G := crypto.ScalarBaseMult(R.Curve(), big.NewInt(1))
+	z1Gz2H, err := crypto.MultiScalarMult(R.Curve(), []*crypto.ECPoint{G, H}, []*big.Int{pf.Z1, pf.Z2})
+	if err != nil {
+		return false
+	}
+	a2cT, err := pf.A2.Add(T.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return z1Gz2H.Equals(a2cT)
+}
+
+func (pf *ZKSTProof) ValidateBasic() bool {
+	return pf.Z1 != nil && pf.Z2 != nil && pf.A1.ValidateBasic() && pf.A2.ValidateBasic()
+}
+
+func stChallenge(ctx *zkp.Transcript, S, T, R, H, a1, a2 *crypto.ECPoint) *big.Int {
+	tr := zkp.ForProof(ctx, "schnorr/st")
+	tr.AppendPoints("statement", S, T, R, H)
+	tr.AppendPoints("commitment", a1, a2)
+	return tr.Challenge("challenge", R.Curve().Params().N)
+}
+
+// NewZKDLEQProofInTranscript constructs a proof of knowledge of x such that X = x*G and D = x*H, which shows that D is
+// made with the secret of the public X (Chaum-Pedersen), with the challenge drawn in the context `ctx`
+func NewZKDLEQProofInTranscript(ctx *zkp.Transcript, X, D, H *crypto.ECPoint, x *big.Int) (*ZKDLEQProof, error) {
+	if ctx == nil {
+		return nil, errNoContext
+	}
+	if X == nil || D == nil || H == nil || x == nil || !X.ValidateBasic() || !D.ValidateBasic() || !H.ValidateBasic() {
+		return nil, errors.New("ZKDLEQProof constructor received nil value(s)")
+	}
+	q := X.Curve().Params().N
+	a := common.GetRandomPositiveInt(q)
+	a1, a2 := crypto.ScalarBaseMult(X.Curve(), a), H.ScalarMult(a)
+	c := dleqChallenge(ctx, X, D, H, a1, a2)
+	z := common.ModInt(q).Add(a, new(big.Int).Mul(c, x))
+	return &ZKDLEQProof{A1: a1, A2: a2, Z: z}, nil
+}
+
+// VerifyInTranscript is Verify for a proof made with NewZKDLEQProofInTranscript in the context `ctx`
+func (pf *ZKDLEQProof) VerifyInTranscript(ctx *zkp.Transcript, X, D, H *crypto.ECPoint) bool {
+	if ctx == nil || pf == nil || !pf.ValidateBasic() || !X.ValidateBasic() || !D.ValidateBasic() || !H.ValidateBasic() {
+		return false
+	}
+	c := dleqChallenge(ctx, X, D, H, pf.A1, pf.A2)
+
+	// z*G == A1 + c*X
+	a1cX, err := pf.A1.Add(X.ScalarMult(c))
+	if err != nil || !crypto.ScalarBaseMult(X.Curve(), pf.Z).Equals(a1cX) {
+		return false
+	}
+
+	// z*H == A2 + c*D
+	a2cD, err := pf.A2.Add(D.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return H.ScalarMult(pf.Z).Equals(a2cD)
+}
+
+func (pf *ZKDLEQProof) ValidateBasic() bool {
+	return pf.Z != nil && pf.A1.ValidateBasic() && pf.A2.ValidateBasic()
+}
+
+func dleqChallenge(ctx *zkp.Transcript, X, D, H, a1, a2 *crypto.ECPoint) *big.Int {
+	tr := zkp.ForProof(ctx, "schnorr/dleq")
+	tr.AppendPoints("statement", X, D, H)
+	tr.AppendPoints("commitment", a1, a2)
+	return tr.Challenge("challenge", X.Curve().Params().N)
+}
@@ -7,6 +7,7 @@
 package schnorr_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,14 +15,20 @@ import (
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
 	. "github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
+// testContext returns the context of the proofs of the tests
+func testContext() *zkp.Transcript {
+	return zkp.NewSessionTranscript("test", []byte("session"), 1)
+}
+
 func TestSchnorrProof(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 	u := common.GetRandomPositiveInt(q)
-	uG := crypto.ScalarBaseMult(tss.EC(), u)
-	proof, _ := NewZKProof(u, uG)
+	uG := crypto.ScalarBaseMult(tss.S256(), u)
+	proof, _ := NewZKProofInTranscript(testContext(), u, uG)
 
 	assert.True(t, proof.Alpha.IsOnCurve())
 	assert.NotZero(t, proof.Alpha.X())
@@ -30,73 +37,155 @@ func TestSchnorrProof(t *testing.T) {
 }
 
 func TestSchnorrProofVerify(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 	u := common.GetRandomPositiveInt(q)
-	X := crypto.ScalarBaseMult(tss.EC(), u)
+	X := crypto.ScalarBaseMult(tss.S256(), u)
 
-	proof, _ := NewZKProof(u, X)
-	res := proof.Verify(X)
+	proof, _ := NewZKProofInTranscript(testContext(), u, X)
+	res := proof.VerifyInTranscript(testContext(), X)
 
 	assert.True(t, res, "verify result must be true")
 }
 
 func TestSchnorrProofVerifyBadX(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 	u := common.GetRandomPositiveInt(q)
 	u2 := common.GetRandomPositiveInt(q)
-	X := crypto.ScalarBaseMult(tss.EC(), u)
-	X2 := crypto.ScalarBaseMult(tss.EC(), u2)
+	X := crypto.ScalarBaseMult(tss.S256(), u)
+	X2 := crypto.ScalarBaseMult(tss.S256(), u2)
 
-	proof, _ := NewZKProof(u2, X2)
-	res := proof.Verify(X)
+	proof, _ := NewZKProofInTranscript(testContext(), u2, X2)
+	res := proof.VerifyInTranscript(testContext(), X)
 
 	assert.False(t, res, "verify result must be false")
 }
 
 func TestSchnorrVProofVerify(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 	k := common.GetRandomPositiveInt(q)
 	s := common.GetRandomPositiveInt(q)
 	l := common.GetRandomPositiveInt(q)
-	R := crypto.ScalarBaseMult(tss.EC(), k) // k_-1 * G
+	R := crypto.ScalarBaseMult(tss.S256(), k) // k_-1 * G
 	Rs := R.ScalarMult(s)
-	lG := crypto.ScalarBaseMult(tss.EC(), l)
+	lG := crypto.ScalarBaseMult(tss.S256(), l)
 	V, _ := Rs.Add(lG)
 
-	proof, _ := NewZKVProof(V, R, s, l)
-	res := proof.Verify(V, R)
+	proof, _ := NewZKVProofInTranscript(testContext(), V, R, s, l)
+	res := proof.VerifyInTranscript(testContext(), V, R)
 
 	assert.True(t, res, "verify result must be true")
 }
 
 func TestSchnorrVProofVerifyBadPartialV(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 	k := common.GetRandomPositiveInt(q)
 	s := common.GetRandomPositiveInt(q)
 	l := common.GetRandomPositiveInt(q)
-	R := crypto.ScalarBaseMult(tss.EC(), k) // k_-1 * G
+	R := crypto.ScalarBaseMult(tss.S256(), k) // k_-1 * G
 	Rs := R.ScalarMult(s)
 	V := Rs
 
-	proof, _ := NewZKVProof(V, R, s, l)
-	res := proof.Verify(V, R)
+	proof, _ := NewZKVProofInTranscript(testContext(), V, R, s, l)
+	res := proof.VerifyInTranscript(testContext(), V, R)
 
 	assert.False(t, res, "verify result must be true")
 }
 
 func TestSchnorrVProofVerifyBadS(t *testing.T) {
-	q := tss.EC().Params().N
+	q := tss.S256().Params().N
 	k := common.GetRandomPositiveInt(q)
 	s := common.GetRandomPositiveInt(q)
 	s2 := common.GetRandomPositiveInt(q)
 	l := common.GetRandomPositiveInt(q)
-	R := crypto.ScalarBaseMult(tss.EC(), k) // k_-1 * G
+	R := crypto.ScalarBaseMult(tss.S256(), k) // k_-1 * G
 	Rs := R.ScalarMult(s)
-	lG := crypto.ScalarBaseMult(tss.EC(), l)
+	lG := crypto.ScalarBaseMult(tss.S256(), l)
 	V, _ := Rs.Add(lG)
 
-	proof, _ := NewZKVProof(V, R, s2, l)
-	res := proof.Verify(V, R)
+	proof, _ := NewZKVProofInTranscript(testContext(), V, R, s2, l)
+	res := proof.VerifyInTranscript(testContext(), V, R)
 
 	assert.False(t, res, "verify result must be true")
 }
+
+func TestSchnorrSTProofVerify(t *testing.T) {
+	q := tss.S256().Params().N
+	R := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+	H := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+	s := common.GetRandomPositiveInt(q)
+	l := common.GetRandomPositiveInt(q)
+	S := R.ScalarMult(s)
+	T, _ := crypto.ScalarBaseMult(tss.S256(), s).Add(H.ScalarMult(l))
+
+	proof, err := NewZKSTProofInTranscript(testContext(), S, T, R, H, s, l)
+	assert.NoError(t, err)
+	assert.True(t, proof.VerifyInTranscript(testContext(), S, T, R, H), "verify result must be true")
+
+	// S for another s
+	S2 := R.ScalarMult(new(big.Int).Add(s, big.NewInt(1)))
+	assert.False(t, proof.VerifyInTranscript(testContext(), S2, T, R, H), "verify result must be false")
+	proof, err = NewZKSTProofInTranscript(testContext(), S2, T, R, H, s, l)
+	assert.NoError(t, err)
+	assert.False(t, proof.VerifyInTranscript(testContext(), S2, T, R, H), "verify result must be false")
+}
+
+func TestSchnorrDLEQProofVerify(t *testing.T) {
+	q := tss.S256().Params().N
+	H := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+	x := common.GetRandomPositiveInt(q)
+	X := crypto.ScalarBaseMult(tss.S256(), x)
+	D := H.ScalarMult(x)
+
+	proof, err := NewZKDLEQProofInTranscript(testContext(), X, D, H, x)
+	assert.NoError(t, err)
+	assert.True(t, proof.VerifyInTranscript(testContext(), X, D, H), "verify result must be true")
+
+	// D for another x
+	D2 := H.ScalarMult(new(big.Int).Add(x, big.NewInt(1)))
+	assert.False(t, proof.VerifyInTranscript(testContext(), X, D2, H), "verify result must be false")
+	proof, err = NewZKDLEQProofInTranscript(testContext(), X, D2, H, x)
+	assert.NoError(t, err)
+	assert.False(t, proof.VerifyInTranscript(testContext(), X, D2, H), "verify result must be false")
+}
+
+func TestProofsInTranscript(t *testing.T) {
+	q := tss.S256().Params().N
+	ctx := zkp.NewSessionTranscript("signing", []byte("session 1"), 4)
+	other := zkp.NewSessionTranscript("signing", []byte("session 2"), 4)
+	otherRound := zkp.NewSessionTranscript("signing", []byte("session 1"), 5)
+	x, l := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+	X := crypto.ScalarBaseMult(tss.S256(), x)
+	H := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(q))
+	D := H.ScalarMult(x)
+	T, _ := X.Add(H.ScalarMult(l))
+
+	zkPf, err := NewZKProofInTranscript(ctx, x, X)
+	assert.NoError(t, err)
+	assert.True(t, zkPf.VerifyInTranscript(ctx, X))
+	assert.False(t, zkPf.VerifyInTranscript(other, X))
+	assert.False(t, zkPf.VerifyInTranscript(otherRound, X))
+	assert.False(t, zkPf.VerifyInTranscript(nil, X))
+	_, err = NewZKProofInTranscript(nil, x, X)
+	assert.Error(t, err, "a proof without a context must be refused")
+
+	vPf, err := NewZKVProofInTranscript(ctx, T, H, l, x)
+	assert.NoError(t, err)
+	assert.True(t, vPf.VerifyInTranscript(ctx, T, H))
+	assert.False(t, vPf.VerifyInTranscript(other, T, H))
+	assert.False(t, vPf.VerifyInTranscript(otherRound, T, H))
+	assert.False(t, vPf.VerifyInTranscript(nil, T, H))
+
+	stPf, err := NewZKSTProofInTranscript(ctx, D, T, H, H, x, l)
+	assert.NoError(t, err)
+	assert.True(t, stPf.VerifyInTranscript(ctx, D, T, H, H))
+	assert.False(t, stPf.VerifyInTranscript(other, D, T, H, H))
+	assert.False(t, stPf.VerifyInTranscript(otherRound, D, T, H, H))
+	assert.False(t, stPf.VerifyInTranscript(nil, D, T, H, H))
+
+	dleqPf, err := NewZKDLEQProofInTranscript(ctx, X, D, H, x)
+	assert.NoError(t, err)
+	assert.True(t, dleqPf.VerifyInTranscript(ctx, X, D, H))
+	assert.False(t, dleqPf.VerifyInTranscript(other, X, D, H))
+	assert.False(t, dleqPf.VerifyInTranscript(otherRound, X, D, H))
+	assert.False(t, dleqPf.VerifyInTranscript(nil, X, D, H))
+}
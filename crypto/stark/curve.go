@@ -0,0 +1,137 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package stark provides the STARK-friendly curve y^2 = x^3 + x + b used by StarkNet and StarkEx as an elliptic.Curve,
+// so that it can be set with Parameters.SetCurve, and the StarkNet rules for ECDSA messages and signatures.
+// The coefficient a = 1 is not supported by the generic elliptic.CurveParams, which assumes a = -3.
+package stark
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+)
+
+const (
+	// Name is the name of the curve in its params and in the tss curve registry
+	Name = "stark"
+)
+
+type curve struct {
+	*elliptic.CurveParams
+	a *big.Int
+}
+
+var (
+	initOnce sync.Once
+	stark    *curve
+
+	_ elliptic.Curve = (*curve)(nil)
+)
+
+func initStark() {
+	p, _ := new(big.Int).SetString("800000000000011000000000000000000000000000000000000000000000001", 16)
+	n, _ := new(big.Int).SetString("800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f", 16)
+	b, _ := new(big.Int).SetString("6f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89", 16)
+	gx, _ := new(big.Int).SetString("1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca", 16)
+	gy, _ := new(big.Int).SetString("5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f", 16)
+	stark = &curve{
+		CurveParams: &elliptic.CurveParams{P: p, N: n, B: b, Gx: gx, Gy: gy, BitSize: 252, Name: Name},
+		a:           big.NewInt(1),
+	}
+}
+
+// Curve returns the STARK curve. Like the generic elliptic.CurveParams its arithmetic is not constant time.
+func Curve() elliptic.Curve {
+	initOnce.Do(initStark)
+	return stark
+}
+
+func (c *curve) Params() *elliptic.CurveParams {
+	return c.CurveParams
+}
+
+func (c *curve) IsOnCurve(x, y *big.Int) bool {
+	P := c.P
+	if x.Sign() < 0 || x.Cmp(P) >= 0 || y.Sign() < 0 || y.Cmp(P) >= 0 {
+		return false
+	}
+	// y^2 = x^3 + a*x + b
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, P)
+	rhs := new(big.Int).Mul(x, x)
+	rhs.Add(rhs, c.a).Mul(rhs, x).Add(rhs, c.B).Mod(rhs, P)
+	return y2.Cmp(rhs) == 0
+}
+
+// The point at infinity is (0, 0) as in the standard library, which is not on the curve as b != 0.
+
+func (c *curve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	if isInfinity(x1, y1) {
+		return new(big.Int).Set(x2), new(big.Int).Set(y2)
+	}
+	if isInfinity(x2, y2) {
+		return new(big.Int).Set(x1), new(big.Int).Set(y1)
+	}
+	P := c.P
+	if x1.Cmp(x2) == 0 {
+		if sum := new(big.Int).Add(y1, y2); sum.Mod(sum, P).Sign() == 0 {
+			return new(big.Int), new(big.Int)
+		}
+		return c.Double(x1, y1)
+	}
+	// l = (y2 - y1) / (x2 - x1)
+	l := new(big.Int).Sub(x2, x1)
+	l.Mod(l, P).ModInverse(l, P)
+	l.Mul(l, new(big.Int).Sub(y2, y1)).Mod(l, P)
+	return c.fromSlope(l, x1, y1, x2)
+}
+
+func (c *curve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	if isInfinity(x1, y1) || y1.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+	P := c.P
+	// l = (3*x1^2 + a) / (2*y1)
+	l := new(big.Int).Lsh(y1, 1)
+	l.Mod(l, P).ModInverse(l, P)
+	num := new(big.Int).Mul(x1, x1)
+	num.Mul(num, big.NewInt(3)).Add(num, c.a)
+	l.Mul(l, num).Mod(l, P)
+	return c.fromSlope(l, x1, y1, x1)
+}
+
+// fromSlope returns (x3, y3) = (l^2 - x1 - x2, l*(x1 - x3) - y1)
+func (c *curve) fromSlope(l, x1, y1, x2 *big.Int) (*big.Int, *big.Int) {
+	P := c.P
+	x3 := new(big.Int).Mul(l, l)
+	x3.Sub(x3, x1).Sub(x3, x2).Mod(x3, P)
+	y3 := new(big.Int).Sub(x1, x3)
+	y3.Mul(y3, l).Sub(y3, y1).Mod(y3, P)
+	return x3, y3
+}
+
+func (c *curve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	x, y := new(big.Int), new(big.Int)
+	for _, byte := range k {
+		for bit := 0; bit < 8; bit++ {
+			x, y = c.Double(x, y)
+			if byte&0x80 == 0x80 {
+				x, y = c.Add(x1, y1, x, y)
+			}
+			byte <<= 1
+		}
+	}
+	return x, y
+}
+
+func (c *curve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.ScalarMult(c.Gx, c.Gy, k)
+}
+
+func isInfinity(x, y *big.Int) bool {
+	return x.Sign() == 0 && y.Sign() == 0
+}
@@ -0,0 +1,87 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package stark_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	. "github.com/binance-chain/tss-lib/crypto/stark"
+)
+
+func TestCurveParams(t *testing.T) {
+	c := Curve()
+	params := c.Params()
+	assert.True(t, c.IsOnCurve(params.Gx, params.Gy), "G must be on the curve")
+	x, y := c.ScalarBaseMult(params.N.Bytes())
+	assert.Equal(t, 0, x.Sign(), "n*G must be the point at infinity")
+	assert.Equal(t, 0, y.Sign(), "n*G must be the point at infinity")
+	assert.True(t, params.N.ProbablyPrime(20))
+
+	// the StarkEx test key pair
+	priv, _ := new(big.Int).SetString("3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc", 16)
+	pubX, _ := new(big.Int).SetString("77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43", 16)
+	x, y = c.ScalarBaseMult(priv.Bytes())
+	assert.Equal(t, 0, pubX.Cmp(x))
+	assert.True(t, c.IsOnCurve(x, y))
+}
+
+func TestCurveArithmetic(t *testing.T) {
+	c := Curve()
+	params := c.Params()
+	x2, y2 := c.Double(params.Gx, params.Gy)
+	x3, y3 := c.Add(x2, y2, params.Gx, params.Gy)
+	ex, ey := c.ScalarBaseMult([]byte{3})
+	assert.Equal(t, 0, x3.Cmp(ex))
+	assert.Equal(t, 0, y3.Cmp(ey))
+
+	// G + (-G) is the point at infinity, which is the identity
+	nx, ny := c.Add(params.Gx, params.Gy, params.Gx, new(big.Int).Sub(params.P, params.Gy))
+	assert.Equal(t, 0, nx.Sign())
+	assert.Equal(t, 0, ny.Sign())
+	x, y := c.Add(nx, ny, x3, y3)
+	assert.Equal(t, 0, x.Cmp(x3))
+	assert.Equal(t, 0, y.Cmp(y3))
+
+	// the compressed SEC 1 encoding works with a = 1
+	P, err := crypto.NewECPoint(c, x3, y3)
+	assert.NoError(t, err)
+	P2, err := crypto.NewECPointFromBytes(c, P.CompressedBytes())
+	assert.NoError(t, err)
+	assert.True(t, P.Equals(P2))
+}
+
+func TestVerify(t *testing.T) {
+	c := Curve()
+	N := c.Params().N
+	priv, err := ecdsa.GenerateKey(c, rand.Reader)
+	assert.NoError(t, err)
+	m := HashToMessage([]byte("a 32 byte digest of a transaction"))
+	assert.True(t, ValidMessage(m))
+	assert.True(t, m.BitLen() <= 250)
+
+	// sign the StarkNet way, retrying until r and 1/s are in range
+	var r, s *big.Int
+	for {
+		k, _ := rand.Int(rand.Reader, N)
+		rx, _ := c.ScalarBaseMult(k.Bytes())
+		r = rx
+		s = new(big.Int).Mul(r, priv.D)
+		s.Add(s, m).Mul(s, new(big.Int).ModInverse(k, N)).Mod(s, N)
+		if r.BitLen() <= MessageBits && new(big.Int).ModInverse(s, N).BitLen() <= MessageBits {
+			break
+		}
+	}
+	assert.True(t, Verify(&priv.PublicKey, m, r, s))
+	assert.False(t, Verify(&priv.PublicKey, new(big.Int).Add(m, big.NewInt(1)), r, s))
+	assert.False(t, Verify(&priv.PublicKey, new(big.Int).Lsh(big.NewInt(1), MessageBits), r, s))
+}
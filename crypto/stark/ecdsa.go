@@ -0,0 +1,57 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package stark
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+)
+
+const (
+	// MessageBits bounds the messages and the values r and 1/s of StarkNet ECDSA signatures to [0, 2^251)
+	MessageBits = 251
+)
+
+var (
+	messageBound = new(big.Int).Lsh(big.NewInt(1), MessageBits)
+	// sn_keccak keeps the 250 least significant bits of the Keccak-256 digest
+	keccakMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 250), big.NewInt(1))
+)
+
+// HashToMessage reduces a digest to a StarkNet message by keeping its 250 least significant bits, as sn_keccak does
+// with a Keccak-256 digest. Pedersen and Poseidon hashes are field elements and are signed as they are.
+func HashToMessage(digest []byte) *big.Int {
+	return new(big.Int).And(new(big.Int).SetBytes(digest), keccakMask)
+}
+
+// ValidMessage reports whether `m` may be signed under the StarkNet rules, i.e. 0 <= m < 2^251
+func ValidMessage(m *big.Int) bool {
+	return m != nil && m.Sign() >= 0 && m.Cmp(messageBound) < 0
+}
+
+// Verify checks a signature under the StarkNet rules, which are stricter than ECDSA: r must be the x coordinate of
+// R itself rather than reduced mod n, and r, 1/s and the message must be below 2^251.
+func Verify(pub *ecdsa.PublicKey, m, r, s *big.Int) bool {
+	c := Curve()
+	N := c.Params().N
+	if pub == nil || pub.X == nil || pub.Y == nil || !c.IsOnCurve(pub.X, pub.Y) || r == nil || s == nil {
+		return false
+	}
+	if !ValidMessage(m) || r.Sign() <= 0 || r.Cmp(messageBound) >= 0 || s.Sign() <= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+	w := new(big.Int).ModInverse(s, N)
+	if w == nil || w.Cmp(messageBound) >= 0 {
+		return false
+	}
+	// R = w*(m*G + r*Q)
+	x1, y1 := c.ScalarBaseMult(m.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, r.Bytes())
+	x, y := c.Add(x1, y1, x2, y2)
+	x, _ = c.ScalarMult(x, y, w.Bytes())
+	return x.Cmp(r) == 0
+}
@@ -0,0 +1,107 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package vrf provides an ECVRF-style verifiable random function on the curve of the key: the proof of the input `alpha` under the
+// key x is (Gamma, c, s) with Gamma = x*H(Y, alpha) and a Chaum-Pedersen proof that log_G(Y) = log_H(Gamma), and the
+// output is a hash of Gamma. It follows the structure of RFC 9381 but not its cipher suites, so proofs are checked
+// with Verify rather than with RFC 9381 implementations. The vrf/evaluation party evaluates it under a threshold key.
+package vrf
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+var (
+	hashToCurveDomain = []byte("tss-lib vrf hash to curve")
+	proofToHashDomain = []byte("tss-lib vrf proof to hash")
+)
+
+type (
+	Proof struct {
+		Gamma *crypto.ECPoint
+		C, S  *big.Int
+	}
+)
+
+// HashToCurve hashes the public key and the input to a point H whose discrete log nobody knows, by try-and-increment
+func HashToCurve(pub *crypto.ECPoint, alpha []byte) *crypto.ECPoint {
+	ec := pub.Curve()
+	P := ec.Params().P
+	x := new(big.Int).Mod(new(big.Int).SetBytes(common.SHA512_256Canonical(string(hashToCurveDomain), pub.Bytes(), alpha)), P)
+	for {
+		if H, err := crypto.LiftX(ec, x, false); err == nil {
+			return H
+		}
+		x = new(big.Int).Mod(x.Add(x, big.NewInt(1)), P)
+	}
+}
+
+// Challenge returns c = H(Y, H, Gamma, U, V) for the nonce commitments U = k*G and V = k*H, drawn from a zkp.Transcript
+func Challenge(pub, H, Gamma, U, V *crypto.ECPoint) *big.Int {
+	tr := zkp.ForProof(nil, "vrf")
+	tr.AppendPoints("statement", pub, H, Gamma)
+	tr.AppendPoints("commitment", U, V)
+	return tr.Challenge("challenge", pub.Curve().Params().N)
+}
+
+// Prove evaluates the VRF at `alpha` with the private key `x` on the curve `ec`, e.g. to check a threshold evaluation in
+// tests
+func Prove(ec elliptic.Curve, x *big.Int, alpha []byte) (*Proof, error) {
+	if x == nil || x.Sign() <= 0 {
+		return nil, errors.New("Prove() received an invalid private key")
+	}
+	N := ec.Params().N
+	pub := crypto.ScalarBaseMult(ec, x)
+	H := HashToCurve(pub, alpha)
+	k := common.GetRandomPositiveInt(N)
+	Gamma := H.ScalarMult(x)
+	c := Challenge(pub, H, Gamma, crypto.ScalarBaseMult(ec, k), H.ScalarMult(k))
+	s := common.ModInt(N).Add(k, new(big.Int).Mul(c, x))
+	return &Proof{Gamma: Gamma, C: c, S: s}, nil
+}
+
+// Verify checks the proof of the VRF at `alpha` under the public key `pub` and returns the output beta
+func Verify(pub *crypto.ECPoint, alpha []byte, proof *Proof) ([]byte, bool) {
+	if !pub.ValidateBasic() || !proof.ValidateBasic() {
+		return nil, false
+	}
+	N := pub.Curve().Params().N
+	H := HashToCurve(pub, alpha)
+	minusC := new(big.Int).Sub(N, proof.C)
+
+	// U = s*G - c*Y, V = s*H - c*Gamma
+	U, err := crypto.ScalarBaseMult(pub.Curve(), proof.S).Add(pub.ScalarMult(minusC))
+	if err != nil {
+		return nil, false
+	}
+	V, err := H.ScalarMult(proof.S).Add(proof.Gamma.ScalarMult(minusC))
+	if err != nil {
+		return nil, false
+	}
+	if Challenge(pub, H, proof.Gamma, U, V).Cmp(proof.C) != 0 {
+		return nil, false
+	}
+	return ProofToHash(proof), true
+}
+
+// ProofToHash returns the output beta of the VRF, which is only meaningful once the proof has been verified
+func ProofToHash(proof *Proof) []byte {
+	return common.SHA512_256Canonical(string(proofToHashDomain), proof.Gamma.CompressedBytes())
+}
+
+func (pf *Proof) ValidateBasic() bool {
+	if pf == nil || pf.C == nil || pf.S == nil || !pf.Gamma.ValidateBasic() {
+		return false
+	}
+	N := pf.Gamma.Curve().Params().N
+	return pf.C.Sign() > 0 && pf.C.Cmp(N) < 0 && pf.S.Sign() >= 0 && pf.S.Cmp(N) < 0
+}
@@ -0,0 +1,47 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package vrf_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	. "github.com/binance-chain/tss-lib/crypto/vrf"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestProveVerify(t *testing.T) {
+	x := common.GetRandomPositiveInt(tss.S256().Params().N)
+	pub := crypto.ScalarBaseMult(tss.S256(), x)
+	alpha := []byte("epoch 42")
+
+	proof, err := Prove(tss.S256(), x, alpha)
+	assert.NoError(t, err)
+	beta, ok := Verify(pub, alpha, proof)
+	assert.True(t, ok, "the proof must verify")
+	assert.Len(t, beta, 32)
+
+	// the output is unique: another proof of the same input gives the same beta
+	proof2, err := Prove(tss.S256(), x, alpha)
+	assert.NoError(t, err)
+	beta2, ok := Verify(pub, alpha, proof2)
+	assert.True(t, ok)
+	assert.Equal(t, beta, beta2)
+
+	_, ok = Verify(pub, []byte("epoch 43"), proof)
+	assert.False(t, ok, "the proof must not verify for another input")
+	_, ok = Verify(crypto.ScalarBaseMult(tss.S256(), big.NewInt(2)), alpha, proof)
+	assert.False(t, ok, "the proof must not verify under another key")
+	forged := *proof
+	forged.Gamma = proof.Gamma.ScalarMult(big.NewInt(2))
+	_, ok = Verify(pub, alpha, &forged)
+	assert.False(t, ok, "a proof with another Gamma must not verify")
+}
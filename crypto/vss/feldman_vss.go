@@ -11,13 +11,13 @@
 package vss
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
-	"github.com/binance-chain/tss-lib/tss"
 )
 
 type (
@@ -40,9 +40,10 @@ var (
 )
 
 // Returns a new array of secret shares created by Shamir's Secret Sharing Algorithm,
-// requiring a minimum number of shares to recreate, of length shares, from the input secret
+// requiring a minimum number of shares to recreate, of length shares, from the input secret, with the commitments on
+// the curve `ec`
 //
-func Create(threshold int, secret *big.Int, indexes []*big.Int) (Vs, Shares, error) {
+func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.Int) (Vs, Shares, error) {
 	if secret == nil || indexes == nil {
 		return nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
 	}
@@ -54,11 +55,11 @@ func Create(threshold int, secret *big.Int, indexes []*big.Int) (Vs, Shares, err
 		return nil, nil, ErrNumSharesBelowThreshold
 	}
 
-	poly := samplePolynomial(threshold, secret)
+	poly := samplePolynomial(ec, threshold, secret)
 	poly[0] = secret // becomes sigma*G in v
 	v := make(Vs, len(poly))
 	for i, ai := range poly {
-		v[i] = crypto.ScalarBaseMult(tss.EC(), ai)
+		v[i] = crypto.ScalarBaseMult(ec, ai)
 	}
 
 	shares := make(Shares, num)
@@ -66,38 +67,121 @@ func Create(threshold int, secret *big.Int, indexes []*big.Int) (Vs, Shares, err
 		if indexes[i].Cmp(big.NewInt(0)) == 0 {
 			return nil, nil, fmt.Errorf("party index should not be 0")
 		}
-		share := evaluatePolynomial(threshold, poly, indexes[i])
+		share := evaluatePolynomial(ec, threshold, poly, indexes[i])
 		shares[i] = &Share{Threshold: threshold, ID: indexes[i], Share: share}
 	}
 	return v, shares, nil
 }
 
 func (share *Share) Verify(threshold int, vs Vs) bool {
-	if share.Threshold != threshold || vs == nil {
+	if share.Threshold != threshold || len(vs) != threshold+1 {
 		return false
 	}
-	var err error
-	modQ := common.ModInt(tss.EC().Params().N)
-	v, t := vs[0], one // YRO : we need to have our accumulator outside of the loop
-	for j := 1; j <= threshold; j++ {
-		// t = k_i^j
-		t = modQ.Mul(t, share.ID)
-		// v = v * v_j^t
-		vjt := vs[j].SetCurve(tss.EC()).ScalarMult(t)
-		v, err = v.SetCurve(tss.EC()).Add(vjt)
-		if err != nil {
-			return false
+	v, err := vs.Evaluate(share.ID)
+	if err != nil {
+		return false
+	}
+	sigmaGi := crypto.ScalarBaseMult(v.Curve(), share.Share)
+	return sigmaGi.Equals(v)
+}
+
+// Evaluate returns sum_j(v_j * id^j) for the commitments v0..vt, i.e. the share of `id` times G, with one
+// multi-scalar multiplication
+func (vs Vs) Evaluate(id *big.Int) (*crypto.ECPoint, error) {
+	if len(vs) == 0 || vs[0] == nil || id == nil {
+		return nil, errors.New("vss Evaluate() received no commitments or a nil id")
+	}
+	ec := vs[0].Curve()
+	return crypto.MultiScalarMult(ec, vs, powersOf(ec, id, 0, len(vs)))
+}
+
+// CreateZeroSharing returns a new array of shares of the secret 0, which may be added to the shares of an existing
+// sharing to re-randomise them without changing the secret (proactive refresh).
+// The commitment to the constant term would be the point at infinity, so it is omitted and the returned Vs holds v1..vt.
+func CreateZeroSharing(ec elliptic.Curve, threshold int, indexes []*big.Int) (Vs, Shares, error) {
+	if indexes == nil {
+		return nil, nil, errors.New("vss indexes == nil")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(ec, threshold, zero)
+	v := make(Vs, threshold)
+	for i := 1; i <= threshold; i++ {
+		v[i-1] = crypto.ScalarBaseMult(ec, poly[i])
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		if indexes[i].Cmp(big.NewInt(0)) == 0 {
+			return nil, nil, fmt.Errorf("party index should not be 0")
 		}
+		share := evaluatePolynomial(ec, threshold, poly, indexes[i])
+		shares[i] = &Share{Threshold: threshold, ID: indexes[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// VerifyZeroSharing verifies a share created by CreateZeroSharing against the commitments v1..vt
+func (share *Share) VerifyZeroSharing(threshold int, vs Vs) bool {
+	if share.Threshold != threshold || len(vs) != threshold {
+		return false
 	}
-	sigmaGi := crypto.ScalarBaseMult(tss.EC(), share.Share)
+	v, err := vs.EvaluateZeroSharing(share.ID)
+	if err != nil {
+		return false
+	}
+	sigmaGi := crypto.ScalarBaseMult(v.Curve(), share.Share)
 	return sigmaGi.Equals(v)
 }
 
-func (shares Shares) ReConstruct() (secret *big.Int, err error) {
+// EvaluateZeroSharing returns sum_j(v_j * id^j) for the commitments v1..vt of a zero sharing, i.e. the share of zero
+// of `id` times G, which is added to the public share X of that party when its secret share is refreshed
+func (vs Vs) EvaluateZeroSharing(id *big.Int) (*crypto.ECPoint, error) {
+	if len(vs) == 0 || vs[0] == nil || id == nil {
+		return nil, errors.New("vss EvaluateZeroSharing() received no commitments or a nil id")
+	}
+	ec := vs[0].Curve()
+	return crypto.MultiScalarMult(ec, vs, powersOf(ec, id, 1, len(vs)))
+}
+
+// SumZeroSharings adds up the commitments v1..vt of several zero sharings of the same threshold, committing to the
+// sum of their polynomials, which is again a sharing of zero
+func SumZeroSharings(vjs []Vs) (Vs, error) {
+	if len(vjs) == 0 {
+		return nil, errors.New("vss SumZeroSharings() received no commitments")
+	}
+	threshold := len(vjs[0])
+	var err error
+	sum := make(Vs, threshold)
+	for c := range sum {
+		for j, vj := range vjs {
+			if len(vj) != threshold {
+				return nil, fmt.Errorf("vss SumZeroSharings(): commitments %d have length %d, expected %d", j, len(vj), threshold)
+			}
+			if sum[c] == nil {
+				sum[c] = vj[c]
+				continue
+			}
+			if sum[c], err = sum[c].Add(vj[c]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sum, nil
+}
+
+// ReConstruct returns the secret of the shares, which are of a sharing on the curve `ec`
+func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error) {
 	if shares != nil && shares[0].Threshold > len(shares) {
 		return nil, ErrNumSharesBelowThreshold
 	}
-	modN := common.ModInt(tss.EC().Params().N)
+	modN := common.ModInt(ec.Params().N)
 
 	// x coords
 	xs := make([]*big.Int, 0)
@@ -125,8 +209,8 @@ func (shares Shares) ReConstruct() (secret *big.Int, err error) {
 	return secret, nil
 }
 
-func samplePolynomial(threshold int, secret *big.Int) []*big.Int {
-	q := tss.EC().Params().N
+func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int) []*big.Int {
+	q := ec.Params().N
 	v := make([]*big.Int, threshold+1)
 	v[0] = secret
 	for i := 1; i <= threshold; i++ {
@@ -136,12 +220,24 @@ func samplePolynomial(threshold int, secret *big.Int) []*big.Int {
 	return v
 }
 
+// powersOf returns id^from..id^(from+count-1) mod q
+func powersOf(ec elliptic.Curve, id *big.Int, from, count int) []*big.Int {
+	modQ := common.ModInt(ec.Params().N)
+	t := modQ.Exp(id, big.NewInt(int64(from)))
+	powers := make([]*big.Int, count)
+	for j := range powers {
+		powers[j] = t
+		t = modQ.Mul(t, id)
+	}
+	return powers
+}
+
 // Evauluates a polynomial with coefficients such that:
 // evaluatePolynomial([a, b, c, d], x):
 // 		returns a + bx + cx^2 + dx^3
 //
-func evaluatePolynomial(threshold int, v []*big.Int, id *big.Int) (result *big.Int) {
-	q := tss.EC().Params().N
+func evaluatePolynomial(ec elliptic.Curve, threshold int, v []*big.Int, id *big.Int) (result *big.Int) {
+	q := ec.Params().N
 	modQ := common.ModInt(q)
 	result = new(big.Int).Set(v[0])
 	X := big.NewInt(int64(1))
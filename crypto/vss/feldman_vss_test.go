@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
 	. "github.com/binance-chain/tss-lib/crypto/vss"
 	"github.com/binance-chain/tss-lib/tss"
 )
@@ -20,14 +21,14 @@ import (
 func TestCreate(t *testing.T) {
 	num, threshold := 5, 3
 
-	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
 
 	ids := make([]*big.Int, 0)
 	for i := 0; i < num; i++ {
-		ids = append(ids, common.GetRandomPositiveInt(tss.EC().Params().N))
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
 	}
 
-	vs, _, err := Create(threshold, secret, ids)
+	vs, _, err := Create(tss.S256(), threshold, secret, ids)
 	assert.Nil(t, err)
 
 	assert.Equal(t, threshold+1, len(vs))
@@ -48,14 +49,14 @@ func TestCreate(t *testing.T) {
 func TestVerify(t *testing.T) {
 	num, threshold := 5, 3
 
-	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
 
 	ids := make([]*big.Int, 0)
 	for i := 0; i < num; i++ {
-		ids = append(ids, common.GetRandomPositiveInt(tss.EC().Params().N))
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
 	}
 
-	vs, shares, err := Create(threshold, secret, ids)
+	vs, shares, err := Create(tss.S256(), threshold, secret, ids)
 	assert.NoError(t, err)
 
 	for i := 0; i < num; i++ {
@@ -66,25 +67,101 @@ func TestVerify(t *testing.T) {
 func TestReconstruct(t *testing.T) {
 	num, threshold := 5, 3
 
-	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
 
 	ids := make([]*big.Int, 0)
 	for i := 0; i < num; i++ {
-		ids = append(ids, common.GetRandomPositiveInt(tss.EC().Params().N))
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
 	}
 
-	_, shares, err := Create(threshold, secret, ids)
+	_, shares, err := Create(tss.S256(), threshold, secret, ids)
 	assert.NoError(t, err)
 
-	secret2, err2 := shares[:threshold-1].ReConstruct()
+	secret2, err2 := shares[:threshold-1].ReConstruct(tss.S256())
 	assert.Error(t, err2) // not enough shares to satisfy the threshold
 	assert.Nil(t, secret2)
 
-	secret3, err3 := shares[:threshold].ReConstruct()
+	secret3, err3 := shares[:threshold].ReConstruct(tss.S256())
 	assert.NoError(t, err3)
 	assert.NotZero(t, secret3)
 
-	secret4, err4 := shares[:num].ReConstruct()
+	secret4, err4 := shares[:num].ReConstruct(tss.S256())
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestZeroSharing(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
+	}
+
+	_, shares, err := Create(tss.S256(), threshold, secret, ids)
+	assert.NoError(t, err)
+
+	zeroVs, zeroShares, err := CreateZeroSharing(tss.S256(), threshold, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(zeroVs))
+
+	refreshed := make(Shares, num)
+	for i := 0; i < num; i++ {
+		assert.True(t, zeroShares[i].VerifyZeroSharing(threshold, zeroVs))
+		assert.False(t, shares[i].VerifyZeroSharing(threshold, zeroVs))
+		refreshed[i] = &Share{
+			Threshold: threshold,
+			ID:        ids[i],
+			Share:     new(big.Int).Mod(new(big.Int).Add(shares[i].Share, zeroShares[i].Share), tss.S256().Params().N),
+		}
+		assert.NotEqual(t, 0, refreshed[i].Share.Cmp(shares[i].Share))
+	}
+
+	// the refreshed shares reconstruct the same secret
+	secret2, err := refreshed[:threshold+1].ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, secret2.Cmp(secret))
+}
+
+func TestSumZeroSharings(t *testing.T) {
+	num, threshold := 5, 3
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
+	}
+
+	// each party deals a zero sharing; party i sums the shares it receives
+	vjs := make([]Vs, num)
+	sums := make(Shares, num)
+	for j := 0; j < num; j++ {
+		vj, sharesj, err := CreateZeroSharing(tss.S256(), threshold, ids)
+		assert.NoError(t, err)
+		vjs[j] = vj
+		for i, share := range sharesj {
+			if sums[i] == nil {
+				sums[i] = &Share{Threshold: threshold, ID: ids[i], Share: big.NewInt(0)}
+			}
+			sums[i].Share = new(big.Int).Mod(new(big.Int).Add(sums[i].Share, share.Share), tss.S256().Params().N)
+		}
+	}
+	Vc, err := SumZeroSharings(vjs)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(Vc))
+	for i := 0; i < num; i++ {
+		assert.True(t, sums[i].VerifyZeroSharing(threshold, Vc))
+		zi, err := Vc.EvaluateZeroSharing(ids[i])
+		assert.NoError(t, err)
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), sums[i].Share).Equals(zi))
+	}
+
+	// the sum is a sharing of zero
+	zero, err := sums[:threshold+1].ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Zero(t, zero.Sign())
+
+	_, err = SumZeroSharings(append(vjs, vjs[0][:threshold-1]))
+	assert.Error(t, err)
+}
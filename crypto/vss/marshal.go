@@ -0,0 +1,149 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package vss
+
+import (
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Marshal and Unmarshal encode shares and commitments for storage or transport outside of the wire messages, as JSON
+// tagged with the name of the curve (tss.GetCurveName). Unmarshal accepts them on any registered curve, and checks that
+// the share is in range and that the commitments are points of the prime-order group of the curve.
+
+type (
+	shareJSON struct {
+		Curve     string
+		Threshold int
+		ID, Share *big.Int
+	}
+
+	vsJSON struct {
+		Curve  string
+		Points [][2]*big.Int
+	}
+)
+
+// Marshal encodes the share of a sharing on the curve `ec`, tagged with its name
+func (share *Share) Marshal(ec elliptic.Curve) ([]byte, error) {
+	if share == nil || share.ID == nil || share.Share == nil {
+		return nil, errors.New("vss Share.Marshal() received a nil share")
+	}
+	name, err := curveName(ec)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&shareJSON{Curve: name, Threshold: share.Threshold, ID: share.ID, Share: share.Share})
+}
+
+// Unmarshal decodes a share encoded by Marshal, with 0 < ID < q and 0 <= Share < q for the order q of its curve
+func (share *Share) Unmarshal(bz []byte) error {
+	aux := &shareJSON{}
+	if err := json.Unmarshal(bz, aux); err != nil {
+		return err
+	}
+	ec, err := curveByName(aux.Curve)
+	if err != nil {
+		return err
+	}
+	q := ec.Params().N
+	if aux.Threshold < 1 {
+		return errors.New("vss Share.Unmarshal(): threshold < 1")
+	}
+	if aux.ID == nil || aux.ID.Sign() <= 0 || aux.ID.Cmp(q) >= 0 {
+		return errors.New("vss Share.Unmarshal(): the id is not in [1, q)")
+	}
+	if aux.Share == nil || aux.Share.Sign() < 0 || aux.Share.Cmp(q) >= 0 {
+		return errors.New("vss Share.Unmarshal(): the share is not in [0, q)")
+	}
+	share.Threshold, share.ID, share.Share = aux.Threshold, aux.ID, aux.Share
+	return nil
+}
+
+// Marshal encodes the commitments, tagged with the name of their curve
+func (vs Vs) Marshal() ([]byte, error) {
+	if len(vs) == 0 || vs[0] == nil {
+		return nil, errors.New("vss Vs.Marshal(): no commitments")
+	}
+	name, err := curveName(vs[0].Curve())
+	if err != nil {
+		return nil, err
+	}
+	aux := &vsJSON{Curve: name, Points: make([][2]*big.Int, len(vs))}
+	for i, v := range vs {
+		if v == nil {
+			return nil, fmt.Errorf("vss Vs.Marshal(): the commitment %d is nil", i)
+		}
+		aux.Points[i] = [2]*big.Int{v.X(), v.Y()}
+	}
+	return json.Marshal(aux)
+}
+
+// Unmarshal decodes commitments encoded by Marshal, which must be points of the prime-order group of their curve
+func (vs *Vs) Unmarshal(bz []byte) error {
+	aux := &vsJSON{}
+	if err := json.Unmarshal(bz, aux); err != nil {
+		return err
+	}
+	ec, err := curveByName(aux.Curve)
+	if err != nil {
+		return err
+	}
+	if len(aux.Points) == 0 {
+		return errors.New("vss Vs.Unmarshal(): no commitments")
+	}
+	points := make(Vs, len(aux.Points))
+	for i, xy := range aux.Points {
+		if xy[0] == nil || xy[1] == nil {
+			return fmt.Errorf("vss Vs.Unmarshal(): the commitment %d is nil", i)
+		}
+		point, err := crypto.NewECPoint(ec, xy[0], xy[1])
+		if err != nil {
+			return fmt.Errorf("vss Vs.Unmarshal(): the commitment %d is not on the curve", i)
+		}
+		if !inPrimeOrderSubgroup(ec, point) {
+			return fmt.Errorf("vss Vs.Unmarshal(): the commitment %d is not in the prime-order subgroup", i)
+		}
+		points[i] = point
+	}
+	*vs = points
+	return nil
+}
+
+// ----- //
+
+func curveName(ec elliptic.Curve) (string, error) {
+	name, ok := tss.GetCurveName(ec)
+	if !ok {
+		return "", errors.New("vss: the curve is not registered, see tss.RegisterCurve")
+	}
+	return name, nil
+}
+
+func curveByName(name string) (elliptic.Curve, error) {
+	curve, ok := tss.GetCurveByName(name)
+	if !ok {
+		return nil, fmt.Errorf("vss: unknown curve %q", name)
+	}
+	return curve, nil
+}
+
+// inPrimeOrderSubgroup checks that q*P is the identity, which is q*G in the representation of the curve: (0, 0) for the
+// short Weierstrass curves and (0, 1) for ed448. All the points of the curves of prime order are in it.
+func inPrimeOrderSubgroup(curve elliptic.Curve, p *crypto.ECPoint) bool {
+	params := curve.Params()
+	qBz := params.N.Bytes()
+	idX, idY := curve.ScalarMult(params.Gx, params.Gy, qBz)
+	x, y := curve.ScalarMult(p.X(), p.Y(), qBz)
+	return x.Cmp(idX) == 0 && y.Cmp(idY) == 0
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package vss_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	. "github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestShareAndVsMarshal(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
+	}
+
+	vs, shares, err := Create(tss.S256(), threshold, secret, ids)
+	assert.NoError(t, err)
+
+	vsBz, err := vs.Marshal()
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(vsBz), `"Curve":"secp256k1"`))
+	vs2 := Vs{}
+	assert.NoError(t, vs2.Unmarshal(vsBz))
+	assert.Equal(t, len(vs), len(vs2))
+	for i := range vs {
+		assert.True(t, vs[i].Equals(vs2[i]))
+	}
+
+	for _, share := range shares {
+		bz, err := share.Marshal(tss.S256())
+		assert.NoError(t, err)
+		share2 := &Share{}
+		assert.NoError(t, share2.Unmarshal(bz))
+		assert.Equal(t, share.Threshold, share2.Threshold)
+		assert.Equal(t, 0, share.ID.Cmp(share2.ID))
+		assert.Equal(t, 0, share.Share.Cmp(share2.Share))
+		assert.True(t, share2.Verify(threshold, vs2))
+	}
+
+	// another curve, an unknown curve, a point off the curve and a share out of range
+	assert.Error(t, vs2.Unmarshal([]byte(strings.Replace(string(vsBz), "secp256k1", elliptic.P256().Params().Name, 1))))
+	assert.Error(t, vs2.Unmarshal([]byte(strings.Replace(string(vsBz), "secp256k1", "secp256r2", 1))))
+	offCurve, err := Vs{crypto.NewECPointNoCurveCheck(tss.S256(), big.NewInt(1), big.NewInt(1))}.Marshal()
+	assert.NoError(t, err)
+	assert.Error(t, vs2.Unmarshal(offCurve))
+
+	bad := &Share{Threshold: threshold, ID: ids[0], Share: tss.S256().Params().N}
+	bz, err := bad.Marshal(tss.S256())
+	assert.NoError(t, err)
+	assert.Error(t, (&Share{}).Unmarshal(bz))
+	bad = &Share{Threshold: threshold, ID: big.NewInt(0), Share: big.NewInt(1)}
+	bz, err = bad.Marshal(tss.S256())
+	assert.NoError(t, err)
+	assert.Error(t, (&Share{}).Unmarshal(bz))
+}
+
+func TestVsUnmarshalSmallOrder(t *testing.T) {
+	ec := ed448.Curve()
+
+	// (0, -1) is on ed448 and has order 2
+	P := ed448.Curve().Params().P
+	smallOrder := crypto.NewECPointNoCurveCheck(ec, big.NewInt(0), new(big.Int).Sub(P, big.NewInt(1)))
+	assert.True(t, smallOrder.IsOnCurve())
+	G := crypto.ScalarBaseMult(ec, big.NewInt(1))
+
+	bz, err := Vs{G}.Marshal()
+	assert.NoError(t, err)
+	vs := Vs{}
+	assert.NoError(t, vs.Unmarshal(bz))
+
+	bz, err = Vs{G, smallOrder}.Marshal()
+	assert.NoError(t, err)
+	assert.Error(t, vs.Unmarshal(bz))
+}
@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Pedersen VSS, based on Torben Pryds Pedersen, 1991., Non-interactive and information-theoretic secure verifiable
+// secret sharing. In Advances in Cryptology — CRYPTO '91, LNCS 576, 129–140
+//
+
+package vss
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+const (
+	// the number of x coordinates tried by PedersenGenerator before giving up; about half of them are on the curve
+	pedersenGeneratorMaxTries = 256
+)
+
+var (
+	pedersenGeneratorDomain = []byte("tss-lib vss pedersen generator")
+)
+
+// PedersenGenerator returns the second generator H of the Pedersen commitments on `curve`, which nobody knows the
+// discrete logarithm of to the base G. It is the first point with an even y whose x coordinate is
+// SHA512_256Canonical(domain, curve name, G, counter) mod P, for counter = 0, 1, ... (try-and-increment).
+// The curve must be a short Weierstrass curve of prime order, as are secp256k1, P-256 and the STARK curve.
+func PedersenGenerator(curve elliptic.Curve) (*crypto.ECPoint, error) {
+	if curve == nil {
+		return nil, errors.New("PedersenGenerator() received a nil curve")
+	}
+	params := curve.Params()
+	for counter := 0; counter < pedersenGeneratorMaxTries; counter++ {
+		h := common.SHA512_256Canonical(
+			string(pedersenGeneratorDomain),
+			[]byte(params.Name),
+			params.Gx.Bytes(),
+			params.Gy.Bytes(),
+			big.NewInt(int64(counter)).Bytes())
+		x := new(big.Int).Mod(new(big.Int).SetBytes(h), params.P)
+		if H, err := crypto.LiftX(curve, x, false); err == nil {
+			return H, nil
+		}
+	}
+	return nil, fmt.Errorf("PedersenGenerator(): no point found on the curve %q", params.Name)
+}
+
+// CreatePedersen is Create with hiding commitments: the secret is shared with a random polynomial f as in Create, and
+// the returned commitments are c_j = a_j*G + b_j*H for the coefficients a_j of f and b_j of a second random polynomial
+// g, with H from PedersenGenerator. The second Shares hold the evaluations of g, which each party must receive along
+// with its share to verify it with VerifyPedersen. Unlike the Vs of Create, the commitments reveal nothing about the
+// secret, not even secret*G.
+func CreatePedersen(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.Int) (Vs, Shares, Shares, error) {
+	if secret == nil || indexes == nil {
+		return nil, nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
+	}
+	if threshold < 1 {
+		return nil, nil, nil, errors.New("vss threshold < 1")
+	}
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, nil, ErrNumSharesBelowThreshold
+	}
+	H, err := PedersenGenerator(ec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	poly := samplePolynomial(ec, threshold, secret)
+	blindingPoly := samplePolynomial(ec, threshold, common.GetRandomPositiveInt(ec.Params().N))
+	c := make(Vs, len(poly))
+	for i := range poly {
+		c[i], err = crypto.ScalarBaseMult(ec, poly[i]).Add(H.ScalarMult(blindingPoly[i]))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	shares, blindings := make(Shares, num), make(Shares, num)
+	for i := 0; i < num; i++ {
+		if indexes[i].Cmp(big.NewInt(0)) == 0 {
+			return nil, nil, nil, fmt.Errorf("party index should not be 0")
+		}
+		share := evaluatePolynomial(ec, threshold, poly, indexes[i])
+		blinding := evaluatePolynomial(ec, threshold, blindingPoly, indexes[i])
+		shares[i] = &Share{Threshold: threshold, ID: indexes[i], Share: share}
+		blindings[i] = &Share{Threshold: threshold, ID: indexes[i], Share: blinding}
+	}
+	return c, shares, blindings, nil
+}
+
+// VerifyPedersen verifies a share created by CreatePedersen, with its blinding share, against the commitments c0..ct
+func (share *Share) VerifyPedersen(threshold int, blinding *Share, cs Vs) bool {
+	if share.Threshold != threshold || len(cs) != threshold+1 ||
+		blinding == nil || blinding.Threshold != threshold || blinding.ID == nil || blinding.ID.Cmp(share.ID) != 0 {
+		return false
+	}
+	c, err := cs.Evaluate(share.ID)
+	if err != nil {
+		return false
+	}
+	ec := c.Curve()
+	H, err := PedersenGenerator(ec)
+	if err != nil {
+		return false
+	}
+	// sigma_i*G + tau_i*H
+	G := crypto.ScalarBaseMult(ec, one)
+	sigmaGiTauHi, err := crypto.MultiScalarMult(ec, []*crypto.ECPoint{G, H}, []*big.Int{share.Share, blinding.Share})
+	if err != nil {
+		return false
+	}
+	return sigmaGiTauHi.Equals(c)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package vss_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	. "github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestPedersenGenerator(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.S256(), elliptic.P256(), stark.Curve()} {
+		H, err := PedersenGenerator(curve)
+		assert.NoError(t, err)
+		assert.True(t, H.IsOnCurve())
+		assert.False(t, H.Equals(crypto.ScalarBaseMult(curve, big.NewInt(1))))
+
+		// deterministic
+		H2, err := PedersenGenerator(curve)
+		assert.NoError(t, err)
+		assert.True(t, H.Equals(H2))
+	}
+}
+
+func TestCreatePedersen(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.S256().Params().N))
+	}
+
+	cs, shares, blindings, err := CreatePedersen(tss.S256(), threshold, secret, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold+1, len(cs))
+	assert.Equal(t, num, len(shares))
+	assert.Equal(t, num, len(blindings))
+
+	// c0 hides the secret
+	assert.False(t, cs[0].Equals(crypto.ScalarBaseMult(tss.S256(), secret)))
+
+	for i := 0; i < num; i++ {
+		assert.True(t, shares[i].VerifyPedersen(threshold, blindings[i], cs))
+		// a wrong blinding share, a wrong share or the Feldman check fail
+		assert.False(t, shares[i].VerifyPedersen(threshold, blindings[(i+1)%num], cs))
+		assert.False(t, shares[i].VerifyPedersen(threshold, shares[i], cs))
+		assert.False(t, shares[i].Verify(threshold, cs))
+	}
+	bad := &Share{Threshold: threshold, ID: ids[0], Share: new(big.Int).Add(shares[0].Share, big.NewInt(1))}
+	assert.False(t, bad.VerifyPedersen(threshold, blindings[0], cs))
+	assert.False(t, shares[0].VerifyPedersen(threshold, blindings[0], cs[:threshold]))
+
+	// the shares reconstruct the secret as those of Create
+	secret2, err := shares[:threshold+1].ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, secret2.Cmp(secret))
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package modproof
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// BatchVerify checks the modulus proofs of a whole committee at once. Its work is split into one task per proof (the
+// checks of N, W, A and B and the challenges) and one task per iteration, spread over a pool of workers, so that the
+// proofs of many parties, and the Iterations checks of each, run in parallel.
+//
+// As with paillier.BatchVerify, the checks are not folded into a random linear combination: the proofs of different
+// parties use different moduli, and within one proof Z*_N has elements of order 2, by which a combination of the
+// x_i^4 = (-1)^a_i * W^b_i * y_i checks cannot tell a fourth root from a root of another sign.
+
+type (
+	// Statement is a Proof with the modulus that it was made for
+	Statement struct {
+		Proof *Proof
+		N     *big.Int
+	}
+)
+
+var (
+	ErrInvalidProof = errors.New("modproof verify: the proof is invalid")
+)
+
+// BatchVerify checks each statement as Proof.Verify does, with as many workers as CPUs or the number given in
+// `optionalConcurrency`. It returns one error per statement, nil for a valid proof and ErrInvalidProof for one that
+// fails.
+func BatchVerify(statements []Statement, optionalConcurrency ...int) []error {
+	var concurrency int
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("BatchVerify: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	} else {
+		concurrency = runtime.NumCPU()
+	}
+	errs := make([]error, len(statements))
+
+	// 1. the checks of N, W, A and B and the challenges of each proof
+	Ys := make([][Iterations]*big.Int, len(statements))
+	fanOut(concurrency, len(statements), func(j int) {
+		Y, ok := statements[j].Proof.verifyBasic(statements[j].N)
+		if !ok {
+			errs[j] = ErrInvalidProof
+			return
+		}
+		Ys[j] = Y
+	})
+
+	// 2. every iteration of every proof that is left
+	failed := make([][Iterations]bool, len(statements))
+	fanOut(concurrency, len(statements)*Iterations, func(task int) {
+		j, i := task/Iterations, task%Iterations
+		if errs[j] != nil {
+			return
+		}
+		failed[j][i] = !statements[j].Proof.verifyIteration(statements[j].N, Ys[j], i)
+	})
+	for j := range statements {
+		if errs[j] != nil {
+			continue
+		}
+		for _, f := range failed[j] {
+			if f {
+				errs[j] = ErrInvalidProof
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// fanOut runs f(0), ..., f(count-1) on `concurrency` workers and waits for them
+func fanOut(concurrency, count int, f func(int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	tasks := make(chan int, count)
+	for t := 0; t < count; t++ {
+		tasks <- t
+	}
+	close(tasks)
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				f(t)
+			}
+		}()
+	}
+	wg.Wait()
+}
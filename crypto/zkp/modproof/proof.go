@@ -0,0 +1,249 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package modproof implements the Paillier-Blum modulus proof of Canetti, Gennaro, Goldfeder, Makriyannis and Peled: UC
+// Non-Interactive, Proactive, Threshold ECDSA with Identifiable Aborts (2021), Fig. 16.
+//
+// The prover knows the factorization N = p*q and shows that p and q are primes that are 3 mod 4 and that
+// gcd(N, phi(N)) = 1, so that N is square-free. The proof is non-interactive by Fiat-Shamir: the challenges y_i are
+// drawn from a zkp.Transcript of N and the prover's W. Together with the no small factor proof of package facproof it
+// shows that a Paillier modulus is well formed; paillier.CorrectKeyProof is made of the two.
+package modproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+const (
+	// Iterations is the number of challenges of a proof; a modulus that is not a Paillier-Blum modulus passes each with
+	// probability at most 1/2
+	Iterations = 80
+	// ProofBytesParts is the number of parts of a serialized Proof
+	ProofBytesParts = 3 + 2*Iterations
+
+	// the bit length of the random multiple of the group order added to a blinded exponent
+	exponentBlindingBits = 64
+)
+
+type (
+	// Proof is the Paillier-Blum modulus proof of CGGMP21 Fig. 16.
+	// Bit i of A and B holds a_i and b_i.
+	Proof struct {
+		W    *big.Int
+		X, Z [Iterations]*big.Int
+		A, B *big.Int
+	}
+
+	// Exponentiator computes x^y mod m for x in [0, m) and y >= 0. The prover's exponentiations with the secret
+	// factors go through it; paillier.Exponentiator satisfies it.
+	Exponentiator interface {
+		Exp(x, y, m *big.Int) *big.Int
+	}
+
+	bigExponentiator struct{}
+)
+
+var (
+	one  = big.NewInt(1)
+	four = big.NewInt(4)
+)
+
+func (bigExponentiator) Exp(x, y, m *big.Int) *big.Int {
+	return common.ModInt(m).Exp(x, y)
+}
+
+// NewProof proves that N = P*Q is a Paillier-Blum modulus
+func NewProof(N, P, Q *big.Int) (*Proof, error) {
+	return NewProofWithExponentiator(N, P, Q, nil)
+}
+
+// NewProofWithExponentiator is NewProof with the exponentiations with secret exponents done by `exp`, e.g. a
+// constant-time one. A nil `exp` uses math/big. The secret exponents are blinded with a random multiple of the group
+// order either way.
+func NewProofWithExponentiator(N, P, Q *big.Int, exp Exponentiator) (*Proof, error) {
+	if N == nil || P == nil || Q == nil {
+		return nil, errors.New("modproof.NewProof() received nil value(s)")
+	}
+	if new(big.Int).Mul(P, Q).Cmp(N) != 0 {
+		return nil, errors.New("modproof.NewProof(): N != P*Q")
+	}
+	if exp == nil {
+		exp = bigExponentiator{}
+	}
+	secretExp := func(x, y, m, order *big.Int) *big.Int {
+		k := common.MustGetRandomInt(exponentBlindingBits)
+		y = new(big.Int).Add(y, k.Mul(k, order))
+		return exp.Exp(new(big.Int).Mod(x, m), y, m)
+	}
+	PhiN := new(big.Int).Mul(new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one))
+	NInv := new(big.Int).ModInverse(N, PhiN)
+	if NInv == nil {
+		return nil, errors.New("modproof.NewProof(): N is not invertible mod phi(N)")
+	}
+	// 1. sample W with Jacobi symbol -1
+	var W *big.Int
+	for {
+		W = common.GetRandomPositiveRelativelyPrimeInt(N)
+		if big.Jacobi(W, N) == -1 {
+			break
+		}
+	}
+	// 2. compute the challenges y_i
+	Y := challenges(N, W)
+	// 3. compute x_i = ((-1)^a_i * W^b_i * y_i)^1/4 and z_i = y_i^(1/N)
+	modN := common.ModInt(N)
+	pf := &Proof{W: W, A: new(big.Int), B: new(big.Int)}
+	for i, Yi := range Y {
+		found := false
+		for j := 0; j < 4 && !found; j++ {
+			a, b := uint(j&1), uint(j>>1)
+			Yi2 := new(big.Int).Set(Yi)
+			if a == 1 {
+				Yi2.Sub(N, Yi2)
+			}
+			if b == 1 {
+				Yi2 = modN.Mul(W, Yi2)
+			}
+			if big.Jacobi(Yi2, P) != 1 || big.Jacobi(Yi2, Q) != 1 {
+				continue
+			}
+			pf.X[i] = fourthRoot(Yi2, P, Q, secretExp)
+			pf.A.SetBit(pf.A, i, a)
+			pf.B.SetBit(pf.B, i, b)
+			found = true
+		}
+		if !found {
+			return nil, errors.New("modproof.NewProof(): P and Q must be 3 mod 4")
+		}
+		pf.Z[i] = secretExp(Yi, NInv, N, PhiN)
+	}
+	return pf, nil
+}
+
+// Verify checks that N is the product of two primes that are 3 mod 4, with gcd(N, phi(N)) = 1
+func (pf *Proof) Verify(N *big.Int) bool {
+	Y, ok := pf.verifyBasic(N)
+	if !ok {
+		return false
+	}
+	for i := range Y {
+		if !pf.verifyIteration(N, Y, i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *Proof) ValidateBasic() bool {
+	if pf.W == nil || pf.A == nil || pf.B == nil {
+		return false
+	}
+	for i := range pf.X {
+		if pf.X[i] == nil || pf.Z[i] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyBasic runs the checks of Verify on N, W, A and B and returns the challenges
+func (pf *Proof) verifyBasic(N *big.Int) ([Iterations]*big.Int, bool) {
+	if pf == nil || !pf.ValidateBasic() || N == nil {
+		return [Iterations]*big.Int{}, false
+	}
+	if N.Sign() <= 0 || N.Bit(0) == 0 || N.ProbablyPrime(20) {
+		return [Iterations]*big.Int{}, false
+	}
+	if !common.IsNumberInMultiplicativeGroup(N, pf.W) || big.Jacobi(pf.W, N) != -1 {
+		return [Iterations]*big.Int{}, false
+	}
+	if pf.A.Sign() < 0 || pf.B.Sign() < 0 || pf.A.BitLen() > Iterations || pf.B.BitLen() > Iterations {
+		return [Iterations]*big.Int{}, false
+	}
+	return challenges(N, pf.W), true
+}
+
+// verifyIteration checks z_i^N = y_i and x_i^4 = (-1)^a_i * W^b_i * y_i mod N
+func (pf *Proof) verifyIteration(N *big.Int, Y [Iterations]*big.Int, i int) bool {
+	Xi, Zi, Yi := pf.X[i], pf.Z[i], Y[i]
+	if !common.IsNumberInMultiplicativeGroup(N, Xi) || !common.IsNumberInMultiplicativeGroup(N, Zi) {
+		return false
+	}
+	modN := common.ModInt(N)
+	if modN.Exp(Zi, N).Cmp(Yi) != 0 {
+		return false
+	}
+	Yi2 := new(big.Int).Set(Yi)
+	if pf.A.Bit(i) == 1 {
+		Yi2.Sub(N, Yi2)
+	}
+	if pf.B.Bit(i) == 1 {
+		Yi2 = modN.Mul(pf.W, Yi2)
+	}
+	return modN.Exp(Xi, four).Cmp(Yi2) == 0
+}
+
+// Bytes returns the ProofBytesParts parts of the proof: W, A, B, then the x_i and the z_i
+func (pf *Proof) Bytes() [ProofBytesParts][]byte {
+	var bzs [ProofBytesParts][]byte
+	bzs[0], bzs[1], bzs[2] = pf.W.Bytes(), pf.A.Bytes(), pf.B.Bytes()
+	for i := 0; i < Iterations; i++ {
+		bzs[3+i] = pf.X[i].Bytes()
+		bzs[3+Iterations+i] = pf.Z[i].Bytes()
+	}
+	return bzs
+}
+
+// ProofFromBytes parses the parts made by Bytes. A and B may be empty, as all of their bits may be 0.
+func ProofFromBytes(bzs [][]byte) (*Proof, error) {
+	if len(bzs) != ProofBytesParts {
+		return nil, fmt.Errorf("expected %d byte parts to construct a modulus proof but got %d", ProofBytesParts, len(bzs))
+	}
+	ints := common.MultiBytesToBigInts(bzs)
+	pf := &Proof{W: ints[0], A: ints[1], B: ints[2]}
+	copy(pf.X[:], ints[3:3+Iterations])
+	copy(pf.Z[:], ints[3+Iterations:])
+	return pf, nil
+}
+
+// challenges draws the challenges y_i in Z*_N from the transcript of the proof after N and W
+func challenges(N, W *big.Int) [Iterations]*big.Int {
+	var Y [Iterations]*big.Int
+	tr := zkp.ForProof(nil, "modproof")
+	tr.AppendInts("statement", N)
+	tr.AppendInts("commitment", W)
+	for i := range Y {
+		for {
+			Y[i] = tr.Challenge("challenge", N)
+			if common.IsNumberInMultiplicativeGroup(N, Y[i]) {
+				break
+			}
+		}
+	}
+	return Y
+}
+
+// fourthRoot returns the fourth root mod N = P*Q of a quadratic residue mod P and Q, with P, Q = 3 mod 4. Mod such a
+// prime p, y^((p+1)/4) is the square root of y that is itself a quadratic residue.
+func fourthRoot(y, P, Q *big.Int, secretExp func(x, y, m, order *big.Int) *big.Int) *big.Int {
+	rootMod := func(p *big.Int) *big.Int {
+		e := new(big.Int).Rsh(new(big.Int).Add(p, one), 2)
+		e.Mul(e, e)
+		pMinus1 := new(big.Int).Sub(p, one)
+		e.Mod(e, pMinus1)
+		return secretExp(y, e, p, pMinus1)
+	}
+	xP, xQ := rootMod(P), rootMod(Q)
+	// x = xQ + Q * ((xP - xQ) * Q^-1 mod P)
+	qInv := new(big.Int).ModInverse(Q, P)
+	x := common.ModInt(P).Mul(new(big.Int).Sub(xP, xQ), qInv)
+	return x.Mul(x, Q).Add(x, xQ)
+}
@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package modproof_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/crypto/zkp/modproof"
+)
+
+const (
+	testPrimeBits = 1024
+)
+
+// blumPrime returns a random prime that is 3 mod 4, or 1 mod 4 if `blum` is false
+func blumPrime(blum bool) *big.Int {
+	for {
+		p := common.GetRandomPrimeInt(testPrimeBits)
+		if (p.Bit(1) == 1) == blum {
+			return p
+		}
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	P, Q := blumPrime(true), blumPrime(true)
+	N := new(big.Int).Mul(P, Q)
+
+	pf, err := NewProof(N, P, Q)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(N))
+
+	// through its bytes
+	bzs := pf.Bytes()
+	pf2, err := ProofFromBytes(bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(N))
+	_, err = ProofFromBytes(bzs[:ProofBytesParts-1])
+	assert.Error(t, err)
+
+	// against another modulus, or tampered
+	assert.False(t, pf.Verify(new(big.Int).Add(N, big.NewInt(2))))
+	bad := *pf
+	bad.A = new(big.Int).Xor(pf.A, big.NewInt(1))
+	assert.False(t, bad.Verify(N))
+	bad = *pf
+	bad.Z[Iterations-1] = new(big.Int).Add(pf.Z[Iterations-1], big.NewInt(1))
+	assert.False(t, bad.Verify(N))
+	assert.False(t, (*Proof)(nil).Verify(N))
+	assert.False(t, new(Proof).Verify(N))
+
+	_, err = NewProof(N, P, P)
+	assert.Error(t, err)
+}
+
+func TestNotBlum(t *testing.T) {
+	P, Q := blumPrime(false), blumPrime(true)
+	N := new(big.Int).Mul(P, Q)
+	pf, err := NewProof(N, P, Q)
+	if err == nil {
+		assert.False(t, pf.Verify(N), "a prime that is 1 mod 4 has no valid proof")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	statements := make([]Statement, 3)
+	for j := range statements {
+		P, Q := blumPrime(true), blumPrime(true)
+		N := new(big.Int).Mul(P, Q)
+		pf, err := NewProof(N, P, Q)
+		assert.NoError(t, err)
+		statements[j] = Statement{Proof: pf, N: N}
+	}
+	for _, err := range BatchVerify(statements) {
+		assert.NoError(t, err)
+	}
+
+	bad := *statements[1].Proof
+	bad.X[0] = new(big.Int).Add(bad.X[0], big.NewInt(1))
+	statements[1].Proof = &bad
+	statements[2].N = statements[0].N
+	errs := BatchVerify(statements, 2)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, ErrInvalidProof, errs[1])
+	assert.Equal(t, ErrInvalidProof, errs[2])
+
+	errs = BatchVerify([]Statement{{Proof: nil, N: statements[0].N}, {Proof: statements[0].Proof, N: nil}})
+	assert.Equal(t, ErrInvalidProof, errs[0])
+	assert.Equal(t, ErrInvalidProof, errs[1])
+}
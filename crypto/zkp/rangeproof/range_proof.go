@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package rangeproof implements the proof that the plaintext of a Paillier ciphertext is small (GG18Spec (9) Fig. 9),
+// which is Alice's range proof in the MtA share conversion of signing.
+//
+// The prover knows m in [0, q) and r such that c = Gamma^m * r^N mod N^2. The proof is made against the verifier's
+// ring-Pedersen parameters NTilde, h1 and h2, whose factors the prover must not know. It masks m with a value from
+// [0, bound), so a proof that verifies shows that m lies in [-bound, bound]. GG18 uses bound = q^3, see
+// mta.ProveRangeAlice.
+package rangeproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+const (
+	ProofBytesParts = 6
+)
+
+var (
+	zero = big.NewInt(0)
+)
+
+type (
+	// Proof is the proof of GG18Spec Fig. 9, with z = h1^m*h2^rho, u = Gamma^alpha*beta^N and w = h1^alpha*h2^gamma, and
+	// the responses s = r^e*beta, s1 = e*m+alpha and s2 = e*rho+gamma
+	Proof struct {
+		Z, U, W, S, S1, S2 *big.Int
+	}
+)
+
+// Prove constructs a proof that the plaintext m of `c`, which was encrypted with the randomness `r`, lies in
+// [-bound, bound]. The challenges are drawn from [0, q) in the context `ctx`, see zkp.ForProof, and m must be in [0, q)
+// for the proof to verify.
+func Prove(ctx *zkp.Transcript, pk *paillier.PublicKey, c, NTilde, h1, h2, m, r, q, bound *big.Int) (*Proof, error) {
+	if pk == nil || NTilde == nil || h1 == nil || h2 == nil || c == nil || m == nil || r == nil || q == nil || bound == nil {
+		return nil, errors.New("rangeproof.Prove() received nil value(s)")
+	}
+
+	qNTilde := new(big.Int).Mul(q, NTilde)
+	boundNTilde := new(big.Int).Mul(bound, NTilde)
+
+	// 1.
+	alpha := common.GetRandomPositiveInt(bound)
+	// 2.
+	beta := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+
+	// 3.
+	gamma := common.GetRandomPositiveInt(boundNTilde)
+
+	// 4.
+	rho := common.GetRandomPositiveInt(qNTilde)
+
+	// 5.
+	modNTilde := common.ModInt(NTilde)
+	z := modNTilde.Exp(h1, m)
+	z = modNTilde.Mul(z, modNTilde.Exp(h2, rho))
+
+	// 6.
+	modNSquared := common.ModInt(pk.NSquare())
+	u := modNSquared.Exp(pk.Gamma(), alpha)
+	u = modNSquared.Mul(u, modNSquared.Exp(beta, pk.N))
+
+	// 7.
+	w := modNTilde.Exp(h1, alpha)
+	w = modNTilde.Mul(w, modNTilde.Exp(h2, gamma))
+
+	// 8-9. e'
+	e := challenge(ctx, pk, NTilde, h1, h2, c, z, u, w, q)
+
+	modN := common.ModInt(pk.N)
+	s := modN.Exp(r, e)
+	s = modN.Mul(s, beta)
+
+	// s1 = e * m + alpha
+	s1 := new(big.Int).Mul(e, m)
+	s1 = new(big.Int).Add(s1, alpha)
+
+	// s2 = e * rho + gamma
+	s2 := new(big.Int).Mul(e, rho)
+	s2 = new(big.Int).Add(s2, gamma)
+
+	return &Proof{Z: z, U: u, W: w, S: s, S1: s1, S2: s2}, nil
+}
+
+func ProofFromBytes(bzs [][]byte) (*Proof, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct a range proof", ProofBytesParts)
+	}
+	return &Proof{
+		Z:  new(big.Int).SetBytes(bzs[0]),
+		U:  new(big.Int).SetBytes(bzs[1]),
+		W:  new(big.Int).SetBytes(bzs[2]),
+		S:  new(big.Int).SetBytes(bzs[3]),
+		S1: new(big.Int).SetBytes(bzs[4]),
+		S2: new(big.Int).SetBytes(bzs[5]),
+	}, nil
+}
+
+// Verify checks the proof that the plaintext of `c` lies in [-bound, bound]; `ctx`, `q` and `bound` must be those of
+// the prover
+func (pf *Proof) Verify(ctx *zkp.Transcript, pk *paillier.PublicKey, NTilde, h1, h2, c, q, bound *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || pk == nil || NTilde == nil || h1 == nil || h2 == nil || c == nil ||
+		q == nil || bound == nil {
+		return false
+	}
+	if pk.ValidateCiphertext(c) != nil {
+		return false
+	}
+
+	// 3.
+	if pf.S1.Cmp(bound) == 1 {
+		return false
+	}
+
+	// 1-2. e'
+	e := challenge(ctx, pk, NTilde, h1, h2, c, pf.Z, pf.U, pf.W, q)
+
+	var products *big.Int // for the following conditionals
+	minusE := new(big.Int).Sub(zero, e)
+
+	{ // 4. gamma^s_1 * s^N * c^-e
+		modN2 := common.ModInt(pk.NSquare())
+
+		cExpMinusE := modN2.Exp(c, minusE)
+		sExpN := modN2.Exp(pf.S, pk.N)
+		gammaExpS1 := modN2.Exp(pk.Gamma(), pf.S1)
+		// u != (4)
+		products = modN2.Mul(gammaExpS1, sExpN)
+		products = modN2.Mul(products, cExpMinusE)
+		if pf.U.Cmp(products) != 0 {
+			return false
+		}
+	}
+
+	{ // 5. h_1^s_1 * h_2^s_2 * z^-e
+		modNTilde := common.ModInt(NTilde)
+
+		h1ExpS1 := modNTilde.Exp(h1, pf.S1)
+		h2ExpS2 := modNTilde.Exp(h2, pf.S2)
+		zExpMinusE := modNTilde.Exp(pf.Z, minusE)
+		// w != (5)
+		products = modNTilde.Mul(h1ExpS1, h2ExpS2)
+		products = modNTilde.Mul(products, zExpMinusE)
+		if pf.W.Cmp(products) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *Proof) ValidateBasic() bool {
+	return pf.Z != nil &&
+		pf.U != nil &&
+		pf.W != nil &&
+		pf.S != nil &&
+		pf.S1 != nil &&
+		pf.S2 != nil
+}
+
+func (pf *Proof) Bytes() [ProofBytesParts][]byte {
+	return [...][]byte{
+		pf.Z.Bytes(),
+		pf.U.Bytes(),
+		pf.W.Bytes(),
+		pf.S.Bytes(),
+		pf.S1.Bytes(),
+		pf.S2.Bytes(),
+	}
+}
+
+// challenge returns e' of steps 8-9, drawn in [0, q) from the transcript of the proof after the statement and the
+// commitments z, u and w
+func challenge(ctx *zkp.Transcript, pk *paillier.PublicKey, NTilde, h1, h2, c, z, u, w, q *big.Int) *big.Int {
+	tr := zkp.ForProof(ctx, "rangeproof")
+	tr.AppendInts("public-key", pk.AsInts()...)
+	tr.AppendInts("statement", NTilde, h1, h2, c)
+	tr.AppendInts("commitment", z, u, w)
+	return tr.Challenge("challenge", q)
+}
@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package rangeproof_test
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	. "github.com/binance-chain/tss-lib/crypto/zkp/rangeproof"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testPaillierKeyLength = 2048
+	testSafePrimeBits     = 1024
+)
+
+var (
+	update = flag.Bool("update", false, "regenerate the test vectors in testdata")
+
+	vectorsFile = filepath.Join("testdata", "vectors.json")
+)
+
+type (
+	// a test vector, with the numbers in hex
+	vector struct {
+		Name                 string
+		N, NTilde, H1, H2, C string
+		Q, Bound             string
+		Proof                [ProofBytesParts]string
+		Valid                bool
+	}
+
+	setup struct {
+		sk                *paillier.PrivateKey
+		pk                *paillier.PublicKey
+		NTilde, h1, h2, q *big.Int
+	}
+)
+
+func newSetup(t *testing.T) *setup {
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testSafePrimeBits), common.GetRandomPrimeInt(testSafePrimeBits)}
+	NTilde, h1, h2, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+	return &setup{sk: sk, pk: pk, NTilde: NTilde, h1: h1, h2: h2, q: tss.S256().Params().N}
+}
+
+func (s *setup) prove(t *testing.T, m, bound *big.Int) (*big.Int, *Proof) {
+	c, r, err := s.sk.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+	pf, err := Prove(nil, s.pk, c, s.NTilde, s.h1, s.h2, m, r, s.q, bound)
+	assert.NoError(t, err)
+	return c, pf
+}
+
+func TestProveVerify(t *testing.T) {
+	s := newSetup(t)
+	q3 := new(big.Int).Exp(s.q, big.NewInt(3), nil)
+	m := common.GetRandomPositiveInt(s.q)
+	c, pf := s.prove(t, m, q3)
+	assert.True(t, pf.Verify(nil, s.pk, s.NTilde, s.h1, s.h2, c, s.q, q3))
+
+	// through its bytes
+	bzs := pf.Bytes()
+	pf2, err := ProofFromBytes(bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, pf2.Verify(nil, s.pk, s.NTilde, s.h1, s.h2, c, s.q, q3))
+	_, err = ProofFromBytes(bzs[:ProofBytesParts-1])
+	assert.Error(t, err)
+
+	// a ciphertext outside of Z*_{N^2} never verifies
+	for _, bad := range []*big.Int{big.NewInt(0), s.pk.N, s.pk.NSquare()} {
+		assert.False(t, pf.Verify(nil, s.pk, s.NTilde, s.h1, s.h2, bad, s.q, q3))
+	}
+	assert.False(t, (*Proof)(nil).Verify(nil, s.pk, s.NTilde, s.h1, s.h2, c, s.q, q3))
+	_, err = Prove(nil, s.pk, c, s.NTilde, s.h1, s.h2, m, nil, s.q, q3)
+	assert.Error(t, err)
+
+	// a proof made in a context verifies only in that context
+	ctx := zkp.NewSessionTranscript("signing", []byte("session"), 1)
+	c, r, err := s.sk.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+	pf, err = Prove(ctx, s.pk, c, s.NTilde, s.h1, s.h2, m, r, s.q, q3)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(ctx, s.pk, s.NTilde, s.h1, s.h2, c, s.q, q3))
+	assert.False(t, pf.Verify(zkp.NewSessionTranscript("signing", []byte("session"), 2), s.pk, s.NTilde, s.h1, s.h2, c, s.q, q3))
+	assert.False(t, pf.Verify(nil, s.pk, s.NTilde, s.h1, s.h2, c, s.q, q3))
+}
+
+func TestVectors(t *testing.T) {
+	if *update {
+		writeVectors(t)
+	}
+	bz, err := ioutil.ReadFile(vectorsFile)
+	assert.NoError(t, err)
+	var vectors []vector
+	assert.NoError(t, json.Unmarshal(bz, &vectors))
+	assert.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		pk := &paillier.PublicKey{N: fromHex(t, v.N)}
+		bzs := make([][]byte, ProofBytesParts)
+		for i, part := range v.Proof {
+			bzs[i] = fromHex(t, part).Bytes()
+		}
+		pf, err := ProofFromBytes(bzs)
+		assert.NoError(t, err, v.Name)
+		ok := pf.Verify(nil, pk, fromHex(t, v.NTilde), fromHex(t, v.H1), fromHex(t, v.H2), fromHex(t, v.C),
+			fromHex(t, v.Q), fromHex(t, v.Bound))
+		assert.Equal(t, v.Valid, ok, v.Name)
+	}
+}
+
+// writeVectors regenerates the test vectors, with `go test -run TestVectors -args -update`
+func writeVectors(t *testing.T) {
+	s := newSetup(t)
+	q2 := new(big.Int).Exp(s.q, big.NewInt(2), nil)
+	q3 := new(big.Int).Exp(s.q, big.NewInt(3), nil)
+
+	newVector := func(name string, c *big.Int, pf *Proof, bound *big.Int, valid bool) vector {
+		v := vector{
+			Name: name,
+			N:    s.pk.N.Text(16), NTilde: s.NTilde.Text(16), H1: s.h1.Text(16), H2: s.h2.Text(16), C: c.Text(16),
+			Q: s.q.Text(16), Bound: bound.Text(16),
+			Valid: valid,
+		}
+		for i, part := range pf.Bytes() {
+			v.Proof[i] = new(big.Int).SetBytes(part).Text(16)
+		}
+		return v
+	}
+	c, pf := s.prove(t, common.GetRandomPositiveInt(s.q), q3)
+	tampered := *pf
+	tampered.S2 = new(big.Int).Add(pf.S2, big.NewInt(1))
+	otherC := new(big.Int).Mul(c, c)
+	otherC.Mod(otherC, s.pk.NSquare())
+	cBig, pfBig := s.prove(t, new(big.Int).Add(q3, big.NewInt(5)), q3)
+	vectors := []vector{
+		newVector("m in [0, q) with the GG18 bound q^3", c, pf, q3, true),
+		newVector("the same proof against the bound q^2", c, pf, q2, false),
+		newVector("a tampered s2", c, &tampered, q3, false),
+		newVector("another ciphertext", otherC, pf, q3, false),
+		newVector("m beyond the bound q^3", cBig, pfBig, q3, false),
+	}
+	bz, err := json.MarshalIndent(vectors, "", "  ")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(vectorsFile, append(bz, '\n'), 0644))
+}
+
+func fromHex(t *testing.T, s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	assert.True(t, ok, "bad hex %q", s)
+	return n
+}
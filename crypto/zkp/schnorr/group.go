@@ -0,0 +1,90 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package schnorr
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+type (
+	// Group is a group of prime order in which a discrete logarithm is proven
+	Group interface {
+		// Order returns the prime order q of the group
+		Order() *big.Int
+		// Generator returns the generator G that the discrete logarithms are taken to the base of
+		Generator() Element
+		// ScalarBaseMult returns k*G
+		ScalarBaseMult(k *big.Int) Element
+	}
+
+	// Element is an element of a Group. The methods are only given elements of the same group.
+	Element interface {
+		// Add returns the sum of the element and `other`, or an error if the group cannot represent it
+		Add(other Element) (Element, error)
+		// ScalarMult returns k times the element
+		ScalarMult(k *big.Int) Element
+		// Equal returns whether the element is `other`
+		Equal(other Element) bool
+		// Encode returns the parts of the element that are appended to a Transcript
+		Encode() [][]byte
+	}
+
+	// CurvePoint is an Element of a CurveGroup; a *crypto.ECPoint converts to it and back
+	CurvePoint crypto.ECPoint
+
+	curveGroup struct {
+		curve elliptic.Curve
+	}
+)
+
+// CurveGroup returns the group of the points of `curve`, such as tss.S256(). Its elements are *CurvePoint, which are
+// encoded as their affine coordinates x and y, as in the proofs of GG18Spec Fig. 16.
+func CurveGroup(curve elliptic.Curve) Group {
+	return curveGroup{curve: curve}
+}
+
+func (g curveGroup) Order() *big.Int {
+	return g.curve.Params().N
+}
+
+func (g curveGroup) Generator() Element {
+	params := g.curve.Params()
+	return (*CurvePoint)(crypto.NewECPointNoCurveCheck(g.curve, params.Gx, params.Gy)) // already on the curve.
+}
+
+func (g curveGroup) ScalarBaseMult(k *big.Int) Element {
+	return (*CurvePoint)(crypto.ScalarBaseMult(g.curve, k))
+}
+
+// ECPoint returns the point as a *crypto.ECPoint
+func (p *CurvePoint) ECPoint() *crypto.ECPoint {
+	return (*crypto.ECPoint)(p)
+}
+
+func (p *CurvePoint) Add(other Element) (Element, error) {
+	sum, err := p.ECPoint().Add(other.(*CurvePoint).ECPoint())
+	if err != nil {
+		return nil, err
+	}
+	return (*CurvePoint)(sum), nil
+}
+
+func (p *CurvePoint) ScalarMult(k *big.Int) Element {
+	return (*CurvePoint)(p.ECPoint().ScalarMult(k))
+}
+
+func (p *CurvePoint) Equal(other Element) bool {
+	q, ok := other.(*CurvePoint)
+	return ok && p.ECPoint().Equals(q.ECPoint())
+}
+
+func (p *CurvePoint) Encode() [][]byte {
+	return [][]byte{p.ECPoint().X().Bytes(), p.ECPoint().Y().Bytes()}
+}
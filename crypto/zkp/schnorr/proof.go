@@ -0,0 +1,64 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package schnorr implements the Schnorr ZK proof of knowledge of a discrete logarithm (GG18Spec Fig. 16) once for any
+// group of prime order and any Fiat-Shamir transcript. The proofs of keygen on the curves, in crypto/schnorr, and those
+// in the ristretto255 and BLS12-381 G1 groups are made with it.
+package schnorr
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+type (
+	// Proof is a proof of knowledge of x such that X = x*G, with the commitment Alpha = a*G and the response t = a + c*x
+	Proof struct {
+		Alpha Element
+		T     *big.Int
+	}
+)
+
+// Prove constructs a proof of knowledge of `x` such that X = x*G in `group`. The challenge is drawn from `tr` after X,
+// G and Alpha have been appended to it.
+func Prove(group Group, tr Transcript, x *big.Int, X Element) (*Proof, error) {
+	if group == nil || tr == nil || x == nil || X == nil {
+		return nil, errors.New("schnorr.Prove() received nil value(s)")
+	}
+	q := group.Order()
+	a := common.GetRandomPositiveInt(q)
+	alpha := group.ScalarBaseMult(a)
+	c := challenge(group, tr, X, alpha)
+	t := common.ModInt(q).Add(a, new(big.Int).Mul(c, x))
+	return &Proof{Alpha: alpha, T: t}, nil
+}
+
+// Verify checks that t*G = Alpha + c*X, with the challenge c drawn from `tr` as in Prove
+func (pf *Proof) Verify(group Group, tr Transcript, X Element) bool {
+	if pf == nil || !pf.ValidateBasic() || group == nil || tr == nil || X == nil {
+		return false
+	}
+	c := challenge(group, tr, X, pf.Alpha)
+	tG := group.ScalarBaseMult(pf.T)
+	aXc, err := pf.Alpha.Add(X.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return tG.Equal(aXc)
+}
+
+func (pf *Proof) ValidateBasic() bool {
+	return pf.T != nil && pf.Alpha != nil
+}
+
+func challenge(group Group, tr Transcript, X, alpha Element) *big.Int {
+	tr.Append(X.Encode()...)
+	tr.Append(group.Generator().Encode()...)
+	tr.Append(alpha.Encode()...)
+	return tr.Challenge(group.Order())
+}
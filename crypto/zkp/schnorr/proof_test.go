@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package schnorr_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/bls12381"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	"github.com/binance-chain/tss-lib/crypto/ristretto"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	. "github.com/binance-chain/tss-lib/crypto/zkp/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestProveVerify(t *testing.T) {
+	groups := map[string]Group{
+		"secp256k1":  CurveGroup(tss.S256()),
+		"P-256":      CurveGroup(elliptic.P256()),
+		"stark":      CurveGroup(stark.Curve()),
+		"ed448":      CurveGroup(ed448.Curve()),
+		"ristretto":  ristretto.SchnorrGroup,
+		"bls12381G1": bls12381.SchnorrGroup,
+	}
+	for name, group := range groups {
+		x := common.GetRandomPositiveInt(group.Order())
+		X := group.ScalarBaseMult(x)
+
+		pf, err := Prove(group, NewTranscript(), x, X)
+		assert.NoError(t, err, name)
+		assert.True(t, pf.Verify(group, NewTranscript(), X), name)
+
+		// a proof for another element, or with a bad response, does not verify
+		assert.False(t, pf.Verify(group, NewTranscript(), group.ScalarBaseMult(big.NewInt(2))), name)
+		bad := &Proof{Alpha: pf.Alpha, T: new(big.Int).Add(pf.T, big.NewInt(1))}
+		assert.False(t, bad.Verify(group, NewTranscript(), X), name)
+	}
+}
+
+func TestTranscriptBinding(t *testing.T) {
+	group := CurveGroup(tss.S256())
+	x := common.GetRandomPositiveInt(group.Order())
+	X := group.ScalarBaseMult(x)
+
+	pf, err := Prove(group, NewTranscript([]byte("keygen"), []byte("session 1")), x, X)
+	assert.NoError(t, err)
+	assert.True(t, pf.Verify(group, NewTranscript([]byte("keygen"), []byte("session 1")), X))
+	assert.False(t, pf.Verify(group, NewTranscript([]byte("keygen"), []byte("session 2")), X))
+	assert.False(t, pf.Verify(group, NewTranscript([]byte("signing"), []byte("session 1")), X))
+	assert.False(t, pf.Verify(group, NewTranscript(), X))
+}
+
+func TestInTranscript(t *testing.T) {
+	group := CurveGroup(tss.S256())
+	x := common.GetRandomPositiveInt(group.Order())
+	X := group.ScalarBaseMult(x)
+
+	ctx := zkp.NewSessionTranscript("keygen", []byte("session 1"), 2)
+	pf, err := Prove(group, InTranscript(ctx), x, X)
+	assert.NoError(t, err)
+	// the context is not changed by a proof, so it can be used to verify it
+	assert.True(t, pf.Verify(group, InTranscript(ctx), X))
+	assert.True(t, pf.Verify(group, InTranscript(zkp.NewSessionTranscript("keygen", []byte("session 1"), 2)), X))
+	assert.False(t, pf.Verify(group, InTranscript(zkp.NewSessionTranscript("keygen", []byte("session 1"), 3)), X))
+	assert.False(t, pf.Verify(group, InTranscript(zkp.NewSessionTranscript("keygen", []byte("session 2"), 2)), X))
+	assert.False(t, pf.Verify(group, InTranscript(nil), X))
+	assert.False(t, pf.Verify(group, NewTranscript(), X))
+}
@@ -0,0 +1,56 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package schnorr
+
+import (
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+const (
+	// proofLabel is the label of the proofs of this package in a zkp.Transcript
+	proofLabel = "schnorr/dlog"
+)
+
+type (
+	// Transcript is the Fiat-Shamir transcript of a proof, which derives the challenge from the public values that have
+	// been appended to it. Prove and Verify append to the transcript, so each of them needs a transcript of its own.
+	Transcript interface {
+		// Append appends public values to the transcript
+		Append(parts ...[]byte)
+		// Challenge returns the challenge in [0, q) for the values appended so far
+		Challenge(q *big.Int) *big.Int
+	}
+
+	zkpTranscript struct {
+		tr *zkp.Transcript
+	}
+)
+
+// NewTranscript returns a Transcript with no context but the `prefix`, which binds the proofs to their context, e.g. a
+// domain tag and a session ID.
+func NewTranscript(prefix ...[]byte) Transcript {
+	tr := InTranscript(nil)
+	tr.Append(prefix...)
+	return tr
+}
+
+// InTranscript returns a Transcript for a proof in the context `ctx`, which is left as it is; see zkp.ForProof
+func InTranscript(ctx *zkp.Transcript) Transcript {
+	return &zkpTranscript{tr: zkp.ForProof(ctx, proofLabel)}
+}
+
+func (tr *zkpTranscript) Append(parts ...[]byte) {
+	for _, part := range parts {
+		tr.tr.AppendMessage("part", part)
+	}
+}
+
+func (tr *zkpTranscript) Challenge(q *big.Int) *big.Int {
+	return tr.tr.Challenge("challenge", q)
+}
@@ -0,0 +1,143 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package zkp holds the Fiat-Shamir Transcript that the challenges of all of the zero-knowledge proofs of this library
+// derive from. Its subpackages implement some of the proofs.
+package zkp
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/gtank/merlin"
+
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+const (
+	// transcriptDomain is the application label of the Merlin transcripts of this library
+	transcriptDomain = "tss-lib/zkp/v1"
+)
+
+type (
+	// Transcript is a Fiat-Shamir transcript on Merlin: the protocol name, its session ID and round, the commitments
+	// of the prover and the statement of a proof are appended to it with a label each, and a challenge depends on
+	// everything that was appended before it, including the challenges before it.
+	//
+	// A Merlin transcript cannot be copied, so a Transcript keeps its operations to replay them in Clone.
+	Transcript struct {
+		t   *merlin.Transcript
+		ops []transcriptOp
+	}
+
+	transcriptOp struct {
+		label     string
+		msg       []byte
+		challenge int // the length of a challenge, or 0 for an append
+	}
+)
+
+// NewTranscript returns a transcript for the protocol named `protocol`, e.g. "signing"
+func NewTranscript(protocol string) *Transcript {
+	tr := &Transcript{t: merlin.NewTranscript(transcriptDomain)}
+	tr.AppendMessage("protocol", []byte(protocol))
+	return tr
+}
+
+// NewSessionTranscript returns a transcript for round `round` of the session `sessionID` of `protocol`, so that a proof
+// made in it cannot be replayed in another session, round or protocol. The session ID may be empty.
+func NewSessionTranscript(protocol string, sessionID []byte, round int) *Transcript {
+	tr := NewTranscript(protocol)
+	tr.AppendMessage("session", sessionID)
+	tr.AppendInts("round", big.NewInt(int64(round)))
+	return tr
+}
+
+// ForProof returns the transcript of a proof labelled `proof` in the context `ctx`: a clone of `ctx` with the label
+// appended, so that a context can be used for many proofs. A nil `ctx` is a context with no protocol.
+func ForProof(ctx *Transcript, proof string) *Transcript {
+	var tr *Transcript
+	if ctx == nil {
+		tr = NewTranscript("")
+	} else {
+		tr = ctx.Clone()
+	}
+	tr.AppendMessage("proof", []byte(proof))
+	return tr
+}
+
+// Clone returns an independent copy of the transcript
+func (tr *Transcript) Clone() *Transcript {
+	c := &Transcript{t: merlin.NewTranscript(transcriptDomain), ops: make([]transcriptOp, 0, len(tr.ops))}
+	for _, op := range tr.ops {
+		if op.challenge == 0 {
+			c.AppendMessage(op.label, op.msg)
+		} else {
+			c.ChallengeBytes(op.label, op.challenge)
+		}
+	}
+	return c
+}
+
+// AppendMessage appends `msg` with the label `label`
+func (tr *Transcript) AppendMessage(label string, msg []byte) {
+	msg = append([]byte(nil), msg...)
+	tr.t.AppendMessage([]byte(label), msg)
+	tr.ops = append(tr.ops, transcriptOp{label: label, msg: msg})
+}
+
+// AppendInts appends the count of `ints` and then each of them, with its sign, with the label `label`. A nil int is
+// appended as distinct from 0.
+func (tr *Transcript) AppendInts(label string, ints ...*big.Int) {
+	count := make([]byte, 8)
+	binary.BigEndian.PutUint64(count, uint64(len(ints)))
+	tr.AppendMessage(label, count)
+	for _, n := range ints {
+		switch {
+		case n == nil:
+			tr.AppendMessage(label, nil)
+		case n.Sign() < 0:
+			tr.AppendMessage(label, append([]byte{1}, n.Bytes()...))
+		default:
+			tr.AppendMessage(label, append([]byte{0}, n.Bytes()...))
+		}
+	}
+}
+
+// AppendPoints appends the affine coordinates of `points` with the label `label`
+func (tr *Transcript) AppendPoints(label string, points ...*crypto.ECPoint) {
+	coords := make([]*big.Int, 0, 2*len(points))
+	for _, p := range points {
+		if p == nil {
+			coords = append(coords, nil, nil)
+			continue
+		}
+		coords = append(coords, p.X(), p.Y())
+	}
+	tr.AppendInts(label, coords...)
+}
+
+// ChallengeBytes returns `n` challenge bytes with the label `label`; n must be positive
+func (tr *Transcript) ChallengeBytes(label string, n int) []byte {
+	tr.ops = append(tr.ops, transcriptOp{label: label, challenge: n})
+	return tr.t.ExtractBytes([]byte(label), n)
+}
+
+// Challenge returns a uniform challenge in [0, q) with the label `label`. It takes BitLen(q) bits from the transcript
+// until they are below q, so for a q just above a power of 2 it takes 2 tries on average.
+func (tr *Transcript) Challenge(label string, q *big.Int) *big.Int {
+	bits := q.BitLen()
+	if bits == 0 {
+		return new(big.Int)
+	}
+	for {
+		bz := tr.ChallengeBytes(label, (bits+7)/8)
+		bz[0] &= byte(0xff >> uint(8*len(bz)-bits))
+		if c := new(big.Int).SetBytes(bz); c.Cmp(q) < 0 {
+			return c
+		}
+	}
+}
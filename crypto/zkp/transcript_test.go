@@ -0,0 +1,94 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package zkp_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	. "github.com/binance-chain/tss-lib/crypto/zkp"
+)
+
+func TestTranscriptDeterministic(t *testing.T) {
+	q := new(big.Int).SetBytes([]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01})
+	newTr := func() *Transcript {
+		tr := NewSessionTranscript("keygen", []byte("session"), 1)
+		tr.AppendInts("statement", big.NewInt(1), big.NewInt(-2), nil)
+		return tr
+	}
+	c1, c2 := newTr().Challenge("c", q), newTr().Challenge("c", q)
+	assert.Equal(t, 0, c1.Cmp(c2))
+	assert.True(t, c1.Sign() >= 0 && c1.Cmp(q) < 0)
+
+	// the next challenge depends on the ones before it
+	tr := newTr()
+	assert.NotEqual(t, tr.ChallengeBytes("c", 32), tr.ChallengeBytes("c", 32))
+	assert.Len(t, newTr().ChallengeBytes("c", 100), 100)
+}
+
+func TestTranscriptSeparation(t *testing.T) {
+	challenge := func(tr *Transcript) []byte {
+		return tr.ChallengeBytes("c", 32)
+	}
+	appended := func(label string, msgs ...[]byte) *Transcript {
+		tr := NewTranscript("protocol")
+		for _, msg := range msgs {
+			tr.AppendMessage(label, msg)
+		}
+		return tr
+	}
+	base := challenge(NewSessionTranscript("keygen", []byte("session"), 1))
+	assert.NotEqual(t, base, challenge(NewSessionTranscript("signing", []byte("session"), 1)))
+	assert.NotEqual(t, base, challenge(NewSessionTranscript("keygen", []byte("session 2"), 1)))
+	assert.NotEqual(t, base, challenge(NewSessionTranscript("keygen", []byte("session"), 2)))
+
+	// neither the boundaries between messages nor the labels can be moved
+	assert.NotEqual(t,
+		challenge(appended("m", []byte{1}, []byte{2, 3})),
+		challenge(appended("m", []byte{1, 2}, []byte{3})))
+	assert.NotEqual(t, challenge(appended("m", []byte{1})), challenge(appended("n", []byte{1})))
+	assert.NotEqual(t, challenge(appended("m", nil)), challenge(appended("m")))
+
+	ints := func(ns ...*big.Int) []byte {
+		tr := NewTranscript("protocol")
+		tr.AppendInts("n", ns...)
+		return challenge(tr)
+	}
+	assert.NotEqual(t, ints(big.NewInt(2)), ints(big.NewInt(-2)))
+	assert.NotEqual(t, ints(big.NewInt(0)), ints(nil))
+	assert.NotEqual(t, ints(big.NewInt(0x0102)), ints(big.NewInt(0x01), big.NewInt(0x02)))
+}
+
+func TestForProof(t *testing.T) {
+	ctx := NewSessionTranscript("signing", []byte("session"), 4)
+	before := ctx.Clone().ChallengeBytes("c", 32)
+
+	dlog := ForProof(ctx, "dlog")
+	dlog.AppendMessage("statement", []byte{1})
+	dlog.ChallengeBytes("c", 32)
+	// the context is left as it is
+	assert.Equal(t, before, ctx.Clone().ChallengeBytes("c", 32))
+
+	assert.NotEqual(t, ForProof(ctx, "dlog").ChallengeBytes("c", 32), ForProof(ctx, "dleq").ChallengeBytes("c", 32))
+	assert.NotEqual(t, ForProof(ctx, "dlog").ChallengeBytes("c", 32), ForProof(nil, "dlog").ChallengeBytes("c", 32))
+	assert.Equal(t, ForProof(nil, "dlog").ChallengeBytes("c", 32), ForProof(nil, "dlog").ChallengeBytes("c", 32))
+}
+
+// the golden vector of a transcript; it must never change, as the verifiers of other versions derive the same
+// challenges
+func TestTranscriptGolden(t *testing.T) {
+	tr := NewSessionTranscript("keygen", []byte("session"), 1)
+	tr.AppendInts("statement", big.NewInt(1), big.NewInt(-2), nil)
+	tr.AppendPoints("points", crypto.ScalarBaseMult(btcec.S256(), big.NewInt(1)))
+	assert.Equal(t, "855694fa89231e69ff649bf8c14af521e24a3371465a5350010837649355db9d",
+		hex.EncodeToString(tr.ChallengeBytes("c", 32)))
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+const (
+	// HardenedOffset is the first hardened child index, as in BIP32
+	HardenedOffset uint32 = 0x80000000
+
+	// ChainCodeLen is the length in bytes of a chain code
+	ChainCodeLen = 32
+)
+
+var (
+	prfBaseDomain = []byte("tss-lib ecdsa derivation prf base")
+)
+
+type (
+	// DerivedKey is the output of a hardened derivation
+	DerivedKey struct {
+		// the save data of the child key, with the shares of all the parties of the parent key
+		Key keygen.LocalPartySaveData
+		// the chain code of the child key, for deriving its own children
+		ChainCode []byte
+		// the tweak added to the parent key. Parties of the parent key that did not take part derive their child save
+		// data with ApplyTweak. Like a BIP32 chain code it must be kept among the holders of the key: together with a
+		// child private key it reveals the parent private key.
+		Tweak *big.Int
+	}
+)
+
+// ApplyTweak returns the save data of the key x + tweak, in which each share xi and public share Xj is offset by the
+// tweak. As the Lagrange coefficients of any t+1 parties sum to 1, the shares still interpolate to the child key.
+func ApplyTweak(key keygen.LocalPartySaveData, tweak *big.Int) (keygen.LocalPartySaveData, error) {
+	if key.Xi == nil || !key.ECDSAPub.ValidateBasic() || tweak == nil {
+		return key, errors.New("ApplyTweak() received invalid save data or a nil tweak")
+	}
+	ec := key.ECDSAPub.Curve()
+	tweakG := crypto.ScalarBaseMult(ec, tweak)
+	child := key
+	child.Xi = common.ModInt(ec.Params().N).Add(key.Xi, tweak)
+	child.BigXj = make([]*crypto.ECPoint, len(key.BigXj))
+	for j, Xj := range key.BigXj {
+		var err error
+		if child.BigXj[j], err = Xj.Add(tweakG); err != nil {
+			return key, err
+		}
+	}
+	var err error
+	if child.ECDSAPub, err = key.ECDSAPub.Add(tweakG); err != nil {
+		return key, err
+	}
+	return child, nil
+}
+
+// prfBase hashes the parent public key, chain code and child index to a point H whose discrete log nobody knows.
+// The threshold PRF of the key x at the index is then F = x*H, computed as the sum of the shares wi*H of t+1 parties.
+func prfBase(pub *crypto.ECPoint, chainCode []byte, index uint32) *crypto.ECPoint {
+	ec := pub.Curve()
+	P := ec.Params().P
+	indexBz := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBz, index)
+	x := new(big.Int).Mod(new(big.Int).SetBytes(common.SHA512_256Canonical(string(prfBaseDomain), pub.Bytes(), chainCode, indexBz)), P)
+	for {
+		if H, err := crypto.LiftX(ec, x, false); err == nil {
+			return H
+		}
+		x = new(big.Int).Mod(x.Add(x, big.NewInt(1)), P)
+	}
+}
+
+// childTweak returns the tweak and the chain code of the child from I = HMAC-SHA512(c_par, F || ser32(i)), like BIP32
+// CKDpriv with the PRF output F in place of the parent private key
+func childTweak(F *crypto.ECPoint, chainCode []byte, index uint32) (*big.Int, []byte, error) {
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(F.CompressedBytes())
+	indexBz := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBz, index)
+	mac.Write(indexBz)
+	I := mac.Sum(nil)
+	tweak := new(big.Int).SetBytes(I[:32])
+	if tweak.Sign() == 0 || tweak.Cmp(F.Curve().Params().N) >= 0 {
+		// BIP32 skips to the next index in this case, which happens with a negligible probability
+		return nil, nil, errors.New("the child key is invalid; proceed with the next index")
+	}
+	return tweak, I[32:], nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+
+	// 1. check each Fj against the public share Wj and sum them to F = x*H
+	F := round.temp.Fi
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.derivationRound1Messages[j].Content().(*DerivationRound1Message)
+		Fj, err := r1msg.UnmarshalPRFShare(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r1msg.UnmarshalProof(round.EC())
+		if err != nil || !proof.VerifyInTranscript(round.proofTranscript(1, Pj), round.temp.bigWs[j], Fj, round.temp.H) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if F, err = F.Add(Fj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "F.Add(Fj)"))
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the PRF share Fj"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. the tweak and chain code of the child
+	tweak, chainCode, err := childTweak(F, round.temp.chainCode, round.temp.index)
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// 3. the child save data with the shares of all the parties of the parent key
+	child, err := ApplyTweak(round.temp.fullKey, tweak)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "ApplyTweak"))
+	}
+	round.end <- DerivedKey{Key: child, ChainCode: chainCode, Tweak: tweak}
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
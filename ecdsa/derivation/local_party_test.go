@@ -0,0 +1,151 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	chainCode := common.SHA512_256([]byte("a chain code"))
+	index := HardenedOffset + 44
+
+	// two random sets of t+1 parties derive the same child
+	keys, pIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	derived := derive(t, keys, pIDs, index, chainCode)
+	keys2, pIDs2, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	derived2 := derive(t, keys2, pIDs2, index, chainCode)
+
+	child := derived[0]
+	for _, d := range append(derived, derived2...) {
+		assert.True(t, child.Key.ECDSAPub.Equals(d.Key.ECDSAPub), "all parties must derive the same child key")
+		assert.Equal(t, child.ChainCode, d.ChainCode, "all parties must derive the same chain code")
+		assert.Equal(t, 0, child.Tweak.Cmp(d.Tweak))
+	}
+	assert.Len(t, child.ChainCode, ChainCodeLen)
+	assert.NoError(t, child.Key.VerifyECDSAPub(testThreshold), "the child public shares must interpolate to the child key")
+	expected, err := keys[0].ECDSAPub.Add(crypto.ScalarBaseMult(tss.S256(), child.Tweak))
+	assert.NoError(t, err)
+	assert.True(t, expected.Equals(child.Key.ECDSAPub))
+
+	// the child shares of the signers interpolate to the child private key
+	sumW := big.NewInt(0)
+	modN := common.ModInt(tss.S256().Params().N)
+	for i, d := range derived {
+		subset := keygen.BuildLocalSaveDataSubset(d.Key, pIDs)
+		wi, _ := signing.PrepareForSigning(tss.S256(), i, len(pIDs), subset.Xi, subset.Ks, subset.BigXj)
+		sumW = modN.Add(sumW, wi)
+	}
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), sumW).Equals(child.Key.ECDSAPub), "the child shares must match the child key")
+
+	// a party that did not take part applies the tweak to its own save data
+	others, _, err := keygen.LoadKeygenTestFixtures(1)
+	assert.NoError(t, err)
+	otherChild, err := ApplyTweak(others[0], child.Tweak)
+	assert.NoError(t, err)
+	assert.True(t, otherChild.ECDSAPub.Equals(child.Key.ECDSAPub))
+
+	// another index gives another child
+	other := derive(t, keys, pIDs, index+1, chainCode)
+	assert.False(t, other[0].Key.ECDSAPub.Equals(child.Key.ECDSAPub))
+}
+
+func TestNonHardenedIndex(t *testing.T) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+	params, err := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), testThreshold)
+	assert.NoError(t, err)
+	P := NewLocalParty(44, common.SHA512_256([]byte("a chain code")), params, keys[0], make(chan tss.Message, 1), nil)
+	assert.Error(t, P.Start(context.Background()), "a non-hardened index must be rejected")
+}
+
+func derive(t *testing.T, keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs, index uint32, chainCode []byte) []DerivedKey {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan DerivedKey, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(index, chainCode, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	derived := make([]DerivedKey, len(pIDs))
+	var ended int
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case d := <-endCh:
+			for j, P := range parties {
+				if P.keys.ShareID.Cmp(d.Key.ShareID) == 0 {
+					derived[j] = d
+				}
+			}
+			if ended++; ended == len(pIDs) {
+				t.Logf("Done. Received the child key from %d participants", ended)
+				return derived
+			}
+		}
+	}
+}
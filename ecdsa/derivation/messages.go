@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-derivation.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that derivation messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*DerivationRound1Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*DerivationRound1Message)(nil), tss.ECDSAProtoNamePrefix+"derivation.DerivationRound1Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewDerivationRound1Message(
+	from *tss.PartyID,
+	Fi *crypto.ECPoint,
+	proof *schnorr.ZKDLEQProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &DerivationRound1Message{
+		PrfShareX: Fi.X().Bytes(),
+		PrfShareY: Fi.Y().Bytes(),
+		ProofA1X:  proof.A1.X().Bytes(),
+		ProofA1Y:  proof.A1.Y().Bytes(),
+		ProofA2X:  proof.A2.X().Bytes(),
+		ProofA2Y:  proof.A2.Y().Bytes(),
+		ProofZ:    proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *DerivationRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPrfShareX()) &&
+		common.NonEmptyBytes(m.GetPrfShareY()) &&
+		common.NonEmptyBytes(m.GetProofA1X()) &&
+		common.NonEmptyBytes(m.GetProofA1Y()) &&
+		common.NonEmptyBytes(m.GetProofA2X()) &&
+		common.NonEmptyBytes(m.GetProofA2Y()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *DerivationRound1Message) UnmarshalPRFShare(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetPrfShareX()),
+		new(big.Int).SetBytes(m.GetPrfShareY()))
+}
+
+func (m *DerivationRound1Message) UnmarshalProof(ec elliptic.Curve) (*schnorr.ZKDLEQProof, error) {
+	a1, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofA1X()),
+		new(big.Int).SetBytes(m.GetProofA1Y()))
+	if err != nil {
+		return nil, err
+	}
+	a2, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofA2X()),
+		new(big.Int).SetBytes(m.GetProofA2Y()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKDLEQProof{
+		A1: a1,
+		A2: a2,
+		Z:  new(big.Int).SetBytes(m.GetProofZ()),
+	}, nil
+}
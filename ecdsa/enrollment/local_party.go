@@ -0,0 +1,173 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty admits a single new party to the committee of an existing key, growing n by one without a full reshare.
+	// The first t+1 existing parties (the "helpers") each send the new party a masked Lagrange term of their share, so
+	// that the new party learns its share x_new = f(k_new) but nothing else about the helpers' shares.
+	// The threshold, the ECDSA public key and the shares of the existing parties are unchanged.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		enRound1Message1s,
+		enRound1Message2s,
+		enRound2Message1s,
+		enRound2Message2s []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after enrollment)
+		newIdx      int
+		existingIDs tss.SortedPartyIDs
+		isHelper    []bool
+		wi,
+		masksSent *big.Int
+	}
+)
+
+// Exported, used in `tss` client
+// `params` must list all of the parties of the key plus the new party, `newPartyID`.
+// Existing parties pass their save data in `key`. The new party may pass its pre-params in `key.LocalPreParams`,
+// otherwise they are generated during the protocol.
+func NewLocalParty(
+	params *tss.Parameters,
+	newPartyID *tss.PartyID,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     key,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.enRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.enRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.enRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.enRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.newIdx = -1
+	p.temp.existingIDs = make(tss.SortedPartyIDs, 0, partyCount)
+	for j, Pj := range params.Parties().IDs() {
+		if Pj.KeyInt().Cmp(newPartyID.KeyInt()) == 0 {
+			p.temp.newIdx = j
+			continue
+		}
+		p.temp.existingIDs = append(p.temp.existingIDs, Pj)
+	}
+	p.temp.isHelper = make([]bool, partyCount)
+	for e, Pj := range p.temp.existingIDs {
+		if e > params.Threshold() {
+			break
+		}
+		p.temp.isHelper[Pj.Index] = true
+	}
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start(ctx context.Context) *tss.Error {
+	return tss.BaseStart(ctx, p, TaskName)
+}
+
+func (p *LocalParty) Update(ctx context.Context, msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(ctx, p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(ctx context.Context, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := p.params.ParseReceived(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(ctx, msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	if err := p.params.ValidateSessionID(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	if err := p.params.ValidateSender(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store the messages of each round; tss.BaseUpdate holds those of later rounds until then
+	// replays are dropped by tss.BaseUpdate. we expect the caller to apply spoofing protection, e.g. with tss.Parameters.OpenMessage.
+	switch msg.Content().(type) {
+	case *ENRound1Message1:
+		p.temp.enRound1Message1s[fromPIdx] = msg
+	case *ENRound1Message2:
+		p.temp.enRound1Message2s[fromPIdx] = msg
+	case *ENRound2Message1:
+		p.temp.enRound2Message1s[fromPIdx] = msg
+	case *ENRound2Message2:
+		p.temp.enRound2Message2s[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		p.params.Logger().Warn("unrecognised message ignored", "msg", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
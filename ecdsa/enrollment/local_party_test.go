@@ -0,0 +1,116 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	. "github.com/binance-chain/tss-lib/ecdsa/enrollment"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// the last fixture party joins a committee made of the others; its share must come out the same as in the fixture
+	newIdx := len(pIDs) - 1
+	newPID := pIDs[newIdx]
+	existingPIDs := pIDs[:newIdx]
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		var key keygen.LocalPartySaveData
+		if i == newIdx {
+			key.LocalPreParams = keys[i].LocalPreParams
+		} else {
+			key = keygen.BuildLocalSaveDataSubset(keys[i], existingPIDs)
+		}
+		P := NewLocalParty(params, newPID, key, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				for _, to := range dest {
+					go updater(parties[to.Index], msg, errCh)
+				}
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			ended++
+		}
+	}
+
+	assert.Equal(t, 0, newKeys[newIdx].Xi.Cmp(keys[newIdx].Xi), "the new share must be f(k_new)")
+	for i, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(keys[i].ECDSAPub), "the public key must not change")
+		assert.Equal(t, 0, key.Xi.Cmp(keys[i].Xi), "the existing shares must not change")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), key.Xi).Equals(key.BigXj[i]), "X_i must match x_i")
+		assert.Len(t, key.Ks, len(pIDs))
+		for j := range key.BigXj {
+			assert.Equal(t, 0, key.Ks[j].Cmp(keys[i].Ks[j]))
+			assert.True(t, key.BigXj[j].Equals(keys[i].BigXj[j]), "all parties must agree on X_j")
+			assert.Equal(t, 0, key.NTildej[j].Cmp(keys[i].NTildej[j]))
+			assert.Equal(t, 0, key.PaillierPKs[j].N.Cmp(keys[i].PaillierPKs[j].N))
+		}
+	}
+}
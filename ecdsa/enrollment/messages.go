@@ -0,0 +1,266 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-enrollment.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that enrollment messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*ENRound1Message1)(nil),
+		(*ENRound1Message2)(nil),
+		(*ENRound2Message1)(nil),
+		(*ENRound2Message2)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*ENRound1Message1)(nil), tss.ECDSAProtoNamePrefix+"enrollment.ENRound1Message1")
+	proto.RegisterType((*ENRound1Message2)(nil), tss.ECDSAProtoNamePrefix+"enrollment.ENRound1Message2")
+	proto.RegisterType((*ENRound2Message1)(nil), tss.ECDSAProtoNamePrefix+"enrollment.ENRound2Message1")
+	proto.RegisterType((*ENRound2Message2)(nil), tss.ECDSAProtoNamePrefix+"enrollment.ENRound2Message2")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewENRound1Message1(
+	to, from *tss.PartyID,
+	mask *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &ENRound1Message1{
+		Mask: mask.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *ENRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetMask())
+}
+
+func (m *ENRound1Message1) UnmarshalMask() *big.Int {
+	return new(big.Int).SetBytes(m.GetMask())
+}
+
+// ----- //
+
+// NewENRound1Message2 sends the public data of the key to the joining party.
+// The arrays in `key` must be ordered like the existing parties.
+func NewENRound1Message2(
+	to, from *tss.PartyID,
+	key *keygen.LocalPartySaveData,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	bigXjBzs, err := crypto.CompressECPoints(key.BigXj)
+	if err != nil {
+		return nil, err
+	}
+	paillierNs := make([]*big.Int, len(key.PaillierPKs))
+	for j, pk := range key.PaillierPKs {
+		if pk == nil {
+			return nil, errors.New("NewENRound1Message2() received a nil Paillier public key")
+		}
+		paillierNs[j] = pk.N
+	}
+	content := &ENRound1Message2{
+		EcdsaPubX:  key.ECDSAPub.X().Bytes(),
+		EcdsaPubY:  key.ECDSAPub.Y().Bytes(),
+		Ks:         common.BigIntsToBytes(key.Ks),
+		BigXj:      bigXjBzs,
+		NTildej:    common.BigIntsToBytes(key.NTildej),
+		H1J:        common.BigIntsToBytes(key.H1j),
+		H2J:        common.BigIntsToBytes(key.H2j),
+		PaillierNs: common.BigIntsToBytes(paillierNs),
+		Epoch:      key.Epoch,
+	}
+	if !key.RefreshedAt.IsZero() {
+		content.RefreshedAt = key.RefreshedAt.UnixNano()
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *ENRound1Message2) ValidateBasic() bool {
+	if m == nil {
+		return false
+	}
+	n := len(m.GetKs())
+	return common.NonEmptyBytes(m.GetEcdsaPubX()) &&
+		common.NonEmptyBytes(m.GetEcdsaPubY()) &&
+		common.NonEmptyMultiBytes(m.GetKs(), n) &&
+		common.NonEmptyMultiBytes(m.GetBigXj(), n) &&
+		common.NonEmptyMultiBytes(m.GetNTildej(), n) &&
+		common.NonEmptyMultiBytes(m.GetH1J(), n) &&
+		common.NonEmptyMultiBytes(m.GetH2J(), n) &&
+		common.NonEmptyMultiBytes(m.GetPaillierNs(), n)
+}
+
+// UnmarshalSaveData returns the public data of the key as a LocalPartySaveData, without any secrets
+func (m *ENRound1Message2) UnmarshalSaveData(ec elliptic.Curve) (keygen.LocalPartySaveData, error) {
+	n := len(m.GetKs())
+	save := keygen.NewLocalPartySaveData(n)
+	ecdsaPub, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetEcdsaPubX()),
+		new(big.Int).SetBytes(m.GetEcdsaPubY()))
+	if err != nil {
+		return save, err
+	}
+	bigXj, err := crypto.DecompressECPoints(ec, m.GetBigXj())
+	if err != nil {
+		return save, err
+	}
+	save.ECDSAPub = ecdsaPub
+	save.Ks = common.MultiBytesToBigInts(m.GetKs())
+	save.BigXj = bigXj
+	save.NTildej = common.MultiBytesToBigInts(m.GetNTildej())
+	save.H1j = common.MultiBytesToBigInts(m.GetH1J())
+	save.H2j = common.MultiBytesToBigInts(m.GetH2J())
+	for j, N := range common.MultiBytesToBigInts(m.GetPaillierNs()) {
+		save.PaillierPKs[j] = &paillier.PublicKey{N: N}
+	}
+	save.Epoch = m.GetEpoch()
+	if m.GetRefreshedAt() != 0 {
+		save.RefreshedAt = time.Unix(0, m.GetRefreshedAt())
+	}
+	return save, nil
+}
+
+// ----- //
+
+func NewENRound2Message1(
+	to, from *tss.PartyID,
+	share *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &ENRound2Message1{
+		Share: share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *ENRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *ENRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetShare())
+}
+
+// ----- //
+
+func NewENRound2Message2(
+	to []*tss.PartyID,
+	from *tss.PartyID,
+	paillierPK *paillier.PublicKey,
+	paillierPf paillier.Proof,
+	NTildei, H1i, H2i *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: true,
+	}
+	paiPfBzs := common.BigIntsToBytes(paillierPf[:])
+	dlnProof1Bz, err := dlnProof1.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	content := &ENRound2Message2{
+		PaillierN:     paillierPK.N.Bytes(),
+		PaillierProof: paiPfBzs,
+		NTilde:        NTildei.Bytes(),
+		H1:            H1i.Bytes(),
+		H2:            H2i.Bytes(),
+		Dlnproof_1:    dlnProof1Bz,
+		Dlnproof_2:    dlnProof2Bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *ENRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters) &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+func (m *ENRound2Message2) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *ENRound2Message2) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *ENRound2Message2) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *ENRound2Message2) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *ENRound2Message2) UnmarshalPaillierProof() paillier.Proof {
+	var pf paillier.Proof
+	ints := common.MultiBytesToBigInts(m.GetPaillierProof())
+	copy(pf[:], ints[:paillier.ProofIters])
+	return pf
+}
+
+func (m *ENRound2Message2) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *ENRound2Message2) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
@@ -0,0 +1,144 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the enrollment protocol: the helpers exchange masks with each other and send the
+// public data of the key to the new party
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	if round.temp.newIdx < 0 {
+		return round.WrapError(errors.New("the new party was not found in the parties of the enrollment")).WithCode(tss.CodeBadInput)
+	}
+	if len(round.temp.existingIDs) <= round.Threshold() {
+		return round.WrapError(fmt.Errorf("at least %d existing parties must take part in the enrollment", round.Threshold()+1)).WithCode(tss.CodeBadInput)
+	}
+
+	// the new party only receives in this round
+	if round.isNewParty() {
+		*round.save = keygen.NewLocalPartySaveData(round.PartyCount())
+		round.save.LocalPreParams = round.input.LocalPreParams
+		for j := range round.ok {
+			round.ok[j] = !round.temp.isHelper[j]
+		}
+		return nil
+	}
+
+	// every party of the key must take part, as all of them must learn about the new party
+	ks, ids := round.input.Ks, round.temp.existingIDs
+	if round.input.Xi == nil || len(ks) != len(ids) {
+		return round.WrapError(fmt.Errorf("all %d parties of the key must take part in the enrollment", len(ks))).WithCode(tss.CodeBadInput)
+	}
+	*round.input = keygen.BuildLocalSaveDataSubset(*round.input, ids)
+	for e, Pj := range ids {
+		if round.input.Ks[e].Cmp(Pj.KeyInt()) != 0 {
+			return round.WrapError(errors.New("a party was not found in the save data of the key"), Pj).WithCode(tss.CodeBadInput)
+		}
+	}
+
+	if !round.temp.isHelper[i] {
+		for j := range round.ok {
+			round.ok[j] = true
+		}
+		return nil
+	}
+
+	// 1. w_i = lambda_i * x_i, where lambda_i interpolates the helpers' shares at k_new
+	helpers := round.helperIDs()
+	helperKs := make([]*big.Int, len(helpers))
+	ei := 0
+	for e, Pj := range helpers {
+		helperKs[e] = Pj.KeyInt()
+		if Pj.Index == i {
+			ei = e
+		}
+	}
+	modQ := common.ModInt(round.EC().Params().N)
+	newK := round.Parties().IDs()[round.temp.newIdx].KeyInt()
+	round.temp.wi = modQ.Mul(round.lagrangeAt(newK, helperKs, ei), round.input.Xi)
+
+	// 2. send a random mask to each of the other helpers
+	round.temp.masksSent = big.NewInt(0)
+	for _, Pj := range helpers {
+		if Pj.Index == i {
+			continue
+		}
+		mask := common.GetRandomPositiveInt(round.EC().Params().N)
+		round.temp.masksSent = modQ.Add(round.temp.masksSent, mask)
+		r1msg1 := NewENRound1Message1(Pj, Pi, mask)
+		round.out <- round.WithSessionID(r1msg1)
+	}
+
+	// 3. send the public data of the key to the new party
+	r1msg2, err := NewENRound1Message2(round.Parties().IDs()[round.temp.newIdx], Pi, round.input)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.out <- round.WithSessionID(r1msg2)
+
+	for j := range round.ok {
+		round.ok[j] = !round.temp.isHelper[j] || j == i
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*ENRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*ENRound1Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	msgs := round.temp.enRound1Message1s
+	if round.isNewParty() {
+		msgs = round.temp.enRound1Message2s
+	}
+	for j, msg := range msgs {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
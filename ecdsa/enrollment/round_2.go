@@ -0,0 +1,154 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	Ps := round.Parties().IDs()
+	newIdx := round.temp.newIdx
+
+	if round.isNewParty() {
+		return round.startNewParty()
+	}
+
+	// existing parties wait for the Paillier key and NTilde of the new party
+	for j := range round.ok {
+		round.ok[j] = j != newIdx
+	}
+	if !round.temp.isHelper[i] {
+		return nil
+	}
+
+	// 1. s_i = w_i + sum_j(mask_ji) - sum_j(mask_ij); the masks cancel out in the sum of the s_i
+	modQ := common.ModInt(round.EC().Params().N)
+	si := modQ.Sub(round.temp.wi, round.temp.masksSent)
+	for _, Pj := range round.helperIDs() {
+		if Pj.Index == i {
+			continue
+		}
+		r1msg1 := round.temp.enRound1Message1s[Pj.Index].Content().(*ENRound1Message1)
+		si = modQ.Add(si, r1msg1.UnmarshalMask())
+	}
+
+	// 2. send s_i to the new party
+	r2msg1 := NewENRound2Message1(Ps[newIdx], Pi, si)
+	round.out <- round.WithSessionID(r2msg1)
+	return nil
+}
+
+// startNewParty checks the public data that the helpers sent and broadcasts the Paillier key and NTilde of the new party
+func (round *round2) startNewParty() *tss.Error {
+	Pi := round.PartyID()
+	helpers := round.helperIDs()
+
+	// 1. every helper must have sent the same public data
+	r1msg2 := round.temp.enRound1Message2s[helpers[0].Index].Content().(*ENRound1Message2)
+	for _, Pj := range helpers[1:] {
+		if !proto.Equal(r1msg2, round.temp.enRound1Message2s[Pj.Index].Content().(*ENRound1Message2)) {
+			return round.WrapError(errors.New("the helpers sent inconsistent public data for the key"), helpers...)
+		}
+	}
+	pub, err := r1msg2.UnmarshalSaveData(round.EC())
+	if err != nil {
+		return round.WrapError(err, helpers...)
+	}
+	if len(pub.Ks) != len(round.temp.existingIDs) {
+		return round.WrapError(errors.New("the public data of the key does not match the parties of the enrollment"), helpers...)
+	}
+	for e, Pj := range round.temp.existingIDs {
+		if pub.Ks[e].Cmp(Pj.KeyInt()) != 0 {
+			return round.WrapError(errors.New("the public data of the key does not match the parties of the enrollment"), helpers...)
+		}
+	}
+	*round.input = pub
+
+	// 2. use the pre-params if they were provided to the LocalParty constructor
+	var preParams *keygen.LocalPreParams
+	if round.save.LocalPreParams.Validate() && !round.save.LocalPreParams.ValidateWithProof() {
+		return round.WrapError(
+			errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+	} else if round.save.LocalPreParams.ValidateWithProof() {
+		preParams = &round.save.LocalPreParams
+	} else {
+		preParams, err = keygen.GeneratePreParams(round.SafePrimeGenTimeout())
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+	}
+	round.save.LocalPreParams = *preParams
+
+	// 3. BROADCAST the Paillier key and NTilde with their proofs to the existing parties
+	dlnProof1 := dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei)
+	dlnProof2 := dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei)
+	paillierPf := preParams.PaillierSK.Proof(Pi.KeyInt(), pub.ECDSAPub)
+	r2msg2, err := NewENRound2Message2(
+		round.temp.existingIDs, Pi,
+		&preParams.PaillierSK.PublicKey, paillierPf, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.enRound2Message2s[Pi.Index] = r2msg2
+	round.out <- round.WithSessionID(r2msg2)
+
+	// the new party waits for the shares of the helpers
+	for j := range round.ok {
+		round.ok[j] = !round.temp.isHelper[j]
+	}
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*ENRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*ENRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	msgs := round.temp.enRound2Message2s
+	if round.isNewParty() {
+		msgs = round.temp.enRound2Message1s
+	}
+	for j, msg := range msgs {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
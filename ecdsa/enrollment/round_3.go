@@ -0,0 +1,155 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	for j := range round.ok {
+		round.ok[j] = true // no messages are received in this round
+	}
+
+	Pi := round.PartyID()
+	Ps := round.Parties().IDs()
+	newIdx := round.temp.newIdx
+	helpers := round.helperIDs()
+
+	// 1. X_new = sum_i(lambda_i * X_i) over the helpers; every party can compute this from the public data
+	helperKs := make([]*big.Int, len(helpers))
+	for e := range helpers {
+		helperKs[e] = round.input.Ks[e]
+	}
+	newK := Ps[newIdx].KeyInt()
+	var newBigX *crypto.ECPoint
+	for e := range helpers {
+		term := round.input.BigXj[e].ScalarMult(round.lagrangeAt(newK, helperKs, e))
+		if newBigX == nil {
+			newBigX = term
+			continue
+		}
+		var err error
+		if newBigX, err = newBigX.Add(term); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "newBigX.Add(term)"))
+		}
+	}
+
+	// 2. the new party combines the helpers' terms into its share and checks it against X_new
+	if round.isNewParty() {
+		modQ := common.ModInt(round.EC().Params().N)
+		xi := big.NewInt(0)
+		for _, Pj := range helpers {
+			r2msg1 := round.temp.enRound2Message1s[Pj.Index].Content().(*ENRound2Message1)
+			xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+		}
+		if !crypto.ScalarBaseMult(round.EC(), xi).Equals(newBigX) {
+			return round.WrapError(errors.New("assertion failed: g^x_new != X_new"), helpers...)
+		}
+		preParams := round.save.LocalPreParams
+		*round.save = round.expandSaveData(newBigX, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i)
+		round.save.LocalPreParams = preParams
+		round.save.Xi = xi
+		round.save.ShareID = Pi.KeyInt()
+		round.end <- *round.save
+		return nil
+	}
+
+	// 3. the existing parties verify the Paillier key and NTilde of the new party
+	r2msg2 := round.temp.enRound2Message2s[newIdx].Content().(*ENRound2Message2)
+	paiPK, NTildej, H1j, H2j :=
+		r2msg2.UnmarshalPaillierPK(),
+		r2msg2.UnmarshalNTilde(),
+		r2msg2.UnmarshalH1(),
+		r2msg2.UnmarshalH2()
+	if H1j.Cmp(H2j) == 0 {
+		return round.WrapError(errors.New("h1j and h2j were equal for this party"), Ps[newIdx]).WithCode(tss.CodeInvalidMessage)
+	}
+	nTildeJHex, paiNJHex := hex.EncodeToString(NTildej.Bytes()), hex.EncodeToString(paiPK.N.Bytes())
+	for e := range round.temp.existingIDs {
+		if hex.EncodeToString(round.input.NTildej[e].Bytes()) == nTildeJHex ||
+			hex.EncodeToString(round.input.PaillierPKs[e].N.Bytes()) == paiNJHex {
+			return round.WrapError(errors.New("this Paillier key or NTilde was already used by another party"), Ps[newIdx]).WithCode(tss.CodeInvalidMessage)
+		}
+	}
+	if ok, err := r2msg2.UnmarshalPaillierProof().Verify(paiPK.N, Ps[newIdx].KeyInt(), round.input.ECDSAPub); err != nil || !ok {
+		return round.WrapError(errors.New("paillier verify failed"), Ps[newIdx]).WithCode(tss.CodeInvalidProof)
+	}
+	if dlnProof1, err := r2msg2.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+		return round.WrapError(errors.New("dln proof 1 verify failed"), Ps[newIdx]).WithCode(tss.CodeInvalidProof)
+	}
+	if dlnProof2, err := r2msg2.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+		return round.WrapError(errors.New("dln proof 2 verify failed"), Ps[newIdx]).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 4. SAVE the key with the new party added; the existing shares are unchanged
+	*round.save = round.expandSaveData(newBigX, paiPK, NTildej, H1j, H2j)
+	round.save.LocalPreParams = round.input.LocalPreParams
+	round.save.LocalSecrets = round.input.LocalSecrets
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// expandSaveData returns the public data of the key with the entries of the new party inserted at its index
+func (round *round3) expandSaveData(newBigX *crypto.ECPoint, paiPK *paillier.PublicKey, NTilde, H1, H2 *big.Int) keygen.LocalPartySaveData {
+	Ps := round.Parties().IDs()
+	newIdx := round.temp.newIdx
+	save := keygen.NewLocalPartySaveData(len(Ps))
+	save.ECDSAPub = round.input.ECDSAPub
+	save.CurveName = round.CurveName()
+	save.Epoch, save.RefreshedAt = round.input.Epoch, round.input.RefreshedAt
+	for j, Pj := range Ps {
+		if j == newIdx {
+			save.Ks[j] = Pj.KeyInt()
+			save.BigXj[j] = newBigX
+			save.PaillierPKs[j] = paiPK
+			save.NTildej[j], save.H1j[j], save.H2j[j] = NTilde, H1, H2
+			continue
+		}
+		e := j
+		if j > newIdx {
+			e = j - 1
+		}
+		save.Ks[j] = round.input.Ks[e]
+		save.BigXj[j] = round.input.BigXj[e]
+		save.PaillierPKs[j] = round.input.PaillierPKs[e]
+		save.NTildej[j], save.H1j[j], save.H2j[j] = round.input.NTildej[e], round.input.H1j[e], round.input.H2j[e]
+	}
+	return save
+}
@@ -153,7 +153,11 @@ func (m *KGRound2Message1) GetShare() []byte {
 //
 // Represents a BROADCAST message sent to each party during Round 2 of the ECDSA TSS keygen protocol.
 type KGRound2Message2 struct {
-	DeCommitment         [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+	// PVSS only: the share of each party encrypted to its Paillier key, and the PDL proofs that they match the
+	// commitments, 8 parts each
+	EncryptedShares      [][]byte `protobuf:"bytes,2,rep,name=encrypted_shares,json=encryptedShares,proto3" json:"encrypted_shares,omitempty"`
+	ShareProofs          [][]byte `protobuf:"bytes,3,rep,name=share_proofs,json=shareProofs,proto3" json:"share_proofs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -191,6 +195,20 @@ func (m *KGRound2Message2) GetDeCommitment() [][]byte {
 	return nil
 }
 
+func (m *KGRound2Message2) GetEncryptedShares() [][]byte {
+	if m != nil {
+		return m.EncryptedShares
+	}
+	return nil
+}
+
+func (m *KGRound2Message2) GetShareProofs() [][]byte {
+	if m != nil {
+		return m.ShareProofs
+	}
+	return nil
+}
+
 //
 // Represents a BROADCAST message sent to each party during Round 3 of the ECDSA TSS keygen protocol.
 type KGRound3Message struct {
@@ -242,22 +260,24 @@ func init() {
 func init() { proto.RegisterFile("protob/ecdsa-keygen.proto", fileDescriptor_1a2e19e981cdbb01) }
 
 var fileDescriptor_1a2e19e981cdbb01 = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x41, 0x4b, 0xc3, 0x40,
-	0x10, 0x85, 0x49, 0x6a, 0x53, 0x1c, 0xd2, 0x28, 0x8b, 0xe0, 0x7a, 0x50, 0x4a, 0x44, 0xe8, 0x45,
-	0xcb, 0x6e, 0x0f, 0x7a, 0xd6, 0x83, 0x07, 0x51, 0xa4, 0x78, 0xf2, 0x12, 0xd2, 0xee, 0xd8, 0x04,
-	0x93, 0xdd, 0x90, 0xc4, 0x83, 0xbf, 0xd0, 0xbf, 0x25, 0x3b, 0xd9, 0x04, 0x83, 0xc7, 0xf7, 0xbd,
-	0x64, 0xd8, 0xf7, 0xc1, 0x59, 0x55, 0x9b, 0xd6, 0x6c, 0x57, 0xb8, 0x53, 0x4d, 0x7a, 0xfd, 0x89,
-	0xdf, 0x7b, 0xd4, 0x37, 0xc4, 0xe2, 0x1f, 0x0f, 0x8e, 0x9e, 0x1e, 0x37, 0xe6, 0x4b, 0x2b, 0xf1,
-	0x8c, 0x4d, 0x93, 0xee, 0x91, 0x5d, 0x00, 0xec, 0x4c, 0x59, 0xe6, 0x6d, 0x89, 0xba, 0xe5, 0xde,
-	0xc2, 0x5b, 0x86, 0x9b, 0x3f, 0x84, 0x9d, 0x03, 0x54, 0x69, 0x5e, 0x14, 0x39, 0xd6, 0x89, 0xe6,
-	0x3e, 0xf5, 0x87, 0x3d, 0x79, 0x61, 0xa7, 0x30, 0xd3, 0x49, 0x9b, 0x17, 0x0a, 0xf9, 0x84, 0xba,
-	0x40, 0xbf, 0xd9, 0xc4, 0x22, 0xf0, 0x33, 0xc1, 0x0f, 0x88, 0xf9, 0x99, 0xa0, 0x2c, 0xf9, 0xd4,
-	0x65, 0x69, 0xef, 0xaa, 0x42, 0x57, 0xb5, 0x31, 0x1f, 0x89, 0xe0, 0xc1, 0x62, 0x62, 0xef, 0xf6,
-	0x44, 0x8c, 0x6a, 0xc9, 0x67, 0xe3, 0x5a, 0xc6, 0x4b, 0x38, 0x76, 0x43, 0xa4, 0x1b, 0x22, 0xd8,
-	0x09, 0x4c, 0x9b, 0x2c, 0xad, 0xd1, 0x8d, 0xe8, 0x42, 0x7c, 0xfb, 0xef, 0x4b, 0xc9, 0x2e, 0x61,
-	0xae, 0x30, 0x19, 0xcd, 0xb6, 0xf7, 0x43, 0x85, 0x0f, 0x03, 0x8b, 0xef, 0x06, 0x57, 0xeb, 0xde,
-	0xd5, 0x15, 0x44, 0x83, 0x0b, 0x7a, 0x88, 0xfb, 0x71, 0xde, 0xd3, 0x57, 0x0b, 0xef, 0xa3, 0xf7,
-	0x90, 0xe4, 0xaf, 0x3a, 0xf9, 0xdb, 0x80, 0xec, 0xaf, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xe9,
-	0x13, 0x63, 0x79, 0x9a, 0x01, 0x00, 0x00,
+	// 299 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xbf, 0x4e, 0xc3, 0x30,
+	0x10, 0xc6, 0x95, 0x94, 0xb6, 0xe2, 0x48, 0xff, 0xc8, 0x42, 0xc2, 0x0c, 0xa0, 0x12, 0x84, 0x54,
+	0x06, 0xa8, 0xec, 0x2e, 0xcc, 0x30, 0x30, 0x20, 0x10, 0x2a, 0x4c, 0x2c, 0x56, 0x5a, 0x1f, 0x6d,
+	0x44, 0x62, 0x47, 0x71, 0x18, 0xfa, 0x00, 0x3c, 0x1b, 0xaf, 0x85, 0x72, 0x4d, 0x02, 0x11, 0xe3,
+	0xfd, 0xbe, 0xcf, 0xe7, 0xfb, 0xee, 0xe0, 0x38, 0xcb, 0x6d, 0x61, 0x97, 0x33, 0x5c, 0x69, 0x17,
+	0x5d, 0x7d, 0xe0, 0x76, 0x8d, 0xe6, 0x9a, 0x58, 0xf8, 0xed, 0xc1, 0xe8, 0xe1, 0x7e, 0x61, 0x3f,
+	0x8d, 0x16, 0x8f, 0xe8, 0x5c, 0xb4, 0x46, 0x76, 0x0a, 0xb0, 0xb2, 0x69, 0x1a, 0x17, 0x29, 0x9a,
+	0x82, 0x7b, 0x13, 0x6f, 0x1a, 0x2c, 0xfe, 0x10, 0x76, 0x02, 0x90, 0x45, 0x71, 0x92, 0xc4, 0x98,
+	0x2b, 0xc3, 0x7d, 0xd2, 0xf7, 0x6b, 0xf2, 0xc4, 0x8e, 0xa0, 0x6f, 0x54, 0x11, 0x27, 0x1a, 0x79,
+	0x87, 0xb4, 0x9e, 0x79, 0x2d, 0x2b, 0x36, 0x04, 0x7f, 0x23, 0xf8, 0x1e, 0x31, 0x7f, 0x23, 0xa8,
+	0x96, 0xbc, 0x5b, 0xd5, 0xb2, 0xec, 0xab, 0x13, 0x93, 0xe5, 0xd6, 0xbe, 0x2b, 0xc1, 0x7b, 0x93,
+	0x4e, 0xd9, 0xb7, 0x26, 0xa2, 0x25, 0x4b, 0xde, 0x6f, 0xcb, 0x32, 0x9c, 0xc2, 0xb8, 0x0a, 0x22,
+	0xab, 0x20, 0x82, 0x1d, 0x42, 0xd7, 0x6d, 0xa2, 0x1c, 0xab, 0x10, 0xbb, 0x22, 0xfc, 0xf2, 0xfe,
+	0x59, 0x25, 0x3b, 0x87, 0x81, 0x46, 0xd5, 0xca, 0x5d, 0x7e, 0x10, 0x68, 0xbc, 0xfb, 0x4d, 0x7e,
+	0x09, 0x63, 0x34, 0xab, 0x7c, 0x9b, 0x15, 0xa8, 0x15, 0x35, 0x73, 0xdc, 0x27, 0xdf, 0xa8, 0xe1,
+	0x2f, 0x84, 0xd9, 0x19, 0x04, 0x64, 0x50, 0x34, 0x9e, 0xe3, 0x1d, 0xb2, 0x1d, 0x10, 0x7b, 0x26,
+	0x14, 0xde, 0x34, 0xab, 0x9f, 0xd7, 0xab, 0xbf, 0x80, 0x61, 0xb3, 0x5a, 0x7a, 0x58, 0x8d, 0x31,
+	0xa8, 0x29, 0x3d, 0xbd, 0x1d, 0xbe, 0x05, 0x74, 0xcb, 0xd9, 0xee, 0x96, 0xcb, 0x1e, 0x1d, 0x73,
+	0xfe, 0x33, 0x00, 0x97, 0xda, 0x90, 0xcf, 0xe9, 0x01, 0x00, 0x00,
 }
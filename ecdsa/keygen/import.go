@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	// the time allowed to generate the pre-params of each party when importing a key
+	importSafePrimeGenTimeout = 5 * time.Minute
+)
+
+// ImportFromPrivateKey is a trusted dealer utility that splits an existing ECDSA private key `sk` on the curve `ec` into
+// threshold shares for the `partyIDs`, to migrate a pre-existing single-key wallet into the threshold scheme.
+// It returns the complete LocalPartySaveData of every party, in the order of `partyIDs`.
+//
+// The dealer sees every share: run it on an air-gapped machine, distribute each save data over a secure channel and
+// destroy `sk` and the other parties' save data afterwards. A refresh of the shares once they are distributed limits the
+// damage of a dealer that was compromised after the import.
+//
+// The Paillier keys and NTilde, h1, h2 of each party are generated, which can take minutes per party, unless pre-params
+// are given in `optionalPreParams`, one per party in the order of `partyIDs`.
+func ImportFromPrivateKey(ec elliptic.Curve, sk *big.Int, threshold int, partyIDs tss.SortedPartyIDs, optionalPreParams ...LocalPreParams) ([]LocalPartySaveData, error) {
+	curveName, ok := tss.GetCurveName(ec)
+	if !ok {
+		return nil, errors.New("ImportFromPrivateKey: the curve is not registered, see tss.RegisterCurve")
+	}
+	q := ec.Params().N
+	if sk == nil || sk.Sign() <= 0 || q.BitLen() < sk.BitLen() || common.ConstantTimeCmp(sk, q, (q.BitLen()+7)/8) >= 0 {
+		return nil, errors.New("ImportFromPrivateKey: the private key must be in the range [1, q-1]")
+	}
+	partyCount := len(partyIDs)
+	if threshold < 1 || partyCount <= threshold {
+		return nil, fmt.Errorf("ImportFromPrivateKey: t+1=%d parties are needed to sign, but there are %d", threshold+1, partyCount)
+	}
+	if 0 < len(optionalPreParams) && len(optionalPreParams) != partyCount {
+		return nil, fmt.Errorf("ImportFromPrivateKey: expected %d items in `optionalPreParams`, got %d", partyCount, len(optionalPreParams))
+	}
+
+	// 1. pre-params for each party
+	preParams := make([]LocalPreParams, partyCount)
+	for j := range partyIDs {
+		if 0 < len(optionalPreParams) {
+			if !optionalPreParams[j].ValidateWithProof() {
+				return nil, errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib")
+			}
+			preParams[j] = optionalPreParams[j]
+			continue
+		}
+		pp, err := GeneratePreParams(importSafePrimeGenTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("ImportFromPrivateKey: pre-params generation failed: %v", err)
+		}
+		preParams[j] = *pp
+	}
+
+	// 2. share the private key
+	ks := partyIDs.Keys()
+	_, shares, err := vss.Create(ec, threshold, sk, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. build the public data that every party shares
+	public := NewLocalPartySaveData(partyCount)
+	public.CurveName = curveName
+	public.ECDSAPub = crypto.ScalarBaseMult(ec, sk)
+	for j := range partyIDs {
+		public.Ks[j] = ks[j]
+		public.BigXj[j] = crypto.ScalarBaseMult(ec, shares[j].Share)
+		public.PaillierPKs[j] = &preParams[j].PaillierSK.PublicKey
+		public.NTildej[j] = preParams[j].NTildei
+		public.H1j[j], public.H2j[j] = preParams[j].H1i, preParams[j].H2i
+	}
+
+	// 4. add the secrets of each party, to a copy of the public data of its own
+	saves := make([]LocalPartySaveData, partyCount)
+	for j := range partyIDs {
+		saves[j] = public.copyPublic()
+		saves[j].LocalPreParams = preParams[j]
+		saves[j].Xi = shares[j].Share
+		saves[j].ShareID = ks[j]
+	}
+	return saves, nil
+}
+
+// copyPublic returns a copy of the save data whose public data shares nothing with `save`, so that changing the save
+// data of one party does not change that of another
+func (save LocalPartySaveData) copyPublic() LocalPartySaveData {
+	cp := save
+	cp.Ks = make([]*big.Int, len(save.Ks))
+	cp.NTildej, cp.H1j, cp.H2j = make([]*big.Int, len(save.NTildej)), make([]*big.Int, len(save.H1j)), make([]*big.Int, len(save.H2j))
+	cp.BigXj = make([]*crypto.ECPoint, len(save.BigXj))
+	cp.PaillierPKs = make([]*paillier.PublicKey, len(save.PaillierPKs))
+	for j := range save.Ks {
+		cp.Ks[j] = new(big.Int).Set(save.Ks[j])
+		cp.NTildej[j] = new(big.Int).Set(save.NTildej[j])
+		cp.H1j[j], cp.H2j[j] = new(big.Int).Set(save.H1j[j]), new(big.Int).Set(save.H2j[j])
+		cp.BigXj[j] = crypto.NewECPointNoCurveCheck(save.BigXj[j].Curve(), save.BigXj[j].X(), save.BigXj[j].Y())
+		cp.PaillierPKs[j] = &paillier.PublicKey{N: new(big.Int).Set(save.PaillierPKs[j].N)}
+	}
+	cp.ECDSAPub = crypto.NewECPointNoCurveCheck(save.ECDSAPub.Curve(), save.ECDSAPub.X(), save.ECDSAPub.Y())
+	return cp
+}
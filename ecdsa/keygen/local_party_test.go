@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/json"
@@ -16,6 +17,7 @@ import (
 	"runtime"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
@@ -43,14 +45,15 @@ func setUp(level string) {
 func TestStartRound1Paillier(t *testing.T) {
 	setUp("debug")
 
-	pIDs := tss.GenerateTestPartyIDs(1)
+	pIDs := tss.GenerateTestPartyIDs(2)
 	p2pCtx := tss.NewPeerContext(pIDs)
 	threshold := 1
-	params := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), threshold)
+	params, err := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), threshold)
+	assert.NoError(t, err)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
-		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
+		common.DefaultLogger().Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
 	}
 
@@ -61,7 +64,7 @@ func TestStartRound1Paillier(t *testing.T) {
 	} else {
 		lp = NewLocalParty(params, out, nil).(*LocalParty)
 	}
-	if err := lp.Start(); err != nil {
+	if err := lp.Start(context.Background()); err != nil {
 		assert.FailNow(t, err.Error())
 	}
 	<-out
@@ -80,17 +83,82 @@ func TestStartRound1Paillier(t *testing.T) {
 	assert.Equal(t, 2048/8, len2)
 }
 
+func TestStartRound1PaillierModulusLen(t *testing.T) {
+	setUp("debug")
+
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if err != nil {
+		t.Skip("the test fixtures are needed for the 2048-bit pre-params")
+	}
+	pIDs := tss.GenerateTestPartyIDs(2)
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, tss.DefaultPaillierModulusLen, params.PaillierModulusLen())
+	assert.Error(t, params.SetPaillierModulusLen(1024))
+	assert.NoError(t, params.SetPaillierModulusLen(3072))
+	assert.Equal(t, 3072, params.PaillierModulusLen())
+
+	// the 2048-bit Paillier key of the fixture is too short
+	lp := NewLocalParty(params, make(chan tss.Message, 1), nil, fixtures[0].LocalPreParams).(*LocalParty)
+	assert.Error(t, lp.Start(context.Background()))
+}
+
+func TestNewParametersValidation(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	_, err := tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 2)
+	assert.NoError(t, err)
+
+	_, err = tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 0)
+	assert.Error(t, err, "the threshold must be at least 1")
+	_, err = tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 3)
+	assert.Error(t, err, "the threshold must be less than the party count")
+	_, err = tss.NewParameters(p2pCtx, pIDs[1], len(pIDs)+1, 2)
+	assert.Error(t, err, "the party count must match the peer context")
+
+	unsorted := tss.NewPeerContext(tss.SortedPartyIDs{pIDs[1], pIDs[0], pIDs[2]})
+	_, err = tss.NewParameters(unsorted, pIDs[1], len(pIDs), 2)
+	assert.Error(t, err, "the parties must be sorted")
+	dup := *pIDs[1]
+	dup.Index = 2
+	duplicated := tss.NewPeerContext(tss.SortedPartyIDs{pIDs[0], pIDs[1], &dup})
+	_, err = tss.NewParameters(duplicated, pIDs[0], len(pIDs), 2)
+	assert.Error(t, err, "the keys of the parties must be unique")
+
+	stranger := tss.GenerateTestPartyIDs(1)[0]
+	_, err = tss.NewParameters(p2pCtx, stranger, len(pIDs), 2)
+	assert.Error(t, err, "the party must be in the peer context")
+	unsortedSelf := tss.NewPartyID(pIDs[1].Id, pIDs[1].Moniker, pIDs[1].KeyInt())
+	_, err = tss.NewParameters(p2pCtx, unsortedSelf, len(pIDs), 2)
+	assert.Error(t, err, "the party must have its index in the peer context")
+}
+
+func TestGeneratePreParamsWithProgress(t *testing.T) {
+	var reports []PreParamsProgress
+	preParams, err := GeneratePreParamsWithProgress(10*time.Minute, tss.DefaultPaillierModulusLen, func(p PreParamsProgress) {
+		reports = append(reports, p)
+	})
+	assert.NoError(t, err)
+	assert.True(t, preParams.Validate())
+	if assert.NotEmpty(t, reports) {
+		last := reports[len(reports)-1]
+		assert.True(t, 2 <= last.Paillier.Found || 2 <= last.NTilde.Found)
+		assert.True(t, 0 < last.Paillier.Tested+last.NTilde.Tested)
+	}
+}
+
 func TestFinishAndSaveH1H2(t *testing.T) {
 	setUp("debug")
 
-	pIDs := tss.GenerateTestPartyIDs(1)
+	pIDs := tss.GenerateTestPartyIDs(2)
 	p2pCtx := tss.NewPeerContext(pIDs)
 	threshold := 1
-	params := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), threshold)
+	params, err := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), threshold)
+	assert.NoError(t, err)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
-		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
+		common.DefaultLogger().Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
 	}
 
@@ -101,7 +169,7 @@ func TestFinishAndSaveH1H2(t *testing.T) {
 	} else {
 		lp = NewLocalParty(params, out, nil).(*LocalParty)
 	}
-	if err := lp.Start(); err != nil {
+	if err := lp.Start(context.Background()); err != nil {
 		assert.FailNow(t, err.Error())
 	}
 
@@ -133,11 +201,12 @@ func TestBadMessageCulprits(t *testing.T) {
 
 	pIDs := tss.GenerateTestPartyIDs(2)
 	p2pCtx := tss.NewPeerContext(pIDs)
-	params := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	params, err := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	assert.NoError(t, err)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
-		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
+		common.DefaultLogger().Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
 	}
 
@@ -148,12 +217,12 @@ func TestBadMessageCulprits(t *testing.T) {
 	} else {
 		lp = NewLocalParty(params, out, nil).(*LocalParty)
 	}
-	if err := lp.Start(); err != nil {
+	if err := lp.Start(context.Background()); err != nil {
 		assert.FailNow(t, err.Error())
 	}
 
 	badMsg, _ := NewKGRound1Message(pIDs[1], zero, &paillier.PublicKey{N: zero}, zero, zero, zero, new(dlnproof.Proof), new(dlnproof.Proof))
-	ok, err2 := lp.Update(badMsg)
+	ok, err2 := lp.Update(context.Background(), badMsg)
 	t.Log(err2)
 	assert.False(t, ok)
 	if !assert.Error(t, err2) {
@@ -161,20 +230,263 @@ func TestBadMessageCulprits(t *testing.T) {
 	}
 	assert.Equal(t, 1, len(err2.Culprits()))
 	assert.Equal(t, pIDs[1], err2.Culprits()[0])
+	assert.Equal(t, tss.CodeInvalidMessage, err2.Code())
+	if assert.Equal(t, 1, len(err2.Evidence())) {
+		assert.Equal(t, badMsg, err2.Evidence()[0].Message)
+	}
 	assert.Equal(t,
-		"task ecdsa-keygen, party {0,P[1]}, round 1, culprits [{1,2}]: message failed ValidateBasic: Type: binance.tss-lib.ecdsa.keygen.KGRound1Message, From: {1,2}, To: all",
+		"task ecdsa-keygen, party {0,P[1]}, round 1, code invalid-message, culprits [{1,2}]: message failed ValidateBasic: Type: binance.tss-lib.ecdsa.keygen.KGRound1Message, From: {1,2}, To: all",
 		err2.Error())
 }
 
-func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+func TestSessionIDMismatch(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	newParty := func(sessionID []byte, out chan tss.Message) *LocalParty {
+		params, err := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		params.SetSessionID(sessionID)
+		return NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	}
+
+	out := make(chan tss.Message, len(pIDs))
+	lp := newParty([]byte("session a"), out)
+	if err := lp.Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	bz, _, err := (<-out).WireBytes()
+	assert.NoError(t, err)
+	msg, err := tss.ParseWireMessage(bz, pIDs[1], true)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("session a"), msg.GetSessionID(), "the session ID should be sent in the wire bytes")
+
+	ok, err2 := newParty([]byte("session a"), nil).ValidateMessage(msg)
+	assert.True(t, ok)
+	assert.Nil(t, err2)
+	for _, sessionID := range [][]byte{[]byte("session b"), nil} {
+		ok, err2 = newParty(sessionID, nil).ValidateMessage(msg)
+		assert.False(t, ok)
+		if assert.Error(t, err2) {
+			assert.Equal(t, []*tss.PartyID{pIDs[1]}, err2.Culprits())
+		}
+	}
+}
+
+func TestDuplicateMessages(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	assert.NoError(t, err)
+	out := make(chan tss.Message, len(pIDs))
+	lp := NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	if err := lp.Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// a round 1 message with valid content, as if from P[2]
+	bz, _, err := (<-out).WireBytes()
+	assert.NoError(t, err)
+	msg, err := tss.ParseWireMessage(bz, pIDs[1], true)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		ok, err2 := lp.Update(context.Background(), msg)
+		assert.True(t, ok, "a message and its duplicate should be accepted")
+		assert.Nil(t, err2)
+	}
+
+	content := *msg.Content().(*KGRound1Message)
+	content.Commitment = append([]byte{1}, content.Commitment...)
+	meta := tss.MessageRouting{From: pIDs[1], IsBroadcast: true}
+	ok, err2 := lp.Update(context.Background(), tss.NewMessage(meta, &content, tss.NewMessageWrapper(meta, &content)))
+	assert.False(t, ok)
+	if assert.Error(t, err2, "a conflicting duplicate should be rejected") {
+		assert.Equal(t, []*tss.PartyID{pIDs[1]}, err2.Culprits())
+	}
+}
+
+func TestWaitingForMessages(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	assert.NoError(t, err)
+	out := make(chan tss.Message, len(pIDs))
+	lp := NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	if err := lp.Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, map[string][]*tss.PartyID{"": pIDs[1:]}, lp.WaitingForMessages(),
+		"no type of round 1 is known before a peer sends one")
+
+	bz, _, err := (<-out).WireBytes()
+	assert.NoError(t, err)
+	msg, err := tss.ParseWireMessage(bz, pIDs[1], true)
+	assert.NoError(t, err)
+	_, err2 := lp.Update(context.Background(), msg)
+	assert.Nil(t, err2)
+	assert.Equal(t, map[string][]*tss.PartyID{msg.Type(): pIDs[2:]}, lp.WaitingForMessages())
+}
+
+func TestProgress(t *testing.T) {
 	setUp("info")
 
-	// tss.SetCurve(elliptic.P256())
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	assert.NoError(t, err)
+	out := make(chan tss.Message, len(pIDs))
+	lp := NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	assert.Equal(t, tss.Progress{}, lp.Progress())
+	assert.Equal(t, "not running", lp.Progress().String())
+
+	if err := lp.Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	progress := lp.Progress()
+	assert.Equal(t, tss.Progress{Round: 1, TotalRounds: 4, Phase: "commitments and Paillier keys"}, progress)
+	assert.Equal(t, "1/4: commitments and Paillier keys", progress.String())
+}
+
+func TestVerifyECDSAPub(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := keys[0]
+	assert.NoError(t, key.VerifyECDSAPub(testThreshold))
+
+	badPub := key
+	badPub.ECDSAPub = key.BigXj[0]
+	assert.Error(t, badPub.VerifyECDSAPub(testThreshold), "a different public key must fail")
+
+	badBigXj := key
+	badBigXj.BigXj = append([]*crypto.ECPoint{}, key.BigXj...)
+	badBigXj.BigXj[len(badBigXj.BigXj)-1] = key.BigXj[0]
+	assert.Error(t, badBigXj.VerifyECDSAPub(testThreshold), "a public share off the polynomial must fail")
+
+	assert.Error(t, key.VerifyECDSAPub(testThreshold-1), "a lower threshold must fail")
+}
+
+func TestKeyEpoch(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := keys[0]
+	key.Epoch = 3
+	assert.NoError(t, key.CheckEpoch(3))
+	assert.Error(t, key.CheckEpoch(2), "an older epoch must fail")
+	assert.Error(t, key.CheckEpoch(4), "a newer epoch must fail")
+
+	key.RefreshedAt = time.Time{}
+	assert.True(t, key.RefreshDue(time.Hour), "save data without a refresh time must be due")
+	key.RefreshedAt = time.Now().Add(-2 * time.Hour)
+	assert.True(t, key.RefreshDue(time.Hour))
+	assert.False(t, key.RefreshDue(3*time.Hour))
+}
+
+func TestImportFromPrivateKey(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(3)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	preParams := make([]LocalPreParams, len(fixtures))
+	for j, fixture := range fixtures {
+		preParams[j] = fixture.LocalPreParams
+	}
+	pIDs := tss.GenerateTestPartyIDs(len(fixtures))
+	threshold := 1
+
+	sk := common.GetRandomPositiveInt(tss.S256().Params().N)
+	saves, err := ImportFromPrivateKey(tss.S256(), sk, threshold, pIDs, preParams...)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, saves, len(pIDs))
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), sk).Equals(saves[0].ECDSAPub))
+	shares := make(vss.Shares, 0, len(saves))
+	for j, save := range saves {
+		index, err := save.OriginalIndex()
+		assert.NoError(t, err)
+		assert.Equal(t, j, index)
+		assert.NoError(t, save.VerifyECDSAPub(threshold))
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), save.Xi).Equals(save.BigXj[j]), "ensure BigX_j == g^x_j")
+		assert.True(t, save.ValidateWithProof())
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+	}
+	reconstructed, err := shares[:threshold+1].ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reconstructed.Cmp(sk))
+
+	// the parties share none of their public data
+	saves[0].Ks[1] = big.NewInt(1)
+	saves[0].NTildej[1].SetInt64(1)
+	saves[0].H1j[1], saves[0].H2j[1] = nil, nil
+	saves[0].BigXj[1] = saves[0].BigXj[0]
+	saves[0].PaillierPKs[1].N.SetInt64(1)
+	assert.NotEqual(t, 0, saves[1].Ks[1].Cmp(big.NewInt(1)))
+	assert.NotEqual(t, 0, saves[1].NTildej[1].Cmp(big.NewInt(1)))
+	assert.NotNil(t, saves[1].H1j[1])
+	assert.NotNil(t, saves[1].H2j[1])
+	assert.False(t, saves[1].BigXj[1].Equals(saves[1].BigXj[0]))
+	assert.NotEqual(t, 0, saves[1].PaillierPKs[1].N.Cmp(big.NewInt(1)))
+	assert.NotEqual(t, 0, preParams[1].NTildei.Cmp(big.NewInt(1)), "the pre-params must not be changed either")
+	assert.True(t, saves[1].ValidateWithProof())
+
+	_, err = ImportFromPrivateKey(tss.S256(), sk, len(pIDs), pIDs, preParams...)
+	assert.Error(t, err, "the threshold must be below the party count")
+	_, err = ImportFromPrivateKey(tss.S256(), big.NewInt(0), threshold, pIDs, preParams...)
+	assert.Error(t, err, "a zero private key must be rejected")
+}
+
+func TestReconstructPrivateKey(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(testThreshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	sk, err := ReconstructPrivateKey(keys)
+	if assert.NoError(t, err) {
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), sk).Equals(keys[0].ECDSAPub))
+	}
+
+	_, err = ReconstructPrivateKey(keys[:testThreshold])
+	assert.Error(t, err, "t shares must not be enough")
+	_, err = ReconstructPrivateKey(append(keys[:testThreshold:testThreshold], keys[0]))
+	assert.Error(t, err, "a duplicated share must be rejected")
+
+	shares := vss.Shares{}
+	for _, key := range keys {
+		shares = append(shares, &vss.Share{ID: key.ShareID, Share: key.Xi})
+	}
+	skFromShares, err := ReconstructPrivateKeyFromShares(keys[0].ECDSAPub, shares)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, sk.Cmp(skFromShares))
+	}
+	_, err = ReconstructPrivateKeyFromShares(keys[0].BigXj[0], shares)
+	assert.Error(t, err, "a different public key must fail")
+}
+
+func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+	setUp("info")
 
 	threshold := testThreshold
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
-		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
+		common.DefaultLogger().Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
 	}
 
@@ -192,7 +504,8 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
 		var P *LocalParty
-		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
 		if i < len(fixtures) {
 			P = NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
 		} else {
@@ -200,7 +513,7 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 		}
 		parties = append(parties, P)
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -213,7 +526,7 @@ keygen:
 		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
 		select {
 		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			break keygen
 
@@ -262,17 +575,17 @@ keygen:
 						}
 						pShares = append(pShares, shareStruct)
 					}
-					uj, err := pShares[:threshold+1].ReConstruct()
+					uj, err := pShares[:threshold+1].ReConstruct(tss.S256())
 					assert.NoError(t, err, "vss.ReConstruct should not throw error")
 
 					// uG test: u*G[j] == V[0]
 					assert.Equal(t, uj, Pj.temp.ui)
-					uG := crypto.ScalarBaseMult(tss.EC(), uj)
+					uG := crypto.ScalarBaseMult(tss.S256(), uj)
 					assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
 
 					// xj tests: BigXj == xj*G
 					xj := Pj.data.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
+					gXj := crypto.ScalarBaseMult(tss.S256(), xj)
 					BigXj := Pj.data.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 
@@ -280,10 +593,10 @@ keygen:
 					{
 						badShares := pShares[:threshold]
 						badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-						uj, err := pShares[:threshold].ReConstruct()
+						uj, err := pShares[:threshold].ReConstruct(tss.S256())
 						assert.NoError(t, err)
 						assert.NotEqual(t, parties[j].temp.ui, uj)
-						BigXjX, BigXjY := tss.EC().ScalarBaseMult(uj.Bytes())
+						BigXjX, BigXjY := tss.S256().ScalarBaseMult(uj.Bytes())
 						assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
 						assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
 					}
@@ -293,7 +606,7 @@ keygen:
 				// build ecdsa key pair
 				pkX, pkY := save.ECDSAPub.X(), save.ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.S256(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -306,7 +619,7 @@ keygen:
 
 				// public key tests
 				assert.NotZero(t, u, "u should not be zero")
-				ourPkX, ourPkY := tss.EC().ScalarBaseMult(u.Bytes())
+				ourPkX, ourPkY := tss.S256().ScalarBaseMult(u.Bytes())
 				assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
 				assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
 				t.Log("Public key tests done.")
@@ -337,6 +650,106 @@ keygen:
 	}
 }
 
+func TestE2EPVSS(t *testing.T) {
+	setUp("info")
+
+	threshold := 2
+	fixtures, pIDs, err := LoadKeygenTestFixtures(4)
+	if err != nil {
+		t.Skip("the test fixtures are needed for the pre-params")
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
+		params.SetPVSS(true)
+		params.SetSessionID([]byte("pvss"))
+		P := NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// an observer records the broadcasts of rounds 1 and 2
+	r1msgs := make([]tss.ParsedMessage, len(pIDs))
+	r2msgs := make([]tss.ParsedMessage, len(pIDs))
+	saves := make([]LocalPartySaveData, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if !assert.Nil(t, dest, "PVSS sends no point-to-point messages") {
+				return
+			}
+			switch msg.(tss.ParsedMessage).Content().(type) {
+			case *KGRound1Message:
+				r1msgs[msg.GetFrom().Index] = msg.(tss.ParsedMessage)
+			case *KGRound2Message2:
+				r2msgs[msg.GetFrom().Index] = msg.(tss.ParsedMessage)
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case save := <-endCh:
+			saves = append(saves, save)
+			if len(saves) == len(pIDs) {
+				break keygen
+			}
+		}
+	}
+
+	for _, save := range saves {
+		assert.NoError(t, save.VerifyECDSAPub(threshold))
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub))
+	}
+	sk, err := ReconstructPrivateKey(saves[:threshold+1])
+	if assert.NoError(t, err) {
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), sk).Equals(saves[0].ECDSAPub))
+	}
+
+	culprits, err := VerifyDealings(tss.S256(), pIDs, threshold, []byte("pvss"), r1msgs, r2msgs)
+	assert.NoError(t, err)
+	assert.Empty(t, culprits)
+
+	// the dealings do not verify in another session, or when a dealer swaps the shares of two recipients
+	culprits, err = VerifyDealings(tss.S256(), pIDs, threshold, nil, r1msgs, r2msgs)
+	assert.NoError(t, err)
+	assert.Len(t, culprits, len(pIDs))
+
+	dealer := r2msgs[1]
+	swapped := *dealer.Content().(*KGRound2Message2)
+	swapped.EncryptedShares = append([][]byte{swapped.EncryptedShares[1], swapped.EncryptedShares[0]}, swapped.EncryptedShares[2:]...)
+	meta := tss.MessageRouting{From: dealer.GetFrom(), IsBroadcast: true}
+	badR2msgs := append([]tss.ParsedMessage{}, r2msgs...)
+	badR2msgs[1] = tss.NewMessage(meta, &swapped, tss.NewMessageWrapper(meta, &swapped))
+	culprits, err = VerifyDealings(tss.S256(), pIDs, threshold, []byte("pvss"), r1msgs, badR2msgs)
+	assert.NoError(t, err)
+	assert.Equal(t, []*tss.PartyID{pIDs[1]}, culprits)
+
+	_, err = VerifyDealings(tss.S256(), pIDs, threshold, []byte("pvss"), r1msgs, r2msgs[1:])
+	assert.Error(t, err)
+}
+
 func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(index)
 
@@ -362,3 +775,271 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestEarlyMessages(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	newParty := func(i int, out chan tss.Message) *LocalParty {
+		params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		return NewLocalParty(params, out, nil, fixtures[i].LocalPreParams).(*LocalParty)
+	}
+
+	// a round 1 message from P[2], which P[1] receives before it has started
+	out1 := make(chan tss.Message, len(pIDs))
+	if err := newParty(1, out1).Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	bz, _, err := (<-out1).WireBytes()
+	assert.NoError(t, err)
+	msg, err := tss.ParseWireMessage(bz, pIDs[1], true)
+	assert.NoError(t, err)
+
+	lp := newParty(0, make(chan tss.Message, len(pIDs)))
+	ok, err2 := lp.Update(context.Background(), msg)
+	assert.True(t, ok, "a message that arrives before Start should be held")
+	assert.Nil(t, err2)
+	assert.Nil(t, lp.temp.kgRound1Messages[1])
+
+	if err := lp.Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, msg, lp.temp.kgRound1Messages[1], "the held message should be applied once round 1 has started")
+	assert.NotContains(t, lp.WaitingFor(), pIDs[1])
+}
+
+func TestContextCancelled(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lp := NewLocalParty(params, make(chan tss.Message, len(pIDs)), nil, fixtures[0].LocalPreParams).(*LocalParty)
+	if err2 := lp.Start(ctx); assert.Error(t, err2, "a cancelled context should stop Start") {
+		assert.Equal(t, context.Canceled, err2.Cause())
+		assert.Equal(t, tss.CodeCancelled, err2.Code())
+	}
+
+	out := make(chan tss.Message, len(pIDs))
+	lp = NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	if err := lp.Start(context.Background()); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	bz, _, err := (<-out).WireBytes()
+	assert.NoError(t, err)
+	msg, err := tss.ParseWireMessage(bz, pIDs[1], true)
+	assert.NoError(t, err)
+	ok, err2 := lp.Update(ctx, msg)
+	assert.False(t, ok)
+	if assert.Error(t, err2, "a cancelled context should stop Update") {
+		assert.Equal(t, context.Canceled, err2.Cause())
+		assert.Equal(t, tss.CodeCancelled, err2.Code())
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	setUp("info")
+
+	threshold := 2
+	fixtures, pIDs, err := LoadKeygenTestFixtures(4)
+	if err != nil {
+		t.Skip("the test fixtures are needed for the pre-params")
+	}
+	ctx := context.Background()
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs)*4)
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		param, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
+		params = append(params, param)
+		parties = append(parties, NewLocalParty(params[i], outCh, endCh, fixtures[i].LocalPreParams))
+	}
+	for _, P := range parties {
+		if err := P.Start(ctx); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// deliver the messages one at a time, and move party 0 to a new party once it has reached round 2
+	restored := false
+	for len(endCh) < len(pIDs) {
+		if !assert.NotZero(t, len(outCh), "the parties should not wait for messages") {
+			return
+		}
+		msg := <-outCh
+		bz, routing, err := msg.WireBytes()
+		assert.NoError(t, err)
+		dest := routing.To
+		if dest == nil {
+			dest = pIDs
+		}
+		for _, Pj := range dest {
+			if Pj.Index == msg.GetFrom().Index {
+				continue
+			}
+			if _, err := parties[Pj.Index].UpdateFromBytes(ctx, bz, msg.GetFrom(), routing.IsBroadcast); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		if !restored && parties[0].Progress().Round == 2 {
+			snapshot, err := parties[0].(*LocalParty).Bytes()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tss.CodeUnexpectedState, tss.RestoreParty(ctx, parties[0].(*LocalParty), snapshot).Code(),
+				"a running party should not be restored")
+			P, err2 := RestoreLocalParty(ctx, snapshot, params[0], outCh, endCh)
+			if err2 != nil {
+				assert.FailNow(t, err2.Error())
+			}
+			assert.Equal(t, parties[0].Progress(), P.Progress())
+			assert.Equal(t, parties[0].WaitingFor(), P.WaitingFor())
+			parties[0], restored = P, true
+		}
+	}
+	assert.True(t, restored)
+
+	saves := make([]LocalPartySaveData, 0, len(pIDs))
+	for len(endCh) > 0 {
+		saves = append(saves, <-endCh)
+	}
+	for _, save := range saves {
+		assert.NoError(t, save.VerifyECDSAPub(threshold))
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub))
+	}
+}
+
+// a transport of the test that queues each message with the recipients that it was sent to
+type queueTransport chan queuedMessage
+
+type queuedMessage struct {
+	msg tss.Message
+	to  []*tss.PartyID
+}
+
+func (q queueTransport) Send(msg tss.Message, to ...*tss.PartyID) error {
+	q <- queuedMessage{msg: msg, to: to}
+	return nil
+}
+
+func (q queueTransport) Broadcast(msg tss.Message) error {
+	q <- queuedMessage{msg: msg}
+	return nil
+}
+
+func TestCrashResume(t *testing.T) {
+	setUp("info")
+
+	threshold := 2
+	fixtures, pIDs, err := LoadKeygenTestFixtures(4)
+	if err != nil {
+		t.Skip("the test fixtures are needed for the pre-params")
+	}
+	ctx := context.Background()
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs)*4)
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+	wire := make(queueTransport, len(pIDs)*len(pIDs)*8)
+
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	outboxes := make([]*tss.Outbox, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		param, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
+		param.SetSessionID([]byte("crash-resume"))
+		params = append(params, param)
+		parties = append(parties, NewLocalParty(params[i], outCh, endCh, fixtures[i].LocalPreParams))
+		outboxes = append(outboxes, tss.NewOutbox(wire))
+	}
+	for _, P := range parties {
+		if err := P.Start(ctx); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// party 0 crashes once it has reached round 2 and loses the messages sent to it until it has been resumed
+	var snapshot []byte
+	crashed, resumed := false, false
+	for len(endCh) < len(pIDs) {
+		for len(outCh) > 0 {
+			msg := <-outCh
+			assert.NoError(t, tss.Deliver(outboxes[msg.GetFrom().Index], msg))
+		}
+		if len(wire) == 0 {
+			if !crashed || resumed {
+				assert.FailNow(t, "the parties should not wait for messages")
+			}
+			// the peers wait for party 0, which resumes from its snapshot and asks them for the messages it lost
+			P, err := RestoreLocalParty(ctx, snapshot, params[0], outCh, endCh)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			reqs := tss.ResendRequests(P)
+			assert.Equal(t, len(pIDs)-1, len(reqs))
+			for Pj, req := range reqs {
+				bz, err := req.Bytes()
+				assert.NoError(t, err)
+				parsed, err := tss.ParseResendRequest(bz, pIDs[0])
+				assert.NoError(t, err)
+				n, err := outboxes[Pj.Index].Resend(parsed)
+				assert.NoError(t, err)
+				assert.NotZero(t, n)
+			}
+			parties[0], resumed = P, true
+			continue
+		}
+		queued := <-wire
+		bz, routing, err := queued.msg.WireBytes()
+		assert.NoError(t, err)
+		dest := queued.to
+		if dest == nil {
+			dest = pIDs
+		}
+		for _, Pj := range dest {
+			if Pj.Index == queued.msg.GetFrom().Index || (Pj.Index == 0 && crashed && !resumed) {
+				continue
+			}
+			if _, err := parties[Pj.Index].UpdateFromBytes(ctx, bz, queued.msg.GetFrom(), routing.IsBroadcast); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		if !crashed && parties[0].Progress().Round == 2 {
+			if snapshot, err = parties[0].(*LocalParty).Bytes(); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			crashed = true
+		}
+	}
+	assert.True(t, resumed)
+
+	saves := make([]LocalPartySaveData, 0, len(pIDs))
+	for len(endCh) > 0 {
+		saves = append(saves, <-endCh)
+	}
+	for _, save := range saves {
+		assert.NoError(t, save.VerifyECDSAPub(threshold))
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub))
+	}
+
+	// a request without a sender is refused, and one of another session asks for nothing
+	_, err = outboxes[1].Resend(&tss.ResendRequest{})
+	assert.Error(t, err)
+	n, err := outboxes[1].Resend(&tss.ResendRequest{From: pIDs[0], SessionID: []byte("another")})
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"math/big"
 
 	"github.com/golang/protobuf/proto"
@@ -14,6 +15,7 @@ import (
 	"github.com/binance-chain/tss-lib/common"
 	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
 	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/mta"
 	"github.com/binance-chain/tss-lib/crypto/paillier"
 	"github.com/binance-chain/tss-lib/crypto/vss"
 	"github.com/binance-chain/tss-lib/tss"
@@ -23,8 +25,8 @@ import (
 // The following messages are registered on the Protocol Buffers "wire"
 
 var (
-	// Ensure that keygen messages implement ValidateBasic
-	_ = []tss.MessageContent{
+	// Ensure that keygen messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
 		(*KGRound1Message)(nil),
 		(*KGRound2Message1)(nil),
 		(*KGRound2Message2)(nil),
@@ -37,6 +39,7 @@ func init() {
 	proto.RegisterType((*KGRound2Message1)(nil), tss.ECDSAProtoNamePrefix+"keygen.KGRound2Message1")
 	proto.RegisterType((*KGRound2Message2)(nil), tss.ECDSAProtoNamePrefix+"keygen.KGRound2Message2")
 	proto.RegisterType((*KGRound3Message)(nil), tss.ECDSAProtoNamePrefix+"keygen.KGRound3Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
 }
 
 // ----- //
@@ -142,9 +145,13 @@ func (m *KGRound2Message1) UnmarshalShare() *big.Int {
 
 // ----- //
 
+// NewKGRound2Message2 makes the broadcast of round 2. With PVSS, `encryptedShares` and `shareProofs` hold the share of
+// each party encrypted to its Paillier key and the proofs that they match the commitments; otherwise they are nil.
 func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
+	encryptedShares []*big.Int,
+	shareProofs []*mta.ProofPDL,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -154,13 +161,27 @@ func NewKGRound2Message2(
 	content := &KGRound2Message2{
 		DeCommitment: dcBzs,
 	}
+	if 0 < len(encryptedShares) {
+		content.EncryptedShares = common.BigIntsToBytes(encryptedShares)
+		content.ShareProofs = make([][]byte, 0, len(shareProofs)*mta.ProofPDLBytesParts)
+		for _, pf := range shareProofs {
+			pfBzs := pf.Bytes()
+			content.ShareProofs = append(content.ShareProofs, pfBzs[:]...)
+		}
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound2Message2) ValidateBasic() bool {
-	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetDeCommitment())
+	if m == nil || !common.NonEmptyMultiBytes(m.GetDeCommitment()) {
+		return false
+	}
+	if len(m.GetEncryptedShares()) == 0 {
+		return len(m.GetShareProofs()) == 0
+	}
+	return common.NonEmptyMultiBytes(m.GetEncryptedShares()) &&
+		common.NonEmptyMultiBytes(m.GetShareProofs(), len(m.GetEncryptedShares())*mta.ProofPDLBytesParts)
 }
 
 func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
@@ -168,6 +189,29 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+// IsPVSS returns whether the message carries the encrypted shares of PVSS
+func (m *KGRound2Message2) IsPVSS() bool {
+	return 0 < len(m.GetEncryptedShares())
+}
+
+func (m *KGRound2Message2) UnmarshalEncryptedShares() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetEncryptedShares())
+}
+
+// UnmarshalShareProofs decodes the proofs of the encrypted shares of a dealing on the curve `ec`
+func (m *KGRound2Message2) UnmarshalShareProofs(ec elliptic.Curve) ([]*mta.ProofPDL, error) {
+	pfBzs := m.GetShareProofs()
+	pfs := make([]*mta.ProofPDL, len(pfBzs)/mta.ProofPDLBytesParts)
+	for j := range pfs {
+		pf, err := mta.ProofPDLFromBytes(ec, pfBzs[j*mta.ProofPDLBytesParts:(j+1)*mta.ProofPDLBytesParts])
+		if err != nil {
+			return nil, err
+		}
+		pfs[j] = pf
+	}
+	return pfs, nil
+}
+
 // ----- //
 
 func NewKGRound3Message(
@@ -10,29 +10,50 @@ import (
 	"errors"
 	"math/big"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/tss"
 )
 
 const (
-	// Using a modulus length of 2048 is recommended in the GG18 spec
-	paillierModulusLen = 2048
 	// Two 1024-bit safe primes to produce NTilde
 	safePrimeBitLen = 1024
 	// Ticker for printing log statements while generating primes/modulus
 	logProgressTickInterval = 8 * time.Second
 )
 
+type (
+	// PreParamsProgress reports on the two searches of GeneratePreParamsWithProgress, which run concurrently: for the
+	// safe primes of the Paillier key and for those of NTilde
+	PreParamsProgress struct {
+		Paillier, NTilde common.SafePrimeProgress
+		Elapsed          time.Duration
+	}
+)
+
 // GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
 // This can be a time consuming process so it is recommended to do it out-of-band.
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
 func GeneratePreParams(timeout time.Duration, optionalConcurrency ...int) (*LocalPreParams, error) {
+	return GeneratePreParamsWithModulusLen(timeout, tss.DefaultPaillierModulusLen, optionalConcurrency...)
+}
+
+// GeneratePreParamsWithModulusLen is GeneratePreParams with a Paillier modulus of `paillierModulusLen` bits, as set
+// with tss.Parameters.SetPaillierModulusLen
+func GeneratePreParamsWithModulusLen(timeout time.Duration, paillierModulusLen int, optionalConcurrency ...int) (*LocalPreParams, error) {
+	return GeneratePreParamsWithProgress(timeout, paillierModulusLen, nil, optionalConcurrency...)
+}
+
+// GeneratePreParamsWithProgress is GeneratePreParamsWithModulusLen that reports its progress to `progress`, which may
+// be nil, e.g. for a UI to show during the setup. The calls are serialized.
+func GeneratePreParamsWithProgress(timeout time.Duration, paillierModulusLen int, progress func(PreParamsProgress), optionalConcurrency ...int) (*LocalPreParams, error) {
 	var concurrency int
 	if 0 < len(optionalConcurrency) {
 		if 1 < len(optionalConcurrency) {
-			panic(errors.New("GeneratePreParams: expected 0 or 1 item in `optionalConcurrency`"))
+			panic(errors.New("GeneratePreParamsWithProgress: expected 0 or 1 item in `optionalConcurrency`"))
 		}
 		concurrency = optionalConcurrency[0]
 	} else {
@@ -46,31 +67,52 @@ func GeneratePreParams(timeout time.Duration, optionalConcurrency ...int) (*Loca
 	paiCh := make(chan *paillier.PrivateKey, 1)
 	sgpCh := make(chan []*common.GermainSafePrime, 1)
 
+	var paiProgress func(common.SafePrimeProgress)
+	var sgpOpts *common.SafePrimeOptions
+	if progress != nil {
+		start := time.Now()
+		mtx := sync.Mutex{}
+		latest := PreParamsProgress{}
+		report := func(update func(*PreParamsProgress)) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			update(&latest)
+			latest.Elapsed = time.Since(start)
+			progress(latest)
+		}
+		paiProgress = func(p common.SafePrimeProgress) {
+			report(func(latest *PreParamsProgress) { latest.Paillier = p })
+		}
+		sgpOpts = &common.SafePrimeOptions{Progress: func(p common.SafePrimeProgress) {
+			report(func(latest *PreParamsProgress) { latest.NTilde = p })
+		}}
+	}
+
 	// 4. generate Paillier public key E_i, private key and proof
 	go func(ch chan<- *paillier.PrivateKey) {
-		common.Logger.Info("generating the Paillier modulus, please wait...")
+		common.DefaultLogger().Info("generating the Paillier modulus, please wait...")
 		start := time.Now()
 		// more concurrency weight is assigned here because the paillier primes have a requirement of having "large" P-Q
-		PiPaillierSk, _, err := paillier.GenerateKeyPair(paillierModulusLen, timeout, concurrency*2)
+		PiPaillierSk, _, err := paillier.GenerateKeyPairWithProgress(paillierModulusLen, timeout, paiProgress, concurrency*2)
 		if err != nil {
 			ch <- nil
 			return
 		}
-		common.Logger.Infof("paillier modulus generated. took %s\n", time.Since(start))
+		common.DefaultLogger().Info("paillier modulus generated", "took", time.Since(start))
 		ch <- PiPaillierSk
 	}(paiCh)
 
 	// 5-7. generate safe primes for ZKPs used later on
 	go func(ch chan<- []*common.GermainSafePrime) {
 		var err error
-		common.Logger.Info("generating the safe primes for the signing proofs, please wait...")
+		common.DefaultLogger().Info("generating the safe primes for the signing proofs, please wait...")
 		start := time.Now()
-		sgps, err := common.GetRandomSafePrimesConcurrent(safePrimeBitLen, 2, timeout, concurrency)
+		sgps, err := common.GetRandomSafePrimesWithOptions(safePrimeBitLen, 2, timeout, concurrency, sgpOpts)
 		if err != nil {
 			ch <- nil
 			return
 		}
-		common.Logger.Infof("safe primes generated. took %s\n", time.Since(start))
+		common.DefaultLogger().Info("safe primes generated", "took", time.Since(start))
 		ch <- sgps
 	}(sgpCh)
 
@@ -84,7 +126,7 @@ consumer:
 	for {
 		select {
 		case <-logProgressTicker.C:
-			common.Logger.Info("still generating primes...")
+			common.DefaultLogger().Info("still generating primes...")
 		case sgps = <-sgpCh:
 			if sgps == nil ||
 				sgps[0] == nil || sgps[1] == nil ||
@@ -0,0 +1,175 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// With PVSS (see tss.Parameters.SetPVSS), a dealer encrypts the share of each party P_j under the Paillier key of P_j
+// and proves with a PDL proof, against the NTilde_j, h1_j and h2_j of P_j, that the plaintext x of the ciphertext has
+// x*G = V(j), the public share of P_j on the polynomial that the dealer committed to. The shares are broadcast in round
+// 2 with the de-commitment, so the round 1 and round 2 broadcasts of a ceremony are all that is needed to check that
+// every dealer dealt consistent shares to every party.
+//
+// A PDL proof is sound only if its prover does not know the factors of the NTilde that it is made against. A dealer that
+// colludes with its recipient could therefore deal the recipient a bad share, which only harms the recipient.
+
+type (
+	// recipient holds the public keys of a party that shares are encrypted to, from its round 1 message
+	recipient struct {
+		pk             *paillier.PublicKey
+		NTilde, h1, h2 *big.Int
+	}
+)
+
+// encryptShares encrypts each of the `shares` of a sharing on the curve `ec` to its recipient and proves that it matches
+// its public share, in the context `ctx` of the dealer's round 2 proofs
+func encryptShares(ctx *zkp.Transcript, ec elliptic.Curve, shares vss.Shares, recipients []recipient) ([]*big.Int, []*mta.ProofPDL, error) {
+	if len(shares) != len(recipients) {
+		return nil, nil, errors.New("encryptShares() expected a share for each recipient")
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	cs := make([]*big.Int, len(shares))
+	pfs := make([]*mta.ProofPDL, len(shares))
+	for j, share := range shares {
+		rj := recipients[j]
+		c, r, err := rj.pk.EncryptAndReturnRandomness(share.Share)
+		if err != nil {
+			return nil, nil, err
+		}
+		S := crypto.ScalarBaseMult(ec, share.Share)
+		if pfs[j], err = mta.ProvePDL(ctx, rj.pk, c, G, S, rj.NTilde, rj.h1, rj.h2, share.Share, r); err != nil {
+			return nil, nil, err
+		}
+		cs[j] = c
+	}
+	return cs, pfs, nil
+}
+
+// verifyEncryptedShares checks that the encrypted share of each of the `parties` in `r2msg` matches its public share on
+// the polynomial committed to by `vs`, in the context `ctx` of the dealer's round 2 proofs
+func verifyEncryptedShares(ctx *zkp.Transcript, parties tss.SortedPartyIDs, recipients []recipient, vs vss.Vs, r2msg *KGRound2Message2) error {
+	ec := vs[0].Curve()
+	cs := r2msg.UnmarshalEncryptedShares()
+	pfs, err := r2msg.UnmarshalShareProofs(ec)
+	if err != nil {
+		return err
+	}
+	if len(cs) != len(parties) || len(pfs) != len(parties) {
+		return fmt.Errorf("expected %d encrypted shares with their proofs", len(parties))
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	for j, Pj := range parties {
+		S, err := vs.Evaluate(Pj.KeyInt())
+		if err != nil {
+			return err
+		}
+		rj := recipients[j]
+		if !pfs[j].Verify(ctx, rj.pk, cs[j], G, S, rj.NTilde, rj.h1, rj.h2) {
+			return fmt.Errorf("the encrypted share of party %s did not verify", Pj)
+		}
+	}
+	return nil
+}
+
+// decryptShare decrypts an encrypted share that was verified with verifyEncryptedShares. The PDL proof bounds the
+// plaintext by q^3 in absolute value, so a plaintext above N/2 is the encryption of a negative share.
+func decryptShare(q *big.Int, sk *paillier.PrivateKey, c *big.Int) (*big.Int, error) {
+	m, err := sk.Decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(new(big.Int).Rsh(sk.N, 1)) == 1 {
+		m = new(big.Int).Sub(m, sk.N)
+	}
+	return m.Mod(m, q), nil
+}
+
+// openDealing opens the commitment of round 1 to the Feldman commitments on the curve `ec` of the dealer with the
+// de-commitment of round 2, accepting the commitments of the framing `f`
+func openDealing(ec elliptic.Curve, f commitments.Framing, threshold int, sessionID []byte, r1msg *KGRound1Message, r2msg *KGRound2Message2) (vss.Vs, error) {
+	cmtDeCmt := commitments.HashCommitDecommit{C: r1msg.UnmarshalCommitment(), D: r2msg.UnmarshalDeCommitment()}
+	ok, flatPolyGs := cmtDeCmt.DeCommitInSession(f, commitmentDomain, sessionID)
+	if !ok || len(flatPolyGs) != (threshold+1)*2 { // they're points so * 2
+		return nil, errors.New("de-commitment verify failed")
+	}
+	return crypto.UnFlattenECPoints(ec, flatPolyGs)
+}
+
+// VerifyDealings checks the dealings of an ECDSA keygen ceremony run with PVSS from its broadcast messages alone, so
+// that an observer who is not one of the `parties` can check that every dealer dealt consistent shares. `r1msgs` and
+// `r2msgs` are the KGRound1Message and KGRound2Message2 broadcasts of the parties, in their order, and `sessionID` is
+// the session ID of the ceremony, if any, and `ec` is its curve. The commitments of the legacy framing are rejected, as
+// the parties of the versions before the framing v1 do not deal with PVSS.
+//
+// It returns the parties whose round 1 keys or round 2 dealing did not verify; the error is only for arguments that
+// cannot be checked, such as a missing message.
+func VerifyDealings(ec elliptic.Curve, parties tss.SortedPartyIDs, threshold int, sessionID []byte, r1msgs, r2msgs []tss.ParsedMessage) ([]*tss.PartyID, error) {
+	if len(r1msgs) != len(parties) || len(r2msgs) != len(parties) {
+		return nil, fmt.Errorf("expected the round 1 and round 2 broadcasts of %d parties", len(parties))
+	}
+	if threshold < 1 || len(parties) <= threshold {
+		return nil, errors.New("the threshold must be at least 1 and below the party count")
+	}
+	r1contents := make([]*KGRound1Message, len(parties))
+	r2contents := make([]*KGRound2Message2, len(parties))
+	for j := range parties {
+		var ok1, ok2 bool
+		if r1msgs[j] != nil && r2msgs[j] != nil {
+			r1contents[j], ok1 = r1msgs[j].Content().(*KGRound1Message)
+			r2contents[j], ok2 = r2msgs[j].Content().(*KGRound2Message2)
+		}
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("missing the round 1 or round 2 broadcast of party %s", parties[j])
+		}
+	}
+
+	culprits := make([]*tss.PartyID, 0, len(parties))
+	recipients := make([]recipient, len(parties))
+	for j, r1msg := range r1contents {
+		rj := recipient{r1msg.UnmarshalPaillierPK(), r1msg.UnmarshalNTilde(), r1msg.UnmarshalH1(), r1msg.UnmarshalH2()}
+		recipients[j] = rj
+		if !r1msg.ValidateBasic() || rj.pk.ValidatePeer(tss.DefaultPaillierModulusLen) != nil {
+			culprits = append(culprits, parties[j])
+			continue
+		}
+		dlnProof1, err1 := r1msg.UnmarshalDLNProof1()
+		dlnProof2, err2 := r1msg.UnmarshalDLNProof2()
+		dlnCtx := proofTranscript(sessionID, 1, parties[j])
+		if err1 != nil || err2 != nil || !dlnProof1.VerifyInTranscript(dlnCtx, rj.h1, rj.h2, rj.NTilde) ||
+			!dlnProof2.VerifyInTranscript(dlnCtx, rj.h2, rj.h1, rj.NTilde) {
+			culprits = append(culprits, parties[j])
+		}
+	}
+	if 0 < len(culprits) {
+		// the proofs of the dealings are sound only against well-formed keys
+		return culprits, nil
+	}
+	for j, r2msg := range r2contents {
+		if !r2msg.ValidateBasic() || !r2msg.IsPVSS() {
+			culprits = append(culprits, parties[j])
+			continue
+		}
+		vs, err := openDealing(ec, commitments.FramingV1, threshold, sessionID, r1contents[j], r2msg)
+		if err != nil || verifyEncryptedShares(proofTranscript(sessionID, 2, parties[j]), parties, recipients, vs, r2msg) != nil {
+			culprits = append(culprits, parties[j])
+		}
+	}
+	return culprits, nil
+}
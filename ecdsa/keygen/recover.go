@@ -0,0 +1,72 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+)
+
+// ReconstructPrivateKey recovers the raw ECDSA private key from the save data of at least t+1 parties.
+//
+// WARNING: this is a break-glass disaster recovery utility for when the threshold cluster is unrecoverable. It defeats
+// the purpose of threshold signing: whoever runs it holds the whole key. Run it on an air-gapped machine, move the
+// funds to a new key as soon as possible and destroy the reconstructed key and the save data afterwards.
+//
+// The result is checked against the ECDSAPub of the save data, so too few or inconsistent shares return an error.
+func ReconstructPrivateKey(saves []LocalPartySaveData) (*big.Int, error) {
+	if len(saves) == 0 {
+		return nil, errors.New("ReconstructPrivateKey: no save data was given")
+	}
+	ecdsaPub := saves[0].ECDSAPub
+	shares := make(vss.Shares, 0, len(saves))
+	for j, save := range saves {
+		if save.ECDSAPub == nil || !save.ECDSAPub.Equals(ecdsaPub) {
+			return nil, fmt.Errorf("ReconstructPrivateKey: save data %d belongs to a different key", j)
+		}
+		shares = append(shares, &vss.Share{ID: save.ShareID, Share: save.Xi})
+	}
+	return ReconstructPrivateKeyFromShares(ecdsaPub, shares)
+}
+
+// ReconstructPrivateKeyFromShares recovers the raw ECDSA private key from at least t+1 exported share fragments, each
+// holding the ShareID and Xi of a party, and checks it against `ecdsaPub`.
+// It carries the same WARNING as ReconstructPrivateKey.
+func ReconstructPrivateKeyFromShares(ecdsaPub *crypto.ECPoint, shares vss.Shares) (*big.Int, error) {
+	if ecdsaPub == nil {
+		return nil, errors.New("ReconstructPrivateKeyFromShares: the public key is required to check the result")
+	}
+	if len(shares) == 0 {
+		return nil, errors.New("ReconstructPrivateKeyFromShares: no shares were given")
+	}
+	seen := make(map[string]struct{}, len(shares))
+	fragments := make(vss.Shares, 0, len(shares))
+	for j, share := range shares {
+		if share == nil || share.ID == nil || share.Share == nil {
+			return nil, fmt.Errorf("ReconstructPrivateKeyFromShares: share %d is incomplete", j)
+		}
+		idHex := hex.EncodeToString(share.ID.Bytes())
+		if _, found := seen[idHex]; found {
+			return nil, fmt.Errorf("ReconstructPrivateKeyFromShares: share %d was given more than once", j)
+		}
+		seen[idHex] = struct{}{}
+		fragments = append(fragments, &vss.Share{Threshold: len(shares) - 1, ID: share.ID, Share: share.Share})
+	}
+	sk, err := fragments.ReConstruct(ecdsaPub.Curve())
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.ScalarBaseMult(ecdsaPub.Curve(), sk).Equals(ecdsaPub) {
+		return nil, errors.New("ReconstructPrivateKeyFromShares: the shares do not reconstruct the key; at least t+1 consistent shares are needed")
+	}
+	return sk, nil
+}
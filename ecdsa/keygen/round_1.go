@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"errors"
 	"math/big"
 
@@ -28,9 +29,9 @@ func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTemp
 		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
 }
 
-func (round *round1) Start() *tss.Error {
+func (round *round1) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 1
 	round.started = true
@@ -40,13 +41,13 @@ func (round *round1) Start() *tss.Error {
 	i := Pi.Index
 
 	// 1. calculate "partial" key share ui
-	ui := common.GetRandomPositiveInt(tss.EC().Params().N)
+	ui := common.GetRandomPositiveInt(round.EC().Params().N)
 
 	round.temp.ui = ui
 
 	// 2. compute the vss shares
 	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.Threshold(), ui, ids)
+	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
@@ -61,7 +62,7 @@ func (round *round1) Start() *tss.Error {
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
-	cmt := cmts.NewHashCommitment(pGFlat...)
+	cmt := cmts.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), pGFlat...)
 
 	// 4. generate Paillier public key E_i, private key and proof
 	// 5-7. generate safe primes for ZKPs used later on
@@ -74,11 +75,14 @@ func (round *round1) Start() *tss.Error {
 	} else if round.save.LocalPreParams.ValidateWithProof() {
 		preParams = &round.save.LocalPreParams
 	} else {
-		preParams, err = GeneratePreParams(round.SafePrimeGenTimeout(), 3)
+		preParams, err = GeneratePreParamsWithModulusLen(round.SafePrimeGenTimeout(), round.PaillierModulusLen(), round.Concurrency())
 		if err != nil {
 			return round.WrapError(errors.New("pre-params generation failed"), Pi)
 		}
 	}
+	if preParams.PaillierSK.N.BitLen() < round.PaillierModulusLen() {
+		return round.WrapError(errors.New("the Paillier modulus of `optionalPreParams` is shorter than the configured length"), Pi).WithCode(tss.CodeBadInput)
+	}
 	round.save.LocalPreParams = *preParams
 	round.save.NTildej[i] = preParams.NTildei
 	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
@@ -92,8 +96,9 @@ func (round *round1) Start() *tss.Error {
 		preParams.P,
 		preParams.Q,
 		preParams.NTildei
-	dlnProof1 := dlnproof.NewDLNProof(h1i, h2i, alpha, p, q, NTildei)
-	dlnProof2 := dlnproof.NewDLNProof(h2i, h1i, beta, p, q, NTildei)
+	dlnCtx := proofTranscript(round.Params().SessionID(), 1, round.PartyID())
+	dlnProof1 := dlnproof.NewDLNProofInTranscript(dlnCtx, h1i, h2i, alpha, p, q, NTildei)
+	dlnProof2 := dlnproof.NewDLNProofInTranscript(dlnCtx, h2i, h1i, beta, p, q, NTildei)
 
 	// for this P: SAVE
 	// - shareID
@@ -117,7 +122,7 @@ func (round *round1) Start() *tss.Error {
 			return round.WrapError(err, Pi)
 		}
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		round.out <- round.WithSessionID(msg)
 	}
 	return nil
 }
@@ -129,7 +134,7 @@ func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round1) Update() (bool, *tss.Error) {
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.kgRound1Messages {
 		if round.ok[j] {
 			continue
@@ -7,17 +7,19 @@
 package keygen
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"math/big"
 	"sync"
 
+	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round2) Start() *tss.Error {
+func (round *round2) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 2
 	round.started = true
@@ -29,6 +31,7 @@ func (round *round2) Start() *tss.Error {
 	h1H2Map := make(map[string]struct{}, len(round.temp.kgRound1Messages)*2)
 	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
 	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
+	sem := common.NewSemaphore(round.Concurrency())
 	wg := new(sync.WaitGroup)
 	for j, msg := range round.temp.kgRound1Messages {
 		r1msg := msg.Content().(*KGRound1Message)
@@ -37,34 +40,58 @@ func (round *round2) Start() *tss.Error {
 			r1msg.UnmarshalH2(),
 			r1msg.UnmarshalNTilde()
 		if H1j.Cmp(H2j) == 0 {
-			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
 		if _, found := h1H2Map[h1JHex]; found {
-			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		if _, found := h1H2Map[h2JHex]; found {
-			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		dlnCtx := proofTranscript(round.Params().SessionID(), 1, msg.GetFrom())
 		wg.Add(2)
 		go func(j int, msg tss.ParsedMessage, r1msg *KGRound1Message, H1j, H2j, NTildej *big.Int) {
-			if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
+			ok := round.VerifyProof("dln1", msg.GetFrom(), func() bool {
+				dlnProof1, err := r1msg.UnmarshalDLNProof1()
+				return err == nil && dlnProof1.VerifyInTranscript(dlnCtx, H1j, H2j, NTildej)
+			})
+			if !ok {
 				dlnProof1FailCulprits[j] = msg.GetFrom()
 			}
 			wg.Done()
 		}(j, msg, r1msg, H1j, H2j, NTildej)
 		go func(j int, msg tss.ParsedMessage, r1msg *KGRound1Message, H1j, H2j, NTildej *big.Int) {
-			if dlnProof2, err := r1msg.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
+			ok := round.VerifyProof("dln2", msg.GetFrom(), func() bool {
+				dlnProof2, err := r1msg.UnmarshalDLNProof2()
+				return err == nil && dlnProof2.VerifyInTranscript(dlnCtx, H2j, H1j, NTildej)
+			})
+			if !ok {
 				dlnProof2FailCulprits[j] = msg.GetFrom()
 			}
 			wg.Done()
 		}(j, msg, r1msg, H1j, H2j, NTildej)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(err)
+	}
 	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
 		if culprit != nil {
-			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+			return round.WrapError(errors.New("dln proof verification failed"), culprit).WithCode(tss.CodeInvalidProof)
 		}
 	}
 	// save NTilde_j, h1_j, h2_j, ...
@@ -79,12 +106,31 @@ func (round *round2) Start() *tss.Error {
 			r1msg.UnmarshalH2(),
 			r1msg.UnmarshalNTilde(),
 			r1msg.UnmarshalCommitment()
+		if err := paillierPK.ValidatePeer(round.PaillierModulusLen()); err != nil {
+			return round.WrapError(err, msg.GetFrom())
+		}
 		round.save.PaillierPKs[j] = paillierPK // used in round 4
 		round.save.NTildej[j] = NTildej
 		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
 		round.temp.KGCs[j] = KGC
 	}
 
+	// with PVSS the shares are broadcast encrypted, see pvss.go
+	if round.PVSS() {
+		recipients := make([]recipient, len(round.Parties().IDs()))
+		for j := range recipients {
+			recipients[j] = recipient{round.save.PaillierPKs[j], round.save.NTildej[j], round.save.H1j[j], round.save.H2j[j]}
+		}
+		encryptedShares, shareProofs, err := encryptShares(proofTranscript(round.Params().SessionID(), 2, round.PartyID()), round.EC(), round.temp.shares, recipients)
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+		r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, encryptedShares, shareProofs)
+		round.temp.kgRound2Message2s[i] = r2msg2
+		round.out <- round.WithSessionID(r2msg2)
+		return nil
+	}
+
 	// 5. p2p send share ij to Pj
 	shares := round.temp.shares
 	for j, Pj := range round.Parties().IDs() {
@@ -95,34 +141,34 @@ func (round *round2) Start() *tss.Error {
 			continue
 		}
 		round.temp.kgRound2Message1s[i] = r2msg1
-		round.out <- r2msg1
+		round.out <- round.WithSessionID(r2msg1)
 	}
 
 	// 7. BROADCAST de-commitments of Shamir poly*G
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, nil, nil)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	round.out <- round.WithSessionID(r2msg2)
 
 	return nil
 }
 
 func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
 	if _, ok := msg.Content().(*KGRound2Message1); ok {
-		return !msg.IsBroadcast()
+		return !msg.IsBroadcast() && !round.PVSS()
 	}
-	if _, ok := msg.Content().(*KGRound2Message2); ok {
-		return msg.IsBroadcast()
+	if r2msg2, ok := msg.Content().(*KGRound2Message2); ok {
+		return msg.IsBroadcast() && r2msg2.IsPVSS() == round.PVSS()
 	}
 	return false
 }
 
-func (round *round2) Update() (bool, *tss.Error) {
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
 	// guard - VERIFY de-commit for all Pj
 	for j, msg := range round.temp.kgRound2Message1s {
 		if round.ok[j] {
 			continue
 		}
-		if msg == nil || !round.CanAccept(msg) {
+		if !round.PVSS() && (msg == nil || !round.CanAccept(msg)) {
 			return false, nil
 		}
 		msg2 := round.temp.kgRound2Message2s[j]
@@ -7,7 +7,9 @@
 package keygen
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/hashicorp/go-multierror"
@@ -15,14 +17,13 @@ import (
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
-	"github.com/binance-chain/tss-lib/crypto/commitments"
 	"github.com/binance-chain/tss-lib/crypto/vss"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round3) Start() *tss.Error {
+func (round *round3) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 3
 	round.started = true
@@ -31,35 +32,34 @@ func (round *round3) Start() *tss.Error {
 	Ps := round.Parties().IDs()
 	PIdx := round.PartyID().Index
 
-	// 1,9. calculate xi
-	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
-	for j := range Ps {
-		if j == PIdx {
-			continue
-		}
-		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
-		share := r2msg1.UnmarshalShare()
-		xi = new(big.Int).Add(xi, share)
-	}
-	round.save.Xi = new(big.Int).Mod(xi, tss.EC().Params().N)
-
 	// 2-3.
 	Vc := make(vss.Vs, round.Threshold()+1)
 	for c := range Vc {
 		Vc[c] = round.temp.vs[c] // ours
 	}
 
+	// with PVSS every dealing is checked for all of the recipients, see pvss.go
+	var recipients []recipient
+	if round.PVSS() {
+		recipients = make([]recipient, len(Ps))
+		for j := range recipients {
+			recipients[j] = recipient{round.save.PaillierPKs[j], round.save.NTildej[j], round.save.H1j[j], round.save.H2j[j]}
+		}
+	}
+
 	// 4-11.
 	type vssOut struct {
 		unWrappedErr error
 		pjVs         vss.Vs
+		share        *big.Int
 	}
+	sem := common.NewSemaphore(round.Concurrency())
 	chs := make([]chan vssOut, len(Ps))
 	for i := range chs {
 		if i == PIdx {
 			continue
 		}
-		chs[i] = make(chan vssOut)
+		chs[i] = make(chan vssOut, 1)
 	}
 	for j := range Ps {
 		if j == PIdx {
@@ -67,33 +67,48 @@ func (round *round3) Start() *tss.Error {
 		}
 		// 6-8.
 		go func(j int, ch chan<- vssOut) {
-			// 4-9.
-			KGCj := round.temp.KGCs[j]
-			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
-			KGDj := r2msg2.UnmarshalDeCommitment()
-			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
-			ok, flatPolyGs := cmtDeCmt.DeCommit()
-			if !ok || flatPolyGs == nil {
-				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+			sem.Acquire()
+			defer sem.Release()
+			if err := ctx.Err(); err != nil {
+				ch <- vssOut{err, nil, nil}
 				return
 			}
-			PjVs, err := crypto.UnFlattenECPoints(tss.EC(), flatPolyGs)
+			// 4-9.
+			r1msg := round.temp.kgRound1Messages[j].Content().(*KGRound1Message)
+			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+			PjVs, err := openDealing(round.EC(), round.Params().CommitmentFraming(), round.Threshold(), round.Params().SessionID(), r1msg, r2msg2)
 			if err != nil {
-				ch <- vssOut{err, nil}
+				ch <- vssOut{err, nil, nil}
 				return
 			}
-			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+			var share *big.Int
+			if round.PVSS() {
+				if err = verifyEncryptedShares(proofTranscript(round.Params().SessionID(), 2, Ps[j]), Ps, recipients, PjVs, r2msg2); err != nil {
+					ch <- vssOut{err, nil, nil}
+					return
+				}
+				if err = ctx.Err(); err != nil {
+					ch <- vssOut{err, nil, nil}
+					return
+				}
+				if share, err = decryptShare(round.EC().Params().N, round.save.PaillierSK, r2msg2.UnmarshalEncryptedShares()[PIdx]); err != nil {
+					ch <- vssOut{err, nil, nil}
+					return
+				}
+			} else {
+				share = round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1).UnmarshalShare()
+			}
 			PjShare := vss.Share{
 				Threshold: round.Threshold(),
 				ID:        round.PartyID().KeyInt(),
-				Share:     r2msg1.UnmarshalShare(),
+				Share:     share,
 			}
-			if ok = PjShare.Verify(round.Threshold(), PjVs); !ok {
-				ch <- vssOut{errors.New("vss verify failed"), nil}
+			if ok := round.VerifyProof("vss-share", Ps[j], func() bool { return PjShare.Verify(round.Threshold(), PjVs) }); !ok {
+				ch <- vssOut{errors.New("vss verify failed"), nil, nil}
 				return
 			}
 			// (9) handled above
-			ch <- vssOut{nil, PjVs}
+			ch <- vssOut{nil, PjVs, share}
 		}(j, chs[j])
 	}
 
@@ -111,6 +126,10 @@ func (round *round3) Start() *tss.Error {
 				culprits = append(culprits, Pj)
 			}
 		}
+		// a done context is not the fault of the peers
+		if err := ctx.Err(); err != nil {
+			return round.WrapError(err)
+		}
 		var multiErr error
 		if len(culprits) > 0 {
 			for _, vssResult := range vssResults {
@@ -122,6 +141,16 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(multiErr, culprits...)
 		}
 	}
+
+	// 1,9. calculate xi
+	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		xi = new(big.Int).Add(xi, vssResults[j].share)
+	}
+	round.save.Xi = new(big.Int).Mod(xi, round.EC().Params().N)
 	{
 		var err error
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
@@ -139,52 +168,44 @@ func (round *round3) Start() *tss.Error {
 			}
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...).WithCode(tss.CodeInvalidMessage)
 		}
 	}
 
 	// 12-16. compute Xj for each Pj
 	{
 		var err error
-		modQ := common.ModInt(tss.EC().Params().N)
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
 		bigXj := round.save.BigXj
 		for j := 0; j < round.PartyCount(); j++ {
 			Pj := round.Parties().IDs()[j]
-			kj := Pj.KeyInt()
-			BigXj := Vc[0]
-			z := new(big.Int).SetInt64(int64(1))
-			for c := 1; c <= round.Threshold(); c++ {
-				z = modQ.Mul(z, kj)
-				BigXj, err = BigXj.Add(Vc[c].ScalarMult(z))
-				if err != nil {
-					culprits = append(culprits, Pj)
-				}
+			if bigXj[j], err = Vc.Evaluate(Pj.KeyInt()); err != nil {
+				culprits = append(culprits, Pj)
 			}
-			bigXj[j] = BigXj
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
+			return round.WrapError(errors.New("evaluating Vc at kj resulted in a point not on the curve"), culprits...).WithCode(tss.CodeInvalidMessage)
 		}
 		round.save.BigXj = bigXj
 	}
 
 	// 17. compute and SAVE the ECDSA public key `y`
-	ecdsaPubKey, err := crypto.NewECPoint(tss.EC(), Vc[0].X(), Vc[0].Y())
+	ecdsaPubKey, err := crypto.NewECPoint(round.EC(), Vc[0].X(), Vc[0].Y())
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
 	}
 	round.save.ECDSAPub = ecdsaPubKey
+	round.save.CurveName = round.CurveName()
 
 	// PRINT public key & private share
-	common.Logger.Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
+	round.Logger().Debug("public key", "key", fmt.Sprintf("%x", ecdsaPubKey))
 
 	// BROADCAST paillier proof for Pi
 	ki := round.PartyID().KeyInt()
 	proof := round.save.PaillierSK.Proof(ki, ecdsaPubKey)
 	r3msg := NewKGRound3Message(round.PartyID(), proof)
 	round.temp.kgRound3Messages[PIdx] = r3msg
-	round.out <- r3msg
+	round.out <- round.WithSessionID(r3msg)
 	return nil
 }
 
@@ -195,7 +216,7 @@ func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round3) Update() (bool, *tss.Error) {
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.kgRound3Messages {
 		if round.ok[j] {
 			continue
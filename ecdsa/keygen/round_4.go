@@ -7,16 +7,17 @@
 package keygen
 
 import (
+	"context"
 	"errors"
+	"time"
 
-	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto/paillier"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round4) Start() *tss.Error {
+func (round *round4) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 4
 	round.started = true
@@ -29,50 +30,43 @@ func (round *round4) Start() *tss.Error {
 
 	// 1-3. (concurrent)
 	// r3 messages are assumed to be available and != nil in this function
-	r3msgs := round.temp.kgRound3Messages
-	chs := make([]chan bool, len(r3msgs))
-	for i := range chs {
-		chs[i] = make(chan bool)
-	}
+	statements := make([]paillier.ProofStatement, 0, len(Ps)-1)
+	js := make([]int, 0, len(Ps)-1)
 	for j, msg := range round.temp.kgRound3Messages {
 		if j == i {
 			continue
 		}
 		r3msg := msg.Content().(*KGRound3Message)
-		go func(prf paillier.Proof, j int, ch chan<- bool) {
-			ppk := round.save.PaillierPKs[j]
-			ok, err := prf.Verify(ppk.N, PIDs[j], ecdsaPub)
-			if err != nil {
-				common.Logger.Error(round.WrapError(err, Ps[j]).Error())
-				ch <- false
-				return
-			}
-			ch <- ok
-		}(r3msg.UnmarshalProofInts(), j, chs[j])
+		statements = append(statements, paillier.ProofStatement{
+			Proof: r3msg.UnmarshalProofInts(),
+			N:     round.save.PaillierPKs[j].N,
+			K:     PIDs[j],
+		})
+		js = append(js, j)
 	}
-
-	// consume unbuffered channels (end the goroutines)
-	for j, ch := range chs {
-		if j == i {
-			round.ok[j] = true
-			continue
+	round.ok[i] = true
+	for n, err := range paillier.BatchVerify(statements, ecdsaPub, round.Concurrency()) {
+		j := js[n]
+		if err != nil && err != paillier.ErrInvalidProof {
+			round.Logger().Error("paillier verify failed", "culprit", Ps[j], "err", err)
 		}
-		round.ok[j] = <-ch
+		round.ok[j] = err == nil
 	}
 	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
 	for j, ok := range round.ok {
 		if !ok {
 			culprits = append(culprits, Ps[j])
-			common.Logger.Warningf("paillier verify failed for party %s", Ps[j])
+			round.Logger().Warn("paillier verify failed", "culprit", Ps[j])
 			continue
 		}
-		common.Logger.Debugf("paillier verify passed for party %s", Ps[j])
+		round.Logger().Debug("paillier verify passed", "peer", Ps[j])
 
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.New("paillier verify failed"), culprits...)
+		return round.WrapError(errors.New("paillier verify failed"), culprits...).WithCode(tss.CodeInvalidProof)
 	}
 
+	round.save.RefreshedAt = time.Now()
 	round.end <- *round.save
 
 	return nil
@@ -83,7 +77,7 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round4) Update() (bool, *tss.Error) {
+func (round *round4) Update(ctx context.Context) (bool, *tss.Error) {
 	// not expecting any incoming messages in this round
 	return false, nil
 }
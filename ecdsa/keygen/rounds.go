@@ -7,11 +7,15 @@
 package keygen
 
 import (
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
 const (
 	TaskName = "ecdsa-keygen"
+
+	// the domain of the hash commitments made in round 1, see commitments.NewHashCommitmentInSession
+	commitmentDomain = TaskName + "/round-1"
 )
 
 type (
@@ -46,6 +50,14 @@ var (
 	_ tss.Round = (*round4)(nil)
 )
 
+// phases names the rounds by their number
+var phases = []string{
+	1: "commitments and Paillier keys",
+	2: "shares and de-commitments",
+	3: "Paillier proofs",
+	4: "finalization",
+}
+
 // ----- //
 
 func (round *base) Params() *tss.Parameters {
@@ -56,6 +68,14 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+func (round *base) TotalRounds() int {
+	return len(phases) - 1
+}
+
+func (round *base) Phase() string {
+	return phases[round.number]
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -94,3 +114,20 @@ func (round *base) resetOK() {
 		round.ok[j] = false
 	}
 }
+
+// resume puts a round restored from a snapshot in the state that Start leaves it in, without running it again
+func (round *base) resume(number int) {
+	round.number = number
+	round.started = true
+	round.resetOK()
+}
+
+// ----- //
+
+// proofTranscript returns the context of the proofs that `prover` makes in round `number`, which binds them to the
+// session `sessionID` of keygen, to the round and to the prover, see zkp.NewSessionTranscript
+func proofTranscript(sessionID []byte, number int, prover *tss.PartyID) *zkp.Transcript {
+	tr := zkp.NewSessionTranscript(TaskName, sessionID, number)
+	tr.AppendInts("prover", prover.KeyInt())
+	return tr
+}
@@ -8,7 +8,10 @@ package keygen
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
@@ -47,6 +50,14 @@ type (
 
 		// used for test assertions (may be discarded)
 		ECDSAPub *crypto.ECPoint // y
+
+		// the name of the curve of the key, see tss.RegisterCurve; it is empty in save data from before it was recorded,
+		// whose key is taken to be on the curve of the party
+		CurveName string
+
+		// the number of refreshes and reshares the key has been through, and when its shares were last (re)generated
+		Epoch       uint64
+		RefreshedAt time.Time
 	}
 )
 
@@ -84,10 +95,12 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
+	newData.CurveName = sourceData.CurveName
+	newData.Epoch, newData.RefreshedAt = sourceData.Epoch, sourceData.RefreshedAt
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
-			common.Logger.Warning("BuildLocalSaveDataSubset: unable to find a signer party in the local save data", id)
+			common.DefaultLogger().Warn("BuildLocalSaveDataSubset: unable to find a signer party in the local save data", "party", id)
 		}
 		newData.Ks[j] = sourceData.Ks[savedIdx]
 		newData.NTildej[j] = sourceData.NTildej[savedIdx]
@@ -98,3 +111,101 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// CheckEpoch returns an error if a peer's `epoch` differs from the epoch of this save data.
+// A peer on an older epoch still holds shares from before a refresh or reshare and must not take part in a session.
+func (save LocalPartySaveData) CheckEpoch(epoch uint64) error {
+	if epoch != save.Epoch {
+		return fmt.Errorf("the peer is on key epoch %d but this party is on epoch %d", epoch, save.Epoch)
+	}
+	return nil
+}
+
+// RefreshDue returns true if the shares were last (re)generated longer than `interval` ago, so that a refresh should be
+// scheduled. Save data without a RefreshedAt time is always due.
+func (save LocalPartySaveData) RefreshDue(interval time.Duration) bool {
+	return save.RefreshedAt.IsZero() || interval <= time.Since(save.RefreshedAt)
+}
+
+// VerifyECDSAPub checks that the public shares BigXj lie on a polynomial of degree `threshold` which interpolates to
+// ECDSAPub at 0, so that any t+1 of the parties will produce signatures under ECDSAPub.
+// It needs no secret material and may be run by anyone holding the public part of the save data.
+func (save LocalPartySaveData) VerifyECDSAPub(threshold int) error {
+	if save.ECDSAPub == nil {
+		return errors.New("VerifyECDSAPub: the save data has no ECDSAPub")
+	}
+	if len(save.Ks) != len(save.BigXj) {
+		return fmt.Errorf("VerifyECDSAPub: len(Ks) != len(BigXj) (%d != %d)", len(save.Ks), len(save.BigXj))
+	}
+	if len(save.Ks) <= threshold {
+		return fmt.Errorf("VerifyECDSAPub: t+1=%d is not satisfied by the key count of %d", threshold+1, len(save.Ks))
+	}
+	if err := save.checkCurve(); err != nil {
+		return err
+	}
+	ks, bigXs := save.Ks[:threshold+1], save.BigXj[:threshold+1]
+	pub, err := interpolateECPoints(big.NewInt(0), ks, bigXs)
+	if err != nil {
+		return err
+	}
+	if !pub.Equals(save.ECDSAPub) {
+		return errors.New("VerifyECDSAPub: the public shares do not reproduce ECDSAPub")
+	}
+	for j := threshold + 1; j < len(save.Ks); j++ {
+		bigXj, err := interpolateECPoints(save.Ks[j], ks, bigXs)
+		if err != nil {
+			return err
+		}
+		if !bigXj.Equals(save.BigXj[j]) {
+			return fmt.Errorf("VerifyECDSAPub: BigXj[%d] is not on the polynomial of the other public shares", j)
+		}
+	}
+	return nil
+}
+
+// checkCurve checks that ECDSAPub is on the curve named by CurveName
+func (save LocalPartySaveData) checkCurve() error {
+	if save.CurveName == "" {
+		return nil
+	}
+	curve, ok := tss.GetCurveName(save.ECDSAPub.Curve())
+	if !ok || curve != save.CurveName {
+		return fmt.Errorf("VerifyECDSAPub: ECDSAPub is not on the curve %q of the save data", save.CurveName)
+	}
+	return nil
+}
+
+// interpolateECPoints evaluates the polynomial "in the exponent" through the points (ks[j], bigXs[j]) at x
+func interpolateECPoints(x *big.Int, ks []*big.Int, bigXs []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	if len(bigXs) == 0 || bigXs[0] == nil {
+		return nil, errors.New("interpolateECPoints: BigXj[0] is nil")
+	}
+	modQ := common.ModInt(bigXs[0].Curve().Params().N)
+	var result *crypto.ECPoint
+	for j, kj := range ks {
+		if bigXs[j] == nil {
+			return nil, fmt.Errorf("interpolateECPoints: BigXj[%d] is nil", j)
+		}
+		lambda := big.NewInt(1)
+		for c, kc := range ks {
+			if c == j {
+				continue
+			}
+			if kc.Cmp(kj) == 0 {
+				return nil, errors.New("interpolateECPoints: index of two parties are equal")
+			}
+			// big.Int Div is calculated as: a/b = a * modInv(b,q)
+			lambda = modQ.Mul(lambda, modQ.Mul(new(big.Int).Sub(x, kc), modQ.ModInverse(new(big.Int).Sub(kj, kc))))
+		}
+		term := bigXs[j].ScalarMult(lambda)
+		if result == nil {
+			result = term
+			continue
+		}
+		var err error
+		if result, err = result.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,215 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	. "github.com/binance-chain/tss-lib/ecdsa/refresh"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		P := NewLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			ended++
+		}
+	}
+
+	oldShares, newShares, mixedShares := make(vss.Shares, 0), make(vss.Shares, 0), make(vss.Shares, 0)
+	for i, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(keys[i].ECDSAPub), "the public key must not change")
+		assert.Equal(t, keys[i].Epoch+1, key.Epoch, "the epoch must be bumped")
+		assert.False(t, key.RefreshedAt.IsZero())
+		assert.NotEqual(t, 0, key.Xi.Cmp(keys[i].Xi), "the share must change")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), key.Xi).Equals(key.BigXj[i]), "X_i must match x_i")
+		for j := range key.BigXj {
+			assert.True(t, key.BigXj[j].Equals(newKeys[0].BigXj[j]), "all parties must agree on X_j")
+		}
+		if i <= testThreshold {
+			oldShares = append(oldShares, &vss.Share{Threshold: testThreshold, ID: keys[i].ShareID, Share: keys[i].Xi})
+			newShares = append(newShares, &vss.Share{Threshold: testThreshold, ID: key.ShareID, Share: key.Xi})
+			if i%2 == 0 {
+				mixedShares = append(mixedShares, &vss.Share{Threshold: testThreshold, ID: keys[i].ShareID, Share: keys[i].Xi})
+			} else {
+				mixedShares = append(mixedShares, &vss.Share{Threshold: testThreshold, ID: key.ShareID, Share: key.Xi})
+			}
+		}
+	}
+
+	// the refreshed shares reconstruct the same private key, but cannot be combined with the old ones
+	oldSecret, err := oldShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	newSecret, err := newShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	mixedSecret, err := mixedShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, newSecret.Cmp(oldSecret))
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), newSecret).Equals(keys[0].ECDSAPub))
+	assert.NotEqual(t, 0, mixedSecret.Cmp(oldSecret))
+}
+
+func TestE2ERemoval(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// remove the first and the last parties from the committee
+	removedKeys := []keygen.LocalPartySaveData{keys[0], keys[len(keys)-1]}
+	removed := tss.UnSortedPartyIDs{pIDs[0], pIDs[len(pIDs)-1]}
+	keys = keys[1 : len(keys)-1]
+	pIDs, err = tss.SortPartyIDs(pIDs[1:len(pIDs)-1].ToUnSorted(), tss.S256())
+	assert.NoError(t, err, "should sort the remaining parties")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		P := NewRemovalLocalParty(params, keys[i], removed, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			ended++
+		}
+	}
+
+	newShares, revokedShares := make(vss.Shares, 0), make(vss.Shares, 0)
+	for i, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(keys[i].ECDSAPub), "the public key must not change")
+		assert.Len(t, key.Ks, len(pIDs), "the save data must shrink to the remaining parties")
+		assert.Len(t, key.BigXj, len(pIDs))
+		assert.Len(t, key.PaillierPKs, len(pIDs))
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), key.Xi).Equals(key.BigXj[i]), "X_i must match x_i")
+		for _, Pj := range removed {
+			for _, kj := range key.Ks {
+				assert.NotEqual(t, 0, kj.Cmp(Pj.KeyInt()), "a removed party must not be in the save data")
+			}
+		}
+		if i <= testThreshold {
+			newShares = append(newShares, &vss.Share{Threshold: testThreshold, ID: key.ShareID, Share: key.Xi})
+		}
+		if i < testThreshold {
+			revokedShares = append(revokedShares, &vss.Share{Threshold: testThreshold, ID: key.ShareID, Share: key.Xi})
+		}
+	}
+	revokedShares = append(revokedShares,
+		&vss.Share{Threshold: testThreshold, ID: removedKeys[0].ShareID, Share: removedKeys[0].Xi})
+
+	// the remaining parties reconstruct the same private key, but the share of a removed party is useless
+	newSecret, err := newShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), newSecret).Equals(keys[0].ECDSAPub))
+	revokedSecret, err := revokedShares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, revokedSecret.Cmp(newSecret))
+}
@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the proactive share refresh protocol: every party deals a Feldman VSS sharing of zero
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+	if err := round.CheckCurveName(round.input.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	// every party of the original keygen must take part, as all of the shares are refreshed; only the removed parties
+	// may be absent
+	ids, removed := round.Parties().IDs(), round.temp.removed
+	if round.input.Xi == nil || len(round.input.Ks) != len(ids)+len(removed) {
+		return round.WrapError(fmt.Errorf("all %d parties of the key must take part in the refresh", len(round.input.Ks)-len(removed))).WithCode(tss.CodeBadInput)
+	}
+	if len(ids) <= round.Threshold() {
+		return round.WrapError(fmt.Errorf("at least %d parties must remain after the refresh", round.Threshold()+1)).WithCode(tss.CodeBadInput)
+	}
+	keyIdx := make(map[string]struct{}, len(round.input.Ks))
+	for _, kj := range round.input.Ks {
+		keyIdx[hex.EncodeToString(kj.Bytes())] = struct{}{}
+	}
+	for _, Pj := range removed {
+		if ids.FindByKey(Pj.KeyInt()) != nil {
+			return round.WrapError(errors.New("a removed party also takes part in the refresh"), Pj).WithCode(tss.CodeBadInput)
+		}
+	}
+	for _, Pjs := range []tss.UnSortedPartyIDs{ids.ToUnSorted(), removed} {
+		for _, Pj := range Pjs {
+			if _, ok := keyIdx[hex.EncodeToString(Pj.Key)]; !ok {
+				return round.WrapError(errors.New("a party was not found in the save data of the key"), Pj).WithCode(tss.CodeBadInput)
+			}
+		}
+	}
+	*round.input = keygen.BuildLocalSaveDataSubset(*round.input, ids)
+	ks := round.input.Ks
+
+	// 1. create a sharing of zero among all parties
+	vs, shares, err := vss.CreateZeroSharing(round.EC(), round.Threshold(), ks)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 2. commit to v_1..v_t
+	flatVs, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	vCmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), flatVs...)
+
+	// 3. populate temp data
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.VD = vCmt.D
+	round.temp.VCs[i] = vCmt.C
+
+	// 4. BROADCAST the commitment
+	r1msg := NewRefreshRound1Message(Pi, vCmt.C)
+	round.temp.rfRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		r1msg := msg.Content().(*RefreshRound1Message)
+		round.temp.VCs[j] = r1msg.UnmarshalVCommitment()
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
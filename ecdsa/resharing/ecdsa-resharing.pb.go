@@ -26,6 +26,7 @@ type DGRound1Message struct {
 	EcdsaPubX            []byte   `protobuf:"bytes,1,opt,name=ecdsa_pub_x,json=ecdsaPubX,proto3" json:"ecdsa_pub_x,omitempty"`
 	EcdsaPubY            []byte   `protobuf:"bytes,2,opt,name=ecdsa_pub_y,json=ecdsaPubY,proto3" json:"ecdsa_pub_y,omitempty"`
 	VCommitment          []byte   `protobuf:"bytes,3,opt,name=v_commitment,json=vCommitment,proto3" json:"v_commitment,omitempty"`
+	Epoch                uint64   `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -77,6 +78,13 @@ func (m *DGRound1Message) GetVCommitment() []byte {
 	return nil
 }
 
+func (m *DGRound1Message) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
 //
 // The Round 2 data is broadcast to other peers of the New Committee in this message.
 type DGRound2Message1 struct {
@@ -326,24 +334,26 @@ func init() {
 func init() { proto.RegisterFile("protob/ecdsa-resharing.proto", fileDescriptor_f7d3ae1dc68dc295) }
 
 var fileDescriptor_f7d3ae1dc68dc295 = []byte{
-	// 303 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xcf, 0x4b, 0xc3, 0x30,
-	0x1c, 0xc5, 0x69, 0xe7, 0x3a, 0x96, 0xd5, 0xcd, 0x05, 0xc1, 0x1c, 0x54, 0x66, 0x40, 0xe8, 0x45,
-	0x47, 0x32, 0x2f, 0x5e, 0x75, 0xe0, 0x49, 0x19, 0xc5, 0x83, 0x7a, 0x09, 0xed, 0x1a, 0xd7, 0x42,
-	0x9b, 0x94, 0xfe, 0x42, 0xff, 0x4a, 0xff, 0x25, 0x69, 0x96, 0x86, 0x95, 0x1d, 0xdf, 0xe7, 0x7d,
-	0x9b, 0xc7, 0xeb, 0x03, 0x97, 0x79, 0x21, 0x2b, 0x19, 0x2e, 0xf9, 0x36, 0x2a, 0x83, 0xbb, 0x82,
-	0x97, 0x71, 0x50, 0x24, 0x62, 0x77, 0xaf, 0x30, 0xae, 0xc0, 0x6c, 0xfd, 0xe2, 0xcb, 0x5a, 0x44,
-	0xe4, 0x95, 0x97, 0x65, 0xb0, 0xe3, 0xf0, 0x1a, 0x4c, 0xd4, 0x2d, 0xcb, 0xeb, 0x90, 0xfd, 0x20,
-	0x6b, 0x61, 0x79, 0xae, 0x3f, 0x56, 0x68, 0x53, 0x87, 0x1f, 0x7d, 0xff, 0x17, 0xd9, 0x7d, 0xff,
-	0x13, 0xde, 0x00, 0xb7, 0x61, 0x5b, 0x99, 0x65, 0x49, 0x95, 0x71, 0x51, 0xa1, 0x81, 0x3a, 0x98,
-	0x34, 0xcf, 0x06, 0xe1, 0x3f, 0x0b, 0x9c, 0xe9, 0x58, 0xaa, 0x63, 0x09, 0xbc, 0x02, 0x20, 0x0f,
-	0x92, 0x34, 0x4d, 0x78, 0xc1, 0x44, 0x17, 0xdb, 0x91, 0x37, 0x78, 0x0b, 0xa6, 0xc6, 0xce, 0x0b,
-	0x29, 0xbf, 0x91, 0xbd, 0x18, 0x78, 0xae, 0x7f, 0xda, 0xd1, 0x4d, 0x0b, 0xe1, 0x05, 0x18, 0x09,
-	0x56, 0x25, 0x69, 0xc4, 0x75, 0xb0, 0x23, 0xde, 0x5b, 0x05, 0xa7, 0xc0, 0x8e, 0x09, 0x3a, 0x51,
-	0xcc, 0x8e, 0x89, 0xd2, 0x14, 0x0d, 0xb5, 0xa6, 0x6d, 0x7c, 0x94, 0x0a, 0xf5, 0x32, 0x23, 0xc8,
-	0x51, 0x6f, 0x8f, 0x3b, 0x42, 0x7a, 0x36, 0x45, 0xa3, 0xbe, 0x4d, 0x31, 0x3c, 0x2a, 0x44, 0xb1,
-	0x67, 0xd8, 0xca, 0x94, 0x3c, 0x07, 0xc3, 0x76, 0x00, 0xae, 0xfb, 0xed, 0x05, 0x7e, 0x3c, 0xba,
-	0xa4, 0x6d, 0xdf, 0x86, 0x45, 0xfc, 0xe0, 0x47, 0x5a, 0xfb, 0xbe, 0xcd, 0xfa, 0x00, 0xe2, 0xb9,
-	0x19, 0xf0, 0x41, 0x7f, 0xfa, 0x34, 0xff, 0x9a, 0xa9, 0x35, 0x96, 0x66, 0xec, 0xd0, 0x51, 0x6b,
-	0xaf, 0xfe, 0x03, 0x00, 0x00, 0xff, 0xff, 0xe8, 0x14, 0x8e, 0x99, 0x0d, 0x02, 0x00, 0x00,
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0x5f, 0x4b, 0xf3, 0x30,
+	0x18, 0xc5, 0x69, 0xf7, 0x8f, 0x3d, 0xeb, 0xbb, 0xbd, 0x0b, 0x03, 0x73, 0xa1, 0x32, 0x0b, 0x42,
+	0x6f, 0x74, 0x24, 0xf3, 0xc6, 0x5b, 0x1d, 0x78, 0xa5, 0x8c, 0xe2, 0x85, 0x7a, 0x13, 0xda, 0x35,
+	0xae, 0x85, 0x2e, 0x29, 0x6d, 0x57, 0xf4, 0x2b, 0xf8, 0xe5, 0xfc, 0x4a, 0xd2, 0x2c, 0x0d, 0x2b,
+	0xbb, 0x3c, 0xbf, 0xf3, 0x34, 0x3d, 0x27, 0x4f, 0xe0, 0x3c, 0xcb, 0x65, 0x29, 0xc3, 0x05, 0xdf,
+	0x44, 0x45, 0x70, 0x93, 0xf3, 0x22, 0x0e, 0xf2, 0x44, 0x6c, 0x6f, 0x15, 0x76, 0x7f, 0x2c, 0x98,
+	0xac, 0x9e, 0x7c, 0xb9, 0x17, 0x11, 0x79, 0xe6, 0x45, 0x11, 0x6c, 0x39, 0xba, 0x84, 0x91, 0x1a,
+	0x66, 0xd9, 0x3e, 0x64, 0x5f, 0xd8, 0x9a, 0x5b, 0x9e, 0xe3, 0x0f, 0x15, 0x5a, 0xef, 0xc3, 0xb7,
+	0xb6, 0xff, 0x8d, 0xed, 0xb6, 0xff, 0x8e, 0xae, 0xc0, 0xa9, 0xd8, 0x46, 0xee, 0x76, 0x49, 0xb9,
+	0xe3, 0xa2, 0xc4, 0x1d, 0x35, 0x30, 0xaa, 0x1e, 0x0d, 0x42, 0x33, 0xe8, 0xf1, 0x4c, 0x6e, 0x62,
+	0xdc, 0x9d, 0x5b, 0x5e, 0xd7, 0x3f, 0x08, 0xf7, 0xd7, 0x82, 0xff, 0x3a, 0x0c, 0xd5, 0x61, 0x08,
+	0xba, 0x00, 0xc8, 0x82, 0x24, 0x4d, 0x13, 0x9e, 0x33, 0xd1, 0x84, 0x69, 0xc8, 0x0b, 0xba, 0x86,
+	0xb1, 0xb1, 0xb3, 0x5c, 0xca, 0x4f, 0x6c, 0xcf, 0x3b, 0x9e, 0xe3, 0xff, 0x6b, 0xe8, 0xba, 0x86,
+	0xe8, 0x0c, 0x06, 0x82, 0x95, 0x49, 0x1a, 0x71, 0x1d, 0xa7, 0x2f, 0x5e, 0x6b, 0x85, 0xc6, 0x60,
+	0xc7, 0x44, 0xc5, 0x70, 0x7c, 0x3b, 0x26, 0x4a, 0x53, 0xdc, 0xd3, 0x9a, 0xd6, 0xbf, 0x8f, 0x52,
+	0xa1, 0x4e, 0x66, 0x04, 0xf7, 0xd5, 0xd9, 0xc3, 0x86, 0x90, 0x96, 0x4d, 0xf1, 0xa0, 0x6d, 0x53,
+	0x17, 0x9d, 0x14, 0xa2, 0xae, 0x67, 0xd8, 0xd2, 0x94, 0x9c, 0x41, 0xaf, 0xde, 0x0b, 0xd7, 0xfd,
+	0x0e, 0xc2, 0xbd, 0x3f, 0x99, 0xa4, 0x75, 0xdf, 0x8a, 0x45, 0xfc, 0xe8, 0x7a, 0xad, 0x43, 0xdf,
+	0x6a, 0x75, 0x04, 0xdd, 0xa9, 0x59, 0xeb, 0x9d, 0xfe, 0xf4, 0x61, 0xfa, 0x31, 0x51, 0x3b, 0x5a,
+	0x98, 0x37, 0x10, 0xf6, 0xd5, 0x23, 0x58, 0xfe, 0x0d, 0x00, 0xdc, 0xc7, 0x33, 0xbf, 0x24, 0x02,
+	0x00, 0x00,
 }
@@ -0,0 +1,130 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// ShareEvidence is the evidence against a member of the old committee that dealt a bad share to a member of the new
+	// committee. It holds everything that the receiver got from the dealer, so that anyone can check it with Verify.
+	ShareEvidence struct {
+		Dealer, Receiver *tss.PartyID
+		Share            *big.Int
+		VCommitment      cmt.HashCommitment
+		VDeCommitment    cmt.HashDeCommitment
+
+		// the session ID of the ceremony, which the commitment is bound to
+		SessionID []byte
+		// whether the ceremony accepted the commitments of the legacy framing, see tss.Parameters.SetLegacyFraming
+		LegacyFraming bool
+
+		// the SignedEnvelopes of the messages of the dealer that carried the fields above, as opened by the receiver with
+		// tss.Parameters.OpenMessage. Without them the receiver could make up the evidence, so they are nil, and the
+		// evidence does not verify, unless the parties have an identity.
+		VCommitmentEnvelope, ShareEnvelope, VDeCommitmentEnvelope []byte
+	}
+
+	// ShareVerificationError is the cause of the *tss.Error that a member of the new committee returns when shares from
+	// the old committee did not verify. The culprits of the *tss.Error are the dealers of the bad shares.
+	ShareVerificationError struct {
+		Evidence []*ShareEvidence
+	}
+)
+
+// Verify returns true if the evidence shows that the dealer misbehaved: either the de-commitment of v_0..v_t' does not
+// open the commitment that the dealer broadcast, or the share does not lie on the committed polynomial. The curve is
+// that of the key being re-shared, and the identity must verify the envelopes of the dealer.
+func (ev *ShareEvidence) Verify(ec elliptic.Curve, newThreshold int, identity tss.Identity) bool {
+	if ev == nil || ev.Dealer == nil || ev.Receiver == nil || ev.Share == nil || !ev.authentic(identity) {
+		return false
+	}
+	cmtDeCmt := cmt.HashCommitDecommit{C: ev.VCommitment, D: ev.VDeCommitment}
+	ok, flatVs := cmtDeCmt.DeCommitInSession(framingOf(ev.LegacyFraming), commitmentDomain, ev.SessionID)
+	if !ok || len(flatVs) != (newThreshold+1)*2 { // they're points so * 2
+		return true
+	}
+	vs, err := crypto.UnFlattenECPoints(ec, flatVs)
+	if err != nil {
+		return true
+	}
+	share := &vss.Share{
+		Threshold: newThreshold,
+		ID:        ev.Receiver.KeyInt(),
+		Share:     ev.Share,
+	}
+	return !share.Verify(newThreshold, vs)
+}
+
+// authentic returns true if the dealer signed the messages that the evidence holds, in the session of the evidence and
+// with the share for the receiver
+func (ev *ShareEvidence) authentic(identity tss.Identity) bool {
+	if identity == nil {
+		return false
+	}
+	open := func(envelope []byte) tss.MessageContent {
+		msg, err := tss.OpenEnvelope(identity, ev.Dealer, ev.Receiver, envelope)
+		if err != nil || !bytes.Equal(msg.GetSessionID(), ev.SessionID) {
+			return nil
+		}
+		return msg.Content()
+	}
+	r1msg, ok := open(ev.VCommitmentEnvelope).(*DGRound1Message)
+	if !ok || r1msg.UnmarshalVCommitment().Cmp(ev.VCommitment) != 0 {
+		return false
+	}
+	// the share alone is sent to the receiver only, so OpenEnvelope has checked that it was for the receiver
+	r3msg1, ok := open(ev.ShareEnvelope).(*DGRound3Message1)
+	if !ok || new(big.Int).SetBytes(r3msg1.GetShare()).Cmp(ev.Share) != 0 {
+		return false
+	}
+	r3msg2, ok := open(ev.VDeCommitmentEnvelope).(*DGRound3Message2)
+	if !ok {
+		return false
+	}
+	deCmt := r3msg2.UnmarshalVDeCommitment()
+	if len(deCmt) != len(ev.VDeCommitment) {
+		return false
+	}
+	for k := range deCmt {
+		if ev.VDeCommitment[k] == nil || deCmt[k].Cmp(ev.VDeCommitment[k]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Code classifies the *tss.Error that wraps the error
+func (err *ShareVerificationError) Code() tss.ErrorCode {
+	return tss.CodeInvalidShare
+}
+
+func (err *ShareVerificationError) Error() string {
+	dealers := make([]*tss.PartyID, len(err.Evidence))
+	for j, ev := range err.Evidence {
+		dealers[j] = ev.Dealer
+	}
+	return fmt.Sprintf("shares from old committee members %v did not pass Verify()", dealers)
+}
+
+// framingOf returns the framing of the commitments that a record of a ceremony opens, given whether the ceremony
+// accepted the legacy framing
+func framingOf(legacy bool) cmt.Framing {
+	if legacy {
+		return cmt.FramingLegacy
+	}
+	return cmt.FramingV1
+}
@@ -0,0 +1,117 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	. "github.com/binance-chain/tss-lib/ecdsa/resharing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestShareEvidence(t *testing.T) {
+	newThreshold := 2
+	pIDs := tss.GenerateTestPartyIDs(newThreshold + 2)
+	dealer, receiver := pIDs[0], pIDs[1]
+
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
+	vs, shares, err := vss.Create(tss.S256(), newThreshold, secret, pIDs[1:].Keys())
+	assert.NoError(t, err)
+	flatVs, err := crypto.FlattenECPoints(vs)
+	assert.NoError(t, err)
+	sessionID := []byte("resharing 1")
+	vCmt := commitments.NewHashCommitmentInSession(commitments.SHA512_256, commitments.FramingV1, "ecdsa-resharing/round-1", sessionID, flatVs...)
+
+	// the dealer signs its messages with its identity key, and the receiver keeps the envelopes
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	identity := &tss.Ed25519Identity{Key: priv, PeerKeys: map[string]ed25519.PublicKey{dealer.Id: pub}}
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), dealer, len(pIDs), newThreshold)
+	assert.NoError(t, err)
+	params.SetSessionID(sessionID)
+	params.SetIdentity(identity)
+	seal := func(msg tss.Message) []byte {
+		envelope, err := params.SealMessage(params.WithSessionID(msg))
+		assert.NoError(t, err)
+		return envelope
+	}
+	sealShare := func(share *big.Int) []byte {
+		return seal(NewDGRound3Message1(receiver, dealer, &vss.Share{Threshold: newThreshold, ID: receiver.KeyInt(), Share: share}))
+	}
+
+	ev := &ShareEvidence{
+		Dealer:        dealer,
+		Receiver:      receiver,
+		Share:         shares[0].Share,
+		VCommitment:   vCmt.C,
+		VDeCommitment: vCmt.D,
+		SessionID:     sessionID,
+
+		VCommitmentEnvelope:   seal(NewDGRound1Message(pIDs[1:], dealer, crypto.ScalarBaseMult(tss.S256(), secret), vCmt.C, 0)),
+		ShareEnvelope:         sealShare(shares[0].Share),
+		VDeCommitmentEnvelope: seal(NewDGRound3Message2(pIDs[1:], dealer, vCmt.D)),
+	}
+	assert.False(t, ev.Verify(tss.S256(), newThreshold, identity), "a good share must not incriminate the dealer")
+
+	badShare := *ev
+	badShare.Share = new(big.Int).Add(ev.Share, big.NewInt(1))
+	badShare.ShareEnvelope = sealShare(badShare.Share)
+	assert.True(t, badShare.Verify(tss.S256(), newThreshold, identity), "a bad share must incriminate the dealer")
+	assert.False(t, badShare.Verify(tss.S256(), newThreshold, nil), "evidence must not verify without the identity of the dealer")
+
+	forged := badShare
+	forged.ShareEnvelope = ev.ShareEnvelope
+	assert.False(t, forged.Verify(tss.S256(), newThreshold, identity), "a share that the dealer did not sign must not incriminate it")
+	forged.ShareEnvelope = nil
+	assert.False(t, forged.Verify(tss.S256(), newThreshold, identity), "a share without its envelope must not incriminate the dealer")
+
+	otherReceiver := badShare
+	otherReceiver.Receiver = pIDs[2]
+	assert.False(t, otherReceiver.Verify(tss.S256(), newThreshold, identity), "a share for another receiver must not incriminate the dealer")
+
+	badDeCmt := *ev
+	badDeCmt.VDeCommitment = commitments.NewHashCommitment(flatVs[2:]...).D
+	badDeCmt.VDeCommitmentEnvelope = seal(NewDGRound3Message2(pIDs[1:], dealer, badDeCmt.VDeCommitment))
+	assert.True(t, badDeCmt.Verify(tss.S256(), newThreshold, identity), "a bad de-commitment must incriminate the dealer")
+
+	otherSession := *ev
+	otherSession.SessionID = []byte("resharing 2")
+	assert.False(t, otherSession.Verify(tss.S256(), newThreshold, identity), "envelopes of another ceremony must not incriminate the dealer")
+
+	otherCmt := commitments.NewHashCommitmentInSession(commitments.SHA512_256, commitments.FramingV1, "ecdsa-resharing/round-1", []byte("resharing 2"), flatVs...)
+	replayed := *ev
+	replayed.VCommitment, replayed.VDeCommitment = otherCmt.C, otherCmt.D
+	replayed.VCommitmentEnvelope = seal(NewDGRound1Message(pIDs[1:], dealer, crypto.ScalarBaseMult(tss.S256(), secret), otherCmt.C, 0))
+	replayed.VDeCommitmentEnvelope = seal(NewDGRound3Message2(pIDs[1:], dealer, otherCmt.D))
+	assert.True(t, replayed.Verify(tss.S256(), newThreshold, identity), "a commitment from another ceremony must incriminate the dealer")
+
+	legacyCmt := commitments.NewHashCommitmentInSession(commitments.SHA512_256, commitments.FramingLegacy, "ecdsa-resharing/round-1", sessionID, flatVs...)
+	legacy := *ev
+	legacy.VCommitment, legacy.VDeCommitment = legacyCmt.C, legacyCmt.D
+	legacy.VCommitmentEnvelope = seal(NewDGRound1Message(pIDs[1:], dealer, crypto.ScalarBaseMult(tss.S256(), secret), legacyCmt.C, 0))
+	legacy.VDeCommitmentEnvelope = seal(NewDGRound3Message2(pIDs[1:], dealer, legacyCmt.D))
+	assert.True(t, legacy.Verify(tss.S256(), newThreshold, identity), "a commitment of the legacy framing must incriminate the dealer")
+	legacy.LegacyFraming = true
+	assert.False(t, legacy.Verify(tss.S256(), newThreshold, identity), "unless the ceremony accepted the legacy framing")
+
+	err = &ShareVerificationError{Evidence: []*ShareEvidence{&badShare}}
+	assert.Contains(t, err.Error(), dealer.String())
+
+	tErr := tss.NewError(errors.Wrap(err, "round 4"), TaskName, 4, receiver, dealer)
+	assert.Equal(t, tss.CodeInvalidShare, tErr.Code(), "the code must be found through wrapping")
+	assert.True(t, tErr.Code().Misbehaviour())
+}
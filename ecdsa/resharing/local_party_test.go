@@ -7,18 +7,22 @@
 package resharing_test
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"runtime"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/vss"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	. "github.com/binance-chain/tss-lib/ecdsa/resharing"
 	"github.com/binance-chain/tss-lib/ecdsa/signing"
@@ -40,8 +44,6 @@ func setUp(level string) {
 func TestE2EConcurrent(t *testing.T) {
 	setUp("info")
 
-	// tss.SetCurve(elliptic.P256())
-
 	threshold, newThreshold := testThreshold, testThreshold
 
 	// PHASE: load keygen fixtures
@@ -54,7 +56,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// init the new parties; re-use the fixture pre-params for speed
 	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
-		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
+		common.DefaultLogger().Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 	}
 	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
 	newP2PCtx := tss.NewPeerContext(newPIDs)
@@ -90,7 +92,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// start the new parties; they will wait for messages
 	for _, P := range newCommittee {
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -98,7 +100,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// start the old parties; they will send messages
 	for _, P := range oldCommittee {
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -111,7 +113,7 @@ func TestE2EConcurrent(t *testing.T) {
 		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
 		select {
 		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			return
 
@@ -149,7 +151,7 @@ func TestE2EConcurrent(t *testing.T) {
 				for j, key := range newKeys {
 					// xj test: BigXj == xj*G
 					xj := key.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
+					gXj := crypto.ScalarBaseMult(tss.S256(), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 				}
@@ -171,11 +173,12 @@ signing:
 	signEndCh := make(chan common.SignatureData, len(signPIDs))
 
 	for j, signPID := range signPIDs {
-		params := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), newThreshold)
+		params, err := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), newThreshold)
+		assert.NoError(t, err)
 		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
 		signParties = append(signParties, P)
 		go func(P *signing.LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				signErrCh <- err
 			}
 		}(P)
@@ -186,7 +189,7 @@ signing:
 		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
 		select {
 		case err := <-signErrCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			return
 
@@ -214,7 +217,7 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := signKeys[0].ECDSAPub.X(), signKeys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.S256(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -231,3 +234,236 @@ signing:
 		}
 	}
 }
+
+func TestE2EConcurrentOverlappingCommittees(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := testThreshold, 1
+
+	// PHASE: load keygen fixtures
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+
+	// the first two old parties stay on in the new committee, which also has one fresh party.
+	// the fixture pre-params of parties outside of the old committee are fresh for this key.
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	stayingPIDs := oldPIDs[:2]
+	newPIDs := tss.UnSortedPartyIDs{tss.GenerateTestPartyIDs(1)[0]}
+	for _, pID := range stayingPIDs {
+		newPIDs = append(newPIDs, tss.NewPartyID(pID.Id, pID.Moniker, pID.KeyInt()))
+	}
+	sortedNewPIDs, err := tss.SortPartyIDs(newPIDs, tss.S256())
+	assert.NoError(t, err, "should sort the new parties")
+	newP2PCtx := tss.NewPeerContext(sortedNewPIDs)
+	newPCount := len(sortedNewPIDs)
+	preParams := func(j int) keygen.LocalPreParams {
+		return fixtures[len(fixtures)-1-j].LocalPreParams
+	}
+
+	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
+	newCommittee := make([]*LocalParty, newPCount)
+	parties := make([]*LocalParty, 0, len(oldPIDs)+newPCount)
+
+	errCh := make(chan *tss.Error, len(oldPIDs)+newPCount)
+	outCh := make(chan tss.Message, len(oldPIDs)+newPCount)
+	endCh := make(chan keygen.LocalPartySaveData, len(oldPIDs)+newPCount)
+
+	updater := test.SharedPartyUpdater
+
+	// a single LocalParty plays both roles for the staying parties
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, len(oldPIDs), threshold, newPCount, newThreshold)
+		var P *LocalParty
+		if newPID := sortedNewPIDs.FindByKey(pID.KeyInt()); newPID != nil {
+			P = NewLocalParty(params, oldKeys[j], outCh, endCh, preParams(newPID.Index)).(*LocalParty)
+			newCommittee[newPID.Index] = P
+		} else {
+			P = NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty)
+		}
+		oldCommittee = append(oldCommittee, P)
+		parties = append(parties, P)
+	}
+	for j, pID := range sortedNewPIDs {
+		if newCommittee[j] != nil {
+			continue
+		}
+		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, len(oldPIDs), threshold, newPCount, newThreshold)
+		save := keygen.NewLocalPartySaveData(newPCount)
+		save.LocalPreParams = preParams(j)
+		P := NewLocalParty(params, save, outCh, endCh).(*LocalParty)
+		newCommittee[j] = P
+		parties = append(parties, P)
+	}
+	// track the rounds that one of the staying parties goes through
+	rounds := make([]int, 0, 5)
+	newCommittee[0].SetProgressCallback(func(progress Progress) {
+		if n := len(rounds); n == 0 || rounds[n-1] != progress.Round {
+			rounds = append(rounds, progress.Round)
+		}
+	})
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, newPCount)
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				t.Fatal("did not expect a msg to have a nil destination during resharing")
+			}
+			if msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					go updater(oldCommittee[destP.Index], msg, errCh)
+				}
+				for _, destP := range dest[len(oldCommittee):] {
+					go updater(newCommittee[destP.Index], msg, errCh)
+				}
+			} else if msg.IsToOldCommittee() {
+				for _, destP := range dest {
+					go updater(oldCommittee[destP.Index], msg, errCh)
+				}
+			} else {
+				for _, destP := range dest {
+					go updater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+
+		case save := <-endCh:
+			if save.Xi != nil {
+				index, err := save.OriginalIndex()
+				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+				newKeys[index] = save
+			}
+			ended++
+		}
+	}
+
+	// the staying parties' old shares are wiped
+	for j := range stayingPIDs {
+		assert.Equal(t, 0, oldKeys[j].Xi.Sign(), "the old share must be zeroed")
+	}
+	shares := make(vss.Shares, 0, newPCount)
+	for j, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(oldKeys[0].ECDSAPub), "the public key must not change")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), key.Xi).Equals(key.BigXj[j]), "ensure BigX_j == g^x_j")
+		shares = append(shares, &vss.Share{Threshold: newThreshold, ID: key.ShareID, Share: key.Xi})
+	}
+	secret, err := shares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), secret).Equals(oldKeys[0].ECDSAPub))
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, rounds)
+
+	// an auditor can check the transcript of the ceremony against the public part of the old key
+	tr := newCommittee[0].Transcript()
+	if assert.NotNil(t, tr) {
+		oldPub := keygen.NewLocalPartySaveData(len(oldKeys[0].Ks))
+		oldPub.Ks, oldPub.BigXj = oldKeys[0].Ks, oldKeys[0].BigXj
+		assert.NoError(t, tr.Verify(oldPub))
+		bz, err := json.Marshal(tr)
+		assert.NoError(t, err)
+		decoded := new(Transcript)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		assert.NoError(t, decoded.Verify())
+		decoded.NewBigXj[0] = decoded.NewBigXj[1]
+		assert.Error(t, decoded.Verify(), "a tampered transcript must fail")
+		for _, field := range []func(tr *Transcript) []*big.Int{
+			func(tr *Transcript) []*big.Int { return tr.OldKs },
+			func(tr *Transcript) []*big.Int { return tr.NewKs },
+			func(tr *Transcript) []*big.Int { return tr.PaillierNs },
+			func(tr *Transcript) []*big.Int { return tr.NTildej },
+			func(tr *Transcript) []*big.Int { return tr.H1j },
+			func(tr *Transcript) []*big.Int { return tr.H2j },
+		} {
+			withNil := new(Transcript)
+			assert.NoError(t, json.Unmarshal(bz, withNil))
+			field(withNil)[len(field(withNil))-1] = nil
+			assert.Error(t, withNil.Verify(), "a transcript with a nil entry must fail")
+		}
+		oldPub.BigXj = append([]*crypto.ECPoint{oldPub.BigXj[1]}, oldPub.BigXj[1:]...)
+		assert.Error(t, tr.Verify(oldPub), "a different old key must fail")
+	}
+	progress := newCommittee[0].Snapshot()
+	assert.Empty(t, progress.WaitingForOld)
+	assert.Empty(t, progress.WaitingForNew)
+}
+
+func TestRoundDeadline(t *testing.T) {
+	setUp("info")
+
+	oldPIDs := tss.GenerateTestPartyIDs(3)
+	newPIDs := tss.GenerateTestPartyIDs(3, len(oldPIDs))
+	oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, newPIDs[0], len(oldPIDs), 1, len(newPIDs), 1)
+
+	out := make(chan tss.Message, len(oldPIDs)+len(newPIDs))
+	end := make(chan keygen.LocalPartySaveData, 1)
+	P := NewLocalParty(params, keygen.NewLocalPartySaveData(len(newPIDs)), out, end).(*LocalParty)
+	P.SetRoundDeadline(time.Millisecond)
+	assert.Nil(t, P.CheckDeadline(), "the deadline must not apply before the party starts")
+
+	if err := P.Start(context.Background()); !assert.Nil(t, err) {
+		return
+	}
+	progress := P.Snapshot()
+	assert.Equal(t, 1, progress.Round)
+	assert.Equal(t, []*tss.PartyID(oldPIDs), progress.WaitingForOld)
+	assert.Empty(t, progress.WaitingForNew)
+
+	time.Sleep(10 * time.Millisecond)
+	err := P.CheckDeadline()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, 1, err.Round())
+		assert.Equal(t, []*tss.PartyID(oldPIDs), err.Culprits())
+	}
+}
+
+func TestValidateQuorumChange(t *testing.T) {
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	newParams := func(threshold, newThreshold int) *tss.ReSharingParameters {
+		return tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, newPIDs[0], len(oldPIDs), threshold, len(newPIDs), newThreshold)
+	}
+
+	assert.NoError(t, ValidateQuorumChange(newParams(testThreshold, testThreshold)))
+	assert.NoError(t, ValidateQuorumChange(newParams(testThreshold, testThreshold), oldKeys[0]))
+
+	err = ValidateQuorumChange(newParams(testThreshold+1, testThreshold))
+	assert.Error(t, err, "too few old parties must fail")
+	err = ValidateQuorumChange(newParams(testThreshold, len(newPIDs)))
+	assert.Error(t, err, "a new threshold of n' must fail")
+	err = ValidateQuorumChange(newParams(testThreshold-1, testThreshold), oldKeys[0])
+	assert.Error(t, err, "an old threshold lower than the key's must fail")
+
+	strangerPIDs := tss.GenerateTestPartyIDs(testThreshold+1, testParticipants)
+	params := tss.NewReSharingParameters(tss.NewPeerContext(strangerPIDs), newP2PCtx, newPIDs[0], len(strangerPIDs), testThreshold, len(newPIDs), testThreshold)
+	assert.NoError(t, ValidateQuorumChange(params))
+	err = ValidateQuorumChange(params, oldKeys[0])
+	if assert.Error(t, err, "old parties that do not hold the key must fail") {
+		assert.Contains(t, err.Error(), "does not hold a share of the key")
+	}
+
+	dupPIDs := append(tss.SortedPartyIDs{}, newPIDs...)
+	dupPIDs[1] = tss.NewPartyID("dup", "dup", newPIDs[0].KeyInt())
+	params = tss.NewReSharingParameters(oldP2PCtx, tss.NewPeerContext(dupPIDs), newPIDs[0], len(oldPIDs), testThreshold, len(dupPIDs), testThreshold)
+	if err = ValidateQuorumChange(params); assert.Error(t, err, "duplicate keys must fail") {
+		assert.Contains(t, err.Error(), "have the same key")
+	}
+}
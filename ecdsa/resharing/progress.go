@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// Progress is a snapshot of the state of a resharing LocalParty, for orchestration layers that drive UIs and retries
+	Progress struct {
+		tss.Progress
+		StartedAt time.Time // when the current round started
+		// members of each committee that this party is still waiting for in the current round
+		WaitingForOld,
+		WaitingForNew []*tss.PartyID
+	}
+
+	progressTracker struct {
+		mtx      sync.Mutex
+		current  Progress
+		callback func(Progress)
+		deadline time.Duration
+	}
+)
+
+// SetProgressCallback sets a function that is called with a Progress snapshot when a round starts and whenever a
+// message is accepted. It is called while the party is locked, so it must not block or call back into the party.
+// It must be set before Start.
+func (p *LocalParty) SetProgressCallback(callback func(Progress)) {
+	p.progress.mtx.Lock()
+	defer p.progress.mtx.Unlock()
+	p.progress.callback = callback
+}
+
+// SetRoundDeadline sets how long each round may wait for messages before CheckDeadline reports the parties that are
+// holding it up. A zero deadline, the default, disables the check.
+func (p *LocalParty) SetRoundDeadline(deadline time.Duration) {
+	p.progress.mtx.Lock()
+	defer p.progress.mtx.Unlock()
+	p.progress.deadline = deadline
+}
+
+// Snapshot returns the latest Progress snapshot of the party; its tss.Progress is that of the last round reported
+func (p *LocalParty) Snapshot() Progress {
+	p.progress.mtx.Lock()
+	defer p.progress.mtx.Unlock()
+	return p.progress.current
+}
+
+// CheckDeadline returns an error naming the outstanding members of both committees as culprits if the current round
+// has waited for messages for longer than the round deadline. Orchestration layers may call it periodically.
+func (p *LocalParty) CheckDeadline() *tss.Error {
+	p.progress.mtx.Lock()
+	defer p.progress.mtx.Unlock()
+	cur := p.progress.current
+	culprits := append(append([]*tss.PartyID{}, cur.WaitingForOld...), cur.WaitingForNew...)
+	if p.progress.deadline <= 0 || len(culprits) == 0 || time.Since(cur.StartedAt) <= p.progress.deadline {
+		return nil
+	}
+	return tss.NewError(fmt.Errorf("round %d did not complete within its deadline of %s", cur.Round, p.progress.deadline),
+		TaskName, cur.Round, p.PartyID(), culprits...).WithCode(tss.CodeTimeout)
+}
+
+// ----- //
+
+// reportProgress records a Progress snapshot of the round and passes it to the progress callback
+func (round *base) reportProgress() {
+	cur := Progress{Progress: tss.Progress{Round: round.number, TotalRounds: round.TotalRounds(), Phase: round.Phase()}}
+	oldPs, newPs := round.OldParties().IDs(), round.NewParties().IDs()
+	for j, ok := range round.oldOK {
+		if !ok {
+			cur.WaitingForOld = append(cur.WaitingForOld, oldPs[j])
+		}
+	}
+	for j, ok := range round.newOK {
+		if !ok {
+			cur.WaitingForNew = append(cur.WaitingForNew, newPs[j])
+		}
+	}
+	round.progress.mtx.Lock()
+	if cur.Round == round.progress.current.Round {
+		cur.StartedAt = round.progress.current.StartedAt
+	} else {
+		cur.StartedAt = time.Now()
+	}
+	round.progress.current = cur
+	callback := round.progress.callback
+	round.progress.mtx.Unlock()
+	if callback != nil {
+		callback(cur)
+	}
+}
@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -19,48 +20,55 @@ import (
 )
 
 // round 1 represents round 1 of the keygen part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySaveData, temp *localTempData, progress *progressTracker, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
 	return &round1{
-		&base{params, temp, input, save, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1}}
+		&base{params, temp, input, save, progress, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1}}
 }
 
-func (round *round1) Start() *tss.Error {
+func (round *round1) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 1
 	round.started = true
+	defer round.reportProgress()
 	round.resetOK() // resets both round.oldOK and round.newOK
 	round.allNewOK()
 
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
+	if err := round.CheckCurveName(round.input.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+	// a member of both committees also receives the messages of the old committee in this round
+	if !round.ReSharingParams().IsNewCommittee() {
+		round.allOldOK()
+	}
 
-	Pi := round.PartyID()
+	Pi := round.oldPartyID()
 	i := Pi.Index
 
 	// 1. PrepareForSigning() -> w_i
 	xi, ks, bigXj := round.input.Xi, round.input.Ks, round.input.BigXj
 	if round.Threshold()+1 > len(ks) {
-		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
+		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), Pi).WithCode(tss.CodeBadInput)
 	}
 	newKs := round.NewParties().IDs().Keys()
-	wi, _ := signing.PrepareForSigning(i, len(round.OldParties().IDs()), xi, ks, bigXj)
+	wi, _ := signing.PrepareForSigning(round.EC(), i, len(round.OldParties().IDs()), xi, ks, bigXj)
 
 	// 2.
-	vi, shares, err := vss.Create(round.NewThreshold(), wi, newKs)
+	vi, shares, err := vss.Create(round.EC(), round.NewThreshold(), wi, newKs)
 	if err != nil {
-		return round.WrapError(err, round.PartyID())
+		return round.WrapError(err, Pi)
 	}
 
 	// 3.
 	flatVis, err := crypto.FlattenECPoints(vi)
 	if err != nil {
-		return round.WrapError(err, round.PartyID())
+		return round.WrapError(err, Pi)
 	}
-	vCmt := commitments.NewHashCommitment(flatVis...)
+	vCmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), flatVis...)
 
 	// 4. populate temp data
 	round.temp.VD = vCmt.D
@@ -68,10 +76,10 @@ func (round *round1) Start() *tss.Error {
 
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
-		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.ECDSAPub, vCmt.C)
+		round.NewParties().IDs().Exclude(Pi), Pi,
+		round.input.ECDSAPub, vCmt.C, round.input.Epoch)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	round.out <- round.WithSessionID(r1msg)
 
 	return nil
 }
@@ -84,7 +92,8 @@ func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round1) Update() (bool, *tss.Error) {
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	defer round.reportProgress()
 	// only the new committee receive in this round
 	if !round.ReSharingParameters.IsNewCommittee() {
 		return true, nil
@@ -101,16 +110,22 @@ func (round *round1) Update() (bool, *tss.Error) {
 
 		// save the ecdsa pub received from the old committee
 		r1msg := round.temp.dgRound1Messages[0].Content().(*DGRound1Message)
-		candidate, err := r1msg.UnmarshalECDSAPub()
+		candidate, err := r1msg.UnmarshalECDSAPub(round.EC())
 		if err != nil {
-			return false, round.WrapError(errors.New("unable to unmarshal the ecdsa pub key"), msg.GetFrom())
+			return false, round.WrapError(errors.New("unable to unmarshal the ecdsa pub key"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		if round.save.ECDSAPub != nil &&
 			!candidate.Equals(round.save.ECDSAPub) {
 			// uh oh - anomaly!
-			return false, round.WrapError(errors.New("ecdsa pub key did not match what we received previously"), msg.GetFrom())
+			return false, round.WrapError(errors.New("ecdsa pub key did not match what we received previously"), msg.GetFrom()).WithCode(tss.CodeEquivocation)
+		}
+		// the old committee must agree on the epoch of the key, which the new committee bumps in round 5
+		epoch := msg.Content().(*DGRound1Message).GetEpoch()
+		if round.save.ECDSAPub != nil && epoch != round.save.Epoch {
+			return false, round.WrapError(errors.New("key epoch did not match what we received previously"), msg.GetFrom()).WithCode(tss.CodeEquivocation)
 		}
 		round.save.ECDSAPub = candidate
+		round.save.Epoch = epoch
 	}
 	return true, nil
 }
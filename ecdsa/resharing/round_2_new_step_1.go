@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/crypto/dlnproof"
@@ -14,12 +15,13 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round2) Start() *tss.Error {
+func (round *round2) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 2
 	round.started = true
+	defer round.reportProgress()
 	round.resetOK() // resets both round.oldOK and round.newOK
 	round.allOldOK()
 
@@ -27,14 +29,14 @@ func (round *round2) Start() *tss.Error {
 		return nil
 	}
 
-	Pi := round.PartyID()
+	Pi := round.newPartyID()
 	i := Pi.Index
 
 	// 2. "broadcast" "ACK" members of the OLD committee
 	r2msg1 := NewDGRound2Message2(
-		round.OldParties().IDs().Exclude(round.PartyID()), round.PartyID())
+		round.OldParties().IDs().Exclude(Pi), Pi)
 	round.temp.dgRound2Message2s[i] = r2msg1
-	round.out <- r2msg1
+	round.out <- round.WithSessionID(r2msg1)
 
 	// 1.
 	// generate Paillier public key E_i, private key and proof
@@ -49,11 +51,14 @@ func (round *round2) Start() *tss.Error {
 		preParams = &round.save.LocalPreParams
 	} else {
 		var err error
-		preParams, err = keygen.GeneratePreParams(round.SafePrimeGenTimeout())
+		preParams, err = keygen.GeneratePreParamsWithModulusLen(round.SafePrimeGenTimeout(), round.PaillierModulusLen())
 		if err != nil {
 			return round.WrapError(errors.New("pre-params generation failed"), Pi)
 		}
 	}
+	if preParams.PaillierSK.N.BitLen() < round.PaillierModulusLen() {
+		return round.WrapError(errors.New("the Paillier modulus of `optionalPreParams` is shorter than the configured length"), Pi).WithCode(tss.CodeBadInput)
+	}
 	round.save.LocalPreParams = *preParams
 	round.save.NTildej[i] = preParams.NTildei
 	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
@@ -72,13 +77,13 @@ func (round *round2) Start() *tss.Error {
 
 	paillierPf := preParams.PaillierSK.Proof(Pi.KeyInt(), round.save.ECDSAPub)
 	r2msg2, err := NewDGRound2Message1(
-		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
+		round.NewParties().IDs().Exclude(Pi), Pi,
 		&preParams.PaillierSK.PublicKey, paillierPf, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
 	round.temp.dgRound2Message1s[i] = r2msg2
-	round.out <- r2msg2
+	round.out <- round.WithSessionID(r2msg2)
 
 	// for this P: SAVE de-commitments, paillier keys for round 2
 	round.save.PaillierSK = preParams.PaillierSK
@@ -103,7 +108,8 @@ func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round2) Update() (bool, *tss.Error) {
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	defer round.reportProgress()
 	if round.ReSharingParams().IsOldCommittee() && round.ReSharingParameters.IsNewCommittee() {
 		// accept messages from new -> old committee
 		for j, msg1 := range round.temp.dgRound2Message2s {
@@ -143,7 +149,7 @@ func (round *round2) Update() (bool, *tss.Error) {
 			round.newOK[j] = true
 		}
 	} else {
-		return false, round.WrapError(errors.New("this party is not in the old or the new committee"), round.PartyID())
+		return false, round.WrapError(errors.New("this party is not in the old or the new committee"), round.PartyID()).WithCode(tss.CodeBadInput)
 	}
 	return true, nil
 }
@@ -7,42 +7,50 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round3) Start() *tss.Error {
+func (round *round3) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 3
 	round.started = true
+	defer round.reportProgress()
 	round.resetOK() // resets both round.oldOK and round.newOK
 	round.allNewOK()
 
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
+	// a member of both committees also receives the messages of the old committee in this round
+	if !round.ReSharingParams().IsNewCommittee() {
+		round.allOldOK()
+	}
 
-	Pi := round.PartyID()
+	Pi := round.oldPartyID()
 	i := Pi.Index
 
-	// 2. send share to Pj from the new committee
+	// 2. send share to Pj from the new committee; a member of both committees keeps its own share
 	for j, Pj := range round.NewParties().IDs() {
 		share := round.temp.NewShares[j]
-		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
-		round.temp.dgRound3Message1s[i] = r3msg1
-		round.out <- r3msg1
+		r3msg1 := NewDGRound3Message1(Pj, Pi, share)
+		if Pj.KeyInt().Cmp(Pi.KeyInt()) == 0 {
+			round.temp.dgRound3Message1s[i] = r3msg1
+			continue
+		}
+		round.out <- round.WithSessionID(r3msg1)
 	}
 
 	vDeCmt := round.temp.VD
 	r3msg2 := NewDGRound3Message2(
-		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
+		round.NewParties().IDs().Exclude(Pi), Pi,
 		vDeCmt)
 	round.temp.dgRound3Message2s[i] = r3msg2
-	round.out <- r3msg2
+	round.out <- round.WithSessionID(r3msg2)
 
 	return nil
 }
@@ -57,7 +65,8 @@ func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round3) Update() (bool, *tss.Error) {
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	defer round.reportProgress()
 	// only the new committee receive in this round
 	if !round.ReSharingParams().IsNewCommittee() {
 		return true, nil
@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"math/big"
@@ -21,12 +22,13 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round4) Start() *tss.Error {
+func (round *round4) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 4
 	round.started = true
+	defer round.reportProgress()
 	round.resetOK() // resets both round.oldOK and round.newOK
 
 	round.allOldOK()
@@ -36,14 +38,18 @@ func (round *round4) Start() *tss.Error {
 		return nil
 	}
 
-	Pi := round.PartyID()
+	Pi := round.newPartyID()
 	i := Pi.Index
 
 	// 1-3. verify paillier & dln proofs, store message pieces, ensure uniqueness of h1j, h2j
 	h1H2Map := make(map[string]struct{}, len(round.temp.dgRound2Message1s)*2)
+	// the Paillier moduli and NTildes must be fresh: unique in the new committee and not re-used from the old key
+	auxMap := make(map[string]struct{}, len(round.temp.dgRound2Message1s)*2)
+	oldAuxMap := round.oldAuxParams()
 	paiProofCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s)) // who caused the error(s)
 	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s))
 	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s))
+	sem := common.NewSemaphore(round.Concurrency())
 	wg := new(sync.WaitGroup)
 	for j, msg := range round.temp.dgRound2Message1s {
 		r2msg1 := msg.Content().(*DGRound2Message1)
@@ -53,43 +59,74 @@ func (round *round4) Start() *tss.Error {
 			r2msg1.UnmarshalH1(),
 			r2msg1.UnmarshalH2()
 		if H1j.Cmp(H2j) == 0 {
-			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
 		if _, found := h1H2Map[h1JHex]; found {
-			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		if _, found := h1H2Map[h2JHex]; found {
-			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		nTildeJHex, paiNJHex := hex.EncodeToString(NTildej.Bytes()), hex.EncodeToString(paiPK.N.Bytes())
+		for _, aux := range []string{nTildeJHex, paiNJHex} {
+			if _, found := oldAuxMap[aux]; found {
+				return round.WrapError(errors.New("this party re-used a Paillier key or NTilde of the old committee"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+			}
+			if _, found := auxMap[aux]; found {
+				return round.WrapError(errors.New("this Paillier key or NTilde was already used by another party"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+			}
+			auxMap[aux] = struct{}{}
+		}
 		wg.Add(3)
 		go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
 			if ok, err := r2msg1.UnmarshalPaillierProof().Verify(paiPK.N, msg.GetFrom().KeyInt(), round.save.ECDSAPub); err != nil || !ok {
 				paiProofCulprits[j] = msg.GetFrom()
-				common.Logger.Warningf("paillier verify failed for party %s", msg.GetFrom(), err)
+				round.Logger().Warn("paillier verify failed", "culprit", msg.GetFrom(), "err", err)
 			}
 			wg.Done()
 		}(j, msg, r2msg1)
 		go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1, H1j, H2j, NTildej *big.Int) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
 			if dlnProof1, err := r2msg1.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
 				dlnProof1FailCulprits[j] = msg.GetFrom()
-				common.Logger.Warningf("dln proof 1 verify failed for party %s", msg.GetFrom(), err)
+				round.Logger().Warn("dln proof 1 verify failed", "culprit", msg.GetFrom(), "err", err)
 			}
 			wg.Done()
 		}(j, msg, r2msg1, H1j, H2j, NTildej)
 		go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1, H1j, H2j, NTildej *big.Int) {
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				wg.Done()
+				return
+			}
 			if dlnProof2, err := r2msg1.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
 				dlnProof2FailCulprits[j] = msg.GetFrom()
-				common.Logger.Warningf("dln proof 2 verify failed for party %s", msg.GetFrom(), err)
+				round.Logger().Warn("dln proof 2 verify failed", "culprit", msg.GetFrom(), "err", err)
 			}
 			wg.Done()
 		}(j, msg, r2msg1, H1j, H2j, NTildej)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(err)
+	}
 	for _, culprit := range append(append(paiProofCulprits, dlnProof1FailCulprits...), dlnProof2FailCulprits...) {
 		if culprit != nil {
-			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+			return round.WrapError(errors.New("dln proof verification failed"), culprit).WithCode(tss.CodeInvalidProof)
 		}
 	}
 	// save NTilde_j, h1_j, h2_j received in NewCommitteeStep1 here
@@ -107,43 +144,64 @@ func (round *round4) Start() *tss.Error {
 	newXi := big.NewInt(0)
 
 	// 5-9.
-	modQ := common.ModInt(tss.EC().Params().N)
 	vjc := make([][]*crypto.ECPoint, len(round.OldParties().IDs()))
+	evidence := make([]*ShareEvidence, 0, len(vjc))
 	for j := 0; j <= len(vjc)-1; j++ { // P1..P_t+1. Ps are indexed from 0 here
 		// 6-7.
 		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
+		r3msg1 := round.temp.dgRound3Message1s[j].Content().(*DGRound3Message1)
 		r3msg2 := round.temp.dgRound3Message2s[j].Content().(*DGRound3Message2)
+		ev := &ShareEvidence{
+			Dealer:        round.Parties().IDs()[j],
+			Receiver:      Pi,
+			Share:         new(big.Int).SetBytes(r3msg1.Share),
+			VCommitment:   r1msg.UnmarshalVCommitment(),
+			VDeCommitment: r3msg2.UnmarshalVDeCommitment(),
+			SessionID:     round.Params().SessionID(),
+			LegacyFraming: round.Params().LegacyFraming(),
 
-		vCj, vDj := r1msg.UnmarshalVCommitment(), r3msg2.UnmarshalVDeCommitment()
+			VCommitmentEnvelope:   tss.Envelope(round.temp.dgRound1Messages[j]),
+			ShareEnvelope:         tss.Envelope(round.temp.dgRound3Message1s[j]),
+			VDeCommitmentEnvelope: tss.Envelope(round.temp.dgRound3Message2s[j]),
+		}
 
 		// 6. unpack flat "v" commitment content
-		vCmtDeCmt := commitments.HashCommitDecommit{C: vCj, D: vDj}
-		ok, flatVs := vCmtDeCmt.DeCommit()
+		vCmtDeCmt := commitments.HashCommitDecommit{C: ev.VCommitment, D: ev.VDeCommitment}
+		ok, flatVs := vCmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, ev.SessionID)
 		if !ok || len(flatVs) != (round.NewThreshold()+1)*2 { // they're points so * 2
-			// TODO collect culprits and return a list of them as per convention
-			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
+			evidence = append(evidence, ev)
+			continue
 		}
-		vj, err := crypto.UnFlattenECPoints(tss.EC(), flatVs)
+		vj, err := crypto.UnFlattenECPoints(round.EC(), flatVs)
 		if err != nil {
-			return round.WrapError(err, round.Parties().IDs()[j])
+			evidence = append(evidence, ev)
+			continue
 		}
 		vjc[j] = vj
 
 		// 8.
-		r3msg1 := round.temp.dgRound3Message1s[j].Content().(*DGRound3Message1)
 		sharej := &vss.Share{
 			Threshold: round.NewThreshold(),
-			ID:        round.PartyID().KeyInt(),
-			Share:     new(big.Int).SetBytes(r3msg1.Share),
+			ID:        Pi.KeyInt(),
+			Share:     ev.Share,
 		}
 		if ok := sharej.Verify(round.NewThreshold(), vj); !ok {
-			// TODO collect culprits and return a list of them as per convention
-			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
+			evidence = append(evidence, ev)
+			continue
 		}
 
 		// 9.
 		newXi = new(big.Int).Add(newXi, sharej.Share)
 	}
+	if len(evidence) > 0 {
+		culprits := make([]*tss.PartyID, len(evidence))
+		blames := make([]*tss.Evidence, len(evidence))
+		for c, ev := range evidence {
+			culprits[c] = ev.Dealer
+			blames[c] = &tss.Evidence{Culprit: ev.Dealer, Code: tss.CodeInvalidShare, Round: round.number, Proof: ev}
+		}
+		return round.WrapError(&ShareVerificationError{Evidence: evidence}, culprits...).WithEvidence(blames...)
+	}
 
 	// 10-13.
 	var err error
@@ -160,7 +218,7 @@ func (round *round4) Start() *tss.Error {
 
 	// 14.
 	if !Vc[0].Equals(round.save.ECDSAPub) {
-		return round.WrapError(errors.New("assertion failed: V_0 != y"), round.PartyID())
+		return round.WrapError(errors.New("assertion failed: V_0 != y"), Pi)
 	}
 
 	// 15-19.
@@ -170,20 +228,13 @@ func (round *round4) Start() *tss.Error {
 	for j := 0; j < round.NewPartyCount(); j++ {
 		Pj := round.NewParties().IDs()[j]
 		kj := Pj.KeyInt()
-		newBigXj := Vc[0]
 		newKs = append(newKs, kj)
-		z := new(big.Int).SetInt64(int64(1))
-		for c := 1; c <= round.NewThreshold(); c++ {
-			z = modQ.Mul(z, kj)
-			newBigXj, err = newBigXj.Add(Vc[c].ScalarMult(z))
-			if err != nil {
-				paiProofCulprits = append(paiProofCulprits, Pj)
-			}
+		if newBigXjs[j], err = vss.Vs(Vc).Evaluate(kj); err != nil {
+			paiProofCulprits = append(paiProofCulprits, Pj)
 		}
-		newBigXjs[j] = newBigXj
 	}
 	if len(paiProofCulprits) > 0 {
-		return round.WrapError(errors2.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))"), paiProofCulprits...)
+		return round.WrapError(errors2.Wrapf(err, "Vc.Evaluate(kj)"), paiProofCulprits...)
 	}
 
 	round.temp.newXi = newXi
@@ -193,7 +244,7 @@ func (round *round4) Start() *tss.Error {
 	// Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg := NewDGRound4Message(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Messages[i] = r4msg
-	round.out <- r4msg
+	round.out <- round.WithSessionID(r4msg)
 
 	return nil
 }
@@ -205,7 +256,8 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round4) Update() (bool, *tss.Error) {
+func (round *round4) Update(ctx context.Context) (bool, *tss.Error) {
+	defer round.reportProgress()
 	// accept messages from new -> old&new committees
 	for j, msg := range round.temp.dgRound4Messages {
 		if round.newOK[j] {
@@ -223,3 +275,20 @@ func (round *round4) NextRound() tss.Round {
 	round.started = false
 	return &round5{round}
 }
+
+// oldAuxParams returns the Paillier moduli and NTildes of the old key, which are known to members of both committees
+func (round *round4) oldAuxParams() map[string]struct{} {
+	aux := make(map[string]struct{})
+	if !round.ReSharingParams().IsOldCommittee() {
+		return aux
+	}
+	for j := range round.input.Ks {
+		if NTildej := round.input.NTildej[j]; NTildej != nil {
+			aux[hex.EncodeToString(NTildej.Bytes())] = struct{}{}
+		}
+		if pkj := round.input.PaillierPKs[j]; pkj != nil {
+			aux[hex.EncodeToString(pkj.N.Bytes())] = struct{}{}
+		}
+	}
+	return aux
+}
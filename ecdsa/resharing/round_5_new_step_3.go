@@ -7,31 +7,36 @@
 package resharing
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round5) Start() *tss.Error {
+func (round *round5) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 5
 	round.started = true
 
 	round.allOldOK()
 	round.allNewOK()
-
-	Pi := round.PartyID()
-	i := Pi.Index
+	round.reportProgress()
 
 	if round.IsNewCommittee() {
+		i := round.newPartyID().Index
+
 		// 21.
 		// for this P: SAVE data
 		round.save.BigXj = round.temp.newBigXjs
 		round.save.ShareID = round.PartyID().KeyInt()
 		round.save.Xi = round.temp.newXi
 		round.save.Ks = round.temp.newKs
+		round.save.CurveName = round.CurveName()
+		round.save.Epoch++
+		round.save.RefreshedAt = time.Now()
 
 		// misc: build list of paillier public keys to save
 		for j, msg := range round.temp.dgRound2Message1s {
@@ -39,9 +44,26 @@ func (round *round5) Start() *tss.Error {
 				continue
 			}
 			r2msg1 := msg.Content().(*DGRound2Message1)
-			round.save.PaillierPKs[j] = r2msg1.UnmarshalPaillierPK()
+			paillierPK := r2msg1.UnmarshalPaillierPK()
+			if err := paillierPK.ValidatePeer(round.PaillierModulusLen()); err != nil {
+				return round.WrapError(err, msg.GetFrom())
+			}
+			round.save.PaillierPKs[j] = paillierPK
+		}
+
+		// 22. refuse to save the new shares unless they reproduce the original ECDSA public key
+		if err := round.save.VerifyECDSAPub(round.NewThreshold()); err != nil {
+			return round.WrapError(err)
+		}
+
+		// 23. keep the public record of the ceremony for auditors
+		transcript, err := round.buildTranscript()
+		if err != nil {
+			return round.WrapError(err)
 		}
-	} else if round.IsOldCommittee() {
+		round.temp.transcript = transcript
+	}
+	if round.IsOldCommittee() {
 		round.input.Xi.SetInt64(0)
 	}
 
@@ -53,7 +75,7 @@ func (round *round5) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round5) Update() (bool, *tss.Error) {
+func (round *round5) Update(ctx context.Context) (bool, *tss.Error) {
 	return false, nil
 }
 
@@ -13,6 +13,9 @@ import (
 
 const (
 	TaskName = "ecdsa-resharing"
+
+	// the domain of the hash commitments made in round 1, see commitments.NewHashCommitmentInSession
+	commitmentDomain = TaskName + "/round-1"
 )
 
 type (
@@ -20,6 +23,7 @@ type (
 		*tss.ReSharingParameters
 		temp        *localTempData
 		input, save *keygen.LocalPartySaveData
+		progress    *progressTracker
 		out         chan<- tss.Message
 		end         chan<- keygen.LocalPartySaveData
 		oldOK,      // old committee "ok" tracker
@@ -52,6 +56,15 @@ var (
 	_ tss.Round = (*round5)(nil)
 )
 
+// phases names the rounds by their number
+var phases = []string{
+	1: "old committee commitments",
+	2: "new committee Paillier keys",
+	3: "old committee shares",
+	4: "new committee ACKs",
+	5: "finalization",
+}
+
 // ----- //
 
 func (round *base) Params() *tss.Parameters {
@@ -66,6 +79,14 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+func (round *base) TotalRounds() int {
+	return len(phases) - 1
+}
+
+func (round *base) Phase() string {
+	return phases[round.number]
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -110,6 +131,16 @@ func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
 
 // ----- //
 
+// oldPartyID returns the PartyID of this party in the old committee, or nil if it is not a member
+func (round *base) oldPartyID() *tss.PartyID {
+	return round.OldParties().IDs().FindByKey(round.PartyID().KeyInt())
+}
+
+// newPartyID returns the PartyID of this party in the new committee, or nil if it is not a member
+func (round *base) newPartyID() *tss.PartyID {
+	return round.NewParties().IDs().FindByKey(round.PartyID().KeyInt())
+}
+
 // `oldOK` tracks parties which have been verified by Update()
 func (round *base) resetOK() {
 	for j := range round.oldOK {
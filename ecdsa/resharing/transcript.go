@@ -0,0 +1,232 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+type (
+	// Transcript is the public record of a resharing ceremony, built by the members of the new committee.
+	// An offline auditor can check it with Verify to confirm that the key was reshared to fresh shares and that the
+	// ECDSA public key is unchanged, without access to any secret material. It may be serialized with encoding/json.
+	Transcript struct {
+		ECDSAPub     *crypto.ECPoint
+		OldKs        []*big.Int
+		NewThreshold int
+		NewKs        []*big.Int
+
+		// the commitments to v_j0..v_jt' dealt by each member of the old committee, and their de-commitments
+		VCommitments   []cmt.HashCommitment
+		VDeCommitments []cmt.HashDeCommitment
+
+		// the session ID of the ceremony, which the commitments are bound to
+		SessionID []byte
+		// whether the ceremony accepted the commitments of the legacy framing, see tss.Parameters.SetLegacyFraming
+		LegacyFraming bool
+
+		// the Paillier keys and NTilde, h1, h2 of each member of the new committee, with their proofs
+		PaillierNs,
+		NTildej,
+		H1j, H2j []*big.Int
+		PaillierProofs []paillier.Proof
+		DLNProofs1,
+		DLNProofs2 []*dlnproof.Proof
+
+		// the public shares of the new committee
+		NewBigXj []*crypto.ECPoint
+	}
+)
+
+// Transcript returns the Transcript of the ceremony. It is available to members of the new committee once the new
+// save data has been sent to the `end` channel, and is nil otherwise.
+func (p *LocalParty) Transcript() *Transcript {
+	return p.temp.transcript
+}
+
+// Verify checks the transcript. When the public part of the old key is given in `optionalOldKey` it also checks that
+// each member of the old committee dealt a sharing of its own share of the old key.
+func (tr *Transcript) Verify(optionalOldKey ...keygen.LocalPartySaveData) error {
+	if tr == nil || tr.ECDSAPub == nil {
+		return errors.New("transcript is empty")
+	}
+	if 1 < len(optionalOldKey) {
+		return errors.New("Transcript.Verify expected 0 or 1 item in `optionalOldKey`")
+	}
+	oldCount, newCount := len(tr.OldKs), len(tr.NewKs)
+	if len(tr.VCommitments) != oldCount || len(tr.VDeCommitments) != oldCount {
+		return errors.New("transcript must hold one commitment and de-commitment per old committee member")
+	}
+	if len(tr.PaillierNs) != newCount || len(tr.NTildej) != newCount || len(tr.H1j) != newCount ||
+		len(tr.H2j) != newCount || len(tr.PaillierProofs) != newCount || len(tr.DLNProofs1) != newCount ||
+		len(tr.DLNProofs2) != newCount || len(tr.NewBigXj) != newCount {
+		return errors.New("transcript must hold the public data of every new committee member")
+	}
+	if newCount <= tr.NewThreshold {
+		return fmt.Errorf("t'+1=%d is not satisfied by the new committee of %d", tr.NewThreshold+1, newCount)
+	}
+	for _, field := range []struct {
+		name string
+		ints []*big.Int
+	}{
+		{"OldKs", tr.OldKs}, {"NewKs", tr.NewKs}, {"PaillierNs", tr.PaillierNs},
+		{"NTildej", tr.NTildej}, {"H1j", tr.H1j}, {"H2j", tr.H2j},
+	} {
+		for j, x := range field.ints {
+			if x == nil {
+				return fmt.Errorf("transcript must not hold a nil entry, found %s[%d]", field.name, j)
+			}
+		}
+	}
+
+	// 1. open the commitments of the old committee
+	vjc := make([][]*crypto.ECPoint, oldCount)
+	for j := range tr.OldKs {
+		cmtDeCmt := cmt.HashCommitDecommit{C: tr.VCommitments[j], D: tr.VDeCommitments[j]}
+		ok, flatVs := cmtDeCmt.DeCommitInSession(framingOf(tr.LegacyFraming), commitmentDomain, tr.SessionID)
+		if !ok || len(flatVs) != (tr.NewThreshold+1)*2 { // they're points so * 2
+			return fmt.Errorf("de-commitment of v_j0..v_jt' failed for old committee member %d", j)
+		}
+		vj, err := crypto.UnFlattenECPoints(tr.ECDSAPub.Curve(), flatVs)
+		if err != nil {
+			return err
+		}
+		vjc[j] = vj
+	}
+
+	// 2. v_j0 must be g^w_j, the Lagrange-weighted share of the old key held by P_j
+	if 0 < len(optionalOldKey) {
+		oldKey := optionalOldKey[0]
+		keysToIndices := make(map[string]int, len(oldKey.Ks))
+		for c, kc := range oldKey.Ks {
+			keysToIndices[hex.EncodeToString(kc.Bytes())] = c
+		}
+		modQ := common.ModInt(tr.ECDSAPub.Curve().Params().N)
+		for j, kj := range tr.OldKs {
+			c, ok := keysToIndices[hex.EncodeToString(kj.Bytes())]
+			if !ok || len(oldKey.BigXj) <= c {
+				return fmt.Errorf("old committee member %d was not found in the old key", j)
+			}
+			lambda := big.NewInt(1)
+			for m, km := range tr.OldKs {
+				if m == j {
+					continue
+				}
+				// big.Int Div is calculated as: a/b = a * modInv(b,q)
+				lambda = modQ.Mul(lambda, modQ.Mul(km, modQ.ModInverse(new(big.Int).Sub(km, kj))))
+			}
+			if !oldKey.BigXj[c].ScalarMult(lambda).Equals(vjc[j][0]) {
+				return fmt.Errorf("old committee member %d did not reshare its share of the old key", j)
+			}
+		}
+	}
+
+	// 3. V_c = sum_j(v_jc) and V_0 = y
+	var err error
+	Vc := make([]*crypto.ECPoint, tr.NewThreshold+1)
+	for c := range Vc {
+		Vc[c] = vjc[0][c]
+		for j := 1; j < oldCount; j++ {
+			if Vc[c], err = Vc[c].Add(vjc[j][c]); err != nil {
+				return err
+			}
+		}
+	}
+	if !Vc[0].Equals(tr.ECDSAPub) {
+		return errors.New("the reshared polynomial does not interpolate to the ECDSA public key")
+	}
+
+	// 4. X_k = sum_c(V_c * k^c) for each new committee member
+	for k, kk := range tr.NewKs {
+		bigXk, err := vss.Vs(Vc).Evaluate(kk)
+		if err != nil {
+			return err
+		}
+		if tr.NewBigXj[k] == nil || !bigXk.Equals(tr.NewBigXj[k]) {
+			return fmt.Errorf("the public share of new committee member %d does not match the reshared polynomial", k)
+		}
+	}
+
+	// 5. the Paillier keys and NTildes of the new committee are unique and proven
+	auxMap := make(map[string]struct{}, newCount*2)
+	for k, kk := range tr.NewKs {
+		if tr.H1j[k].Cmp(tr.H2j[k]) == 0 {
+			return fmt.Errorf("h1j and h2j were equal for new committee member %d", k)
+		}
+		for _, aux := range []*big.Int{tr.NTildej[k], tr.PaillierNs[k]} {
+			auxHex := hex.EncodeToString(aux.Bytes())
+			if _, found := auxMap[auxHex]; found {
+				return fmt.Errorf("new committee member %d re-used a Paillier key or NTilde", k)
+			}
+			auxMap[auxHex] = struct{}{}
+		}
+		if ok, err := tr.PaillierProofs[k].Verify(tr.PaillierNs[k], kk, tr.ECDSAPub); err != nil || !ok {
+			return fmt.Errorf("paillier verify failed for new committee member %d", k)
+		}
+		if tr.DLNProofs1[k] == nil || !tr.DLNProofs1[k].Verify(tr.H1j[k], tr.H2j[k], tr.NTildej[k]) {
+			return fmt.Errorf("dln proof 1 verify failed for new committee member %d", k)
+		}
+		if tr.DLNProofs2[k] == nil || !tr.DLNProofs2[k].Verify(tr.H2j[k], tr.H1j[k], tr.NTildej[k]) {
+			return fmt.Errorf("dln proof 2 verify failed for new committee member %d", k)
+		}
+	}
+	return nil
+}
+
+// ----- //
+
+// buildTranscript collects the Transcript of the ceremony from the messages that a member of the new committee received
+func (round *round5) buildTranscript() (*Transcript, error) {
+	oldCount, newCount := len(round.OldParties().IDs()), round.NewPartyCount()
+	tr := &Transcript{
+		ECDSAPub:       round.save.ECDSAPub,
+		OldKs:          round.OldParties().IDs().Keys(),
+		NewThreshold:   round.NewThreshold(),
+		NewKs:          round.temp.newKs,
+		VCommitments:   make([]cmt.HashCommitment, oldCount),
+		VDeCommitments: make([]cmt.HashDeCommitment, oldCount),
+		SessionID:      round.Params().SessionID(),
+		LegacyFraming:  round.Params().LegacyFraming(),
+		PaillierNs:     make([]*big.Int, newCount),
+		NTildej:        make([]*big.Int, newCount),
+		H1j:            make([]*big.Int, newCount),
+		H2j:            make([]*big.Int, newCount),
+		PaillierProofs: make([]paillier.Proof, newCount),
+		DLNProofs1:     make([]*dlnproof.Proof, newCount),
+		DLNProofs2:     make([]*dlnproof.Proof, newCount),
+		NewBigXj:       round.temp.newBigXjs,
+	}
+	for j := 0; j < oldCount; j++ {
+		tr.VCommitments[j] = round.temp.dgRound1Messages[j].Content().(*DGRound1Message).UnmarshalVCommitment()
+		tr.VDeCommitments[j] = round.temp.dgRound3Message2s[j].Content().(*DGRound3Message2).UnmarshalVDeCommitment()
+	}
+	var err error
+	for k := 0; k < newCount; k++ {
+		r2msg1 := round.temp.dgRound2Message1s[k].Content().(*DGRound2Message1)
+		tr.PaillierNs[k] = r2msg1.UnmarshalPaillierPK().N
+		tr.NTildej[k], tr.H1j[k], tr.H2j[k] = r2msg1.UnmarshalNTilde(), r2msg1.UnmarshalH1(), r2msg1.UnmarshalH2()
+		tr.PaillierProofs[k] = r2msg1.UnmarshalPaillierProof()
+		if tr.DLNProofs1[k], err = r2msg1.UnmarshalDLNProof1(); err != nil {
+			return nil, err
+		}
+		if tr.DLNProofs2[k], err = r2msg1.UnmarshalDLNProof2(); err != nil {
+			return nil, err
+		}
+	}
+	return tr, nil
+}
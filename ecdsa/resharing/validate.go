@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// ValidateQuorumChange is a dry run of the checks that a resharing ceremony with `params` depends on, so that a
+// misconfigured committee change can be caught before any party is started. It returns nil if the change is valid, or
+// an error listing every problem found.
+//
+// It may be run by an operator who is in neither committee. If the save data of the key being reshared is given in
+// `optionalOldKey` (only its public part is used) the old committee and threshold are also checked against the key.
+func ValidateQuorumChange(params *tss.ReSharingParameters, optionalOldKey ...keygen.LocalPartySaveData) error {
+	if 1 < len(optionalOldKey) {
+		panic(errors.New("ValidateQuorumChange: expected 0 or 1 item in `optionalOldKey`"))
+	}
+	if params == nil || params.OldParties() == nil || params.NewParties() == nil {
+		return errors.New("ValidateQuorumChange: the parameters must have an old and a new peer context")
+	}
+	var multiErr error
+	appendErr := func(format string, a ...interface{}) {
+		multiErr = multierror.Append(multiErr, fmt.Errorf(format, a...))
+	}
+	oldIDs, newIDs := params.OldParties().IDs(), params.NewParties().IDs()
+
+	// the old committee: t+1 or more holders of the key must take part to reconstruct the secret in the exponent
+	if len(oldIDs) != params.OldPartyCount() {
+		appendErr("the old party count is %d but the old peer context has %d parties", params.OldPartyCount(), len(oldIDs))
+	}
+	if params.Threshold() < 1 {
+		appendErr("the old threshold must be at least 1, got %d", params.Threshold())
+	}
+	if len(oldIDs) <= params.Threshold() {
+		appendErr("at least t+1 = %d old parties must take part, got %d", params.Threshold()+1, len(oldIDs))
+	}
+	checkPartyKeys(params.EC(), "old", oldIDs, appendErr)
+
+	// the new committee: the new shares are points on a polynomial of degree t', so at least t'+1 parties must hold one
+	if len(newIDs) != params.NewPartyCount() {
+		appendErr("the new party count is %d but the new peer context has %d parties", params.NewPartyCount(), len(newIDs))
+	}
+	if params.NewThreshold() < 1 {
+		appendErr("the new threshold must be at least 1, got %d", params.NewThreshold())
+	}
+	if len(newIDs) <= params.NewThreshold() {
+		appendErr("the new committee must have at least t'+1 = %d parties, got %d", params.NewThreshold()+1, len(newIDs))
+	}
+	checkPartyKeys(params.EC(), "new", newIDs, appendErr)
+
+	if 0 < len(optionalOldKey) {
+		key := optionalOldKey[0]
+		held := make(map[string]bool, len(key.Ks))
+		for _, k := range key.Ks {
+			if k != nil {
+				held[k.Text(16)] = true
+			}
+		}
+		for _, Pj := range oldIDs {
+			if Pj != nil && !held[Pj.KeyInt().Text(16)] {
+				appendErr("old party %s does not hold a share of the key", Pj)
+			}
+		}
+		if len(key.Ks) <= params.Threshold() {
+			appendErr("the key has %d shares, which is not enough for the old threshold %d", len(key.Ks), params.Threshold())
+		} else if err := key.VerifyECDSAPub(params.Threshold()); err != nil {
+			appendErr("the old threshold %d does not match the key: %v", params.Threshold(), err)
+		}
+	}
+	return multiErr
+}
+
+// checkPartyKeys appends an error for each party of a committee whose key cannot be used as its share index
+func checkPartyKeys(ec elliptic.Curve, committee string, ids tss.SortedPartyIDs, appendErr func(string, ...interface{})) {
+	seen := make(map[string]*tss.PartyID, len(ids))
+	for i, Pj := range ids {
+		if Pj == nil {
+			appendErr("%s party %d is nil", committee, i)
+			continue
+		}
+		k := new(big.Int).Mod(Pj.KeyInt(), ec.Params().N)
+		if k.Sign() == 0 {
+			appendErr("%s party %s has a key of zero mod q", committee, Pj)
+			continue
+		}
+		if prev, ok := seen[k.Text(16)]; ok {
+			appendErr("%s parties %s and %s have the same key", committee, prev, Pj)
+			continue
+		}
+		seen[k.Text(16)] = Pj
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// PreSignature is an ECDSA adaptor signature against the adaptor point T = t*G. Anyone who knows t can complete it
+	// to a signature with CompleteAdaptorSignature, and t can be extracted from the pre-signature and that signature
+	// with ExtractAdaptorSecret, which enables atomic swaps and point time locked contracts.
+	PreSignature struct {
+		R     *crypto.ECPoint // R = (sum(gamma_j)*G)^(1/theta) = (1/k)*G
+		RT    *crypto.ECPoint // R_T = (sum(gamma_j)*T)^(1/theta) = (1/k)*T; r = R_T.x
+		S     *big.Int        // s' = k*(m + r*x); the signature has s = s'/t
+		M     *big.Int
+		T     *crypto.ECPoint
+		Proof *AdaptorProof
+	}
+
+	// AdaptorProof shows that R and R_T have the same discrete logarithm to G and T. Nobody knows 1/k, so it is made
+	// of each signer's Gamma_j = gamma_j*G and gamma_j*T with a DLEQ proof, and the theta^-1 that both sums are
+	// scaled by. The DLEQ proofs are bound to the session ID of the ceremony and to the keys Ks of their signers.
+	AdaptorProof struct {
+		SessionID       []byte
+		Ks              []*big.Int
+		ThetaInverse    *big.Int
+		Gammas, GammaTs []*crypto.ECPoint
+		Proofs          []*schnorr.ZKDLEQProof
+	}
+)
+
+// NewAdaptorLocalParty creates a party that signs `msg` like NewLocalParty, but produces a pre-signature against the
+// adaptor point `T` instead of a signature. The SignatureData sent to `end` holds r and s' of the pre-signature;
+// PreSignature returns the whole pre-signature once the ceremony has finished.
+func NewAdaptorLocalParty(
+	msg *big.Int,
+	T *crypto.ECPoint,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- common.SignatureData,
+) tss.Party {
+	p := NewLocalParty(msg, params, key, out, end).(*LocalParty)
+	p.adaptorT = T
+	p.resetTempData(msg)
+	return p
+}
+
+// PreSignature returns the pre-signature of an adaptor signing ceremony. It returns nil until the ceremony has finished.
+func (p *LocalParty) PreSignature() *PreSignature {
+	return p.temp.preSig
+}
+
+// Verify checks that R_T has the same discrete logarithm to T as R has to G
+func (pf *AdaptorProof) Verify(R, RT, T *crypto.ECPoint) bool {
+	if pf == nil || pf.ThetaInverse == nil || len(pf.Gammas) == 0 || len(pf.GammaTs) != len(pf.Gammas) ||
+		len(pf.Proofs) != len(pf.Gammas) || len(pf.Ks) != len(pf.Gammas) {
+		return false
+	}
+	var gamma, gammaT *crypto.ECPoint
+	for j := range pf.Gammas {
+		if pf.Ks[j] == nil || !pf.Proofs[j].VerifyInTranscript(adaptorTranscript(pf.SessionID, pf.Ks[j]), pf.Gammas[j], pf.GammaTs[j], T) {
+			return false
+		}
+		if j == 0 {
+			gamma, gammaT = pf.Gammas[0], pf.GammaTs[0]
+			continue
+		}
+		var err error
+		if gamma, err = gamma.Add(pf.Gammas[j]); err != nil {
+			return false
+		}
+		if gammaT, err = gammaT.Add(pf.GammaTs[j]); err != nil {
+			return false
+		}
+	}
+	return gamma.ScalarMult(pf.ThetaInverse).Equals(R) && gammaT.ScalarMult(pf.ThetaInverse).Equals(RT)
+}
+
+// VerifyPreSignature checks a pre-signature against the ECDSAPub of the keygen save data: s'*R == m*G + r*Y with
+// r = R_T.x, and R_T has the same discrete logarithm to T as R has to G
+func VerifyPreSignature(pre *PreSignature, pub *crypto.ECPoint) bool {
+	if pre == nil || pre.S == nil || pre.M == nil || !pre.R.ValidateBasic() || !pre.RT.ValidateBasic() ||
+		!pre.T.ValidateBasic() || !pub.ValidateBasic() {
+		return false
+	}
+	N := pub.Curve().Params().N
+	r := new(big.Int).Mod(pre.RT.X(), N)
+	if r.Sign() == 0 || pre.S.Sign() <= 0 || pre.S.Cmp(N) >= 0 {
+		return false
+	}
+	expected := pub.ScalarMult(r)
+	if pre.M.Sign() != 0 {
+		var err error
+		if expected, err = expected.Add(crypto.ScalarBaseMult(pub.Curve(), pre.M)); err != nil {
+			return false
+		}
+	}
+	return pre.R.ScalarMult(pre.S).Equals(expected) && pre.Proof.Verify(pre.R, pre.RT, pre.T)
+}
+
+// CompleteAdaptorSignature completes a pre-signature to a signature with the secret t of its adaptor point T
+func CompleteAdaptorSignature(pre *PreSignature, t *big.Int) (*common.SignatureData, error) {
+	if pre == nil || !pre.T.ValidateBasic() {
+		return nil, errors.New("the pre-signature has no adaptor point")
+	}
+	ec := pre.T.Curve()
+	N := ec.Params().N
+	if t == nil || t.Sign() <= 0 || t.Cmp(N) >= 0 || !crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return nil, errors.New("the secret does not match the adaptor point")
+	}
+	modN := common.ModInt(N)
+	data := new(common.SignatureData)
+	setSignature(data, pre.RT, modN.Mul(pre.S, modN.ModInverseCT(t)), pre.M)
+	return data, nil
+}
+
+// ExtractAdaptorSecret recovers the secret t of the adaptor point T from a pre-signature and the signature that was
+// completed from it
+func ExtractAdaptorSecret(pre *PreSignature, sigData *common.SignatureData) (*big.Int, error) {
+	if pre == nil || !pre.T.ValidateBasic() {
+		return nil, errors.New("the pre-signature has no adaptor point")
+	}
+	ec := pre.T.Curve()
+	N := ec.Params().N
+	r, s := new(big.Int).SetBytes(sigData.GetR()), new(big.Int).SetBytes(sigData.GetS())
+	if s.Sign() == 0 || s.Cmp(N) >= 0 || r.Cmp(new(big.Int).Mod(pre.RT.X(), N)) != 0 {
+		return nil, errors.New("the signature was not completed from the pre-signature")
+	}
+	// s may have been normalised to the lower half of the order, which negates t
+	modN := common.ModInt(N)
+	t := modN.Mul(pre.S, modN.ModInverse(s))
+	if crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return t, nil
+	}
+	t.Sub(N, t)
+	if crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return t, nil
+	}
+	return nil, errors.New("the signature was not completed from the pre-signature")
+}
+
+// ----- //
+
+// computeRT checks each gamma_j*T against Gamma_j and returns R_T = (sum(gamma_j)*T)^(1/theta), the nonce point of
+// the signature completed from the pre-signature
+func (round *base) computeRT() (*crypto.ECPoint, *tss.Error) {
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	proof := &AdaptorProof{
+		SessionID:    round.Params().SessionID(),
+		Ks:           round.Parties().IDs().Keys(),
+		ThetaInverse: round.temp.thetaInverse,
+		Gammas:       round.temp.bigGammaJs,
+		GammaTs:      make([]*crypto.ECPoint, len(Ps)),
+		Proofs:       make([]*schnorr.ZKDLEQProof, len(Ps)),
+	}
+	proof.GammaTs[i], proof.Proofs[i] = round.temp.adaptorGammaT, round.temp.adaptorGammaProof
+
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		if !r4msg.HasAdaptorGamma() {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		gammaTj, err := r4msg.UnmarshalAdaptorGamma(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		pf, err := r4msg.UnmarshalAdaptorProof(round.EC())
+		if err != nil || !pf.VerifyInTranscript(adaptorTranscript(proof.SessionID, Pj.KeyInt()), proof.Gammas[j], gammaTj, round.temp.adaptorT) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof.GammaTs[j], proof.Proofs[j] = gammaTj, pf
+	}
+	if len(culprits) > 0 {
+		return nil, round.WrapError(errors.New("failed to verify gamma_j*T"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	RT := proof.GammaTs[0]
+	for j := 1; j < len(Ps); j++ {
+		var err error
+		if RT, err = RT.Add(proof.GammaTs[j]); err != nil {
+			return nil, round.WrapError(err)
+		}
+	}
+	round.temp.adaptorProof = proof
+	return RT.ScalarMult(round.temp.thetaInverse), nil
+}
+
+// adaptorTranscript returns the context of the DLEQ proof of gamma_j*T that the signer with the key `proverKey` makes in
+// round 4 of the signing session `sessionID`
+func adaptorTranscript(sessionID []byte, proverKey *big.Int) *zkp.Transcript {
+	return proofTranscript(sessionID, 4, proverKey)
+}
+
+// savePreSignature sets the pre-signature (r, s') in the round's data and verifies it
+func (round *base) savePreSignature(sumS *big.Int) *tss.Error {
+	pre := &PreSignature{
+		R:     round.temp.bigR,
+		RT:    round.temp.bigRT,
+		S:     sumS,
+		M:     round.temp.m,
+		T:     round.temp.adaptorT,
+		Proof: round.temp.adaptorProof,
+	}
+	if !VerifyPreSignature(pre, round.key.ECDSAPub) {
+		return round.WrapError(errors.New("pre-signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.temp.preSig = pre
+
+	round.data.Signature = append(round.temp.rx.Bytes(), sumS.Bytes()...)
+	round.data.R = round.temp.rx.Bytes()
+	round.data.S = sumS.Bytes()
+	round.data.M = round.temp.m.Bytes()
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestE2EConcurrentAdaptor(t *testing.T) {
+	for _, protocol := range []tss.SigningProtocol{tss.GG18, tss.GG20} {
+		testE2EConcurrentAdaptor(t, protocol)
+	}
+}
+
+func testE2EConcurrentAdaptor(t *testing.T, protocol tss.SigningProtocol) {
+	setUp("info")
+	threshold := testThreshold
+
+	// the adaptor secret of the counterparty
+	secret := common.GetRandomPositiveInt(tss.S256().Params().N)
+	T := crypto.ScalarBaseMult(tss.S256(), secret)
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+		params.SetSigningProtocol(protocol)
+
+		P := NewAdaptorLocalParty(big.NewInt(42), T, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received pre-signature data from %d participants", ended)
+
+				pre := parties[0].PreSignature()
+				assert.NotNil(t, pre)
+				for _, P := range parties {
+					assert.True(t, VerifyPreSignature(P.PreSignature(), keys[0].ECDSAPub), "pre-signature verify must pass")
+				}
+
+				// the pre-signature alone is not a valid signature
+				pk := keys[0].ECDSAPub.ToECDSAPubKey()
+				assert.False(t, ecdsa.Verify(pk, big.NewInt(42).Bytes(), new(big.Int).Mod(pre.RT.X(), tss.S256().Params().N), pre.S))
+
+				// a wrong secret cannot complete it
+				_, err := CompleteAdaptorSignature(pre, new(big.Int).Add(secret, big.NewInt(1)))
+				assert.Error(t, err)
+
+				sig, err := CompleteAdaptorSignature(pre, secret)
+				assert.NoError(t, err)
+				r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+				assert.True(t, ecdsa.Verify(pk, big.NewInt(42).Bytes(), r, s), "ecdsa verify must pass")
+				assert.True(t, Verify(sig, keys[0].ECDSAPub, big.NewInt(42).Bytes()))
+
+				extracted, err := ExtractAdaptorSecret(pre, sig)
+				assert.NoError(t, err)
+				assert.Equal(t, 0, extracted.Cmp(secret), "the extracted secret must match")
+				t.Log("ECDSA adaptor signing test done.")
+
+				break signing
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"context"
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	curveTestParticipants = 3
+	curveTestThreshold    = 1
+)
+
+// TestE2EConcurrentP256 runs keygen and signing over NIST P-256 and checks the output against the standard library
+// and the encodings used by X.509, TLS and JOSE
+func TestE2EConcurrentP256(t *testing.T) {
+	setUp("info")
+	payload := []byte("a TLS handshake transcript, a JWT or a code signing manifest")
+	pub, sigData := keygenAndSignOverCurve(t, "P-256", payload, gocrypto.SHA256)
+
+	// the standard library verifies the SHA-256 digest of the payload
+	stdPub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: pub.X(), Y: pub.Y()}
+	digest := sha256.Sum256(payload)
+	r, s := new(big.Int).SetBytes(sigData.R), new(big.Int).SetBytes(sigData.S)
+	assert.True(t, ecdsa.Verify(stdPub, digest[:], r, s), "the signature must verify with crypto/ecdsa")
+
+	// X.509 / TLS: DER signature and SEC 1 public key
+	der, err := ToDERSignature(sigData)
+	assert.NoError(t, err)
+	var derSig struct{ R, S *big.Int }
+	_, err = asn1.Unmarshal(der, &derSig)
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.Verify(stdPub, digest[:], derSig.R, derSig.S), "the DER signature must verify")
+	x, y := elliptic.Unmarshal(elliptic.P256(), pub.Bytes())
+	assert.True(t, x != nil && x.Cmp(pub.X()) == 0 && y.Cmp(pub.Y()) == 0, "the SEC 1 public key must decode")
+	decoded, err := crypto.NewECPointFromBytes(elliptic.P256(), pub.CompressedBytes())
+	assert.NoError(t, err)
+	assert.True(t, decoded.Equals(pub), "the compressed public key must decode")
+
+	// JWS ES256: fixed size r || s
+	fixed := ToFixedSizeSignature(elliptic.P256(), sigData)
+	assert.Len(t, fixed, 64)
+	assert.Equal(t, 0, r.Cmp(new(big.Int).SetBytes(fixed[:32])))
+	assert.Equal(t, 0, s.Cmp(new(big.Int).SetBytes(fixed[32:])))
+}
+
+// TestE2EConcurrentStark runs keygen and signing over the STARK curve and checks the output under the StarkNet rules
+func TestE2EConcurrentStark(t *testing.T) {
+	setUp("info")
+	payload := []byte("a StarkNet transaction")
+	pub, sigData := keygenAndSignOverCurve(t, stark.Name, payload, gocrypto.SHA256)
+
+	digest := sha256.Sum256(payload)
+	m := stark.HashToMessage(digest[:])
+	assert.Equal(t, 0, m.Cmp(new(big.Int).SetBytes(sigData.M)), "the message must be reduced to 250 bits")
+	stdPub := &ecdsa.PublicKey{Curve: stark.Curve(), X: pub.X(), Y: pub.Y()}
+	r, s := new(big.Int).SetBytes(sigData.R), new(big.Int).SetBytes(sigData.S)
+	assert.True(t, stark.Verify(stdPub, m, r, s), "the signature must verify under the StarkNet rules")
+}
+
+// keygenAndSignOverCurve runs keygen over the registered curve `curveName` and then signs the digest of `payload`
+func keygenAndSignOverCurve(t *testing.T, curveName string, payload []byte, hash gocrypto.Hash) (*crypto.ECPoint, *common.SignatureData) {
+	// the pre-params do not depend on the curve, so they are loaded from the secp256k1 fixtures
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(curveTestParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ec, ok := tss.GetCurveByName(curveName)
+	assert.True(t, ok, "the curve %q must be registered", curveName)
+
+	// PHASE: keygen
+	pIDs := tss.GenerateTestPartyIDs(curveTestParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	keygenParties := make([]tss.Party, 0, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	keygenEndCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), curveTestThreshold)
+		assert.NoError(t, err)
+		params.SetCurve(ec)
+		keygenParties = append(keygenParties, keygen.NewLocalParty(params, outCh, keygenEndCh, fixtures[i].LocalPreParams))
+	}
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	keygenDone := make(chan struct{})
+	go func() {
+		for range pIDs {
+			save := <-keygenEndCh
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err, "should not be an error getting a party's index from save data")
+			keys[index] = save
+		}
+		close(keygenDone)
+	}()
+	runCurveTestParties(t, keygenParties, outCh, errCh, keygenDone)
+	pub := keys[0].ECDSAPub
+	assert.True(t, ec.IsOnCurve(pub.X(), pub.Y()), "the public key must be on %s", curveName)
+	assert.Equal(t, curveName, keys[0].CurveName, "the save data must record the curve")
+
+	// a party on another curve must refuse to sign with the key
+	params, err := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), curveTestThreshold)
+	assert.NoError(t, err)
+	tssErr := NewLocalParty(big.NewInt(42), params, keys[0], outCh, make(chan common.SignatureData, 1)).Start(context.Background())
+	if assert.NotNil(t, tssErr, "signing on the default curve must fail") {
+		assert.Equal(t, tss.CodeBadInput, tssErr.Code())
+	}
+
+	// PHASE: signing
+	m, err := HashMessage(ec, bytes.NewReader(payload), hash)
+	assert.NoError(t, err)
+	signParties := make([]tss.Party, 0, len(pIDs))
+	signEndCh := make(chan common.SignatureData, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), curveTestThreshold)
+		assert.NoError(t, err)
+		params.SetCurve(ec)
+		signParties = append(signParties, NewLocalParty(m, params, keys[i], outCh, signEndCh))
+	}
+	var sigData common.SignatureData
+	signDone := make(chan struct{})
+	go func() {
+		for range pIDs {
+			sigData = <-signEndCh
+		}
+		close(signDone)
+	}()
+	runCurveTestParties(t, signParties, outCh, errCh, signDone)
+	return pub, &sigData
+}
+
+// runCurveTestParties starts the parties and routes their messages until `done` is closed
+func runCurveTestParties(t *testing.T, parties []tss.Party, outCh chan tss.Message, errCh chan *tss.Error, done <-chan struct{}) {
+	updater := test.SharedPartyUpdater
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	for {
+		select {
+		case <-done:
+			return
+
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+		}
+	}
+}
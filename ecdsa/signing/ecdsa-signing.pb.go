@@ -20,7 +20,6 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//
 // Represents a P2P message sent to each party during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message1 struct {
 	C                    []byte   `protobuf:"bytes,1,opt,name=c,proto3" json:"c,omitempty"`
@@ -69,10 +68,10 @@ func (m *SignRound1Message1) GetRangeProofAlice() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message2 struct {
 	Commitment           []byte   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Epoch                uint64   `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -110,7 +109,13 @@ func (m *SignRound1Message2) GetCommitment() []byte {
 	return nil
 }
 
-//
+func (m *SignRound1Message2) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
 // Represents a P2P message sent to each party during Round 2 of the ECDSA TSS signing protocol.
 type SignRound2Message struct {
 	C1                   []byte   `protobuf:"bytes,1,opt,name=c1,proto3" json:"c1,omitempty"`
@@ -175,10 +180,16 @@ func (m *SignRound2Message) GetProofBobWc() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 3 of the ECDSA TSS signing protocol.
 type SignRound3Message struct {
-	Theta                []byte   `protobuf:"bytes,1,opt,name=theta,proto3" json:"theta,omitempty"`
+	Theta []byte `protobuf:"bytes,1,opt,name=theta,proto3" json:"theta,omitempty"`
+	// GG20 only: T_i = sigma_i*G + l_i*H and a proof of knowledge of sigma_i and l_i
+	TX                   []byte   `protobuf:"bytes,2,opt,name=t_x,json=tX,proto3" json:"t_x,omitempty"`
+	TY                   []byte   `protobuf:"bytes,3,opt,name=t_y,json=tY,proto3" json:"t_y,omitempty"`
+	TProofAlphaX         []byte   `protobuf:"bytes,4,opt,name=t_proof_alpha_x,json=tProofAlphaX,proto3" json:"t_proof_alpha_x,omitempty"`
+	TProofAlphaY         []byte   `protobuf:"bytes,5,opt,name=t_proof_alpha_y,json=tProofAlphaY,proto3" json:"t_proof_alpha_y,omitempty"`
+	TProofT              []byte   `protobuf:"bytes,6,opt,name=t_proof_t,json=tProofT,proto3" json:"t_proof_t,omitempty"`
+	TProofU              []byte   `protobuf:"bytes,7,opt,name=t_proof_u,json=tProofU,proto3" json:"t_proof_u,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -216,13 +227,62 @@ func (m *SignRound3Message) GetTheta() []byte {
 	return nil
 }
 
-//
+func (m *SignRound3Message) GetTX() []byte {
+	if m != nil {
+		return m.TX
+	}
+	return nil
+}
+
+func (m *SignRound3Message) GetTY() []byte {
+	if m != nil {
+		return m.TY
+	}
+	return nil
+}
+
+func (m *SignRound3Message) GetTProofAlphaX() []byte {
+	if m != nil {
+		return m.TProofAlphaX
+	}
+	return nil
+}
+
+func (m *SignRound3Message) GetTProofAlphaY() []byte {
+	if m != nil {
+		return m.TProofAlphaY
+	}
+	return nil
+}
+
+func (m *SignRound3Message) GetTProofT() []byte {
+	if m != nil {
+		return m.TProofT
+	}
+	return nil
+}
+
+func (m *SignRound3Message) GetTProofU() []byte {
+	if m != nil {
+		return m.TProofU
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to all parties during Round 4 of the ECDSA TSS signing protocol.
 type SignRound4Message struct {
-	DeCommitment         [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
-	ProofAlphaX          []byte   `protobuf:"bytes,2,opt,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
-	ProofAlphaY          []byte   `protobuf:"bytes,3,opt,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
-	ProofT               []byte   `protobuf:"bytes,4,opt,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+	ProofAlphaX  []byte   `protobuf:"bytes,2,opt,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
+	ProofAlphaY  []byte   `protobuf:"bytes,3,opt,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
+	ProofT       []byte   `protobuf:"bytes,4,opt,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+	// adaptor signing only: gamma_i*T and a proof that it uses the gamma_i of Gamma_i
+	AdaptorGammaX        []byte   `protobuf:"bytes,5,opt,name=adaptor_gamma_x,json=adaptorGammaX,proto3" json:"adaptor_gamma_x,omitempty"`
+	AdaptorGammaY        []byte   `protobuf:"bytes,6,opt,name=adaptor_gamma_y,json=adaptorGammaY,proto3" json:"adaptor_gamma_y,omitempty"`
+	AdaptorProofA1X      []byte   `protobuf:"bytes,7,opt,name=adaptor_proof_a1_x,json=adaptorProofA1X,proto3" json:"adaptor_proof_a1_x,omitempty"`
+	AdaptorProofA1Y      []byte   `protobuf:"bytes,8,opt,name=adaptor_proof_a1_y,json=adaptorProofA1Y,proto3" json:"adaptor_proof_a1_y,omitempty"`
+	AdaptorProofA2X      []byte   `protobuf:"bytes,9,opt,name=adaptor_proof_a2_x,json=adaptorProofA2X,proto3" json:"adaptor_proof_a2_x,omitempty"`
+	AdaptorProofA2Y      []byte   `protobuf:"bytes,10,opt,name=adaptor_proof_a2_y,json=adaptorProofA2Y,proto3" json:"adaptor_proof_a2_y,omitempty"`
+	AdaptorProofZ        []byte   `protobuf:"bytes,11,opt,name=adaptor_proof_z,json=adaptorProofZ,proto3" json:"adaptor_proof_z,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -281,7 +341,55 @@ func (m *SignRound4Message) GetProofT() []byte {
 	return nil
 }
 
-//
+func (m *SignRound4Message) GetAdaptorGammaX() []byte {
+	if m != nil {
+		return m.AdaptorGammaX
+	}
+	return nil
+}
+
+func (m *SignRound4Message) GetAdaptorGammaY() []byte {
+	if m != nil {
+		return m.AdaptorGammaY
+	}
+	return nil
+}
+
+func (m *SignRound4Message) GetAdaptorProofA1X() []byte {
+	if m != nil {
+		return m.AdaptorProofA1X
+	}
+	return nil
+}
+
+func (m *SignRound4Message) GetAdaptorProofA1Y() []byte {
+	if m != nil {
+		return m.AdaptorProofA1Y
+	}
+	return nil
+}
+
+func (m *SignRound4Message) GetAdaptorProofA2X() []byte {
+	if m != nil {
+		return m.AdaptorProofA2X
+	}
+	return nil
+}
+
+func (m *SignRound4Message) GetAdaptorProofA2Y() []byte {
+	if m != nil {
+		return m.AdaptorProofA2Y
+	}
+	return nil
+}
+
+func (m *SignRound4Message) GetAdaptorProofZ() []byte {
+	if m != nil {
+		return m.AdaptorProofZ
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to all parties during Round 5 of the ECDSA TSS signing protocol.
 type SignRound5Message struct {
 	Commitment           []byte   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
@@ -322,7 +430,6 @@ func (m *SignRound5Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 6 of the ECDSA TSS signing protocol.
 type SignRound6Message struct {
 	DeCommitment         [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
@@ -419,7 +526,6 @@ func (m *SignRound6Message) GetVProofU() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 7 of the ECDSA TSS signing protocol.
 type SignRound7Message struct {
 	Commitment           []byte   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
@@ -460,7 +566,6 @@ func (m *SignRound7Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 8 of the ECDSA TSS signing protocol.
 type SignRound8Message struct {
 	DeCommitment         [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
@@ -501,10 +606,10 @@ func (m *SignRound8Message) GetDeCommitment() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 9 of the ECDSA TSS signing protocol.
 type SignRound9Message struct {
 	S                    []byte   `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+	L                    []byte   `protobuf:"bytes,2,opt,name=l,proto3" json:"l,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -542,6 +647,238 @@ func (m *SignRound9Message) GetS() []byte {
 	return nil
 }
 
+func (m *SignRound9Message) GetL() []byte {
+	if m != nil {
+		return m.L
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 5 of the GG20 ECDSA TSS signing protocol.
+// Proves that the sender's share of R is consistent with the encryption of k_i that the recipient received in Round 1.
+type SignRound5GG20Message1 struct {
+	PdlProof             [][]byte `protobuf:"bytes,1,rep,name=pdl_proof,json=pdlProof,proto3" json:"pdl_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRound5GG20Message1) Reset()         { *m = SignRound5GG20Message1{} }
+func (m *SignRound5GG20Message1) String() string { return proto.CompactTextString(m) }
+func (*SignRound5GG20Message1) ProtoMessage()    {}
+func (*SignRound5GG20Message1) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f861bfc687bec19, []int{10}
+}
+
+func (m *SignRound5GG20Message1) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRound5GG20Message1.Unmarshal(m, b)
+}
+func (m *SignRound5GG20Message1) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRound5GG20Message1.Marshal(b, m, deterministic)
+}
+func (m *SignRound5GG20Message1) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRound5GG20Message1.Merge(m, src)
+}
+func (m *SignRound5GG20Message1) XXX_Size() int {
+	return xxx_messageInfo_SignRound5GG20Message1.Size(m)
+}
+func (m *SignRound5GG20Message1) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRound5GG20Message1.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRound5GG20Message1 proto.InternalMessageInfo
+
+func (m *SignRound5GG20Message1) GetPdlProof() [][]byte {
+	if m != nil {
+		return m.PdlProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 5 of the GG20 ECDSA TSS signing protocol.
+type SignRound5GG20Message2 struct {
+	RBarX                []byte   `protobuf:"bytes,1,opt,name=r_bar_x,json=rBarX,proto3" json:"r_bar_x,omitempty"`
+	RBarY                []byte   `protobuf:"bytes,2,opt,name=r_bar_y,json=rBarY,proto3" json:"r_bar_y,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRound5GG20Message2) Reset()         { *m = SignRound5GG20Message2{} }
+func (m *SignRound5GG20Message2) String() string { return proto.CompactTextString(m) }
+func (*SignRound5GG20Message2) ProtoMessage()    {}
+func (*SignRound5GG20Message2) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f861bfc687bec19, []int{11}
+}
+
+func (m *SignRound5GG20Message2) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRound5GG20Message2.Unmarshal(m, b)
+}
+func (m *SignRound5GG20Message2) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRound5GG20Message2.Marshal(b, m, deterministic)
+}
+func (m *SignRound5GG20Message2) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRound5GG20Message2.Merge(m, src)
+}
+func (m *SignRound5GG20Message2) XXX_Size() int {
+	return xxx_messageInfo_SignRound5GG20Message2.Size(m)
+}
+func (m *SignRound5GG20Message2) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRound5GG20Message2.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRound5GG20Message2 proto.InternalMessageInfo
+
+func (m *SignRound5GG20Message2) GetRBarX() []byte {
+	if m != nil {
+		return m.RBarX
+	}
+	return nil
+}
+
+func (m *SignRound5GG20Message2) GetRBarY() []byte {
+	if m != nil {
+		return m.RBarY
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 6 of the GG20 ECDSA TSS signing protocol.
+type SignRound6GG20Message struct {
+	SX                   []byte   `protobuf:"bytes,1,opt,name=s_x,json=sX,proto3" json:"s_x,omitempty"`
+	SY                   []byte   `protobuf:"bytes,2,opt,name=s_y,json=sY,proto3" json:"s_y,omitempty"`
+	StProofA1X           []byte   `protobuf:"bytes,3,opt,name=st_proof_a1_x,json=stProofA1X,proto3" json:"st_proof_a1_x,omitempty"`
+	StProofA1Y           []byte   `protobuf:"bytes,4,opt,name=st_proof_a1_y,json=stProofA1Y,proto3" json:"st_proof_a1_y,omitempty"`
+	StProofA2X           []byte   `protobuf:"bytes,5,opt,name=st_proof_a2_x,json=stProofA2X,proto3" json:"st_proof_a2_x,omitempty"`
+	StProofA2Y           []byte   `protobuf:"bytes,6,opt,name=st_proof_a2_y,json=stProofA2Y,proto3" json:"st_proof_a2_y,omitempty"`
+	StProofZ1            []byte   `protobuf:"bytes,7,opt,name=st_proof_z1,json=stProofZ1,proto3" json:"st_proof_z1,omitempty"`
+	StProofZ2            []byte   `protobuf:"bytes,8,opt,name=st_proof_z2,json=stProofZ2,proto3" json:"st_proof_z2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRound6GG20Message) Reset()         { *m = SignRound6GG20Message{} }
+func (m *SignRound6GG20Message) String() string { return proto.CompactTextString(m) }
+func (*SignRound6GG20Message) ProtoMessage()    {}
+func (*SignRound6GG20Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f861bfc687bec19, []int{12}
+}
+
+func (m *SignRound6GG20Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRound6GG20Message.Unmarshal(m, b)
+}
+func (m *SignRound6GG20Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRound6GG20Message.Marshal(b, m, deterministic)
+}
+func (m *SignRound6GG20Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRound6GG20Message.Merge(m, src)
+}
+func (m *SignRound6GG20Message) XXX_Size() int {
+	return xxx_messageInfo_SignRound6GG20Message.Size(m)
+}
+func (m *SignRound6GG20Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRound6GG20Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRound6GG20Message proto.InternalMessageInfo
+
+func (m *SignRound6GG20Message) GetSX() []byte {
+	if m != nil {
+		return m.SX
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetSY() []byte {
+	if m != nil {
+		return m.SY
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetStProofA1X() []byte {
+	if m != nil {
+		return m.StProofA1X
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetStProofA1Y() []byte {
+	if m != nil {
+		return m.StProofA1Y
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetStProofA2X() []byte {
+	if m != nil {
+		return m.StProofA2X
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetStProofA2Y() []byte {
+	if m != nil {
+		return m.StProofA2Y
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetStProofZ1() []byte {
+	if m != nil {
+		return m.StProofZ1
+	}
+	return nil
+}
+
+func (m *SignRound6GG20Message) GetStProofZ2() []byte {
+	if m != nil {
+		return m.StProofZ2
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 7 of the GG20 ECDSA TSS signing protocol.
+type SignRound7GG20Message struct {
+	S                    []byte   `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRound7GG20Message) Reset()         { *m = SignRound7GG20Message{} }
+func (m *SignRound7GG20Message) String() string { return proto.CompactTextString(m) }
+func (*SignRound7GG20Message) ProtoMessage()    {}
+func (*SignRound7GG20Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f861bfc687bec19, []int{13}
+}
+
+func (m *SignRound7GG20Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRound7GG20Message.Unmarshal(m, b)
+}
+func (m *SignRound7GG20Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRound7GG20Message.Marshal(b, m, deterministic)
+}
+func (m *SignRound7GG20Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRound7GG20Message.Merge(m, src)
+}
+func (m *SignRound7GG20Message) XXX_Size() int {
+	return xxx_messageInfo_SignRound7GG20Message.Size(m)
+}
+func (m *SignRound7GG20Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRound7GG20Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRound7GG20Message proto.InternalMessageInfo
+
+func (m *SignRound7GG20Message) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignRound1Message1)(nil), "SignRound1Message1")
 	proto.RegisterType((*SignRound1Message2)(nil), "SignRound1Message2")
@@ -553,35 +890,58 @@ func init() {
 	proto.RegisterType((*SignRound7Message)(nil), "SignRound7Message")
 	proto.RegisterType((*SignRound8Message)(nil), "SignRound8Message")
 	proto.RegisterType((*SignRound9Message)(nil), "SignRound9Message")
+	proto.RegisterType((*SignRound5GG20Message1)(nil), "SignRound5GG20Message1")
+	proto.RegisterType((*SignRound5GG20Message2)(nil), "SignRound5GG20Message2")
+	proto.RegisterType((*SignRound6GG20Message)(nil), "SignRound6GG20Message")
+	proto.RegisterType((*SignRound7GG20Message)(nil), "SignRound7GG20Message")
 }
 
 func init() { proto.RegisterFile("protob/ecdsa-signing.proto", fileDescriptor_5f861bfc687bec19) }
 
 var fileDescriptor_5f861bfc687bec19 = []byte{
-	// 395 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x93, 0x5f, 0x4b, 0xe3, 0x40,
-	0x14, 0xc5, 0x49, 0xfa, 0xff, 0x6e, 0xba, 0xa5, 0xc3, 0xc2, 0x0e, 0x5d, 0x58, 0xba, 0xb3, 0x2c,
-	0x74, 0x17, 0xd6, 0x92, 0xb4, 0x6a, 0x7d, 0xb4, 0x3e, 0x2b, 0xa5, 0x56, 0xb4, 0xbe, 0x84, 0x64,
-	0x32, 0xa6, 0x01, 0x9b, 0x09, 0x49, 0x5a, 0xed, 0x47, 0xf1, 0xc9, 0xaf, 0x2a, 0x9d, 0x64, 0xca,
-	0xb4, 0x15, 0xd4, 0x37, 0x1f, 0xef, 0x3d, 0xbf, 0x3b, 0xe7, 0xce, 0x81, 0x0b, 0xad, 0x28, 0xe6,
-	0x29, 0x77, 0xbb, 0x8c, 0x7a, 0x89, 0xf3, 0x3f, 0x09, 0xfc, 0x30, 0x08, 0xfd, 0x03, 0xd1, 0x24,
-	0x17, 0x80, 0x2e, 0x03, 0x3f, 0x1c, 0xf3, 0x45, 0xe8, 0x99, 0xe7, 0x2c, 0x49, 0x1c, 0x9f, 0x99,
-	0xc8, 0x00, 0x8d, 0x62, 0xad, 0xad, 0x75, 0x8c, 0xb1, 0x46, 0xd1, 0x3f, 0x68, 0xc6, 0x4e, 0xe8,
-	0x33, 0x3b, 0x8a, 0x39, 0xbf, 0xb3, 0x9d, 0xfb, 0x80, 0x32, 0xac, 0xb7, 0x0b, 0x1d, 0x63, 0xdc,
-	0x10, 0xc2, 0x68, 0xdd, 0x3f, 0x5d, 0xb7, 0x49, 0xff, 0x95, 0xf7, 0x2c, 0xf4, 0x13, 0x80, 0xf2,
-	0xf9, 0x3c, 0x48, 0xe7, 0x2c, 0x4c, 0xf3, 0x87, 0x95, 0x0e, 0x89, 0xa1, 0xb9, 0x99, 0xb2, 0xf2,
-	0x29, 0xf4, 0x15, 0x74, 0x6a, 0xe6, 0xb0, 0x4e, 0x4d, 0x51, 0x5b, 0x58, 0xcf, 0x6b, 0x0b, 0xfd,
-	0x80, 0x5a, 0xb6, 0x90, 0xcb, 0x5d, 0x5c, 0x10, 0xeb, 0x54, 0x45, 0x63, 0xc8, 0x5d, 0xd4, 0x06,
-	0x63, 0x23, 0xda, 0x0f, 0x14, 0x17, 0x85, 0x0e, 0x52, 0xbf, 0xa6, 0xe4, 0xaf, 0xe2, 0xd9, 0x93,
-	0x9e, 0xdf, 0xa0, 0x94, 0xce, 0x58, 0xea, 0xe4, 0xb6, 0x59, 0x41, 0x9e, 0x34, 0x85, 0xed, 0x4b,
-	0xf6, 0x37, 0xd4, 0x3d, 0x66, 0x6f, 0xfd, 0x6b, 0xed, 0x61, 0x78, 0xec, 0x6c, 0xd3, 0x43, 0x04,
-	0xea, 0x32, 0xb5, 0x68, 0xe6, 0xd8, 0x8f, 0xf9, 0xfe, 0x5f, 0xa2, 0x2c, 0xb2, 0x68, 0xe6, 0xdc,
-	0xec, 0x32, 0x2b, 0x5c, 0xd8, 0x65, 0xa6, 0xe8, 0x3b, 0x54, 0x32, 0x26, 0xc5, 0x45, 0xa1, 0x96,
-	0x45, 0x39, 0x21, 0x3d, 0x65, 0xb5, 0x43, 0xb9, 0xda, 0x5b, 0x79, 0x3f, 0xeb, 0xca, 0xd4, 0xd1,
-	0xa7, 0xfa, 0x10, 0xfa, 0x03, 0x8d, 0xa5, 0xbd, 0x6d, 0x51, 0x12, 0x80, 0xb1, 0x1c, 0x29, 0x1e,
-	0x7b, 0xd8, 0x0a, 0x97, 0xf7, 0xb0, 0x29, 0x6a, 0x41, 0x4d, 0x62, 0x29, 0xae, 0x08, 0xa0, 0x92,
-	0x01, 0x13, 0x55, 0x5b, 0xe0, 0xaa, 0xaa, 0x5d, 0x6d, 0xc5, 0x7a, 0xfc, 0xde, 0x58, 0x07, 0xca,
-	0xd0, 0xe0, 0x23, 0xa9, 0x92, 0x5f, 0xca, 0xe4, 0x89, 0x9c, 0x34, 0x40, 0x4b, 0xe4, 0x15, 0x26,
-	0xc3, 0xc6, 0x6d, 0x5d, 0x1c, 0x70, 0x37, 0x3f, 0x60, 0xb7, 0x2c, 0x2e, 0xb8, 0xf7, 0x12, 0x00,
-	0x00, 0xff, 0xff, 0xe8, 0xdb, 0x9c, 0xf3, 0xdf, 0x03, 0x00, 0x00,
+	// 689 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x95, 0xcf, 0x6e, 0xd3, 0x4e,
+	0x10, 0xc7, 0x65, 0x27, 0xcd, 0x9f, 0x69, 0xf2, 0x8b, 0xba, 0xea, 0xaf, 0xac, 0x8a, 0x54, 0x85,
+	0x45, 0x45, 0x15, 0x08, 0x8a, 0x5d, 0x4a, 0xcb, 0x91, 0x72, 0x28, 0x42, 0x02, 0xa1, 0x40, 0x85,
+	0xdd, 0x8b, 0xe5, 0xd8, 0x26, 0x89, 0x94, 0xc4, 0x96, 0xbd, 0x0d, 0x71, 0xef, 0x3c, 0x07, 0x4f,
+	0xc3, 0x03, 0xf0, 0x46, 0xc8, 0xfb, 0xc7, 0x5e, 0x3b, 0x46, 0xc0, 0x8d, 0xe3, 0xce, 0x7c, 0x76,
+	0x76, 0xe6, 0x3b, 0x3b, 0xbb, 0xb0, 0x1f, 0xc5, 0x21, 0x0d, 0xc7, 0xc7, 0x81, 0xe7, 0x27, 0xee,
+	0xe3, 0x64, 0x36, 0x59, 0xce, 0x96, 0x93, 0x27, 0xcc, 0x48, 0xde, 0x01, 0xfa, 0x30, 0x9b, 0x2c,
+	0x47, 0xe1, 0xcd, 0xd2, 0x37, 0xde, 0x06, 0x49, 0xe2, 0x4e, 0x02, 0x03, 0xf5, 0x40, 0xf3, 0xb0,
+	0x36, 0xd4, 0x8e, 0x7a, 0x23, 0xcd, 0x43, 0x0f, 0x61, 0x27, 0x76, 0x97, 0x93, 0xc0, 0x89, 0xe2,
+	0x30, 0xfc, 0xec, 0xb8, 0xf3, 0x99, 0x17, 0x60, 0x7d, 0xd8, 0x38, 0xea, 0x8d, 0x06, 0xcc, 0xf1,
+	0x3e, 0xb3, 0xbf, 0xcc, 0xcc, 0xe4, 0x4d, 0x4d, 0x3c, 0x13, 0x1d, 0x00, 0x78, 0xe1, 0x62, 0x31,
+	0xa3, 0x8b, 0x60, 0x49, 0x45, 0x60, 0xc5, 0x82, 0x76, 0x61, 0x2b, 0x88, 0x42, 0x6f, 0x8a, 0xf5,
+	0xa1, 0x76, 0xd4, 0x1c, 0xf1, 0x05, 0x89, 0x61, 0x27, 0x8f, 0x65, 0x8a, 0x58, 0xe8, 0x3f, 0xd0,
+	0x3d, 0x43, 0x84, 0xd0, 0x3d, 0x83, 0xad, 0x4d, 0xac, 0x8b, 0xb5, 0x89, 0xee, 0x42, 0x97, 0xa7,
+	0x39, 0x0e, 0xc7, 0xb8, 0xc1, 0x92, 0xec, 0x30, 0xc3, 0x45, 0x38, 0x46, 0x43, 0xe8, 0xe5, 0x4e,
+	0xe7, 0x8b, 0x87, 0x9b, 0xcc, 0x0f, 0xd2, 0xff, 0xc9, 0x23, 0x3f, 0x34, 0xe5, 0xd0, 0x13, 0x79,
+	0xe8, 0x2e, 0x6c, 0xd1, 0x69, 0x40, 0x5d, 0x71, 0x2e, 0x5f, 0xa0, 0x01, 0x34, 0xa8, 0xb3, 0x96,
+	0x67, 0x53, 0x8b, 0x1b, 0x52, 0xdc, 0x10, 0x06, 0x1b, 0x1d, 0xc2, 0x80, 0xe6, 0xaa, 0x45, 0x53,
+	0xd7, 0x59, 0xe3, 0x26, 0x73, 0xf6, 0xa8, 0xd0, 0x2c, 0x9a, 0xba, 0xd6, 0x26, 0x96, 0xe2, 0xad,
+	0x0d, 0xcc, 0x46, 0xfb, 0xd0, 0x95, 0x18, 0xc5, 0x2d, 0x06, 0xb4, 0x39, 0xf0, 0x51, 0xf5, 0xdd,
+	0xe0, 0xb6, 0xea, 0xbb, 0x22, 0xdf, 0x1b, 0x4a, 0x4d, 0xcf, 0x64, 0x4d, 0xf7, 0xa1, 0xef, 0x07,
+	0x4e, 0xa9, 0x2d, 0x99, 0x18, 0x3d, 0x3f, 0x78, 0x55, 0x34, 0x86, 0x40, 0xbf, 0x9c, 0x3e, 0x2f,
+	0x76, 0x3b, 0x52, 0xb2, 0xaf, 0x30, 0xb2, 0x7e, 0x85, 0xb1, 0xd1, 0x1d, 0x68, 0xcb, 0xc4, 0xb9,
+	0x00, 0xad, 0x88, 0xe7, 0xfd, 0x00, 0x06, 0xae, 0xef, 0x46, 0x34, 0x8c, 0x9d, 0x89, 0xbb, 0x58,
+	0x64, 0x47, 0xf0, 0xd2, 0xfb, 0xc2, 0x7c, 0x99, 0x59, 0xad, 0x4d, 0x2e, 0xc5, 0xad, 0x4d, 0xce,
+	0x46, 0x8f, 0x00, 0x49, 0x4e, 0x24, 0x65, 0x38, 0x6b, 0x21, 0x88, 0x8c, 0xc0, 0x35, 0x35, 0xac,
+	0x5a, 0x38, 0xc5, 0x9d, 0x3a, 0xb8, 0x2e, 0xb2, 0xe9, 0xac, 0x71, 0xb7, 0x06, 0x36, 0xad, 0x5a,
+	0x38, 0xc5, 0x50, 0x07, 0xdb, 0x6a, 0x6d, 0x1c, 0xbe, 0xc5, 0xdb, 0xa5, 0xda, 0x18, 0x79, 0x4d,
+	0x4e, 0x94, 0x36, 0x9e, 0xca, 0x36, 0xfe, 0x66, 0xb4, 0xc8, 0x37, 0x5d, 0xd9, 0xf5, 0xfc, 0xdf,
+	0x6a, 0xfe, 0x21, 0x0c, 0x56, 0x95, 0xf1, 0x10, 0xf7, 0x7e, 0x55, 0x19, 0x8f, 0x55, 0x65, 0x3c,
+	0x5a, 0x1b, 0x18, 0x1b, 0x8f, 0x55, 0x3e, 0x1e, 0x62, 0x04, 0x56, 0xc5, 0x78, 0xac, 0xf2, 0xf1,
+	0xe8, 0xa8, 0xbe, 0xab, 0x92, 0xac, 0x67, 0x7f, 0x2a, 0xeb, 0xb9, 0xb2, 0xe9, 0xfc, 0x6f, 0x54,
+	0x25, 0xc7, 0xca, 0xce, 0x17, 0x72, 0x67, 0x0f, 0xb4, 0x44, 0x3e, 0xb8, 0x49, 0xb6, 0x9a, 0x0b,
+	0xb1, 0xb5, 0x39, 0x39, 0x85, 0xbd, 0xa2, 0xed, 0x97, 0x97, 0xe6, 0xd3, 0xfc, 0x99, 0xce, 0xde,
+	0x3a, 0x7f, 0xce, 0xeb, 0x12, 0x67, 0x75, 0x22, 0x7f, 0xce, 0xea, 0x22, 0xaf, 0x7f, 0xb1, 0xcd,
+	0x44, 0x7b, 0xd0, 0x8e, 0x9d, 0xb1, 0x1b, 0x3b, 0x6b, 0xf9, 0x9e, 0xc5, 0x17, 0x6e, 0x6c, 0x15,
+	0xf6, 0x14, 0xeb, 0x85, 0xdd, 0x26, 0x5f, 0x75, 0xf8, 0xbf, 0xb8, 0x42, 0x4a, 0xa8, 0xec, 0xc1,
+	0x4b, 0xf2, 0x28, 0x7a, 0x62, 0x71, 0x83, 0xdc, 0xae, 0x27, 0x36, 0xba, 0x07, 0xfd, 0x84, 0xaa,
+	0xa3, 0xc8, 0xef, 0x07, 0x24, 0x34, 0x9f, 0xc2, 0x0a, 0x92, 0xe2, 0x66, 0x05, 0xa9, 0x44, 0x31,
+	0xf3, 0x6b, 0x92, 0x23, 0xa6, 0x55, 0x45, 0xe4, 0x15, 0x29, 0x10, 0x1b, 0x1d, 0xc0, 0x76, 0x8e,
+	0xdc, 0x1a, 0xe2, 0x8a, 0x74, 0x05, 0x70, 0x6d, 0x94, 0xfd, 0x26, 0xee, 0x94, 0xfd, 0x26, 0x39,
+	0x54, 0x64, 0x38, 0x53, 0x65, 0x28, 0x75, 0xef, 0x62, 0x70, 0xdd, 0x67, 0x3f, 0xed, 0xb1, 0xf8,
+	0x69, 0xc7, 0x2d, 0xf6, 0xd5, 0x9e, 0xfc, 0x1c, 0x00, 0xcf, 0x7f, 0x83, 0xcd, 0x88, 0x07, 0x00,
+	0x00,
 }
@@ -7,41 +7,87 @@
 package signing
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *finalization) Start() *tss.Error {
+func (round *finalization) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 10
 	round.started = true
 	round.resetOK()
 
 	sumS := round.temp.si
-	modN := common.ModInt(tss.EC().Params().N)
+	modN := common.ModInt(round.EC().Params().N)
 
-	for j := range round.Parties().IDs() {
+	partialSigs := make([]*PartialSignature, len(round.Parties().IDs()))
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
 		round.ok[j] = true
 		if j == round.PartyID().Index {
+			partialSigs[j] = &PartialSignature{
+				Party: Pj, Si: round.temp.si, Li: round.temp.li, R: round.temp.bigR, BigVi: round.temp.bigVi,
+			}
 			continue
 		}
 		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
-		sumS = modN.Add(sumS, r9msg.UnmarshalS())
+		partialSigs[j] = &PartialSignature{
+			Party: Pj, Si: r9msg.UnmarshalS(), Li: r9msg.UnmarshalL(), R: round.temp.bigR, BigVi: round.temp.bigVjs[j],
+		}
+		if !partialSigs[j].Verify() {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, partialSigs[j].Si)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("partial signature verification failed"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+	round.temp.partialSigs = partialSigs
+
+	if err := round.saveSignature(sumS); err != nil {
+		return err
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+// saveSignature sets and verifies the signature (r, s) in the round's data, or the pre-signature when signing with an
+// adaptor point
+func (round *base) saveSignature(sumS *big.Int) *tss.Error {
+	if round.temp.adaptorT != nil {
+		return round.savePreSignature(sumS)
+	}
+	setSignature(round.data, round.temp.bigR, sumS, round.temp.m)
+
+	// on the STARK curve this also fails, with a negligible probability, if r = R.x or 1/s is not below 2^251 as
+	// StarkNet requires; the signers should then sign again with new nonces
+	if ok := Verify(round.data, round.key.ECDSAPub, round.temp.m.Bytes()); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
 	}
+	return nil
+}
 
+// setSignature sets the signature (r, s) with r = R.x in `data` along with its recovery id, normalising s to the lower
+// half of the curve order as Bitcoin does
+func setSignature(data *common.SignatureData, R *crypto.ECPoint, sumS, m *big.Int) {
+	N := R.Curve().Params().N
+	rx := new(big.Int).Mod(R.X(), N)
 	recid := 0
 	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.rx.Cmp(tss.EC().Params().N) > 0 {
+	if R.X().Cmp(N) >= 0 {
 		recid = 2
 	}
-	if round.temp.ry.Bit(0) != 0 {
+	if R.Y().Bit(0) != 0 {
 		recid |= 1
 	}
 
@@ -49,32 +95,18 @@ func (round *finalization) Start() *tss.Error {
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(tss.EC().Params().N, 1)
+	secp256k1halfN := new(big.Int).Rsh(N, 1)
 	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(tss.EC().Params().N, sumS)
+		sumS.Sub(N, sumS)
 		recid ^= 1
 	}
 
 	// save the signature for final output
-	round.data.Signature = append(round.temp.rx.Bytes(), sumS.Bytes()...)
-	round.data.SignatureRecovery = []byte{byte(recid)}
-	round.data.R = round.temp.rx.Bytes()
-	round.data.S = sumS.Bytes()
-	round.data.M = round.temp.m.Bytes()
-
-	pk := ecdsa.PublicKey{
-		Curve: tss.EC(),
-		X:     round.key.ECDSAPub.X(),
-		Y:     round.key.ECDSAPub.Y(),
-	}
-	ok := ecdsa.Verify(&pk, round.temp.m.Bytes(), round.temp.rx, sumS)
-	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
-	}
-
-	round.end <- *round.data
-
-	return nil
+	data.Signature = append(rx.Bytes(), sumS.Bytes()...)
+	data.SignatureRecovery = []byte{byte(recid)}
+	data.R = rx.Bytes()
+	data.S = sumS.Bytes()
+	data.M = m.Bytes()
 }
 
 func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
@@ -82,7 +114,7 @@ func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *finalization) Update() (bool, *tss.Error) {
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
 	// not expecting any incoming messages in this round
 	return false, nil
 }
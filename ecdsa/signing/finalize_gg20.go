@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalizationGG20) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 8
+	round.started = true
+	round.resetOK()
+
+	sumS := round.temp.si
+	modN := common.ModInt(round.EC().Params().N)
+	R, m, r := round.temp.bigR, round.temp.m, round.temp.rx
+
+	// check each s_j*R = m*R_bar_j + r*S_j so that a bad share names its sender
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r7msg := round.temp.signRound7GG20Messages[j].Content().(*SignRound7GG20Message)
+		sj := r7msg.UnmarshalS()
+		expected := round.temp.bigSjs[j].ScalarMult(r)
+		if m.Sign() != 0 {
+			var err error
+			if expected, err = expected.Add(round.temp.bigRBarjs[j].ScalarMult(m)); err != nil {
+				culprits = append(culprits, Pj)
+				continue
+			}
+		}
+		if sj.Sign() == 0 || !R.ScalarMult(sj).Equals(expected) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("signature share verification failed"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	if err := round.saveSignature(sumS); err != nil {
+		return err
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalizationGG20) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalizationGG20) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalizationGG20) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,32 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+var (
+	pedersenHDomain = []byte("tss-lib ecdsa signing gg20 pedersen generator")
+)
+
+// pedersenH returns the second generator H on the curve `ec` of the commitments T_i = sigma_i*G + l_i*H used by GG20.
+// Nobody may know log_G(H), so H is found by hashing G to an x coordinate and incrementing it until it is on the curve.
+func pedersenH(ec elliptic.Curve) *crypto.ECPoint {
+	params := ec.Params()
+	x := new(big.Int).Mod(common.SHA512_256iCanonical(string(pedersenHDomain), params.Gx, params.Gy), params.P)
+	for {
+		if H, err := crypto.LiftX(ec, x, false); err == nil {
+			return H
+		}
+		x = new(big.Int).Mod(x.Add(x, big.NewInt(1)), params.P)
+	}
+}
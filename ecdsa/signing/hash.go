@@ -0,0 +1,69 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	gocrypto "crypto"
+	"crypto/elliptic"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// HashMessage hashes the payload read from `r` incrementally with the hash function `hash` and returns the digest as
+// the message to sign on the curve `ec`. Like crypto/ecdsa, a digest longer than the curve order is truncated to its leftmost bits so
+// that the output of signing verifies with standard ECDSA implementations. On the STARK curve the digest is reduced to
+// 250 bits by stark.HashToMessage instead.
+func HashMessage(ec elliptic.Curve, r io.Reader, hash gocrypto.Hash) (*big.Int, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash function %d is not available; it must be linked into the binary", hash)
+	}
+	h := hash.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, fmt.Errorf("failed to hash the payload: %v", err)
+	}
+	digest := h.Sum(nil)
+	if ec.Params().Name == stark.Name {
+		return stark.HashToMessage(digest), nil
+	}
+
+	orderBits := ec.Params().N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	m := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		m.Rsh(m, uint(excess))
+	}
+	return m, nil
+}
+
+// NewLocalPartyFromReader constructs a LocalParty that signs the digest of the payload read from `r`.
+// Large payloads are hashed incrementally and never held in memory; all signers must hash the same payload with the
+// same `hash`. The signature output verifies against the digest returned by HashMessage.
+func NewLocalPartyFromReader(
+	r io.Reader,
+	hash gocrypto.Hash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- common.SignatureData,
+) (tss.Party, error) {
+	msg, err := HashMessage(params.EC(), r, hash)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalParty(msg, params, key, out, end), nil
+}
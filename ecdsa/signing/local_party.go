@@ -7,14 +7,18 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
 	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
 	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/binance-chain/tss-lib/tss"
 )
@@ -27,12 +31,18 @@ var _ fmt.Stringer = (*LocalParty)(nil)
 type (
 	LocalParty struct {
 		*tss.BaseParty
-		params *tss.Parameters
+		params atomic.Value // *tss.Parameters, replaced by Restart while messages are validated outside of the lock
 
+		key  keygen.LocalPartySaveData // the full save data, used to re-build `keys` on Restart
 		keys keygen.LocalPartySaveData
 		temp localTempData
 		data common.SignatureData
 
+		// the adaptor point of NewAdaptorLocalParty, kept on Restart
+		adaptorT *crypto.ECPoint
+		// the precomputed Paillier randomness of SetRandomnessPool, kept on Restart
+		randomnessPool *paillier.RandomnessPool
+
 		// outbound messaging
 		out chan<- tss.Message
 		end chan<- common.SignatureData
@@ -48,13 +58,18 @@ type (
 		signRound6Messages,
 		signRound7Messages,
 		signRound8Messages,
-		signRound9Messages []tss.ParsedMessage
+		signRound9Messages,
+		signRound5GG20Message1s,
+		signRound5GG20Message2s,
+		signRound6GG20Messages,
+		signRound7GG20Messages []tss.ParsedMessage
 	}
 
 	localTempData struct {
 		localMessageStore
 
 		// temp data (thrown away after sign) / round 1
+		randomnessPool *paillier.RandomnessPool
 		w,
 		m,
 		k,
@@ -63,8 +78,10 @@ type (
 		sigma,
 		gamma *big.Int
 		cis        []*big.Int
+		rAs        []*big.Int // the Paillier randomness of cis, used by the PDL proofs of GG20
 		bigWs      []*crypto.ECPoint
 		pointGamma *crypto.ECPoint
+		bigGammaJs []*crypto.ECPoint // de-committed in round 4
 		deCommit   cmt.HashDeCommitment
 
 		// round 2
@@ -72,14 +89,15 @@ type (
 		c1jis,
 		c2jis,
 		vs []*big.Int // return value of Bob_mid_wc
-		pi1jis []*mta.ProofBob
-		pi2jis []*mta.ProofBobWC
+		pi1jis  []*mta.ProofBob
+		pi2jis  []*mta.ProofBobWC
+		bobMids []*bobMidResult  // Bob_mid and Bob_mid_wc, possibly started early by a pipelined party
+		mtaSem  common.Semaphore // bounds the Bob_mid and Bob_mid_wc that run at once
 
 		// round 5
 		li,
 		si,
 		rx,
-		ry,
 		roi *big.Int
 		bigR,
 		bigAi,
@@ -90,6 +108,29 @@ type (
 		Ui,
 		Ti *crypto.ECPoint
 		DTelda cmt.HashDeCommitment
+		bigVjs []*crypto.ECPoint
+
+		// GG20 round 3
+		tl     *big.Int // the blinding l_i of T_i = sigma_i*G + l_i*H
+		bigT   *crypto.ECPoint
+		bigTjs []*crypto.ECPoint
+
+		// GG20 round 6
+		bigRBarjs []*crypto.ECPoint
+
+		// GG20 round 7
+		bigSjs []*crypto.ECPoint
+
+		// adaptor signing
+		adaptorT,
+		adaptorGammaT,
+		bigRT *crypto.ECPoint
+		adaptorGammaProof *schnorr.ZKDLEQProof
+		adaptorProof      *AdaptorProof
+
+		// finalization
+		partialSigs []*PartialSignature
+		preSig      *PreSignature
 	}
 )
 
@@ -100,16 +141,23 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- common.SignatureData,
 ) tss.Party {
-	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
-		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
-		temp:      localTempData{},
-		data:      common.SignatureData{},
+		key:       key,
 		out:       out,
 		end:       end,
 	}
+	p.params.Store(params)
+	p.resetTempData(msg)
+	return p
+}
+
+// resetTempData (re-)initialises the round state of the party for signing `msg` with the current set of signers
+func (p *LocalParty) resetTempData(msg *big.Int) {
+	partyCount := len(p.parameters().Parties().IDs())
+	p.keys = keygen.BuildLocalSaveDataSubset(p.key, p.parameters().Parties().IDs())
+	p.temp = localTempData{}
+	p.data = common.SignatureData{}
 	// msgs init
 	p.temp.signRound1Message1s = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound1Message2s = make([]tss.ParsedMessage, partyCount)
@@ -121,56 +169,127 @@ func NewLocalParty(
 	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound5GG20Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound5GG20Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound6GG20Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound7GG20Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.m = msg
 	p.temp.cis = make([]*big.Int, partyCount)
+	p.temp.rAs = make([]*big.Int, partyCount)
 	p.temp.bigWs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigGammaJs = make([]*crypto.ECPoint, partyCount)
 	p.temp.betas = make([]*big.Int, partyCount)
 	p.temp.c1jis = make([]*big.Int, partyCount)
 	p.temp.c2jis = make([]*big.Int, partyCount)
 	p.temp.pi1jis = make([]*mta.ProofBob, partyCount)
 	p.temp.pi2jis = make([]*mta.ProofBobWC, partyCount)
+	p.temp.bobMids = make([]*bobMidResult, partyCount)
+	p.temp.mtaSem = common.NewSemaphore(p.parameters().Concurrency())
 	p.temp.vs = make([]*big.Int, partyCount)
-	return p
+	p.temp.bigTjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigRBarjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigSjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.adaptorT = p.adaptorT
+	p.temp.randomnessPool = p.randomnessPool
+}
+
+// SetRandomnessPool makes round 1 encrypt k with precomputed randomness from `pool`, which must be a pool for this
+// party's own Paillier public key; otherwise the randomness is computed on the spot. It must be set before Start.
+func (p *LocalParty) SetRandomnessPool(pool *paillier.RandomnessPool) {
+	p.randomnessPool = pool
+	p.temp.randomnessPool = pool
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.keys, &p.data, &p.temp, p.out, p.end)
+	return newRound1(p.parameters(), &p.keys, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start(ctx context.Context) *tss.Error {
+	return tss.BaseStart(ctx, p, TaskName, p.prepare)
 }
 
-func (p *LocalParty) Start() *tss.Error {
-	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
-		round1, ok := round.(*round1)
-		if !ok {
-			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+// Restart aborts the signing ceremony and starts it again for the same message with a new set of signers,
+// e.g. after one of the peers dropped out. All round state is discarded and fresh nonces are generated;
+// the save data given to the constructor is re-used, and so are all of the settings of the parameters. The remaining
+// signers must all call Restart with the same list. Each attempt runs in a session of its own, derived from the session
+// ID of the attempt before it and the new signers, so that the messages of an aborted attempt that are still in flight
+// are rejected with CodeSessionMismatch.
+func (p *LocalParty) Restart(ctx context.Context, newPartyIDs tss.SortedPartyIDs) *tss.Error {
+	return tss.BaseRestart(ctx, p, TaskName, func() *tss.Error {
+		Pi := newPartyIDs.FindByKey(p.PartyID().KeyInt())
+		if Pi == nil {
+			return p.WrapError(errors.New("unable to Restart(). this party is not in the new set of signers")).WithCode(tss.CodeBadInput)
+		}
+		if len(newPartyIDs) < p.parameters().Threshold()+1 {
+			return p.WrapError(fmt.Errorf("unable to Restart(). t+1=%d is not satisfied by the new set of %d signers",
+				p.parameters().Threshold()+1, len(newPartyIDs))).WithCode(tss.CodeBadInput)
 		}
-		if err := round1.prepare(); err != nil {
-			return round.WrapError(err)
+		params, err := p.parameters().WithParties(tss.NewPeerContext(newPartyIDs), Pi, len(newPartyIDs))
+		if err != nil {
+			return p.WrapError(fmt.Errorf("unable to Restart(). %v", err)).WithCode(tss.CodeBadInput)
 		}
+		params.SetSessionID(restartSessionID(p.parameters().SessionID(), newPartyIDs))
+		p.temp.waitBobMids()
+		p.params.Store(params)
+		p.resetTempData(p.temp.m)
 		return nil
-	})
+	}, p.prepare)
 }
 
-func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
-	return tss.BaseUpdate(p, msg, TaskName)
+// parameters returns the parameters of the current attempt, which Restart replaces
+func (p *LocalParty) parameters() *tss.Parameters {
+	return p.params.Load().(*tss.Parameters)
 }
 
-func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
-	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+// restartSessionID returns the session ID of the attempt that a Restart with the signers `parties` begins after the
+// attempt of the session `sessionID`
+func restartSessionID(sessionID []byte, parties tss.SortedPartyIDs) []byte {
+	parts := [][]byte{sessionID}
+	for _, k := range parties.Keys() {
+		parts = append(parts, k.Bytes())
+	}
+	return common.SHA512_256Canonical(restartSessionDomain, parts...)
+}
+
+func (p *LocalParty) prepare(round tss.Round) *tss.Error {
+	round1, ok := round.(*round1)
+	if !ok {
+		return round.WrapError(errors.New("unable to Start(). party is in an unexpected round")).WithCode(tss.CodeUnexpectedState)
+	}
+	if err := round1.prepare(); err != nil {
+		return round.WrapError(err)
+	}
+	return nil
+}
+
+func (p *LocalParty) Update(ctx context.Context, msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(ctx, p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(ctx context.Context, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := p.parameters().ParseReceived(wireBytes, from, isBroadcast)
 	if err != nil {
 		return false, p.WrapError(err)
 	}
-	return p.Update(msg)
+	return p.Update(ctx, msg)
 }
 
 func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
 		return ok, err
 	}
+	params := p.parameters()
+	if err := params.ValidateSessionID(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	if err := params.ValidateSender(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
 	// check that the message's "from index" will fit into the array
-	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+	if maxFromIdx := len(params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
 		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 	}
 	return true, nil
 }
@@ -182,11 +301,12 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	}
 	fromPIdx := msg.GetFrom().Index
 
-	// switch/case is necessary to store any messages beyond current round
-	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	// switch/case is necessary to store the messages of each round; tss.BaseUpdate holds those of later rounds until then
+	// replays are dropped by tss.BaseUpdate. we expect the caller to apply spoofing protection, e.g. with tss.Parameters.OpenMessage.
 	switch msg.Content().(type) {
 	case *SignRound1Message1:
 		p.temp.signRound1Message1s[fromPIdx] = msg
+		p.pipelineRound1Message(msg)
 	case *SignRound1Message2:
 		p.temp.signRound1Message2s[fromPIdx] = msg
 	case *SignRound2Message:
@@ -205,15 +325,23 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound8Messages[fromPIdx] = msg
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
+	case *SignRound5GG20Message1:
+		p.temp.signRound5GG20Message1s[fromPIdx] = msg
+	case *SignRound5GG20Message2:
+		p.temp.signRound5GG20Message2s[fromPIdx] = msg
+	case *SignRound6GG20Message:
+		p.temp.signRound6GG20Messages[fromPIdx] = msg
+	case *SignRound7GG20Message:
+		p.temp.signRound7GG20Messages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
-		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		p.parameters().Logger().Warn("unrecognised message ignored", "msg", msg)
 		return false, nil
 	}
 	return true, nil
 }
 
 func (p *LocalParty) PartyID() *tss.PartyID {
-	return p.params.PartyID()
+	return p.parameters().PartyID()
 }
 
 func (p *LocalParty) String() string {
@@ -7,10 +7,12 @@
 package signing
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
 
@@ -57,12 +59,13 @@ func TestE2EConcurrent(t *testing.T) {
 
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
 
 		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
 		parties = append(parties, P)
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -74,7 +77,7 @@ signing:
 		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
 		select {
 		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			break signing
 
@@ -102,7 +105,7 @@ signing:
 				r := parties[0].temp.rx
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				modN := common.ModInt(tss.EC().Params().N)
+				modN := common.ModInt(tss.S256().Params().N)
 
 				// BEGIN check s correctness
 				sumS := big.NewInt(0)
@@ -115,7 +118,7 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.S256(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -124,8 +127,282 @@ signing:
 				t.Log("ECDSA signing test done.")
 				// END ECDSA verify
 
+				// BEGIN partial signatures check
+				partialSigs := parties[0].PartialSignatures()
+				assert.Equal(t, len(signPIDs), len(partialSigs))
+				for j, ps := range partialSigs {
+					assert.True(t, ps.Verify(), "partial signature %d must verify", j)
+				}
+				forged := *partialSigs[0]
+				forged.Si = modN.Add(forged.Si, big.NewInt(1))
+				assert.False(t, forged.Verify(), "a forged partial signature must not verify")
+				// END partial signatures check
+
+				break signing
+			}
+		}
+	}
+}
+
+func TestE2EConcurrentGG20(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+		params.SetSigningProtocol(tss.GG20)
+
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+
+				// the GG20 rounds must have run in place of rounds 5-9 of GG18
+				for j := range signPIDs {
+					assert.NotNil(t, parties[0].temp.bigSjs[j])
+					assert.NotNil(t, parties[0].temp.bigRBarjs[j])
+				}
+				assert.Nil(t, parties[0].temp.bigVjs)
+
+				pk := keys[0].ECDSAPub.ToECDSAPubKey()
+				r, s := new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+				assert.True(t, ecdsa.Verify(pk, big.NewInt(42).Bytes(), r, s), "ecdsa verify must pass")
+				assert.True(t, Verify(&data, keys[0].ECDSAPub, big.NewInt(42).Bytes()))
+				t.Log("GG20 ECDSA signing test done.")
+
 				break signing
 			}
 		}
 	}
 }
+
+func TestPhases(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	params, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 1)
+	assert.NoError(t, err)
+	round := &base{Parameters: params, number: 5}
+	assert.Equal(t, 10, round.TotalRounds())
+	assert.Equal(t, "commitments to V_i and A_i", round.Phase())
+
+	params.SetSigningProtocol(tss.GG20)
+	assert.Equal(t, 8, round.TotalRounds())
+	assert.Equal(t, "R_bar_i and PDL proofs", round.Phase())
+	round.number = 2
+	assert.Equal(t, "MtA responses", round.Phase(), "GG20 shares its first rounds with GG18")
+	round.number = round.TotalRounds()
+	assert.Equal(t, "finalization", round.Phase())
+}
+
+func TestE2ERestart(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures for one more signer than is required
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+2, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	// PHASE: start the first attempt, whose messages are all lost
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+		// half of the parties are pipelined to exercise both ways of running round 2
+		params.SetPipelined(i%2 == 0)
+		// and the first runs its MtAs one at a time
+		if i == 0 {
+			assert.NoError(t, params.SetConcurrency(1))
+		}
+		params.SetSessionID([]byte("restart"))
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(context.Background()); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	var stale tss.Message
+	for len(outCh) > 0 {
+		if msg := <-outCh; msg.GetFrom().Index == 1 && msg.GetTo() == nil {
+			stale = msg
+		}
+	}
+	firstK := parties[0].temp.k
+
+	// PHASE: the last party drops out; the others restart without it
+	newPIDs, err := tss.SortPartyIDs(signPIDs[:len(signPIDs)-1].ToUnSorted(), tss.S256())
+	assert.NoError(t, err, "should sort the remaining signers")
+	parties = parties[:len(parties)-1]
+	for _, P := range parties {
+		if err := P.Restart(context.Background(), newPIDs); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	assert.NotEqual(t, 0, firstK.Cmp(parties[0].temp.k), "a fresh nonce must be generated on restart")
+	assert.Equal(t, 1, parties[0].parameters().Concurrency(), "the settings of the parameters must be kept on restart")
+	assert.NotEqual(t, []byte("restart"), parties[0].parameters().SessionID())
+	assert.Equal(t, parties[0].parameters().SessionID(), parties[1].parameters().SessionID())
+
+	// a message of the aborted attempt is rejected
+	if assert.NotNil(t, stale) {
+		bz, routing, err := stale.WireBytes()
+		assert.NoError(t, err)
+		_, err2 := parties[0].UpdateFromBytes(context.Background(), bz, stale.GetFrom(), routing.IsBroadcast)
+		if assert.Error(t, err2) {
+			assert.Equal(t, tss.CodeSessionMismatch, err2.Code())
+		}
+	}
+
+	updater := test.SharedPartyUpdater
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(parties)) {
+				pk := ecdsa.PublicKey{
+					Curve: tss.S256(),
+					X:     keys[0].ECDSAPub.X(),
+					Y:     keys[0].ECDSAPub.Y(),
+				}
+				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+				assert.True(t, ok, "ecdsa verify must pass")
+				assert.True(t, Verify(&data, keys[0].ECDSAPub, big.NewInt(42).Bytes()), "signing.Verify must pass")
+				assert.False(t, Verify(&data, keys[0].ECDSAPub, big.NewInt(43).Bytes()), "signing.Verify must fail for another message")
+				assert.True(t, ToBtcecSignature(&data).Verify(big.NewInt(42).Bytes(), ToBtcecPubKey(keys[0].ECDSAPub)), "btcec verify must pass")
+				break signing
+			}
+		}
+	}
+}
+
+// run with -race: Restart replaces the parameters while UpdateFromBytes parses and validates messages without the lock
+func TestRestartConcurrentWithUpdate(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+2, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 4*len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	// only the first two signers start, so that the first round of neither can finish
+	parties := make([]*LocalParty, 0, 2)
+	for i := 0; i < 2; i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		assert.NoError(t, err)
+		params.SetSessionID([]byte("restart"))
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(context.Background()); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	var msgs []tss.Message
+	for len(outCh) > 0 {
+		if msg := <-outCh; msg.GetFrom().Index == 1 && (msg.GetTo() == nil || msg.GetTo()[0].Index == 0) {
+			msgs = append(msgs, msg)
+		}
+	}
+	assert.NotEmpty(t, msgs)
+
+	newPIDs, err := tss.SortPartyIDs(signPIDs[:len(signPIDs)-1].ToUnSorted(), tss.S256())
+	assert.NoError(t, err, "should sort the remaining signers")
+	P := parties[0]
+	sessionID := restartSessionID(P.parameters().SessionID(), newPIDs)
+
+	var wg sync.WaitGroup
+	for _, msg := range msgs {
+		bz, routing, err := msg.WireBytes()
+		assert.NoError(t, err)
+		wg.Add(1)
+		go func(bz []byte, from *tss.PartyID, isBroadcast bool) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				// the message is of the first attempt, so it is either applied or rejected with CodeSessionMismatch
+				if _, err := P.UpdateFromBytes(context.Background(), bz, from, isBroadcast); err != nil {
+					assert.Equal(t, tss.CodeSessionMismatch, err.Code())
+				}
+			}
+		}(bz, msg.GetFrom(), routing.IsBroadcast)
+	}
+	if err := P.Restart(context.Background(), newPIDs); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	wg.Wait()
+	assert.Equal(t, sessionID, P.parameters().SessionID())
+	assert.Equal(t, len(newPIDs), len(P.parameters().Parties().IDs()))
+}
@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"crypto/elliptic"
 	"math/big"
 
 	"github.com/golang/protobuf/proto"
@@ -23,8 +24,8 @@ import (
 // The following messages are registered on the Protocol Buffers "wire"
 
 var (
-	// Ensure that signing messages implement ValidateBasic
-	_ = []tss.MessageContent{
+	// Ensure that signing messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
 		(*SignRound1Message1)(nil),
 		(*SignRound1Message2)(nil),
 		(*SignRound2Message)(nil),
@@ -35,6 +36,10 @@ var (
 		(*SignRound7Message)(nil),
 		(*SignRound8Message)(nil),
 		(*SignRound9Message)(nil),
+		(*SignRound5GG20Message1)(nil),
+		(*SignRound5GG20Message2)(nil),
+		(*SignRound6GG20Message)(nil),
+		(*SignRound7GG20Message)(nil),
 	}
 )
 
@@ -49,6 +54,11 @@ func init() {
 	proto.RegisterType((*SignRound7Message)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound7Message")
 	proto.RegisterType((*SignRound8Message)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound8Message")
 	proto.RegisterType((*SignRound9Message)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound9Message")
+	proto.RegisterType((*SignRound5GG20Message1)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound5GG20Message1")
+	proto.RegisterType((*SignRound5GG20Message2)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound5GG20Message2")
+	proto.RegisterType((*SignRound6GG20Message)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound6GG20Message")
+	proto.RegisterType((*SignRound7GG20Message)(nil), tss.ECDSAProtoNamePrefix+"signing.SignRound7GG20Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
 }
 
 // ----- //
@@ -91,6 +101,7 @@ func (m *SignRound1Message1) UnmarshalRangeProofAlice() (*mta.RangeProofAlice, e
 func NewSignRound1Message2(
 	from *tss.PartyID,
 	commitment cmt.HashCommitment,
+	epoch uint64,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -98,6 +109,7 @@ func NewSignRound1Message2(
 	}
 	content := &SignRound1Message2{
 		Commitment: commitment.Bytes(),
+		Epoch:      epoch,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -150,8 +162,8 @@ func (m *SignRound2Message) UnmarshalProofBob() (*mta.ProofBob, error) {
 	return mta.ProofBobFromBytes(m.ProofBob)
 }
 
-func (m *SignRound2Message) UnmarshalProofBobWC() (*mta.ProofBobWC, error) {
-	return mta.ProofBobWCFromBytes(m.ProofBobWc)
+func (m *SignRound2Message) UnmarshalProofBobWC(ec elliptic.Curve) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(ec, m.ProofBobWc)
 }
 
 // ----- //
@@ -171,11 +183,67 @@ func NewSignRound3Message(
 	return tss.NewMessage(meta, content, msg)
 }
 
+// NewSignRound3MessageGG20 is NewSignRound3Message with the commitment T_i to sigma_i of GG20
+func NewSignRound3MessageGG20(
+	from *tss.PartyID,
+	theta *big.Int,
+	bigT *crypto.ECPoint,
+	tProof *schnorr.ZKVProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound3Message{
+		Theta:        theta.Bytes(),
+		TX:           bigT.X().Bytes(),
+		TY:           bigT.Y().Bytes(),
+		TProofAlphaX: tProof.Alpha.X().Bytes(),
+		TProofAlphaY: tProof.Alpha.Y().Bytes(),
+		TProofT:      tProof.T.Bytes(),
+		TProofU:      tProof.U.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
 func (m *SignRound3Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyBytes(m.Theta)
 }
 
+// HasT returns whether the message carries the commitment T_i of GG20
+func (m *SignRound3Message) HasT() bool {
+	return common.NonEmptyBytes(m.GetTX()) &&
+		common.NonEmptyBytes(m.GetTY()) &&
+		common.NonEmptyBytes(m.GetTProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetTProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetTProofT()) &&
+		common.NonEmptyBytes(m.GetTProofU())
+}
+
+func (m *SignRound3Message) UnmarshalT(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetTX()),
+		new(big.Int).SetBytes(m.GetTY()))
+}
+
+func (m *SignRound3Message) UnmarshalTProof(ec elliptic.Curve) (*schnorr.ZKVProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetTProofAlphaX()),
+		new(big.Int).SetBytes(m.GetTProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKVProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetTProofT()),
+		U:     new(big.Int).SetBytes(m.GetTProofU()),
+	}, nil
+}
+
 // ----- //
 
 func NewSignRound4Message(
@@ -198,6 +266,36 @@ func NewSignRound4Message(
 	return tss.NewMessage(meta, content, msg)
 }
 
+// NewSignRound4MessageAdaptor is NewSignRound4Message with gamma_i*T for signing with the adaptor point T
+func NewSignRound4MessageAdaptor(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *schnorr.ZKProof,
+	adaptorGamma *crypto.ECPoint,
+	adaptorProof *schnorr.ZKDLEQProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &SignRound4Message{
+		DeCommitment:    dcBzs,
+		ProofAlphaX:     proof.Alpha.X().Bytes(),
+		ProofAlphaY:     proof.Alpha.Y().Bytes(),
+		ProofT:          proof.T.Bytes(),
+		AdaptorGammaX:   adaptorGamma.X().Bytes(),
+		AdaptorGammaY:   adaptorGamma.Y().Bytes(),
+		AdaptorProofA1X: adaptorProof.A1.X().Bytes(),
+		AdaptorProofA1Y: adaptorProof.A1.Y().Bytes(),
+		AdaptorProofA2X: adaptorProof.A2.X().Bytes(),
+		AdaptorProofA2Y: adaptorProof.A2.Y().Bytes(),
+		AdaptorProofZ:   adaptorProof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
 func (m *SignRound4Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyMultiBytes(m.DeCommitment, 3) &&
@@ -211,9 +309,9 @@ func (m *SignRound4Message) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
-func (m *SignRound4Message) UnmarshalZKProof() (*schnorr.ZKProof, error) {
+func (m *SignRound4Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
-		tss.EC(),
+		ec,
 		new(big.Int).SetBytes(m.GetProofAlphaX()),
 		new(big.Int).SetBytes(m.GetProofAlphaY()))
 	if err != nil {
@@ -225,6 +323,46 @@ func (m *SignRound4Message) UnmarshalZKProof() (*schnorr.ZKProof, error) {
 	}, nil
 }
 
+// HasAdaptorGamma returns whether the message carries gamma_i*T for signing with an adaptor point
+func (m *SignRound4Message) HasAdaptorGamma() bool {
+	return common.NonEmptyBytes(m.GetAdaptorGammaX()) &&
+		common.NonEmptyBytes(m.GetAdaptorGammaY()) &&
+		common.NonEmptyBytes(m.GetAdaptorProofA1X()) &&
+		common.NonEmptyBytes(m.GetAdaptorProofA1Y()) &&
+		common.NonEmptyBytes(m.GetAdaptorProofA2X()) &&
+		common.NonEmptyBytes(m.GetAdaptorProofA2Y()) &&
+		common.NonEmptyBytes(m.GetAdaptorProofZ())
+}
+
+func (m *SignRound4Message) UnmarshalAdaptorGamma(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetAdaptorGammaX()),
+		new(big.Int).SetBytes(m.GetAdaptorGammaY()))
+}
+
+func (m *SignRound4Message) UnmarshalAdaptorProof(ec elliptic.Curve) (*schnorr.ZKDLEQProof, error) {
+	A1, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetAdaptorProofA1X()),
+		new(big.Int).SetBytes(m.GetAdaptorProofA1Y()))
+	if err != nil {
+		return nil, err
+	}
+	A2, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetAdaptorProofA2X()),
+		new(big.Int).SetBytes(m.GetAdaptorProofA2Y()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKDLEQProof{
+		A1: A1,
+		A2: A2,
+		Z:  new(big.Int).SetBytes(m.GetAdaptorProofZ()),
+	}, nil
+}
+
 // ----- //
 
 func NewSignRound5Message(
@@ -295,9 +433,9 @@ func (m *SignRound6Message) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
-func (m *SignRound6Message) UnmarshalZKProof() (*schnorr.ZKProof, error) {
+func (m *SignRound6Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
-		tss.EC(),
+		ec,
 		new(big.Int).SetBytes(m.GetProofAlphaX()),
 		new(big.Int).SetBytes(m.GetProofAlphaY()))
 	if err != nil {
@@ -309,9 +447,9 @@ func (m *SignRound6Message) UnmarshalZKProof() (*schnorr.ZKProof, error) {
 	}, nil
 }
 
-func (m *SignRound6Message) UnmarshalZKVProof() (*schnorr.ZKVProof, error) {
+func (m *SignRound6Message) UnmarshalZKVProof(ec elliptic.Curve) (*schnorr.ZKVProof, error) {
 	point, err := crypto.NewECPoint(
-		tss.EC(),
+		ec,
 		new(big.Int).SetBytes(m.GetVProofAlphaX()),
 		new(big.Int).SetBytes(m.GetVProofAlphaY()))
 	if err != nil {
@@ -382,7 +520,7 @@ func (m *SignRound8Message) UnmarshalDeCommitment() []*big.Int {
 
 func NewSignRound9Message(
 	from *tss.PartyID,
-	si *big.Int,
+	si, li *big.Int,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -390,6 +528,7 @@ func NewSignRound9Message(
 	}
 	content := &SignRound9Message{
 		S: si.Bytes(),
+		L: li.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -397,9 +536,166 @@ func NewSignRound9Message(
 
 func (m *SignRound9Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.S)
+		common.NonEmptyBytes(m.S) &&
+		common.NonEmptyBytes(m.L)
 }
 
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+func (m *SignRound9Message) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.L)
+}
+
+// ----- //
+
+func NewSignRound5GG20Message1(
+	to, from *tss.PartyID,
+	proof *mta.ProofPDL,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &SignRound5GG20Message1{
+		PdlProof: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound5GG20Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetPdlProof(), mta.ProofPDLBytesParts)
+}
+
+func (m *SignRound5GG20Message1) UnmarshalPDLProof(ec elliptic.Curve) (*mta.ProofPDL, error) {
+	return mta.ProofPDLFromBytes(ec, m.GetPdlProof())
+}
+
+// ----- //
+
+func NewSignRound5GG20Message2(
+	from *tss.PartyID,
+	bigRBar *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound5GG20Message2{
+		RBarX: bigRBar.X().Bytes(),
+		RBarY: bigRBar.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound5GG20Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetRBarX()) &&
+		common.NonEmptyBytes(m.GetRBarY())
+}
+
+func (m *SignRound5GG20Message2) UnmarshalRBar(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetRBarX()),
+		new(big.Int).SetBytes(m.GetRBarY()))
+}
+
+// ----- //
+
+func NewSignRound6GG20Message(
+	from *tss.PartyID,
+	bigS *crypto.ECPoint,
+	proof *schnorr.ZKSTProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound6GG20Message{
+		SX:         bigS.X().Bytes(),
+		SY:         bigS.Y().Bytes(),
+		StProofA1X: proof.A1.X().Bytes(),
+		StProofA1Y: proof.A1.Y().Bytes(),
+		StProofA2X: proof.A2.X().Bytes(),
+		StProofA2Y: proof.A2.Y().Bytes(),
+		StProofZ1:  proof.Z1.Bytes(),
+		StProofZ2:  proof.Z2.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound6GG20Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSX()) &&
+		common.NonEmptyBytes(m.GetSY()) &&
+		common.NonEmptyBytes(m.GetStProofA1X()) &&
+		common.NonEmptyBytes(m.GetStProofA1Y()) &&
+		common.NonEmptyBytes(m.GetStProofA2X()) &&
+		common.NonEmptyBytes(m.GetStProofA2Y()) &&
+		common.NonEmptyBytes(m.GetStProofZ1()) &&
+		common.NonEmptyBytes(m.GetStProofZ2())
+}
+
+func (m *SignRound6GG20Message) UnmarshalS(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetSX()),
+		new(big.Int).SetBytes(m.GetSY()))
+}
+
+func (m *SignRound6GG20Message) UnmarshalSTProof(ec elliptic.Curve) (*schnorr.ZKSTProof, error) {
+	a1, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetStProofA1X()),
+		new(big.Int).SetBytes(m.GetStProofA1Y()))
+	if err != nil {
+		return nil, err
+	}
+	a2, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetStProofA2X()),
+		new(big.Int).SetBytes(m.GetStProofA2Y()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKSTProof{
+		A1: a1,
+		A2: a2,
+		Z1: new(big.Int).SetBytes(m.GetStProofZ1()),
+		Z2: new(big.Int).SetBytes(m.GetStProofZ2()),
+	}, nil
+}
+
+// ----- //
+
+func NewSignRound7GG20Message(
+	from *tss.PartyID,
+	si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound7GG20Message{
+		S: si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound7GG20Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetS())
+}
+
+func (m *SignRound7GG20Message) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.GetS())
+}
@@ -0,0 +1,51 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// PartialSignature is a signer's contribution s_i to the final signature s = sum(s_i),
+	// together with the public values of the ceremony that it can be verified against.
+	PartialSignature struct {
+		Party *tss.PartyID
+		Si    *big.Int        // s_i = m*k_i + r*sigma_i
+		Li    *big.Int        // l_i; blinds s_i in V_i until the phase 5 checks have passed
+		R     *crypto.ECPoint // R = (sum(gamma_j)*G)^(1/theta)
+		BigVi *crypto.ECPoint // V_i = R^s_i * g^l_i, de-committed in round 7
+	}
+)
+
+// Verify checks that s_i is the value that the signer committed to in V_i, i.e. that R^s_i * g^l_i == V_i
+func (ps *PartialSignature) Verify() bool {
+	if ps == nil || ps.Si == nil || ps.Li == nil || !ps.R.ValidateBasic() || !ps.BigVi.ValidateBasic() {
+		return false
+	}
+	rToSi := ps.R.ScalarMult(ps.Si)
+	liPoint := crypto.ScalarBaseMult(ps.R.Curve(), ps.Li)
+	bigVi, err := rToSi.Add(liPoint)
+	if err != nil {
+		return false
+	}
+	return bigVi.Equals(ps.BigVi)
+}
+
+// PartialSignatures returns the verified partial signatures of all of the signers, indexed by their party index.
+// It returns nil until the ceremony has finished.
+func (p *LocalParty) PartialSignatures() []*PartialSignature {
+	if p.temp.partialSigs == nil {
+		return nil
+	}
+	sigs := make([]*PartialSignature, len(p.temp.partialSigs))
+	copy(sigs, p.temp.partialSigs)
+	return sigs
+}
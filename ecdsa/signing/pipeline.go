@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	errorspkg "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// bobMidResult holds the output of Bob_mid and Bob_mid_wc for one peer.
+	// When the party is pipelined the computation is started as soon as the peer's SignRound1Message1 arrives,
+	// otherwise it is started by round 2. `done` is closed once all of the fields have been set.
+	bobMidResult struct {
+		msg   tss.ParsedMessage // the SignRound1Message1 that the computation was started for
+		done  chan struct{}
+		beta  *big.Int
+		c1ji  *big.Int
+		pi1ji *mta.ProofBob
+		v     *big.Int
+		c2ji  *big.Int
+		pi2ji *mta.ProofBobWC
+		err   *tss.Error
+	}
+)
+
+// startBobMid begins Bob_mid and Bob_mid_wc for the sender of `r1msg1` in the background.
+// The inputs are captured before returning so the computation does not touch the party's state, which Restart may
+// replace while it runs.
+func startBobMid(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *localTempData, r1msg1 tss.ParsedMessage) *bobMidResult {
+	i, j := params.PartyID().Index, r1msg1.GetFrom().Index
+	Pj := r1msg1.GetFrom()
+	gamma, w, bigWi, sem := temp.gamma, temp.w, temp.bigWs[i], temp.mtaSem
+	ec, mtaParams := params.EC(), params.MtAProofParams()
+	pkj, NTildej, h1j, h2j := key.PaillierPKs[j], key.NTildej[j], key.H1j[j], key.H2j[j]
+	NTildei, h1i, h2i := key.NTildej[i], key.H1j[i], key.H2j[i]
+	sessionID, Pi := params.SessionID(), params.PartyID()
+	res := &bobMidResult{msg: r1msg1, done: make(chan struct{})}
+
+	go func() {
+		defer close(res.done)
+		wrapError := func(err error) *tss.Error {
+			return tss.NewError(err, TaskName, 2, Pi, Pj)
+		}
+		r1msg := r1msg1.Content().(*SignRound1Message1)
+		rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
+		if err != nil {
+			res.err = wrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"))
+			return
+		}
+		// Alice's range proof is from round 1, and Bob's proofs are from round 2
+		aliceCtx, bobCtx := proofTranscript(sessionID, 1, Pj.KeyInt()), proofTranscript(sessionID, 2, Pi.KeyInt())
+		var errBobMid, errBobMidWC error
+		wcDone := make(chan struct{})
+		// Bob_mid_wc
+		go func() {
+			defer close(wcDone)
+			sem.Acquire()
+			defer sem.Release()
+			res.v, res.c2ji, _, res.pi2ji, errBobMidWC = mta.BobMidWC(
+				aliceCtx,
+				bobCtx,
+				ec,
+				pkj,
+				rangeProofAliceJ,
+				w,
+				r1msg.UnmarshalC(),
+				NTildej,
+				h1j,
+				h2j,
+				NTildei,
+				h1i,
+				h2i,
+				bigWi,
+				mtaParams)
+		}()
+		// Bob_mid
+		sem.Acquire()
+		res.beta, res.c1ji, _, res.pi1ji, errBobMid = mta.BobMid(
+			aliceCtx,
+			bobCtx,
+			ec,
+			pkj,
+			rangeProofAliceJ,
+			gamma,
+			r1msg.UnmarshalC(),
+			NTildej,
+			h1j,
+			h2j,
+			NTildei,
+			h1i,
+			h2i,
+			mtaParams)
+		sem.Release()
+		<-wcDone
+		if errBobMid != nil {
+			res.err = wrapError(errBobMid)
+		} else if errBobMidWC != nil {
+			res.err = wrapError(errBobMidWC)
+		}
+	}()
+	return res
+}
+
+// pipelineRound1Message starts the round 2 computation for a peer whose SignRound1Message1 has just been stored,
+// provided that round 1 has started on this party and round 2 has not
+func (p *LocalParty) pipelineRound1Message(msg tss.ParsedMessage) {
+	if !p.parameters().Pipelined() || p.temp.gamma == nil || p.temp.bobMids == nil || msg.IsBroadcast() {
+		return
+	}
+	j := msg.GetFrom().Index
+	if j == p.PartyID().Index || p.temp.bobMids[j] != nil {
+		return
+	}
+	p.temp.bobMids[j] = startBobMid(p.parameters(), &p.keys, &p.temp, msg)
+}
+
+// waitBobMids waits for the Bob_mid computations that are in flight, e.g. before Restart discards the round state
+func (temp *localTempData) waitBobMids() {
+	for _, res := range temp.bobMids {
+		if res != nil {
+			<-res.done
+		}
+	}
+}
@@ -7,17 +7,17 @@
 package signing
 
 import (
+	"crypto/elliptic"
 	"fmt"
 	"math/big"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/crypto"
-	"github.com/binance-chain/tss-lib/tss"
 )
 
 // PrepareForSigning(), GG18Spec (11) Fig. 14
-func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.ECPoint) (wi *big.Int, bigWs []*crypto.ECPoint) {
-	modQ := common.ModInt(tss.EC().Params().N)
+func PrepareForSigning(ec elliptic.Curve, i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.ECPoint) (wi *big.Int, bigWs []*crypto.ECPoint) {
+	modQ := common.ModInt(ec.Params().N)
 	if len(ks) != len(bigXs) {
 		panic(fmt.Errorf("PrepareForSigning: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs)))
 	}
@@ -39,10 +39,10 @@ func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.E
 		wi = modQ.Mul(wi, coef)
 	}
 
-	// 5-10.
+	// 5-10. the coefficients of each BigXj are multiplied first, so that the point is multiplied once, with its table
 	bigWs = make([]*crypto.ECPoint, len(ks))
 	for j := 0; j < pax; j++ {
-		bigWj := bigXs[j]
+		coef := big.NewInt(1)
 		for c := 0; c < pax; c++ {
 			if j == c {
 				continue
@@ -54,9 +54,9 @@ func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.E
 			}
 			// big.Int Div is calculated as: a/b = a * modInv(b,q)
 			iota := modQ.Mul(ksc, modQ.ModInverse(new(big.Int).Sub(ksc, ksj)))
-			bigWj = bigWj.ScalarMult(iota)
+			coef = modQ.Mul(coef, iota)
 		}
-		bigWs[j] = bigWj
+		bigWs[j] = crypto.ScalarMultFixedBase(bigXs[j], coef)
 	}
 	return
 }
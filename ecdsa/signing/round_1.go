@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -15,6 +16,7 @@ import (
 	"github.com/binance-chain/tss-lib/crypto"
 	"github.com/binance-chain/tss-lib/crypto/commitments"
 	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/crypto/stark"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/binance-chain/tss-lib/tss"
 )
@@ -29,28 +31,35 @@ func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *com
 		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
 }
 
-func (round *round1) Start() *tss.Error {
+func (round *round1) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	if err := round.CheckCurveName(round.key.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
 	}
 
 	// Spec requires calculate H(M) here,
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(tss.EC().Params().N) >= 0 {
-		return round.WrapError(errors.New("hashed message is not valid"))
+	if round.temp.m.Cmp(round.EC().Params().N) >= 0 {
+		return round.WrapError(errors.New("hashed message is not valid")).WithCode(tss.CodeBadInput)
+	}
+	if round.EC().Params().Name == stark.Name && !stark.ValidMessage(round.temp.m) {
+		return round.WrapError(errors.New("hashed message is not below 2^251 as StarkNet requires")).WithCode(tss.CodeBadInput)
 	}
 
 	round.number = 1
 	round.started = true
 	round.resetOK()
 
-	k := common.GetRandomPositiveInt(tss.EC().Params().N)
-	gamma := common.GetRandomPositiveInt(tss.EC().Params().N)
+	k := common.GetRandomPositiveInt(round.EC().Params().N)
+	gamma := common.GetRandomPositiveInt(round.EC().Params().N)
 
-	pointGamma := crypto.ScalarBaseMult(tss.EC(), gamma)
-	cmt := commitments.NewHashCommitment(pointGamma.X(), pointGamma.Y())
+	pointGamma := crypto.ScalarBaseMult(round.EC(), gamma)
+	cmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), round1CommitmentDomain, round.Params().SessionID(), pointGamma.X(), pointGamma.Y())
 	round.temp.k = k
 	round.temp.gamma = gamma
 	round.temp.pointGamma = pointGamma
@@ -59,27 +68,58 @@ func (round *round1) Start() *tss.Error {
 	i := round.PartyID().Index
 	round.ok[i] = true
 
+	// check the peers' Paillier keys before any of their ciphertexts is processed in round 2
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		if err := round.key.PaillierPKs[j].ValidatePeer(round.PaillierModulusLen()); err != nil {
+			return round.WrapError(err, Pj)
+		}
+	}
+
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
-		cA, pi, err := mta.AliceInit(round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j])
+		if err := ctx.Err(); err != nil {
+			return round.WrapError(err)
+		}
+		aliceCtx := round.proofTranscript(1, round.PartyID())
+		var cA, rA *big.Int
+		var pi *mta.RangeProofAlice
+		var err error
+		if pool := round.temp.randomnessPool; pool != nil && pool.PublicKey().N.Cmp(round.key.PaillierPKs[i].N) == 0 {
+			cA, rA, pi, err = mta.AliceInitFromPool(aliceCtx, round.EC(), pool, k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.MtAProofParams())
+		} else {
+			cA, rA, pi, err = mta.AliceInitWithRandomness(aliceCtx, round.EC(), round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.MtAProofParams())
+		}
 		if err != nil {
 			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
 		}
 		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.temp.cis[j] = cA
-		round.out <- r1msg1
+		round.temp.rAs[j] = rA
+		round.out <- round.WithSessionID(r1msg1)
 	}
 
-	r1msg2 := NewSignRound1Message2(round.PartyID(), cmt.C)
+	r1msg2 := NewSignRound1Message2(round.PartyID(), cmt.C, round.key.Epoch)
 	round.temp.signRound1Message2s[i] = r1msg2
-	round.out <- r1msg2
-
+	round.out <- round.WithSessionID(r1msg2)
+
+	// a pipelined party begins round 2's MtA for the peers whose messages arrived before this round started
+	if round.Pipelined() {
+		for j, r1msg1 := range round.temp.signRound1Message1s {
+			if j == i || r1msg1 == nil || r1msg1.IsBroadcast() {
+				continue
+			}
+			round.temp.bobMids[j] = startBobMid(round.Parameters, round.key, round.temp, r1msg1)
+		}
+	}
 	return nil
 }
 
-func (round *round1) Update() (bool, *tss.Error) {
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg1 := range round.temp.signRound1Message1s {
 		if round.ok[j] {
 			continue
@@ -91,6 +131,10 @@ func (round *round1) Update() (bool, *tss.Error) {
 		if msg2 == nil || !round.CanAccept(msg2) {
 			return false, nil
 		}
+		// a peer holding shares from before a refresh or reshare would poison the session
+		if err := round.key.CheckEpoch(msg2.Content().(*SignRound1Message2).GetEpoch()); err != nil {
+			return false, round.WrapError(err, msg2.GetFrom())
+		}
 		round.ok[j] = true
 	}
 	return true, nil
@@ -124,7 +168,7 @@ func (round *round1) prepare() error {
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
-	wi, bigWs := PrepareForSigning(i, len(ks), xi, ks, bigXs)
+	wi, bigWs := PrepareForSigning(round.EC(), i, len(ks), xi, ks, bigXs)
 
 	round.temp.w = wi
 	round.temp.bigWs = bigWs
@@ -7,18 +7,15 @@
 package signing
 
 import (
+	"context"
 	"errors"
-	"sync"
 
-	errorspkg "github.com/pkg/errors"
-
-	"github.com/binance-chain/tss-lib/crypto/mta"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round2) Start() *tss.Error {
+func (round *round2) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 2
 	round.started = true
@@ -27,79 +24,37 @@ func (round *round2) Start() *tss.Error {
 	i := round.PartyID().Index
 	round.ok[i] = true
 
-	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2)
-	wg := sync.WaitGroup{}
-	wg.Add((len(round.Parties().IDs()) - 1) * 2)
-	for j, Pj := range round.Parties().IDs() {
+	// Bob_mid and Bob_mid_wc; a pipelined party may have started these already for some peers
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
-		// Bob_mid
-		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
-			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
-				return
-			}
-			beta, c1ji, _, pi1ji, err := mta.BobMid(
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.gamma,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i])
-			// should be thread safe as these are pre-allocated
-			round.temp.betas[j] = beta
-			round.temp.c1jis[j] = c1ji
-			round.temp.pi1jis[j] = pi1ji
-			if err != nil {
-				errChs <- round.WrapError(err, Pj)
-			}
-		}(j, Pj)
-		// Bob_mid_wc
-		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
-			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
-				return
-			}
-			v, c2ji, _, pi2ji, err := mta.BobMidWC(
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.w,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.temp.bigWs[i])
-			round.temp.vs[j] = v
-			round.temp.c2jis[j] = c2ji
-			round.temp.pi2jis[j] = pi2ji
-			if err != nil {
-				errChs <- round.WrapError(err, Pj)
-			}
-		}(j, Pj)
+		r1msg1 := round.temp.signRound1Message1s[j]
+		if res := round.temp.bobMids[j]; res == nil || res.msg != r1msg1 {
+			round.temp.bobMids[j] = startBobMid(round.Parameters, round.key, round.temp, r1msg1)
+		}
 	}
-	// consume error channels; wait for goroutines
-	wg.Wait()
-	close(errChs)
-	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
-	for err := range errChs {
-		culprits = append(culprits, err.Culprits()...)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		res := round.temp.bobMids[j]
+		select {
+		case <-res.done:
+		case <-ctx.Done():
+			return round.WrapError(ctx.Err())
+		}
+		if res.err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.betas[j], round.temp.c1jis[j], round.temp.pi1jis[j] = res.beta, res.c1ji, res.pi1ji
+		round.temp.vs[j], round.temp.c2jis[j], round.temp.pi2jis[j] = res.v, res.c2ji, res.pi2ji
 	}
+	round.temp.bobMids = nil
 	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate Bob_mid or Bob_mid_wc"), culprits...)
+		return round.WrapError(errors.New("failed to calculate Bob_mid or Bob_mid_wc"), culprits...).WithCode(tss.CodeInvalidProof)
 	}
 	// create and send messages
 	for j, Pj := range round.Parties().IDs() {
@@ -108,12 +63,12 @@ func (round *round2) Start() *tss.Error {
 		}
 		r2msg := NewSignRound2Message(
 			Pj, round.PartyID(), round.temp.c1jis[j], round.temp.pi1jis[j], round.temp.c2jis[j], round.temp.pi2jis[j])
-		round.out <- r2msg
+		round.out <- round.WithSessionID(r2msg)
 	}
 	return nil
 }
 
-func (round *round2) Update() (bool, *tss.Error) {
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound2Messages {
 		if round.ok[j] {
 			continue
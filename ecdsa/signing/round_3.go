@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"math/big"
 	"sync"
@@ -14,13 +15,15 @@ import (
 	errorspkg "github.com/pkg/errors"
 
 	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
 	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round3) Start() *tss.Error {
+func (round *round3) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 3
 	round.started = true
@@ -32,6 +35,7 @@ func (round *round3) Start() *tss.Error {
 	i := round.PartyID().Index
 
 	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2)
+	sem := common.NewSemaphore(round.Concurrency())
 	wg := sync.WaitGroup{}
 	wg.Add((len(round.Parties().IDs()) - 1) * 2)
 	for j, Pj := range round.Parties().IDs() {
@@ -41,21 +45,33 @@ func (round *round3) Start() *tss.Error {
 		// Alice_end
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				return
+			}
 			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
 			proofBob, err := r2msg.UnmarshalProofBob()
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBob failed"), Pj)
+				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBob failed"), Pj).WithCode(tss.CodeInvalidMessage)
 				return
 			}
-			alphaIj, err := mta.AliceEnd(
-				round.key.PaillierPKs[i],
-				proofBob,
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.temp.cis[j],
-				new(big.Int).SetBytes(r2msg.GetC1()),
-				round.key.NTildej[i],
-				round.key.PaillierSK)
+			var alphaIj *big.Int
+			round.VerifyProof("mta-bob", Pj, func() bool {
+				alphaIj, err = mta.AliceEnd(
+					round.proofTranscript(2, Pj),
+					round.EC(),
+					round.key.PaillierPKs[i],
+					proofBob,
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.temp.cis[j],
+					new(big.Int).SetBytes(r2msg.GetC1()),
+					round.key.NTildej[i],
+					round.key.PaillierSK,
+					round.MtAProofParams())
+				return err == nil
+			})
 			alphas[j] = alphaIj
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
@@ -64,22 +80,34 @@ func (round *round3) Start() *tss.Error {
 		// Alice_end_wc
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if ctx.Err() != nil {
+				return
+			}
 			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
-			proofBobWC, err := r2msg.UnmarshalProofBobWC()
+			proofBobWC, err := r2msg.UnmarshalProofBobWC(round.EC())
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBobWC failed"), Pj)
+				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBobWC failed"), Pj).WithCode(tss.CodeInvalidMessage)
 				return
 			}
-			uIj, err := mta.AliceEndWC(
-				round.key.PaillierPKs[i],
-				proofBobWC,
-				round.temp.bigWs[j],
-				round.temp.cis[j],
-				new(big.Int).SetBytes(r2msg.GetC2()),
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.key.PaillierSK)
+			var uIj *big.Int
+			round.VerifyProof("mta-bob-wc", Pj, func() bool {
+				uIj, err = mta.AliceEndWC(
+					round.proofTranscript(2, Pj),
+					round.EC(),
+					round.key.PaillierPKs[i],
+					proofBobWC,
+					round.temp.bigWs[j],
+					round.temp.cis[j],
+					new(big.Int).SetBytes(r2msg.GetC2()),
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.key.PaillierSK,
+					round.MtAProofParams())
+				return err == nil
+			})
 			us[j] = uIj
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
@@ -90,15 +118,18 @@ func (round *round3) Start() *tss.Error {
 	// consume error channels; wait for goroutines
 	wg.Wait()
 	close(errChs)
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(err)
+	}
 	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
 	for err := range errChs {
 		culprits = append(culprits, err.Culprits()...)
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate Alice_end or Alice_end_wc"), culprits...)
+		return round.WrapError(errors.New("failed to calculate Alice_end or Alice_end_wc"), culprits...).WithCode(tss.CodeInvalidProof)
 	}
 
-	modN := common.ModInt(tss.EC().Params().N)
+	modN := common.ModInt(round.EC().Params().N)
 	thelta := modN.Mul(round.temp.k, round.temp.gamma)
 	sigma := modN.Mul(round.temp.k, round.temp.w)
 
@@ -112,14 +143,34 @@ func (round *round3) Start() *tss.Error {
 
 	round.temp.theta = thelta
 	round.temp.sigma = sigma
-	r3msg := NewSignRound3Message(round.PartyID(), thelta)
+	var r3msg tss.ParsedMessage
+	if round.SigningProtocol() == tss.GG20 {
+		// GG20 commits to sigma_i with T_i = sigma_i*G + l_i*H so that S_i can be checked against it in round 7
+		l := common.GetRandomPositiveInt(round.EC().Params().N)
+		H := pedersenH(round.EC())
+		sigmaG := crypto.ScalarBaseMult(round.EC(), sigma)
+		bigT, err := sigmaG.Add(H.ScalarMult(l))
+		if err != nil {
+			return round.WrapError(errorspkg.Wrapf(err, "sigmaG.Add(lH)"))
+		}
+		tProof, err := schnorr.NewZKVProofInTranscript(round.proofTranscript(3, round.PartyID()), bigT, H, l, sigma)
+		if err != nil {
+			return round.WrapError(errorspkg.Wrapf(err, "NewZKVProof(T, H)"))
+		}
+		round.temp.tl = l
+		round.temp.bigT = bigT
+		round.temp.bigTjs[i] = bigT
+		r3msg = NewSignRound3MessageGG20(round.PartyID(), thelta, bigT, tProof)
+	} else {
+		r3msg = NewSignRound3Message(round.PartyID(), thelta)
+	}
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	round.out <- round.WithSessionID(r3msg)
 
 	return nil
 }
 
-func (round *round3) Update() (bool, *tss.Error) {
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound3Messages {
 		if round.ok[j] {
 			continue
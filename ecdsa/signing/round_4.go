@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"math/big"
 
@@ -17,9 +18,9 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round4) Start() *tss.Error {
+func (round *round4) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 4
 	round.started = true
@@ -28,7 +29,13 @@ func (round *round4) Start() *tss.Error {
 	theta := *round.temp.theta
 	thetaInverse := &theta
 
-	modN := common.ModInt(tss.EC().Params().N)
+	modN := common.ModInt(round.EC().Params().N)
+
+	if round.SigningProtocol() == tss.GG20 {
+		if err := round.verifyBigTjs(); err != nil {
+			return err
+		}
+	}
 
 	for j := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
@@ -41,19 +48,31 @@ func (round *round4) Start() *tss.Error {
 
 	// compute the multiplicative inverse thelta mod q
 	thetaInverse = modN.ModInverse(thetaInverse)
-	piGamma, err := schnorr.NewZKProof(round.temp.gamma, round.temp.pointGamma)
+	r1msg2 := round.temp.signRound1Message2s[round.PartyID().Index].Content().(*SignRound1Message2)
+	piGammaCtx := round.proofTranscript(4, round.PartyID(), r1msg2.UnmarshalCommitment())
+	piGamma, err := schnorr.NewZKProofInTranscript(piGammaCtx, round.temp.gamma, round.temp.pointGamma)
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "NewZKProof(gamma, bigGamma)"))
 	}
 	round.temp.thetaInverse = thetaInverse
 	r4msg := NewSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma)
+	if T := round.temp.adaptorT; T != nil {
+		// gamma_i*T and the proof that it uses the gamma_i of Gamma_i, for R_T = (sum(gamma_j)*T)^(1/theta)
+		gammaT := T.ScalarMult(round.temp.gamma)
+		piGammaT, err := schnorr.NewZKDLEQProofInTranscript(adaptorTranscript(round.Params().SessionID(), round.PartyID().KeyInt()), round.temp.pointGamma, gammaT, T, round.temp.gamma)
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NewZKDLEQProofInTranscript(gamma, gammaT)"))
+		}
+		round.temp.adaptorGammaT, round.temp.adaptorGammaProof = gammaT, piGammaT
+		r4msg = NewSignRound4MessageAdaptor(round.PartyID(), round.temp.deCommit, piGamma, gammaT, piGammaT)
+	}
 	round.temp.signRound4Messages[round.PartyID().Index] = r4msg
-	round.out <- r4msg
+	round.out <- round.WithSessionID(r4msg)
 
 	return nil
 }
 
-func (round *round4) Update() (bool, *tss.Error) {
+func (round *round4) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound4Messages {
 		if round.ok[j] {
 			continue
@@ -75,5 +94,39 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round4) NextRound() tss.Round {
 	round.started = false
+	if round.SigningProtocol() == tss.GG20 {
+		return &round5GG20{round}
+	}
 	return &round5{round}
 }
+
+// verifyBigTjs checks the proof of knowledge of each T_j = sigma_j*G + l_j*H sent in round 3 of GG20
+func (round *round4) verifyBigTjs() *tss.Error {
+	H := pedersenH(round.EC())
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		if !r3msg.HasT() {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		bigTj, err := r3msg.UnmarshalT(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r3msg.UnmarshalTProof(round.EC())
+		if err != nil || !round.VerifyProof("t", Pj, func() bool { return proof.VerifyInTranscript(round.proofTranscript(3, Pj), bigTj, H) }) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.bigTjs[j] = bigTj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to prove T_j"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+	return nil
+}
@@ -7,7 +7,9 @@
 package signing
 
 import (
+	"context"
 	"errors"
+	"math/big"
 
 	errors2 "github.com/pkg/errors"
 
@@ -17,50 +19,30 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round5) Start() *tss.Error {
+func (round *round5) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 5
 	round.started = true
 	round.resetOK()
 
-	R := round.temp.pointGamma
-	for j, Pj := range round.Parties().IDs() {
-		if j == round.PartyID().Index {
-			continue
-		}
-		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
-		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
-		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
-		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
-		ok, bigGammaJ := cmtDeCmt.DeCommit()
-		if !ok || len(bigGammaJ) != 2 {
-			return round.WrapError(errors.New("commitment verify failed"), Pj)
-		}
-		bigGammaJPoint, err := crypto.NewECPoint(tss.EC(), bigGammaJ[0], bigGammaJ[1])
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
-		}
-		proof, err := r4msg.UnmarshalZKProof()
-		if err != nil {
-			return round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
-		}
-		ok = proof.Verify(bigGammaJPoint)
-		if !ok {
-			return round.WrapError(errors.New("failed to prove bigGamma"), Pj)
-		}
-		R, err = R.Add(bigGammaJPoint)
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
+	R, tErr := round.computeR()
+	if tErr != nil {
+		return tErr
+	}
+	// with an adaptor point T, r is taken from R_T = (1/k)*T instead
+	rPoint := R
+	if round.temp.adaptorT != nil {
+		if rPoint, tErr = round.computeRT(); tErr != nil {
+			return tErr
 		}
+		round.temp.bigRT = rPoint
 	}
-
-	R = R.ScalarMult(round.temp.thetaInverse)
-	N := tss.EC().Params().N
+	N := round.EC().Params().N
 	modN := common.ModInt(N)
-	rx := R.X()
-	ry := R.Y()
+	// r = R.x mod q; R.x may exceed q on curves such as P-256
+	rx := new(big.Int).Mod(rPoint.X(), N)
 	si := modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(rx, round.temp.sigma))
 
 	// clear temp.w and temp.k from memory, lint ignore
@@ -70,17 +52,17 @@ func (round *round5) Start() *tss.Error {
 	li := common.GetRandomPositiveInt(N)  // li
 	roI := common.GetRandomPositiveInt(N) // pi
 	rToSi := R.ScalarMult(si)
-	liPoint := crypto.ScalarBaseMult(tss.EC(), li)
-	bigAi := crypto.ScalarBaseMult(tss.EC(), roI)
+	liPoint := crypto.ScalarBaseMult(round.EC(), li)
+	bigAi := crypto.ScalarBaseMult(round.EC(), roI)
 	bigVi, err := rToSi.Add(liPoint)
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "rToSi.Add(li)"))
 	}
 
-	cmt := commitments.NewHashCommitment(bigVi.X(), bigVi.Y(), bigAi.X(), bigAi.Y())
+	cmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), round5CommitmentDomain, round.Params().SessionID(), bigVi.X(), bigVi.Y(), bigAi.X(), bigAi.Y())
 	r5msg := NewSignRound5Message(round.PartyID(), cmt.C)
 	round.temp.signRound5Messages[round.PartyID().Index] = r5msg
-	round.out <- r5msg
+	round.out <- round.WithSessionID(r5msg)
 
 	round.temp.li = li
 	round.temp.bigAi = bigAi
@@ -89,13 +71,12 @@ func (round *round5) Start() *tss.Error {
 	round.temp.DPower = cmt.D
 	round.temp.si = si
 	round.temp.rx = rx
-	round.temp.ry = ry
 	round.temp.bigR = R
 
 	return nil
 }
 
-func (round *round5) Update() (bool, *tss.Error) {
+func (round *round5) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound5Messages {
 		if round.ok[j] {
 			continue
@@ -119,3 +100,42 @@ func (round *round5) NextRound() tss.Round {
 	round.started = false
 	return &round6{round}
 }
+
+// ----- //
+
+// computeR de-commits each Gamma_j and checks its proof, then returns R = (sum Gamma_j)^(theta^-1)
+func (round *base) computeR() (*crypto.ECPoint, *tss.Error) {
+	R := round.temp.pointGamma
+	round.temp.bigGammaJs[round.PartyID().Index] = R
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
+		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
+		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
+		ok, bigGammaJ := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), round1CommitmentDomain, round.Params().SessionID())
+		if !ok || len(bigGammaJ) != 2 {
+			return nil, round.WrapError(errors.New("commitment verify failed"), Pj).WithCode(tss.CodeDecommitMismatch)
+		}
+		bigGammaJPoint, err := crypto.NewECPoint(round.EC(), bigGammaJ[0], bigGammaJ[1])
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		proof, err := r4msg.UnmarshalZKProof(round.EC())
+		if err != nil {
+			return nil, round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		ok = round.VerifyProof("schnorr", Pj, func() bool { return proof.VerifyInTranscript(round.proofTranscript(4, Pj, SCj), bigGammaJPoint) })
+		if !ok {
+			return nil, round.WrapError(errors.New("failed to prove bigGamma"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		round.temp.bigGammaJs[j] = bigGammaJPoint
+		R, err = R.Add(bigGammaJPoint)
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
+		}
+	}
+	return R.ScalarMult(round.temp.thetaInverse), nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 5 of GG20 (Gennaro, Goldfeder; 2020) computes R and proves R_bar_i = k_i*R against the MtA ciphertexts of k_i
+func (round *round5GG20) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	R, tErr := round.computeR()
+	if tErr != nil {
+		return tErr
+	}
+	round.temp.bigR = R
+	// with an adaptor point T, r is taken from R_T = (1/k)*T instead
+	rPoint := R
+	if round.temp.adaptorT != nil {
+		if rPoint, tErr = round.computeRT(); tErr != nil {
+			return tErr
+		}
+		round.temp.bigRT = rPoint
+	}
+	// r = R.x mod q; R.x may exceed q on curves such as P-256
+	round.temp.rx = new(big.Int).Mod(rPoint.X(), round.EC().Params().N)
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	bigRBari := R.ScalarMult(round.temp.k)
+	round.temp.bigRBarjs[i] = bigRBari
+
+	// prove to each P_j that R_bar_i uses the k_i encrypted in the c_i sent to P_j in round 1
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return round.WrapError(err)
+		}
+		proof, err := mta.ProvePDL(
+			round.proofTranscript(5, round.PartyID()),
+			round.key.PaillierPKs[i],
+			round.temp.cis[j],
+			R,
+			bigRBari,
+			round.key.NTildej[j],
+			round.key.H1j[j],
+			round.key.H2j[j],
+			round.temp.k,
+			round.temp.rAs[j],
+			round.MtAProofParams())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "ProvePDL(R_bar_i)"))
+		}
+		r5msg1 := NewSignRound5GG20Message1(Pj, round.PartyID(), proof)
+		round.out <- round.WithSessionID(r5msg1)
+	}
+
+	r5msg2 := NewSignRound5GG20Message2(round.PartyID(), bigRBari)
+	round.temp.signRound5GG20Message2s[i] = r5msg2
+	round.out <- round.WithSessionID(r5msg2)
+
+	return nil
+}
+
+func (round *round5GG20) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg2 := range round.temp.signRound5GG20Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		msg1 := round.temp.signRound5GG20Message1s[j]
+		if msg1 == nil || !round.CanAccept(msg1) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round5GG20) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound5GG20Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*SignRound5GG20Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round5GG20) NextRound() tss.Round {
+	round.started = false
+	return &round6GG20{round}
+}
@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 
 	errors2 "github.com/pkg/errors"
@@ -15,30 +16,32 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round6) Start() *tss.Error {
+func (round *round6) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 6
 	round.started = true
 	round.resetOK()
 
-	piAi, err := schnorr.NewZKProof(round.temp.roi, round.temp.bigAi)
+	r5msg := round.temp.signRound5Messages[round.PartyID().Index].Content().(*SignRound5Message)
+	proofCtx := round.proofTranscript(6, round.PartyID(), r5msg.UnmarshalCommitment())
+	piAi, err := schnorr.NewZKProofInTranscript(proofCtx, round.temp.roi, round.temp.bigAi)
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "NewZKProof(roi, bigAi)"))
 	}
-	piV, err := schnorr.NewZKVProof(round.temp.bigVi, round.temp.bigR, round.temp.si, round.temp.li)
+	piV, err := schnorr.NewZKVProofInTranscript(proofCtx, round.temp.bigVi, round.temp.bigR, round.temp.si, round.temp.li)
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "NewZKVProof(bigVi, bigR, si, li)"))
 	}
 
 	r6msg := NewSignRound6Message(round.PartyID(), round.temp.DPower, piAi, piV)
 	round.temp.signRound6Messages[round.PartyID().Index] = r6msg
-	round.out <- r6msg
+	round.out <- round.WithSessionID(r6msg)
 	return nil
 }
 
-func (round *round6) Update() (bool, *tss.Error) {
+func (round *round6) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound6Messages {
 		if round.ok[j] {
 			continue
@@ -0,0 +1,123 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round6GG20) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	R := round.temp.bigR
+
+	// 1. verify each R_bar_j against the ciphertext of k_j that P_j sent to this party in round 1
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return round.WrapError(err)
+		}
+		r1msg1 := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
+		r5msg1 := round.temp.signRound5GG20Message1s[j].Content().(*SignRound5GG20Message1)
+		r5msg2 := round.temp.signRound5GG20Message2s[j].Content().(*SignRound5GG20Message2)
+		bigRBarj, err := r5msg2.UnmarshalRBar(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r5msg1.UnmarshalPDLProof(round.EC())
+		if err != nil || !round.VerifyProof("pdl", Pj, func() bool {
+			return proof.Verify(
+				round.proofTranscript(5, Pj),
+				round.key.PaillierPKs[j],
+				r1msg1.UnmarshalC(),
+				R,
+				bigRBarj,
+				round.key.NTildej[i],
+				round.key.H1j[i],
+				round.key.H2j[i],
+				round.MtAProofParams())
+		}) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.bigRBarjs[j] = bigRBarj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to prove R_bar_j"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. sum(R_bar_j) = k*R must be G
+	sumRBar := round.temp.bigRBarjs[i]
+	for j, bigRBarj := range round.temp.bigRBarjs {
+		if j == i {
+			continue
+		}
+		var err error
+		if sumRBar, err = sumRBar.Add(bigRBarj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "sumRBar.Add(R_bar_j)"))
+		}
+	}
+	ecParams := round.EC().Params()
+	if !sumRBar.Equals(crypto.NewECPointNoCurveCheck(round.EC(), ecParams.Gx, ecParams.Gy)) {
+		return round.WrapError(errors.New("consistency check of R failed: sum(R_bar_j) != G")).WithCode(tss.CodeInvalidResult)
+	}
+
+	// 3. S_i = sigma_i*R, with a proof that it uses the sigma_i committed to in T_i
+	bigSi := R.ScalarMult(round.temp.sigma)
+	proof, err := schnorr.NewZKSTProofInTranscript(round.proofTranscript(6, round.PartyID()), bigSi, round.temp.bigT, R, pedersenH(round.EC()), round.temp.sigma, round.temp.tl)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NewZKSTProof(S_i, T_i)"))
+	}
+	round.temp.bigSjs[i] = bigSi
+	r6msg := NewSignRound6GG20Message(round.PartyID(), bigSi, proof)
+	round.temp.signRound6GG20Messages[i] = r6msg
+	round.out <- round.WithSessionID(r6msg)
+
+	return nil
+}
+
+func (round *round6GG20) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound6GG20Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round6GG20) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound6GG20Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round6GG20) NextRound() tss.Round {
+	round.started = false
+	return &round7GG20{round}
+}
@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"math/big"
 
@@ -18,9 +19,9 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round7) Start() *tss.Error {
+func (round *round7) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 7
 	round.started = true
@@ -36,62 +37,66 @@ func (round *round7) Start() *tss.Error {
 		r6msg := round.temp.signRound6Messages[j].Content().(*SignRound6Message)
 		cj, dj := r5msg.UnmarshalCommitment(), r6msg.UnmarshalDeCommitment()
 		cmtDeCmt := commitments.HashCommitDecommit{C: cj, D: dj}
-		ok, values := cmtDeCmt.DeCommit()
+		ok, values := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), round5CommitmentDomain, round.Params().SessionID())
 		if !ok || len(values) != 4 {
-			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
+			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj).WithCode(tss.CodeDecommitMismatch)
 		}
 		bigVjX, bigVjY, bigAjX, bigAjY := values[0], values[1], values[2], values[3]
-		bigVj, err := crypto.NewECPoint(tss.EC(), bigVjX, bigVjY)
+		bigVj, err := crypto.NewECPoint(round.EC(), bigVjX, bigVjY)
 		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigVj)"), Pj)
+			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigVj)"), Pj).WithCode(tss.CodeInvalidMessage)
 		}
 		bigVjs[j] = bigVj
-		bigAj, err := crypto.NewECPoint(tss.EC(), bigAjX, bigAjY)
+		bigAj, err := crypto.NewECPoint(round.EC(), bigAjX, bigAjY)
 		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigAj)"), Pj)
+			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigAj)"), Pj).WithCode(tss.CodeInvalidMessage)
 		}
 		bigAjs[j] = bigAj
-		pijA, err := r6msg.UnmarshalZKProof()
-		if err != nil || !pijA.Verify(bigAj) {
+		proofCtx := round.proofTranscript(6, Pj, cj)
+		pijA, err := r6msg.UnmarshalZKProof(round.EC())
+		if err != nil || !round.VerifyProof("schnorr", Pj, func() bool { return pijA.VerifyInTranscript(proofCtx, bigAj) }) {
 			return round.WrapError(errors.New("schnorr verify for Aj failed"), Pj)
 		}
-		pijV, err := r6msg.UnmarshalZKVProof()
-		if err != nil || !pijV.Verify(bigVj, round.temp.bigR) {
+		pijV, err := r6msg.UnmarshalZKVProof(round.EC())
+		if err != nil || !round.VerifyProof("schnorr-v", Pj, func() bool { return pijV.VerifyInTranscript(proofCtx, bigVj, round.temp.bigR) }) {
 			return round.WrapError(errors.New("vverify for Vj failed"), Pj)
 		}
 	}
 
-	modN := common.ModInt(tss.EC().Params().N)
+	modN := common.ModInt(round.EC().Params().N)
 	AX, AY := round.temp.bigAi.X(), round.temp.bigAi.Y()
 	minusM := modN.Sub(big.NewInt(0), round.temp.m)
-	gToMInvX, gToMInvY := tss.EC().ScalarBaseMult(minusM.Bytes())
+	gToMInvX, gToMInvY := round.EC().ScalarBaseMult(minusM.Bytes())
 	minusR := modN.Sub(big.NewInt(0), round.temp.rx)
-	yToRInvX, yToRInvY := tss.EC().ScalarMult(round.key.ECDSAPub.X(), round.key.ECDSAPub.Y(), minusR.Bytes())
-	VX, VY := tss.EC().Add(gToMInvX, gToMInvY, yToRInvX, yToRInvY)
-	VX, VY = tss.EC().Add(VX, VY, round.temp.bigVi.X(), round.temp.bigVi.Y())
+	yToRInvX, yToRInvY := round.EC().ScalarMult(round.key.ECDSAPub.X(), round.key.ECDSAPub.Y(), minusR.Bytes())
+	VX, VY := round.EC().Add(gToMInvX, gToMInvY, yToRInvX, yToRInvY)
+	VX, VY = round.EC().Add(VX, VY, round.temp.bigVi.X(), round.temp.bigVi.Y())
 
 	for j := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
 			continue
 		}
-		VX, VY = tss.EC().Add(VX, VY, bigVjs[j].X(), bigVjs[j].Y())
-		AX, AY = tss.EC().Add(AX, AY, bigAjs[j].X(), bigAjs[j].Y())
+		VX, VY = round.EC().Add(VX, VY, bigVjs[j].X(), bigVjs[j].Y())
+		AX, AY = round.EC().Add(AX, AY, bigAjs[j].X(), bigAjs[j].Y())
 	}
 
-	UiX, UiY := tss.EC().ScalarMult(VX, VY, round.temp.roi.Bytes())
-	TiX, TiY := tss.EC().ScalarMult(AX, AY, round.temp.li.Bytes())
-	round.temp.Ui = crypto.NewECPointNoCurveCheck(tss.EC(), UiX, UiY)
-	round.temp.Ti = crypto.NewECPointNoCurveCheck(tss.EC(), TiX, TiY)
-	cmt := commitments.NewHashCommitment(UiX, UiY, TiX, TiY)
+	bigVjs[round.PartyID().Index] = round.temp.bigVi
+	round.temp.bigVjs = bigVjs
+
+	UiX, UiY := round.EC().ScalarMult(VX, VY, round.temp.roi.Bytes())
+	TiX, TiY := round.EC().ScalarMult(AX, AY, round.temp.li.Bytes())
+	round.temp.Ui = crypto.NewECPointNoCurveCheck(round.EC(), UiX, UiY)
+	round.temp.Ti = crypto.NewECPointNoCurveCheck(round.EC(), TiX, TiY)
+	cmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), round7CommitmentDomain, round.Params().SessionID(), UiX, UiY, TiX, TiY)
 	r7msg := NewSignRound7Message(round.PartyID(), cmt.C)
 	round.temp.signRound7Messages[round.PartyID().Index] = r7msg
-	round.out <- r7msg
+	round.out <- round.WithSessionID(r7msg)
 	round.temp.DTelda = cmt.D
 
 	return nil
 }
 
-func (round *round7) Update() (bool, *tss.Error) {
+func (round *round7) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound7Messages {
 		if round.ok[j] {
 			continue
@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round7GG20) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 7
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	R, H := round.temp.bigR, pedersenH(round.EC())
+
+	// 1. verify each S_j against T_j
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r6msg := round.temp.signRound6GG20Messages[j].Content().(*SignRound6GG20Message)
+		bigSj, err := r6msg.UnmarshalS(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r6msg.UnmarshalSTProof(round.EC())
+		if err != nil || !round.VerifyProof("st", Pj, func() bool {
+			return proof.VerifyInTranscript(round.proofTranscript(6, Pj), bigSj, round.temp.bigTjs[j], R, H)
+		}) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.bigSjs[j] = bigSj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to prove S_j"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. sum(S_j) = sigma*R = x*G must be the public key
+	sumS := round.temp.bigSjs[i]
+	for j, bigSj := range round.temp.bigSjs {
+		if j == i {
+			continue
+		}
+		var err error
+		if sumS, err = sumS.Add(bigSj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "sumS.Add(S_j)"))
+		}
+	}
+	if !sumS.Equals(round.key.ECDSAPub) {
+		return round.WrapError(errors.New("consistency check of sigma failed: sum(S_j) != y")).WithCode(tss.CodeInvalidResult)
+	}
+
+	// 3. s_i = m*k_i + r*sigma_i; this is the only step that depends on the message
+	modN := common.ModInt(round.EC().Params().N)
+	si := modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(round.temp.rx, round.temp.sigma))
+
+	// clear temp.w and temp.k from memory, lint ignore
+	round.temp.w = zero
+	round.temp.k = zero
+
+	round.temp.si = si
+	r7msg := NewSignRound7GG20Message(round.PartyID(), si)
+	round.temp.signRound7GG20Messages[i] = r7msg
+	round.out <- round.WithSessionID(r7msg)
+
+	return nil
+}
+
+func (round *round7GG20) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound7GG20Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round7GG20) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound7GG20Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round7GG20) NextRound() tss.Round {
+	round.started = false
+	return &finalizationGG20{round}
+}
@@ -7,14 +7,15 @@
 package signing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round8) Start() *tss.Error {
+func (round *round8) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 8
 	round.started = true
@@ -22,12 +23,12 @@ func (round *round8) Start() *tss.Error {
 
 	r8msg := NewSignRound8Message(round.PartyID(), round.temp.DTelda)
 	round.temp.signRound8Messages[round.PartyID().Index] = r8msg
-	round.out <- r8msg
+	round.out <- round.WithSessionID(r8msg)
 
 	return nil
 }
 
-func (round *round8) Update() (bool, *tss.Error) {
+func (round *round8) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound8Messages {
 		if round.ok[j] {
 			continue
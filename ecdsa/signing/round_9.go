@@ -7,15 +7,16 @@
 package signing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/crypto/commitments"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round9) Start() *tss.Error {
+func (round *round9) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 9
 	round.started = true
@@ -32,25 +33,26 @@ func (round *round9) Start() *tss.Error {
 		r8msg := round.temp.signRound8Messages[j].Content().(*SignRound8Message)
 		cj, dj := r7msg.UnmarshalCommitment(), r8msg.UnmarshalDeCommitment()
 		cmt := commitments.HashCommitDecommit{C: cj, D: dj}
-		ok, values := cmt.DeCommit()
+		ok, values := cmt.DeCommitInSession(round.Params().CommitmentFraming(), round7CommitmentDomain, round.Params().SessionID())
 		if !ok && len(values) != 4 {
-			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
+			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj).WithCode(tss.CodeDecommitMismatch)
 		}
 		UjX, UjY, TjX, TjY := values[0], values[1], values[2], values[3]
-		UX, UY = tss.EC().Add(UX, UY, UjX, UjY)
-		TX, TY = tss.EC().Add(TX, TY, TjX, TjY)
+		UX, UY = round.EC().Add(UX, UY, UjX, UjY)
+		TX, TY = round.EC().Add(TX, TY, TjX, TjY)
 	}
 	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
 		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
 	}
 
-	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
+	// l_i is revealed alongside s_i so that peers can verify s_i against the V_i that was de-committed in round 7
+	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si, round.temp.li)
 	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
-	round.out <- r9msg
+	round.out <- round.WithSessionID(r9msg)
 	return nil
 }
 
-func (round *round9) Update() (bool, *tss.Error) {
+func (round *round9) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound9Messages {
 		if round.ok[j] {
 			continue
@@ -7,13 +7,24 @@
 package signing
 
 import (
+	"math/big"
+
 	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
 const (
 	TaskName = "signing"
+
+	// the domains of the hash commitments made in rounds 1, 5 and 7, see commitments.NewHashCommitmentInSession
+	round1CommitmentDomain = "ecdsa-signing/round-1"
+	round5CommitmentDomain = "ecdsa-signing/round-5"
+	round7CommitmentDomain = "ecdsa-signing/round-7"
+
+	// the domain of the session IDs of the attempts begun by Restart
+	restartSessionDomain = "ecdsa-signing/restart"
 )
 
 type (
@@ -58,6 +69,20 @@ type (
 	finalization struct {
 		*round9
 	}
+
+	// GG20 shares rounds 1-4 with GG18 and continues with these
+	round5GG20 struct {
+		*round4
+	}
+	round6GG20 struct {
+		*round5GG20
+	}
+	round7GG20 struct {
+		*round6GG20
+	}
+	finalizationGG20 struct {
+		*round7GG20
+	}
 )
 
 var (
@@ -71,6 +96,32 @@ var (
 	_ tss.Round = (*round8)(nil)
 	_ tss.Round = (*round9)(nil)
 	_ tss.Round = (*finalization)(nil)
+	_ tss.Round = (*round5GG20)(nil)
+	_ tss.Round = (*round6GG20)(nil)
+	_ tss.Round = (*round7GG20)(nil)
+	_ tss.Round = (*finalizationGG20)(nil)
+)
+
+// phases names the rounds of GG18 by their number; GG20 shares the first 4 with it
+var (
+	phases = []string{
+		1:  "MtA requests and commitments",
+		2:  "MtA responses",
+		3:  "delta shares",
+		4:  "Gamma de-commitments",
+		5:  "commitments to V_i and A_i",
+		6:  "de-commitments of V_i and A_i",
+		7:  "commitments to U_i and T_i",
+		8:  "de-commitments of U_i and T_i",
+		9:  "signature shares",
+		10: "finalization",
+	}
+	phasesGG20 = append(phases[:5:5],
+		"R_bar_i and PDL proofs",
+		"S_i shares",
+		"signature shares",
+		"finalization",
+	)
 )
 
 // ----- //
@@ -83,6 +134,21 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+func (round *base) TotalRounds() int {
+	return len(round.phases()) - 1
+}
+
+func (round *base) Phase() string {
+	return round.phases()[round.number]
+}
+
+func (round *base) phases() []string {
+	if round.SigningProtocol() == tss.GG20 {
+		return phasesGG20
+	}
+	return phases
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -121,3 +187,18 @@ func (round *base) resetOK() {
 		round.ok[j] = false
 	}
 }
+
+// proofTranscript returns the context of the proofs that `prover` makes in round `number`, which binds them to this
+// signing session, to the round, to the prover and to the `priorCommitments` that the prover made before them
+func (round *base) proofTranscript(number int, prover *tss.PartyID, priorCommitments ...*big.Int) *zkp.Transcript {
+	return proofTranscript(round.Params().SessionID(), number, prover.KeyInt(), priorCommitments...)
+}
+
+// proofTranscript returns the context of the proofs that the party with the key `proverKey` makes in round `number` of
+// the signing session `sessionID`, for the code that runs outside of a round
+func proofTranscript(sessionID []byte, number int, proverKey *big.Int, priorCommitments ...*big.Int) *zkp.Transcript {
+	tr := zkp.NewSessionTranscript(TaskName, sessionID, number)
+	tr.AppendInts("prover", proverKey)
+	tr.AppendInts("commitments", priorCommitments...)
+	return tr
+}
@@ -0,0 +1,205 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	// SessionManager runs many concurrent signing ceremonies over the same key share.
+	// The parameters of each session are set to its ID, which every message carries in its header, and inbound messages
+	// are routed to the LocalParty of the session in their header.
+	SessionManager struct {
+		mtx      sync.Mutex
+		key      keygen.LocalPartySaveData
+		sessions map[string]*signingSession
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- SessionSignatureData
+	}
+
+	// SessionSignatureData is the output of a finished signing session
+	SessionSignatureData struct {
+		SessionID string
+		Data      common.SignatureData
+	}
+
+	signingSession struct {
+		party tss.Party
+		done  chan struct{}
+	}
+)
+
+// NewSessionManager constructs a SessionManager for the given key share.
+// Outbound messages of every session are sent to `out` and finished signatures to `end`.
+func NewSessionManager(
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- SessionSignatureData,
+) *SessionManager {
+	return &SessionManager{
+		key:      key,
+		sessions: make(map[string]*signingSession),
+		out:      out,
+		end:      end,
+	}
+}
+
+// StartSession creates and starts a new LocalParty for the session `sessionID`, which it sets on `params` with
+// SetSessionID unless they are already set to it. The session ID must be agreed on by all of the signers out-of-band
+// and must not be in use on this manager.
+func (sm *SessionManager) StartSession(ctx context.Context, sessionID string, msg *big.Int, params *tss.Parameters) *tss.Error {
+	if sessionID == "" {
+		return tss.NewError(errors.New("session id must not be empty"), TaskName, -1, params.PartyID()).WithCode(tss.CodeBadInput)
+	}
+	if set := params.SessionID(); 0 < len(set) && !bytes.Equal(set, []byte(sessionID)) {
+		return tss.NewError(fmt.Errorf("the parameters are set to the session %x, not %s", set, sessionID), TaskName, -1, params.PartyID()).
+			WithCode(tss.CodeBadInput)
+	}
+	params.SetSessionID([]byte(sessionID))
+	outCh := make(chan tss.Message, len(params.Parties().IDs()))
+	endCh := make(chan common.SignatureData, 1)
+	sess := &signingSession{
+		party: NewLocalParty(msg, params, sm.key, outCh, endCh),
+		done:  make(chan struct{}),
+	}
+	sm.mtx.Lock()
+	if _, exists := sm.sessions[sessionID]; exists {
+		sm.mtx.Unlock()
+		return tss.NewError(fmt.Errorf("session %s already exists", sessionID), TaskName, -1, params.PartyID()).WithCode(tss.CodeBadInput)
+	}
+	sm.sessions[sessionID] = sess
+	sm.mtx.Unlock()
+
+	go sm.forward(sessionID, sess, outCh, endCh)
+	if err := sess.party.Start(ctx); err != nil {
+		sm.endSession(sessionID, sess)
+		return err
+	}
+	return nil
+}
+
+// Update routes an inbound message to the LocalParty of the session in its header
+func (sm *SessionManager) Update(ctx context.Context, msg tss.ParsedMessage) (bool, *tss.Error) {
+	sess, err := sm.session(string(msg.GetSessionID()))
+	if err != nil {
+		return false, tss.NewError(err, TaskName, -1, nil, msg.GetFrom()).WithCode(tss.CodeSessionMismatch)
+	}
+	return sess.party.Update(ctx, msg)
+}
+
+// UpdateFromBytes routes what was received from the wire, the wire bytes of a message or a SignedEnvelope of them, to
+// the LocalParty of the session in its header, which parses it
+func (sm *SessionManager) UpdateFromBytes(ctx context.Context, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	sessionID, err := tss.WireSessionID(wireBytes)
+	if err != nil {
+		return false, tss.NewError(err, TaskName, -1, nil, from).WithCode(tss.CodeInvalidMessage)
+	}
+	sess, err := sm.session(string(sessionID))
+	if err != nil {
+		return false, tss.NewError(err, TaskName, -1, nil, from).WithCode(tss.CodeSessionMismatch)
+	}
+	return sess.party.UpdateFromBytes(ctx, wireBytes, from, isBroadcast)
+}
+
+// Party returns the LocalParty running the session `sessionID`, or nil if there is no such session
+func (sm *SessionManager) Party(sessionID string) tss.Party {
+	sess, err := sm.session(sessionID)
+	if err != nil {
+		return nil
+	}
+	return sess.party
+}
+
+// Sessions returns the IDs of the sessions that are currently running
+func (sm *SessionManager) Sessions() []string {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+	ids := make([]string, 0, len(sm.sessions))
+	for id := range sm.sessions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// EndSession discards the session `sessionID`. Messages for it that arrive afterwards are rejected.
+// Sessions are ended automatically once their signature has been sent to the `end` channel.
+func (sm *SessionManager) EndSession(sessionID string) {
+	sm.endSession(sessionID, nil)
+}
+
+// ----- //
+
+// endSession ends the session `sessionID` if it is `sess`, or whichever it is if `sess` is nil, so that a session that
+// ends late never ends another one that has since been started with the same ID
+func (sm *SessionManager) endSession(sessionID string, sess *signingSession) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+	if cur, ok := sm.sessions[sessionID]; ok && (sess == nil || cur == sess) {
+		close(cur.done)
+		delete(sm.sessions, sessionID)
+	}
+}
+
+func (sm *SessionManager) session(sessionID string) (*signingSession, error) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+	sess, ok := sm.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("received a message for an unknown session: %x", sessionID)
+	}
+	return sess, nil
+}
+
+// forward passes on the messages of a session and tags its result with its ID until the session ends. The result is sent before
+// the session ends, so that its ID cannot be taken by a new session while the result is pending, and every send gives
+// up once the session has been ended, so that the goroutine does not leak if nobody reads `out` or `end`.
+func (sm *SessionManager) forward(sessionID string, sess *signingSession, outCh <-chan tss.Message, endCh <-chan common.SignatureData) {
+	send := func(msg tss.Message) bool {
+		select {
+		case sm.out <- msg:
+			return true
+		case <-sess.done:
+			return false
+		}
+	}
+	for {
+		select {
+		case msg := <-outCh:
+			if !send(msg) {
+				return
+			}
+		case data := <-endCh:
+			// flush any messages that were queued before the signature was produced
+			for len(outCh) > 0 {
+				if !send(<-outCh) {
+					return
+				}
+			}
+			select {
+			case sm.end <- SessionSignatureData{SessionID: sessionID, Data: data}:
+			case <-sess.done:
+				return
+			}
+			sm.endSession(sessionID, sess)
+			return
+		case <-sess.done:
+			return
+		}
+	}
+}
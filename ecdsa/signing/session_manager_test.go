@@ -0,0 +1,138 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestE2ESessionManager(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	sessionMsgs := map[string]*big.Int{
+		"session-a": big.NewInt(42),
+		"session-b": big.NewInt(43),
+	}
+
+	errCh := make(chan *tss.Error, len(signPIDs)*len(sessionMsgs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(sessionMsgs))
+	endCh := make(chan SessionSignatureData, len(signPIDs)*len(sessionMsgs))
+
+	managers := make([]*SessionManager, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		managers = append(managers, NewSessionManager(keys[i], outCh, endCh))
+	}
+	for sessionID, m := range sessionMsgs {
+		for i, sm := range managers {
+			params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+			assert.NoError(t, err)
+			go func(sm *SessionManager, sessionID string, m *big.Int, params *tss.Parameters) {
+				if err := sm.StartSession(context.Background(), sessionID, m, params); err != nil {
+					errCh <- err
+				}
+			}(sm, sessionID, m, params)
+		}
+	}
+
+	updater := func(sm *SessionManager, msg tss.Message) {
+		bz, _, err := msg.WireBytes()
+		if err != nil {
+			errCh <- tss.NewError(err, TaskName, -1, nil)
+			return
+		}
+		// the session may not have been started on this manager yet
+		for sm.Party(string(msg.GetSessionID())) == nil {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if _, err := sm.UpdateFromBytes(context.Background(), bz, msg.GetFrom(), msg.IsBroadcast()); err != nil {
+			errCh <- err
+		}
+	}
+
+	ended := make(map[string]int, len(sessionMsgs))
+	for total := 0; total < len(signPIDs)*len(sessionMsgs); {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for j, sm := range managers {
+					if j == msg.GetFrom().Index {
+						continue
+					}
+					go updater(sm, msg)
+				}
+			} else {
+				go updater(managers[dest[0].Index], msg)
+			}
+
+		case sig := <-endCh:
+			ended[sig.SessionID]++
+			total++
+			pk := ecdsa.PublicKey{
+				Curve: tss.S256(),
+				X:     keys[0].ECDSAPub.X(),
+				Y:     keys[0].ECDSAPub.Y(),
+			}
+			ok := ecdsa.Verify(&pk, sessionMsgs[sig.SessionID].Bytes(),
+				new(big.Int).SetBytes(sig.Data.R), new(big.Int).SetBytes(sig.Data.S))
+			assert.True(t, ok, "ecdsa verify must pass for session %s", sig.SessionID)
+		}
+	}
+	for sessionID := range sessionMsgs {
+		assert.Equal(t, len(signPIDs), ended[sessionID])
+	}
+	for _, sm := range managers {
+		assert.Empty(t, sm.Sessions())
+	}
+}
+
+func TestSessionManagerForward(t *testing.T) {
+	// nobody reads the result, so the session holds its ID until it is ended
+	sm := NewSessionManager(keygen.LocalPartySaveData{}, make(chan tss.Message), make(chan SessionSignatureData))
+	sess := &signingSession{done: make(chan struct{})}
+	sm.sessions["a"] = sess
+	endCh := make(chan common.SignatureData, 1)
+	endCh <- common.SignatureData{}
+	returned := make(chan struct{})
+	go func() {
+		sm.forward("a", sess, make(chan tss.Message), endCh)
+		close(returned)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"a"}, sm.Sessions(), "the session must not end while its result is pending")
+
+	// ending it releases the goroutine, and a late end never ends a new session of the same ID
+	sm.EndSession("a")
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "the forwarding goroutine should return once the session has ended")
+	}
+	next := &signingSession{done: make(chan struct{})}
+	sm.sessions["a"] = next
+	sm.endSession("a", sess)
+	assert.Equal(t, []string{"a"}, sm.Sessions())
+}
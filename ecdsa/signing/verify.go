@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/stark"
+)
+
+// Verify checks the output of a signing ceremony against the ECDSAPub of the keygen save data.
+// `msg` must be the message that was given to NewLocalParty, as big-endian bytes. On the STARK curve the message is
+// taken as an integer rather than a digest to be truncated to the bit length of the order, and the StarkNet rules apply.
+func Verify(sigData *common.SignatureData, pub *crypto.ECPoint, msg []byte) bool {
+	if sigData == nil || len(sigData.R) == 0 || len(sigData.S) == 0 || !pub.ValidateBasic() {
+		return false
+	}
+	r, s := new(big.Int).SetBytes(sigData.R), new(big.Int).SetBytes(sigData.S)
+	if pubKey := pub.ToECDSAPubKey(); pubKey.Curve.Params().Name == stark.Name {
+		return stark.Verify(pubKey, new(big.Int).SetBytes(msg), r, s)
+	}
+	return ecdsa.Verify(pub.ToECDSAPubKey(), msg, r, s)
+}
+
+// ToECDSAPublicKey converts the ECDSAPub of the keygen save data to a standard library ECDSA public key
+func ToECDSAPublicKey(pub *crypto.ECPoint) *ecdsa.PublicKey {
+	return pub.ToECDSAPubKey()
+}
+
+// ToDERSignature encodes the output of a signing ceremony as the ASN.1 DER sequence of r and s used by X.509, TLS and
+// code signing
+func ToDERSignature(sigData *common.SignatureData) ([]byte, error) {
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(sigData.R),
+		S: new(big.Int).SetBytes(sigData.S),
+	})
+}
+
+// ToFixedSizeSignature encodes the output of a signing ceremony on the curve `ec` as r || s, each padded to the byte
+// length of the curve order, as JWS (ES256 etc.) and PKCS #11 expect. The unpadded SignatureData.Signature may be
+// shorter.
+func ToFixedSizeSignature(ec elliptic.Curve, sigData *common.SignatureData) []byte {
+	byteLen := (ec.Params().N.BitLen() + 7) / 8
+	bz := make([]byte, 2*byteLen)
+	copy(bz[byteLen-len(sigData.R):], sigData.R)
+	copy(bz[2*byteLen-len(sigData.S):], sigData.S)
+	return bz
+}
+
+// ToBtcecPubKey converts the ECDSAPub of the keygen save data to a btcec public key.
+// The key must be on the secp256k1 curve.
+func ToBtcecPubKey(pub *crypto.ECPoint) *btcec.PublicKey {
+	return &btcec.PublicKey{
+		Curve: btcec.S256(),
+		X:     pub.X(),
+		Y:     pub.Y(),
+	}
+}
+
+// ToBtcecSignature converts the output of a signing ceremony to a btcec signature
+func ToBtcecSignature(sigData *common.SignatureData) *btcec.Signature {
+	return &btcec.Signature{
+		R: new(big.Int).SetBytes(sigData.R),
+		S: new(big.Int).SetBytes(sigData.S),
+	}
+}
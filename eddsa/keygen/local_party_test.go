@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -40,12 +41,10 @@ func setUp(level string) {
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	setUp("info")
 
-	tss.SetCurve(edwards.Edwards())
-
 	threshold := testThreshold
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
-		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
+		common.DefaultLogger().Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
 	}
 
@@ -63,7 +62,9 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
 		var P *LocalParty
-		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
+		params.SetCurve(tss.Edwards())
 		if i < len(fixtures) {
 			P = NewLocalParty(params, outCh, endCh).(*LocalParty)
 		} else {
@@ -71,7 +72,7 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 		}
 		parties = append(parties, P)
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -84,7 +85,7 @@ keygen:
 		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
 		select {
 		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			break keygen
 
@@ -133,17 +134,17 @@ keygen:
 						}
 						pShares = append(pShares, shareStruct)
 					}
-					uj, err := pShares[:threshold+1].ReConstruct()
+					uj, err := pShares[:threshold+1].ReConstruct(tss.Edwards())
 					assert.NoError(t, err, "vss.ReConstruct should not throw error")
 
 					// uG test: u*G[j] == V[0]
 					assert.Equal(t, uj, Pj.temp.ui)
-					uG := crypto.ScalarBaseMult(tss.EC(), uj)
+					uG := crypto.ScalarBaseMult(tss.Edwards(), uj)
 					assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
 
 					// xj tests: BigXj == xj*G
 					xj := Pj.data.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
+					gXj := crypto.ScalarBaseMult(tss.Edwards(), xj)
 					BigXj := Pj.data.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 
@@ -151,23 +152,23 @@ keygen:
 					{
 						badShares := pShares[:threshold]
 						badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-						uj, err := pShares[:threshold].ReConstruct()
+						uj, err := pShares[:threshold].ReConstruct(tss.Edwards())
 						assert.NoError(t, err)
 						assert.NotEqual(t, parties[j].temp.ui, uj)
-						BigXjX, BigXjY := tss.EC().ScalarBaseMult(uj.Bytes())
+						BigXjX, BigXjY := tss.Edwards().ScalarBaseMult(uj.Bytes())
 						assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
 						assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
 					}
 					u = new(big.Int).Add(u, uj)
 				}
-				u = new(big.Int).Mod(u, tss.EC().Params().N)
+				u = new(big.Int).Mod(u, tss.Edwards().Params().N)
 				scalar := make([]byte, 0, 32)
 				copy(scalar, u.Bytes())
 
 				// build eddsa key pair
 				pkX, pkY := save.EDDSAPub.X(), save.EDDSAPub.Y()
 				pk := edwards.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.Edwards(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -182,7 +183,7 @@ keygen:
 
 				// public key tests
 				assert.NotZero(t, u, "u should not be zero")
-				ourPkX, ourPkY := tss.EC().ScalarBaseMult(u.Bytes())
+				ourPkX, ourPkY := tss.Edwards().ScalarBaseMult(u.Bytes())
 				assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
 				assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
 				t.Log("Public key tests done.")
@@ -238,3 +239,198 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestSnapshotRestore(t *testing.T) {
+	setUp("info")
+
+	threshold := 2
+	pIDs := tss.GenerateTestPartyIDs(4)
+	ctx := context.Background()
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs)*4)
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		param, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
+		param.SetCurve(tss.Edwards())
+		params = append(params, param)
+		parties = append(parties, NewLocalParty(params[i], outCh, endCh))
+	}
+	for _, P := range parties {
+		if err := P.Start(ctx); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// deliver the messages one at a time, and move party 0 to a new party once it has reached round 2
+	restored := false
+	for len(endCh) < len(pIDs) {
+		if !assert.NotZero(t, len(outCh), "the parties should not wait for messages") {
+			return
+		}
+		msg := <-outCh
+		bz, routing, err := msg.WireBytes()
+		assert.NoError(t, err)
+		dest := routing.To
+		if dest == nil {
+			dest = pIDs
+		}
+		for _, Pj := range dest {
+			if Pj.Index == msg.GetFrom().Index {
+				continue
+			}
+			if _, err := parties[Pj.Index].UpdateFromBytes(ctx, bz, msg.GetFrom(), routing.IsBroadcast); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		if !restored && parties[0].Progress().Round == 2 {
+			snapshot, err := parties[0].(*LocalParty).Bytes()
+			if !assert.NoError(t, err) {
+				return
+			}
+			P, err2 := RestoreLocalParty(ctx, snapshot, params[0], outCh, endCh)
+			if err2 != nil {
+				assert.FailNow(t, err2.Error())
+			}
+			assert.Equal(t, parties[0].Progress(), P.Progress())
+			assert.Equal(t, parties[0].WaitingFor(), P.WaitingFor())
+			parties[0], restored = P, true
+		}
+	}
+	assert.True(t, restored)
+
+	saves := make([]LocalPartySaveData, 0, len(pIDs))
+	for len(endCh) > 0 {
+		saves = append(saves, <-endCh)
+	}
+	for _, save := range saves {
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub))
+		i, err := save.OriginalIndex()
+		if assert.NoError(t, err) {
+			assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), save.Xi).Equals(save.BigXj[i]), "ensure BigX_i == g^x_i")
+		}
+	}
+}
+
+// a transport of the test that queues each message with the recipients that it was sent to
+type queueTransport chan queuedMessage
+
+type queuedMessage struct {
+	msg tss.Message
+	to  []*tss.PartyID
+}
+
+func (q queueTransport) Send(msg tss.Message, to ...*tss.PartyID) error {
+	q <- queuedMessage{msg: msg, to: to}
+	return nil
+}
+
+func (q queueTransport) Broadcast(msg tss.Message) error {
+	q <- queuedMessage{msg: msg}
+	return nil
+}
+
+func TestCrashResume(t *testing.T) {
+	setUp("info")
+
+	threshold := 2
+	pIDs := tss.GenerateTestPartyIDs(4)
+	ctx := context.Background()
+	p2pCtx := tss.NewPeerContext(pIDs)
+	sessionID := []byte("crash-resume")
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs)*4)
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+	wire := make(queueTransport, len(pIDs)*len(pIDs)*8)
+
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	outboxes := make([]*tss.Outbox, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		param, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		assert.NoError(t, err)
+		param.SetCurve(tss.Edwards())
+		param.SetSessionID(sessionID)
+		params = append(params, param)
+		parties = append(parties, NewLocalParty(params[i], outCh, endCh))
+		outboxes = append(outboxes, tss.NewOutbox(wire))
+	}
+	for _, P := range parties {
+		if err := P.Start(ctx); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// party 0 crashes once it has reached round 2 and loses the messages sent to it until it has been resumed
+	var snapshot []byte
+	crashed, resumed := false, false
+	for len(endCh) < len(pIDs) {
+		for len(outCh) > 0 {
+			msg := <-outCh
+			assert.NoError(t, tss.Deliver(outboxes[msg.GetFrom().Index], msg))
+		}
+		if len(wire) == 0 {
+			if !crashed || resumed {
+				assert.FailNow(t, "the parties should not wait for messages")
+			}
+			// the peers wait for party 0, which resumes from its snapshot and asks them for the messages it lost
+			P, err := RestoreLocalParty(ctx, snapshot, params[0], outCh, endCh)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			reqs := tss.ResendRequests(P)
+			assert.Equal(t, len(pIDs)-1, len(reqs))
+			for Pj, req := range reqs {
+				bz, err := req.Bytes()
+				assert.NoError(t, err)
+				parsed, err := tss.ParseResendRequest(bz, pIDs[0])
+				assert.NoError(t, err)
+				n, err := outboxes[Pj.Index].Resend(parsed)
+				assert.NoError(t, err)
+				assert.NotZero(t, n)
+			}
+			parties[0], resumed = P, true
+			continue
+		}
+		queued := <-wire
+		bz, routing, err := queued.msg.WireBytes()
+		assert.NoError(t, err)
+		dest := queued.to
+		if dest == nil {
+			dest = pIDs
+		}
+		for _, Pj := range dest {
+			if Pj.Index == queued.msg.GetFrom().Index || (Pj.Index == 0 && crashed && !resumed) {
+				continue
+			}
+			if _, err := parties[Pj.Index].UpdateFromBytes(ctx, bz, queued.msg.GetFrom(), routing.IsBroadcast); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		if !crashed && parties[0].Progress().Round == 2 {
+			if snapshot, err = parties[0].(*LocalParty).Bytes(); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			crashed = true
+		}
+	}
+	assert.True(t, resumed)
+
+	saves := make([]LocalPartySaveData, 0, len(pIDs))
+	for len(endCh) > 0 {
+		saves = append(saves, <-endCh)
+	}
+	for _, save := range saves {
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub))
+	}
+
+	// once the ceremony has ended the outboxes drop their messages
+	for _, outbox := range outboxes {
+		outbox.Reset()
+		n, err := outbox.Resend(&tss.ResendRequest{From: pIDs[0], SessionID: sessionID})
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+	}
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"math/big"
 
 	"github.com/golang/protobuf/proto"
@@ -23,8 +24,8 @@ import (
 // The following messages are registered on the Protocol Buffers "wire"
 
 var (
-	// Ensure that keygen messages implement ValidateBasic
-	_ = []tss.MessageContent{
+	// Ensure that keygen messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
 		(*KGRound1Message)(nil),
 		(*KGRound2Message1)(nil),
 		(*KGRound2Message2)(nil),
@@ -35,6 +36,7 @@ func init() {
 	proto.RegisterType((*KGRound1Message)(nil), tss.EDDSAProtoNamePrefix+"keygen.KGRound1Message")
 	proto.RegisterType((*KGRound2Message1)(nil), tss.EDDSAProtoNamePrefix+"keygen.KGRound2Message1")
 	proto.RegisterType((*KGRound2Message2)(nil), tss.EDDSAProtoNamePrefix+"keygen.KGRound2Message2")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
 }
 
 // ----- //
@@ -118,9 +120,9 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
-func (m *KGRound2Message2) UnmarshalZKProof() (*schnorr.ZKProof, error) {
+func (m *KGRound2Message2) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
-		tss.EC(),
+		ec,
 		new(big.Int).SetBytes(m.GetProofAlphaX()),
 		new(big.Int).SetBytes(m.GetProofAlphaY()))
 	if err != nil {
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"errors"
 
 	errors2 "github.com/pkg/errors"
@@ -15,9 +16,9 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round2) Start() *tss.Error {
+func (round *round2) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 2
 	round.started = true
@@ -41,19 +42,19 @@ func (round *round2) Start() *tss.Error {
 			continue
 		}
 		round.temp.kgRound2Message1s[i] = r2msg1
-		round.out <- r2msg1
+		round.out <- round.WithSessionID(r2msg1)
 	}
 
 	// 5. compute Schnorr prove
-	pii, err := schnorr.NewZKProof(round.temp.ui, round.temp.vs[0])
+	pii, err := schnorr.NewZKProofInTranscript(round.proofTranscript(2, round.PartyID()), round.temp.ui, round.temp.vs[0])
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKProof(ui, vi0)"))
+		return round.WrapError(errors2.Wrapf(err, "NewZKProofInTranscript(ui, vi0)"))
 	}
 
 	// 5. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, pii)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	round.out <- round.WithSessionID(r2msg2)
 
 	return nil
 }
@@ -68,7 +69,7 @@ func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round2) Update() (bool, *tss.Error) {
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
 	// guard - VERIFY de-commit for all Pj
 	for j, msg := range round.temp.kgRound2Message1s {
 		if round.ok[j] {
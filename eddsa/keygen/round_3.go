@@ -7,7 +7,9 @@
 package keygen
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/hashicorp/go-multierror"
@@ -20,9 +22,9 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round3) Start() *tss.Error {
+func (round *round3) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 3
 	round.started = true
@@ -41,7 +43,7 @@ func (round *round3) Start() *tss.Error {
 		share := r2msg1.UnmarshalShare()
 		xi = new(big.Int).Add(xi, share)
 	}
-	round.save.Xi = new(big.Int).Mod(xi, tss.EC().Params().N)
+	round.save.Xi = new(big.Int).Mod(xi, round.EC().Params().N)
 
 	// 2-3.
 	Vc := make(vss.Vs, round.Threshold()+1)
@@ -54,12 +56,13 @@ func (round *round3) Start() *tss.Error {
 		unWrappedErr error
 		pjVs         vss.Vs
 	}
+	sem := common.NewSemaphore(round.Concurrency())
 	chs := make([]chan vssOut, len(Ps))
 	for i := range chs {
 		if i == PIdx {
 			continue
 		}
-		chs[i] = make(chan vssOut)
+		chs[i] = make(chan vssOut, 1)
 	}
 	for j := range Ps {
 		if j == PIdx {
@@ -67,27 +70,29 @@ func (round *round3) Start() *tss.Error {
 		}
 		// 6-9.
 		go func(j int, ch chan<- vssOut) {
+			sem.Acquire()
+			defer sem.Release()
 			// 4-10.
 			KGCj := round.temp.KGCs[j]
 			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
 			KGDj := r2msg2.UnmarshalDeCommitment()
 			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
-			ok, flatPolyGs := cmtDeCmt.DeCommit()
+			ok, flatPolyGs := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
 			if !ok || flatPolyGs == nil {
 				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
 				return
 			}
-			PjVs, err := crypto.UnFlattenECPoints(tss.EC(), flatPolyGs)
+			PjVs, err := crypto.UnFlattenECPoints(round.EC(), flatPolyGs)
 			if err != nil {
 				ch <- vssOut{err, nil}
 				return
 			}
-			proof, err := r2msg2.UnmarshalZKProof()
+			proof, err := r2msg2.UnmarshalZKProof(round.EC())
 			if err != nil {
 				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
 				return
 			}
-			ok = proof.Verify(PjVs[0])
+			ok = round.VerifyProof("schnorr", Ps[j], func() bool { return proof.VerifyInTranscript(round.proofTranscript(2, Ps[j]), PjVs[0]) })
 			if !ok {
 				ch <- vssOut{errors.New("failed to prove schnorr proof"), nil}
 				return
@@ -98,7 +103,7 @@ func (round *round3) Start() *tss.Error {
 				ID:        round.PartyID().KeyInt(),
 				Share:     r2msg1.UnmarshalShare(),
 			}
-			if ok = PjShare.Verify(round.Threshold(), PjVs); !ok {
+			if ok = round.VerifyProof("vss-share", Ps[j], func() bool { return PjShare.Verify(round.Threshold(), PjVs) }); !ok {
 				ch <- vssOut{errors.New("vss verify failed"), nil}
 				return
 			}
@@ -149,14 +154,14 @@ func (round *round3) Start() *tss.Error {
 			}
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...).WithCode(tss.CodeInvalidMessage)
 		}
 	}
 
 	// 13-17. compute Xj for each Pj
 	{
 		var err error
-		modQ := common.ModInt(tss.EC().Params().N)
+		modQ := common.ModInt(round.EC().Params().N)
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
 		bigXj := round.save.BigXj
 		for j := 0; j < round.PartyCount(); j++ {
@@ -174,20 +179,21 @@ func (round *round3) Start() *tss.Error {
 			bigXj[j] = BigXj
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
+			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...).WithCode(tss.CodeInvalidMessage)
 		}
 		round.save.BigXj = bigXj
 	}
 
 	// 18. compute and SAVE the EDDSA public key `y`
-	eddsaPubKey, err := crypto.NewECPoint(tss.EC(), Vc[0].X(), Vc[0].Y())
+	eddsaPubKey, err := crypto.NewECPoint(round.EC(), Vc[0].X(), Vc[0].Y())
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
 	}
 	round.save.EDDSAPub = eddsaPubKey
+	round.save.CurveName = round.CurveName()
 
 	// PRINT public key & private share
-	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
+	round.Logger().Debug("public key", "key", fmt.Sprintf("%x", eddsaPubKey))
 
 	round.end <- *round.save
 	return nil
@@ -198,7 +204,7 @@ func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round3) Update() (bool, *tss.Error) {
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
 	// not expecting any incoming messages in this round
 	return false, nil
 }
@@ -7,11 +7,15 @@
 package keygen
 
 import (
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
 const (
 	TaskName = "eddsa-keygen"
+
+	// the domain of the hash commitments made in round 1, see commitments.NewHashCommitmentInSession
+	commitmentDomain = TaskName + "/round-1"
 )
 
 type (
@@ -36,6 +40,13 @@ type (
 	}
 )
 
+// phases names the rounds by their number
+var phases = []string{
+	1: "commitments",
+	2: "shares and de-commitments",
+	3: "finalization",
+}
+
 func (round *base) Params() *tss.Parameters {
 	return round.Parameters
 }
@@ -44,6 +55,14 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+func (round *base) TotalRounds() int {
+	return len(phases) - 1
+}
+
+func (round *base) Phase() string {
+	return phases[round.number]
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -82,3 +101,18 @@ func (round *base) resetOK() {
 		round.ok[j] = false
 	}
 }
+
+// resume puts a round restored from a snapshot in the state that Start leaves it in, without running it again
+func (round *base) resume(number int) {
+	round.number = number
+	round.started = true
+	round.resetOK()
+}
+
+// proofTranscript returns the context of the proofs that `prover` makes in round `number`, which binds them to this
+// keygen session, to the round and to the prover, see zkp.NewSessionTranscript
+func (round *base) proofTranscript(number int, prover *tss.PartyID) *zkp.Transcript {
+	tr := zkp.NewSessionTranscript(TaskName, round.Params().SessionID(), number)
+	tr.AppendInts("prover", prover.KeyInt())
+	return tr
+}
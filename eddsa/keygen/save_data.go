@@ -33,6 +33,10 @@ type (
 
 		// used for test assertions (may be discarded)
 		EDDSAPub *crypto.ECPoint // y
+
+		// the name of the curve of the key, see tss.RegisterCurve; it is empty in save data from before it was recorded,
+		// whose key is taken to be on the curve of the party
+		CurveName string
 	}
 )
 
@@ -51,10 +55,11 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData := NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.EDDSAPub = sourceData.EDDSAPub
+	newData.CurveName = sourceData.CurveName
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
-			common.Logger.Warning("BuildLocalSaveDataSubset: unable to find a signer party in the local save data", id)
+			common.DefaultLogger().Warn("BuildLocalSaveDataSubset: unable to find a signer party in the local save data", "party", id)
 		}
 		newData.Ks[j] = sourceData.Ks[savedIdx]
 		newData.BigXj[j] = sourceData.BigXj[savedIdx]
@@ -0,0 +1,120 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Implements StatefulParty
+var _ tss.StatefulParty = (*LocalParty)(nil)
+
+// partyState is the state of a keygen party between two rounds, see tss.SnapshotParty
+type partyState struct {
+	Save LocalPartySaveData `json:"save"`
+
+	Ui            *big.Int                 `json:"ui"`
+	KGCs          []cmt.HashCommitment     `json:"kgcs"`
+	Vs            vss.Vs                   `json:"vs"`
+	Shares        vss.Shares               `json:"shares"`
+	DeCommitPolyG cmt.HashDeCommitment     `json:"deCommitPolyG"`
+	Messages      [][]*tss.SnapshotMessage `json:"messages"`
+}
+
+// Bytes returns a snapshot of the party between two rounds, for RestoreLocalParty to resume it
+func (p *LocalParty) Bytes() ([]byte, error) {
+	return tss.SnapshotParty(p)
+}
+
+// RestoreLocalParty returns a party in the state of a snapshot made with Bytes. It takes the parameters of the party
+// that made the snapshot, and waits for the messages of the round that the snapshot was made in.
+func RestoreLocalParty(
+	ctx context.Context,
+	snapshot []byte,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- LocalPartySaveData,
+) (tss.Party, *tss.Error) {
+	p := NewLocalParty(params, out, end).(*LocalParty)
+	if err := tss.RestoreParty(ctx, p, snapshot); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *LocalParty) MarshalState() ([]byte, error) {
+	state := partyState{
+		Save:          p.data,
+		Ui:            p.temp.ui,
+		KGCs:          p.temp.KGCs,
+		Vs:            p.temp.vs,
+		Shares:        p.temp.shares,
+		DeCommitPolyG: p.temp.deCommitPolyG,
+	}
+	for _, msgs := range p.temp.messageStores() {
+		encs, err := tss.EncodeMessages(*msgs)
+		if err != nil {
+			return nil, err
+		}
+		state.Messages = append(state.Messages, encs)
+	}
+	return json.Marshal(&state)
+}
+
+func (p *LocalParty) RestoreState(bz []byte, number int) (tss.Round, error) {
+	state := new(partyState)
+	if err := json.Unmarshal(bz, state); err != nil {
+		return nil, err
+	}
+	stores := p.temp.messageStores()
+	if len(state.Messages) != len(stores) {
+		return nil, fmt.Errorf("the state has %d message stores, expected %d", len(state.Messages), len(stores))
+	}
+	// round 3 does not wait for messages, so a party is never left in it
+	if number < 1 || 2 < number {
+		return nil, fmt.Errorf("the state is of round %d, which keygen does not wait in", number)
+	}
+	for s, msgs := range stores {
+		decoded, err := tss.DecodeMessages(state.Messages[s], p.params.Parties().IDs())
+		if err != nil {
+			return nil, err
+		}
+		if len(decoded) != len(*msgs) {
+			return nil, fmt.Errorf("the state has a message store of %d messages, expected %d", len(decoded), len(*msgs))
+		}
+		*msgs = decoded
+	}
+	p.data = state.Save
+	p.temp.ui, p.temp.KGCs, p.temp.vs, p.temp.shares, p.temp.deCommitPolyG =
+		state.Ui, state.KGCs, state.Vs, state.Shares, state.DeCommitPolyG
+
+	round := p.FirstRound()
+	for r := 1; r < number; r++ {
+		round = round.NextRound()
+	}
+	round.(interface{ resume(int) }).resume(number)
+	return round, nil
+}
+
+// ----- //
+
+// messageStores returns the message stores of every round, in order
+func (temp *localTempData) messageStores() []*[]tss.ParsedMessage {
+	return []*[]tss.ParsedMessage{
+		&temp.kgRound1Messages,
+		&temp.kgRound2Message1s,
+		&temp.kgRound2Message2s,
+		&temp.kgRound3Messages,
+	}
+}
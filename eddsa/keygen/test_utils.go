@@ -59,7 +59,10 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 		pMoniker := fmt.Sprintf("%d", i+start+1)
 		partyIDs[i] = tss.NewPartyID(pMoniker, pMoniker, key.ShareID)
 	}
-	sortedPIDs := tss.SortPartyIDs(partyIDs)
+	sortedPIDs, err := tss.SortPartyIDs(partyIDs, tss.Edwards())
+	if err != nil {
+		return nil, nil, err
+	}
 	return keys, sortedPIDs, nil
 }
 
@@ -96,7 +99,10 @@ func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveDat
 		partyIDs[j] = tss.NewPartyID(pMoniker, pMoniker, key.ShareID)
 		j++
 	}
-	sortedPIDs := tss.SortPartyIDs(partyIDs)
+	sortedPIDs, err := tss.SortPartyIDs(partyIDs, tss.Edwards())
+	if err != nil {
+		return nil, nil, err
+	}
 	sort.Slice(keys, func(i, j int) bool { return keys[i].ShareID.Cmp(keys[j].ShareID) == -1 })
 	return keys, sortedPIDs, nil
 }
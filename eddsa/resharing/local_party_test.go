@@ -7,6 +7,7 @@
 package resharing_test
 
 import (
+	"context"
 	"math/big"
 	"sync/atomic"
 	"testing"
@@ -38,8 +39,6 @@ func setUp(level string) {
 func TestE2EConcurrent(t *testing.T) {
 	setUp("info")
 
-	tss.SetCurve(edwards.Edwards())
-
 	threshold, newThreshold := testThreshold, testThreshold
 
 	// PHASE: load keygen fixtures
@@ -68,6 +67,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// init the old parties first
 	for j, pID := range oldPIDs {
 		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params.SetCurve(tss.Edwards())
 		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
 	}
@@ -75,6 +75,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// init the new parties
 	for _, pID := range newPIDs {
 		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params.SetCurve(tss.Edwards())
 		save := keygen.NewLocalPartySaveData(newPCount)
 		P := NewLocalParty(params, save, outCh, endCh).(*LocalParty)
 		newCommittee = append(newCommittee, P)
@@ -83,7 +84,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// start the new parties; they will wait for messages
 	for _, P := range newCommittee {
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -91,7 +92,7 @@ func TestE2EConcurrent(t *testing.T) {
 	// start the old parties; they will send messages
 	for _, P := range oldCommittee {
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -103,7 +104,7 @@ func TestE2EConcurrent(t *testing.T) {
 	for {
 		select {
 		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			return
 
@@ -141,7 +142,7 @@ func TestE2EConcurrent(t *testing.T) {
 				for j, key := range newKeys {
 					// xj test: BigXj == xj*G
 					xj := key.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
+					gXj := crypto.ScalarBaseMult(tss.Edwards(), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 				}
@@ -163,11 +164,13 @@ signing:
 	signEndCh := make(chan common.SignatureData, len(signPIDs))
 
 	for j, signPID := range signPIDs {
-		params := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), newThreshold)
+		params, err := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), newThreshold)
+		assert.NoError(t, err)
+		params.SetCurve(tss.Edwards())
 		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
 		signParties = append(signParties, P)
 		go func(P *signing.LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				signErrCh <- err
 			}
 		}(P)
@@ -177,7 +180,7 @@ signing:
 	for {
 		select {
 		case err := <-signErrCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			return
 
@@ -205,7 +208,7 @@ signing:
 				// BEGIN EDDSA verify
 				pkX, pkY := signKeys[0].EDDSAPub.X(), signKeys[0].EDDSAPub.Y()
 				pk := edwards.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.Edwards(),
 					X:     pkX,
 					Y:     pkY,
 				}
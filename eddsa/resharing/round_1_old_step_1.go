@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -24,9 +25,9 @@ func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySa
 		&base{params, temp, input, save, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1}}
 }
 
-func (round *round1) Start() *tss.Error {
+func (round *round1) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 1
 	round.started = true
@@ -36,6 +37,9 @@ func (round *round1) Start() *tss.Error {
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
+	if err := round.CheckCurveName(round.input.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
 	round.allOldOK()
 
 	Pi := round.PartyID()
@@ -44,13 +48,13 @@ func (round *round1) Start() *tss.Error {
 	// 1. PrepareForSigning() -> w_i
 	xi, ks := round.input.Xi, round.input.Ks
 	if round.Threshold()+1 > len(ks) {
-		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
+		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID()).WithCode(tss.CodeBadInput)
 	}
 	newKs := round.NewParties().IDs().Keys()
-	wi := signing.PrepareForSigning(i, len(round.OldParties().IDs()), xi, ks)
+	wi := signing.PrepareForSigning(round.EC(), i, len(round.OldParties().IDs()), xi, ks)
 
 	// 2.
-	vi, shares, err := vss.Create(round.NewThreshold(), wi, newKs)
+	vi, shares, err := vss.Create(round.EC(), round.NewThreshold(), wi, newKs)
 	if err != nil {
 		return round.WrapError(err, round.PartyID())
 	}
@@ -60,7 +64,7 @@ func (round *round1) Start() *tss.Error {
 	if err != nil {
 		return round.WrapError(err, round.PartyID())
 	}
-	vCmt := commitments.NewHashCommitment(flatVis...)
+	vCmt := commitments.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), flatVis...)
 
 	// 4. populate temp data
 	round.temp.VD = vCmt.D
@@ -71,7 +75,7 @@ func (round *round1) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		round.input.EDDSAPub, vCmt.C)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	round.out <- round.WithSessionID(r1msg)
 
 	return nil
 }
@@ -84,7 +88,7 @@ func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round1) Update() (bool, *tss.Error) {
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
 	// only the new committee receive in this round
 	if !round.ReSharingParameters.IsNewCommittee() {
 		return true, nil
@@ -101,14 +105,14 @@ func (round *round1) Update() (bool, *tss.Error) {
 
 		// save the eddsa pub received from the old committee
 		r1msg := round.temp.dgRound1Messages[0].Content().(*DGRound1Message)
-		candidate, err := r1msg.UnmarshalEDDSAPub()
+		candidate, err := r1msg.UnmarshalEDDSAPub(round.EC())
 		if err != nil {
-			return false, round.WrapError(errors.New("unable to unmarshal the eddsa pub key"), msg.GetFrom())
+			return false, round.WrapError(errors.New("unable to unmarshal the eddsa pub key"), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
 		}
 		if round.save.EDDSAPub != nil &&
 			!candidate.Equals(round.save.EDDSAPub) {
 			// uh oh - anomaly!
-			return false, round.WrapError(errors.New("eddsa pub key did not match what we received previously"), msg.GetFrom())
+			return false, round.WrapError(errors.New("eddsa pub key did not match what we received previously"), msg.GetFrom()).WithCode(tss.CodeEquivocation)
 		}
 		round.save.EDDSAPub = candidate
 	}
@@ -7,14 +7,15 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round2) Start() *tss.Error {
+func (round *round2) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 2
 	round.started = true
@@ -32,7 +33,7 @@ func (round *round2) Start() *tss.Error {
 	// 1. "broadcast" "ACK" members of the OLD committee
 	r2msg := NewDGRound2Message(round.OldParties().IDs(), Pi)
 	round.temp.dgRound2Messages[i] = r2msg
-	round.out <- r2msg
+	round.out <- round.WithSessionID(r2msg)
 
 	return nil
 }
@@ -44,7 +45,7 @@ func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round2) Update() (bool, *tss.Error) {
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
 	// only the old committee receive in this round
 	if !round.ReSharingParams().IsOldCommittee() {
 		return true, nil
@@ -7,14 +7,15 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round3) Start() *tss.Error {
+func (round *round3) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 3
 	round.started = true
@@ -34,7 +35,7 @@ func (round *round3) Start() *tss.Error {
 		share := round.temp.NewShares[j]
 		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
 		round.temp.dgRound3Message1s[i] = r3msg1
-		round.out <- r3msg1
+		round.out <- round.WithSessionID(r3msg1)
 	}
 
 	// 3. broadcast de-commitment to new committees
@@ -43,7 +44,7 @@ func (round *round3) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		vDeCmt)
 	round.temp.dgRound3Message2s[i] = r3msg2
-	round.out <- r3msg2
+	round.out <- round.WithSessionID(r3msg2)
 
 	return nil
 }
@@ -58,7 +59,7 @@ func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round3) Update() (bool, *tss.Error) {
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
 	// only the new committee receive in this round
 	if !round.ReSharingParams().IsNewCommittee() {
 		return true, nil
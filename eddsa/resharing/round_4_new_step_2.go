@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/pkg/errors"
@@ -18,9 +19,9 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round4) Start() *tss.Error {
+func (round *round4) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 4
 	round.started = true
@@ -40,7 +41,7 @@ func (round *round4) Start() *tss.Error {
 	newXi := big.NewInt(0)
 
 	// 2-8.
-	modQ := common.ModInt(tss.EC().Params().N)
+	modQ := common.ModInt(round.EC().Params().N)
 	vjc := make([][]*crypto.ECPoint, len(round.OldParties().IDs()))
 	for j := 0; j <= len(vjc)-1; j++ { // P1..P_t+1. Ps are indexed from 0 here
 		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
@@ -50,12 +51,12 @@ func (round *round4) Start() *tss.Error {
 
 		// 3. unpack flat "v" commitment content
 		vCmtDeCmt := commitments.HashCommitDecommit{C: vCj, D: vDj}
-		ok, flatVs := vCmtDeCmt.DeCommit()
+		ok, flatVs := vCmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
 		if !ok || len(flatVs) != (round.NewThreshold()+1)*2 { // they're points so * 2
 			// TODO collect culprits and return a list of them as per convention
-			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
+			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j]).WithCode(tss.CodeDecommitMismatch)
 		}
-		vj, err := crypto.UnFlattenECPoints(tss.EC(), flatVs)
+		vj, err := crypto.UnFlattenECPoints(round.EC(), flatVs)
 		if err != nil {
 			return round.WrapError(err, round.Parties().IDs()[j])
 		}
@@ -68,7 +69,7 @@ func (round *round4) Start() *tss.Error {
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
 		if ok := sharej.Verify(round.NewThreshold(), vj); !ok {
-			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
+			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j]).WithCode(tss.CodeInvalidShare)
 		}
 
 		newXi = new(big.Int).Add(newXi, sharej.Share)
@@ -122,7 +123,7 @@ func (round *round4) Start() *tss.Error {
 	// 21. Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg := NewDGRound4Message(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Messages[i] = r4msg
-	round.out <- r4msg
+	round.out <- round.WithSessionID(r4msg)
 
 	return nil
 }
@@ -134,7 +135,7 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round4) Update() (bool, *tss.Error) {
+func (round *round4) Update(ctx context.Context) (bool, *tss.Error) {
 	// accept messages from new -> old&new committees
 	for j, msg := range round.temp.dgRound4Messages {
 		if round.newOK[j] {
@@ -7,14 +7,15 @@
 package resharing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round5) Start() *tss.Error {
+func (round *round5) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 5
 	round.started = true
@@ -28,6 +29,7 @@ func (round *round5) Start() *tss.Error {
 		round.save.ShareID = round.PartyID().KeyInt()
 		round.save.Xi = round.temp.newXi
 		round.save.Ks = round.temp.newKs
+		round.save.CurveName = round.CurveName()
 
 	} else if round.IsOldCommittee() {
 		round.input.Xi.SetInt64(0)
@@ -41,7 +43,7 @@ func (round *round5) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round5) Update() (bool, *tss.Error) {
+func (round *round5) Update(ctx context.Context) (bool, *tss.Error) {
 	return false, nil
 }
 
@@ -13,6 +13,9 @@ import (
 
 const (
 	TaskName = "eddsa-resharing"
+
+	// the domain of the hash commitments made in round 1, see commitments.NewHashCommitmentInSession
+	commitmentDomain = TaskName + "/round-1"
 )
 
 type (
@@ -52,6 +55,15 @@ var (
 	_ tss.Round = (*round5)(nil)
 )
 
+// phases names the rounds by their number
+var phases = []string{
+	1: "old committee commitments",
+	2: "new committee ACKs",
+	3: "old committee shares",
+	4: "new committee ACKs",
+	5: "finalization",
+}
+
 // ----- //
 
 func (round *base) Params() *tss.Parameters {
@@ -66,6 +78,14 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+func (round *base) TotalRounds() int {
+	return len(phases) - 1
+}
+
+func (round *base) Phase() string {
+	return phases[round.number]
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
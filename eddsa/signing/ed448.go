@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// With the curve of the party set to ed448.Curve(), rounds 3 and 4 make an Ed448 signature of RFC 8032 (with an empty context) in
+// place of an Ed25519 one. The other rounds and the keygen do not depend on the curve.
+
+func (round *base) isEd448() bool {
+	return round.EC().Params().Name == ed448.Name
+}
+
+func (round *round3) startEd448() *tss.Error {
+	// 1-6. compute R
+	R := round.temp.pointRi
+	i := round.PartyID().Index
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		Rj, tssErr := round.deCommitRj(j, Pj)
+		if tssErr != nil {
+			return tssErr
+		}
+		var err error
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(err, Pj)
+		}
+	}
+
+	// 7. compute lambda = SHAKE256(dom4(0, "") || R || A || M, 114) mod N
+	encodedR := ed448.EncodePoint(R.X(), R.Y())
+	encodedPubKey := ed448.EncodePoint(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
+	lambda := ed448.Challenge(encodedR, encodedPubKey, round.temp.m.Bytes())
+
+	// 8. compute si = lambda*wi + ri
+	modN := common.ModInt(round.EC().Params().N)
+	si := modN.Add(modN.Mul(lambda, round.temp.wi), round.temp.ri)
+
+	// 9. store r3 message pieces
+	round.temp.bigSi = si
+	round.temp.encodedR = encodedR
+
+	// 10. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- round.WithSessionID(r3msg)
+
+	return nil
+}
+
+func (round *finalization) finalizeEd448() *tss.Error {
+	modN := common.ModInt(round.EC().Params().N)
+	sumS := round.temp.bigSi
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sumS = modN.Add(sumS, r3msg.UnmarshalS())
+	}
+
+	// save the signature for final output
+	encodedS := ed448.EncodeScalar(sumS)
+	round.data.Signature = append(append([]byte{}, round.temp.encodedR...), encodedS...)
+	round.data.R = round.temp.encodedR
+	round.data.S = encodedS
+	round.data.M = round.temp.m.Bytes()
+
+	encodedPubKey := ed448.EncodePoint(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
+	if ok := ed448.Verify(encodedPubKey, round.data.M, round.data.Signature); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
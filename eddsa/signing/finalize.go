@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -17,14 +18,18 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *finalization) Start() *tss.Error {
+func (round *finalization) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 4
 	round.started = true
 	round.resetOK()
 
+	if round.isEd448() {
+		return round.finalizeEd448()
+	}
+
 	sumS := round.temp.si
 	for j := range round.Parties().IDs() {
 		round.ok[j] = true
@@ -45,7 +50,7 @@ func (round *finalization) Start() *tss.Error {
 	round.data.M = round.temp.m.Bytes()
 
 	pk := edwards.PublicKey{
-		Curve: tss.EC(),
+		Curve: round.EC(),
 		X:     round.key.EDDSAPub.X(),
 		Y:     round.key.EDDSAPub.Y(),
 	}
@@ -53,7 +58,7 @@ func (round *finalization) Start() *tss.Error {
 
 	ok := edwards.Verify(&pk, round.temp.m.Bytes(), round.temp.r, s)
 	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
 	}
 	round.end <- *round.data
 
@@ -65,7 +70,7 @@ func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *finalization) Update() (bool, *tss.Error) {
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
 	// not expecting any incoming messages in this round
 	return false, nil
 }
@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sync/atomic"
@@ -37,8 +38,6 @@ func setUp(level string) {
 func TestE2EConcurrent(t *testing.T) {
 	setUp("info")
 
-	tss.SetCurve(edwards.Edwards())
-
 	threshold := testThreshold
 
 	// PHASE: load keygen fixtures
@@ -61,12 +60,14 @@ func TestE2EConcurrent(t *testing.T) {
 	msg := big.NewInt(200)
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+		params.SetCurve(tss.Edwards())
 
 		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
 		parties = append(parties, P)
 		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
+			if err := P.Start(context.Background()); err != nil {
 				errCh <- err
 			}
 		}(P)
@@ -77,7 +78,7 @@ signing:
 	for {
 		select {
 		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
+			common.DefaultLogger().Error("error", "err", err)
 			assert.FailNow(t, err.Error())
 			break signing
 
@@ -121,7 +122,7 @@ signing:
 				// BEGIN EDDSA verify
 				pkX, pkY := keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y()
 				pk := edwards.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.Edwards(),
 					X:     pkX,
 					Y:     pkY,
 				}
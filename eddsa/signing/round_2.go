@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 
 	errors2 "github.com/pkg/errors"
@@ -15,9 +16,9 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round2) Start() *tss.Error {
+func (round *round2) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 	round.number = 2
 	round.started = true
@@ -32,15 +33,15 @@ func (round *round2) Start() *tss.Error {
 	}
 
 	// 2. compute Schnorr prove
-	pir, err := schnorr.NewZKProof(round.temp.ri, round.temp.pointRi)
+	pir, err := schnorr.NewZKProofInTranscript(round.proofTranscript(2, round.PartyID()), round.temp.ri, round.temp.pointRi)
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKProof(ri, pointRi)"))
+		return round.WrapError(errors2.Wrapf(err, "NewZKProofInTranscript(ri, pointRi)"))
 	}
 
 	// 3. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewSignRound2Message(round.PartyID(), round.temp.deCommit, pir)
 	round.temp.signRound2Messages[i] = r2msg2
-	round.out <- r2msg2
+	round.out <- round.WithSessionID(r2msg2)
 
 	return nil
 }
@@ -52,7 +53,7 @@ func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
 	return false
 }
 
-func (round *round2) Update() (bool, *tss.Error) {
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound2Messages {
 		if round.ok[j] {
 			continue
@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"crypto/sha512"
 
 	"github.com/agl/ed25519/edwards25519"
@@ -17,15 +18,19 @@ import (
 	"github.com/binance-chain/tss-lib/tss"
 )
 
-func (round *round3) Start() *tss.Error {
+func (round *round3) Start(ctx context.Context) *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
 	}
 
 	round.number = 3
 	round.started = true
 	round.resetOK()
 
+	if round.isEd448() {
+		return round.startEd448()
+	}
+
 	// 1. init R
 	var R edwards25519.ExtendedGroupElement
 	riBytes := bigIntToEncodedBytes(round.temp.ri)
@@ -38,28 +43,9 @@ func (round *round3) Start() *tss.Error {
 			continue
 		}
 
-		msg := round.temp.signRound2Messages[j]
-		r2msg := msg.Content().(*SignRound2Message)
-		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
-		ok, coordinates := cmtDeCmt.DeCommit()
-		if !ok {
-			return round.WrapError(errors.New("de-commitment verify failed"))
-		}
-		if len(coordinates) != 2 {
-			return round.WrapError(errors.New("length of de-commitment should be 2"))
-		}
-
-		Rj, err := crypto.NewECPoint(tss.EC(), coordinates[0], coordinates[1])
-		if err != nil {
-			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
-		}
-		proof, err := r2msg.UnmarshalZKProof()
+		Rj, err := round.deCommitRj(j, Pj)
 		if err != nil {
-			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
-		}
-		ok = proof.Verify(Rj)
-		if !ok {
-			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+			return err
 		}
 
 		extendedRj := ecPointToExtendedElement(Rj.X(), Rj.Y())
@@ -94,12 +80,40 @@ func (round *round3) Start() *tss.Error {
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	round.out <- round.WithSessionID(r3msg)
 
 	return nil
 }
 
-func (round *round3) Update() (bool, *tss.Error) {
+// deCommitRj opens the commitment of Pj to Rj and verifies the proof of knowledge of its discrete log
+func (round *round3) deCommitRj(j int, Pj *tss.PartyID) (*crypto.ECPoint, *tss.Error) {
+	msg := round.temp.signRound2Messages[j]
+	r2msg := msg.Content().(*SignRound2Message)
+	cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+	ok, coordinates := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+	if !ok {
+		return nil, round.WrapError(errors.New("de-commitment verify failed"))
+	}
+	if len(coordinates) != 2 {
+		return nil, round.WrapError(errors.New("length of de-commitment should be 2"))
+	}
+
+	Rj, err := crypto.NewECPoint(round.EC(), coordinates[0], coordinates[1])
+	if err != nil {
+		return nil, round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj).WithCode(tss.CodeInvalidMessage)
+	}
+	proof, err := r2msg.UnmarshalZKProof(round.EC())
+	if err != nil {
+		return nil, round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj).WithCode(tss.CodeInvalidMessage)
+	}
+	ok = round.VerifyProof("schnorr", Pj, func() bool { return proof.VerifyInTranscript(round.proofTranscript(2, Pj), Rj) })
+	if !ok {
+		return nil, round.WrapError(errors.New("failed to prove Rj"), Pj).WithCode(tss.CodeInvalidProof)
+	}
+	return Rj, nil
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
 	for j, msg := range round.temp.signRound3Messages {
 		if round.ok[j] {
 			continue
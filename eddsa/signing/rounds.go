@@ -8,12 +8,16 @@ package signing
 
 import (
 	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/zkp"
 	"github.com/binance-chain/tss-lib/eddsa/keygen"
 	"github.com/binance-chain/tss-lib/tss"
 )
 
 const (
 	TaskName = "eddsa-signing"
+
+	// the domain of the hash commitments made in round 1, see commitments.NewHashCommitmentInSession
+	commitmentDomain = TaskName + "/round-1"
 )
 
 type (
@@ -49,6 +53,14 @@ var (
 	_ tss.Round = (*finalization)(nil)
 )
 
+// phases names the rounds by their number
+var phases = []string{
+	1: "nonce commitments",
+	2: "nonce de-commitments",
+	3: "signature shares",
+	4: "finalization",
+}
+
 // ----- //
 
 func (round *base) Params() *tss.Parameters {
@@ -59,6 +71,14 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+func (round *base) TotalRounds() int {
+	return len(phases) - 1
+}
+
+func (round *base) Phase() string {
+	return phases[round.number]
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -97,3 +117,11 @@ func (round *base) resetOK() {
 		round.ok[j] = false
 	}
 }
+
+// proofTranscript returns the context of the proofs that `prover` makes in round `number`, which binds them to this
+// signing session, to the round and to the prover, see zkp.NewSessionTranscript
+func (round *base) proofTranscript(number int, prover *tss.PartyID) *zkp.Transcript {
+	tr := zkp.NewSessionTranscript(TaskName, round.Params().SessionID(), number)
+	tr.AppendInts("prover", prover.KeyInt())
+	return tr
+}
@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/elgamal"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	C1 := round.temp.ct.C1
+
+	// 1. check each Dj against the public share Wj
+	Ds := make([]*crypto.ECPoint, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			Ds[j] = round.temp.Di
+			continue
+		}
+		r1msg := round.temp.decryptRound1Messages[j].Content().(*DecryptRound1Message)
+		Dj, err := r1msg.UnmarshalDecryptionShare(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r1msg.UnmarshalProof(round.EC())
+		if err != nil || !proof.VerifyInTranscript(round.proofTranscript(1, Pj), round.temp.bigWs[j], Dj, C1) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		Ds[j] = Dj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the decryption share Dj"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. combine the decryption shares: M = C2 - sum(Dj)
+	M, err := elgamal.Combine(round.temp.ct, Ds)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Combine"))
+	}
+	round.end <- M
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/elgamal"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: encryption
+	// any t+1 of the ECDSA keygen fixtures, encrypted to their ECDSAPub
+	keys, decPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ct, key, err := elgamal.EncapsulateKey(keys[0].ECDSAPub)
+	assert.NoError(t, err)
+
+	// PHASE: decryption
+	p2pCtx := tss.NewPeerContext(decPIDs)
+	parties := make([]*LocalParty, 0, len(decPIDs))
+
+	errCh := make(chan *tss.Error, len(decPIDs))
+	outCh := make(chan tss.Message, len(decPIDs))
+	endCh := make(chan *crypto.ECPoint, len(decPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(decPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, decPIDs[i], len(decPIDs), threshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(ct, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+decryption:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break decryption
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case M := <-endCh:
+			assert.Equal(t, key, elgamal.KeyFromPoint(M), "the decrypted key must match")
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(decPIDs)) {
+				t.Logf("Done. Received the key from %d participants", ended)
+				break decryption
+			}
+		}
+	}
+}
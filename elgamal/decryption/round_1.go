@@ -0,0 +1,95 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of threshold ElGamal decryption
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *crypto.ECPoint) tss.Round {
+	return &round1{
+		&base{params, key, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+	if err := round.CheckCurveName(round.key.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	if round.Threshold()+1 > len(round.key.Ks) {
+		return round.WrapError(errors.New("t+1 parties are required to decrypt")).WithCode(tss.CodeBadInput)
+	}
+	if !round.temp.ct.ValidateBasic() {
+		return round.WrapError(errors.New("the ciphertext is invalid")).WithCode(tss.CodeBadInput)
+	}
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. the additive share wi of the private key and the public shares Wj = wj*G of the other parties
+	wi, bigWs := signing.PrepareForSigning(round.EC(), i, len(round.key.Ks), round.key.Xi, round.key.Ks, round.key.BigXj)
+	round.temp.bigWs = bigWs
+
+	// 2. the decryption share Di = wi*C1 and the proof that it was made with the same wi as Wi
+	Di := round.temp.ct.C1.ScalarMult(wi)
+	proof, err := schnorr.NewZKDLEQProofInTranscript(round.proofTranscript(1, Pi), bigWs[i], Di, round.temp.ct.C1, wi)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NewZKDLEQProofInTranscript(Di)"), Pi)
+	}
+	round.temp.Di = Di
+
+	round.ok[i] = true
+
+	// 3. broadcast the decryption share
+	r1msg := NewDecryptRound1Message(Pi, Di, proof)
+	round.temp.decryptRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.decryptRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*DecryptRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
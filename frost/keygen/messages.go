@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into frost-keygen.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that keygen messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*KGRound1Message)(nil),
+		(*KGRound2Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*KGRound1Message)(nil), tss.FROSTProtoNamePrefix+"keygen.KGRound1Message")
+	proto.RegisterType((*KGRound2Message)(nil), tss.FROSTProtoNamePrefix+"keygen.KGRound2Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewKGRound1Message(
+	from *tss.PartyID,
+	vs vss.Vs,
+	proof *ProofOfPossession,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	vsBzs, err := crypto.CompressECPoints(vs)
+	if err != nil {
+		return nil, err
+	}
+	content := &KGRound1Message{
+		Commitments: vsBzs,
+		ProofRX:     proof.R.X().Bytes(),
+		ProofRY:     proof.R.Y().Bytes(),
+		ProofMu:     proof.Mu.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *KGRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetCommitments()) &&
+		common.NonEmptyBytes(m.GetProofRX()) &&
+		common.NonEmptyBytes(m.GetProofRY()) &&
+		common.NonEmptyBytes(m.GetProofMu())
+}
+
+func (m *KGRound1Message) UnmarshalCommitments(ec elliptic.Curve) (vss.Vs, error) {
+	return crypto.DecompressECPoints(ec, m.GetCommitments())
+}
+
+func (m *KGRound1Message) UnmarshalProof(ec elliptic.Curve) (*ProofOfPossession, error) {
+	R, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofRX()),
+		new(big.Int).SetBytes(m.GetProofRY()))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofOfPossession{
+		R:  R,
+		Mu: new(big.Int).SetBytes(m.GetProofMu()),
+	}, nil
+}
+
+// ----- //
+
+func NewKGRound2Message(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &KGRound2Message{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *KGRound2Message) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/zkp/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// the domain of the hash of the keygen context, which hashes the canonical encoding of its input (see
+// common.SHA512_256iCanonical)
+const keygenContextDomain = "FROST-keygen-context"
+
+// ProofOfPossession is the Schnorr signature σi = (Ri, μi) of FROST keygen step 2, proving knowledge of ai0 for the
+// commitment φi0 = ai0*G. The challenge is bound to the prover's index and the keygen context so it cannot be replayed.
+type ProofOfPossession struct {
+	R  *crypto.ECPoint
+	Mu *big.Int
+}
+
+// NewProofOfPossession computes σi = (k*G, k + ai0*ci) with ci = H(ki, Φ, φi0, G, Ri)
+func NewProofOfPossession(ki, context, ai0 *big.Int, phi0 *crypto.ECPoint) (*ProofOfPossession, error) {
+	if ki == nil || context == nil || ai0 == nil || phi0 == nil {
+		return nil, errors.New("NewProofOfPossession received nil value(s)")
+	}
+	pf, err := schnorr.Prove(schnorr.CurveGroup(phi0.Curve()), popTranscript(ki, context), ai0, (*schnorr.CurvePoint)(phi0))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofOfPossession{R: pf.Alpha.(*schnorr.CurvePoint).ECPoint(), Mu: pf.T}, nil
+}
+
+// Verify checks that μi*G = Ri + ci*φi0
+func (pf *ProofOfPossession) Verify(ki, context *big.Int, phi0 *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || ki == nil || context == nil || phi0 == nil {
+		return false
+	}
+	sPf := &schnorr.Proof{Alpha: (*schnorr.CurvePoint)(pf.R), T: pf.Mu}
+	return sPf.Verify(schnorr.CurveGroup(phi0.Curve()), popTranscript(ki, context), (*schnorr.CurvePoint)(phi0))
+}
+
+func (pf *ProofOfPossession) ValidateBasic() bool {
+	return pf.Mu != nil && pf.R != nil && pf.R.ValidateBasic()
+}
+
+// popTranscript binds the challenge to the prover's index and the keygen context
+func popTranscript(ki, context *big.Int) schnorr.Transcript {
+	return schnorr.NewTranscript(ki.Bytes(), context.Bytes())
+}
+
+// keygenContext is the context string Φ of the FROST keygen, which binds the proofs of possession to this set of
+// parties and threshold
+func keygenContext(params *tss.Parameters) *big.Int {
+	ks := params.Parties().IDs().Keys()
+	in := append([]*big.Int{big.NewInt(int64(params.Threshold()))}, ks...)
+	return common.SHA512_256iCanonical(keygenContextDomain, in...)
+}
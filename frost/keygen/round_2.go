@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 5. verify the commitments and the proof of possession of every Pj
+	context := keygenContext(round.Params())
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			round.temp.pjVs[j] = round.temp.vs
+			continue
+		}
+		r1msg := round.temp.kgRound1Messages[j].Content().(*KGRound1Message)
+		PjVs, err := r1msg.UnmarshalCommitments(round.EC())
+		if err != nil || len(PjVs) != round.Threshold()+1 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r1msg.UnmarshalProof(round.EC())
+		if err != nil || !proof.Verify(Pj.KeyInt(), context, PjVs[0]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.pjVs[j] = PjVs
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the commitments or the proof of possession"), culprits...).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 6. p2p send share ij to Pj
+	shares := round.temp.shares
+	for j, Pj := range Ps {
+		r2msg := NewKGRound2Message(Pj, round.PartyID(), shares[j])
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.kgRound2Messages[j] = r2msg
+			continue
+		}
+		round.out <- round.WithSessionID(r2msg)
+	}
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.kgRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
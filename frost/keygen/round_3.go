@@ -0,0 +1,117 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/vss"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+	modQ := common.ModInt(round.EC().Params().N)
+
+	// 1. verify each share fj(i) against φj and compute the long-lived share si = sum(fj(i))
+	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == PIdx {
+			continue
+		}
+		r2msg := round.temp.kgRound2Messages[j].Content().(*KGRound2Message)
+		PjShare := vss.Share{
+			Threshold: round.Threshold(),
+			ID:        round.PartyID().KeyInt(),
+			Share:     r2msg.UnmarshalShare(),
+		}
+		if ok := PjShare.Verify(round.Threshold(), round.temp.pjVs[j]); !ok {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		xi = modQ.Add(xi, PjShare.Share)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("vss verify failed"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+	round.save.Xi = xi
+
+	// 2. sum the commitments of all parties
+	Vc := make(vss.Vs, round.Threshold()+1)
+	for c := range Vc {
+		Vc[c] = round.temp.vs[c] // ours
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		PjVs := round.temp.pjVs[j]
+		for c := 0; c <= round.Threshold(); c++ {
+			var err error
+			if Vc[c], err = Vc[c].Add(PjVs[c]); err != nil {
+				return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"))
+			}
+		}
+	}
+
+	// 3. compute the public verification share Yj = sj*G of each Pj
+	{
+		bigXj := round.save.BigXj
+		for j := 0; j < round.PartyCount(); j++ {
+			Pj := Ps[j]
+			kj := Pj.KeyInt()
+			BigXj := Vc[0]
+			var err error
+			z := new(big.Int).SetInt64(int64(1))
+			for c := 1; c <= round.Threshold(); c++ {
+				z = modQ.Mul(z, kj)
+				if BigXj, err = BigXj.Add(Vc[c].ScalarMult(z)); err != nil {
+					return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"))
+				}
+			}
+			bigXj[j] = BigXj
+		}
+		round.save.BigXj = bigXj
+	}
+
+	// 4. compute and SAVE the group public key Y = sum(φj0)
+	round.save.PubKey = Vc[0]
+	round.save.CurveName = round.CurveName()
+
+	// PRINT public key & private share
+	round.Logger().Debug("public key", "x", fmt.Sprintf("%x", Vc[0].X()), "y", fmt.Sprintf("%x", Vc[0].Y()))
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	modQ := common.ModInt(round.EC().Params().N)
+
+	// 1. check each zj against its commitments: zj*G = Rj + c*λj*Yj
+	z := round.temp.zi
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+		zj := r2msg.UnmarshalZ()
+		zjG := crypto.ScalarBaseMult(round.EC(), zj)
+		expected, err := round.temp.bigRjs[j].Add(round.temp.bigWs[j].ScalarMult(round.temp.c))
+		if err != nil || !zjG.Equals(expected) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		z = modQ.Add(z, zj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the signature share zj"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. save the signature (R, z) for final output
+	round.data.R = encodePoint(round.temp.bigR)
+	round.data.S = padScalar(round.EC(), z)
+	round.data.Signature = append(append([]byte{}, round.data.R...), round.data.S...)
+	round.data.M = round.temp.m.Bytes()
+
+	if ok := Verify(round.key.PubKey, round.temp.m, round.temp.bigR, z); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,81 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+)
+
+const (
+	// domain separation of the hash functions H1 and H2 of the FROST paper, which hash the canonical encoding of their
+	// inputs (see common.SHA512_256iCanonical)
+	bindingDomain   = "FROST-binding"
+	challengeDomain = "FROST-challenge"
+)
+
+// Verify reports whether (R, z) is a FROST signature of `msg` under `pubKey`, i.e. whether z*G = R + c*Y with
+// c = H2(R, Y, m)
+func Verify(pubKey *crypto.ECPoint, msg *big.Int, R *crypto.ECPoint, z *big.Int) bool {
+	if pubKey == nil || msg == nil || !R.ValidateBasic() || z == nil {
+		return false
+	}
+	q := pubKey.Curve().Params().N
+	if z.Sign() < 0 || z.Cmp(q) >= 0 {
+		return false
+	}
+	c := challenge(R, pubKey, msg)
+	zG := crypto.ScalarBaseMult(pubKey.Curve(), z)
+	expected, err := R.Add(pubKey.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return zG.Equals(expected)
+}
+
+// bindingFactors returns ρℓ = H1(ℓ, m, B) for each signer ℓ, where B is the list of the signers' (ℓ, Dℓ, Eℓ)
+func bindingFactors(ec elliptic.Curve, ks []*big.Int, msg *big.Int, bigDs, bigEs []*crypto.ECPoint) []*big.Int {
+	bigB := make([]*big.Int, 0, 5*len(ks))
+	for j, kj := range ks {
+		bigB = append(bigB, kj, bigDs[j].X(), bigDs[j].Y(), bigEs[j].X(), bigEs[j].Y())
+	}
+	q := ec.Params().N
+	rhos := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		in := append([]*big.Int{kj, msg}, bigB...)
+		rhos[j] = common.RejectionSample(q, common.SHA512_256iCanonical(bindingDomain, in...))
+	}
+	return rhos
+}
+
+// challenge returns c = H2(R, Y, m)
+func challenge(R, pubKey *crypto.ECPoint, msg *big.Int) *big.Int {
+	cHash := common.SHA512_256iCanonical(challengeDomain, R.X(), R.Y(), pubKey.X(), pubKey.Y(), msg)
+	return common.RejectionSample(pubKey.Curve().Params().N, cHash)
+}
+
+// encodePoint returns the compressed SEC1 encoding of P
+func encodePoint(P *crypto.ECPoint) []byte {
+	byteLen := (P.Curve().Params().BitSize + 7) / 8
+	bz := make([]byte, 1+byteLen)
+	bz[0] = byte(2 + P.Y().Bit(0))
+	xBz := P.X().Bytes()
+	copy(bz[1+byteLen-len(xBz):], xBz)
+	return bz
+}
+
+// padScalar returns k as a big-endian byte slice the length of the curve order
+func padScalar(ec elliptic.Curve, k *big.Int) []byte {
+	byteLen := (ec.Params().N.BitLen() + 7) / 8
+	bz := make([]byte, byteLen)
+	kBz := k.Bytes()
+	copy(bz[byteLen-len(kBz):], kBz)
+	return bz
+}
@@ -0,0 +1,137 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/frost/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = keygen.TestParticipants
+	testThreshold    = keygen.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	// PHASE: signing
+	msg := big.NewInt(42)
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+
+				pubKey := keys[0].PubKey
+				R := parties[0].temp.bigR
+				z := new(big.Int).SetBytes(data.S)
+				assert.Equal(t, encodePoint(R), data.R)
+				assert.True(t, Verify(pubKey, msg, R, z), "frost verify must pass")
+				assert.False(t, Verify(pubKey, big.NewInt(43), R, z), "frost verify must fail for another message")
+				break signing
+			}
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// a single-party signature: z = r + c*x
+	q := tss.S256().Params().N
+	x, r := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+	pubKey, R := crypto.ScalarBaseMult(tss.S256(), x), crypto.ScalarBaseMult(tss.S256(), r)
+	msg := big.NewInt(42)
+	z := common.ModInt(q).Add(r, new(big.Int).Mul(challenge(R, pubKey, msg), x))
+	assert.True(t, Verify(pubKey, msg, R, z))
+
+	assert.False(t, Verify(pubKey, msg, R, new(big.Int).Add(z, big.NewInt(1))))
+	assert.False(t, Verify(pubKey, msg, R, new(big.Int).Add(z, q)), "z must be reduced")
+	assert.False(t, Verify(R, msg, R, z), "wrong public key")
+	assert.False(t, Verify(pubKey, msg, nil, z))
+}
+
+// the golden vectors of H1 and H2 on secp256k1, which hash the canonical encoding of their inputs
+func TestHashGolden(t *testing.T) {
+	ec := tss.S256()
+	G, G2 := crypto.ScalarBaseMult(ec, big.NewInt(1)), crypto.ScalarBaseMult(ec, big.NewInt(2))
+	assert.Equal(t, "d6271da031a7b25ed83ed575695dee5e1160899ef75c839c302cef04f6ca7b46",
+		hex.EncodeToString(challenge(G, G2, big.NewInt(3)).Bytes()))
+
+	ks := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	rhos := bindingFactors(ec, ks, big.NewInt(3), []*crypto.ECPoint{G, G2}, []*crypto.ECPoint{G2, G})
+	assert.Equal(t, "e0ed730f2013d13e72f64281c9469ce02b6c99a22d9767c270031514a09671e7", hex.EncodeToString(rhos[0].Bytes()))
+	assert.Equal(t, "73bc8cd1ce5960bbbbcc7c7d0ac19385f60fc9d83abef5194be3f9db31ac630b", hex.EncodeToString(rhos[1].Bytes()))
+}
@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into frost-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*SignRound1Message)(nil), tss.FROSTProtoNamePrefix+"signing.SignRound1Message")
+	proto.RegisterType((*SignRound2Message)(nil), tss.FROSTProtoNamePrefix+"signing.SignRound2Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	bigD, bigE *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		DX: bigD.X().Bytes(),
+		DY: bigD.Y().Bytes(),
+		EX: bigE.X().Bytes(),
+		EY: bigE.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDX()) &&
+		common.NonEmptyBytes(m.GetDY()) &&
+		common.NonEmptyBytes(m.GetEX()) &&
+		common.NonEmptyBytes(m.GetEY())
+}
+
+func (m *SignRound1Message) UnmarshalNonceCommitments(ec elliptic.Curve) (bigD, bigE *crypto.ECPoint, err error) {
+	if bigD, err = crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetDX()),
+		new(big.Int).SetBytes(m.GetDY())); err != nil {
+		return nil, nil, err
+	}
+	if bigE, err = crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetEX()),
+		new(big.Int).SetBytes(m.GetEY())); err != nil {
+		return nil, nil, err
+	}
+	return
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		Z: zi.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetZ())
+}
+
+func (m *SignRound2Message) UnmarshalZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetZ())
+}
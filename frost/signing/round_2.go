@@ -0,0 +1,108 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. collect B, the list of the signers' nonce commitments (Dj, Ej)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		bigDj, bigEj, err := r1msg.UnmarshalNonceCommitments(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.bigDs[j], round.temp.bigEs[j] = bigDj, bigEj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to unmarshal the nonce commitments"), culprits...).WithCode(tss.CodeInvalidMessage)
+	}
+
+	// 2. compute the binding factors ρj and the commitment shares Rj = Dj + ρj*Ej, then R = sum(Rj)
+	rhos := bindingFactors(round.EC(), round.key.Ks, round.temp.m, round.temp.bigDs, round.temp.bigEs)
+	var R *crypto.ECPoint
+	for j := range Ps {
+		Rj, err := round.temp.bigDs[j].Add(round.temp.bigEs[j].ScalarMult(rhos[j]))
+		if err != nil {
+			return round.WrapError(errors.New("Dj + ρj*Ej is not on the curve"), Ps[j]).WithCode(tss.CodeInvalidMessage)
+		}
+		round.temp.bigRjs[j] = Rj
+		if R == nil {
+			R = Rj
+			continue
+		}
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors.New("R + Rj is not on the curve"))
+		}
+	}
+
+	// 3. c = H2(R, Y, m)
+	c := challenge(R, round.key.PubKey, round.temp.m)
+
+	// 4. compute the signature share zi = di + ei*ρi + λi*si*c, where wi = λi*si
+	modQ := common.ModInt(round.EC().Params().N)
+	zi := modQ.Add(round.temp.di, modQ.Add(modQ.Mul(round.temp.ei, rhos[i]), modQ.Mul(round.temp.wi, c)))
+
+	// security: the nonces must never be used again
+	round.temp.di, round.temp.ei = nil, nil
+
+	// 5. store r2 message pieces
+	round.temp.bigR = R
+	round.temp.c = c
+	round.temp.zi = zi
+
+	// 6. broadcast zi to other parties
+	r2msg := NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- round.WithSessionID(r2msg)
+
+	return nil
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,93 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i], round.ok[j] = true, true
+
+	if i == P2 {
+		r1msg := round.temp.kgRound1P1Messages[j].Content().(*KGRound1P1Message)
+		r3msg := round.temp.kgRound3P1Messages[j].Content().(*KGRound3P1Message)
+
+		// 1. de-commit Q1 and verify the proof of knowledge of x1
+		cmtDeCmt := cmts.HashCommitDecommit{C: r1msg.UnmarshalCommitment(), D: r3msg.UnmarshalDeCommitment()}
+		ok, flat := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+		if !ok || len(flat) != 5 {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj).WithCode(tss.CodeDecommitMismatch)
+		}
+		Q1, err := crypto.NewECPoint(round.EC(), flat[0], flat[1])
+		if err != nil {
+			return round.WrapError(err, Pj)
+		}
+		alpha, err := crypto.NewECPoint(round.EC(), flat[2], flat[3])
+		if err != nil {
+			return round.WrapError(err, Pj)
+		}
+		if proof := (&schnorr.ZKProof{Alpha: alpha, T: flat[4]}); !proof.VerifyInTranscript(round.proofTranscript(1, Pj), Q1) {
+			return round.WrapError(errors.New("failed to prove Q1"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		round.save.BigXj[j] = Q1
+
+		// 2. the public key Q = x2*Q1
+		round.save.ECDSAPub = Q1.ScalarMult(round.save.Xi)
+		round.save.CurveName = round.CurveName()
+
+		// 3. verify the Paillier key and that c_key encrypts the discrete log of Q1
+		pk, cKey := r3msg.UnmarshalPaillierPK(), r3msg.UnmarshalCKey()
+		if err := pk.ValidatePeer(round.PaillierModulusLen()); err != nil {
+			return round.WrapError(err, Pj)
+		}
+		if ok, err := r3msg.UnmarshalPaillierProof().Verify(pk.N, round.save.Ks[j], round.save.ECDSAPub); err != nil || !ok {
+			return round.WrapError(errors.New("paillier verify failed"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		pdlProof, err := r3msg.UnmarshalPDLProof(round.EC())
+		if err != nil {
+			return round.WrapError(err, Pj)
+		}
+		G := crypto.NewECPointNoCurveCheck(round.EC(), round.EC().Params().Gx, round.EC().Params().Gy)
+		if !pdlProof.Verify(round.proofTranscript(3, Pj), pk, cKey, G, Q1, round.temp.NTilde, round.temp.h1, round.temp.h2, round.MtAProofParams()) {
+			return round.WrapError(errors.New("failed to prove c_key"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		round.save.PaillierPK, round.save.CKey = pk, cKey
+	}
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,141 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+	setUp("info")
+
+	// use the pre-params of the ecdsa fixtures rather than generating safe primes here
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(2)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), 1)
+		assert.NoError(t, err)
+		P := NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// PHASE: keygen
+	var ended int32
+	saves := make([]LocalPartySaveData, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil || len(dest) != 1 {
+				t.Fatalf("expected a point-to-point message from party %d", msg.GetFrom().Index)
+				return
+			}
+			if dest[0].Index == msg.GetFrom().Index {
+				t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				return
+			}
+			go updater(parties[dest[0].Index], msg, errCh)
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			tryWriteTestFixtureFile(t, index, save)
+			saves[index] = save
+
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+
+				// both parties have the public key x1*x2*G and the same c_key
+				x1, x2 := saves[P1].Xi, saves[P2].Xi
+				Q := crypto.ScalarBaseMult(tss.S256(), common.ModInt(tss.S256().Params().N).Mul(x1, x2))
+				assert.True(t, Q.Equals(saves[P1].ECDSAPub), "ensure x1*x2*G == Q")
+				assert.True(t, Q.Equals(saves[P2].ECDSAPub), "ensure x1*x2*G == Q")
+				for j := range saves {
+					assert.True(t, crypto.ScalarBaseMult(tss.S256(), saves[j].Xi).Equals(saves[P1].BigXj[j]))
+					assert.True(t, saves[P1].BigXj[j].Equals(saves[P2].BigXj[j]))
+				}
+				assert.Equal(t, 0, saves[P1].CKey.Cmp(saves[P2].CKey))
+				assert.Equal(t, 0, saves[P1].PaillierPK.N.Cmp(saves[P2].PaillierPK.N))
+				assert.Nil(t, saves[P2].PaillierSK, "P2 must not hold the Paillier key")
+
+				// c_key decrypts to x1
+				dec, err := saves[P1].PaillierSK.Decrypt(saves[P2].CKey)
+				assert.NoError(t, err)
+				assert.Equal(t, 0, dec.Cmp(x1), "ensure Dec(c_key) == x1")
+				break keygen
+			}
+		}
+	}
+}
+
+func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePath(index)
+
+	// fixture file does not already exist?
+	// if it does, we won't re-create it here
+	fi, err := os.Stat(fixtureFileName)
+	if !(err == nil && fi != nil && !fi.IsDir()) {
+		fd, err := os.OpenFile(fixtureFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			assert.NoErrorf(t, err, "unable to open fixture file %s for writing", fixtureFileName)
+		}
+		bz, err := json.Marshal(&data)
+		if err != nil {
+			t.Fatalf("unable to marshal save data for fixture file %s", fixtureFileName)
+		}
+		_, err = fd.Write(bz)
+		if err != nil {
+			t.Fatalf("unable to write to fixture file %s", fixtureFileName)
+		}
+		t.Logf("Saved a test fixture file for party %d: %s", index, fixtureFileName)
+	} else {
+		t.Logf("Fixture file already exists for party %d; not re-creating: %s", index, fixtureFileName)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into lindell-keygen.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that keygen messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*KGRound1P1Message)(nil),
+		(*KGRound1P2Message)(nil),
+		(*KGRound2P2Message)(nil),
+		(*KGRound3P1Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*KGRound1P1Message)(nil), tss.LindellProtoNamePrefix+"keygen.KGRound1P1Message")
+	proto.RegisterType((*KGRound1P2Message)(nil), tss.LindellProtoNamePrefix+"keygen.KGRound1P2Message")
+	proto.RegisterType((*KGRound2P2Message)(nil), tss.LindellProtoNamePrefix+"keygen.KGRound2P2Message")
+	proto.RegisterType((*KGRound3P1Message)(nil), tss.LindellProtoNamePrefix+"keygen.KGRound3P1Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewKGRound1P1Message(
+	to, from *tss.PartyID,
+	ct cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &KGRound1P1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound1P1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *KGRound1P1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewKGRound1P2Message(
+	to, from *tss.PartyID,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	dlnProof1Bz, err := dlnProof1.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	content := &KGRound1P2Message{
+		NTilde:     nTildeI.Bytes(),
+		H1:         h1I.Bytes(),
+		H2:         h2I.Bytes(),
+		Dlnproof_1: dlnProof1Bz,
+		Dlnproof_2: dlnProof2Bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *KGRound1P2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+func (m *KGRound1P2Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *KGRound1P2Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *KGRound1P2Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *KGRound1P2Message) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *KGRound1P2Message) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+func NewKGRound2P2Message(
+	to, from *tss.PartyID,
+	Q2 *crypto.ECPoint,
+	proof *schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &KGRound2P2Message{
+		PublicShareX: Q2.X().Bytes(),
+		PublicShareY: Q2.Y().Bytes(),
+		ProofAlphaX:  proof.Alpha.X().Bytes(),
+		ProofAlphaY:  proof.Alpha.Y().Bytes(),
+		ProofT:       proof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound2P2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPublicShareX()) &&
+		common.NonEmptyBytes(m.GetPublicShareY()) &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofT())
+}
+
+func (m *KGRound2P2Message) UnmarshalPublicShare(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetPublicShareX()),
+		new(big.Int).SetBytes(m.GetPublicShareY()))
+}
+
+func (m *KGRound2P2Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()),
+		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
+
+// ----- //
+
+func NewKGRound3P1Message(
+	to, from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	paillierPK *paillier.PublicKey,
+	cKey *big.Int,
+	paillierProof paillier.Proof,
+	pdlProof *mta.ProofPDL,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	pfBzs := make([][]byte, len(paillierProof))
+	for i := range pfBzs {
+		if paillierProof[i] == nil {
+			continue
+		}
+		pfBzs[i] = paillierProof[i].Bytes()
+	}
+	pdlBz := pdlProof.Bytes()
+	content := &KGRound3P1Message{
+		DeCommitment:  dcBzs,
+		PaillierN:     paillierPK.N.Bytes(),
+		CKey:          cKey.Bytes(),
+		PaillierProof: pfBzs,
+		PdlProof:      pdlBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound3P1Message) ValidateBasic() bool {
+	return m != nil &&
+		// r, Q1 and the proof of knowledge of x1
+		common.NonEmptyMultiBytes(m.GetDeCommitment(), 6) &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetCKey()) &&
+		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters) &&
+		common.NonEmptyMultiBytes(m.GetPdlProof(), mta.ProofPDLBytesParts)
+}
+
+func (m *KGRound3P1Message) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+func (m *KGRound3P1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *KGRound3P1Message) UnmarshalCKey() *big.Int {
+	return new(big.Int).SetBytes(m.GetCKey())
+}
+
+func (m *KGRound3P1Message) UnmarshalPaillierProof() paillier.Proof {
+	var pf paillier.Proof
+	proofBzs := m.GetPaillierProof()
+	for i := range pf {
+		pf[i] = new(big.Int).SetBytes(proofBzs[i])
+	}
+	return pf
+}
+
+func (m *KGRound3P1Message) UnmarshalPDLProof(ec elliptic.Curve) (*mta.ProofPDL, error) {
+	return mta.ProofPDLFromBytes(ec, m.GetPdlProof())
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/dlnproof"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the two-party ECDSA keygen of Lindell (2017)
+func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.PartyCount() != 2 || round.Threshold() != 1 {
+		return round.WrapError(errors.New("two-party keygen requires 2 parties and a threshold of 1")).WithCode(tss.CodeBadInput)
+	}
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	round.save.Ks = round.Parties().IDs().Keys()
+	round.save.ShareID = round.save.Ks[i]
+
+	// 1. the share xi and Qi = xi*G
+	xi := common.GetRandomPositiveInt(round.EC().Params().N)
+	round.save.Xi = xi
+	round.save.BigXj[i] = crypto.ScalarBaseMult(round.EC(), xi)
+
+	if i == P1 {
+		// 2. commit to Q1 and the proof of knowledge of x1
+		Q1 := round.save.BigXj[i]
+		proof, err := schnorr.NewZKProofInTranscript(round.proofTranscript(1, Pi), xi, Q1)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		cmt := cmts.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), Q1.X(), Q1.Y(), proof.Alpha.X(), proof.Alpha.Y(), proof.T)
+		round.temp.deCommit = cmt.D
+
+		// 3. the Paillier key for c_key, from the pre-params if they were provided to the LocalParty constructor
+		if round.temp.preParams != nil {
+			round.save.PaillierSK = round.temp.preParams.PaillierSK
+		} else {
+			sk, _, err := paillier.GenerateKeyPair(round.PaillierModulusLen(), round.SafePrimeGenTimeout(), round.Concurrency())
+			if err != nil {
+				return round.WrapError(errors.New("paillier key generation failed"), Pi)
+			}
+			round.save.PaillierSK = sk
+		}
+		round.save.PaillierPK = &round.save.PaillierSK.PublicKey
+
+		r1msg := NewKGRound1P1Message(round.peer(), Pi, cmt.C)
+		round.temp.kgRound1P1Messages[i] = r1msg
+		round.out <- round.WithSessionID(r1msg)
+		return nil
+	}
+
+	// 2. P2's NTilde, h1, h2 for the proof of c_key, from the pre-params if they were provided to the LocalParty constructor
+	preParams := round.temp.preParams
+	if preParams == nil {
+		var err error
+		if preParams, err = ecdsakeygen.GeneratePreParams(round.SafePrimeGenTimeout(), round.Concurrency()); err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.temp.preParams = preParams
+	}
+	dlnProof1 := dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei)
+	dlnProof2 := dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei)
+	round.temp.NTilde, round.temp.h1, round.temp.h2 = preParams.NTildei, preParams.H1i, preParams.H2i
+
+	r1msg, err := NewKGRound1P2Message(round.peer(), Pi, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.kgRound1P2Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound1P1Message); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*KGRound1P2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	// P1 waits for P2's NTilde, h1, h2 and P2 for P1's commitment
+	if round.PartyID().Index == P1 {
+		return round.waitFor(round.temp.kgRound1P2Messages, round.CanAccept)
+	}
+	return round.waitFor(round.temp.kgRound1P1Messages, round.CanAccept)
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i] = true
+
+	if i == P1 {
+		// 1. verify P2's dln proofs; the proof of c_key is only zero knowledge when h1 and h2 generate the same group
+		r1msg := round.temp.kgRound1P2Messages[j].Content().(*KGRound1P2Message)
+		H1j, H2j, NTildej :=
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde()
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+			return round.WrapError(errors.New("dln proof verification failed"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		if dlnProof2, err := r1msg.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+			return round.WrapError(errors.New("dln proof verification failed"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		round.temp.NTilde, round.temp.h1, round.temp.h2 = NTildej, H1j, H2j
+		// P1 waits for Q2
+		return nil
+	}
+
+	// 1. now that P1 has committed to Q1, send Q2 with the proof of knowledge of x2
+	Q2 := round.save.BigXj[i]
+	proof, err := schnorr.NewZKProofInTranscript(round.proofTranscript(2, Pi), round.save.Xi, Q2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	r2msg := NewKGRound2P2Message(Pj, Pi, Q2, proof)
+	round.temp.kgRound2P2Messages[i] = r2msg
+	round.out <- round.WithSessionID(r2msg)
+
+	// P2 does not receive anything in this round
+	round.ok[j] = true
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound2P2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	// P1 waits for Q2
+	return round.waitFor(round.temp.kgRound2P2Messages, round.CanAccept)
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/mta"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i] = true
+
+	if i == P2 {
+		// P2 waits for P1's de-commitment and c_key
+		return nil
+	}
+
+	// 1. verify the proof of knowledge of x2
+	r2msg := round.temp.kgRound2P2Messages[j].Content().(*KGRound2P2Message)
+	Q2, err := r2msg.UnmarshalPublicShare(round.EC())
+	if err != nil {
+		return round.WrapError(err, Pj)
+	}
+	proof, err := r2msg.UnmarshalZKProof(round.EC())
+	if err != nil || !proof.VerifyInTranscript(round.proofTranscript(2, Pj), Q2) {
+		return round.WrapError(errors.New("failed to prove Q2"), Pj).WithCode(tss.CodeInvalidProof)
+	}
+	round.save.BigXj[j] = Q2
+
+	// 2. the public key Q = x1*Q2
+	round.save.ECDSAPub = Q2.ScalarMult(round.save.Xi)
+	round.save.CurveName = round.CurveName()
+
+	// 3. c_key = Enc(x1) with the proofs of the Paillier key and that c_key encrypts the discrete log of Q1
+	pk := round.save.PaillierPK
+	cKey, r, err := pk.EncryptAndReturnRandomness(round.save.Xi)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Encrypt(x1)"), Pi)
+	}
+	round.save.CKey = cKey
+	paillierProof := round.save.PaillierSK.Proof(round.save.Ks[i], round.save.ECDSAPub)
+	G := crypto.NewECPointNoCurveCheck(round.EC(), round.EC().Params().Gx, round.EC().Params().Gy)
+	pdlProof, err := mta.ProvePDL(round.proofTranscript(3, Pi), pk, cKey, G, round.save.BigXj[i], round.temp.NTilde, round.temp.h1, round.temp.h2,
+		round.save.Xi, r, round.MtAProofParams())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "ProvePDL(c_key)"), Pi)
+	}
+
+	r3msg := NewKGRound3P1Message(Pj, Pi, round.temp.deCommit, pk, cKey, paillierProof, pdlProof)
+	round.temp.kgRound3P1Messages[i] = r3msg
+	round.out <- round.WithSessionID(r3msg)
+
+	// P1 does not receive anything in this round
+	round.ok[j] = true
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound3P1Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	// P2 waits for P1's de-commitment and c_key
+	return round.waitFor(round.temp.kgRound3P1Messages, round.CanAccept)
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
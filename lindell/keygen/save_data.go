@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+const (
+	// the indexes of the two parties in the sorted party IDs: P1 holds the Paillier key and P2 the encryption of x1
+	P1 = 0
+	P2 = 1
+)
+
+type (
+	LocalSecrets struct {
+		// secret fields (not shared, but stored locally)
+		Xi, ShareID *big.Int // x1 or x2, ki
+
+		// the Paillier private key of P1; nil for P2
+		PaillierSK *paillier.PrivateKey
+	}
+
+	// Everything in LocalPartySaveData is saved locally to user's HD when done
+	LocalPartySaveData struct {
+		LocalSecrets
+
+		// original indexes (ki in signing preparation phase)
+		Ks []*big.Int
+
+		// public shares Q1 = x1*G and Q2 = x2*G
+		BigXj []*crypto.ECPoint // Xj
+
+		// P1's Paillier public key and c_key = Enc(x1) under it
+		PaillierPK *paillier.PublicKey
+		CKey       *big.Int
+
+		// the public key Q = x1*x2*G
+		ECDSAPub *crypto.ECPoint // y
+
+		// the name of the curve of the key, see tss.RegisterCurve; it is empty in save data from before it was recorded,
+		// whose key is taken to be on the curve of the party
+		CurveName string
+	}
+)
+
+func NewLocalPartySaveData() (saveData LocalPartySaveData) {
+	saveData.Ks = make([]*big.Int, 2)
+	saveData.BigXj = make([]*crypto.ECPoint, 2)
+	return
+}
+
+// recovers a party's original index in the set of parties during keygen
+func (save LocalPartySaveData) OriginalIndex() (int, error) {
+	index := -1
+	ki := save.ShareID
+	for j, kj := range save.Ks {
+		if kj.Cmp(ki) != 0 {
+			continue
+		}
+		index = j
+		break
+	}
+	if index < 0 {
+		return -1, errors.New("a party index could not be recovered from Ks")
+	}
+	return index, nil
+}
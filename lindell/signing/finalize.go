@@ -0,0 +1,93 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ecdsasigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i], round.ok[j] = true, true
+
+	// 1. P2 checks the signature from P1
+	if i == keygen.P2 {
+		r5msg := round.temp.signRound5P1Messages[j].Content().(*SignRound5P1Message)
+		if err := round.finalize(r5msg.UnmarshalS()); err != nil {
+			return round.WrapError(err, Pj)
+		}
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// finalize normalises s to the lower half of the curve order, saves the signature (r, s) and checks it
+func (round *base) finalize(s *big.Int) error {
+	N := round.EC().Params().N
+	if s.Sign() == 0 || s.Cmp(N) >= 0 {
+		return errors.New("s is out of range")
+	}
+	m, r, R := round.temp.m, round.temp.r, round.temp.bigR
+
+	recid := 0
+	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if R.X().Cmp(N) > 0 {
+		recid = 2
+	}
+	if R.Y().Bit(0) != 0 {
+		recid |= 1
+	}
+	halfN := new(big.Int).Rsh(N, 1)
+	if s.Cmp(halfN) > 0 {
+		s = new(big.Int).Sub(N, s)
+		recid ^= 1
+	}
+	round.temp.s = s
+
+	round.data.Signature = append(r.Bytes(), s.Bytes()...)
+	round.data.SignatureRecovery = []byte{byte(recid)}
+	round.data.R = r.Bytes()
+	round.data.S = s.Bytes()
+	round.data.M = m.Bytes()
+
+	if ok := ecdsasigning.Verify(round.data, round.key.ECDSAPub, m.Bytes()); !ok {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsasigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures()
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, 2, len(keys))
+
+	// PHASE: signing
+	for _, msg := range []*big.Int{big.NewInt(42), common.SHA512_256i(big.NewInt(1)), common.SHA512_256i(big.NewInt(2))} {
+		p2pCtx := tss.NewPeerContext(signPIDs)
+		parties := make([]*LocalParty, 0, len(signPIDs))
+
+		errCh := make(chan *tss.Error, len(signPIDs))
+		outCh := make(chan tss.Message, len(signPIDs))
+		endCh := make(chan common.SignatureData, len(signPIDs))
+
+		updater := test.SharedPartyUpdater
+
+		// init the parties
+		for i := 0; i < len(signPIDs); i++ {
+			params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), 1)
+			assert.NoError(t, err)
+
+			P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+			parties = append(parties, P)
+			go func(P *LocalParty) {
+				if err := P.Start(context.Background()); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+
+		var ended int32
+		sigs := make([]common.SignatureData, 0, len(signPIDs))
+	signing:
+		for {
+			select {
+			case err := <-errCh:
+				common.DefaultLogger().Error("error", "err", err)
+				assert.FailNow(t, err.Error())
+				break signing
+
+			case msg := <-outCh:
+				dest := msg.GetTo()
+				if dest == nil || len(dest) != 1 {
+					t.Fatalf("expected a point-to-point message from party %d", msg.GetFrom().Index)
+					return
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+
+			case data := <-endCh:
+				sigs = append(sigs, data)
+				atomic.AddInt32(&ended, 1)
+				if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+					t.Logf("Done. Received signature data from %d participants", ended)
+
+					// both parties have the same signature, which verifies under the public key
+					assert.Equal(t, sigs[0].Signature, sigs[1].Signature)
+					assert.True(t, ecdsasigning.Verify(&data, keys[0].ECDSAPub, msg.Bytes()), "ecdsa verify must pass")
+					pk := ecdsa.PublicKey{
+						Curve: tss.S256(),
+						X:     keys[0].ECDSAPub.X(),
+						Y:     keys[0].ECDSAPub.Y(),
+					}
+					r, s := new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+					assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+					break signing
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into lindell-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*SignRound1P1Message)(nil),
+		(*SignRound2P2Message)(nil),
+		(*SignRound3P1Message)(nil),
+		(*SignRound4P2Message)(nil),
+		(*SignRound5P1Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*SignRound1P1Message)(nil), tss.LindellProtoNamePrefix+"signing.SignRound1P1Message")
+	proto.RegisterType((*SignRound2P2Message)(nil), tss.LindellProtoNamePrefix+"signing.SignRound2P2Message")
+	proto.RegisterType((*SignRound3P1Message)(nil), tss.LindellProtoNamePrefix+"signing.SignRound3P1Message")
+	proto.RegisterType((*SignRound4P2Message)(nil), tss.LindellProtoNamePrefix+"signing.SignRound4P2Message")
+	proto.RegisterType((*SignRound5P1Message)(nil), tss.LindellProtoNamePrefix+"signing.SignRound5P1Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewSignRound1P1Message(
+	to, from *tss.PartyID,
+	ct cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound1P1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1P1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *SignRound1P1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewSignRound2P2Message(
+	to, from *tss.PartyID,
+	R2 *crypto.ECPoint,
+	proof *schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound2P2Message{
+		R2X:         R2.X().Bytes(),
+		R2Y:         R2.Y().Bytes(),
+		ProofAlphaX: proof.Alpha.X().Bytes(),
+		ProofAlphaY: proof.Alpha.Y().Bytes(),
+		ProofT:      proof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2P2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetR2X()) &&
+		common.NonEmptyBytes(m.GetR2Y()) &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofT())
+}
+
+func (m *SignRound2P2Message) UnmarshalR2(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetR2X()),
+		new(big.Int).SetBytes(m.GetR2Y()))
+}
+
+func (m *SignRound2P2Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()),
+		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
+
+// ----- //
+
+func NewSignRound3P1Message(
+	to, from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &SignRound3P1Message{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound3P1Message) ValidateBasic() bool {
+	return m != nil &&
+		// r, R1 and the proof of knowledge of k1
+		common.NonEmptyMultiBytes(m.GetDeCommitment(), 6)
+}
+
+func (m *SignRound3P1Message) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+// ----- //
+
+func NewSignRound4P2Message(
+	to, from *tss.PartyID,
+	c3 *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound4P2Message{
+		C3: c3.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound4P2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetC3())
+}
+
+func (m *SignRound4P2Message) UnmarshalC3() *big.Int {
+	return new(big.Int).SetBytes(m.GetC3())
+}
+
+// ----- //
+
+func NewSignRound5P1Message(
+	to, from *tss.PartyID,
+	s *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound5P1Message{
+		S: s.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound5P1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetS())
+}
+
+func (m *SignRound5P1Message) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.GetS())
+}
@@ -0,0 +1,94 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the two-party ECDSA signing of Lindell (2017)
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+	if err := round.CheckCurveName(round.key.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	if round.PartyCount() != 2 || round.Threshold() != 1 {
+		return round.WrapError(errors.New("two-party signing requires 2 parties and a threshold of 1")).WithCode(tss.CodeBadInput)
+	}
+	for j, Pj := range round.Parties().IDs() {
+		if len(round.key.Ks) != 2 || round.key.Ks[j].Cmp(Pj.KeyInt()) != 0 {
+			return round.WrapError(errors.New("the signing parties must be the parties of the key")).WithCode(tss.CodeBadInput)
+		}
+	}
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. the nonce share ki and Ri = ki*G
+	ki := common.GetRandomPositiveInt(round.EC().Params().N)
+	round.temp.ki = ki
+	round.temp.bigRi = crypto.ScalarBaseMult(round.EC(), ki)
+
+	if i == keygen.P2 {
+		// P2 waits for P1's commitment
+		return nil
+	}
+
+	// 2. commit to R1 and the proof of knowledge of k1
+	R1 := round.temp.bigRi
+	proof, err := schnorr.NewZKProofInTranscript(round.proofTranscript(1, Pi), ki, R1)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), R1.X(), R1.Y(), proof.Alpha.X(), proof.Alpha.Y(), proof.T)
+	round.temp.deCommit = cmt.D
+
+	r1msg := NewSignRound1P1Message(round.peer(), Pi, cmt.C)
+	round.temp.signRound1P1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	// P1 does not receive anything in this round
+	round.ok[round.peer().Index] = true
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1P1Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	// P2 waits for P1's commitment
+	return round.waitFor(round.temp.signRound1P1Messages, round.CanAccept)
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i] = true
+
+	if i == keygen.P1 {
+		// P1 waits for R2
+		return nil
+	}
+
+	// 1. now that P1 has committed to R1, send R2 with the proof of knowledge of k2
+	R2 := round.temp.bigRi
+	proof, err := schnorr.NewZKProofInTranscript(round.proofTranscript(2, Pi), round.temp.ki, R2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	r2msg := NewSignRound2P2Message(Pj, Pi, R2, proof)
+	round.temp.signRound2P2Messages[i] = r2msg
+	round.out <- round.WithSessionID(r2msg)
+
+	// P2 does not receive anything in this round
+	round.ok[j] = true
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2P2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	// P1 waits for R2
+	return round.waitFor(round.temp.signRound2P2Messages, round.CanAccept)
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
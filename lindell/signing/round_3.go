@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i] = true
+
+	if i == keygen.P2 {
+		// P2 waits for P1's de-commitment
+		return nil
+	}
+
+	// 1. verify the proof of knowledge of k2
+	r2msg := round.temp.signRound2P2Messages[j].Content().(*SignRound2P2Message)
+	R2, err := r2msg.UnmarshalR2(round.EC())
+	if err != nil {
+		return round.WrapError(err, Pj)
+	}
+	proof, err := r2msg.UnmarshalZKProof(round.EC())
+	if err != nil || !proof.VerifyInTranscript(round.proofTranscript(2, Pj), R2) {
+		return round.WrapError(errors.New("failed to prove R2"), Pj).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. R = k1*R2 and r = R.x mod q
+	if err := round.setR(R2.ScalarMult(round.temp.ki)); err != nil {
+		return round.WrapError(err, Pj)
+	}
+
+	// 3. de-commit R1
+	r3msg := NewSignRound3P1Message(Pj, Pi, round.temp.deCommit)
+	round.temp.signRound3P1Messages[i] = r3msg
+	round.out <- round.WithSessionID(r3msg)
+
+	// P1 does not receive anything in this round
+	round.ok[j] = true
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3P1Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	// P2 waits for P1's de-commitment
+	return round.waitFor(round.temp.signRound3P1Messages, round.CanAccept)
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
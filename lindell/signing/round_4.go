@@ -0,0 +1,110 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round4) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i] = true
+
+	if i == keygen.P1 {
+		// P1 waits for c3
+		return nil
+	}
+
+	// 1. de-commit R1 and verify the proof of knowledge of k1
+	r1msg := round.temp.signRound1P1Messages[j].Content().(*SignRound1P1Message)
+	r3msg := round.temp.signRound3P1Messages[j].Content().(*SignRound3P1Message)
+	cmtDeCmt := cmts.HashCommitDecommit{C: r1msg.UnmarshalCommitment(), D: r3msg.UnmarshalDeCommitment()}
+	ok, flat := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+	if !ok || len(flat) != 5 {
+		return round.WrapError(errors.New("de-commitment verify failed"), Pj).WithCode(tss.CodeDecommitMismatch)
+	}
+	R1, err := crypto.NewECPoint(round.EC(), flat[0], flat[1])
+	if err != nil {
+		return round.WrapError(err, Pj)
+	}
+	alpha, err := crypto.NewECPoint(round.EC(), flat[2], flat[3])
+	if err != nil {
+		return round.WrapError(err, Pj)
+	}
+	if proof := (&schnorr.ZKProof{Alpha: alpha, T: flat[4]}); !proof.VerifyInTranscript(round.proofTranscript(1, Pj), R1) {
+		return round.WrapError(errors.New("failed to prove R1"), Pj).WithCode(tss.CodeInvalidProof)
+	}
+
+	// 2. R = k2*R1 and r = R.x mod q
+	if err := round.setR(R1.ScalarMult(round.temp.ki)); err != nil {
+		return round.WrapError(err, Pj)
+	}
+
+	// 3. c3 = Enc(rho*q + k2^-1*m) + (k2^-1*r*x2)*c_key, where rho in [0, q^2) masks the multiple of q in P1's plaintext
+	q := round.EC().Params().N
+	modQ := common.ModInt(q)
+	pk := round.key.PaillierPK
+	k2Inv := modQ.ModInverseCT(round.temp.ki)
+	rho := common.GetRandomPositiveInt(new(big.Int).Mul(q, q))
+	v := new(big.Int).Add(new(big.Int).Mul(rho, q), modQ.Mul(k2Inv, round.temp.m))
+	c1, err := pk.Encrypt(v)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Encrypt(rho*q + k2^-1*m)"), Pi)
+	}
+	c2, err := pk.HomoMult(modQ.Mul(modQ.Mul(k2Inv, round.temp.r), round.key.Xi), round.key.CKey)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "HomoMult(c_key)"), Pi)
+	}
+	c3, err := pk.HomoAdd(c1, c2)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "HomoAdd(c1, c2)"), Pi)
+	}
+
+	r4msg := NewSignRound4P2Message(Pj, Pi, c3)
+	round.temp.signRound4P2Messages[i] = r4msg
+	round.out <- round.WithSessionID(r4msg)
+
+	// P2 does not receive anything in this round
+	round.ok[j] = true
+	return nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound4P2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round4) Update(ctx context.Context) (bool, *tss.Error) {
+	// P1 waits for c3
+	return round.waitFor(round.temp.signRound4P2Messages, round.CanAccept)
+}
+
+func (round *round4) NextRound() tss.Round {
+	round.started = false
+	return &round5{round}
+}
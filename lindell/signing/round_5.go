@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/lindell/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round5) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	Pi, Pj := round.PartyID(), round.peer()
+	i, j := Pi.Index, Pj.Index
+	round.ok[i] = true
+
+	if i == keygen.P2 {
+		// P2 waits for s
+		return nil
+	}
+
+	// 1. s = k1^-1 * Dec(c3) mod q
+	r4msg := round.temp.signRound4P2Messages[j].Content().(*SignRound4P2Message)
+	sPrime, err := round.key.PaillierSK.Decrypt(r4msg.UnmarshalC3())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Decrypt(c3)"), Pj)
+	}
+	modQ := common.ModInt(round.EC().Params().N)
+	s := modQ.Mul(modQ.ModInverseCT(round.temp.ki), sPrime)
+
+	// 2. check the signature before sending it; an s made from a bad c3 could tell a malicious P2 about x1
+	if err := round.finalize(s); err != nil {
+		return round.WrapError(err, Pj)
+	}
+
+	r5msg := NewSignRound5P1Message(Pj, Pi, round.temp.s)
+	round.temp.signRound5P1Messages[i] = r5msg
+	round.out <- round.WithSessionID(r5msg)
+
+	// P1 does not receive anything in this round
+	round.ok[j] = true
+	return nil
+}
+
+func (round *round5) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound5P1Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round5) Update(ctx context.Context) (bool, *tss.Error) {
+	// P2 waits for s
+	return round.waitFor(round.temp.signRound5P1Messages, round.CanAccept)
+}
+
+func (round *round5) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,55 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package dealer makes the key data of threshold Paillier decryption with a trusted dealer, which learns the private
+// key. It is not a distributed key generation: generating the RSA modulus jointly, with distributed biprimality
+// testing, is not implemented. The dealer must run in a trusted environment and erase its output once it has been
+// distributed to the parties.
+package dealer
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Deal generates a threshold Paillier key of two safe primes and returns the save data of each of the `sortedIDs`,
+// any `threshold`+1 of which can decrypt
+func Deal(threshold int, sortedIDs tss.SortedPartyIDs, modulusBitLen int, timeout time.Duration, optionalConcurrency ...int) ([]LocalPartySaveData, error) {
+	pk, shares, vks, err := paillier.GenerateThresholdKey(modulusBitLen, threshold, len(sortedIDs), timeout, optionalConcurrency...)
+	if err != nil {
+		return nil, err
+	}
+	return buildSaveData(sortedIDs, pk, shares, vks), nil
+}
+
+// DealFromPrimes is Deal with the safe primes 2*pPrime+1 and 2*qPrime+1
+func DealFromPrimes(threshold int, sortedIDs tss.SortedPartyIDs, pPrime, qPrime *big.Int) ([]LocalPartySaveData, error) {
+	pk, shares, vks, err := paillier.NewThresholdKey(threshold, len(sortedIDs), pPrime, qPrime)
+	if err != nil {
+		return nil, err
+	}
+	return buildSaveData(sortedIDs, pk, shares, vks), nil
+}
+
+func buildSaveData(sortedIDs tss.SortedPartyIDs, pk *paillier.ThresholdPublicKey, shares, vks []*big.Int) []LocalPartySaveData {
+	saves := make([]LocalPartySaveData, len(sortedIDs))
+	for i := range sortedIDs {
+		save := NewLocalPartySaveData(len(sortedIDs))
+		for j, Pj := range sortedIDs {
+			save.Ks[j] = Pj.KeyInt()
+			save.ShareIDs[j] = big.NewInt(int64(j + 1))
+		}
+		copy(save.BigVj, vks)
+		save.Si = shares[i]
+		save.ShareID = save.ShareIDs[i]
+		save.PubKey = pk
+		saves[i] = save
+	}
+	return saves
+}
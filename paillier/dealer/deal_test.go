@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package dealer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+// dealTestKeys deals a key from the Sophie Germain primes of an NTilde fixture, without generating safe primes here
+func dealTestKeys(t *testing.T, pIDs tss.SortedPartyIDs) []LocalPartySaveData {
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	keys, err := DealFromPrimes(testThreshold, pIDs, fixtures[0].P, fixtures[0].Q)
+	if !assert.NoError(t, err, "should deal the threshold key") {
+		t.FailNow()
+	}
+	return keys
+}
+
+func TestDealFromPrimes(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys := dealTestKeys(t, pIDs)
+	assert.Equal(t, testParticipants, len(keys))
+
+	pk := keys[0].PubKey
+	assert.Equal(t, testThreshold, pk.Threshold)
+	assert.Equal(t, testParticipants, pk.PartyCount)
+	for i, key := range keys {
+		assert.Equal(t, pk, key.PubKey, "every party must get the same public key")
+		assert.Equal(t, 0, key.ShareID.Cmp(big.NewInt(int64(i+1))))
+		for j, Pj := range pIDs {
+			assert.Equal(t, 0, key.Ks[j].Cmp(Pj.KeyInt()))
+			assert.Equal(t, 0, key.ShareIDs[j].Cmp(big.NewInt(int64(j+1))))
+			assert.Equal(t, 0, key.BigVj[j].Cmp(keys[0].BigVj[j]), "every party must get the same verification keys")
+		}
+		assert.Equal(t, 0, key.BigVj[i].Cmp(pk.VerificationKey(key.Si)), "the verification key must match the share")
+	}
+
+	// any t+1 shares decrypt, and t shares do not
+	m := common.GetRandomPositiveInt(pk.N)
+	c, err := pk.Encrypt(m)
+	assert.NoError(t, err)
+	ids, cis := make([]int, 0, testThreshold+1), make([]*big.Int, 0, testThreshold+1)
+	for _, key := range keys[testParticipants-testThreshold-1:] {
+		ci, proof, err := pk.DecryptionShare(c, key.Si)
+		assert.NoError(t, err)
+		assert.True(t, pk.VerifyDecryptionShare(c, ci, key.BigVj[key.ShareID.Int64()-1], proof))
+		ids, cis = append(ids, int(key.ShareID.Int64())), append(cis, ci)
+	}
+	plaintext, err := pk.CombineDecryptionShares(ids, cis)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.Cmp(plaintext), "the decrypted plaintext must match")
+	_, err = pk.CombineDecryptionShares(ids[1:], cis[1:])
+	assert.Error(t, err, "t shares must not decrypt")
+}
+
+func TestDealFromPrimesBadInput(t *testing.T) {
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	P, Q := fixtures[0].P, fixtures[0].Q
+	pIDs := tss.GenerateTestPartyIDs(3)
+
+	_, err = DealFromPrimes(0, pIDs, P, Q)
+	assert.Error(t, err, "a threshold of 0 must be rejected")
+	_, err = DealFromPrimes(3, pIDs, P, Q)
+	assert.Error(t, err, "a threshold of the party count must be rejected")
+	_, err = DealFromPrimes(1, pIDs, P, P)
+	assert.Error(t, err, "two equal primes must be rejected")
+}
+
+func TestBuildLocalSaveDataSubset(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys := dealTestKeys(t, pIDs)
+
+	// the last t+1 parties, so the share indices are not 1..t+1
+	subset := pIDs[testParticipants-testThreshold-1:]
+	subsetIDs, err := tss.SortPartyIDs(tss.UnSortedPartyIDs(subset), tss.S256())
+	assert.NoError(t, err)
+	key := keys[testParticipants-1]
+	sub := BuildLocalSaveDataSubset(key, subsetIDs)
+	assert.Equal(t, key.LocalSecrets, sub.LocalSecrets)
+	assert.Equal(t, key.PubKey, sub.PubKey)
+	assert.Equal(t, len(subsetIDs), len(sub.Ks))
+	for j, Pj := range subsetIDs {
+		idx := testParticipants - testThreshold - 1 + j
+		assert.Equal(t, 0, sub.Ks[j].Cmp(Pj.KeyInt()))
+		assert.Equal(t, 0, sub.ShareIDs[j].Cmp(key.ShareIDs[idx]))
+		assert.Equal(t, 0, sub.BigVj[j].Cmp(key.BigVj[idx]))
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	pk := round.key.PubKey
+
+	// 1. check each c_j against the verification key V_j
+	ids, cis := make([]int, len(Ps)), make([]*big.Int, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		ids[j] = int(round.key.ShareIDs[j].Int64())
+		if j == round.PartyID().Index {
+			cis[j] = round.temp.ci
+			continue
+		}
+		r1msg := round.temp.decryptRound1Messages[j].Content().(*DecryptRound1Message)
+		cj := r1msg.UnmarshalDecryptionShare()
+		if !pk.VerifyDecryptionShare(round.temp.c, cj, round.key.BigVj[j], r1msg.UnmarshalProof()) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		cis[j] = cj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the decryption share c_j"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. combine the decryption shares
+	m, err := pk.CombineDecryptionShares(ids, cis)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "CombineDecryptionShares"))
+	}
+	round.data.Set(m)
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/paillier/dealer"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: deal
+	// the Sophie Germain primes of an NTilde fixture give a 2048-bit modulus without generating safe primes here
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys, err := dealer.DealFromPrimes(threshold, pIDs, fixtures[0].P, fixtures[0].Q)
+	assert.NoError(t, err, "should deal the threshold key")
+
+	pk := keys[0].PubKey
+	m := common.GetRandomPositiveInt(pk.N)
+	c, err := pk.Encrypt(m)
+	assert.NoError(t, err)
+
+	// PHASE: decryption
+	// t+1 parties from the end of the set, so the share indices are not 1..t+1
+	decKeys := keys[testParticipants-threshold-1:]
+	decPIDs, err := tss.SortPartyIDs(tss.UnSortedPartyIDs(pIDs[testParticipants-threshold-1:]), tss.S256())
+	assert.NoError(t, err, "should sort the decrypting parties")
+
+	p2pCtx := tss.NewPeerContext(decPIDs)
+	parties := make([]*LocalParty, 0, len(decPIDs))
+
+	errCh := make(chan *tss.Error, len(decPIDs))
+	outCh := make(chan tss.Message, len(decPIDs))
+	endCh := make(chan *big.Int, len(decPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(decPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, decPIDs[i], len(decPIDs), threshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(c, params, decKeys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+decryption:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break decryption
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case plaintext := <-endCh:
+			assert.Equal(t, 0, m.Cmp(plaintext), "the decrypted plaintext must match")
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(decPIDs)) {
+				t.Logf("Done. Received the plaintext from %d participants", ended)
+				break decryption
+			}
+		}
+	}
+}
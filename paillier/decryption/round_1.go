@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/paillier/dealer"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of threshold Paillier decryption
+func newRound1(params *tss.Parameters, key *dealer.LocalPartySaveData, data *big.Int, temp *localTempData, out chan<- tss.Message, end chan<- *big.Int) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.Threshold()+1 > len(round.key.Ks) {
+		return round.WrapError(errors.New("t+1 parties are required to decrypt")).WithCode(tss.CodeBadInput)
+	}
+
+	// 1. the decryption share c_i = c^(2*Delta*s_i) and its proof
+	ci, proof, err := round.key.PubKey.DecryptionShare(round.temp.c, round.key.Si)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "DecryptionShare(c)"))
+	}
+	round.temp.ci = ci
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 2. broadcast the decryption share
+	r1msg := NewDecryptRound1Message(round.PartyID(), ci, proof)
+	round.temp.decryptRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.decryptRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*DecryptRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package decryption
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into paillier-decryption.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that decryption messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*DecryptRound1Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*DecryptRound1Message)(nil), tss.PaillierProtoNamePrefix+"decryption.DecryptRound1Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewDecryptRound1Message(
+	from *tss.PartyID,
+	decryptionShare *big.Int,
+	proof *paillier.DecryptionShareProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &DecryptRound1Message{
+		DecryptionShare: decryptionShare.Bytes(),
+		ProofE:          proof.E.Bytes(),
+		ProofZ:          proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *DecryptRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDecryptionShare()) &&
+		common.NonEmptyBytes(m.GetProofE()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *DecryptRound1Message) UnmarshalDecryptionShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetDecryptionShare())
+}
+
+func (m *DecryptRound1Message) UnmarshalProof() *paillier.DecryptionShareProof {
+	return &paillier.DecryptionShareProof{
+		E: new(big.Int).SetBytes(m.GetProofE()),
+		Z: new(big.Int).SetBytes(m.GetProofZ()),
+	}
+}
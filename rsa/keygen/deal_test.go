@@ -0,0 +1,178 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen_test
+
+import (
+	"context"
+	"crypto"
+	gorsa "crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	. "github.com/binance-chain/tss-lib/rsa/keygen"
+	"github.com/binance-chain/tss-lib/rsa/signing"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+
+	// a small modulus keeps the safe prime generation of these tests short
+	testModulusBitLen = 512
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// deal returns the save data of a freshly dealt key for `pIDs`
+func deal(t *testing.T, pIDs tss.SortedPartyIDs) []LocalPartySaveData {
+	keys, err := Deal(testThreshold, pIDs, testModulusBitLen, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestDeal(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys := deal(t, pIDs)
+	assert.Len(t, keys, testParticipants)
+
+	pk := keys[0].PubKey
+	assert.Equal(t, testModulusBitLen, pk.N.BitLen())
+	assert.Equal(t, testThreshold, pk.Threshold)
+	assert.Equal(t, testParticipants, pk.PartyCount)
+	for i, key := range keys {
+		assert.Equal(t, pk, key.PubKey, "every party must have the same public key")
+		assert.Equal(t, big.NewInt(int64(i+1)), key.ShareID)
+		for j, Pj := range pIDs {
+			assert.Equal(t, 0, Pj.KeyInt().Cmp(key.Ks[j]))
+			assert.Equal(t, big.NewInt(int64(j+1)), key.ShareIDs[j])
+			assert.Equal(t, 0, keys[j].BigVj[j].Cmp(key.BigVj[j]), "every party must have the same verification keys")
+		}
+		assert.Equal(t, 0, pk.VerificationKey(key.Si).Cmp(key.BigVj[i]), "the share must match its verification key")
+	}
+
+	_, err := Deal(0, pIDs, testModulusBitLen, time.Minute)
+	assert.Error(t, err, "a threshold of 0 must be rejected")
+	_, err = Deal(testParticipants, pIDs, testModulusBitLen, time.Minute)
+	assert.Error(t, err, "a threshold of the party count must be rejected")
+}
+
+// any t+1 of the shares decrypt a ciphertext encrypted to the public key
+func TestDealDecrypt(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys := deal(t, pIDs)
+	pk := keys[0].PubKey
+
+	m := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	c := new(big.Int).Exp(m, pk.E, pk.N)
+
+	// t+1 parties from the end of the set, so the share indices are not 1..t+1
+	subset := pIDs[testParticipants-testThreshold-1:]
+	ids := make([]int, len(subset))
+	cis := make([]*big.Int, len(subset))
+	for j, Pj := range subset {
+		key := BuildLocalSaveDataSubset(keys[Pj.Index], subset)
+		assert.Equal(t, 0, key.ShareIDs[j].Cmp(key.ShareID))
+		ci, proof, err := pk.SignatureShare(c, key.Si)
+		assert.NoError(t, err)
+		assert.True(t, pk.VerifySignatureShare(c, ci, key.BigVj[j], proof), "the decryption share must verify")
+		ids[j], cis[j] = int(key.ShareID.Int64()), ci
+	}
+	got, err := pk.CombineSignatureShares(c, ids, cis)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, m.Cmp(got), "the shares must decrypt the ciphertext")
+	}
+	_, err = pk.CombineSignatureShares(c, ids[1:], cis[1:])
+	assert.Error(t, err, "t shares must not decrypt")
+}
+
+func TestE2EDealAndSign(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: deal
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys := deal(t, pIDs)
+	pk := keys[0].PubKey
+	hashed := sha256.Sum256([]byte("a certificate to sign"))
+
+	// PHASE: signing
+	signKeys := keys[:threshold+1]
+	signPIDs, err := tss.SortPartyIDs(tss.UnSortedPartyIDs(pIDs[:threshold+1]), tss.S256())
+	assert.NoError(t, err, "should sort the signers")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+
+		P := signing.NewLocalParty(crypto.SHA256, hashed[:], params, signKeys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			assert.Len(t, data.Signature, pk.Size())
+			assert.NoError(t, gorsa.VerifyPKCS1v15(pk.PublicKey(), crypto.SHA256, hashed[:], data.Signature),
+				"rsa verify must pass")
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+				break signing
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/rsa"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type (
+	LocalSecrets struct {
+		// secret fields (not shared, but stored locally)
+		Si *big.Int // the share of the private exponent
+		// the share index of this party, in 1..n
+		ShareID *big.Int
+	}
+
+	// Everything in LocalPartySaveData is saved locally to user's HD when done
+	LocalPartySaveData struct {
+		LocalSecrets
+
+		// the keys of the parties' IDs, to match them with the shares
+		Ks []*big.Int
+		// the share indexes of the parties, in 1..n
+		ShareIDs []*big.Int
+
+		// verification keys (Vj = V^sj for each Pj)
+		BigVj []*big.Int
+
+		// the threshold RSA public key
+		PubKey *rsa.ThresholdPublicKey
+	}
+)
+
+func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
+	saveData.Ks = make([]*big.Int, partyCount)
+	saveData.ShareIDs = make([]*big.Int, partyCount)
+	saveData.BigVj = make([]*big.Int, partyCount)
+	return
+}
+
+// BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
+func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
+	keysToIndices := make(map[string]int, len(sourceData.Ks))
+	for j, kj := range sourceData.Ks {
+		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newData := NewLocalPartySaveData(sortedIDs.Len())
+	newData.LocalSecrets = sourceData.LocalSecrets
+	newData.PubKey = sourceData.PubKey
+	for j, id := range sortedIDs {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+		if !ok {
+			common.DefaultLogger().Warn("BuildLocalSaveDataSubset: unable to find a signing party in the local save data", "party", id)
+		}
+		newData.Ks[j] = sourceData.Ks[savedIdx]
+		newData.ShareIDs[j] = sourceData.ShareIDs[savedIdx]
+		newData.BigVj[j] = sourceData.BigVj[savedIdx]
+	}
+	return newData
+}
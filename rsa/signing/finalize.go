@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	gorsa "crypto/rsa"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	pk := round.key.PubKey
+
+	// 1. check each x_j against the verification key V_j
+	ids, xis := make([]int, len(Ps)), make([]*big.Int, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		ids[j] = int(round.key.ShareIDs[j].Int64())
+		if j == round.PartyID().Index {
+			xis[j] = round.temp.xi
+			continue
+		}
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		xj := r1msg.UnmarshalSignatureShare()
+		if !pk.VerifySignatureShare(round.temp.x, xj, round.key.BigVj[j], r1msg.UnmarshalProof()) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		xis[j] = xj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the signature share x_j"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. combine the signature shares
+	y, err := pk.CombineSignatureShares(round.temp.x, ids, xis)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "CombineSignatureShares"))
+	}
+
+	// 3. save the signature for final output
+	round.data.Signature = pk.SignatureBytes(y)
+	round.data.M = round.temp.hashed
+
+	if err := gorsa.VerifyPKCS1v15(pk.PublicKey(), round.temp.hash, round.temp.hashed, round.data.Signature); err != nil {
+		return round.WrapError(errors2.Wrapf(err, "signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"crypto"
+	gorsa "crypto/rsa"
+	"crypto/sha256"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/rsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: deal
+	// the Sophie Germain primes of an NTilde fixture give a 2048-bit modulus without generating safe primes here
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys, err := keygen.DealFromPrimes(threshold, pIDs, fixtures[0].P, fixtures[0].Q)
+	assert.NoError(t, err, "should deal the threshold key")
+
+	pk := keys[0].PubKey
+	hashed := sha256.Sum256([]byte("a certificate to sign"))
+
+	// PHASE: signing
+	// t+1 parties from the end of the set, so the share indices are not 1..t+1
+	signKeys := keys[testParticipants-threshold-1:]
+	signPIDs, err := tss.SortPartyIDs(tss.UnSortedPartyIDs(pIDs[testParticipants-threshold-1:]), tss.S256())
+	assert.NoError(t, err, "should sort the signers")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(crypto.SHA256, hashed[:], params, signKeys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			assert.Len(t, data.Signature, pk.Size())
+			assert.NoError(t, gorsa.VerifyPKCS1v15(pk.PublicKey(), crypto.SHA256, hashed[:], data.Signature),
+				"rsa verify must pass")
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+				break signing
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/rsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of threshold RSA signing
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.Threshold()+1 > len(round.key.Ks) {
+		return round.WrapError(errors.New("t+1 parties are required to sign")).WithCode(tss.CodeBadInput)
+	}
+
+	// 1. the PKCS #1 v1.5 message representative x
+	pk := round.key.PubKey
+	x, err := pk.PKCS1v15Representative(round.temp.hash, round.temp.hashed)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.x = x
+
+	// 2. the signature share x_i = x^(2*Delta*s_i) and its proof
+	xi, proof, err := pk.SignatureShare(x, round.key.Si)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "SignatureShare(x)"))
+	}
+	round.temp.xi = xi
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 3. broadcast the signature share
+	r1msg := NewSignRound1Message(round.PartyID(), xi, proof)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/rsa"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into rsa-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*SignRound1Message)(nil), tss.RSAProtoNamePrefix+"signing.SignRound1Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	signatureShare *big.Int,
+	proof *rsa.SignatureShareProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		SignatureShare: signatureShare.Bytes(),
+		ProofE:         proof.E.Bytes(),
+		ProofZ:         proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSignatureShare()) &&
+		common.NonEmptyBytes(m.GetProofE()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *SignRound1Message) UnmarshalSignatureShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetSignatureShare())
+}
+
+func (m *SignRound1Message) UnmarshalProof() *rsa.SignatureShareProof {
+	return &rsa.SignatureShareProof{
+		E: new(big.Int).SetBytes(m.GetProofE()),
+		Z: new(big.Int).SetBytes(m.GetProofZ()),
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package session
+
+import (
+	"context"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	eddsakeygen "github.com/binance-chain/tss-lib/eddsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	TaskName = "keygen-session"
+)
+
+// Algorithm selects the keygen protocol of a ceremony
+type Algorithm int
+
+const (
+	ECDSA Algorithm = iota
+	EdDSA
+)
+
+type (
+	// Ceremony describes one of the keygens of a session
+	Ceremony struct {
+		Algorithm Algorithm
+		// the name of a curve registered with tss.RegisterCurve for an ECDSA keygen, or the curve of the session's
+		// parameters when empty.
+		// EdDSA keygens are always on ed25519
+		CurveName string
+		// the optional pre-params of an ECDSA keygen; otherwise they are generated when the ceremony starts
+		PreParams *ecdsakeygen.LocalPreParams
+	}
+
+	// KeygenResult holds the save data of a ceremony; only the field of the ceremony's algorithm is set
+	KeygenResult struct {
+		Ceremony
+		ECDSA *ecdsakeygen.LocalPartySaveData
+		EdDSA *eddsakeygen.LocalPartySaveData
+	}
+
+	// KeygenSession runs several keygen ceremonies over the same parties and transport, one after the other.
+	// The messages of every ceremony are wrapped in a KeygenSessionMessage, which carries the index of the ceremony,
+	// so that messages of a ceremony this party has not reached yet are kept until it starts.
+	KeygenSession struct {
+		mtx        sync.Mutex
+		params     *tss.Parameters
+		ceremonies []Ceremony
+		curves     []elliptic.Curve
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []KeygenResult
+
+		started, done bool
+		current       int
+		party         tss.Party
+		partyOut      chan tss.Message
+		ecdsaEnd      chan ecdsakeygen.LocalPartySaveData
+		eddsaEnd      chan eddsakeygen.LocalPartySaveData
+		pending       [][]tss.ParsedMessage
+		results       []KeygenResult
+	}
+)
+
+// NewKeygenSession creates a session that runs the keygen `ceremonies` in order with the parties in `params`.
+// The save data of all ceremonies is sent to `end` once the last one has finished.
+// Each ceremony runs with a copy of `params` that is set to the curve of the ceremony, so other protocols may run in
+// the same process during a session.
+func NewKeygenSession(
+	ceremonies []Ceremony,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- []KeygenResult,
+) *KeygenSession {
+	s := &KeygenSession{
+		params:     params,
+		ceremonies: ceremonies,
+		out:        out,
+		end:        end,
+		ecdsaEnd:   make(chan ecdsakeygen.LocalPartySaveData, 1),
+		eddsaEnd:   make(chan eddsakeygen.LocalPartySaveData, 1),
+		pending:    make([][]tss.ParsedMessage, len(ceremonies)),
+		results:    make([]KeygenResult, len(ceremonies)),
+	}
+	for i, c := range ceremonies {
+		s.results[i].Ceremony = c
+	}
+	return s
+}
+
+func (s *KeygenSession) Start(ctx context.Context) *tss.Error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.started {
+		return s.WrapError(errors.New("could not start. the session has already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	if len(s.ceremonies) == 0 {
+		return s.WrapError(errors.New("could not start. the session has no ceremonies")).WithCode(tss.CodeBadInput)
+	}
+	s.curves = make([]elliptic.Curve, len(s.ceremonies))
+	for i, c := range s.ceremonies {
+		switch c.Algorithm {
+		case ECDSA:
+			if c.CurveName == "" {
+				s.curves[i] = s.params.EC()
+				break
+			}
+			curve, ok := tss.GetCurveByName(c.CurveName)
+			if !ok {
+				return s.WrapError(fmt.Errorf("could not start. ceremony %d: the curve %q is not registered", i, c.CurveName)).WithCode(tss.CodeBadInput)
+			}
+			s.curves[i] = curve
+		case EdDSA:
+			s.curves[i] = edwards.Edwards()
+		default:
+			return s.WrapError(fmt.Errorf("could not start. ceremony %d: unknown algorithm %d", i, c.Algorithm)).WithCode(tss.CodeBadInput)
+		}
+	}
+	s.started = true
+	return s.startCeremony(ctx, 0)
+}
+
+// The main entry point when updating the session from the wire.
+// isBroadcast should represent whether the message was received via a reliable broadcast
+func (s *KeygenSession) UpdateFromBytes(ctx context.Context, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := s.params.ParseReceived(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, s.WrapError(err)
+	}
+	return s.Update(ctx, msg)
+}
+
+// Update routes a KeygenSessionMessage to the party of its ceremony
+func (s *KeygenSession) Update(ctx context.Context, msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg == nil || msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, s.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg)).WithCode(tss.CodeInvalidMessage)
+	}
+	content, ok := msg.Content().(*KeygenSessionMessage)
+	if !ok || !content.ValidateBasic() {
+		return false, s.WrapError(fmt.Errorf("received an invalid session msg: %s", msg), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+	}
+	i := int(content.GetCeremony())
+	if i >= len(s.ceremonies) {
+		return false, s.WrapError(fmt.Errorf("received msg for an unknown ceremony %d", i), msg.GetFrom()).WithCode(tss.CodeInvalidMessage)
+	}
+	inner, err := content.UnmarshalMessage(msg.GetFrom(), msg.IsBroadcast())
+	if err != nil {
+		return false, s.WrapError(err, msg.GetFrom())
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.deliver(ctx, i, inner)
+}
+
+// WaitingFor returns the parties that the current ceremony is waiting for
+func (s *KeygenSession) WaitingFor() []*tss.PartyID {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.party == nil || s.done {
+		return []*tss.PartyID{}
+	}
+	return s.party.WaitingFor()
+}
+
+// WaitingForMessages returns the parties that the current ceremony is waiting for, by the type of the messages that
+// they have not sent yet
+func (s *KeygenSession) WaitingForMessages() map[string][]*tss.PartyID {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.party == nil || s.done {
+		return map[string][]*tss.PartyID{}
+	}
+	return s.party.WaitingForMessages()
+}
+
+// Progress returns the progress of the current ceremony, or the zero tss.Progress once the session is done
+func (s *KeygenSession) Progress() tss.Progress {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.party == nil || s.done {
+		return tss.Progress{}
+	}
+	return s.party.Progress()
+}
+
+// WrapError wraps an error of the session itself; the errors of a ceremony are returned as its party wrapped them
+func (s *KeygenSession) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, -1, s.PartyID(), culprits...)
+}
+
+func (s *KeygenSession) PartyID() *tss.PartyID {
+	return s.params.PartyID()
+}
+
+func (s *KeygenSession) String() string {
+	return fmt.Sprintf("id: %s, ceremony: %d/%d", s.PartyID(), s.current+1, len(s.ceremonies))
+}
+
+// ----- //
+
+func (s *KeygenSession) startCeremony(ctx context.Context, i int) *tss.Error {
+	params := *s.params
+	params.SetCurve(s.curves[i])
+	s.current = i
+	s.partyOut = make(chan tss.Message, s.params.PartyCount())
+	go s.forward(i, s.partyOut)
+
+	c := s.ceremonies[i]
+	switch c.Algorithm {
+	case ECDSA:
+		if c.PreParams != nil {
+			s.party = ecdsakeygen.NewLocalParty(&params, s.partyOut, s.ecdsaEnd, *c.PreParams)
+		} else {
+			s.party = ecdsakeygen.NewLocalParty(&params, s.partyOut, s.ecdsaEnd)
+		}
+	case EdDSA:
+		s.party = eddsakeygen.NewLocalParty(&params, s.partyOut, s.eddsaEnd)
+	}
+	s.params.Logger().Info("ceremony starting", "task", TaskName, "ceremony", i)
+	if err := s.party.Start(ctx); err != nil {
+		return err
+	}
+
+	// replay the messages that arrived before this ceremony started
+	pending := s.pending[i]
+	s.pending[i] = nil
+	for _, msg := range pending {
+		if _, err := s.deliver(ctx, i, msg); err != nil {
+			return err
+		}
+	}
+	return s.advance(ctx)
+}
+
+// deliver updates the party of ceremony `i` with `msg`, or keeps it until the ceremony starts
+func (s *KeygenSession) deliver(ctx context.Context, i int, msg tss.ParsedMessage) (bool, *tss.Error) {
+	switch {
+	case !s.started || i > s.current:
+		s.pending[i] = append(s.pending[i], msg)
+		return true, nil
+	case i < s.current || s.done:
+		s.params.Logger().Warn("msg for a finished ceremony ignored", "ceremony", i, "msg", msg)
+		return false, nil
+	}
+	if ok, err := s.party.Update(ctx, msg); !ok || err != nil {
+		return ok, err
+	}
+	return true, s.advance(ctx)
+}
+
+// advance starts the next ceremony once the party of the current one has finished
+func (s *KeygenSession) advance(ctx context.Context) *tss.Error {
+	select {
+	case save := <-s.ecdsaEnd:
+		s.results[s.current].ECDSA = &save
+	case save := <-s.eddsaEnd:
+		s.results[s.current].EdDSA = &save
+	default:
+		return nil
+	}
+	// the party has sent all of its messages before its save data
+	close(s.partyOut)
+	s.params.Logger().Info("ceremony finished", "task", TaskName, "ceremony", s.current)
+
+	if s.current+1 < len(s.ceremonies) {
+		return s.startCeremony(ctx, s.current+1)
+	}
+	s.done = true
+	s.end <- s.results
+	return nil
+}
+
+// forward wraps the messages of ceremony `i` and sends them to the session's out channel
+func (s *KeygenSession) forward(i int, partyOut <-chan tss.Message) {
+	for msg := range partyOut {
+		wrapped, err := NewKeygenSessionMessage(i, msg)
+		if err != nil {
+			s.params.Logger().Error("ceremony msg dropped", "task", TaskName, "ceremony", i, "err", err)
+			continue
+		}
+		s.out <- wrapped
+	}
+}
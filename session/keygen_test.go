@@ -0,0 +1,126 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsakeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = 5
+	testThreshold    = 2
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// the pre-params of the ECDSA keygen come from the fixtures
+	fixtures, pIDs, err := ecdsakeygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	sessions := make([]*KeygenSession, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan []KeygenResult, len(pIDs))
+
+	updater := func(s *KeygenSession, msg tss.Message) {
+		bz, _, err := msg.WireBytes()
+		if err != nil {
+			errCh <- s.WrapError(err)
+			return
+		}
+		if _, err := s.UpdateFromBytes(context.Background(), bz, msg.GetFrom(), msg.IsBroadcast()); err != nil {
+			errCh <- err
+		}
+	}
+
+	// init the sessions
+	for i := 0; i < len(pIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		assert.NoError(t, err)
+		ceremonies := []Ceremony{
+			{Algorithm: ECDSA, PreParams: &fixtures[i].LocalPreParams},
+			{Algorithm: EdDSA},
+		}
+		S := NewKeygenSession(ceremonies, params, outCh, endCh)
+		sessions = append(sessions, S)
+		go func(S *KeygenSession) {
+			if err := S.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(S)
+	}
+
+	bundles := make([][]KeygenResult, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, S := range sessions {
+					if S.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(S, msg)
+				}
+			} else {
+				go updater(sessions[dest[0].Index], msg)
+			}
+
+		case bundle := <-endCh:
+			bundles = append(bundles, bundle)
+			if len(bundles) == len(pIDs) {
+				t.Logf("Done. Received the bundle from %d participants", len(bundles))
+				break keygen
+			}
+		}
+	}
+
+	// every party holds shares of the same keys
+	for _, bundle := range bundles {
+		if !assert.Len(t, bundle, 2) {
+			continue
+		}
+		if assert.NotNil(t, bundle[0].ECDSA) {
+			assert.Nil(t, bundle[0].EdDSA)
+			pub := bundle[0].ECDSA.ECDSAPub
+			assert.True(t, btcec.S256().IsOnCurve(pub.X(), pub.Y()), "the ECDSA public key must be on secp256k1")
+			assert.True(t, pub.Equals(bundles[0][0].ECDSA.ECDSAPub), "the ECDSA public keys must match")
+		}
+		if assert.NotNil(t, bundle[1].EdDSA) {
+			assert.Nil(t, bundle[1].ECDSA)
+			pub := bundle[1].EdDSA.EDDSAPub
+			assert.True(t, edwards.Edwards().IsOnCurve(pub.X(), pub.Y()), "the EdDSA public key must be on ed25519")
+			assert.True(t, pub.Equals(bundles[0][1].EdDSA.EDDSAPub), "the EdDSA public keys must match")
+		}
+	}
+	assert.Equal(t, btcec.S256(), tss.S256(), "the session must not change the default curve")
+}
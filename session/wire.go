@@ -0,0 +1,57 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package session
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into session-keygen.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that session messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*KeygenSessionMessage)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*KeygenSessionMessage)(nil), tss.SessionProtoNamePrefix+"KeygenSessionMessage")
+}
+
+// ----- //
+
+// NewKeygenSessionMessage wraps a message of the ceremony at index `ceremony`, keeping its routing
+func NewKeygenSessionMessage(
+	ceremony int,
+	inner tss.Message,
+) (tss.ParsedMessage, error) {
+	bz, routing, err := inner.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	meta := *routing
+	content := &KeygenSessionMessage{
+		Ceremony: uint32(ceremony),
+		Message:  bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *KeygenSessionMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetMessage())
+}
+
+func (m *KeygenSessionMessage) UnmarshalMessage(from *tss.PartyID, isBroadcast bool) (tss.ParsedMessage, error) {
+	return tss.ParseWireMessage(m.GetMessage(), from, isBroadcast)
+}
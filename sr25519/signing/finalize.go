@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/ristretto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	modL := common.ModInt(ristretto.L)
+
+	// 1. check each sj against its commitments: sj*B = Rj + k*Wj
+	sumS := round.temp.si
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sj := r3msg.UnmarshalS()
+		sjB := ristretto.ScalarBaseMult(sj)
+		expected := ristretto.Add(round.temp.bigRjs[j], ristretto.ScalarMult(round.temp.bigWs[j], round.temp.k))
+		if sjB.Equal(expected) != 1 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modL.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the signature share sj"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. save the signature for final output
+	round.data.Signature = encodeSignature(round.temp.bigR, sumS)
+	round.data.R = round.data.Signature[:32]
+	round.data.S = round.data.Signature[32:]
+	round.data.M = round.temp.m
+
+	pubKey := ristretto.EncodeElement(round.key.PubKey)
+	if ok := Verify(pubKey, round.temp.context, round.temp.m, round.data.Signature); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
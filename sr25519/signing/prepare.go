@@ -0,0 +1,63 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	r255 "github.com/gtank/ristretto255"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/ristretto"
+)
+
+// PrepareForSigning(), GG18Spec (11) Fig. 14, in the ristretto255 group
+func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int, bigXs []*r255.Element) (wi *big.Int, bigWs []*r255.Element) {
+	modL := common.ModInt(ristretto.L)
+	if len(ks) != len(bigXs) {
+		panic(fmt.Errorf("PrepareForSigning: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs)))
+	}
+	if len(ks) != pax {
+		panic(fmt.Errorf("PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
+	}
+	if len(ks) <= i {
+		panic(fmt.Errorf("PrepareForSigning: len(ks) <= i (%d <= %d)", len(ks), i))
+	}
+
+	// 2-4.
+	wi = xi
+	for j := 0; j < pax; j++ {
+		if j == i {
+			continue
+		}
+		// big.Int Div is calculated as: a/b = a * modInv(b,q)
+		coef := modL.Mul(ks[j], modL.ModInverse(new(big.Int).Sub(ks[j], ks[i])))
+		wi = modL.Mul(wi, coef)
+	}
+
+	// 5-10.
+	bigWs = make([]*r255.Element, len(ks))
+	for j := 0; j < pax; j++ {
+		bigWj := bigXs[j]
+		for c := 0; c < pax; c++ {
+			if j == c {
+				continue
+			}
+			ksc := ks[c]
+			ksj := ks[j]
+			if ksj.Cmp(ksc) == 0 {
+				panic(fmt.Errorf("index of two parties are equal"))
+			}
+			// big.Int Div is calculated as: a/b = a * modInv(b,q)
+			iota := modL.Mul(ksc, modL.ModInverse(new(big.Int).Sub(ksc, ksj)))
+			bigWj = ristretto.ScalarMult(bigWj, iota)
+		}
+		bigWs[j] = bigWj
+	}
+	return
+}
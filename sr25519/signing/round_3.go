@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/ristretto"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// 1-5. de-commit and verify each Rj, then compute R = sum(Rj)
+	i := round.PartyID().Index
+	round.temp.bigRjs[i] = round.temp.pointRi
+	R := round.temp.pointRi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, flat := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj).WithCode(tss.CodeDecommitMismatch)
+		}
+		if len(flat) != 1 {
+			return round.WrapError(errors.New("length of de-commitment should be 1"), Pj).WithCode(tss.CodeDecommitMismatch)
+		}
+
+		Rjs, err := ristretto.UnFlattenElements(flat)
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "UnFlattenElements(Rj)"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		Rj := Rjs[0]
+		proof, err := r2msg.UnmarshalZKProof()
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj).WithCode(tss.CodeInvalidMessage)
+		}
+		ok = proof.VerifyInTranscript(round.proofTranscript(2, Pj), Rj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj).WithCode(tss.CodeInvalidProof)
+		}
+		round.temp.bigRjs[j] = Rj
+		R = ristretto.Add(R, Rj)
+	}
+
+	// 6. k = the "sign:c" challenge of the schnorrkel signing transcript over the context, message, key and R
+	k := challenge(round.temp.context, round.temp.m, round.key.PubKey, R)
+
+	// 7. compute si = ri + k*wi
+	modL := common.ModInt(ristretto.L)
+	si := modL.Add(round.temp.ri, modL.Mul(k, round.temp.wi))
+
+	// 8. store r3 message pieces
+	round.temp.si = si
+	round.temp.bigR = R
+	round.temp.k = k
+
+	// 9. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- round.WithSessionID(r3msg)
+
+	return nil
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/gtank/merlin"
+	r255 "github.com/gtank/ristretto255"
+
+	"github.com/binance-chain/tss-lib/crypto/ristretto"
+)
+
+const (
+	// SubstrateContext is the signing context used by Substrate based chains
+	SubstrateContext = "substrate"
+
+	signatureLen = 64
+)
+
+// Verify reports whether `sig` is a valid Schnorrkel (sr25519) signature of `msg` in the signing `context` by the
+// 32-byte encoded public key `pubKey`.
+func Verify(pubKey, context, msg, sig []byte) bool {
+	if len(sig) != signatureLen || sig[63]&0x80 == 0 {
+		return false // schnorrkel marks its signatures by setting the top bit of s
+	}
+	P, err := ristretto.DecodeElement(pubKey)
+	if err != nil {
+		return false
+	}
+	R, err := ristretto.DecodeElement(sig[:32])
+	if err != nil {
+		return false
+	}
+	sBz := make([]byte, 32)
+	copy(sBz, sig[32:])
+	sBz[31] &= 0x7f
+	s := r255.NewScalar()
+	if err = s.Decode(sBz); err != nil {
+		return false
+	}
+	k := challenge(context, msg, P, R)
+
+	// s*B == R + k*P
+	sB := r255.NewElement().ScalarBaseMult(s)
+	expected := ristretto.Add(R, ristretto.ScalarMult(P, k))
+	return sB.Equal(expected) == 1
+}
+
+// challenge returns the scalar k of the signing transcript of schnorrkel's `signing_context(context).bytes(msg)`
+func challenge(context, msg []byte, P, R *r255.Element) *big.Int {
+	t := merlin.NewTranscript("SigningContext")
+	t.AppendMessage([]byte(""), context)
+	t.AppendMessage([]byte("sign-bytes"), msg)
+	t.AppendMessage([]byte("proto-name"), []byte("Schnorr-sig"))
+	t.AppendMessage([]byte("sign:pk"), ristretto.EncodeElement(P))
+	t.AppendMessage([]byte("sign:R"), ristretto.EncodeElement(R))
+	k := r255.NewScalar().FromUniformBytes(t.ExtractBytes([]byte("sign:c"), 64))
+	return ristretto.ScalarToInt(k)
+}
+
+// encodeSignature returns R || s with s little-endian and marked as schnorrkel does
+func encodeSignature(R *r255.Element, s *big.Int) []byte {
+	sig := append(ristretto.EncodeElement(R), ristretto.NewScalar(s).Encode(nil)...)
+	sig[63] |= 0x80
+	return sig
+}
@@ -7,6 +7,8 @@
 package test
 
 import (
+	"context"
+
 	"github.com/binance-chain/tss-lib/tss"
 )
 
@@ -25,7 +27,7 @@ func SharedPartyUpdater(party tss.Party, msg tss.Message, errCh chan<- *tss.Erro
 		errCh <- party.WrapError(err)
 		return
 	}
-	if _, err := party.Update(pMsg); err != nil {
+	if _, err := party.Update(context.Background(), pMsg); err != nil {
 		errCh <- err
 	}
 }
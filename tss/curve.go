@@ -9,28 +9,82 @@ package tss
 import (
 	"crypto/elliptic"
 	"errors"
+	"sync"
 
 	s256k1 "github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/binance-chain/tss-lib/crypto/ed448"
+	"github.com/binance-chain/tss-lib/crypto/stark"
 )
 
 var (
-	ec elliptic.Curve
+	curvesMtx sync.RWMutex
+	curves    = make(map[string]elliptic.Curve)
 )
 
-// Init default curve (secp256k1)
+// Init the curve registry
 func init() {
-	ec = s256k1.S256()
+	RegisterCurve("secp256k1", s256k1.S256())
+	RegisterCurve(elliptic.P256().Params().Name, elliptic.P256())
+	RegisterCurve(stark.Name, stark.Curve())
+	RegisterCurve(ed448.Name, ed448.Curve())
+	RegisterCurve("ed25519", edwards.Edwards())
+}
+
+// S256 returns secp256k1, the curve of the parties whose Parameters have no curve set. There is no process-wide
+// curve to change: set the curve of each party with Parameters.SetCurve.
+func S256() elliptic.Curve {
+	return s256k1.S256()
 }
 
-// EC returns the current elliptic curve in use. The default is secp256k1
-func EC() elliptic.Curve {
-	return ec
+// Edwards returns the twisted Edwards curve of Ed25519, which the EdDSA parties are set to with Parameters.SetCurve
+func Edwards() elliptic.Curve {
+	return edwards.Edwards()
 }
 
-// SetCurve sets the curve used by TSS. Must be called before Start. The default is secp256k1
-func SetCurve(curve elliptic.Curve) {
+// RegisterCurve adds a curve to the registry under `name`, so that it can be selected by name with
+// Parameters.SetCurveByName and found by the name that save data records.
+// The registry holds "secp256k1", "P-256", "stark", "ed448" and "ed25519" by default.
+func RegisterCurve(name string, curve elliptic.Curve) {
 	if curve == nil {
-		panic(errors.New("SetCurve received a nil curve"))
+		panic(errors.New("RegisterCurve received a nil curve"))
 	}
-	ec = curve
+	curvesMtx.Lock()
+	defer curvesMtx.Unlock()
+	curves[name] = curve
+}
+
+// GetCurveByName returns the registered curve with the name `name`
+func GetCurveByName(name string) (elliptic.Curve, bool) {
+	curvesMtx.RLock()
+	defer curvesMtx.RUnlock()
+	curve, ok := curves[name]
+	return curve, ok
+}
+
+// GetCurveName returns the name that `curve` is registered under, e.g. to tag data that is only meaningful on it
+func GetCurveName(curve elliptic.Curve) (string, bool) {
+	if curve == nil {
+		return "", false
+	}
+	curvesMtx.RLock()
+	defer curvesMtx.RUnlock()
+	for name, c := range curves {
+		if c == curve {
+			return name, true
+		}
+	}
+	// some packages, e.g. edwards, make a new instance of their curve on each call
+	for name, c := range curves {
+		if sameParams(c.Params(), curve.Params()) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func sameParams(a, b *elliptic.CurveParams) bool {
+	return a.Name == b.Name && a.BitSize == b.BitSize &&
+		a.P.Cmp(b.P) == 0 && a.N.Cmp(b.N) == 0 && a.Gx.Cmp(b.Gx) == 0 && a.Gy.Cmp(b.Gy) == 0
 }
@@ -0,0 +1,198 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type (
+	// Identity signs the messages of a party with its long-term identity key and verifies those of its peers with
+	// theirs, see Parameters.SealMessage. The keys are set up outside of the protocol, e.g. in a PKI.
+	Identity interface {
+		// Sign returns a signature of `msg` by the identity key of this party
+		Sign(msg []byte) ([]byte, error)
+		// Verify returns whether `sig` is a signature of `msg` by the identity key of `party`
+		Verify(party *PartyID, msg, sig []byte) bool
+	}
+
+	// Ed25519Identity is an Identity with Ed25519 keys. PeerKeys holds the public identity key of each peer by the Id
+	// of its PartyID.
+	Ed25519Identity struct {
+		Key      ed25519.PrivateKey
+		PeerKeys map[string]ed25519.PublicKey
+	}
+)
+
+// the prefix of the bytes that are signed, so that an identity key may also sign other things
+const envelopeDomain = "binance.tss-lib.envelope"
+
+var _ Identity = (*Ed25519Identity)(nil)
+
+func (id *Ed25519Identity) Sign(msg []byte) ([]byte, error) {
+	if len(id.Key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("the Ed25519 identity key must be %d bytes, got %d", ed25519.PrivateKeySize, len(id.Key))
+	}
+	return ed25519.Sign(id.Key, msg), nil
+}
+
+func (id *Ed25519Identity) Verify(party *PartyID, msg, sig []byte) bool {
+	key, ok := id.PeerKeys[party.Id]
+	return ok && len(key) == ed25519.PublicKeySize && ed25519.Verify(key, msg, sig)
+}
+
+// ----- //
+
+// SealMessage returns the wire bytes of `msg` with its routing in a SignedEnvelope, signed with the identity key of
+// the party. Send the envelope instead of the wire bytes, and pass what is received to OpenMessage.
+func (params *Parameters) SealMessage(msg Message) ([]byte, error) {
+	if params.identity == nil {
+		return nil, errors.New("SealMessage: no identity has been set")
+	}
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	body := &EnvelopeBody{
+		From:        routing.From.GetKey(),
+		IsBroadcast: routing.IsBroadcast,
+		WireBytes:   wireBytes,
+	}
+	for _, to := range routing.To {
+		body.To = append(body.To, to.GetKey())
+	}
+	bz, err := proto.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := params.identity.Sign(envelopeSigningBytes(bz))
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&SignedEnvelope{Body: bz, Signature: sig})
+}
+
+// OpenMessage verifies a SignedEnvelope made by SealMessage and returns its message, to pass to Update. The sender must
+// be one of the parties, the signature must be one of its identity key, and the message must be for this party.
+func (params *Parameters) OpenMessage(envelope []byte) (ParsedMessage, error) {
+	return params.openMessage(envelope, params.parties.IDs())
+}
+
+// OpenMessage is Parameters.OpenMessage with a sender from the old or the new committee
+func (rgParams *ReSharingParameters) OpenMessage(envelope []byte) (ParsedMessage, error) {
+	return rgParams.openMessage(envelope, SortedPartyIDs(rgParams.OldAndNewParties()))
+}
+
+// ParseReceived parses what a party received from `from`, as UpdateFromBytes does: the wire bytes of the message, or,
+// once an identity has been set, a SignedEnvelope of SealMessage, which OpenMessage verifies. With an identity, raw wire
+// bytes are rejected, as nothing authenticates their sender, and so is an envelope signed by another party than `from`.
+func (params *Parameters) ParseReceived(bz []byte, from *PartyID, isBroadcast bool) (ParsedMessage, error) {
+	return params.parseReceived(bz, from, isBroadcast, params.parties.IDs())
+}
+
+// ParseReceived is Parameters.ParseReceived with a sender from the old or the new committee
+func (rgParams *ReSharingParameters) ParseReceived(bz []byte, from *PartyID, isBroadcast bool) (ParsedMessage, error) {
+	return rgParams.parseReceived(bz, from, isBroadcast, SortedPartyIDs(rgParams.OldAndNewParties()))
+}
+
+func (params *Parameters) parseReceived(bz []byte, from *PartyID, isBroadcast bool, parties SortedPartyIDs) (ParsedMessage, error) {
+	if params.identity == nil {
+		return ParseWireMessage(bz, from, isBroadcast)
+	}
+	msg, err := params.openMessage(bz, parties)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || msg.GetFrom().KeyInt().Cmp(from.KeyInt()) != 0 {
+		return nil, &codedError{error: fmt.Errorf("the envelope from %s was not received from it but from %s", msg.GetFrom(), from), code: CodeInvalidMessage}
+	}
+	return msg, nil
+}
+
+func (params *Parameters) openMessage(envelope []byte, parties SortedPartyIDs) (ParsedMessage, error) {
+	if params.identity == nil {
+		return nil, errors.New("OpenMessage: no identity has been set")
+	}
+	signed, body, err := unmarshalEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+	from := parties.FindByKey(new(big.Int).SetBytes(body.From))
+	if from == nil {
+		return nil, &codedError{error: fmt.Errorf("OpenMessage: the sender %x is not one of the parties", body.From), code: CodeInvalidMessage}
+	}
+	return openEnvelope("OpenMessage", params.identity, from, params.partyID, envelope, signed, body)
+}
+
+// OpenEnvelope verifies that `envelope` is a SignedEnvelope made by SealMessage of `from` and returns its message, e.g.
+// to check a message that a party presents as evidence against `from`. If the message was sent to some of the parties
+// only, `to` must be one of them.
+func OpenEnvelope(identity Identity, from, to *PartyID, envelope []byte) (ParsedMessage, error) {
+	if identity == nil || from == nil || to == nil {
+		return nil, errors.New("OpenEnvelope: the identity, the sender and the receiver must be set")
+	}
+	signed, body, err := unmarshalEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if new(big.Int).SetBytes(body.From).Cmp(from.KeyInt()) != 0 {
+		return nil, &codedError{error: fmt.Errorf("OpenEnvelope: the message is not from %s", from), code: CodeInvalidMessage}
+	}
+	return openEnvelope("OpenEnvelope", identity, from, to, envelope, signed, body)
+}
+
+// Envelope returns the SignedEnvelope that `msg` was opened from by OpenMessage, or nil if it was not, e.g. to keep it
+// as evidence against the sender
+func Envelope(msg Message) []byte {
+	if mm, ok := msg.(*MessageImpl); ok {
+		return mm.envelope
+	}
+	return nil
+}
+
+func unmarshalEnvelope(envelope []byte) (*SignedEnvelope, *EnvelopeBody, error) {
+	signed := new(SignedEnvelope)
+	if err := proto.Unmarshal(envelope, signed); err != nil {
+		return nil, nil, &codedError{error: err, code: CodeInvalidMessage}
+	}
+	body := new(EnvelopeBody)
+	if err := proto.Unmarshal(signed.Body, body); err != nil {
+		return nil, nil, &codedError{error: err, code: CodeInvalidMessage}
+	}
+	return signed, body, nil
+}
+
+func openEnvelope(fn string, identity Identity, from, to *PartyID, envelope []byte, signed *SignedEnvelope, body *EnvelopeBody) (ParsedMessage, error) {
+	if !identity.Verify(from, envelopeSigningBytes(signed.Body), signed.Signature) {
+		return nil, &codedError{error: fmt.Errorf("%s: the signature of the message from %s is invalid", fn, from), code: CodeInvalidMessage}
+	}
+	if 0 < len(body.To) {
+		self, forSelf := to.GetKey(), false
+		for _, key := range body.To {
+			forSelf = forSelf || bytes.Equal(key, self)
+		}
+		if !forSelf {
+			return nil, &codedError{error: fmt.Errorf("%s: the message from %s is not for %s", fn, from, to), code: CodeInvalidMessage}
+		}
+	}
+	msg, err := ParseWireMessage(body.WireBytes, from, body.IsBroadcast)
+	if err != nil {
+		return nil, err
+	}
+	msg.(*MessageImpl).envelope = envelope
+	return msg, nil
+}
+
+func envelopeSigningBytes(body []byte) []byte {
+	return append([]byte(envelopeDomain), body...)
+}
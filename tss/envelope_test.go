@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// newIdentityParams returns the parameters of each of `pIDs`, each with an Ed25519 identity that knows the others
+func newIdentityParams(t *testing.T, pIDs tss.SortedPartyIDs) []*tss.Parameters {
+	keys := make([]ed25519.PrivateKey, len(pIDs))
+	peerKeys := make(map[string]ed25519.PublicKey, len(pIDs))
+	for i, Pi := range pIDs {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		peerKeys[Pi.Id], keys[i] = pub, priv
+	}
+	params := make([]*tss.Parameters, len(pIDs))
+	for i, Pi := range pIDs {
+		var err error
+		params[i], err = tss.NewParameters(tss.NewPeerContext(pIDs), Pi, len(pIDs), 1)
+		assert.NoError(t, err)
+		params[i].SetIdentity(&tss.Ed25519Identity{Key: keys[i], PeerKeys: peerKeys})
+	}
+	return params
+}
+
+func TestParseReceived(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	params := newIdentityParams(t, pIDs)
+
+	msg := newFakeMessage(pIDs[0], 1, []byte("hello"), pIDs[1])
+	envelope, err := params[0].SealMessage(msg)
+	assert.NoError(t, err)
+	wireBytes, _, err := msg.WireBytes()
+	assert.NoError(t, err)
+
+	parsed, err := params[1].ParseReceived(envelope, pIDs[0], false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, pIDs[0].Id, parsed.GetFrom().Id)
+		assert.Equal(t, []byte("hello"), parsed.Content().(*fakeMessage).Payload)
+		assert.Equal(t, envelope, tss.Envelope(parsed))
+	}
+
+	_, err = params[1].ParseReceived(wireBytes, pIDs[0], false)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "raw wire bytes must be rejected once an identity is set")
+	_, err = params[1].ParseReceived(envelope, pIDs[2], false)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "an envelope received from another party must be rejected")
+	_, err = params[2].ParseReceived(envelope, pIDs[0], false)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "a message to another party must be rejected")
+	forged := append([]byte{}, envelope...)
+	forged[len(forged)-1] ^= 1
+	_, err = params[1].ParseReceived(forged, pIDs[0], false)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "a forged message must be rejected")
+
+	// without an identity the wire bytes are parsed as they are
+	plain, err := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[1], len(pIDs), 1)
+	assert.NoError(t, err)
+	parsed, err = plain.ParseReceived(wireBytes, pIDs[0], false)
+	if assert.NoError(t, err) {
+		assert.Nil(t, tss.Envelope(parsed))
+	}
+}
+
+// TestSignedEnvelopes runs a ceremony whose messages are sealed by their senders and opened by their recipients
+func TestSignedEnvelopes(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	params := newIdentityParams(t, pIDs)
+	out, end := fakeChannels(len(pIDs))
+	runFake(t, newFakeParties(params, out, end), out, end, func(P *fakeParty, msg tss.Message) *tss.Error {
+		envelope, err := params[msg.GetFrom().Index].SealMessage(msg)
+		if err != nil {
+			return P.WrapError(err)
+		}
+		_, tErr := P.UpdateFromBytes(context.Background(), envelope, msg.GetFrom(), msg.IsBroadcast())
+		return tErr
+	})
+
+	// a message to another party, and a forged one, are rejected
+	envelope, err := params[0].SealMessage(newFakeShare(pIDs[0], pIDs[1]))
+	assert.NoError(t, err)
+	_, err = params[1].OpenMessage(envelope)
+	assert.NoError(t, err)
+	_, err = params[2].OpenMessage(envelope)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "a message to another party must be rejected")
+	forged := append([]byte{}, envelope...)
+	forged[len(forged)-1] ^= 1
+	_, err = params[1].OpenMessage(forged)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "a forged message must be rejected")
+}
@@ -7,20 +7,155 @@
 package tss
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
-// fundamental is an error that has a message and a stack, but no caller.
-type Error struct {
-	cause    error
-	task     string
-	round    int
-	victim   *PartyID
-	culprits []*PartyID
+// ErrorCode classifies an Error, so that applications can decide how to respond to it without matching its text
+type ErrorCode int
+
+const (
+	// CodeUnknown is the code of errors that were not classified, e.g. failures of the local party's own computations
+	CodeUnknown ErrorCode = iota
+	// CodeCancelled means that the context of the party was cancelled
+	CodeCancelled
+	// CodeTimeout means that the deadline of the context or of a round passed; the culprits, if any, did not respond
+	CodeTimeout
+	// CodeBadInput means that the parameters, key data or arguments given to the party are invalid
+	CodeBadInput
+	// CodeUnexpectedState means that the party was used out of order, e.g. started twice
+	CodeUnexpectedState
+	// CodeSessionMismatch means that a message was sent in another session, e.g. an older run of the same ceremony
+	CodeSessionMismatch
+	// CodeInvalidMessage means that a message of the culprits was malformed or its content was invalid
+	CodeInvalidMessage
+	// CodeEquivocation means that the culprits sent different content for the same message
+	CodeEquivocation
+	// CodeInvalidProof means that a zero-knowledge proof of the culprits failed to verify
+	CodeInvalidProof
+	// CodeDecommitMismatch means that a de-commitment of the culprits did not open their commitment
+	CodeDecommitMismatch
+	// CodeInvalidShare means that a share dealt or sent by the culprits failed to verify
+	CodeInvalidShare
+	// CodeInvalidResult means that the result of the ceremony, e.g. the signature, failed to verify
+	CodeInvalidResult
+	// CodeUnsupported means that the party does not support what was asked of it, e.g. a snapshot of a signing party
+	CodeUnsupported
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	CodeUnknown:          "unknown",
+	CodeCancelled:        "cancelled",
+	CodeTimeout:          "timeout",
+	CodeBadInput:         "bad-input",
+	CodeUnexpectedState:  "unexpected-state",
+	CodeSessionMismatch:  "session-mismatch",
+	CodeInvalidMessage:   "invalid-message",
+	CodeEquivocation:     "equivocation",
+	CodeInvalidProof:     "invalid-proof",
+	CodeDecommitMismatch: "decommit-mismatch",
+	CodeInvalidShare:     "invalid-share",
+	CodeInvalidResult:    "invalid-result",
+	CodeUnsupported:      "unsupported",
+}
+
+func (code ErrorCode) String() string {
+	if name, ok := errorCodeNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorCode(%d)", int(code))
+}
+
+// Misbehaviour returns true if the culprits of an error with this code sent something that an honest party would not,
+// as opposed to failing to respond or the error being local. Applications may exclude them from later ceremonies.
+func (code ErrorCode) Misbehaviour() bool {
+	switch code {
+	case CodeInvalidMessage, CodeEquivocation, CodeInvalidProof, CodeDecommitMismatch, CodeInvalidShare:
+		return true
+	}
+	return false
 }
 
+type (
+	// Error is returned by the parties and rounds. It names the party that reports it, the culprits to blame if any,
+	// and the code that classifies it.
+	Error struct {
+		cause    error
+		task     string
+		round    int
+		victim   *PartyID
+		culprits []*PartyID
+		code     ErrorCode
+		evidence []*Evidence
+	}
+
+	// Evidence backs the blame of one culprit of an Error
+	Evidence struct {
+		Culprit *PartyID
+		Code    ErrorCode
+		Round   int
+		// the message of the culprit that the error is about, if it was at hand
+		Message ParsedMessage
+		// a protocol specific record of the fault that others can check, e.g. *resharing.ShareEvidence
+		Proof interface{}
+	}
+
+	// Coder is implemented by causes of errors that know their ErrorCode
+	Coder interface {
+		Code() ErrorCode
+	}
+)
+
+// codedError gives a cause the code of the Error that wraps it
+type codedError struct {
+	error
+	code ErrorCode
+}
+
+func (err *codedError) Code() ErrorCode { return err.code }
+
+func (err *codedError) Unwrap() error { return err.error }
+
+// NewError returns an Error with the code of `err`, as given by CodeOf; set another with WithCode
 func NewError(err error, task string, round int, victim *PartyID, culprits ...*PartyID) *Error {
-	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits}
+	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits, code: CodeOf(err)}
+}
+
+// CodeOf returns the code of the first error in the chain of `err` that implements Coder, follows from a cancelled
+// context or is an *Error. Both errors.Unwrap and the Cause method of github.com/pkg/errors are followed.
+func CodeOf(err error) ErrorCode {
+	for err != nil {
+		if coder, ok := err.(Coder); ok {
+			return coder.Code()
+		}
+		switch err {
+		case context.Canceled:
+			return CodeCancelled
+		case context.DeadlineExceeded:
+			return CodeTimeout
+		}
+		if next := errors.Unwrap(err); next != nil {
+			err = next
+		} else if causer, ok := err.(interface{ Cause() error }); ok && causer.Cause() != err {
+			err = causer.Cause()
+		} else {
+			break
+		}
+	}
+	return CodeUnknown
+}
+
+// WithCode sets the code of the error and returns it
+func (err *Error) WithCode(code ErrorCode) *Error {
+	err.code = code
+	return err
+}
+
+// WithEvidence sets the evidence against the culprits and returns the error
+func (err *Error) WithEvidence(evidence ...*Evidence) *Error {
+	err.evidence = evidence
+	return err
 }
 
 func (err *Error) Unwrap() error { return err.cause }
@@ -35,14 +170,33 @@ func (err *Error) Victim() *PartyID { return err.victim }
 
 func (err *Error) Culprits() []*PartyID { return err.culprits }
 
+func (err *Error) Code() ErrorCode { return err.code }
+
+// Evidence returns the evidence against the culprits. Without evidence set by WithEvidence, it has an entry for each
+// culprit with the code and round of the error.
+func (err *Error) Evidence() []*Evidence {
+	if err.evidence != nil {
+		return err.evidence
+	}
+	evidence := make([]*Evidence, 0, len(err.culprits))
+	for _, culprit := range err.culprits {
+		evidence = append(evidence, &Evidence{Culprit: culprit, Code: err.code, Round: err.round})
+	}
+	return evidence
+}
+
 func (err *Error) Error() string {
 	if err == nil || err.cause == nil {
 		return "Error is nil"
 	}
+	code := ""
+	if err.code != CodeUnknown {
+		code = fmt.Sprintf(", code %s", err.code)
+	}
 	if err.culprits != nil && len(err.culprits) > 0 {
-		return fmt.Sprintf("task %s, party %v, round %d, culprits %s: %s",
-			err.task, err.victim, err.round, err.culprits, err.cause.Error())
+		return fmt.Sprintf("task %s, party %v, round %d%s, culprits %s: %s",
+			err.task, err.victim, err.round, code, err.culprits, err.cause.Error())
 	}
-	return fmt.Sprintf("task %s, party %v, round %d: %s",
-		err.task, err.victim, err.round, err.cause.Error())
+	return fmt.Sprintf("task %s, party %v, round %d%s: %s",
+		err.task, err.victim, err.round, code, err.cause.Error())
 }
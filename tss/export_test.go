@@ -0,0 +1,15 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+// HeldMessages returns the messages that `p` holds for a later round
+func HeldMessages(p Party) []ParsedMessage {
+	p.lock()
+	defer p.unlock()
+	_, _, held := p.baseState()
+	return held
+}
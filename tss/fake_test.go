@@ -0,0 +1,358 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const fakeTaskName = "fake"
+
+// fakeMessage is the content of the messages of the tests, which a fake protocol sends in round `Round`
+type fakeMessage struct {
+	Round   uint32 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+// fakeShare is the content of the messages that the fake protocol sends to each peer in its second round
+type fakeShare struct {
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func init() {
+	proto.RegisterType((*fakeMessage)(nil), "binance.tsslib.test.fakeMessage")
+	proto.RegisterType((*fakeShare)(nil), "binance.tsslib.test.fakeShare")
+	tss.RegisterMessageTypes(fakeTaskName, (*fakeMessage)(nil), (*fakeShare)(nil))
+}
+
+func (m *fakeMessage) Reset()         { *m = fakeMessage{} }
+func (m *fakeMessage) String() string { return proto.CompactTextString(m) }
+func (*fakeMessage) ProtoMessage()    {}
+
+func (m *fakeMessage) ValidateBasic() bool {
+	return m != nil && 0 < m.Round
+}
+
+func (m *fakeShare) Reset()         { *m = fakeShare{} }
+func (m *fakeShare) String() string { return proto.CompactTextString(m) }
+func (*fakeShare) ProtoMessage()    {}
+
+func (m *fakeShare) ValidateBasic() bool {
+	return m != nil && 0 < len(m.Payload)
+}
+
+// newFakeMessage returns a message of round `round` from `from`, to `to` or broadcast if `to` is empty
+func newFakeMessage(from *tss.PartyID, round uint32, payload []byte, to ...*tss.PartyID) tss.ParsedMessage {
+	meta := tss.MessageRouting{From: from, IsBroadcast: len(to) == 0}
+	if 0 < len(to) {
+		meta.To = to
+	}
+	content := &fakeMessage{Round: round, Payload: payload}
+	return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+}
+
+// newFakeShare returns the share of `from` for `to`, whose payload names both
+func newFakeShare(from, to *tss.PartyID) tss.ParsedMessage {
+	meta := tss.MessageRouting{From: from, To: []*tss.PartyID{to}}
+	content := &fakeShare{Payload: fakeSharePayload(from, to)}
+	return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+}
+
+func fakeSharePayload(from, to *tss.PartyID) []byte {
+	return []byte(fmt.Sprintf("%s>%s", from.Id, to.Id))
+}
+
+// ----- //
+
+// fakeParty runs a protocol of three rounds that does no cryptography: the parties broadcast a message, then send a
+// share to each peer, which they verify as a proof, and each party finishes with the number of messages it received
+type (
+	fakeParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		round1Messages,
+		round2Messages []tss.ParsedMessage
+
+		out chan<- tss.Message
+		end chan<- int
+	}
+
+	fakeRound struct {
+		party   *fakeParty
+		number  int
+		started bool
+		ok      []bool
+	}
+)
+
+var _ tss.Party = (*fakeParty)(nil)
+
+var fakePhases = []string{
+	1: "broadcast",
+	2: "shares",
+	3: "finalization",
+}
+
+func newFakeParty(params *tss.Parameters, out chan<- tss.Message, end chan<- int) *fakeParty {
+	return &fakeParty{
+		BaseParty:      new(tss.BaseParty),
+		params:         params,
+		round1Messages: make([]tss.ParsedMessage, params.PartyCount()),
+		round2Messages: make([]tss.ParsedMessage, params.PartyCount()),
+		out:            out,
+		end:            end,
+	}
+}
+
+// newFakeParties returns a fake party of each of `params`, which all send to `out` and finish to `end`
+func newFakeParties(params []*tss.Parameters, out chan<- tss.Message, end chan<- int) []*fakeParty {
+	parties := make([]*fakeParty, 0, len(params))
+	for _, param := range params {
+		parties = append(parties, newFakeParty(param, out, end))
+	}
+	return parties
+}
+
+// newFakeParams returns the parameters of each of `pIDs`
+func newFakeParams(t *testing.T, pIDs tss.SortedPartyIDs) []*tss.Parameters {
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	for _, Pi := range pIDs {
+		param, err := tss.NewParameters(tss.NewPeerContext(pIDs), Pi, len(pIDs), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// fakeChannels returns an `out` channel that holds every message of a fake ceremony of `partyCount` parties, and an
+// `end` channel that holds every result
+func fakeChannels(partyCount int) (chan tss.Message, chan int) {
+	return make(chan tss.Message, partyCount*partyCount), make(chan int, partyCount)
+}
+
+// runFake starts `parties` and passes each message sent to `out` to its recipients with `deliver`, from this goroutine
+// alone, until every party has finished
+func runFake(t *testing.T, parties []*fakeParty, out <-chan tss.Message, end <-chan int, deliver func(*fakeParty, tss.Message) *tss.Error) {
+	for _, P := range parties {
+		if err := P.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for ended := 0; ended < len(parties); {
+		select {
+		case msg := <-out:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if to := msg.GetTo(); to != nil && to[0].Index != P.PartyID().Index {
+					continue
+				}
+				if err := deliver(P, msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+		case <-end:
+			ended++
+		}
+	}
+}
+
+// deliverWireBytes passes `msg` to `P` by its wire bytes
+func deliverWireBytes(P *fakeParty, msg tss.Message) *tss.Error {
+	bz, routing, err := msg.WireBytes()
+	if err != nil {
+		return P.WrapError(err)
+	}
+	_, tErr := P.UpdateFromBytes(context.Background(), bz, routing.From, routing.IsBroadcast)
+	return tErr
+}
+
+func (p *fakeParty) FirstRound() tss.Round {
+	return p.newRound(1)
+}
+
+func (p *fakeParty) Start(ctx context.Context) *tss.Error {
+	return tss.BaseStart(ctx, p, fakeTaskName)
+}
+
+func (p *fakeParty) Update(ctx context.Context, msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(ctx, p, msg, fakeTaskName)
+}
+
+func (p *fakeParty) UpdateFromBytes(ctx context.Context, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := p.params.ParseReceived(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(ctx, msg)
+}
+
+func (p *fakeParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	if err := p.params.ValidateSender(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *fakeParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	switch msg.Content().(type) {
+	case *fakeMessage:
+		p.round1Messages[msg.GetFrom().Index] = msg
+	case *fakeShare:
+		p.round2Messages[msg.GetFrom().Index] = msg
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *fakeParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *fakeParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+func (p *fakeParty) newRound(number int) *fakeRound {
+	ok := make([]bool, p.params.PartyCount())
+	ok[p.PartyID().Index] = true
+	return &fakeRound{party: p, number: number, ok: ok}
+}
+
+func (round *fakeRound) Params() *tss.Parameters {
+	return round.party.params
+}
+
+func (round *fakeRound) Start(context.Context) *tss.Error {
+	round.started = true
+	Pi := round.party.PartyID()
+	switch round.number {
+	case 1:
+		round.party.out <- newFakeMessage(Pi, 1, []byte(Pi.Id))
+	case 2:
+		for _, Pj := range round.party.params.Parties().IDs() {
+			if Pj.Index != Pi.Index {
+				round.party.out <- newFakeShare(Pi, Pj)
+			}
+		}
+	case 3:
+		received := 0
+		for j := range round.ok {
+			if round.party.round1Messages[j] != nil {
+				received++
+			}
+			if round.party.round2Messages[j] != nil {
+				received++
+			}
+		}
+		round.party.end <- received
+	}
+	return nil
+}
+
+func (round *fakeRound) Update(context.Context) (bool, *tss.Error) {
+	var msgs []tss.ParsedMessage
+	switch round.number {
+	case 1:
+		msgs = round.party.round1Messages
+	case 2:
+		msgs = round.party.round2Messages
+	default:
+		// not expecting any incoming messages in this round
+		return false, nil
+	}
+	for j, msg := range msgs {
+		if round.ok[j] || msg == nil {
+			continue
+		}
+		if share, isShare := msg.Content().(*fakeShare); isShare {
+			Pj, Pi := msg.GetFrom(), round.party.PartyID()
+			if !round.party.params.VerifyProof("share", Pj, func() bool { return bytes.Equal(share.Payload, fakeSharePayload(Pj, Pi)) }) {
+				return false, round.WrapError(errors.New("the share is not for this party"), Pj)
+			}
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *fakeRound) RoundNumber() int {
+	return round.number
+}
+
+func (round *fakeRound) TotalRounds() int {
+	return len(fakePhases) - 1
+}
+
+func (round *fakeRound) Phase() string {
+	return fakePhases[round.number]
+}
+
+func (round *fakeRound) CanAccept(msg tss.ParsedMessage) bool {
+	switch round.number {
+	case 1:
+		_, ok := msg.Content().(*fakeMessage)
+		return ok && msg.IsBroadcast()
+	case 2:
+		_, ok := msg.Content().(*fakeShare)
+		return ok && !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *fakeRound) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *fakeRound) NextRound() tss.Round {
+	if round.number == round.TotalRounds() {
+		return nil
+	}
+	return round.party.newRound(round.number + 1)
+}
+
+func (round *fakeRound) WaitingFor() []*tss.PartyID {
+	Ps := round.party.params.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if !ok {
+			ids = append(ids, Ps[j])
+		}
+	}
+	return ids
+}
+
+func (round *fakeRound) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, fakeTaskName, round.number, round.party.PartyID(), culprits...)
+}
@@ -8,6 +8,7 @@ package tss
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -28,6 +29,8 @@ type (
 		IsToOldCommittee() bool
 		// Indicates whether the message is to both committees during re-sharing; used mainly in tests
 		IsToOldAndNewCommittees() bool
+		// The session ID of the ceremony that this message belongs to, or nil if none was set
+		GetSessionID() []byte
 		// Returns the encoded inner message bytes to send over the wire along with metadata about how the message should be delivered
 		WireBytes() ([]byte, *MessageRouting, error)
 		// Returns the protobuf message wrapper struct
@@ -61,6 +64,8 @@ type (
 		IsToOldCommittee bool
 		// whether the message should be sent to both old and new committee participants
 		IsToOldAndNewCommittees bool
+		// the session ID of the sender's Parameters, sent in the header of the wire bytes
+		SessionID []byte
 	}
 
 	// Implements ParsedMessage; this is a concrete implementation of what messages produced by a LocalParty look like
@@ -68,6 +73,8 @@ type (
 		MessageRouting
 		content MessageContent
 		wire    *MessageWrapper
+		// the SignedEnvelope that OpenMessage opened the message from, if any
+		envelope []byte
 	}
 )
 
@@ -96,6 +103,7 @@ func NewMessageWrapper(routing MessageRouting, content MessageContent) *MessageW
 		IsToOldAndNewCommittees: routing.IsToOldAndNewCommittees,
 		From:                    routing.From.MessageWrapper_PartyID,
 		To:                      to,
+		SessionId:               routing.SessionID,
 		Message:                 any,
 	}
 }
@@ -136,14 +144,24 @@ func (mm *MessageImpl) IsToOldAndNewCommittees() bool {
 	return mm.wire.IsToOldAndNewCommittees
 }
 
+func (mm *MessageImpl) GetSessionID() []byte {
+	return mm.SessionID
+}
+
 func (mm *MessageImpl) WireBytes() ([]byte, *MessageRouting, error) {
-	bz, err := proto.Marshal(mm.wire.Message)
+	bz, err := encodeWireMessage(mm.SessionID, mm.wire.Message)
 	if err != nil {
 		return nil, nil, err
 	}
 	return bz, &mm.MessageRouting, nil
 }
 
+// setSessionID stamps the message with the session ID of a ceremony; see Parameters.WithSessionID
+func (mm *MessageImpl) setSessionID(sessionID []byte) {
+	mm.SessionID = sessionID
+	mm.wire.SessionId = sessionID
+}
+
 func (mm *MessageImpl) WireMsg() *MessageWrapper {
 	return mm.wire
 }
@@ -156,6 +174,35 @@ func (mm *MessageImpl) ValidateBasic() bool {
 	return mm.content.ValidateBasic()
 }
 
+// ----- //
+
+var (
+	messageTypesMtx sync.RWMutex
+	// the names of the types of the messages of each protocol, by its task name
+	messageTypes = make(map[string]map[string]struct{})
+)
+
+// RegisterMessageTypes registers `contents` as the types of the messages of the protocol `task`, the task name that its
+// parties pass to BaseUpdate. BaseUpdate rejects a message of any other type before it records or holds it.
+func RegisterMessageTypes(task string, contents ...MessageContent) {
+	messageTypesMtx.Lock()
+	defer messageTypesMtx.Unlock()
+	if messageTypes[task] == nil {
+		messageTypes[task] = make(map[string]struct{}, len(contents))
+	}
+	for _, content := range contents {
+		messageTypes[task][proto.MessageName(content)] = struct{}{}
+	}
+}
+
+// messageTypeCount returns the number of the types of the messages of the protocol `task`, and whether `msg` is one
+func messageTypeCount(task string, msg Message) (count int, known bool) {
+	messageTypesMtx.RLock()
+	defer messageTypesMtx.RUnlock()
+	_, known = messageTypes[task][msg.Type()]
+	return len(messageTypes[task]), known
+}
+
 func (mm *MessageImpl) String() string {
 	toStr := "all"
 	if mm.To != nil {
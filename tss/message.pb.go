@@ -34,6 +34,8 @@ type MessageWrapper struct {
 	From *MessageWrapper_PartyID `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
 	// Metadata optionally un-marshalled and used by the transport to route this message.
 	To []*MessageWrapper_PartyID `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`
+	// The session ID of the Parameters of the sender, sent in the header of the wire bytes; empty if none was set.
+	SessionId []byte `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	// This field is actually what is sent through the wire and consumed on the other end by UpdateFromBytes.
 	// An Any contains an arbitrary serialized message as bytes, along with a URL that
 	// acts as a globally unique identifier for and resolves to that message's type.
@@ -103,6 +105,13 @@ func (m *MessageWrapper) GetTo() []*MessageWrapper_PartyID {
 	return nil
 }
 
+func (m *MessageWrapper) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
 func (m *MessageWrapper) GetMessage() *any.Any {
 	if m != nil {
 		return m.Message
@@ -168,32 +177,85 @@ func (m *MessageWrapper_PartyID) GetKey() []byte {
 	return nil
 }
 
+//
+// What is sent through the wire by WireBytes and read by UpdateFromBytes, after the magic bytes 00 54 53 53 ("\0TSS") and
+// the version 1 of the format: the content of a MessageWrapper behind a header that binds it to the ceremony of the sender
+type WireMessage struct {
+	SessionId            []byte   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Message              *any.Any `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WireMessage) Reset()         { *m = WireMessage{} }
+func (m *WireMessage) String() string { return proto.CompactTextString(m) }
+func (*WireMessage) ProtoMessage()    {}
+func (*WireMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5be430ad0e7f3d12, []int{1}
+}
+
+func (m *WireMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WireMessage.Unmarshal(m, b)
+}
+func (m *WireMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WireMessage.Marshal(b, m, deterministic)
+}
+func (m *WireMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WireMessage.Merge(m, src)
+}
+func (m *WireMessage) XXX_Size() int {
+	return xxx_messageInfo_WireMessage.Size(m)
+}
+func (m *WireMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_WireMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WireMessage proto.InternalMessageInfo
+
+func (m *WireMessage) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *WireMessage) GetMessage() *any.Any {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MessageWrapper)(nil), "MessageWrapper")
 	proto.RegisterType((*MessageWrapper_PartyID)(nil), "MessageWrapper.PartyID")
+	proto.RegisterType((*WireMessage)(nil), "WireMessage")
 }
 
 func init() { proto.RegisterFile("protob/message.proto", fileDescriptor_5be430ad0e7f3d12) }
 
 var fileDescriptor_5be430ad0e7f3d12 = []byte{
-	// 297 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x90, 0x4f, 0x4b, 0x33, 0x31,
-	0x10, 0x87, 0x69, 0xda, 0xbe, 0xfb, 0x76, 0x5a, 0x4a, 0x89, 0x85, 0xc6, 0xe2, 0xa1, 0x7a, 0xb1,
-	0x20, 0x66, 0x41, 0xcf, 0x1e, 0x5a, 0xf5, 0xe0, 0xc1, 0x3f, 0x04, 0x41, 0xf0, 0xb2, 0xa4, 0x4d,
-	0x5a, 0x42, 0xbb, 0x99, 0x92, 0x44, 0xca, 0x7e, 0x69, 0x3f, 0x83, 0x98, 0xdd, 0xb5, 0x78, 0xf1,
-	0x96, 0x99, 0x79, 0x7e, 0x99, 0xe1, 0x81, 0xe1, 0xce, 0x61, 0xc0, 0x45, 0x9a, 0x6b, 0xef, 0xe5,
-	0x5a, 0xf3, 0x58, 0x8e, 0x8f, 0xd7, 0x88, 0xeb, 0xad, 0x4e, 0xcb, 0xe1, 0xc7, 0x2a, 0x95, 0xb6,
-	0x28, 0x47, 0x67, 0x9f, 0x04, 0xfa, 0x8f, 0x25, 0xfc, 0xe6, 0xe4, 0x6e, 0xa7, 0x1d, 0x3d, 0x85,
-	0x9e, 0xf1, 0xd9, 0xc2, 0xa1, 0x54, 0x4b, 0xe9, 0x03, 0x6b, 0x4c, 0x1a, 0xd3, 0xff, 0xa2, 0x6b,
-	0xfc, 0xbc, 0x6e, 0xd1, 0x4b, 0x38, 0x32, 0x3e, 0x0b, 0x98, 0xe1, 0x56, 0x65, 0x4b, 0xcc, 0x73,
-	0x13, 0x82, 0xd6, 0x8c, 0x44, 0x72, 0x60, 0xfc, 0x2b, 0x3e, 0x6f, 0xd5, 0x6d, 0xdd, 0xa7, 0x37,
-	0x70, 0x72, 0xc0, 0xa5, 0x55, 0x99, 0xd5, 0xfb, 0x43, 0xcc, 0xb3, 0x76, 0xcc, 0x8d, 0xaa, 0xdc,
-	0xcc, 0xaa, 0x27, 0xbd, 0xff, 0x49, 0x7b, 0x7a, 0x01, 0xad, 0x95, 0xc3, 0x9c, 0x35, 0x27, 0x8d,
-	0x69, 0xf7, 0x6a, 0xc4, 0x7f, 0xdf, 0xcb, 0x5f, 0xa4, 0x0b, 0xc5, 0xc3, 0x9d, 0x88, 0x10, 0x3d,
-	0x07, 0x12, 0x90, 0xb5, 0x26, 0xcd, 0xbf, 0x50, 0x12, 0x90, 0x72, 0x48, 0x2a, 0x4b, 0x0c, 0xe2,
-	0xc7, 0x43, 0x5e, 0x6a, 0xe2, 0xb5, 0x26, 0x3e, 0xb3, 0x85, 0xa8, 0xa1, 0xf1, 0x3d, 0x24, 0x55,
-	0x9c, 0xf6, 0x81, 0x18, 0x15, 0xbd, 0x74, 0x04, 0x31, 0x8a, 0x32, 0x48, 0x72, 0xb4, 0x66, 0xa3,
-	0x5d, 0x54, 0xd0, 0x11, 0x75, 0x49, 0x07, 0xd0, 0xdc, 0xe8, 0x22, 0x5e, 0xde, 0x13, 0xdf, 0xcf,
-	0x79, 0xf2, 0xde, 0xe6, 0x69, 0xf0, 0x7e, 0xf1, 0x2f, 0xae, 0xb9, 0xfe, 0x0a, 0x00, 0x00, 0xff,
-	0xff, 0xac, 0xdd, 0x4e, 0x90, 0xb3, 0x01, 0x00, 0x00,
+	// 331 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x5f, 0x4b, 0xc3, 0x30,
+	0x14, 0xc5, 0x69, 0xbb, 0xad, 0xee, 0x6e, 0x8c, 0x11, 0x07, 0x8b, 0x43, 0xa1, 0xee, 0xc5, 0x81,
+	0x98, 0x82, 0x3e, 0xfb, 0xb0, 0xa9, 0x0f, 0x7b, 0xf0, 0x0f, 0x45, 0x18, 0x88, 0x50, 0xba, 0x25,
+	0x1b, 0x61, 0x6b, 0x33, 0x72, 0x23, 0xa3, 0x1f, 0xc2, 0xef, 0x2c, 0xa6, 0xad, 0x43, 0x1f, 0x86,
+	0x6f, 0xb9, 0xf7, 0xfc, 0x4e, 0x72, 0x72, 0xa0, 0xb7, 0xd5, 0xca, 0xa8, 0x79, 0x98, 0x0a, 0xc4,
+	0x64, 0x25, 0x98, 0x1d, 0x07, 0x27, 0x2b, 0xa5, 0x56, 0x1b, 0x11, 0x16, 0xe2, 0xc7, 0x32, 0x4c,
+	0xb2, 0xbc, 0x90, 0x86, 0x9f, 0x1e, 0x74, 0x1e, 0x0b, 0x78, 0xa6, 0x93, 0xed, 0x56, 0x68, 0x72,
+	0x0e, 0x6d, 0x89, 0xf1, 0x5c, 0xab, 0x84, 0x2f, 0x12, 0x34, 0xd4, 0x09, 0x9c, 0xd1, 0x51, 0xd4,
+	0x92, 0x38, 0xa9, 0x56, 0xe4, 0x0a, 0x8e, 0x25, 0xc6, 0x46, 0xc5, 0x6a, 0xc3, 0xe3, 0x85, 0x4a,
+	0x53, 0x69, 0x8c, 0x10, 0xd4, 0xb5, 0x64, 0x57, 0xe2, 0xab, 0x7a, 0xde, 0xf0, 0xbb, 0x6a, 0x4f,
+	0x6e, 0xe1, 0x74, 0x8f, 0x27, 0x19, 0x8f, 0x33, 0xb1, 0xdb, 0xdb, 0x90, 0xd6, 0xad, 0xaf, 0x5f,
+	0xfa, 0xc6, 0x19, 0x7f, 0x12, 0xbb, 0x1f, 0x37, 0x92, 0x4b, 0xa8, 0x2d, 0xb5, 0x4a, 0xa9, 0x17,
+	0x38, 0xa3, 0xd6, 0x75, 0x9f, 0xfd, 0xce, 0xcb, 0x5e, 0x12, 0x6d, 0xf2, 0xe9, 0x7d, 0x64, 0x21,
+	0x72, 0x01, 0xae, 0x51, 0xb4, 0x16, 0x78, 0x87, 0x50, 0xd7, 0x28, 0x72, 0x06, 0x80, 0x02, 0x51,
+	0xaa, 0x2c, 0x96, 0x9c, 0x36, 0x02, 0x67, 0xd4, 0x8e, 0x9a, 0xe5, 0x66, 0xca, 0x09, 0x03, 0xbf,
+	0x2c, 0x91, 0x82, 0x7d, 0xb7, 0xc7, 0x8a, 0x16, 0x59, 0xd5, 0x22, 0x1b, 0x67, 0x79, 0x54, 0x41,
+	0x83, 0x07, 0xf0, 0xcb, 0xdb, 0x49, 0x07, 0x5c, 0xc9, 0x6d, 0x6d, 0xcd, 0xc8, 0x95, 0x9c, 0x50,
+	0xf0, 0x53, 0x95, 0xc9, 0xb5, 0xd0, 0xb6, 0xa1, 0x66, 0x54, 0x8d, 0xa4, 0x0b, 0xde, 0x5a, 0xe4,
+	0xf6, 0x63, 0xed, 0xe8, 0xfb, 0x38, 0x7c, 0x87, 0xd6, 0x4c, 0x6a, 0x51, 0xe6, 0xfe, 0x13, 0xd2,
+	0x39, 0x10, 0xd2, 0xfd, 0x47, 0xc8, 0x89, 0xff, 0x56, 0x67, 0xa1, 0x41, 0x9c, 0x37, 0xac, 0x7e,
+	0xf3, 0x35, 0x00, 0xc6, 0xb2, 0xcf, 0x22, 0x30, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,52 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"time"
+)
+
+type (
+	// Observer is told of the rounds, messages and proofs of a party, e.g. to export latency and failure metrics. It
+	// is set with Parameters.SetObserver. Its methods are called while the party is locked, and those of OnProofVerified
+	// from the goroutines that verify the proofs at once, so they should return quickly.
+	Observer interface {
+		// OnRoundStart is called once `round` of `task` has started and sent its messages. A round that fails to
+		// start is only told to OnRoundEnd.
+		OnRoundStart(party *PartyID, task string, round int)
+		// OnRoundEnd is called once `round` can proceed to the next round, or has failed with `err`. `took` is the
+		// time since it started, including the time spent waiting for the messages of the peers.
+		OnRoundEnd(party *PartyID, task string, round int, took time.Duration, err *Error)
+		// OnMessageAccepted is called once `msg` is stored by the round that accepts it. `wait` is the time from the
+		// start of that round, and is zero for a message that arrived before it.
+		OnMessageAccepted(party *PartyID, msg ParsedMessage, wait time.Duration)
+		// OnProofVerified is called once the proof named `proof` of `prover` has been verified, with the result
+		OnProofVerified(party *PartyID, proof string, prover *PartyID, took time.Duration, ok bool)
+	}
+
+	// NopObserver ignores everything. Embed it in an Observer to implement only some of the methods.
+	NopObserver struct{}
+)
+
+var _ Observer = NopObserver{}
+
+// VerifyProof runs `verify` and tells the observer of the parameters of the result of the proof named `proof` of
+// `prover` and of the time it took
+func (params *Parameters) VerifyProof(proof string, prover *PartyID, verify func() bool) bool {
+	start := time.Now()
+	ok := verify()
+	params.Observer().OnProofVerified(params.partyID, proof, prover, time.Since(start), ok)
+	return ok
+}
+
+func (NopObserver) OnRoundStart(*PartyID, string, int) {}
+
+func (NopObserver) OnRoundEnd(*PartyID, string, int, time.Duration, *Error) {}
+
+func (NopObserver) OnMessageAccepted(*PartyID, ParsedMessage, time.Duration) {}
+
+func (NopObserver) OnProofVerified(*PartyID, string, *PartyID, time.Duration, bool) {}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// countingObserver counts the events of each party; the fake ceremonies tell it from one goroutine
+type countingObserver struct {
+	tss.NopObserver
+	started    map[string][]int
+	ended      map[string][]int
+	accepted   map[string]int
+	proofs     map[string]int
+	failedEnds int
+	badProofs  int
+}
+
+func (o *countingObserver) OnRoundStart(party *tss.PartyID, _ string, round int) {
+	o.started[party.Id] = append(o.started[party.Id], round)
+}
+
+func (o *countingObserver) OnRoundEnd(party *tss.PartyID, _ string, round int, _ time.Duration, err *tss.Error) {
+	o.ended[party.Id] = append(o.ended[party.Id], round)
+	if err != nil {
+		o.failedEnds++
+	}
+}
+
+func (o *countingObserver) OnMessageAccepted(party *tss.PartyID, _ tss.ParsedMessage, _ time.Duration) {
+	o.accepted[party.Id]++
+}
+
+func (o *countingObserver) OnProofVerified(party *tss.PartyID, _ string, _ *tss.PartyID, _ time.Duration, ok bool) {
+	o.proofs[party.Id]++
+	if !ok {
+		o.badProofs++
+	}
+}
+
+func TestObserver(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(4)
+	observer := &countingObserver{
+		started:  make(map[string][]int),
+		ended:    make(map[string][]int),
+		accepted: make(map[string]int),
+		proofs:   make(map[string]int),
+	}
+	params := newFakeParams(t, pIDs)
+	for _, param := range params {
+		param.SetObserver(observer)
+	}
+	out, end := fakeChannels(len(pIDs))
+	runFake(t, newFakeParties(params, out, end), out, end, deliverWireBytes)
+
+	peers := len(pIDs) - 1
+	for _, Pi := range pIDs {
+		assert.Equal(t, []int{1, 2, 3}, observer.started[Pi.Id], "each round must start once")
+		assert.Equal(t, []int{1, 2, 3}, observer.ended[Pi.Id], "each round must end once")
+		// the broadcast and the share of each peer
+		assert.Equal(t, 2*peers, observer.accepted[Pi.Id])
+		assert.Equal(t, peers, observer.proofs[Pi.Id], "the share of each peer must be verified once")
+	}
+	assert.Zero(t, observer.failedEnds)
+	assert.Zero(t, observer.badProofs)
+}
@@ -7,8 +7,16 @@
 package tss
 
 import (
+	"bytes"
+	"crypto/elliptic"
 	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
 	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/commitments"
 )
 
 type (
@@ -18,6 +26,44 @@ type (
 		partyCount          int
 		threshold           int
 		safePrimeGenTimeout time.Duration
+		mtaProofParams      *MtAProofParams
+		pipelined           bool
+		signingProtocol     SigningProtocol
+		paillierModulusLen  int
+		commitmentHash      commitments.HashFunction
+		legacyFraming       bool
+		sessionID           []byte
+		pvss                bool
+		concurrency         int
+		logger              common.Logger
+		observer            Observer
+		identity            Identity
+		curve               elliptic.Curve
+	}
+
+	// SigningProtocol selects the threshold ECDSA signing protocol run by ecdsa/signing.
+	// Both protocols sign with the key shares made by ecdsa/keygen; all parties of a signing ceremony must use the same
+	// protocol.
+	SigningProtocol int
+
+	// MtAProofParams configures the slack of the zero-knowledge range proofs used in the MtA share conversion
+	// protocol of signing (GG18Spec (9) Figs. 9-11).
+	//
+	// The masks of the proofs are sampled from Z_{q^SlackExp} and a verifier rejects a proof whose response s1 exceeds
+	// q^SlackExp. A larger exponent hides the prover's secret with more statistical slack at the cost of a looser range
+	// being proven. GG18 uses 3, which is the smallest exponent that still lets an honest prover pass.
+	//
+	// In Strict mode Bob's blinding value beta' is sampled from Z_{q^(SlackExp+2)} and the mask of beta' from
+	// Z_{q^(SlackExp+4)} (q^5 and q^7 in the paper), and the verifier additionally rejects a Bob proof whose response t1
+	// exceeds q^(SlackExp+4). All parties of a signing ceremony must use the same MtAProofParams.
+	//
+	// The proofs of the peers are always verified unless InsecureSkipVerify is set. It is meant only for test setups in
+	// which all the parties are trusted, as a malicious peer can then extract the key shares of the others; the proofs
+	// are still made and sent, so that parties with and without it can sign together.
+	MtAProofParams struct {
+		SlackExp           int
+		Strict             bool
+		InsecureSkipVerify bool
 	}
 
 	ReSharingParameters struct {
@@ -29,19 +75,101 @@ type (
 )
 
 const (
+	// GG18 is the signing protocol of Gennaro & Goldfeder (2018) and the default
+	GG18 SigningProtocol = iota
+
+	// GG20 is the signing protocol of Gennaro & Goldfeder (2020). Each party proves that its share of R and its share of
+	// the public key check are consistent with its earlier messages, so a failing ceremony names the culprits, and
+	// only the last round depends on the message.
+	GG20
+)
+
+const (
+	// DefaultPaillierModulusLen is the bit length of the Paillier modulus recommended in the GG18 spec
+	DefaultPaillierModulusLen = 2048
+
 	defaultSafePrimeGenTimeout = 5 * time.Minute
+
+	// the GG18 bound on the range proofs used in MtA is q^3
+	minMtASlackExp = 3
+
+	// the statistical margin in bits that the largest value sampled by the MtA proofs must stay below a modulus by
+	mtaModulusMarginBits = 128
 )
 
+// DefaultMtAProofParams returns the MtA range proof parameters used when none have been set
+func DefaultMtAProofParams() *MtAProofParams {
+	return &MtAProofParams{SlackExp: minMtASlackExp}
+}
+
+// StrictMtAProofParams returns MtA range proof parameters that enforce all of the bounds of the GG18 paper
+func StrictMtAProofParams() *MtAProofParams {
+	return &MtAProofParams{SlackExp: minMtASlackExp, Strict: true}
+}
+
+func (p *MtAProofParams) Validate() error {
+	if p == nil {
+		return errors.New("MtAProofParams must not be nil")
+	}
+	if p.SlackExp < minMtASlackExp {
+		return fmt.Errorf("MtAProofParams.SlackExp must be at least %d, got %d", minMtASlackExp, p.SlackExp)
+	}
+	return nil
+}
+
+// ValidateModulus returns an error unless the largest power of the curve order `q` that the MtA proofs sample from,
+// q^SlackExp or q^(SlackExp+4) in strict mode, stays below the Paillier modulus or NTilde `N` by a statistical margin,
+// so that the values masked with it never wrap around N. The provers and verifiers of crypto/mta check it against
+// the moduli of each proof.
+func (p *MtAProofParams) ValidateModulus(q, N *big.Int) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if q == nil || N == nil {
+		return errors.New("MtAProofParams.ValidateModulus received a nil value")
+	}
+	exp := p.SlackExp
+	if p.Strict {
+		exp += 4
+	}
+	bound := new(big.Int).Lsh(new(big.Int).Exp(q, big.NewInt(int64(exp)), nil), mtaModulusMarginBits)
+	if bound.Cmp(N) >= 0 {
+		return fmt.Errorf("MtAProofParams.SlackExp %d (strict: %v) is too large for a modulus of %d bits", p.SlackExp, p.Strict, N.BitLen())
+	}
+	return nil
+}
+
+// NewParameters returns the parameters of the party `partyID` in a ceremony of the parties in `ctx` with the threshold
+// `threshold`. An error is returned unless 1 <= threshold < partyCount, `ctx` holds partyCount parties as sorted by
+// SortPartyIDs, with unique keys and each at the index of its position, and `partyID` is one of them.
 // Exported, used in `tss` client
-func NewParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int, optionalSafePrimeGenTimeout ...time.Duration) *Parameters {
-	var safePrimeGenTimeout time.Duration
+func NewParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int, optionalSafePrimeGenTimeout ...time.Duration) (*Parameters, error) {
+	if 1 < len(optionalSafePrimeGenTimeout) {
+		return nil, errors.New("NewParameters: expected 0 or 1 item in `optionalSafePrimeGenTimeout`")
+	}
+	if err := validateParties(ctx, partyID, partyCount, threshold); err != nil {
+		return nil, err
+	}
+	return newParameters(ctx, partyID, partyCount, threshold, optionalSafePrimeGenTimeout...), nil
+}
+
+// WithParties returns a copy of the parameters with all of their settings, e.g. the session ID, the identity and the
+// logger, for the party `partyID` among the parties in `ctx`, e.g. to run a ceremony again with other parties. The
+// parties are validated as by NewParameters against the same threshold.
+func (params *Parameters) WithParties(ctx *PeerContext, partyID *PartyID, partyCount int) (*Parameters, error) {
+	if err := validateParties(ctx, partyID, partyCount, params.threshold); err != nil {
+		return nil, err
+	}
+	clone := *params
+	clone.parties, clone.partyID, clone.partyCount = ctx, partyID, partyCount
+	clone.sessionID = append([]byte(nil), params.sessionID...)
+	return &clone, nil
+}
+
+func newParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int, optionalSafePrimeGenTimeout ...time.Duration) *Parameters {
+	safePrimeGenTimeout := defaultSafePrimeGenTimeout
 	if 0 < len(optionalSafePrimeGenTimeout) {
-		if 1 < len(optionalSafePrimeGenTimeout) {
-			panic(errors.New("GeneratePreParams: expected 0 or 1 item in `optionalSafePrimeGenTimeout`"))
-		}
 		safePrimeGenTimeout = optionalSafePrimeGenTimeout[0]
-	} else {
-		safePrimeGenTimeout = defaultSafePrimeGenTimeout
 	}
 	return &Parameters{
 		parties:             ctx,
@@ -72,11 +200,333 @@ func (params *Parameters) SafePrimeGenTimeout() time.Duration {
 	return params.safePrimeGenTimeout
 }
 
+// MtAProofParams returns the parameters of the MtA range proofs used in signing, or the defaults if none have been set
+func (params *Parameters) MtAProofParams() *MtAProofParams {
+	if params.mtaProofParams == nil {
+		return DefaultMtAProofParams()
+	}
+	return params.mtaProofParams
+}
+
+// Pipelined returns whether a party may begin the heavy computation for a peer in the next round as soon as that peer's
+// message for the current round has arrived, instead of waiting for the messages of all peers
+func (params *Parameters) Pipelined() bool {
+	return params.pipelined
+}
+
+// SetPipelined enables or disables pipelined rounds. Pipelining overlaps computation with network time and is most
+// effective on high-latency links; it does not change the messages that are sent.
+func (params *Parameters) SetPipelined(pipelined bool) {
+	params.pipelined = pipelined
+}
+
+// SigningProtocol returns the threshold ECDSA signing protocol, GG18 if none has been set
+func (params *Parameters) SigningProtocol() SigningProtocol {
+	return params.signingProtocol
+}
+
+// SetSigningProtocol selects the threshold ECDSA signing protocol
+func (params *Parameters) SetSigningProtocol(protocol SigningProtocol) {
+	params.signingProtocol = protocol
+}
+
+// SetMtAProofParams overrides the parameters of the MtA range proofs used in signing
+func (params *Parameters) SetMtAProofParams(mtaParams *MtAProofParams) error {
+	if err := mtaParams.Validate(); err != nil {
+		return err
+	}
+	if mtaParams.InsecureSkipVerify {
+		params.Logger().Warn("the MtA proofs of the peers will not be verified; only use this with trusted parties")
+	}
+	params.mtaProofParams = mtaParams
+	return nil
+}
+
+// PaillierModulusLen returns the bit length of the Paillier moduli generated by keygen, which is also the minimum
+// accepted for the Paillier keys of peers. It is DefaultPaillierModulusLen if none has been set.
+func (params *Parameters) PaillierModulusLen() int {
+	if params.paillierModulusLen == 0 {
+		return DefaultPaillierModulusLen
+	}
+	return params.paillierModulusLen
+}
+
+// SetPaillierModulusLen sets the bit length of the Paillier moduli to 2048, 3072 or 4096. Longer moduli suit keys that
+// are kept for a long time, at the cost of slower keygen and signing. All parties should use the same length, as a
+// party rejects the keys of peers that are shorter than its own.
+func (params *Parameters) SetPaillierModulusLen(bits int) error {
+	switch bits {
+	case 2048, 3072, 4096:
+		params.paillierModulusLen = bits
+		return nil
+	default:
+		return fmt.Errorf("the Paillier modulus length must be 2048, 3072 or 4096 bits, got %d", bits)
+	}
+}
+
+// CommitmentHash returns the hash function of the hash commitments that the party makes, SHA-512/256 if none has been
+// set
+func (params *Parameters) CommitmentHash() commitments.HashFunction {
+	return params.commitmentHash
+}
+
+// SetCommitmentHash selects the hash function of the hash commitments that the party makes, e.g. to follow the crypto
+// policy of a deployment. The function is tagged in each commitment, so the parties need not agree on it.
+func (params *Parameters) SetCommitmentHash(h commitments.HashFunction) error {
+	if err := h.Validate(); err != nil {
+		return err
+	}
+	params.commitmentHash = h
+	return nil
+}
+
+// LegacyFraming reports whether the party makes and accepts hash commitments of the legacy framing, see SetLegacyFraming
+func (params *Parameters) LegacyFraming() bool {
+	return params.legacyFraming
+}
+
+// SetLegacyFraming makes the hash commitments of the party use the legacy framing and accept it, for a ceremony with
+// parties that run older versions, which cannot open the commitments of the framing v1. The legacy framing is ambiguous,
+// so it should be enabled only for as long as those parties remain. The commitments of the framing v1 are accepted
+// either way.
+func (params *Parameters) SetLegacyFraming(enabled bool) {
+	params.legacyFraming = enabled
+}
+
+// CommitmentFraming returns the framing of the hash commitments that the party makes and opens, see SetLegacyFraming
+func (params *Parameters) CommitmentFraming() commitments.Framing {
+	if params.legacyFraming {
+		return commitments.FramingLegacy
+	}
+	return commitments.FramingV1
+}
+
+// SessionID returns the ID of the ceremony that the hash commitments, proofs and messages are bound to, or nil if none
+// has been set
+func (params *Parameters) SessionID() []byte {
+	return params.sessionID
+}
+
+// SetSessionID binds the hash commitments of the ceremony to `sessionID`, so that a commitment cannot be replayed from
+// one ceremony into another. All parties of a ceremony must set the same ID, which should be unique to the ceremony,
+// e.g. a random nonce agreed on by the coordinator. The commitments are bound to their protocol and round either way.
+// The ECDSA keygen and signing proofs are bound to it too, and every message carries it in the header of its wire
+// bytes: a party rejects the messages of another session, or of none if it has set one.
+func (params *Parameters) SetSessionID(sessionID []byte) {
+	params.sessionID = append([]byte(nil), sessionID...)
+}
+
+// WithSessionID stamps `msg` with the session ID of the parameters, if one has been set, and returns it. The rounds
+// call it on every message that they send.
+func (params *Parameters) WithSessionID(msg Message) Message {
+	if mm, ok := msg.(*MessageImpl); ok && 0 < len(params.sessionID) {
+		mm.setSessionID(params.sessionID)
+	}
+	return msg
+}
+
+// ValidateSessionID returns an error if `msg` was not sent in the session of the parameters
+func (params *Parameters) ValidateSessionID(msg Message) error {
+	if !bytes.Equal(msg.GetSessionID(), params.sessionID) {
+		return &codedError{
+			error: fmt.Errorf("received msg from another session (%x != %x): %s", msg.GetSessionID(), params.sessionID, msg),
+			code:  CodeSessionMismatch,
+		}
+	}
+	return nil
+}
+
+// ValidateSender returns an error unless the sender of `msg` is the party of its index among the parties of the
+// parameters
+func (params *Parameters) ValidateSender(msg Message) error {
+	return validateSender(msg, params.parties.IDs())
+}
+
+// ValidateSender is Parameters.ValidateSender with a sender from the old or the new committee
+func (rgParams *ReSharingParameters) ValidateSender(msg Message) error {
+	if err := validateSender(msg, rgParams.OldParties().IDs()); err == nil {
+		return nil
+	}
+	return validateSender(msg, rgParams.NewParties().IDs())
+}
+
+func validateSender(msg Message, parties SortedPartyIDs) error {
+	from := msg.GetFrom()
+	if from == nil || from.Index < 0 || len(parties) <= from.Index || parties[from.Index].KeyInt().Cmp(from.KeyInt()) != 0 {
+		return &codedError{
+			error: fmt.Errorf("received msg from a party that is not one of the parties: %s", msg),
+			code:  CodeInvalidMessage,
+		}
+	}
+	return nil
+}
+
+// PVSS returns whether the ECDSA keygen deals its shares with publicly verifiable secret sharing
+func (params *Parameters) PVSS() bool {
+	return params.pvss
+}
+
+// SetPVSS enables or disables publicly verifiable secret sharing in the ECDSA keygen. Each dealer then broadcasts the
+// shares of round 2 encrypted to the Paillier keys of their recipients, with proofs that they match its Feldman
+// commitments, in place of sending them point-to-point, so that anyone can check the dealings with
+// keygen.VerifyDealings. All parties of a keygen ceremony must use the same setting.
+func (params *Parameters) SetPVSS(pvss bool) {
+	params.pvss = pvss
+}
+
+// Concurrency returns the number of goroutines that a party runs CPU-heavy work on at once, e.g. the safe prime
+// search, the verification of the proofs of its peers and the MtAs of signing. It is the number of CPUs if none has
+// been set.
+func (params *Parameters) Concurrency() int {
+	if params.concurrency == 0 {
+		return runtime.NumCPU()
+	}
+	return params.concurrency
+}
+
+// SetConcurrency sets the number of goroutines that a party runs CPU-heavy work on at once, e.g. a small number on a
+// mobile device. It does not change the messages that are sent.
+func (params *Parameters) SetConcurrency(n int) error {
+	if n < 1 {
+		return fmt.Errorf("the concurrency must be at least 1, got %d", n)
+	}
+	params.concurrency = n
+	return nil
+}
+
+// EC returns the elliptic curve of the party. It is secp256k1, S256(), if none has been set.
+func (params *Parameters) EC() elliptic.Curve {
+	if params.curve == nil {
+		return S256()
+	}
+	return params.curve
+}
+
+// SetCurve sets the elliptic curve of the party, so that ceremonies on different curves can run at once. All parties
+// of a ceremony must use the same curve.
+func (params *Parameters) SetCurve(curve elliptic.Curve) {
+	if curve == nil {
+		panic(errors.New("SetCurve received a nil curve"))
+	}
+	params.curve = curve
+}
+
+// SetCurveByName sets the registered curve with the name `name` as the elliptic curve of the party
+func (params *Parameters) SetCurveByName(name string) error {
+	curve, ok := GetCurveByName(name)
+	if !ok {
+		return fmt.Errorf("the curve %q is not registered", name)
+	}
+	params.curve = curve
+	return nil
+}
+
+// CurveName returns the name that the curve of the party is registered under, or "" if it is not registered
+func (params *Parameters) CurveName() string {
+	name, _ := GetCurveName(params.EC())
+	return name
+}
+
+// CheckCurveName returns an error if `name`, the curve recorded in the save data of a key, is not the curve of the
+// party. Save data from before the curve was recorded has no name and is taken to be on the curve of the party; its
+// points carry the name of their curve, see crypto.TagECPointsJSON, so they are checked against it when decoded.
+func (params *Parameters) CheckCurveName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if curve := params.CurveName(); name != curve {
+		return fmt.Errorf("the key is on the curve %q but the party is on %q", name, curve)
+	}
+	return nil
+}
+
+// Logger returns the logger of the party, which adds its ID to each entry in the field "party". It is
+// common.DefaultLogger if none has been set.
+func (params *Parameters) Logger() common.Logger {
+	logger := params.logger
+	if logger == nil {
+		logger = common.DefaultLogger()
+	}
+	return logger.With("party", params.partyID)
+}
+
+// SetLogger sets the logger of the party. Each party has its own parameters, so that the logs of concurrent
+// ceremonies, or of the parties of one process, can be told apart.
+func (params *Parameters) SetLogger(logger common.Logger) {
+	params.logger = logger
+}
+
+// Observer returns the observer of the party, which is NopObserver if none has been set
+func (params *Parameters) Observer() Observer {
+	if params.observer == nil {
+		return NopObserver{}
+	}
+	return params.observer
+}
+
+// SetObserver sets an observer that is told of the rounds, messages and proofs of the party, e.g. to export metrics
+func (params *Parameters) SetObserver(observer Observer) {
+	params.observer = observer
+}
+
+// Identity returns the identity of the party that signs its messages, or nil if none has been set
+func (params *Parameters) Identity() Identity {
+	return params.identity
+}
+
+// SetIdentity sets the identity of the party, with which SealMessage signs its messages and OpenMessage verifies
+// those of its peers
+func (params *Parameters) SetIdentity(identity Identity) {
+	params.identity = identity
+}
+
+// validateParties checks the parties and the threshold given to NewParameters
+func validateParties(ctx *PeerContext, partyID *PartyID, partyCount, threshold int) error {
+	if ctx == nil {
+		return errors.New("NewParameters: the peer context is nil")
+	}
+	if partyID == nil || partyID.MessageWrapper_PartyID == nil || partyID.Key == nil {
+		return errors.New("NewParameters: the party ID has no key")
+	}
+	if threshold < 1 {
+		return fmt.Errorf("NewParameters: the threshold must be at least 1, got %d", threshold)
+	}
+	if partyCount <= threshold {
+		return fmt.Errorf("NewParameters: the threshold %d must be less than the party count %d", threshold, partyCount)
+	}
+	ids := ctx.IDs()
+	if len(ids) != partyCount {
+		return fmt.Errorf("NewParameters: the party count is %d but the peer context has %d parties", partyCount, len(ids))
+	}
+	for i, Pj := range ids {
+		if !Pj.ValidateBasic() {
+			return fmt.Errorf("NewParameters: party %d of the peer context is invalid", i)
+		}
+		if Pj.Index != i {
+			return fmt.Errorf("NewParameters: party %s is at position %d of the peer context; sort the parties with SortPartyIDs", Pj, i)
+		}
+		if 0 < i && ids[i-1].KeyInt().Cmp(Pj.KeyInt()) >= 0 {
+			return fmt.Errorf("NewParameters: parties %s and %s are not sorted by key or have the same key", ids[i-1], Pj)
+		}
+	}
+	Pi := ids.FindByKey(partyID.KeyInt())
+	if Pi == nil {
+		return fmt.Errorf("NewParameters: party %s is not a party of the peer context", partyID)
+	}
+	if Pi.Index != partyID.Index {
+		return fmt.Errorf("NewParameters: party %s is at index %d of the peer context; use the PartyID sorted by SortPartyIDs", partyID, Pi.Index)
+	}
+	return nil
+}
+
 // ----- //
 
+// NewReSharingParameters returns the parameters of the party `partyID` in a re-sharing from the old committee in `ctx`
+// to the new committee in `newCtx`. The party may be in either or both committees, so they are not checked here; check
+// them with ValidateQuorumChange of ecdsa/resharing.
 // Exported, used in `tss` client
 func NewReSharingParameters(ctx, newCtx *PeerContext, partyID *PartyID, partyCount, threshold, newPartyCount, newThreshold int) *ReSharingParameters {
-	params := NewParameters(ctx, partyID, partyCount, threshold)
+	params := newParameters(ctx, partyID, partyCount, threshold)
 	return &ReSharingParameters{
 		Parameters:    params,
 		newParties:    newCtx,
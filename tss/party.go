@@ -7,22 +7,32 @@
 package tss
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/binance-chain/tss-lib/common"
 )
 
+// The lifecycle methods of a Party take a context: once it is done they stop at the next step of the current round and
+// return its error, and a party that is not updated further may be discarded.
 type Party interface {
-	Start() *Error
+	Start(ctx context.Context) *Error
 	// The main entry point when updating a party's state from the wire.
-	// isBroadcast should represent whether the message was received via a reliable broadcast
-	UpdateFromBytes(wireBytes []byte, from *PartyID, isBroadcast bool) (ok bool, err *Error)
+	// isBroadcast should represent whether the message was received via a reliable broadcast.
+	// Once the parameters have an identity, wireBytes must be a SignedEnvelope, see Parameters.ParseReceived.
+	UpdateFromBytes(ctx context.Context, wireBytes []byte, from *PartyID, isBroadcast bool) (ok bool, err *Error)
 	// You may use this entry point to update a party's state when running locally or in tests
-	Update(msg ParsedMessage) (ok bool, err *Error)
+	Update(ctx context.Context, msg ParsedMessage) (ok bool, err *Error)
 	Running() bool
 	WaitingFor() []*PartyID
+	WaitingForMessages() map[string][]*PartyID
+	Progress() Progress
 	ValidateMessage(msg ParsedMessage) (bool, *Error)
 	StoreMessage(msg ParsedMessage) (bool, *Error)
 	FirstRound() Round
@@ -32,7 +42,20 @@ type Party interface {
 
 	// Private lifecycle methods
 	setRound(Round) *Error
+	resetRound()
+	checkReplay(msg ParsedMessage) (duplicate bool, err *Error)
+	hold(msg ParsedMessage)
+	heldFrom(from *PartyID) int
+	takeHeld() []ParsedMessage
+	passed(msg ParsedMessage) bool
+	baseState() (received map[string][]byte, samples map[string]ParsedMessage, held []ParsedMessage)
+	restoreBaseState(received map[string][]byte, samples map[string]ParsedMessage, held []ParsedMessage)
 	round() Round
+	logger() common.Logger
+	observeRoundStart(task string, start time.Time)
+	observeRoundEnd(task string, start time.Time, err *Error)
+	roundStarted() time.Time
+	accept(msg ParsedMessage, held bool)
 	advance()
 	lock()
 	unlock()
@@ -42,6 +65,18 @@ type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
 	FirstRound Round
+
+	// the digest of the content of each message received, by its type and sender
+	received map[string][]byte
+	// the first message stored of each type
+	samples map[string]ParsedMessage
+	// the messages received before the round that accepts them has started, at most one of each type per sender
+	held []ParsedMessage
+	// the logger of the parameters, once the party has started
+	log common.Logger
+	// the observer of the parameters and the time that the current round started at
+	observer   Observer
+	roundStart time.Time
 }
 
 func (p *BaseParty) Running() bool {
@@ -57,6 +92,49 @@ func (p *BaseParty) WaitingFor() []*PartyID {
 	return p.rnd.WaitingFor()
 }
 
+// WaitingForMessages returns the peers that the current round is waiting for, by the type of the messages that they
+// have not sent yet, e.g. for an orchestrator to request them again. The types of a round are known once the party has
+// stored one of them, so a peer that is waited for but has sent every type known so far is listed under "".
+func (p *BaseParty) WaitingForMessages() map[string][]*PartyID {
+	p.lock()
+	defer p.unlock()
+	waiting := make(map[string][]*PartyID)
+	if p.rnd == nil {
+		return waiting
+	}
+	self := p.rnd.Params().PartyID()
+	for _, Pj := range p.rnd.WaitingFor() {
+		// a round may count its own messages only once it is updated
+		if Pj.KeyInt().Cmp(self.KeyInt()) == 0 {
+			continue
+		}
+		missing := false
+		for typ, sample := range p.samples {
+			if !p.rnd.CanAccept(sample) {
+				continue
+			}
+			if _, ok := p.received[replaySlot(typ, Pj)]; !ok {
+				waiting[typ] = append(waiting[typ], Pj)
+				missing = true
+			}
+		}
+		if !missing {
+			waiting[""] = append(waiting[""], Pj)
+		}
+	}
+	return waiting
+}
+
+// Progress returns the number of the current round, the number of rounds and the phase of the current round
+func (p *BaseParty) Progress() Progress {
+	p.lock()
+	defer p.unlock()
+	if p.rnd == nil {
+		return Progress{}
+	}
+	return progressOf(p.rnd)
+}
+
 func (p *BaseParty) WrapError(err error, culprits ...*PartyID) *Error {
 	if p.rnd == nil {
 		return NewError(err, "", -1, nil, culprits...)
@@ -64,16 +142,22 @@ func (p *BaseParty) WrapError(err error, culprits ...*PartyID) *Error {
 	return p.rnd.WrapError(err, culprits...)
 }
 
+// blame returns an error with `code` that blames the sender of `msg`, with the message as the evidence
+func (p *BaseParty) blame(err error, code ErrorCode, msg ParsedMessage) *Error {
+	tErr := p.WrapError(err, msg.GetFrom()).WithCode(code)
+	return tErr.WithEvidence(&Evidence{Culprit: msg.GetFrom(), Code: code, Round: tErr.Round(), Message: msg})
+}
+
 // an implementation of ValidateMessage that is shared across the different types of parties (keygen, signing, dynamic groups)
 func (p *BaseParty) ValidateMessage(msg ParsedMessage) (bool, *Error) {
 	if msg == nil || msg.Content() == nil {
-		return false, p.WrapError(fmt.Errorf("received nil msg: %s", msg))
+		return false, p.WrapError(fmt.Errorf("received nil msg: %s", msg)).WithCode(CodeInvalidMessage)
 	}
 	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
-		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg)).WithCode(CodeInvalidMessage)
 	}
 	if !msg.ValidateBasic() {
-		return false, p.WrapError(fmt.Errorf("message failed ValidateBasic: %s", msg), msg.GetFrom())
+		return false, p.blame(fmt.Errorf("message failed ValidateBasic: %s", msg), CodeInvalidMessage, msg)
 	}
 	return true, nil
 }
@@ -87,16 +171,138 @@ func (p *BaseParty) String() string {
 
 func (p *BaseParty) setRound(round Round) *Error {
 	if p.rnd != nil {
-		return p.WrapError(errors.New("a round is already set on this party"))
+		return p.WrapError(errors.New("a round is already set on this party")).WithCode(CodeUnexpectedState)
 	}
 	p.rnd = round
+	p.log = round.Params().Logger()
+	p.observer = round.Params().Observer()
+	p.roundStart = time.Now()
 	return nil
 }
 
+func (p *BaseParty) resetRound() {
+	p.rnd = nil
+	p.received = nil
+	p.samples = nil
+	p.held = nil
+}
+
+// checkReplay records the message in the slot of its type and sender. It returns true if the slot already held the
+// same content, and an error blaming the sender if it held different content. BaseUpdate only records the messages of
+// the types of the protocol from the parties of the ceremony, so there are at most as many slots as both.
+func (p *BaseParty) checkReplay(msg ParsedMessage) (bool, *Error) {
+	bz, err := proto.Marshal(msg.Content())
+	if err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	digest := common.SHA512_256(bz)
+	slot := replaySlot(msg.Type(), msg.GetFrom())
+	if prev, ok := p.received[slot]; ok {
+		if !bytes.Equal(prev, digest) {
+			return false, p.blame(fmt.Errorf("received a conflicting duplicate of a message: %s", msg), CodeEquivocation, msg)
+		}
+		return true, nil
+	}
+	if p.received == nil {
+		p.received = make(map[string][]byte)
+	}
+	p.received[slot] = digest
+	return false, nil
+}
+
+func (p *BaseParty) hold(msg ParsedMessage) {
+	p.held = append(p.held, msg)
+}
+
+// heldFrom returns the number of the messages of `from` that are held
+func (p *BaseParty) heldFrom(from *PartyID) int {
+	count := 0
+	for _, msg := range p.held {
+		if msg.GetFrom().KeyInt().Cmp(from.KeyInt()) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *BaseParty) takeHeld() []ParsedMessage {
+	held := p.held
+	p.held = nil
+	return held
+}
+
+// baseState returns what BaseParty records of the messages received, for a snapshot
+func (p *BaseParty) baseState() (map[string][]byte, map[string]ParsedMessage, []ParsedMessage) {
+	return p.received, p.samples, p.held
+}
+
+func (p *BaseParty) restoreBaseState(received map[string][]byte, samples map[string]ParsedMessage, held []ParsedMessage) {
+	p.received, p.samples, p.held = received, samples, held
+}
+
+// passed returns whether `msg` is for a round that has passed: one that stored a message of its type, which the current
+// round does not accept
+func (p *BaseParty) passed(msg ParsedMessage) bool {
+	_, stored := p.samples[msg.Type()]
+	return stored && !p.rnd.CanAccept(msg)
+}
+
+func progressOf(round Round) Progress {
+	return Progress{Round: round.RoundNumber(), TotalRounds: round.TotalRounds(), Phase: round.Phase()}
+}
+
+func replaySlot(typ string, from *PartyID) string {
+	return fmt.Sprintf("%s/%x", typ, from.GetKey())
+}
+
 func (p *BaseParty) round() Round {
 	return p.rnd
 }
 
+func (p *BaseParty) logger() common.Logger {
+	return p.log
+}
+
+// partyLogger returns the logger of the parameters of the party, or the default one with the ID of the party until it
+// has started
+func partyLogger(p Party) common.Logger {
+	if logger := p.logger(); logger != nil {
+		return logger
+	}
+	return common.DefaultLogger().With("party", p.PartyID())
+}
+
+// observeRoundStart tells the observer that the current round has started, at `start`
+func (p *BaseParty) observeRoundStart(task string, start time.Time) {
+	p.roundStart = start
+	p.observer.OnRoundStart(p.rnd.Params().PartyID(), task, p.rnd.RoundNumber())
+}
+
+// observeRoundEnd tells the observer that the current round, which started at `start`, can proceed or has failed with
+// `err`
+func (p *BaseParty) observeRoundEnd(task string, start time.Time, err *Error) {
+	p.observer.OnRoundEnd(p.rnd.Params().PartyID(), task, p.rnd.RoundNumber(), time.Since(start), err)
+}
+
+func (p *BaseParty) roundStarted() time.Time {
+	return p.roundStart
+}
+
+// accept records that the current round has stored `msg`, which was held if it arrived before, and tells the observer
+func (p *BaseParty) accept(msg ParsedMessage, held bool) {
+	if p.samples == nil {
+		p.samples = make(map[string]ParsedMessage)
+	}
+	if _, ok := p.samples[msg.Type()]; !ok {
+		p.samples[msg.Type()] = msg
+	}
+	var wait time.Duration
+	if !held {
+		wait = time.Since(p.roundStart)
+	}
+	p.observer.OnMessageAccepted(p.rnd.Params().PartyID(), msg, wait)
+}
+
 func (p *BaseParty) advance() {
 	p.rnd = p.rnd.NextRound()
 }
@@ -111,73 +317,179 @@ func (p *BaseParty) unlock() {
 
 // ----- //
 
-func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
+func BaseStart(ctx context.Context, p Party, task string, prepare ...func(Round) *Error) *Error {
+	p.lock()
+	defer p.unlock()
+	return baseStart(ctx, p, task, prepare...)
+}
+
+// BaseRestart discards the current round of a party and starts it again from its first round.
+// `reset` is called while the party is locked and must re-initialise the party's round state before the first round is created.
+func BaseRestart(ctx context.Context, p Party, task string, reset func() *Error, prepare ...func(Round) *Error) *Error {
 	p.lock()
 	defer p.unlock()
+	if err := reset(); err != nil {
+		return err
+	}
+	p.resetRound()
+	partyLogger(p).Info("restarting", "task", task)
+	return baseStart(ctx, p, task, prepare...)
+}
+
+func baseStart(ctx context.Context, p Party, task string, prepare ...func(Round) *Error) *Error {
+	if err := ctx.Err(); err != nil {
+		return p.WrapError(err)
+	}
 	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
-		return p.WrapError(fmt.Errorf("could not start. this party has an invalid PartyID: %+v", p.PartyID()))
+		return p.WrapError(fmt.Errorf("could not start. this party has an invalid PartyID: %+v", p.PartyID())).WithCode(CodeBadInput)
 	}
 	if p.round() != nil {
-		return p.WrapError(errors.New("could not start. this party is in an unexpected state. use the constructor and Start()"))
+		return p.WrapError(errors.New("could not start. this party is in an unexpected state. use the constructor and Start()")).WithCode(CodeUnexpectedState)
 	}
 	round := p.FirstRound()
 	if err := p.setRound(round); err != nil {
 		return err
 	}
 	if 1 < len(prepare) {
-		return p.WrapError(errors.New("too many prepare functions given to Start(); 1 allowed"))
+		return p.WrapError(errors.New("too many prepare functions given to Start(); 1 allowed")).WithCode(CodeUnexpectedState)
 	}
 	if len(prepare) == 1 {
 		if err := prepare[0](round); err != nil {
 			return err
 		}
 	}
-	common.Logger.Infof("party %s: %s round %d starting", p.round().Params().PartyID(), task, 1)
-	defer func() {
-		common.Logger.Debugf("party %s: %s round %d finished", p.round().Params().PartyID(), task, 1)
-	}()
-	return p.round().Start()
+	partyLogger(p).Info("round starting", "task", task, "round", 1)
+	if err := startRound(ctx, p, task); err != nil {
+		return err
+	}
+	partyLogger(p).Debug("round finished", "task", task, "round", 1)
+	// apply the messages that arrived before the party started
+	if released, err := releaseHeld(p); err != nil || !released {
+		return err
+	}
+	_, err := baseProceed(ctx, p, task)
+	return err
 }
 
-// an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
-func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
-	// fast-fail on an invalid message; do not lock the mutex yet
+// an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups).
+// A message of a type that is not registered for `task` with RegisterMessageTypes is rejected. A message is stored once
+// per type and sender: a duplicate of it is ignored, and one with different content is rejected with an error that
+// blames the sender. A message that the current round does not accept, e.g. one for the next round from a peer that is
+// ahead, or any message before Start, is held and applied once a round accepts it, and dropped once the round that
+// accepted its type has passed.
+func BaseUpdate(ctx context.Context, p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
+	// fast-fail on an invalid message or a done context; do not lock the mutex yet
 	if _, err := p.ValidateMessage(msg); err != nil {
 		return false, err
 	}
-	// lock the mutex. need this mtx unlock hook; L108 is recursive so cannot use defer
-	r := func(ok bool, err *Error) (bool, *Error) {
-		p.unlock()
-		return ok, err
+	typeCount, known := messageTypeCount(task, msg)
+	if !known {
+		return false, p.WrapError(fmt.Errorf("received a message of a type that %s does not have: %s", task, msg), msg.GetFrom()).
+			WithCode(CodeInvalidMessage)
+	}
+	if err := ctx.Err(); err != nil {
+		return false, p.WrapError(err)
 	}
 	p.lock() // data is written to P state below
-	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
-	if p.round() != nil {
-		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
+	defer p.unlock()
+	partyLogger(p).Debug("received message", "msg", msg)
+	if duplicate, err := p.checkReplay(msg); err != nil {
+		return false, err
+	} else if duplicate {
+		partyLogger(p).Debug("ignored a duplicate message", "msg", msg)
+		return true, nil
+	}
+	if p.round() != nil && p.passed(msg) {
+		partyLogger(p).Debug("dropped a message for a round that has passed", "msg", msg)
+		return true, nil
+	}
+	if p.round() == nil || !p.round().CanAccept(msg) {
+		// the replay slots let a sender have one message of each type held at most
+		if typeCount <= p.heldFrom(msg.GetFrom()) {
+			return false, p.WrapError(fmt.Errorf("received more messages to hold than %s has types: %s", task, msg), msg.GetFrom()).
+				WithCode(CodeInvalidMessage)
+		}
+		p.hold(msg)
+		if p.round() == nil {
+			partyLogger(p).Debug("held a message until the party starts", "msg", msg)
+			return true, nil
+		}
+		// the current round may be waiting for no more messages, e.g. of this party's own role
+		partyLogger(p).Debug("held a message for a later round", "msg", msg)
+		return baseProceed(ctx, p, task)
 	}
 	if ok, err := p.StoreMessage(msg); err != nil || !ok {
-		return r(false, err)
+		return false, err
 	}
-	if p.round() != nil {
-		common.Logger.Debugf("party %s: %s round %d update", p.round().Params().PartyID(), task, p.round().RoundNumber())
-		if _, err := p.round().Update(); err != nil {
-			return r(false, err)
-		}
-		if p.round().CanProceed() {
-			if p.advance(); p.round() != nil {
-				if err := p.round().Start(); err != nil {
-					return r(false, err)
-				}
-				rndNum := p.round().RoundNumber()
-				common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
-			} else {
-				// finished! the round implementation will have sent the data through the `end` channel.
-				common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
-			}
-			p.unlock()                      // recursive so can't defer after return
-			return BaseUpdate(p, msg, task) // re-run round update or finish)
+	p.accept(msg, false)
+	return baseProceed(ctx, p, task)
+}
+
+// baseProceed updates the current round with the messages stored so far and starts the next rounds for as long as
+// they can proceed, applying the held messages that each of them accepts. The party must be locked.
+func baseProceed(ctx context.Context, p Party, task string) (ok bool, err *Error) {
+	for p.round() != nil {
+		partyLogger(p).Debug("round update", "task", task, "round", p.round().RoundNumber())
+		if _, err := p.round().Update(ctx); err != nil {
+			p.observeRoundEnd(task, p.roundStarted(), err)
+			return false, err
+		}
+		if !p.round().CanProceed() {
+			return true, nil
+		}
+		p.observeRoundEnd(task, p.roundStarted(), nil)
+		if p.advance(); p.round() == nil {
+			// finished! the round implementation will have sent the data through the `end` channel.
+			partyLogger(p).Info("finished!", "task", task)
+			break
+		}
+		if err := startRound(ctx, p, task); err != nil {
+			return false, err
+		}
+		partyLogger(p).Info("round started", "task", task, "round", progressOf(p.round()))
+		if _, err := releaseHeld(p); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// startRound starts the current round and tells the observer. The last round of a protocol does all of its work in
+// Start and accepts no messages, so it ends once Start has returned.
+func startRound(ctx context.Context, p Party, task string) *Error {
+	start := time.Now()
+	if err := p.round().Start(ctx); err != nil {
+		p.observeRoundEnd(task, start, err)
+		return err
+	}
+	// a round knows its number once it has started
+	p.observeRoundStart(task, start)
+	if round := p.round(); round.RoundNumber() == round.TotalRounds() {
+		p.observeRoundEnd(task, start, nil)
+	}
+	return nil
+}
+
+// releaseHeld stores the held messages that the current round accepts, drops those for the rounds that have passed and
+// keeps holding the others. It returns whether any were stored.
+func releaseHeld(p Party) (released bool, err *Error) {
+	for _, msg := range p.takeHeld() {
+		if p.passed(msg) {
+			partyLogger(p).Debug("dropped a held message for a round that has passed", "msg", msg)
+			continue
+		}
+		if !p.round().CanAccept(msg) {
+			p.hold(msg)
+			continue
+		}
+		ok, err := p.StoreMessage(msg)
+		if err != nil {
+			return released, err
+		}
+		if ok {
+			p.accept(msg, true)
 		}
-		return r(true, nil)
+		released = released || ok
 	}
-	return r(true, nil)
+	return released, nil
 }
@@ -7,6 +7,8 @@
 package tss
 
 import (
+	"crypto/elliptic"
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
@@ -58,26 +60,49 @@ func (pid PartyID) String() string {
 
 // ----- //
 
-// SortPartyIDs sorts a list of []*PartyID by their keys in ascending order
+// SortPartyIDs sorts a list of []*PartyID by their keys in ascending order and assigns their indexes.
+// An error is returned if a party has no key or a zero key, or if two parties have keys that are equal modulo the
+// order of `curve`, the curve of the ceremony, as they would be given the same share and message slots.
 // Exported, used in `tss` client
-func SortPartyIDs(ids UnSortedPartyIDs, startAt ...int) SortedPartyIDs {
+func SortPartyIDs(ids UnSortedPartyIDs, curve elliptic.Curve, startAt ...int) (SortedPartyIDs, error) {
+	if curve == nil {
+		return nil, errors.New("SortPartyIDs: the curve is nil")
+	}
+	frm := 0
+	if len(startAt) > 0 {
+		if frm = startAt[0]; frm < 0 {
+			return nil, fmt.Errorf("SortPartyIDs: the first index must not be negative, got %d", frm)
+		}
+	}
+	q := curve.Params().N
 	sorted := make(SortedPartyIDs, 0, len(ids))
-	for _, id := range ids {
+	for i, id := range ids {
+		if id == nil || id.MessageWrapper_PartyID == nil || id.Key == nil {
+			return nil, fmt.Errorf("SortPartyIDs: party %d has no key", i)
+		}
+		if new(big.Int).Mod(id.KeyInt(), q).Sign() == 0 {
+			return nil, fmt.Errorf("SortPartyIDs: party %s has a zero key", id)
+		}
 		sorted = append(sorted, id)
 	}
 	sort.Sort(sorted)
+	// reject keys that collide; their indexes would differ, but their shares and message slots would not
+	seen := make(map[string]*PartyID, len(sorted))
+	for _, id := range sorted {
+		k := string(new(big.Int).Mod(id.KeyInt(), q).Bytes())
+		if other, ok := seen[k]; ok {
+			return nil, fmt.Errorf("SortPartyIDs: parties %s and %s have colliding keys", other, id)
+		}
+		seen[k] = id
+	}
 	// assign party indexes
 	for i, id := range sorted {
-		frm := 0
-		if len(startAt) > 0 {
-			frm = startAt[0]
-		}
 		id.Index = i + frm
 	}
-	return sorted
+	return sorted, nil
 }
 
-// GenerateTestPartyIDs generates a list of mock PartyIDs for tests
+// GenerateTestPartyIDs generates a list of mock PartyIDs for tests, sorted for secp256k1
 func GenerateTestPartyIDs(count int, startAt ...int) SortedPartyIDs {
 	ids := make(UnSortedPartyIDs, 0, count)
 	key := common.MustGetRandomInt(256)
@@ -98,7 +123,11 @@ func GenerateTestPartyIDs(count int, startAt ...int) SortedPartyIDs {
 			// this key makes tests more deterministic
 		})
 	}
-	return SortPartyIDs(ids, startAt...)
+	sorted, err := SortPartyIDs(ids, S256(), startAt...)
+	if err != nil {
+		panic(err)
+	}
+	return sorted
 }
 
 func (spids SortedPartyIDs) Keys() []*big.Int {
@@ -0,0 +1,35 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestSortPartyIDsOnCurve(t *testing.T) {
+	ed25519, ok := tss.GetCurveByName("ed25519")
+	assert.True(t, ok)
+	// the keys collide modulo the order of ed25519, but not modulo the one of secp256k1
+	newIDs := func() tss.UnSortedPartyIDs {
+		return tss.UnSortedPartyIDs{
+			tss.NewPartyID("1", "P[1]", big.NewInt(1)),
+			tss.NewPartyID("2", "P[2]", new(big.Int).Add(ed25519.Params().N, big.NewInt(1))),
+		}
+	}
+	sorted, err := tss.SortPartyIDs(newIDs(), tss.S256())
+	assert.NoError(t, err)
+	assert.Len(t, sorted, 2)
+	_, err = tss.SortPartyIDs(newIDs(), ed25519)
+	assert.Error(t, err)
+	_, err = tss.SortPartyIDs(newIDs(), nil)
+	assert.Error(t, err)
+}
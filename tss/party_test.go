@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// fakeUnknown is a message of a type that the fake protocol does not have
+type fakeUnknown struct {
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func init() {
+	proto.RegisterType((*fakeUnknown)(nil), "binance.tsslib.test.fakeUnknown")
+}
+
+func (m *fakeUnknown) Reset()         { *m = fakeUnknown{} }
+func (m *fakeUnknown) String() string { return proto.CompactTextString(m) }
+func (*fakeUnknown) ProtoMessage()    {}
+
+func (m *fakeUnknown) ValidateBasic() bool {
+	return m != nil
+}
+
+func TestUpdateRejectsUnknownSendersAndTypes(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	out, end := fakeChannels(len(pIDs))
+	P := newFakeParty(newFakeParams(t, pIDs)[0], out, end)
+	ctx := context.Background()
+
+	// a party that is not one of the parties, and one that takes the index of another
+	outsider := tss.NewPartyID("outsider", "outsider", big.NewInt(42))
+	outsider.Index = 1
+	impostor := tss.NewPartyID(pIDs[1].Id, pIDs[1].Moniker, new(big.Int).Add(pIDs[1].KeyInt(), big.NewInt(1)))
+	impostor.Index = 1
+	for _, from := range []*tss.PartyID{outsider, impostor} {
+		_, err := P.Update(ctx, newFakeMessage(from, 1, nil))
+		if assert.NotNil(t, err, "a message from %s must be rejected", from) {
+			assert.Equal(t, tss.CodeInvalidMessage, err.Code())
+		}
+	}
+
+	meta := tss.MessageRouting{From: pIDs[1], IsBroadcast: true}
+	content := &fakeUnknown{Payload: []byte("unknown")}
+	_, err := P.Update(ctx, tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)))
+	if assert.NotNil(t, err, "a message of a type of another protocol must be rejected") {
+		assert.Equal(t, tss.CodeInvalidMessage, err.Code())
+	}
+	assert.Empty(t, tss.HeldMessages(P), "a rejected message must not be held")
+
+	// before Start the messages of the parties are held, once each
+	msg := newFakeMessage(pIDs[1], 1, []byte(pIDs[1].Id))
+	for i := 0; i < 2; i++ {
+		ok, err := P.Update(ctx, msg)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, len(tss.HeldMessages(P)), "a duplicate must not be held again")
+}
+
+func TestUpdateDropsMessagesOfPassedRounds(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	out, end := fakeChannels(len(pIDs))
+	P := newFakeParty(newFakeParams(t, pIDs)[0], out, end)
+	ctx := context.Background()
+
+	assert.Nil(t, P.Start(ctx))
+	for _, Pj := range pIDs[1:] {
+		ok, err := P.Update(ctx, newFakeMessage(Pj, 1, []byte(Pj.Id)))
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, P.Progress().Round, "the party must have proceeded to the shares")
+
+	// e.g. a transport that echoes the broadcasts: the party never stores its own message of the first round
+	ok, err := P.Update(ctx, newFakeMessage(pIDs[0], 1, []byte(pIDs[0].Id)))
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Empty(t, tss.HeldMessages(P), "a message for a round that has passed must be dropped")
+
+	// a share is for the current round and is stored
+	ok, err = P.Update(ctx, newFakeShare(pIDs[1], pIDs[0]))
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Empty(t, tss.HeldMessages(P))
+}
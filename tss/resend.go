@@ -0,0 +1,168 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type (
+	// ResendRequest is a control message that asks a peer to send again the messages that it has sent to the requester,
+	// e.g. by a party that has been resumed with RestoreParty after a crash and has lost the messages that arrived since
+	// its snapshot. It is not a message of a protocol: the transport carries it next to them, and the peer answers it
+	// with Outbox.Resend.
+	ResendRequest struct {
+		// the party that asks, set by ParseResendRequest from the routing of the transport
+		From *PartyID `json:"-"`
+		// the session ID of the ceremony, see Parameters.SetSessionID
+		SessionID []byte `json:"sessionId"`
+		// the types of the messages to send again, or none for all of them
+		Types []string `json:"types,omitempty"`
+	}
+
+	// Outbox is a Transport that keeps the messages that it delivers until Reset, so that it can send them again to a
+	// peer that asks with a ResendRequest. It holds the messages of a single party.
+	Outbox struct {
+		t    Transport
+		mtx  sync.Mutex
+		sent []Message
+	}
+)
+
+var _ Transport = (*Outbox)(nil)
+
+// ResendRequests returns the ResendRequest to send to each of the peers that the party waits for, e.g. once the party
+// has been restored with RestoreParty. A peer whose missing types are known is asked for those, and any other for all
+// of its messages; those that the party has received already are then ignored as duplicates.
+func ResendRequests(p Party) map[*PartyID]*ResendRequest {
+	var sessionID []byte
+	p.lock()
+	if p.round() != nil {
+		sessionID = p.round().Params().SessionID()
+	}
+	p.unlock()
+
+	reqs := make(map[*PartyID]*ResendRequest)
+	for typ, peers := range p.WaitingForMessages() {
+		for _, Pj := range peers {
+			req, ok := reqs[Pj]
+			if !ok {
+				req = &ResendRequest{SessionID: sessionID}
+				reqs[Pj] = req
+			}
+			// a peer is listed under "" only if it has sent every type known so far
+			if typ != "" {
+				req.Types = append(req.Types, typ)
+			}
+		}
+	}
+	return reqs
+}
+
+// Bytes returns the request in the form that ParseResendRequest reads
+func (req *ResendRequest) Bytes() ([]byte, error) {
+	return json.Marshal(req)
+}
+
+// ParseResendRequest parses a request of Bytes that the transport received from the party `from`
+func ParseResendRequest(bz []byte, from *PartyID) (*ResendRequest, error) {
+	if from == nil || !from.ValidateBasic() {
+		return nil, errors.New("ParseResendRequest: the sender is invalid")
+	}
+	req := new(ResendRequest)
+	if err := json.Unmarshal(bz, req); err != nil {
+		return nil, err
+	}
+	req.From = from
+	return req, nil
+}
+
+// asks reports whether the request asks for `msg`: one of the requested types that was sent to the requester
+func (req *ResendRequest) asks(msg Message) bool {
+	if !bytes.Equal(msg.GetSessionID(), req.SessionID) {
+		return false
+	}
+	if 0 < len(req.Types) {
+		found := false
+		for _, typ := range req.Types {
+			if typ == msg.Type() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	to := msg.GetTo()
+	if to == nil {
+		return true
+	}
+	for _, Pj := range to {
+		if bytes.Equal(Pj.GetKey(), req.From.GetKey()) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewOutbox returns an Outbox that delivers the messages through `t`
+func NewOutbox(t Transport) *Outbox {
+	return &Outbox{t: t}
+}
+
+func (o *Outbox) Send(msg Message, to ...*PartyID) error {
+	o.keep(msg)
+	return o.t.Send(msg, to...)
+}
+
+func (o *Outbox) Broadcast(msg Message) error {
+	o.keep(msg)
+	return o.t.Broadcast(msg)
+}
+
+// Resend sends the messages that `req` asks for again, to the requester alone and in the order that they were first
+// sent. A broadcast is sent with Send as well, and the requester should update its party with the IsBroadcast of the
+// message, as a peer that relays a broadcast would. It returns the number of messages sent.
+func (o *Outbox) Resend(req *ResendRequest) (int, error) {
+	if req == nil || req.From == nil {
+		return 0, errors.New("Resend: the request has no sender; use ParseResendRequest")
+	}
+	o.mtx.Lock()
+	sent := append([]Message(nil), o.sent...)
+	o.mtx.Unlock()
+	n := 0
+	for _, msg := range sent {
+		if !req.asks(msg) {
+			continue
+		}
+		if err := o.t.Send(msg, req.From); err != nil {
+			return n, fmt.Errorf("Resend: %v", err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Reset drops the messages kept so far. Call it once the ceremony of the party has ended, e.g. when its result has
+// arrived on the `end` channel, as no peer needs them again and the outbox would otherwise keep them for as long as it
+// is used.
+func (o *Outbox) Reset() {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.sent = nil
+}
+
+func (o *Outbox) keep(msg Message) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.sent = append(o.sent, msg)
+}
@@ -6,14 +6,40 @@
 
 package tss
 
+import (
+	"context"
+	"fmt"
+)
+
 type Round interface {
 	Params() *Parameters
-	Start() *Error
-	Update() (bool, *Error)
+	// Start and Update check `ctx` between the steps of their long loops, e.g. of proofs, and return its error once it
+	// is done
+	Start(ctx context.Context) *Error
+	Update(ctx context.Context) (bool, *Error)
 	RoundNumber() int
+	// TotalRounds is the number of rounds of the protocol, counting its final round, in the mode that the party runs
+	TotalRounds() int
+	// Phase is a human-readable name of what the round does, e.g. "MtA responses"
+	Phase() string
 	CanAccept(msg ParsedMessage) bool
 	CanProceed() bool
 	NextRound() Round
 	WaitingFor() []*PartyID
 	WrapError(err error, culprits ...*PartyID) *Error
 }
+
+// Progress is the position of a party in its protocol, e.g. for a monitoring dashboard or a log line
+type Progress struct {
+	Round       int // the number of the current round, from 1; 0 when the party is not running
+	TotalRounds int
+	Phase       string
+}
+
+// String returns e.g. "3/9: MtA responses"
+func (pr Progress) String() string {
+	if pr.Round == 0 {
+		return "not running"
+	}
+	return fmt.Sprintf("%d/%d: %s", pr.Round, pr.TotalRounds, pr.Phase)
+}
@@ -0,0 +1,172 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrSnapshotUnsupported is returned by SnapshotParty and RestoreParty for a party that does not implement
+// StatefulParty. Its code is CodeUnsupported.
+var ErrSnapshotUnsupported error = &codedError{error: errors.New("the protocol of the party does not support snapshots"), code: CodeUnsupported}
+
+type (
+	// StatefulParty is implemented by the parties whose state between two rounds can be saved with SnapshotParty and
+	// restored with RestoreParty. Only the keygen parties of ECDSA and EdDSA implement it. The signing and re-sharing
+	// parties of ECDSA and EdDSA and the parties of the other protocols do not: their round states are not encoded, so
+	// an interrupted ceremony of those is started again from the save data, in a new session.
+	StatefulParty interface {
+		Party
+		// MarshalState returns the state of the protocol: the save data, the temp data and the messages stored so far
+		MarshalState() ([]byte, error)
+		// RestoreState restores the state of the protocol from `state` and returns round `number` of the protocol in
+		// the state that Start left it in, without running it again
+		RestoreState(state []byte, number int) (Round, error)
+	}
+
+	// SnapshotMessage is a message in a snapshot: its wire bytes with the routing needed to parse them again
+	SnapshotMessage struct {
+		From        []byte `json:"from"`
+		IsBroadcast bool   `json:"isBroadcast"`
+		WireBytes   []byte `json:"wireBytes"`
+	}
+
+	partySnapshot struct {
+		Round    int                         `json:"round"`
+		State    json.RawMessage             `json:"state"`
+		Held     []*SnapshotMessage          `json:"held"`
+		Received map[string][]byte           `json:"received"`
+		Samples  map[string]*SnapshotMessage `json:"samples"`
+	}
+)
+
+// SnapshotParty returns the state of a running keygen party between two rounds, for RestoreParty to resume it e.g.
+// after a crash or on another host. It returns ErrSnapshotUnsupported for a party that does not implement
+// StatefulParty. The snapshot holds the secrets of the party and must be stored as safely as its save data.
+func SnapshotParty(party Party) ([]byte, error) {
+	p, ok := party.(StatefulParty)
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+	p.lock()
+	defer p.unlock()
+	if p.round() == nil {
+		return nil, errors.New("SnapshotParty: the party is not running")
+	}
+	state, err := p.MarshalState()
+	if err != nil {
+		return nil, err
+	}
+	snap := partySnapshot{Round: p.round().RoundNumber(), State: state}
+	received, samples, held := p.baseState()
+	if snap.Held, err = EncodeMessages(held); err != nil {
+		return nil, err
+	}
+	snap.Received = received
+	snap.Samples = make(map[string]*SnapshotMessage, len(samples))
+	for typ, msg := range samples {
+		if snap.Samples[typ], err = EncodeMessage(msg); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(&snap)
+}
+
+// RestoreParty restores a party made by the constructor of its protocol, with the same parameters, from a snapshot of
+// SnapshotParty. It then waits for the messages of the round that it was in, which peers may have to send again. A party
+// that does not implement StatefulParty gets an error with the code CodeUnsupported.
+func RestoreParty(ctx context.Context, party Party, snapshot []byte) *Error {
+	p, ok := party.(StatefulParty)
+	if !ok {
+		return party.WrapError(ErrSnapshotUnsupported)
+	}
+	p.lock()
+	defer p.unlock()
+	if p.round() != nil {
+		return p.WrapError(errors.New("could not restore. the party has already started")).WithCode(CodeUnexpectedState)
+	}
+	snap := new(partySnapshot)
+	if err := json.Unmarshal(snapshot, snap); err != nil {
+		return p.WrapError(err).WithCode(CodeBadInput)
+	}
+	round, err := p.RestoreState(snap.State, snap.Round)
+	if err != nil {
+		return p.WrapError(err).WithCode(CodeBadInput)
+	}
+	parties := round.Params().Parties().IDs()
+	held, err := DecodeMessages(snap.Held, parties)
+	if err != nil {
+		return p.WrapError(err).WithCode(CodeBadInput)
+	}
+	samples := make(map[string]ParsedMessage, len(snap.Samples))
+	for typ, enc := range snap.Samples {
+		if samples[typ], err = DecodeMessage(enc, parties); err != nil {
+			return p.WrapError(err).WithCode(CodeBadInput)
+		}
+	}
+	if err := p.setRound(round); err != nil {
+		return err
+	}
+	p.restoreBaseState(snap.Received, samples, held)
+	// count the messages of the round that were stored before the snapshot
+	_, tErr := round.Update(ctx)
+	return tErr
+}
+
+// EncodeMessage returns `msg` in the form of a snapshot, or nil if it is nil
+func EncodeMessage(msg ParsedMessage) (*SnapshotMessage, error) {
+	if msg == nil {
+		return nil, nil
+	}
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotMessage{From: msg.GetFrom().GetKey(), IsBroadcast: msg.IsBroadcast(), WireBytes: bz}, nil
+}
+
+// DecodeMessage parses a message of a snapshot again, with its sender found among `parties`; nil gives nil
+func DecodeMessage(enc *SnapshotMessage, parties SortedPartyIDs) (ParsedMessage, error) {
+	if enc == nil {
+		return nil, nil
+	}
+	from := parties.FindByKey(new(big.Int).SetBytes(enc.From))
+	if from == nil {
+		return nil, fmt.Errorf("the sender %x of a message of the snapshot is not one of the parties", enc.From)
+	}
+	return ParseWireMessage(enc.WireBytes, from, enc.IsBroadcast)
+}
+
+// EncodeMessages returns the messages in the form of a snapshot, keeping the nil entries of a message store
+func EncodeMessages(msgs []ParsedMessage) ([]*SnapshotMessage, error) {
+	encs := make([]*SnapshotMessage, len(msgs))
+	for j, msg := range msgs {
+		enc, err := EncodeMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		encs[j] = enc
+	}
+	return encs, nil
+}
+
+// DecodeMessages parses the messages of a snapshot again, see DecodeMessage
+func DecodeMessages(encs []*SnapshotMessage, parties SortedPartyIDs) ([]ParsedMessage, error) {
+	msgs := make([]ParsedMessage, len(encs))
+	for j, enc := range encs {
+		msg, err := DecodeMessage(enc, parties)
+		if err != nil {
+			return nil, err
+		}
+		msgs[j] = msg
+	}
+	return msgs, nil
+}
@@ -0,0 +1,36 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestSnapshotUnsupported(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	out, end := fakeChannels(len(pIDs))
+	party := newFakeParties(newFakeParams(t, pIDs), out, end)[0]
+	assert.Nil(t, party.Start(context.Background()))
+
+	// the fake party does not implement StatefulParty, like the signing parties
+	snapshot, err := tss.SnapshotParty(party)
+	assert.Nil(t, snapshot)
+	assert.True(t, errors.Is(err, tss.ErrSnapshotUnsupported))
+	assert.Equal(t, tss.CodeUnsupported, tss.CodeOf(err))
+
+	tErr := tss.RestoreParty(context.Background(), newFakeParties(newFakeParams(t, pIDs), out, end)[0], []byte("{}"))
+	if assert.NotNil(t, tErr) {
+		assert.Equal(t, tss.CodeUnsupported, tErr.Code())
+		assert.True(t, errors.Is(tErr, tss.ErrSnapshotUnsupported))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"context"
+	"errors"
+)
+
+// the most rounds of a protocol of this library, with room to spare; each round of a party sends at most two
+// messages to each of the other parties
+const stepMaxRounds = 16
+
+// Stepper drives a party from the caller's own loop, e.g. in an event loop, a mobile bridge, WASM or an actor,
+// without reading channels or running goroutines for it. Each call to Step hands the party the messages that arrived
+// since the last one and returns the messages that the party sent in the meantime.
+//
+// The parties of ECDSA signing that are pipelined compute in goroutines of their own, which the rounds then wait for;
+// the other parties do their work within Step.
+type Stepper struct {
+	party   Party
+	out     chan Message
+	started bool
+}
+
+// NewStepChannel returns a channel to pass as the `out` channel of the constructor of a party that is driven by a
+// Stepper. It is buffered to hold every message that the party may send in a ceremony of `partyCount` parties, so that
+// the party never waits on it; for re-sharing, `partyCount` is the number of parties of both committees.
+func NewStepChannel(partyCount int) chan Message {
+	return make(chan Message, 2*stepMaxRounds*partyCount)
+}
+
+// NewStepper returns a Stepper of `party`, which must have been made with `out` from NewStepChannel as its `out`
+// channel. The `end` channel given to the constructor must have room for the result, as Step does not read it; it holds
+// the result once Step has reported that the party is done.
+func NewStepper(party Party, out chan Message) *Stepper {
+	return &Stepper{party: party, out: out}
+}
+
+// Step starts the party on the first call, then updates it with each of `incoming` in order, as Update does. It
+// returns the messages that the party sent, to deliver to their recipients by their routing (see Deliver), and whether
+// the party has finished. The messages sent before an error are returned with it.
+func (s *Stepper) Step(ctx context.Context, incoming []ParsedMessage) (outgoing []Message, done bool, err *Error) {
+	if s.party == nil || s.out == nil {
+		return nil, false, NewError(errors.New("the stepper has no party or out channel; use NewStepper"), "", -1, nil).WithCode(CodeBadInput)
+	}
+	if !s.started {
+		if err := s.party.Start(ctx); err != nil {
+			return s.drain(), false, err
+		}
+		s.started = true
+	}
+	for _, msg := range incoming {
+		if _, err := s.party.Update(ctx, msg); err != nil {
+			return s.drain(), false, err
+		}
+	}
+	return s.drain(), s.Done(), nil
+}
+
+// Done returns whether the party has finished, so that its result is in its `end` channel. The last round of a
+// protocol does all of its work in Start, so the party has finished once it has started that round.
+func (s *Stepper) Done() bool {
+	if !s.started {
+		return false
+	}
+	progress := s.party.Progress()
+	return !s.party.Running() || (0 < progress.Round && progress.Round == progress.TotalRounds)
+}
+
+// Party returns the party driven by the stepper, e.g. to call WaitingFor between two steps, or SnapshotParty on a
+// keygen party
+func (s *Stepper) Party() Party {
+	return s.party
+}
+
+// drain returns the messages that the party has sent so far
+func (s *Stepper) drain() []Message {
+	var msgs []Message
+	for {
+		select {
+		case msg := <-s.out:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// TestStepper runs a ceremony with every party driven by a tss.Stepper from this goroutine alone
+func TestStepper(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(4)
+	steppers := make([]*tss.Stepper, 0, len(pIDs))
+	ends := make([]chan int, 0, len(pIDs))
+	for _, params := range newFakeParams(t, pIDs) {
+		out, end := tss.NewStepChannel(len(pIDs)), make(chan int, 1)
+		steppers = append(steppers, tss.NewStepper(newFakeParty(params, out, end), out))
+		ends = append(ends, end)
+	}
+	assert.False(t, steppers[0].Done(), "a party must not be done before it has started")
+
+	inboxes := make([][]tss.ParsedMessage, len(pIDs))
+	route := func(msgs []tss.Message) {
+		for _, msg := range msgs {
+			bz, _, err := msg.WireBytes()
+			assert.NoError(t, err)
+			pMsg, err := tss.ParseWireMessage(bz, msg.GetFrom(), msg.IsBroadcast())
+			assert.NoError(t, err)
+			for j, Pj := range pIDs {
+				if Pj.Index == msg.GetFrom().Index {
+					continue
+				}
+				if to := msg.GetTo(); to == nil || to[0].Index == Pj.Index {
+					inboxes[j] = append(inboxes[j], pMsg)
+				}
+			}
+		}
+	}
+	for steps := 0; ; steps++ {
+		if steps == 10 {
+			t.Fatal("the ceremony must finish")
+		}
+		finished := 0
+		for i, s := range steppers {
+			incoming := inboxes[i]
+			inboxes[i] = nil
+			outgoing, done, err := s.Step(context.Background(), incoming)
+			if !assert.Nil(t, err) {
+				return
+			}
+			route(outgoing)
+			if done {
+				finished++
+			}
+		}
+		if finished == len(steppers) {
+			break
+		}
+	}
+	for _, end := range ends {
+		assert.Equal(t, 2*(len(pIDs)-1), <-end, "each party must have received the messages of its peers")
+	}
+
+	_, _, err := new(tss.Stepper).Step(context.Background(), nil)
+	assert.Equal(t, tss.CodeBadInput, tss.CodeOf(err), "a stepper without a party must be rejected")
+}
@@ -0,0 +1,69 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"context"
+)
+
+type (
+	// Transport delivers the messages of a party to its peers, e.g. over the network stack of an application.
+	// Its methods may block until the message has been handed over; the party then waits for them.
+	Transport interface {
+		// Send delivers `msg` to each of the parties `to`
+		Send(msg Message, to ...*PartyID) error
+		// Broadcast delivers `msg` to all of the other parties
+		Broadcast(msg Message) error
+	}
+
+	// ChannelTransport is a Transport that passes every message to a channel with its routing, as the `out` channel
+	// of a party receives them, e.g. to keep using channels in tests of code written against Transport
+	ChannelTransport chan<- Message
+)
+
+var _ Transport = ChannelTransport(nil)
+
+// Deliver passes `msg` to `t` by its routing: a message without recipients is broadcast, and any other is sent to its
+// recipients. During re-sharing a message to a committee lists its members and reports IsBroadcast, so that the
+// transport can use a reliable broadcast among them.
+func Deliver(t Transport, msg Message) error {
+	if to := msg.GetTo(); to != nil {
+		return t.Send(msg, to...)
+	}
+	return t.Broadcast(msg)
+}
+
+// NewTransportChannel returns a channel to pass as the `out` channel of a party's constructor, which delivers every
+// message sent to it through `t`. The channel is unbuffered and each message is delivered before the next is taken, so
+// a party that sends faster than `t` delivers is held back at its next send. The errors of `t` are reported to
+// `onError`, which may be nil. Delivery stops once `ctx` is done; a party must not send to the channel after that.
+func NewTransportChannel(ctx context.Context, t Transport, onError func(Message, error)) chan<- Message {
+	out := make(chan Message)
+	go func() {
+		for {
+			select {
+			case msg := <-out:
+				if err := Deliver(t, msg); err != nil && onError != nil {
+					onError(msg, err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (ch ChannelTransport) Send(msg Message, _ ...*PartyID) error {
+	ch <- msg
+	return nil
+}
+
+func (ch ChannelTransport) Broadcast(msg Message) error {
+	ch <- msg
+	return nil
+}
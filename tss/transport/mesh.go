@@ -0,0 +1,361 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package transport connects the parties of a ceremony with TLS 1.3 channels that are encrypted and authenticated at
+// both ends by the Ed25519 identity keys of the parties, for applications that have no secure network of their own.
+package transport
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	// MaxFrameSize is the largest message that a peer may send, which bounds the memory that it can make a party use
+	MaxFrameSize = 64 << 20
+
+	// the time that a connection accepted by Connect has to authenticate, so that one that stalls does not hold up the
+	// others
+	acceptHandshakeTimeout = 30 * time.Second
+
+	flagBroadcast = 1 << 0
+)
+
+type (
+	// Mesh is a tss.Transport with a channel to every peer of a party. Make it with NewMesh, connect it with Connect,
+	// pass tss.NewTransportChannel(ctx, mesh, onError) as the `out` channel of the party and run Serve with the party.
+	Mesh struct {
+		self     *tss.PartyID
+		parties  tss.SortedPartyIDs
+		identity *tss.Ed25519Identity
+		cert     tls.Certificate
+
+		mtx   sync.Mutex
+		conns map[string]*conn
+	}
+
+	// conn is a channel to one peer; writes are serialised by its mutex
+	conn struct {
+		peer *tss.PartyID
+		tls  *tls.Conn
+		r    *bufio.Reader
+		mtx  sync.Mutex
+	}
+)
+
+var _ tss.Transport = (*Mesh)(nil)
+
+// NewMesh returns a mesh of `self` with the other `parties`. The identity holds the key of `self` and the public key of
+// every peer by the Id of its PartyID, as for tss.Parameters.SetIdentity.
+func NewMesh(self *tss.PartyID, parties tss.SortedPartyIDs, identity *tss.Ed25519Identity) (*Mesh, error) {
+	if len(identity.Key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("the Ed25519 identity key must be %d bytes, got %d", ed25519.PrivateKeySize, len(identity.Key))
+	}
+	if parties.FindByKey(self.KeyInt()) == nil {
+		return nil, errors.New("NewMesh: this party is not one of the parties")
+	}
+	for _, Pj := range parties {
+		if _, ok := identity.PeerKeys[Pj.Id]; !ok && Pj.KeyInt().Cmp(self.KeyInt()) != 0 {
+			return nil, fmt.Errorf("NewMesh: there is no identity key of %s", Pj)
+		}
+	}
+	cert, err := selfSignedCert(identity.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &Mesh{
+		self:     self,
+		parties:  parties,
+		identity: identity,
+		cert:     cert,
+		conns:    make(map[string]*conn, len(parties)-1),
+	}, nil
+}
+
+// Connect connects the mesh to every peer. It dials the peers that come after this party in the parties, at their
+// address in `addrs` by the Id of their PartyID, and accepts the others on `l`, ignoring the connections that fail to
+// authenticate as one of them. It returns once every peer is connected, or with the first error; `l` may be closed
+// then, and must be to stop accepting after an error.
+func (m *Mesh) Connect(ctx context.Context, l net.Listener, addrs map[string]string) error {
+	self := m.parties.FindByKey(m.self.KeyInt()).Index
+	errCh := make(chan error, len(m.parties))
+	go func() {
+		// the handshakes are counted here, so that Accept is not called again once every earlier peer is connected
+		for accepted := 0; accepted < self && ctx.Err() == nil; {
+			raw, err := l.Accept()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			hsCtx, cancel := context.WithTimeout(ctx, acceptHandshakeTimeout)
+			err = m.handshake(hsCtx, tls.Server(raw, m.tlsConfig(nil)), self)
+			cancel()
+			if err != nil {
+				continue
+			}
+			accepted++
+			errCh <- nil
+		}
+	}()
+	for _, Pj := range m.parties[self+1:] {
+		addr, ok := addrs[Pj.Id]
+		if !ok {
+			return fmt.Errorf("Connect: there is no address of %s", Pj)
+		}
+		go func(Pj *tss.PartyID) {
+			var dialer net.Dialer
+			raw, err := dialer.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			errCh <- m.handshake(ctx, tls.Client(raw, m.tlsConfig(Pj)), -1)
+		}(Pj)
+	}
+	for connected := 0; connected < len(m.parties)-1; connected++ {
+		select {
+		case err := <-errCh:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Serve passes the messages received from every peer to `party` until `ctx` is done or the channels are closed. The
+// errors of the party and of the channels are reported to `onError`, which may be nil.
+func (m *Mesh) Serve(ctx context.Context, party tss.Party, onError func(*tss.PartyID, error)) {
+	m.mtx.Lock()
+	conns := make([]*conn, 0, len(m.conns))
+	for _, c := range m.conns {
+		conns = append(conns, c)
+	}
+	m.mtx.Unlock()
+
+	wg := new(sync.WaitGroup)
+	wg.Add(len(conns))
+	for _, c := range conns {
+		go func(c *conn) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				wireBytes, isBroadcast, err := c.read()
+				if err != nil {
+					if err != io.EOF && ctx.Err() == nil && onError != nil {
+						onError(c.peer, err)
+					}
+					return
+				}
+				if _, err := party.UpdateFromBytes(ctx, wireBytes, c.peer, isBroadcast); err != nil && onError != nil {
+					onError(c.peer, err)
+				}
+			}
+		}(c)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = m.Close()
+		case <-done:
+		}
+	}()
+	wg.Wait()
+}
+
+// Send delivers `msg` to each of the parties `to` over their channels
+func (m *Mesh) Send(msg tss.Message, to ...*tss.PartyID) error {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	for _, Pj := range to {
+		c, err := m.conn(Pj)
+		if err != nil {
+			return err
+		}
+		if err = c.write(wireBytes, routing.IsBroadcast); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Broadcast delivers `msg` to every peer over their channels. The peers do not check that they received the same
+// message, so a reliable broadcast still has to be built on top of it where a protocol needs one.
+func (m *Mesh) Broadcast(msg tss.Message) error {
+	wireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	for _, Pj := range m.parties {
+		if Pj.KeyInt().Cmp(m.self.KeyInt()) == 0 {
+			continue
+		}
+		c, err := m.conn(Pj)
+		if err != nil {
+			return err
+		}
+		if err = c.write(wireBytes, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the channels to every peer
+func (m *Mesh) Close() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var first error
+	for id, c := range m.conns {
+		if err := c.tls.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(m.conns, id)
+	}
+	return first
+}
+
+// ----- //
+
+// tlsConfig returns the configuration of a channel to `peer`, or to any of the peers if it is nil. The certificates
+// are self-signed, so a peer is authenticated by the key of its certificate rather than by a chain.
+func (m *Mesh) tlsConfig(peer *tss.PartyID) *tls.Config {
+	return &tls.Config{
+		Certificates:       []tls.Certificate{m.cert},
+		MinVersion:         tls.VersionTLS13,
+		ClientAuth:         tls.RequireAnyClientCert,
+		InsecureSkipVerify: true, // the peer is verified by VerifyPeerCertificate
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			Pj, err := m.peerOf(rawCerts)
+			if err != nil {
+				return err
+			}
+			if peer != nil && Pj.KeyInt().Cmp(peer.KeyInt()) != 0 {
+				return fmt.Errorf("the peer is %s, expected %s", Pj, peer)
+			}
+			return nil
+		},
+	}
+}
+
+// peerOf returns the party whose identity key is the key of the certificate of a peer
+func (m *Mesh) peerOf(rawCerts [][]byte) (*tss.PartyID, error) {
+	if len(rawCerts) != 1 {
+		return nil, fmt.Errorf("expected the one certificate of the peer, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return nil, err
+	}
+	key, ok := cert.PublicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("the certificate of the peer does not have an Ed25519 key")
+	}
+	for _, Pj := range m.parties {
+		if peerKey, ok := m.identity.PeerKeys[Pj.Id]; ok && Pj.KeyInt().Cmp(m.self.KeyInt()) != 0 && key.Equal(peerKey) {
+			return Pj, nil
+		}
+	}
+	return nil, errors.New("the key of the peer is not the identity key of one of the parties")
+}
+
+// handshake completes the handshake of a channel and adds it to the mesh. An accepted channel must be from a peer
+// that comes before `before` in the parties, as the others are dialed; it is -1 for a dialed channel.
+func (m *Mesh) handshake(ctx context.Context, tlsConn *tls.Conn, before int) error {
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = tlsConn.Close()
+		return err
+	}
+	peer, err := m.peerOf([][]byte{tlsConn.ConnectionState().PeerCertificates[0].Raw})
+	if err == nil && 0 <= before && before <= peer.Index {
+		err = fmt.Errorf("%s connected, but it is dialed by this party", peer)
+	}
+	if err != nil {
+		_ = tlsConn.Close()
+		return err
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.conns[peer.Id]; ok {
+		_ = tlsConn.Close()
+		return fmt.Errorf("%s is already connected", peer)
+	}
+	m.conns[peer.Id] = &conn{peer: peer, tls: tlsConn, r: bufio.NewReader(tlsConn)}
+	return nil
+}
+
+func (m *Mesh) conn(peer *tss.PartyID) (*conn, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	c, ok := m.conns[peer.Id]
+	if !ok {
+		return nil, fmt.Errorf("%s is not connected", peer)
+	}
+	return c, nil
+}
+
+// write sends a frame: the length of the rest of it, the flags and the wire bytes
+func (c *conn) write(wireBytes []byte, isBroadcast bool) error {
+	frame := make([]byte, 5, 5+len(wireBytes))
+	binary.BigEndian.PutUint32(frame, uint32(1+len(wireBytes)))
+	if isBroadcast {
+		frame[4] = flagBroadcast
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, err := c.tls.Write(append(frame, wireBytes...))
+	return err
+}
+
+// read receives a frame; it is only called by the goroutine of Serve that reads from this channel
+func (c *conn) read() ([]byte, bool, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return nil, false, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size < 1 || MaxFrameSize < size {
+		return nil, false, fmt.Errorf("a frame from %s has a size of %d bytes, out of [1, %d]", c.peer, size, MaxFrameSize)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(c.r, frame); err != nil {
+		return nil, false, err
+	}
+	return frame[1:], frame[0]&flagBroadcast != 0, nil
+}
+
+// selfSignedCert returns a certificate of the identity key, signed by itself
+func selfSignedCert(key ed25519.PrivateKey) (tls.Certificate, error) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(100 * 365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,160 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/bls/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/binance-chain/tss-lib/tss/transport"
+)
+
+// setUpMeshes returns a mesh of each party, with a listener on the loopback address and its address by the Id of
+// the party
+func setUpMeshes(t *testing.T, pIDs tss.SortedPartyIDs) ([]*transport.Mesh, []net.Listener, map[string]string) {
+	keys := make([]ed25519.PrivateKey, len(pIDs))
+	peerKeys := make(map[string]ed25519.PublicKey, len(pIDs))
+	for i, Pi := range pIDs {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i], peerKeys[Pi.Id] = priv, pub
+	}
+	meshes := make([]*transport.Mesh, len(pIDs))
+	listeners := make([]net.Listener, len(pIDs))
+	addrs := make(map[string]string, len(pIDs))
+	for i, Pi := range pIDs {
+		mesh, err := transport.NewMesh(Pi, pIDs, &tss.Ed25519Identity{Key: keys[i], PeerKeys: peerKeys})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		meshes[i], listeners[i], addrs[Pi.Id] = mesh, l, l.Addr().String()
+	}
+	return meshes, listeners, addrs
+}
+
+func TestMeshKeygen(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(keygen.TestParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	meshes, listeners, addrs := setUpMeshes(t, pIDs)
+	errCh := make(chan error, len(pIDs))
+	for i := range pIDs {
+		go func(i int) { errCh <- meshes[i].Connect(ctx, listeners[i], addrs) }(i)
+	}
+	for range pIDs {
+		if err := <-errCh; !assert.NoError(t, err) {
+			return
+		}
+	}
+	for i := range pIDs {
+		_ = listeners[i].Close()
+	}
+
+	onError := func(party *tss.PartyID, err error) { errCh <- err }
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+	for i := range pIDs {
+		params, err := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), keygen.TestThreshold)
+		assert.NoError(t, err)
+		out := tss.NewTransportChannel(ctx, meshes[i], func(msg tss.Message, err error) { errCh <- err })
+		P := keygen.NewLocalParty(params, out, endCh)
+		go meshes[i].Serve(ctx, P, onError)
+		go func(P tss.Party) {
+			if err := P.Start(ctx); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]keygen.LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	for _, save := range saves {
+		assert.True(t, saves[0].PubKey.Equals(save.PubKey), "the public keys must match")
+	}
+}
+
+func TestMeshRejectsUnknownKey(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	meshes, listeners, addrs := setUpMeshes(t, pIDs)
+	// the second party has a key that the first does not know
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meshes[1], err = transport.NewMesh(pIDs[1], pIDs, &tss.Ed25519Identity{Key: other, PeerKeys: map[string]ed25519.PublicKey{
+		pIDs[0].Id: nil,
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	go func() { _ = meshes[1].Connect(ctx, listeners[1], addrs) }()
+	err = meshes[0].Connect(ctx, listeners[0], addrs)
+	assert.Error(t, err, "a peer with an unknown key must be rejected")
+}
+
+// acceptCounter is a listener that counts the calls of Accept that have not returned
+type acceptCounter struct {
+	net.Listener
+	pending int32
+}
+
+func (l *acceptCounter) Accept() (net.Conn, error) {
+	atomic.AddInt32(&l.pending, 1)
+	defer atomic.AddInt32(&l.pending, -1)
+	return l.Listener.Accept()
+}
+
+func TestMeshConnectStopsAccepting(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	meshes, listeners, addrs := setUpMeshes(t, pIDs)
+	counters := make([]*acceptCounter, len(pIDs))
+	errCh := make(chan error, len(pIDs))
+	for i := range pIDs {
+		counters[i] = &acceptCounter{Listener: listeners[i]}
+		go func(i int) { errCh <- meshes[i].Connect(ctx, counters[i], addrs) }(i)
+	}
+	for range pIDs {
+		if err := <-errCh; !assert.NoError(t, err) {
+			return
+		}
+	}
+	// once every peer is connected, no party waits for another connection
+	time.Sleep(100 * time.Millisecond)
+	for i := range pIDs {
+		assert.Zero(t, atomic.LoadInt32(&counters[i].pending), "party %d should not accept once every peer is connected", i)
+		_ = listeners[i].Close()
+	}
+}
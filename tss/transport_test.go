@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// partyTransport updates the parties with the messages that it delivers, each from a goroutine of its own, and
+// counts the calls of each method
+type partyTransport struct {
+	mtx        sync.Mutex
+	parties    []*fakeParty
+	errCh      chan<- *tss.Error
+	sends      int
+	broadcasts int
+}
+
+func (tr *partyTransport) Send(msg tss.Message, to ...*tss.PartyID) error {
+	tr.mtx.Lock()
+	tr.sends++
+	tr.mtx.Unlock()
+	for _, Pj := range to {
+		tr.deliver(tr.parties[Pj.Index], msg)
+	}
+	return nil
+}
+
+func (tr *partyTransport) Broadcast(msg tss.Message) error {
+	tr.mtx.Lock()
+	tr.broadcasts++
+	tr.mtx.Unlock()
+	for _, P := range tr.parties {
+		if P.PartyID().Index != msg.GetFrom().Index {
+			tr.deliver(P, msg)
+		}
+	}
+	return nil
+}
+
+func (tr *partyTransport) deliver(P *fakeParty, msg tss.Message) {
+	go func() {
+		if err := deliverWireBytes(P, msg); err != nil {
+			tr.errCh <- err
+		}
+	}()
+}
+
+func TestNewTransportChannel(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	_, end := fakeChannels(len(pIDs))
+	transport := &partyTransport{errCh: errCh}
+	out := tss.NewTransportChannel(ctx, transport, func(msg tss.Message, err error) {
+		t.Errorf("delivering %s: %v", msg, err)
+	})
+	transport.parties = newFakeParties(newFakeParams(t, pIDs), out, end)
+	for _, P := range transport.parties {
+		go func(P *fakeParty) {
+			if err := P.Start(ctx); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case received := <-end:
+			assert.Equal(t, 2*(len(pIDs)-1), received)
+			ended++
+		}
+	}
+	transport.mtx.Lock()
+	defer transport.mtx.Unlock()
+	assert.Equal(t, len(pIDs), transport.broadcasts, "each party must broadcast once")
+	assert.Equal(t, len(pIDs)*(len(pIDs)-1), transport.sends, "each party must send once to each peer")
+}
+
+func TestDeliver(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	ch := make(chan tss.Message, 2)
+	for _, msg := range []tss.Message{newFakeMessage(pIDs[0], 1, nil), newFakeShare(pIDs[0], pIDs[1])} {
+		assert.NoError(t, tss.Deliver(tss.ChannelTransport(ch), msg))
+		assert.Equal(t, msg, <-ch, "a channel transport must pass on each message")
+	}
+}
@@ -7,7 +7,9 @@
 package tss
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -15,20 +17,91 @@ import (
 )
 
 const (
-	ECDSAProtoNamePrefix = "binance.tss-lib.ecdsa."
-	EDDSAProtoNamePrefix = "binance.tss-lib.eddsa."
+	ECDSAProtoNamePrefix    = "binance.tss-lib.ecdsa."
+	EDDSAProtoNamePrefix    = "binance.tss-lib.eddsa."
+	BIP340ProtoNamePrefix   = "binance.tss-lib.bip340."
+	SR25519ProtoNamePrefix  = "binance.tss-lib.sr25519."
+	FROSTProtoNamePrefix    = "binance.tss-lib.frost."
+	CGGMPProtoNamePrefix    = "binance.tss-lib.cggmp."
+	BLSProtoNamePrefix      = "binance.tss-lib.bls."
+	PaillierProtoNamePrefix = "binance.tss-lib.paillier."
+	LindellProtoNamePrefix  = "binance.tss-lib.lindell."
+	ElGamalProtoNamePrefix  = "binance.tss-lib.elgamal."
+	VRFProtoNamePrefix      = "binance.tss-lib.vrf."
+	SessionProtoNamePrefix  = "binance.tss-lib.session."
+	RSAProtoNamePrefix      = "binance.tss-lib.rsa."
 )
 
+// The wire bytes of a message are wireMagic, the version of their format and the WireMessage with the session ID of the
+// sender. No protobuf encoding starts with a zero byte, the tag of the invalid field 0, so the wire bytes of versions
+// before the session ID, which are the Any of the content alone, are told apart from them and still parsed.
+const (
+	wireVersion = 1
+)
+
+var (
+	wireMagic = []byte{0x00, 'T', 'S', 'S'}
+)
+
+// encodeWireMessage returns the wire bytes of the content `message` sent in the session `sessionID`
+func encodeWireMessage(sessionID []byte, message *any.Any) ([]byte, error) {
+	bz, err := proto.Marshal(&WireMessage{SessionId: sessionID, Message: message})
+	if err != nil {
+		return nil, err
+	}
+	return append(append(append([]byte(nil), wireMagic...), wireVersion), bz...), nil
+}
+
+// decodeWireMessage returns the WireMessage of `wireBytes`, which has no session ID if they are of an earlier version
+func decodeWireMessage(wireBytes []byte) (*WireMessage, error) {
+	wireMsg := new(WireMessage)
+	if !bytes.HasPrefix(wireBytes, wireMagic) {
+		wireMsg.Message = new(any.Any)
+		if err := proto.Unmarshal(wireBytes, wireMsg.Message); err != nil {
+			return nil, &codedError{error: err, code: CodeInvalidMessage}
+		}
+		return wireMsg, nil
+	}
+	if version := wireBytes[len(wireMagic):]; len(version) == 0 || version[0] != wireVersion {
+		return nil, &codedError{error: fmt.Errorf("ParseWireMessage: the wire format version is not %d", wireVersion), code: CodeInvalidMessage}
+	}
+	if err := proto.Unmarshal(wireBytes[len(wireMagic)+1:], wireMsg); err != nil {
+		return nil, &codedError{error: err, code: CodeInvalidMessage}
+	}
+	return wireMsg, nil
+}
+
 // Used externally to update a LocalParty with a valid ParsedMessage
 func ParseWireMessage(wireBytes []byte, from *PartyID, isBroadcast bool) (ParsedMessage, error) {
+	wireMsg, err := decodeWireMessage(wireBytes)
+	if err != nil {
+		return nil, err
+	}
+	if wireMsg.Message == nil {
+		return nil, &codedError{error: errors.New("ParseWireMessage: the message had no content"), code: CodeInvalidMessage}
+	}
 	wire := new(MessageWrapper)
-	wire.Message = new(any.Any)
+	wire.Message = wireMsg.Message
 	wire.From = from.MessageWrapper_PartyID
 	wire.IsBroadcast = isBroadcast
-	if err := proto.Unmarshal(wireBytes, wire.Message); err != nil {
+	wire.SessionId = wireMsg.SessionId
+	return parseWrappedMessage(wire, from)
+}
+
+// WireSessionID returns the session ID in the header of what a party received: the wire bytes of a message or a
+// SignedEnvelope of them, e.g. to route it to the party of its session. Nothing is verified, which the party does once
+// it is given the message. The wire bytes of earlier versions have no session ID.
+func WireSessionID(bz []byte) ([]byte, error) {
+	if !bytes.HasPrefix(bz, wireMagic) {
+		if _, body, err := unmarshalEnvelope(bz); err == nil && bytes.HasPrefix(body.WireBytes, wireMagic) {
+			bz = body.WireBytes
+		}
+	}
+	wireMsg, err := decodeWireMessage(bz)
+	if err != nil {
 		return nil, err
 	}
-	return parseWrappedMessage(wire, from)
+	return wireMsg.SessionId, nil
 }
 
 func parseWrappedMessage(wire *MessageWrapper, from *PartyID) (ParsedMessage, error) {
@@ -36,12 +109,13 @@ func parseWrappedMessage(wire *MessageWrapper, from *PartyID) (ParsedMessage, er
 	meta := MessageRouting{
 		From:        from,
 		IsBroadcast: wire.IsBroadcast,
+		SessionID:   wire.SessionId,
 	}
 	if err := ptypes.UnmarshalAny(wire.Message, &any); err != nil {
-		return nil, err
+		return nil, &codedError{error: err, code: CodeInvalidMessage}
 	}
 	if content, ok := any.Message.(MessageContent); ok {
 		return NewMessage(meta, content, wire), nil
 	}
-	return nil, errors.New("ParseWireMessage: the message contained unknown content")
+	return nil, &codedError{error: errors.New("ParseWireMessage: the message contained unknown content"), code: CodeInvalidMessage}
 }
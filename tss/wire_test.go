@@ -0,0 +1,82 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestWireMessageSessionID(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	params := newIdentityParams(t, pIDs)
+	sessionID := []byte("session-a")
+
+	meta := tss.MessageRouting{From: pIDs[0], IsBroadcast: true, SessionID: sessionID}
+	content := &fakeMessage{Round: 1, Payload: []byte("hello")}
+	msg := tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	wireBytes, _, err := msg.WireBytes()
+	assert.NoError(t, err)
+
+	parsed, err := tss.ParseWireMessage(wireBytes, pIDs[0], true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, sessionID, parsed.GetSessionID())
+		assert.Equal(t, []byte("hello"), parsed.Content().(*fakeMessage).Payload)
+	}
+	got, err := tss.WireSessionID(wireBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, got)
+
+	envelope, err := params[0].SealMessage(msg)
+	assert.NoError(t, err)
+	got, err = tss.WireSessionID(envelope)
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, got, "the session ID of an envelope must be read from its wire bytes")
+}
+
+func TestWireMessageLegacy(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+
+	// the wire bytes before the session ID were the Any of the content alone
+	any, err := ptypes.MarshalAny(&fakeMessage{Round: 1, Payload: []byte("hello")})
+	assert.NoError(t, err)
+	legacy, err := proto.Marshal(any)
+	assert.NoError(t, err)
+
+	parsed, err := tss.ParseWireMessage(legacy, pIDs[0], true)
+	if assert.NoError(t, err) {
+		assert.Nil(t, parsed.GetSessionID())
+		assert.Equal(t, []byte("hello"), parsed.Content().(*fakeMessage).Payload)
+	}
+	got, err := tss.WireSessionID(legacy)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestWireMessageUnknownVersion(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+
+	meta := tss.MessageRouting{From: pIDs[0], IsBroadcast: true, SessionID: []byte("session-a")}
+	content := &fakeMessage{Round: 1, Payload: []byte("hello")}
+	wireBytes, _, err := tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)).WireBytes()
+	assert.NoError(t, err)
+
+	// the version follows the 4 bytes of the magic prefix
+	future := append([]byte(nil), wireBytes...)
+	future[4]++
+	_, err = tss.ParseWireMessage(future, pIDs[0], true)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err))
+	_, err = tss.WireSessionID(future)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err))
+	_, err = tss.ParseWireMessage(wireBytes[:4], pIDs[0], true)
+	assert.Equal(t, tss.CodeInvalidMessage, tss.CodeOf(err), "the wire bytes must have a version")
+}
@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/vrf"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	N := round.EC().Params().N
+	modN := common.ModInt(N)
+	H, c := round.temp.H, round.temp.c
+
+	// 1. check each sj: sj*G == Uj + c*Wj and sj*H == Vj + c*Gamma_j
+	s := big.NewInt(0)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		sj := round.temp.sis[j]
+		if j != round.PartyID().Index {
+			r3msg := round.temp.evalRound3Messages[j].Content().(*EvalRound3Message)
+			sj = r3msg.UnmarshalS()
+			if sj.Cmp(N) >= 0 || !verifyShare(sj, c, H, round.temp.bigWs[j], round.temp.gammais[j], round.temp.bigUs[j], round.temp.bigVs[j]) {
+				culprits = append(culprits, Pj)
+				continue
+			}
+		}
+		s = modN.Add(s, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the share s_j"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. the proof (Gamma, c, s) verifies under the public key like a single-key VRF proof
+	proof := &vrf.Proof{Gamma: round.temp.gamma, C: c, S: s}
+	if _, ok := vrf.Verify(round.key.ECDSAPub, round.temp.alpha, proof); !ok {
+		return round.WrapError(errors.New("VRF proof verification failed")).WithCode(tss.CodeInvalidResult)
+	}
+	round.end <- proof
+
+	return nil
+}
+
+func verifyShare(sj, c *big.Int, H, Wj, gammaj, Uj, Vj *crypto.ECPoint) bool {
+	UcW, err := Uj.Add(Wj.ScalarMult(c))
+	if err != nil || !crypto.ScalarBaseMult(Wj.Curve(), sj).Equals(UcW) {
+		return false
+	}
+	VcGamma, err := Vj.Add(gammaj.ScalarMult(c))
+	return err == nil && H.ScalarMult(sj).Equals(VcGamma)
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update(ctx context.Context) (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package evaluation
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/vrf"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/test"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	alpha := []byte("round 42")
+
+	// any two sets of t+1 parties evaluate to the same output
+	beta1 := evaluate(t, alpha)
+	beta2 := evaluate(t, alpha)
+	assert.Equal(t, beta1, beta2, "the VRF output must not depend on the set of parties")
+}
+
+func evaluate(t *testing.T, alpha []byte) []byte {
+	threshold := testThreshold
+
+	// any t+1 of the ECDSA keygen fixtures
+	keys, evalPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(evalPIDs)
+	parties := make([]*LocalParty, 0, len(evalPIDs))
+
+	errCh := make(chan *tss.Error, len(evalPIDs))
+	outCh := make(chan tss.Message, len(evalPIDs))
+	endCh := make(chan *vrf.Proof, len(evalPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(evalPIDs); i++ {
+		params, err := tss.NewParameters(p2pCtx, evalPIDs[i], len(evalPIDs), threshold)
+		assert.NoError(t, err)
+
+		P := NewLocalParty(alpha, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(context.Background()); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var beta []byte
+	var ended int32
+	for {
+		select {
+		case err := <-errCh:
+			common.DefaultLogger().Error("error", "err", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case proof := <-endCh:
+			betaj, ok := vrf.Verify(keys[0].ECDSAPub, alpha, proof)
+			assert.True(t, ok, "the proof must verify under the ECDSA public key")
+			if beta != nil {
+				assert.Equal(t, beta, betaj, "all parties must output the same value")
+			}
+			beta = betaj
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(evalPIDs)) {
+				t.Logf("Done. Received the proof from %d participants", ended)
+				return beta
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/crypto/vrf"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+var (
+	zero = big.NewInt(0)
+)
+
+// round 1 represents round 1 of the threshold VRF evaluation
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *vrf.Proof) tss.Round {
+	return &round1{
+		&base{params, key, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+	if err := round.CheckCurveName(round.key.CurveName); err != nil {
+		return round.WrapError(err).WithCode(tss.CodeBadInput)
+	}
+
+	if round.Threshold()+1 > len(round.key.Ks) {
+		return round.WrapError(errors.New("t+1 parties are required to evaluate the VRF")).WithCode(tss.CodeBadInput)
+	}
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. the additive share wi of the private key and the public shares Wj = wj*G of the other parties
+	wi, bigWs := signing.PrepareForSigning(round.EC(), i, len(round.key.Ks), round.key.Xi, round.key.Ks, round.key.BigXj)
+	round.temp.wi, round.temp.bigWs = wi, bigWs
+
+	// 2. the share Gamma_i = wi*H and the proof that it was made with the same wi as Wi
+	H := vrf.HashToCurve(round.key.ECDSAPub, round.temp.alpha)
+	gammai := H.ScalarMult(wi)
+	proof, err := schnorr.NewZKDLEQProofInTranscript(round.proofTranscript(1, Pi), bigWs[i], gammai, H, wi)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NewZKDLEQProofInTranscript(Gamma_i)"), Pi)
+	}
+	round.temp.H, round.temp.gammais[i] = H, gammai
+
+	// 3. commit to the nonce shares Ui = ki*G and Vi = ki*H
+	ki := common.GetRandomPositiveInt(round.EC().Params().N)
+	Ui, Vi := crypto.ScalarBaseMult(round.EC(), ki), H.ScalarMult(ki)
+	cmt := cmts.NewHashCommitmentInSession(round.Params().CommitmentHash(), round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID(), Ui.X(), Ui.Y(), Vi.X(), Vi.Y())
+	round.temp.ki, round.temp.bigUs[i], round.temp.bigVs[i] = ki, Ui, Vi
+	round.temp.deCommit = cmt.D
+
+	round.ok[i] = true
+
+	// 4. BROADCAST the commitment and Gamma_i
+	r1msg := NewEvalRound1Message(Pi, cmt.C, gammai, proof)
+	round.temp.evalRound1Messages[i] = r1msg
+	round.out <- round.WithSessionID(r1msg)
+
+	return nil
+}
+
+func (round *round1) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.evalRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EvalRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package evaluation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round2) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. check each Gamma_j against the public share Wj
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.evalRound1Messages[j].Content().(*EvalRound1Message)
+		gammaj, err := r1msg.UnmarshalGammaShare(round.EC())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r1msg.UnmarshalProof(round.EC())
+		if err != nil || !proof.VerifyInTranscript(round.proofTranscript(1, Pj), round.temp.bigWs[j], gammaj, round.temp.H) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.gammais[j] = gammaj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the share Gamma_j"), culprits...).WithCode(tss.CodeInvalidShare)
+	}
+
+	// 2. BROADCAST the de-commitment of Ui and Vi
+	r2msg := NewEvalRound2Message(Pi, round.temp.deCommit)
+	round.temp.evalRound2Messages[i] = r2msg
+	round.out <- round.WithSessionID(r2msg)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EvalRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.evalRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
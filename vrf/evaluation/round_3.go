@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmts "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/vrf"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func (round *round3) Start(ctx context.Context) *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started")).WithCode(tss.CodeUnexpectedState)
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. de-commit each Uj and Vj
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.evalRound1Messages[j].Content().(*EvalRound1Message)
+		r2msg := round.temp.evalRound2Messages[j].Content().(*EvalRound2Message)
+		cmtDeCmt := cmts.HashCommitDecommit{C: r1msg.UnmarshalCommitment(), D: r2msg.UnmarshalDeCommitment()}
+		ok, flat := cmtDeCmt.DeCommitInSession(round.Params().CommitmentFraming(), commitmentDomain, round.Params().SessionID())
+		if !ok || len(flat) != 4 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		points, err := crypto.UnFlattenECPoints(round.EC(), flat)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.bigUs[j], round.temp.bigVs[j] = points[0], points[1]
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("de-commitment verify failed"), culprits...).WithCode(tss.CodeDecommitMismatch)
+	}
+
+	// 2. Gamma, U and V are the sums of the shares, and c = H(Y, H, Gamma, U, V)
+	gamma, U, V := round.temp.gammais[0], round.temp.bigUs[0], round.temp.bigVs[0]
+	for j := 1; j < len(round.Parties().IDs()); j++ {
+		var err error
+		if gamma, err = gamma.Add(round.temp.gammais[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "gamma.Add(Gamma_j)"))
+		}
+		if U, err = U.Add(round.temp.bigUs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "U.Add(Uj)"))
+		}
+		if V, err = V.Add(round.temp.bigVs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "V.Add(Vj)"))
+		}
+	}
+	round.temp.gamma = gamma
+	round.temp.c = vrf.Challenge(round.key.ECDSAPub, round.temp.H, gamma, U, V)
+
+	// 3. BROADCAST si = ki + c*wi
+	si := common.ModInt(round.EC().Params().N).Add(round.temp.ki, new(big.Int).Mul(round.temp.c, round.temp.wi))
+	round.temp.sis[i] = si
+
+	// clear temp.ki and temp.wi from memory
+	round.temp.ki, round.temp.wi = zero, zero
+
+	r3msg := NewEvalRound3Message(Pi, si)
+	round.temp.evalRound3Messages[i] = r3msg
+	round.out <- round.WithSessionID(r3msg)
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EvalRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update(ctx context.Context) (bool, *tss.Error) {
+	for j, msg := range round.temp.evalRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
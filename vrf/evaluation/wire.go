@@ -0,0 +1,165 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package evaluation
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	cmt "github.com/binance-chain/tss-lib/crypto/commitments"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into vrf-evaluation.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that evaluation messages implement ValidateBasic; tss.BaseUpdate rejects those of other types
+	messageTypes = []tss.MessageContent{
+		(*EvalRound1Message)(nil),
+		(*EvalRound2Message)(nil),
+		(*EvalRound3Message)(nil),
+	}
+)
+
+func init() {
+	proto.RegisterType((*EvalRound1Message)(nil), tss.VRFProtoNamePrefix+"evaluation.EvalRound1Message")
+	proto.RegisterType((*EvalRound2Message)(nil), tss.VRFProtoNamePrefix+"evaluation.EvalRound2Message")
+	proto.RegisterType((*EvalRound3Message)(nil), tss.VRFProtoNamePrefix+"evaluation.EvalRound3Message")
+	tss.RegisterMessageTypes(TaskName, messageTypes...)
+}
+
+// ----- //
+
+func NewEvalRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+	gammai *crypto.ECPoint,
+	proof *schnorr.ZKDLEQProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &EvalRound1Message{
+		Commitment:  ct.Bytes(),
+		GammaShareX: gammai.X().Bytes(),
+		GammaShareY: gammai.Y().Bytes(),
+		ProofA1X:    proof.A1.X().Bytes(),
+		ProofA1Y:    proof.A1.Y().Bytes(),
+		ProofA2X:    proof.A2.X().Bytes(),
+		ProofA2Y:    proof.A2.Y().Bytes(),
+		ProofZ:      proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EvalRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment()) &&
+		common.NonEmptyBytes(m.GetGammaShareX()) &&
+		common.NonEmptyBytes(m.GetGammaShareY()) &&
+		common.NonEmptyBytes(m.GetProofA1X()) &&
+		common.NonEmptyBytes(m.GetProofA1Y()) &&
+		common.NonEmptyBytes(m.GetProofA2X()) &&
+		common.NonEmptyBytes(m.GetProofA2Y()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *EvalRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *EvalRound1Message) UnmarshalGammaShare(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetGammaShareX()),
+		new(big.Int).SetBytes(m.GetGammaShareY()))
+}
+
+func (m *EvalRound1Message) UnmarshalProof(ec elliptic.Curve) (*schnorr.ZKDLEQProof, error) {
+	a1, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofA1X()),
+		new(big.Int).SetBytes(m.GetProofA1Y()))
+	if err != nil {
+		return nil, err
+	}
+	a2, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofA2X()),
+		new(big.Int).SetBytes(m.GetProofA2Y()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKDLEQProof{
+		A1: a1,
+		A2: a2,
+		Z:  new(big.Int).SetBytes(m.GetProofZ()),
+	}, nil
+}
+
+// ----- //
+
+func NewEvalRound2Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &EvalRound2Message{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EvalRound2Message) ValidateBasic() bool {
+	// r, U and V
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment(), 5)
+}
+
+func (m *EvalRound2Message) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+// ----- //
+
+func NewEvalRound3Message(
+	from *tss.PartyID,
+	si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &EvalRound3Message{
+		S: si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EvalRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetS())
+}
+
+func (m *EvalRound3Message) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.GetS())
+}